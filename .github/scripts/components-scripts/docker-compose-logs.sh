@@ -5,4 +5,6 @@ set -e
 FILE="$1"
 PROJECT="${2:-$FILE}"
 
-docker compose -f .github/infrastructure/docker-compose-${FILE}.yml -p ${PROJECT} logs
+# Set CONTAINER_RUNTIME to "podman" to use Podman instead of Docker
+
+${CONTAINER_RUNTIME:-docker} compose -f .github/infrastructure/docker-compose-${FILE}.yml -p ${PROJECT} logs
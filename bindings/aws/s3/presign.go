@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	// Range of sizes in bytes allowed by presigned POST policies, as "min-max".
+	metadataContentLengthRange = "contentLengthRange"
+	// Size in bytes of the part uploaded with a presigned part URL.
+	metadataContentLength = "contentLength"
+	// Number of the part uploaded with a presigned part URL, between 1 and 10000.
+	metadataPartNumber = "partNumber"
+
+	presignPutOperation            = "presignPut"
+	presignPostOperation           = "presignPost"
+	createMultipartUploadOperation = "createMultipartUpload"
+	presignUploadPartOperation     = "presignUploadPart"
+
+	maxPartNumber = 10000
+
+	postPolicyAlgorithm   = "AWS4-HMAC-SHA256"
+	postPolicyDateFormat  = "20060102T150405Z"
+	postPolicyScopeFormat = "20060102"
+)
+
+type presignPostResponse struct {
+	URL    string            `json:"url"`
+	Fields map[string]string `json:"fields"`
+}
+
+type createMultipartUploadResponse struct {
+	UploadID string `json:"uploadId"`
+}
+
+// presignPut returns a presigned URL to upload an object with a PUT request, with the Content-Type of the request
+// metadata if set.
+func (s *AWSS3) presignPut(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, ttl, err := s.presignKeyAndTTL(req)
+	if err != nil {
+		return nil, err
+	}
+
+	objReq, _ := s.authProvider.S3().S3.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:      ptr.Of(s.metadata.Bucket),
+		Key:         ptr.Of(key),
+		ContentType: optionalString(strings.TrimSpace(req.Metadata[metatadataContentType])),
+	})
+	objReq.SetContext(ctx)
+	url, err := objReq.Presign(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: failed to presign URL: %w", err)
+	}
+
+	return presignURLResponse(url)
+}
+
+// presignPost returns the URL and the form fields to upload an object with a POST request, such as from a browser
+// form, governed by a policy with the Content-Type and content length range of the request metadata if set.
+func (s *AWSS3) presignPost(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, ttl, err := s.presignKeyAndTTL(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []any
+	if contentType := strings.TrimSpace(req.Metadata[metatadataContentType]); contentType != "" {
+		conditions = append(conditions, map[string]string{metatadataContentType: contentType})
+	}
+	if val := req.Metadata[metadataContentLengthRange]; val != "" {
+		lengthMin, lengthMax, rErr := parseContentLengthRange(val)
+		if rErr != nil {
+			return nil, fmt.Errorf("s3 binding error: %w", rErr)
+		}
+		conditions = append(conditions, []any{"content-length-range", lengthMin, lengthMax})
+	}
+
+	client := s.authProvider.S3().S3
+	creds, err := client.Config.Credentials.GetWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: failed to get credentials: %w", err)
+	}
+
+	// Build a request to get the URL of the bucket, with the endpoint and addressing style of the client
+	bucketReq, _ := client.HeadBucketRequest(&s3.HeadBucketInput{Bucket: ptr.Of(s.metadata.Bucket)})
+	err = bucketReq.Build()
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: failed to get the bucket URL: %w", err)
+	}
+
+	fields, err := newPostPolicy(s.metadata.Bucket, key, creds, client.SigningRegion, time.Now().UTC(), ttl, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(presignPostResponse{
+		URL:    bucketReq.HTTPRequest.URL.String(),
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error marshalling presign response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
+// createMultipartUpload starts a multipart upload, whose parts can be uploaded with presigned part URLs.
+func (s *AWSS3) createMultipartUpload(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata, err := s.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error merging metadata: %w", err)
+	}
+
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+
+	var storageClass *string
+	if metadata.StorageClass != "" {
+		storageClass = aws.String(metadata.StorageClass)
+	}
+
+	result, err := s.authProvider.S3().S3.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:               ptr.Of(metadata.Bucket),
+		Key:                  ptr.Of(key),
+		ContentType:          optionalString(strings.TrimSpace(req.Metadata[metatadataContentType])),
+		StorageClass:         storageClass,
+		ServerSideEncryption: optionalString(metadata.ServerSideEncryption),
+		SSEKMSKeyId:          optionalString(metadata.SSEKMSKeyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: creating multipart upload failed: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(createMultipartUploadResponse{
+		UploadID: aws.StringValue(result.UploadId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error marshalling create multipart upload response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+		Metadata: map[string]string{
+			metadataKey: key,
+		},
+	}, nil
+}
+
+// presignUploadPart returns a presigned URL to upload a part of a multipart upload, with the content length of the
+// request metadata if set.
+func (s *AWSS3) presignUploadPart(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, ttl, err := s.presignKeyAndTTL(req)
+	if err != nil {
+		return nil, err
+	}
+	uploadID := req.Metadata[metadataUploadID]
+	if uploadID == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataUploadID)
+	}
+	partNumber, err := strconv.ParseInt(req.Metadata[metadataPartNumber], 10, 64)
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return nil, fmt.Errorf("s3 binding error: invalid value for '%s': must be between 1 and %d", metadataPartNumber, maxPartNumber)
+	}
+
+	input := &s3.UploadPartInput{
+		Bucket:     ptr.Of(s.metadata.Bucket),
+		Key:        ptr.Of(key),
+		UploadId:   ptr.Of(uploadID),
+		PartNumber: ptr.Of(partNumber),
+	}
+	if val := req.Metadata[metadataContentLength]; val != "" {
+		contentLength, pErr := strconv.ParseInt(val, 10, 64)
+		if pErr != nil || contentLength < 0 {
+			return nil, fmt.Errorf("s3 binding error: invalid value for '%s': %s", metadataContentLength, val)
+		}
+		input.ContentLength = ptr.Of(contentLength)
+	}
+
+	partReq, _ := s.authProvider.S3().S3.UploadPartRequest(input)
+	partReq.SetContext(ctx)
+	url, err := partReq.Presign(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: failed to presign URL: %w", err)
+	}
+
+	return presignURLResponse(url)
+}
+
+// presignKeyAndTTL returns the key and the presign TTL of the request, which are required.
+func (s *AWSS3) presignKeyAndTTL(req *bindings.InvokeRequest) (string, time.Duration, error) {
+	metadata, err := s.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("s3 binding error: error merging metadata: %w", err)
+	}
+
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return "", 0, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+	if metadata.PresignTTL == "" {
+		return "", 0, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataPresignTTL)
+	}
+	ttl, err := time.ParseDuration(metadata.PresignTTL)
+	if err != nil {
+		return "", 0, fmt.Errorf("s3 binding error: cannot parse duration %s: %w", metadata.PresignTTL, err)
+	}
+	return key, ttl, nil
+}
+
+func presignURLResponse(url string) (*bindings.InvokeResponse, error) {
+	jsonResponse, err := json.Marshal(presignResponse{
+		PresignURL: url,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error marshalling presign response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
+func parseContentLengthRange(val string) (int64, int64, error) {
+	minStr, maxStr, ok := strings.Cut(val, "-")
+	if ok {
+		lengthMin, minErr := strconv.ParseInt(strings.TrimSpace(minStr), 10, 64)
+		lengthMax, maxErr := strconv.ParseInt(strings.TrimSpace(maxStr), 10, 64)
+		if minErr == nil && maxErr == nil && lengthMin >= 0 && lengthMin <= lengthMax {
+			return lengthMin, lengthMax, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid value for '%s': must be in the format min-max: %s", metadataContentLengthRange, val)
+}
+
+// newPostPolicy returns the form fields of a POST policy signed with Signature Version 4, allowing to upload the
+// object key until now+ttl with the given additional conditions.
+// See: https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
+func newPostPolicy(bucket, key string, creds credentials.Value, region string, now time.Time, ttl time.Duration, conditions []any) (map[string]string, error) {
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request", creds.AccessKeyID, now.Format(postPolicyScopeFormat), region)
+	fields := map[string]string{
+		"key":              key,
+		"x-amz-algorithm":  postPolicyAlgorithm,
+		"x-amz-credential": credential,
+		"x-amz-date":       now.Format(postPolicyDateFormat),
+	}
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+
+	policyConditions := make([]any, 0, len(fields)+len(conditions)+1)
+	policyConditions = append(policyConditions, map[string]string{"bucket": bucket})
+	for k, v := range fields {
+		policyConditions = append(policyConditions, map[string]string{k: v})
+	}
+	for _, c := range conditions {
+		policyConditions = append(policyConditions, c)
+		if m, ok := c.(map[string]string); ok {
+			for k, v := range m {
+				fields[k] = v
+			}
+		}
+	}
+
+	policy, err := json.Marshal(map[string]any{
+		"expiration": now.Add(ttl).Format(time.RFC3339),
+		"conditions": policyConditions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the POST policy: %w", err)
+	}
+	fields["policy"] = b64.StdEncoding.EncodeToString(policy)
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(postPolicyScopeFormat))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, fields["policy"]))
+
+	return fields, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
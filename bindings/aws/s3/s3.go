@@ -14,6 +14,7 @@ limitations under the License.
 package s3
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	b64 "encoding/base64"
@@ -24,6 +25,8 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,11 +54,30 @@ const (
 	metadataStorageClass = "storageClass"
 	metadataTags         = "tags"
 
+	metadataServerSideEncryption      = "serverSideEncryption"
+	metadataSSEKMSKeyID               = "sseKmsKeyId"
+	metadataObjectLockMode            = "objectLockMode"
+	metadataObjectLockRetainUntilDate = "objectLockRetainUntilDate"
+	metadataObjectLockLegalHold       = "objectLockLegalHold"
+	metadataBypassGovernanceRetention = "bypassGovernanceRetention"
+	metadataVersionID                 = "versionID"
+	metadataUploadID                  = "uploadId"
+	metadataDeleteMarker              = "deleteMarker"
+
 	metatadataContentType = "Content-Type"
 	metadataKey           = "key"
 
-	defaultMaxResults = 1000
-	presignOperation  = "presign"
+	defaultMaxResults  = 1000
+	presignOperation   = "presign"
+	retentionOperation = "retention"
+	legalHoldOperation = "legalHold"
+
+	listVersionsOperation = "listVersions"
+	getTagsOperation      = "getTags"
+	setTagsOperation      = "setTags"
+
+	completeMultipartUploadOperation = "completeMultipartUpload"
+	abortMultipartUploadOperation    = "abortMultipartUpload"
 )
 
 // AWSS3 is a binding for an AWS S3 storage bucket.
@@ -82,6 +104,18 @@ type s3Metadata struct {
 	FilePath       string `json:"filePath" mapstructure:"filePath"   mdignore:"true"`
 	PresignTTL     string `json:"presignTTL" mapstructure:"presignTTL"  mdignore:"true"`
 	StorageClass   string `json:"storageClass" mapstructure:"storageClass"  mdignore:"true"`
+
+	ServerSideEncryption      string `json:"serverSideEncryption" mapstructure:"serverSideEncryption"`
+	SSEKMSKeyID               string `json:"sseKmsKeyId" mapstructure:"sseKmsKeyId"`
+	ObjectLockMode            string `json:"objectLockMode" mapstructure:"objectLockMode" mdignore:"true"`
+	ObjectLockRetainUntilDate string `json:"objectLockRetainUntilDate" mapstructure:"objectLockRetainUntilDate" mdignore:"true"`
+	ObjectLockLegalHold       string `json:"objectLockLegalHold" mapstructure:"objectLockLegalHold" mdignore:"true"`
+
+	// Objects are uploaded in parts of PartSize bytes, Concurrency parts at a time.
+	// With LeavePartsOnError, the parts of failed uploads are kept, so the upload can be completed or aborted.
+	PartSize          int64 `json:"partSize,string" mapstructure:"partSize"`
+	Concurrency       int   `json:"concurrency,string" mapstructure:"concurrency"`
+	LeavePartsOnError bool  `json:"leavePartsOnError,string" mapstructure:"leavePartsOnError"`
 }
 
 type createResponse struct {
@@ -101,6 +135,27 @@ type listPayload struct {
 	Delimiter  string `json:"delimiter"`
 }
 
+// completeMultipartUploadPayload is the data of the completeMultipartUpload operation: the parts to complete the
+// upload with, or the number of parts, and optionally their total size, that must have been uploaded.
+type completeMultipartUploadPayload struct {
+	Parts     []completedPart `json:"parts"`
+	PartCount int64           `json:"partCount"`
+	Size      int64           `json:"size"`
+}
+
+type completedPart struct {
+	PartNumber int64  `json:"partNumber"`
+	ETag       string `json:"etag"`
+}
+
+type listVersionsPayload struct {
+	KeyMarker       string `json:"keyMarker"`
+	VersionIDMarker string `json:"versionIdMarker"`
+	Prefix          string `json:"prefix"`
+	MaxResults      int32  `json:"maxResults"`
+	Delimiter       string `json:"delimiter"`
+}
+
 // NewAWSS3 returns a new AWSS3 instance.
 func NewAWSS3(logger logger.Logger) bindings.OutputBinding {
 	return &AWSS3{logger: logger}
@@ -167,6 +222,18 @@ func (s *AWSS3) Operations() []bindings.OperationKind {
 		bindings.DeleteOperation,
 		bindings.ListOperation,
 		presignOperation,
+		retentionOperation,
+		legalHoldOperation,
+		completeMultipartUploadOperation,
+		abortMultipartUploadOperation,
+		presignPutOperation,
+		presignPostOperation,
+		createMultipartUploadOperation,
+		presignUploadPartOperation,
+		selectOperation,
+		listVersionsOperation,
+		getTagsOperation,
+		setTagsOperation,
 	}
 }
 
@@ -220,15 +287,37 @@ func (s *AWSS3) create(ctx context.Context, req *bindings.InvokeRequest) (*bindi
 		storageClass = aws.String(metadata.StorageClass)
 	}
 
+	retainUntil, err := metadata.objectLockRetainUntil()
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: %w", err)
+	}
+
 	resultUpload, err := s.authProvider.S3().Uploader.UploadWithContext(ctx, &s3manager.UploadInput{
-		Bucket:       ptr.Of(metadata.Bucket),
-		Key:          ptr.Of(key),
-		Body:         r,
-		ContentType:  contentType,
-		StorageClass: storageClass,
-		Tagging:      tagging,
+		Bucket:                    ptr.Of(metadata.Bucket),
+		Key:                       ptr.Of(key),
+		Body:                      r,
+		ContentType:               contentType,
+		StorageClass:              storageClass,
+		Tagging:                   tagging,
+		ServerSideEncryption:      optionalString(metadata.ServerSideEncryption),
+		SSEKMSKeyId:               optionalString(metadata.SSEKMSKeyID),
+		ObjectLockMode:            optionalString(metadata.ObjectLockMode),
+		ObjectLockRetainUntilDate: retainUntil,
+		ObjectLockLegalHoldStatus: optionalString(metadata.ObjectLockLegalHold),
+	}, func(u *s3manager.Uploader) {
+		if metadata.PartSize > 0 {
+			u.PartSize = metadata.PartSize
+		}
+		if metadata.Concurrency > 0 {
+			u.Concurrency = metadata.Concurrency
+		}
+		u.LeavePartsOnError = metadata.LeavePartsOnError
 	})
 	if err != nil {
+		var multiErr s3manager.MultiUploadFailure
+		if metadata.LeavePartsOnError && errors.As(err, &multiErr) {
+			return nil, fmt.Errorf("s3 binding error: uploading failed, the uploaded parts are kept in multipart upload %s: %w", multiErr.UploadID(), err)
+		}
 		return nil, fmt.Errorf("s3 binding error: uploading failed: %w", err)
 	}
 
@@ -323,8 +412,9 @@ func (s *AWSS3) get(ctx context.Context, req *bindings.InvokeRequest) (*bindings
 	_, err = s.authProvider.S3().Downloader.DownloadWithContext(ctx,
 		buff,
 		&s3.GetObjectInput{
-			Bucket: ptr.Of(s.metadata.Bucket),
-			Key:    ptr.Of(key),
+			Bucket:    ptr.Of(s.metadata.Bucket),
+			Key:       ptr.Of(key),
+			VersionId: optionalString(req.Metadata[metadataVersionID]),
 		},
 	)
 	if err != nil {
@@ -354,11 +444,20 @@ func (s *AWSS3) delete(ctx context.Context, req *bindings.InvokeRequest) (*bindi
 	if key == "" {
 		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
 	}
-	_, err := s.authProvider.S3().S3.DeleteObjectWithContext(
+
+	// Deleting a specific version removes it permanently, while deleting the latest one in a versioned bucket
+	// only adds a delete marker
+	var bypassGovernance *bool
+	if val, ok := req.Metadata[metadataBypassGovernanceRetention]; ok && val != "" {
+		bypassGovernance = ptr.Of(kitstrings.IsTruthy(val))
+	}
+	result, err := s.authProvider.S3().S3.DeleteObjectWithContext(
 		ctx,
 		&s3.DeleteObjectInput{
-			Bucket: ptr.Of(s.metadata.Bucket),
-			Key:    ptr.Of(key),
+			Bucket:                    ptr.Of(s.metadata.Bucket),
+			Key:                       ptr.Of(key),
+			VersionId:                 optionalString(req.Metadata[metadataVersionID]),
+			BypassGovernanceRetention: bypassGovernance,
 		},
 	)
 	if err != nil {
@@ -369,7 +468,19 @@ func (s *AWSS3) delete(ctx context.Context, req *bindings.InvokeRequest) (*bindi
 		return nil, fmt.Errorf("s3 binding error: delete operation failed: %w", err)
 	}
 
-	return nil, nil
+	if result.VersionId == nil && result.DeleteMarker == nil {
+		return nil, nil
+	}
+	resMetadata := map[string]string{}
+	if result.VersionId != nil {
+		resMetadata[metadataVersionID] = *result.VersionId
+	}
+	if result.DeleteMarker != nil {
+		resMetadata[metadataDeleteMarker] = strconv.FormatBool(*result.DeleteMarker)
+	}
+	return &bindings.InvokeResponse{
+		Metadata: resMetadata,
+	}, nil
 }
 
 func (s *AWSS3) list(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
@@ -404,6 +515,215 @@ func (s *AWSS3) list(ctx context.Context, req *bindings.InvokeRequest) (*binding
 	}, nil
 }
 
+func (s *AWSS3) listVersions(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	payload := listVersionsPayload{}
+	if req.Data != nil {
+		if err := json.Unmarshal(req.Data, &payload); err != nil {
+			return nil, fmt.Errorf("s3 binding (ListVersions Operation) - unable to parse Data property - %v", err)
+		}
+	}
+
+	if payload.MaxResults < 1 {
+		payload.MaxResults = defaultMaxResults
+	}
+	result, err := s.authProvider.S3().S3.ListObjectVersionsWithContext(ctx, &s3.ListObjectVersionsInput{
+		Bucket:          ptr.Of(s.metadata.Bucket),
+		MaxKeys:         ptr.Of(int64(payload.MaxResults)),
+		KeyMarker:       optionalString(payload.KeyMarker),
+		VersionIdMarker: optionalString(payload.VersionIDMarker),
+		Prefix:          optionalString(payload.Prefix),
+		Delimiter:       optionalString(payload.Delimiter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: list versions operation failed: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: list versions operation: cannot marshal list to json: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
+func (s *AWSS3) retention(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata, err := s.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error merging metadata: %w", err)
+	}
+
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+	if metadata.ObjectLockMode == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataObjectLockMode)
+	}
+	retainUntil, err := metadata.objectLockRetainUntil()
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: %w", err)
+	}
+
+	var bypassGovernance *bool
+	if val, ok := req.Metadata[metadataBypassGovernanceRetention]; ok && val != "" {
+		bypassGovernance = ptr.Of(kitstrings.IsTruthy(val))
+	}
+
+	_, err = s.authProvider.S3().S3.PutObjectRetentionWithContext(ctx, &s3.PutObjectRetentionInput{
+		Bucket:    ptr.Of(metadata.Bucket),
+		Key:       ptr.Of(key),
+		VersionId: optionalString(req.Metadata[metadataVersionID]),
+		Retention: &s3.ObjectLockRetention{
+			Mode:            ptr.Of(metadata.ObjectLockMode),
+			RetainUntilDate: retainUntil,
+		},
+		BypassGovernanceRetention: bypassGovernance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: retention operation failed: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (s *AWSS3) legalHold(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata, err := s.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error merging metadata: %w", err)
+	}
+
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+	if metadata.ObjectLockLegalHold == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataObjectLockLegalHold)
+	}
+
+	_, err = s.authProvider.S3().S3.PutObjectLegalHoldWithContext(ctx, &s3.PutObjectLegalHoldInput{
+		Bucket:    ptr.Of(metadata.Bucket),
+		Key:       ptr.Of(key),
+		VersionId: optionalString(req.Metadata[metadataVersionID]),
+		LegalHold: &s3.ObjectLockLegalHold{
+			Status: ptr.Of(metadata.ObjectLockLegalHold),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: legal hold operation failed: %w", err)
+	}
+
+	return nil, nil
+}
+
+func (s *AWSS3) completeMultipartUpload(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	uploadID := req.Metadata[metadataUploadID]
+	if key == "" || uploadID == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' and '%s' missing", metadataKey, metadataUploadID)
+	}
+
+	payload := completeMultipartUploadPayload{}
+	if req.Data != nil {
+		if err := json.Unmarshal(req.Data, &payload); err != nil {
+			return nil, fmt.Errorf("s3 binding (CompleteMultipartUpload Operation) - unable to parse Data property - %v", err)
+		}
+	}
+
+	var parts []*s3.CompletedPart
+	switch {
+	case len(payload.Parts) > 0:
+		if payload.PartCount > 0 && payload.PartCount != int64(len(payload.Parts)) {
+			return nil, fmt.Errorf("s3 binding error: %d parts given, expected %d", len(payload.Parts), payload.PartCount)
+		}
+		for _, part := range payload.Parts {
+			if part.PartNumber < 1 || part.ETag == "" {
+				return nil, errors.New("s3 binding error: each part requires a partNumber and an etag")
+			}
+			parts = append(parts, &s3.CompletedPart{
+				ETag:       ptr.Of(part.ETag),
+				PartNumber: ptr.Of(part.PartNumber),
+			})
+		}
+		slices.SortFunc(parts, func(a, b *s3.CompletedPart) int {
+			return cmp.Compare(*a.PartNumber, *b.PartNumber)
+		})
+	case payload.PartCount > 0:
+		// The parts uploaded so far must be the expected ones, so a part that failed or is still uploading isn't left out
+		var size int64
+		err := s.authProvider.S3().S3.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+			Bucket:   ptr.Of(s.metadata.Bucket),
+			Key:      ptr.Of(key),
+			UploadId: ptr.Of(uploadID),
+		}, func(page *s3.ListPartsOutput, _ bool) bool {
+			for _, part := range page.Parts {
+				parts = append(parts, &s3.CompletedPart{
+					ETag:       part.ETag,
+					PartNumber: part.PartNumber,
+				})
+				size += aws.Int64Value(part.Size)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("s3 binding error: listing parts of multipart upload failed: %w", err)
+		}
+		if int64(len(parts)) != payload.PartCount {
+			return nil, fmt.Errorf("s3 binding error: multipart upload %s has %d parts, expected %d", uploadID, len(parts), payload.PartCount)
+		}
+		if payload.Size > 0 && size != payload.Size {
+			return nil, fmt.Errorf("s3 binding error: multipart upload %s has %d bytes, expected %d", uploadID, size, payload.Size)
+		}
+	default:
+		return nil, errors.New("s3 binding error: required data 'parts' or 'partCount' missing")
+	}
+
+	result, err := s.authProvider.S3().S3.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          ptr.Of(s.metadata.Bucket),
+		Key:             ptr.Of(key),
+		UploadId:        ptr.Of(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: completing multipart upload failed: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(createResponse{
+		Location:  aws.StringValue(result.Location),
+		VersionID: result.VersionId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error marshalling complete multipart upload response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+		Metadata: map[string]string{
+			metadataKey: key,
+		},
+	}, nil
+}
+
+func (s *AWSS3) abortMultipartUpload(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	uploadID := req.Metadata[metadataUploadID]
+	if key == "" || uploadID == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' and '%s' missing", metadataKey, metadataUploadID)
+	}
+
+	_, err := s.authProvider.S3().S3.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   ptr.Of(s.metadata.Bucket),
+		Key:      ptr.Of(key),
+		UploadId: ptr.Of(uploadID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: aborting multipart upload failed: %w", err)
+	}
+
+	return nil, nil
+}
+
 func (s *AWSS3) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	switch req.Operation {
 	case bindings.CreateOperation:
@@ -416,6 +736,30 @@ func (s *AWSS3) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindi
 		return s.list(ctx, req)
 	case presignOperation:
 		return s.presign(ctx, req)
+	case retentionOperation:
+		return s.retention(ctx, req)
+	case legalHoldOperation:
+		return s.legalHold(ctx, req)
+	case completeMultipartUploadOperation:
+		return s.completeMultipartUpload(ctx, req)
+	case abortMultipartUploadOperation:
+		return s.abortMultipartUpload(ctx, req)
+	case presignPutOperation:
+		return s.presignPut(ctx, req)
+	case presignPostOperation:
+		return s.presignPost(ctx, req)
+	case createMultipartUploadOperation:
+		return s.createMultipartUpload(ctx, req)
+	case presignUploadPartOperation:
+		return s.presignUploadPart(ctx, req)
+	case selectOperation:
+		return s.selectObject(ctx, req)
+	case listVersionsOperation:
+		return s.listVersions(ctx, req)
+	case getTagsOperation:
+		return s.getTags(ctx, req)
+	case setTagsOperation:
+		return s.setTags(ctx, req)
 	default:
 		return nil, fmt.Errorf("s3 binding error: unsupported operation %s", req.Operation)
 	}
@@ -427,27 +771,48 @@ func (s *AWSS3) parseMetadata(md bindings.Metadata) (*s3Metadata, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = m.validate()
+	if err != nil {
+		return nil, err
+	}
 	return &m, nil
 }
 
 // Helper for parsing s3 tags metadata
 func (s *AWSS3) parseS3Tags(raw string) (*string, error) {
+	tagSet, err := parseS3TagSet(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tagSet) == 0 {
+		return nil, nil
+	}
+
+	pairs := make([]string, len(tagSet))
+	for i, tag := range tagSet {
+		pairs[i] = fmt.Sprintf("%s=%s", *tag.Key, *tag.Value)
+	}
+	return aws.String(strings.Join(pairs, "&")), nil
+}
+
+// Helper for parsing s3 tags metadata, formatted as "key1=value1,key2=value2", into a tag set
+func parseS3TagSet(raw string) ([]*s3.Tag, error) {
 	tagEntries := strings.Split(raw, ",")
-	pairs := make([]string, 0, len(tagEntries))
+	tagSet := make([]*s3.Tag, 0, len(tagEntries))
 	for _, tagEntry := range tagEntries {
 		kv := strings.SplitN(strings.TrimSpace(tagEntry), "=", 2)
 		isInvalidTag := len(kv) != 2 || strings.TrimSpace(kv[0]) == "" || strings.TrimSpace(kv[1]) == ""
 		if isInvalidTag {
 			return nil, fmt.Errorf("invalid tag format: '%s' (expected key=value)", tagEntry)
 		}
-		pairs = append(pairs, fmt.Sprintf("%s=%s", strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])))
+		tagSet = append(tagSet, &s3.Tag{
+			Key:   aws.String(strings.TrimSpace(kv[0])),
+			Value: aws.String(strings.TrimSpace(kv[1])),
+		})
 	}
 
-	if len(pairs) == 0 {
-		return nil, nil
-	}
-
-	return aws.String(strings.Join(pairs, "&")), nil
+	return tagSet, nil
 }
 
 // Helper to merge config and request metadata.
@@ -474,9 +839,97 @@ func (metadata s3Metadata) mergeWithRequestMetadata(req *bindings.InvokeRequest)
 		merged.StorageClass = val
 	}
 
+	if val, ok := req.Metadata[metadataServerSideEncryption]; ok && val != "" {
+		merged.ServerSideEncryption = val
+	}
+
+	if val, ok := req.Metadata[metadataSSEKMSKeyID]; ok && val != "" {
+		merged.SSEKMSKeyID = val
+	}
+
+	if val, ok := req.Metadata[metadataObjectLockMode]; ok && val != "" {
+		merged.ObjectLockMode = val
+	}
+
+	if val, ok := req.Metadata[metadataObjectLockRetainUntilDate]; ok && val != "" {
+		merged.ObjectLockRetainUntilDate = val
+	}
+
+	if val, ok := req.Metadata[metadataObjectLockLegalHold]; ok && val != "" {
+		merged.ObjectLockLegalHold = val
+	}
+
+	err := merged.validate()
+	if err != nil {
+		return merged, err
+	}
+
 	return merged, nil
 }
 
+// Helper to validate and normalize the encryption and object lock options.
+func (metadata *s3Metadata) validate() error {
+	switch {
+	case metadata.ServerSideEncryption == "" && metadata.SSEKMSKeyID != "":
+		// Specifying a KMS key implies SSE-KMS
+		metadata.ServerSideEncryption = s3.ServerSideEncryptionAwsKms
+	case metadata.ServerSideEncryption == "":
+		// Nop
+	case !slices.Contains(s3.ServerSideEncryption_Values(), metadata.ServerSideEncryption):
+		return fmt.Errorf("invalid value for '%s': %s", metadataServerSideEncryption, metadata.ServerSideEncryption)
+	case metadata.SSEKMSKeyID != "" && metadata.ServerSideEncryption == s3.ServerSideEncryptionAes256:
+		return fmt.Errorf("'%s' cannot be used with server-side encryption %s", metadataSSEKMSKeyID, s3.ServerSideEncryptionAes256)
+	}
+
+	if metadata.ObjectLockMode != "" {
+		metadata.ObjectLockMode = strings.ToUpper(metadata.ObjectLockMode)
+		if !slices.Contains(s3.ObjectLockMode_Values(), metadata.ObjectLockMode) {
+			return fmt.Errorf("invalid value for '%s': %s", metadataObjectLockMode, metadata.ObjectLockMode)
+		}
+	}
+	if (metadata.ObjectLockMode == "") != (metadata.ObjectLockRetainUntilDate == "") {
+		return fmt.Errorf("'%s' and '%s' must be set together", metadataObjectLockMode, metadataObjectLockRetainUntilDate)
+	}
+	if _, err := metadata.objectLockRetainUntil(); err != nil {
+		return err
+	}
+
+	if metadata.PartSize != 0 && metadata.PartSize < s3manager.MinUploadPartSize {
+		return fmt.Errorf("invalid value for 'partSize': must be at least %d bytes", s3manager.MinUploadPartSize)
+	}
+	if metadata.Concurrency < 0 {
+		return errors.New("invalid value for 'concurrency': must not be negative")
+	}
+
+	if metadata.ObjectLockLegalHold != "" {
+		metadata.ObjectLockLegalHold = strings.ToUpper(metadata.ObjectLockLegalHold)
+		if !slices.Contains(s3.ObjectLockLegalHoldStatus_Values(), metadata.ObjectLockLegalHold) {
+			return fmt.Errorf("invalid value for '%s': %s", metadataObjectLockLegalHold, metadata.ObjectLockLegalHold)
+		}
+	}
+
+	return nil
+}
+
+// Helper to parse the object lock retention date, if set.
+func (metadata s3Metadata) objectLockRetainUntil() (*time.Time, error) {
+	if metadata.ObjectLockRetainUntilDate == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, metadata.ObjectLockRetainUntilDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for '%s': must be a RFC 3339 date: %w", metadataObjectLockRetainUntilDate, err)
+	}
+	return &t, nil
+}
+
+func optionalString(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return ptr.Of(val)
+}
+
 // GetComponentMetadata returns the metadata of the component.
 func (s *AWSS3) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := s3Metadata{}
@@ -14,12 +14,19 @@ limitations under the License.
 package s3
 
 import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 )
 
@@ -187,6 +194,60 @@ func TestMergeWithRequestMetadata(t *testing.T) {
 	})
 }
 
+func TestEncryptionAndObjectLockMetadata(t *testing.T) {
+	s3 := AWSS3{}
+	meta, err := s3.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"bucket":      "test",
+		"sseKmsKeyId": "mykey",
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, "aws:kms", meta.ServerSideEncryption)
+	assert.Equal(t, "mykey", meta.SSEKMSKeyID)
+
+	t.Run("Overrides encryption and sets object lock", func(t *testing.T) {
+		merged, err := meta.mergeWithRequestMetadata(&bindings.InvokeRequest{Metadata: map[string]string{
+			"serverSideEncryption":      "aws:kms:dsse",
+			"sseKmsKeyId":               "otherkey",
+			"objectLockMode":            "governance",
+			"objectLockRetainUntilDate": "2030-01-02T03:04:05Z",
+			"objectLockLegalHold":       "on",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "aws:kms:dsse", merged.ServerSideEncryption)
+		assert.Equal(t, "otherkey", merged.SSEKMSKeyID)
+		assert.Equal(t, "GOVERNANCE", merged.ObjectLockMode)
+		assert.Equal(t, "ON", merged.ObjectLockLegalHold)
+
+		retainUntil, err := merged.objectLockRetainUntil()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), *retainUntil)
+
+		// Component metadata is unchanged
+		assert.Equal(t, "aws:kms", meta.ServerSideEncryption)
+		assert.Empty(t, meta.ObjectLockMode)
+	})
+
+	tests := []struct {
+		name string
+		md   map[string]string
+		err  string
+	}{
+		{name: "invalid encryption", md: map[string]string{"serverSideEncryption": "foo"}, err: "invalid value for 'serverSideEncryption': foo"},
+		{name: "KMS key with AES256", md: map[string]string{"serverSideEncryption": "AES256"}, err: "'sseKmsKeyId' cannot be used with server-side encryption AES256"},
+		{name: "invalid mode", md: map[string]string{"objectLockMode": "foo", "objectLockRetainUntilDate": "2030-01-02T03:04:05Z"}, err: "invalid value for 'objectLockMode': FOO"},
+		{name: "mode without date", md: map[string]string{"objectLockMode": "COMPLIANCE"}, err: "'objectLockMode' and 'objectLockRetainUntilDate' must be set together"},
+		{name: "date without mode", md: map[string]string{"objectLockRetainUntilDate": "2030-01-02T03:04:05Z"}, err: "'objectLockMode' and 'objectLockRetainUntilDate' must be set together"},
+		{name: "invalid date", md: map[string]string{"objectLockMode": "COMPLIANCE", "objectLockRetainUntilDate": "2030-01-02"}, err: "invalid value for 'objectLockRetainUntilDate'"},
+		{name: "invalid legal hold", md: map[string]string{"objectLockLegalHold": "yes"}, err: "invalid value for 'objectLockLegalHold': YES"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := meta.mergeWithRequestMetadata(&bindings.InvokeRequest{Metadata: tt.md})
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
 func TestGetOption(t *testing.T) {
 	s3 := NewAWSS3(logger.NewLogger("s3")).(*AWSS3)
 	s3.metadata = &s3Metadata{}
@@ -208,3 +269,258 @@ func TestDeleteOption(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestMultipartUpload(t *testing.T) {
+	t.Run("validates options", func(t *testing.T) {
+		s3 := AWSS3{}
+		meta, err := s3.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"bucket":            "test",
+			"partSize":          "10485760",
+			"concurrency":       "3",
+			"leavePartsOnError": "true",
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10485760), meta.PartSize)
+		assert.Equal(t, 3, meta.Concurrency)
+		assert.True(t, meta.LeavePartsOnError)
+
+		_, err = s3.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"bucket":   "test",
+			"partSize": "1024",
+		}}})
+		require.ErrorContains(t, err, "partSize")
+	})
+
+	var (
+		lock     sync.Mutex
+		requests []string
+		body     string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+"?uploadId="+r.URL.Query().Get("uploadId"))
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`<ListPartsResult><Part><PartNumber>1</PartNumber><ETag>"a"</ETag><Size>4</Size></Part><Part><PartNumber>2</PartNumber><ETag>"b"</ETag><Size>6</Size></Part></ListPartsResult>`))
+		case http.MethodPost:
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`<CompleteMultipartUploadResult><Location>http://localhost/test/big</Location></CompleteMultipartUploadResult>`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	s3 := NewAWSS3(logger.NewLogger("s3")).(*AWSS3)
+	err := s3.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"bucket":         "test",
+		"region":         "us-east-1",
+		"accessKey":      "key",
+		"secretKey":      "secret",
+		"endpoint":       srv.URL,
+		"forcePathStyle": "true",
+	}}})
+	require.NoError(t, err)
+	defer s3.Close()
+
+	t.Run("return error if upload ID is missing", func(t *testing.T) {
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: completeMultipartUploadOperation,
+			Metadata:  map[string]string{"key": "big"},
+		})
+		require.ErrorContains(t, err, "uploadId")
+	})
+
+	t.Run("return error if the parts are missing", func(t *testing.T) {
+		requests = nil
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: completeMultipartUploadOperation,
+			Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+		})
+		require.ErrorContains(t, err, "partCount")
+		assert.Empty(t, requests)
+	})
+
+	t.Run("complete with the given parts", func(t *testing.T) {
+		requests = nil
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: completeMultipartUploadOperation,
+			Data:      []byte(`{"parts":[{"partNumber":2,"etag":"\"b\""},{"partNumber":1,"etag":"\"a\""}]}`),
+			Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"location":"http://localhost/test/big","versionID":null}`, string(res.Data))
+		assert.Equal(t, []string{"POST /test/big?uploadId=upload-1"}, requests)
+		assert.Regexp(t, "<PartNumber>1</PartNumber>.*<PartNumber>2</PartNumber>", body)
+	})
+
+	t.Run("complete with the uploaded parts", func(t *testing.T) {
+		requests = nil
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: completeMultipartUploadOperation,
+			Data:      []byte(`{"partCount":2,"size":10}`),
+			Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"location":"http://localhost/test/big","versionID":null}`, string(res.Data))
+		assert.Equal(t, []string{"GET /test/big?uploadId=upload-1", "POST /test/big?uploadId=upload-1"}, requests)
+		assert.Contains(t, body, "<PartNumber>1</PartNumber>")
+		assert.Contains(t, body, "<PartNumber>2</PartNumber>")
+	})
+
+	t.Run("return error if the uploaded parts don't match", func(t *testing.T) {
+		for _, data := range []string{`{"partCount":3}`, `{"partCount":2,"size":11}`} {
+			requests = nil
+			_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+				Operation: completeMultipartUploadOperation,
+				Data:      []byte(data),
+				Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+			})
+			require.ErrorContains(t, err, "expected")
+			assert.Equal(t, []string{"GET /test/big?uploadId=upload-1"}, requests)
+		}
+
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: completeMultipartUploadOperation,
+			Data:      []byte(`{"parts":[{"partNumber":1,"etag":"\"a\""}],"partCount":2}`),
+			Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+		})
+		require.ErrorContains(t, err, "expected 2")
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		requests = nil
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: abortMultipartUploadOperation,
+			Metadata:  map[string]string{"key": "big", "uploadId": "upload-1"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DELETE /test/big?uploadId=upload-1"}, requests)
+	})
+}
+
+func TestVersionsAndTags(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests []string
+		body     string
+		header   http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		header = r.Header
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Has("tagging"):
+			w.Header().Set("x-amz-version-id", "v1")
+			w.Write([]byte(`<Tagging><TagSet><Tag><Key>project</Key><Value>dapr</Value></Tag></TagSet></Tagging>`))
+		case r.Method == http.MethodPut && r.URL.Query().Has("tagging"):
+			w.Header().Set("x-amz-version-id", "v1")
+		case r.Method == http.MethodGet && r.URL.Query().Has("versions"):
+			w.Write([]byte(`<ListVersionsResult><Version><Key>foo</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version></ListVersionsResult>`))
+		case r.Method == http.MethodDelete:
+			w.Header().Set("x-amz-version-id", "v2")
+			w.Header().Set("x-amz-delete-marker", "true")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	s3 := NewAWSS3(logger.NewLogger("s3")).(*AWSS3)
+	err := s3.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"bucket":         "test",
+		"region":         "us-east-1",
+		"accessKey":      "key",
+		"secretKey":      "secret",
+		"endpoint":       srv.URL,
+		"forcePathStyle": "true",
+	}}})
+	require.NoError(t, err)
+	defer s3.Close()
+
+	t.Run("get tags", func(t *testing.T) {
+		requests = nil
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: getTagsOperation,
+			Metadata:  map[string]string{"key": "foo", "versionID": "v1"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"project":"dapr"}`, string(res.Data))
+		assert.Equal(t, "v1", res.Metadata["versionID"])
+		assert.Equal(t, []string{"GET /test/foo?tagging=&versionId=v1"}, requests)
+	})
+
+	t.Run("set tags", func(t *testing.T) {
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: setTagsOperation,
+			Metadata:  map[string]string{"key": "foo"},
+		})
+		require.ErrorContains(t, err, "tags")
+
+		_, err = s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: setTagsOperation,
+			Metadata:  map[string]string{"key": "foo", "tags": "project"},
+		})
+		require.ErrorContains(t, err, "invalid tag format")
+
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: setTagsOperation,
+			Metadata:  map[string]string{"key": "foo", "tags": "project=dapr, year=2026"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "v1", res.Metadata["versionID"])
+		assert.ElementsMatch(t, []tag{{Key: "project", Value: "dapr"}, {Key: "year", Value: "2026"}}, parseTagging(t, body))
+
+		_, err = s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: setTagsOperation,
+			Metadata:  map[string]string{"key": "foo", "tags": ""},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, body, "<TagSet></TagSet>")
+		assert.Empty(t, parseTagging(t, body))
+	})
+
+	t.Run("list versions", func(t *testing.T) {
+		requests = nil
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: listVersionsOperation,
+			Data:      []byte(`{"prefix":"f","keyMarker":"a","maxResults":10}`),
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(res.Data), `"VersionId":"v1"`)
+		assert.Equal(t, []string{"GET /test?key-marker=a&max-keys=10&prefix=f&versions="}, requests)
+	})
+
+	t.Run("delete a version bypassing governance retention", func(t *testing.T) {
+		requests = nil
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: bindings.DeleteOperation,
+			Metadata:  map[string]string{"key": "foo", "versionID": "v1", "bypassGovernanceRetention": "true"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DELETE /test/foo?versionId=v1"}, requests)
+		assert.Equal(t, "true", header.Get("x-amz-bypass-governance-retention"))
+		assert.Equal(t, map[string]string{"versionID": "v2", "deleteMarker": "true"}, res.Metadata)
+	})
+}
+
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// parseTagging returns the tags of a Tagging request body: the SDK doesn't write them in a stable order.
+func parseTagging(t *testing.T, body string) []tag {
+	t.Helper()
+
+	var tagging struct {
+		Tags []tag `xml:"TagSet>Tag"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(body), &tagging))
+	return tagging.Tags
+}
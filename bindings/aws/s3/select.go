@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	selectOperation = "select"
+
+	metadataBytesScanned   = "bytesScanned"
+	metadataBytesProcessed = "bytesProcessed"
+	metadataBytesReturned  = "bytesReturned"
+)
+
+// selectPayload is the request data of the select operation.
+type selectPayload struct {
+	// SQL expression run against the object, e.g. "SELECT s.name FROM S3Object s WHERE s.age > 30".
+	Expression string `json:"expression"`
+	// Format of the object: CSV (the default), JSON or Parquet.
+	InputFormat string `json:"inputFormat"`
+	// Compression of CSV and JSON objects: NONE (the default), GZIP or BZIP2.
+	CompressionType string `json:"compressionType"`
+	// Format of the results: CSV or JSON; defaults to the input format, or JSON for Parquet objects.
+	OutputFormat string `json:"outputFormat"`
+	// Options of CSV objects.
+	CSVFileHeaderInfo             string `json:"csvFileHeaderInfo"`
+	CSVFieldDelimiter             string `json:"csvFieldDelimiter"`
+	CSVRecordDelimiter            string `json:"csvRecordDelimiter"`
+	CSVQuoteCharacter             string `json:"csvQuoteCharacter"`
+	CSVComments                   string `json:"csvComments"`
+	CSVAllowQuotedRecordDelimiter bool   `json:"csvAllowQuotedRecordDelimiter"`
+	// Type of JSON objects: DOCUMENT (the default) or LINES.
+	JSONType string `json:"jsonType"`
+	// Delimiter of the JSON results; defaults to a newline.
+	JSONRecordDelimiter string `json:"jsonRecordDelimiter"`
+}
+
+// selectObject runs an S3 Select query against the object with the given key and returns the records the query
+// produced, together with the bytes scanned, processed and returned by S3.
+func (s *AWSS3) selectObject(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+
+	payload := selectPayload{}
+	if req.Data != nil {
+		if err := json.Unmarshal(req.Data, &payload); err != nil {
+			return nil, fmt.Errorf("s3 binding (Select Operation) - unable to parse Data property - %v", err)
+		}
+	}
+	input, err := payload.toInput(s.metadata.Bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: %w", err)
+	}
+
+	result, err := s.authProvider.S3().S3.SelectObjectContentWithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: select operation failed: %w", err)
+	}
+	stream := result.GetStream()
+	defer stream.Close()
+
+	var (
+		records bytes.Buffer
+		stats   *s3.Stats
+		ended   bool
+	)
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *s3.RecordsEvent:
+			records.Write(e.Payload)
+		case *s3.StatsEvent:
+			stats = e.Details
+		case *s3.EndEvent:
+			ended = true
+		}
+	}
+	if err = stream.Err(); err != nil {
+		return nil, fmt.Errorf("s3 binding error: select operation failed: %w", err)
+	}
+	// S3 sends the End event only after all the records, so without it the results are incomplete
+	if !ended {
+		return nil, errors.New("s3 binding error: select operation failed: results stream ended before the end event")
+	}
+
+	resMetadata := map[string]string{
+		metadataKey: key,
+	}
+	if stats != nil {
+		resMetadata[metadataBytesScanned] = strconv.FormatInt(aws.Int64Value(stats.BytesScanned), 10)
+		resMetadata[metadataBytesProcessed] = strconv.FormatInt(aws.Int64Value(stats.BytesProcessed), 10)
+		resMetadata[metadataBytesReturned] = strconv.FormatInt(aws.Int64Value(stats.BytesReturned), 10)
+	}
+
+	return &bindings.InvokeResponse{
+		Data:     records.Bytes(),
+		Metadata: resMetadata,
+	}, nil
+}
+
+func (p selectPayload) toInput(bucket, key string) (*s3.SelectObjectContentInput, error) {
+	if p.Expression == "" {
+		return nil, errors.New("select expression is required")
+	}
+
+	input := &s3.SelectObjectContentInput{
+		Bucket:              ptr.Of(bucket),
+		Key:                 ptr.Of(key),
+		Expression:          ptr.Of(p.Expression),
+		ExpressionType:      ptr.Of(s3.ExpressionTypeSql),
+		InputSerialization:  &s3.InputSerialization{},
+		OutputSerialization: &s3.OutputSerialization{},
+	}
+
+	inputFormat := strings.ToUpper(p.InputFormat)
+	outputFormat := strings.ToUpper(p.OutputFormat)
+	switch inputFormat {
+	case "", "CSV":
+		inputFormat = "CSV"
+		fileHeaderInfo := strings.ToUpper(p.CSVFileHeaderInfo)
+		if fileHeaderInfo != "" && !slices.Contains(s3.FileHeaderInfo_Values(), fileHeaderInfo) {
+			return nil, fmt.Errorf("invalid csvFileHeaderInfo %s; allowed: %s", p.CSVFileHeaderInfo, s3.FileHeaderInfo_Values())
+		}
+		input.InputSerialization.CSV = &s3.CSVInput{
+			FileHeaderInfo:             optionalString(fileHeaderInfo),
+			FieldDelimiter:             optionalString(p.CSVFieldDelimiter),
+			RecordDelimiter:            optionalString(p.CSVRecordDelimiter),
+			QuoteCharacter:             optionalString(p.CSVQuoteCharacter),
+			Comments:                   optionalString(p.CSVComments),
+			AllowQuotedRecordDelimiter: ptr.Of(p.CSVAllowQuotedRecordDelimiter),
+		}
+	case "JSON":
+		jsonType := strings.ToUpper(p.JSONType)
+		if jsonType == "" {
+			jsonType = s3.JSONTypeDocument
+		}
+		if !slices.Contains(s3.JSONType_Values(), jsonType) {
+			return nil, fmt.Errorf("invalid jsonType %s; allowed: %s", p.JSONType, s3.JSONType_Values())
+		}
+		input.InputSerialization.JSON = &s3.JSONInput{Type: ptr.Of(jsonType)}
+	case "PARQUET":
+		input.InputSerialization.Parquet = &s3.ParquetInput{}
+		if outputFormat == "" {
+			outputFormat = "JSON"
+		}
+	default:
+		return nil, fmt.Errorf("invalid inputFormat %s; allowed: CSV, JSON, Parquet", p.InputFormat)
+	}
+
+	if p.CompressionType != "" {
+		compressionType := strings.ToUpper(p.CompressionType)
+		if !slices.Contains(s3.CompressionType_Values(), compressionType) {
+			return nil, fmt.Errorf("invalid compressionType %s; allowed: %s", p.CompressionType, s3.CompressionType_Values())
+		}
+		if inputFormat == "PARQUET" && compressionType != s3.CompressionTypeNone {
+			return nil, errors.New("compressionType is not supported with Parquet objects")
+		}
+		input.InputSerialization.CompressionType = ptr.Of(compressionType)
+	}
+
+	if outputFormat == "" {
+		outputFormat = inputFormat
+	}
+	switch outputFormat {
+	case "CSV":
+		input.OutputSerialization.CSV = &s3.CSVOutput{
+			FieldDelimiter:  optionalString(p.CSVFieldDelimiter),
+			RecordDelimiter: optionalString(p.CSVRecordDelimiter),
+			QuoteCharacter:  optionalString(p.CSVQuoteCharacter),
+		}
+	case "JSON":
+		input.OutputSerialization.JSON = &s3.JSONOutput{
+			RecordDelimiter: optionalString(p.JSONRecordDelimiter),
+		}
+	default:
+		return nil, fmt.Errorf("invalid outputFormat %s; allowed: CSV, JSON", p.OutputFormat)
+	}
+
+	return input, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/private/protocol/eventstream"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func TestSelectPayloadToInput(t *testing.T) {
+	t.Run("defaults to CSV", func(t *testing.T) {
+		input, err := selectPayload{Expression: "SELECT * FROM S3Object", CSVFileHeaderInfo: "use"}.toInput("test", "foo")
+		require.NoError(t, err)
+		assert.Equal(t, "USE", aws.StringValue(input.InputSerialization.CSV.FileHeaderInfo))
+		assert.Nil(t, input.InputSerialization.CSV.FieldDelimiter)
+		assert.NotNil(t, input.OutputSerialization.CSV)
+		assert.Nil(t, input.OutputSerialization.JSON)
+	})
+
+	t.Run("JSON lines", func(t *testing.T) {
+		input, err := selectPayload{Expression: "SELECT * FROM S3Object", InputFormat: "json", JSONType: "lines", CompressionType: "gzip"}.toInput("test", "foo")
+		require.NoError(t, err)
+		assert.Equal(t, s3.JSONTypeLines, aws.StringValue(input.InputSerialization.JSON.Type))
+		assert.Equal(t, s3.CompressionTypeGzip, aws.StringValue(input.InputSerialization.CompressionType))
+		assert.NotNil(t, input.OutputSerialization.JSON)
+	})
+
+	t.Run("Parquet outputs JSON", func(t *testing.T) {
+		input, err := selectPayload{Expression: "SELECT * FROM S3Object", InputFormat: "Parquet"}.toInput("test", "foo")
+		require.NoError(t, err)
+		assert.NotNil(t, input.InputSerialization.Parquet)
+		assert.NotNil(t, input.OutputSerialization.JSON)
+	})
+
+	for name, p := range map[string]selectPayload{
+		"missing expression":        {},
+		"invalid input format":      {Expression: "SELECT 1", InputFormat: "xml"},
+		"invalid output format":     {Expression: "SELECT 1", OutputFormat: "parquet"},
+		"invalid file header info":  {Expression: "SELECT 1", CSVFileHeaderInfo: "first"},
+		"invalid JSON type":         {Expression: "SELECT 1", InputFormat: "json", JSONType: "array"},
+		"invalid compression type":  {Expression: "SELECT 1", CompressionType: "zip"},
+		"compressed Parquet object": {Expression: "SELECT 1", InputFormat: "parquet", CompressionType: "gzip"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.toInput("test", "foo")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	var (
+		body    string
+		sendEnd bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+		enc := eventstream.NewEncoder(w)
+		event := func(eventType string, payload string) {
+			headers := eventstream.Headers{}
+			headers.Set(":message-type", eventstream.StringValue("event"))
+			headers.Set(":event-type", eventstream.StringValue(eventType))
+			require.NoError(t, enc.Encode(eventstream.Message{Headers: headers, Payload: []byte(payload)}))
+		}
+		event("Records", `{"name":"a"}`+"\n")
+		event("Records", `{"name":"b"}`+"\n")
+		event("Stats", `<Stats><BytesScanned>100</BytesScanned><BytesProcessed>90</BytesProcessed><BytesReturned>26</BytesReturned></Stats>`)
+		if sendEnd {
+			event("End", "")
+		}
+	}))
+	defer srv.Close()
+
+	s3 := NewAWSS3(logger.NewLogger("s3")).(*AWSS3)
+	err := s3.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"bucket":         "test",
+		"region":         "us-east-1",
+		"accessKey":      "key",
+		"secretKey":      "secret",
+		"endpoint":       srv.URL,
+		"forcePathStyle": "true",
+	}}})
+	require.NoError(t, err)
+	defer s3.Close()
+
+	t.Run("return error if key is missing", func(t *testing.T) {
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: selectOperation,
+			Data:      []byte(`{"expression":"SELECT * FROM S3Object"}`),
+		})
+		require.ErrorContains(t, err, "key")
+	})
+
+	t.Run("return the selected records", func(t *testing.T) {
+		sendEnd = true
+		res, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: selectOperation,
+			Data:      []byte(`{"expression":"SELECT s.name FROM S3Object s","inputFormat":"json","jsonType":"lines"}`),
+			Metadata:  map[string]string{"key": "people.json"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"a"}`+"\n"+`{"name":"b"}`+"\n", string(res.Data))
+		assert.Equal(t, "100", res.Metadata[metadataBytesScanned])
+		assert.Equal(t, "90", res.Metadata[metadataBytesProcessed])
+		assert.Equal(t, "26", res.Metadata[metadataBytesReturned])
+		assert.Contains(t, body, "<Expression>SELECT s.name FROM S3Object s</Expression>")
+		assert.Contains(t, body, "<Type>LINES</Type>")
+	})
+
+	t.Run("return error if the results are incomplete", func(t *testing.T) {
+		sendEnd = false
+		_, err := s3.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: selectOperation,
+			Data:      []byte(`{"expression":"SELECT s.name FROM S3Object s","inputFormat":"json"}`),
+			Metadata:  map[string]string{"key": "people.json"},
+		})
+		require.ErrorContains(t, err, "end event")
+	})
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/ptr"
+)
+
+// getTags returns the tags of an object, or of the version of the object in the request metadata, as a JSON object.
+func (s *AWSS3) getTags(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+
+	result, err := s.authProvider.S3().S3.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    ptr.Of(s.metadata.Bucket),
+		Key:       ptr.Of(key),
+		VersionId: optionalString(req.Metadata[metadataVersionID]),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: get tags operation failed: %w", err)
+	}
+
+	tags := make(map[string]string, len(result.TagSet))
+	for _, tag := range result.TagSet {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	jsonResponse, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: error marshalling get tags response: %w", err)
+	}
+
+	resMetadata := map[string]string{
+		metadataKey: key,
+	}
+	if result.VersionId != nil {
+		resMetadata[metadataVersionID] = *result.VersionId
+	}
+	return &bindings.InvokeResponse{
+		Data:     jsonResponse,
+		Metadata: resMetadata,
+	}, nil
+}
+
+// setTags replaces the tags of an object, or of the version of the object in the request metadata, with the tags in
+// the request metadata; an empty value removes all the tags.
+func (s *AWSS3) setTags(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataKey)
+	}
+	rawTags, ok := req.Metadata[metadataTags]
+	if !ok {
+		return nil, fmt.Errorf("s3 binding error: required metadata '%s' missing", metadataTags)
+	}
+
+	tagSet := []*s3.Tag{}
+	if rawTags != "" {
+		var err error
+		tagSet, err = parseS3TagSet(rawTags)
+		if err != nil {
+			return nil, fmt.Errorf("s3 binding error: parsing tags failed: %w", err)
+		}
+	}
+
+	result, err := s.authProvider.S3().S3.PutObjectTaggingWithContext(ctx, &s3.PutObjectTaggingInput{
+		Bucket:    ptr.Of(s.metadata.Bucket),
+		Key:       ptr.Of(key),
+		VersionId: optionalString(req.Metadata[metadataVersionID]),
+		Tagging:   &s3.Tagging{TagSet: tagSet},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: set tags operation failed: %w", err)
+	}
+
+	resMetadata := map[string]string{
+		metadataKey: key,
+	}
+	if result.VersionId != nil {
+		resMetadata[metadataVersionID] = *result.VersionId
+	}
+	return &bindings.InvokeResponse{
+		Metadata: resMetadata,
+	}, nil
+}
@@ -14,23 +14,35 @@ limitations under the License.
 package blobstorage
 
 import (
+	"bytes"
 	"context"
 	b64 "encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
 	"github.com/google/uuid"
 
 	"github.com/dapr/components-contrib/bindings"
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
 	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
@@ -54,6 +66,34 @@ const (
 	// Defines the delete snapshots option for the delete operation.
 	// See: https://docs.microsoft.com/en-us/rest/api/storageservices/delete-blob#request-headers
 	metadataKeyDeleteSnapshots = "deleteSnapshots"
+	// Identifies the version of the blob to apply the immutability policy or legal hold to.
+	metadataKeyVersionID = "versionId"
+	// Expiry time of the immutability policy, in RFC 3339 format.
+	metadataKeyImmutabilityPolicyExpiry = "immutabilityPolicyExpiry"
+	// Mode of the immutability policy: "Unlocked" (default) or "Locked".
+	// See: https://learn.microsoft.com/en-us/azure/storage/blobs/immutable-time-based-retention-policy-overview
+	metadataKeyImmutabilityPolicyMode = "immutabilityPolicyMode"
+	// Defines if the legal hold is set or cleared.
+	// See: https://learn.microsoft.com/en-us/azure/storage/blobs/immutable-legal-hold-overview
+	metadataKeyLegalHold = "legalHold"
+	// Comma-separated permissions granted by the SAS URL: "read" (default), "write" and "delete".
+	metadataKeySASPermissions = "sasPermissions"
+	// Duration the SAS URL is valid for, such as "15m". Defaults to 1 hour.
+	metadataKeySASExpiry = "sasExpiry"
+	// URL of the blob copied by the copy operation, which can be in another account; it must be public or include a SAS.
+	metadataKeySourceURL = "sourceURL"
+	// Name of the blob copied by the copy operation, in the same container. Ignored if sourceURL is set.
+	metadataKeySourceBlobName = "sourceBlobName"
+	// Defines if the copy operation waits for the copy to complete. Defaults to true.
+	metadataKeyWaitForCompletion = "waitForCompletion"
+	// Access tier set by the setTier operation: "Hot", "Cool", "Cold" or "Archive".
+	// See: https://learn.microsoft.com/en-us/azure/storage/blobs/access-tiers-overview
+	metadataKeyAccessTier = "accessTier"
+	// Defines the response metadata key for the snapshot created by the createSnapshot operation.
+	metadataKeySnapshot = "snapshot"
+	// Default and maximum validity of SAS URLs; user delegation keys can't be valid for more than 7 days.
+	defaultSASExpiry = time.Hour
+	maxSASExpiry     = 7 * 24 * time.Hour
 	// Specifies the maximum number of blobs to return, including all BlobPrefix elements. If the request does not
 	// specify maxresults the server will return up to 5,000 items.
 	// See: https://docs.microsoft.com/en-us/rest/api/storageservices/list-blobs#uri-parameters
@@ -61,14 +101,36 @@ const (
 	endpointKey       = "endpoint"
 )
 
+const (
+	setImmutabilityPolicyOperation    bindings.OperationKind = "setImmutabilityPolicy"
+	deleteImmutabilityPolicyOperation bindings.OperationKind = "deleteImmutabilityPolicy"
+	setLegalHoldOperation             bindings.OperationKind = "setLegalHold"
+	getSASURLOperation                bindings.OperationKind = "getSASURL"
+	createSnapshotOperation           bindings.OperationKind = "createSnapshot"
+	copyOperation                     bindings.OperationKind = "copy"
+	setTierOperation                  bindings.OperationKind = "setTier"
+)
+
 var ErrMissingBlobName = errors.New("blobName is a required attribute")
 
-// AzureBlobStorage allows saving blobs to an Azure Blob Storage account.
+// Interval between the checks of the status of copies; a variable so tests can shorten it.
+var copyPollInterval = time.Second
+
+// AzureBlobStorage allows saving blobs to an Azure Blob Storage account, and reading the events of its blobs.
 type AzureBlobStorage struct {
 	metadata        *storagecommon.BlobStorageMetadata
 	containerClient *container.Client
-
-	logger logger.Logger
+	// Used to sign SAS URLs with a user delegation key when authenticating with Azure AD.
+	serviceClient *service.Client
+	// Client-side encryption of the blobs, nil if disabled.
+	encryption *blobEncryption
+	// Queue with the blob events read by the input binding, nil if disabled.
+	eventsQueue eventsQueue
+
+	logger  logger.Logger
+	closeCh chan struct{}
+	closed  atomic.Bool
+	wg      sync.WaitGroup
 }
 
 type createResponse struct {
@@ -76,12 +138,24 @@ type createResponse struct {
 	BlobName string `json:"blobName"`
 }
 
+type sasURLResponse struct {
+	SASURL    string    `json:"sasURL"`
+	ExpiresOn time.Time `json:"expiresOn"`
+}
+
+type copyResponse struct {
+	CopyID     string `json:"copyId"`
+	CopyStatus string `json:"copyStatus"`
+}
+
 type listInclude struct {
 	Copy             bool `json:"copy"`
 	Metadata         bool `json:"metadata"`
 	Snapshots        bool `json:"snapshots"`
 	UncommittedBlobs bool `json:"uncommittedBlobs"`
 	Deleted          bool `json:"deleted"`
+	Versions         bool `json:"versions"`
+	Tags             bool `json:"tags"`
 }
 
 type listPayload struct {
@@ -92,8 +166,11 @@ type listPayload struct {
 }
 
 // NewAzureBlobStorage returns a new Azure Blob Storage instance.
-func NewAzureBlobStorage(logger logger.Logger) bindings.OutputBinding {
-	return &AzureBlobStorage{logger: logger}
+func NewAzureBlobStorage(logger logger.Logger) bindings.InputOutputBinding {
+	return &AzureBlobStorage{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
 }
 
 // Init performs metadata parsing.
@@ -103,15 +180,74 @@ func (a *AzureBlobStorage) Init(ctx context.Context, metadata bindings.Metadata)
 	if err != nil {
 		return err
 	}
+
+	// Without an account key, SAS URLs are signed with a user delegation key obtained with Azure AD credentials.
+	if a.metadata.ConnectionString == "" && a.metadata.AccountKey == "" {
+		a.serviceClient, err = a.newServiceClient(metadata.Properties)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.encryption, err = newBlobEncryption(ctx, a.logger, a.metadata, metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	if a.metadata.EventsQueueName != "" {
+		a.eventsQueue, err = a.newEventsQueue(metadata.Properties)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (a *AzureBlobStorage) newServiceClient(props map[string]string) (*service.Client, error) {
+	azEnvSettings, err := azauth.NewEnvironmentSettings(props)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := azEnvSettings.GetTokenCredential()
+	if err != nil {
+		return nil, fmt.Errorf("invalid token credentials with error: %w", err)
+	}
+
+	urlParts, err := blob.ParseURL(a.containerClient.URL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse container URL: %w", err)
+	}
+	urlParts.ContainerName = ""
+	client, err := service.NewClient(urlParts.String(), credential, &service.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Retry: policy.RetryOptions{
+				MaxRetries: a.metadata.RetryCount,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot init blob storage service client with Azure AD token: %w", err)
+	}
+	return client, nil
+}
+
 func (a *AzureBlobStorage) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{
 		bindings.CreateOperation,
 		bindings.GetOperation,
 		bindings.DeleteOperation,
 		bindings.ListOperation,
+		setImmutabilityPolicyOperation,
+		deleteImmutabilityPolicyOperation,
+		setLegalHoldOperation,
+		getSASURLOperation,
+		createSnapshotOperation,
+		copyOperation,
+		setTierOperation,
+		acquireLeaseOperation,
+		renewLeaseOperation,
+		releaseLeaseOperation,
+		breakLeaseOperation,
 	}
 }
 
@@ -133,27 +269,53 @@ func (a *AzureBlobStorage) create(ctx context.Context, req *bindings.InvokeReque
 		return nil, err
 	}
 
-	d, err := strconv.Unquote(string(req.Data))
-	if err == nil {
-		req.Data = []byte(d)
-	}
-
-	if a.metadata.DecodeBase64 {
-		decoded, decodeError := b64.StdEncoding.DecodeString(string(req.Data))
-		if decodeError != nil {
-			return nil, decodeError
+	blockBlobClient := a.containerClient.NewBlockBlobClient(blobName)
+	metadata := storagecommon.SanitizeMetadata(a.logger, req.Metadata)
+	body := uploadReader(req.Data, a.metadata.DecodeBase64)
+	switch {
+	case a.encryption != nil:
+		if blobHTTPHeaders.BlobContentMD5 != nil {
+			return nil, errors.New("contentMD5 is not supported with client-side encryption")
 		}
-		req.Data = decoded
-	}
-
-	uploadOptions := azblob.UploadBufferOptions{
-		Metadata:                storagecommon.SanitizeMetadata(a.logger, req.Metadata),
-		HTTPHeaders:             &blobHTTPHeaders,
-		TransactionalContentMD5: blobHTTPHeaders.BlobContentMD5,
+		// The content is encrypted at once, as a single AES-GCM message
+		data, rErr := io.ReadAll(body)
+		if rErr != nil {
+			return nil, rErr
+		}
+		data, encryptionMetadata, eErr := a.encryption.encrypt(ctx, data)
+		if eErr != nil {
+			return nil, eErr
+		}
+		if metadata == nil {
+			metadata = make(map[string]*string, len(encryptionMetadata))
+		}
+		maps.Copy(metadata, encryptionMetadata)
+		_, err = blockBlobClient.UploadStream(ctx, bytes.NewReader(data), &azblob.UploadStreamOptions{
+			BlockSize:   a.metadata.UploadBlockSize,
+			Concurrency: a.metadata.UploadConcurrency,
+			Metadata:    metadata,
+			HTTPHeaders: &blobHTTPHeaders,
+		})
+	case blobHTTPHeaders.BlobContentMD5 != nil:
+		// The MD5 of the whole content is validated by a single upload
+		data, rErr := io.ReadAll(body)
+		if rErr != nil {
+			return nil, rErr
+		}
+		_, err = blockBlobClient.UploadBuffer(ctx, data, &azblob.UploadBufferOptions{
+			Metadata:                metadata,
+			HTTPHeaders:             &blobHTTPHeaders,
+			TransactionalContentMD5: blobHTTPHeaders.BlobContentMD5,
+		})
+	default:
+		// The content is staged in blocks, each sent with its own request
+		_, err = blockBlobClient.UploadStream(ctx, body, &azblob.UploadStreamOptions{
+			BlockSize:   a.metadata.UploadBlockSize,
+			Concurrency: a.metadata.UploadConcurrency,
+			Metadata:    metadata,
+			HTTPHeaders: &blobHTTPHeaders,
+		})
 	}
-
-	blockBlobClient := a.containerClient.NewBlockBlobClient(blobName)
-	_, err = blockBlobClient.UploadBuffer(ctx, req.Data, &uploadOptions)
 	if err != nil {
 		return nil, fmt.Errorf("error uploading az blob: %w", err)
 	}
@@ -184,22 +346,9 @@ func (a *AzureBlobStorage) get(ctx context.Context, req *bindings.InvokeRequest)
 		return nil, ErrMissingBlobName
 	}
 
-	downloadOptions := azblob.DownloadStreamOptions{
-		AccessConditions: &blob.AccessConditions{},
-	}
-
-	blobDownloadResponse, err := blockBlobClient.DownloadStream(ctx, &downloadOptions)
-	if err != nil {
-		if bloberror.HasCode(err, bloberror.BlobNotFound) {
-			return nil, errors.New("blob not found")
-		}
-		return nil, fmt.Errorf("error downloading az blob: %w", err)
-	}
-	reader := blobDownloadResponse.Body
-	defer reader.Close()
-	blobData, err := io.ReadAll(reader)
+	blobData, err := a.download(ctx, blockBlobClient)
 	if err != nil {
-		return nil, fmt.Errorf("error reading az blob: %w", err)
+		return nil, err
 	}
 
 	var metadata map[string]string
@@ -235,6 +384,37 @@ func (a *AzureBlobStorage) get(ctx context.Context, req *bindings.InvokeRequest)
 	}, nil
 }
 
+// download returns the content of a blob, decrypted if it was encrypted client-side.
+func (a *AzureBlobStorage) download(ctx context.Context, blockBlobClient *blockblob.Client) ([]byte, error) {
+	downloadOptions := azblob.DownloadStreamOptions{
+		AccessConditions: &blob.AccessConditions{},
+	}
+
+	blobDownloadResponse, err := blockBlobClient.DownloadStream(ctx, &downloadOptions)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, errors.New("blob not found")
+		}
+		return nil, fmt.Errorf("error downloading az blob: %w", err)
+	}
+	reader := blobDownloadResponse.Body
+	defer reader.Close()
+	blobData, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading az blob: %w", err)
+	}
+	if isEncryptedBlob(blobDownloadResponse.Metadata) {
+		if a.encryption == nil {
+			return nil, errors.New("blob is encrypted client-side, but no encryption key is configured")
+		}
+		blobData, err = a.encryption.decrypt(ctx, blobData, blobDownloadResponse.Metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blobData, nil
+}
+
 func (a *AzureBlobStorage) delete(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	var blockBlobClient *blockblob.Client
 	val, ok := req.Metadata[metadataKeyBlobName]
@@ -267,42 +447,10 @@ func (a *AzureBlobStorage) delete(ctx context.Context, req *bindings.InvokeReque
 }
 
 func (a *AzureBlobStorage) list(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	options := container.ListBlobsFlatOptions{}
-
-	hasPayload := false
-	var payload listPayload
-	if req.Data != nil {
-		err := json.Unmarshal(req.Data, &payload)
-		if err != nil {
-			return nil, err
-		}
-		hasPayload = true
-	}
-	if hasPayload {
-		options.Include.Copy = payload.Include.Copy
-		options.Include.Metadata = payload.Include.Metadata
-		options.Include.Snapshots = payload.Include.Snapshots
-		options.Include.UncommittedBlobs = payload.Include.UncommittedBlobs
-		options.Include.Deleted = payload.Include.Deleted
-	}
-
-	if hasPayload && payload.MaxResults > 0 {
-		options.MaxResults = &payload.MaxResults
-	} else {
-		options.MaxResults = ptr.Of(maxResults) // cannot get address of constant directly
-	}
-
-	if hasPayload && payload.Prefix != "" {
-		options.Prefix = &payload.Prefix
-	}
-
-	var initialMarker string
-	if hasPayload && payload.Marker != "" {
-		initialMarker = payload.Marker
-	} else {
-		initialMarker = ""
+	options, err := listOptions(req.Data)
+	if err != nil {
+		return nil, err
 	}
-	options.Marker = &initialMarker
 
 	metadata := make(map[string]string, 3)
 	blobs := []*container.BlobItem{}
@@ -320,8 +468,8 @@ func (a *AzureBlobStorage) list(ctx context.Context, req *bindings.InvokeRequest
 
 		blobs = append(blobs, resp.Segment.BlobItems...)
 		numBlobs += len(resp.Segment.BlobItems)
-		if resp.Marker != nil {
-			metadata[metadataKeyMarker] = *resp.Marker
+		if resp.NextMarker != nil {
+			metadata[metadataKeyMarker] = *resp.NextMarker
 		} else {
 			metadata[metadataKeyMarker] = ""
 		}
@@ -344,6 +492,367 @@ func (a *AzureBlobStorage) list(ctx context.Context, req *bindings.InvokeRequest
 	}, nil
 }
 
+// uploadReader returns a reader of the content of a blob to create.
+// Quoted content is unquoted, and base64 content is decoded as it's read.
+func uploadReader(data []byte, decodeBase64 bool) io.Reader {
+	if n := len(data); n >= 2 && data[0] == data[n-1] && (data[0] == '"' || data[0] == '`' || data[0] == '\'') {
+		d, err := strconv.Unquote(string(data))
+		if err == nil {
+			data = []byte(d)
+		}
+	}
+
+	var r io.Reader = bytes.NewReader(data)
+	if decodeBase64 {
+		r = b64.NewDecoder(b64.StdEncoding, r)
+	}
+	return r
+}
+
+// listOptions returns the options of a list operation from its payload.
+// The marker of the response metadata continues the listing where the previous list operation stopped.
+func listOptions(data []byte) (container.ListBlobsFlatOptions, error) {
+	var payload listPayload
+	if len(data) > 0 {
+		err := json.Unmarshal(data, &payload)
+		if err != nil {
+			return container.ListBlobsFlatOptions{}, err
+		}
+	}
+	if payload.MaxResults < 0 {
+		return container.ListBlobsFlatOptions{}, errors.New("maxResults must not be negative")
+	}
+
+	options := container.ListBlobsFlatOptions{
+		Include: container.ListBlobsInclude{
+			Copy:             payload.Include.Copy,
+			Metadata:         payload.Include.Metadata,
+			Snapshots:        payload.Include.Snapshots,
+			UncommittedBlobs: payload.Include.UncommittedBlobs,
+			Deleted:          payload.Include.Deleted,
+			Versions:         payload.Include.Versions,
+			Tags:             payload.Include.Tags,
+		},
+		MaxResults: ptr.Of(maxResults),
+		Marker:     ptr.Of(payload.Marker),
+	}
+	if payload.MaxResults > 0 {
+		options.MaxResults = ptr.Of(payload.MaxResults)
+	}
+	if payload.Prefix != "" {
+		options.Prefix = ptr.Of(payload.Prefix)
+	}
+	return options, nil
+}
+
+func (a *AzureBlobStorage) setImmutabilityPolicy(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	val, ok := req.Metadata[metadataKeyImmutabilityPolicyExpiry]
+	if !ok || val == "" {
+		return nil, fmt.Errorf("%s is a required attribute", metadataKeyImmutabilityPolicyExpiry)
+	}
+	expiry, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, must be in RFC 3339 format: %w", metadataKeyImmutabilityPolicyExpiry, err)
+	}
+
+	options := blob.SetImmutabilityPolicyOptions{}
+	if mode, ok := req.Metadata[metadataKeyImmutabilityPolicyMode]; ok && mode != "" {
+		options.Mode = ptr.Of(blob.ImmutabilityPolicySetting(mode))
+		if !slices.Contains(blob.PossibleImmutabilityPolicySettingValues(), *options.Mode) {
+			return nil, fmt.Errorf("invalid immutability policy mode: %s; allowed: %s",
+				mode, blob.PossibleImmutabilityPolicySettingValues())
+		}
+	}
+
+	blobClient, err := a.blobClient(req)
+	if err != nil {
+		return nil, err
+	}
+	_, err = blobClient.SetImmutabilityPolicy(ctx, expiry, &options)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, errors.New("blob not found")
+	}
+
+	return nil, err
+}
+
+func (a *AzureBlobStorage) deleteImmutabilityPolicy(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	blobClient, err := a.blobClient(req)
+	if err != nil {
+		return nil, err
+	}
+	_, err = blobClient.DeleteImmutabilityPolicy(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, errors.New("blob not found")
+	}
+
+	return nil, err
+}
+
+func (a *AzureBlobStorage) setLegalHold(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	val, ok := req.Metadata[metadataKeyLegalHold]
+	if !ok || val == "" {
+		return nil, fmt.Errorf("%s is a required attribute", metadataKeyLegalHold)
+	}
+	legalHold, err := strconv.ParseBool(val)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", metadataKeyLegalHold, err)
+	}
+
+	blobClient, err := a.blobClient(req)
+	if err != nil {
+		return nil, err
+	}
+	_, err = blobClient.SetLegalHold(ctx, legalHold, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, errors.New("blob not found")
+	}
+
+	return nil, err
+}
+
+func (a *AzureBlobStorage) getSASURL(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name, ok := req.Metadata[metadataKeyBlobName]
+	if !ok || name == "" {
+		return nil, ErrMissingBlobName
+	}
+	permissions, expiry, err := sasOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backdate the start time to tolerate clock skew.
+	now := time.Now().UTC()
+	start := now.Add(-5 * time.Minute)
+	expiresOn := now.Add(expiry)
+
+	blobClient := a.containerClient.NewBlobClient(name)
+	sasURL, err := blobClient.GetSASURL(permissions, expiresOn, &blob.GetSASURLOptions{StartTime: &start})
+	if errors.Is(err, bloberror.MissingSharedKeyCredential) {
+		sasURL, err = a.userDelegationSASURL(ctx, blobClient, name, permissions, start, expiresOn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error generating SAS URL: %w", err)
+	}
+
+	b, err := json.Marshal(sasURLResponse{
+		SASURL:    sasURL,
+		ExpiresOn: expiresOn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling SAS URL response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: b,
+	}, nil
+}
+
+// userDelegationSASURL returns a SAS URL for the blob signed with a user delegation key.
+func (a *AzureBlobStorage) userDelegationSASURL(ctx context.Context, blobClient *blob.Client, name string, permissions sas.BlobPermissions, start time.Time, expiresOn time.Time) (string, error) {
+	if a.serviceClient == nil {
+		return "", errors.New("SAS URLs require an account key or Azure AD credentials")
+	}
+
+	credential, err := a.serviceClient.GetUserDelegationCredential(ctx, service.KeyInfo{
+		Start:  ptr.Of(start.Format(sas.TimeFormat)),
+		Expiry: ptr.Of(expiresOn.Format(sas.TimeFormat)),
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user delegation key: %w", err)
+	}
+
+	qps, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		StartTime:     start,
+		ExpiryTime:    expiresOn,
+		Permissions:   permissions.String(),
+		ContainerName: a.metadata.ContainerName,
+		BlobName:      name,
+	}.SignWithUserDelegation(credential)
+	if err != nil {
+		return "", err
+	}
+	return blobClient.URL() + "?" + qps.Encode(), nil
+}
+
+// sasOptions returns the permissions and validity of the SAS URL requested with the metadata.
+func sasOptions(md map[string]string) (sas.BlobPermissions, time.Duration, error) {
+	permissions := sas.BlobPermissions{Read: true}
+	if val, ok := md[metadataKeySASPermissions]; ok && val != "" {
+		permissions = sas.BlobPermissions{}
+		for _, p := range strings.Split(val, ",") {
+			switch strings.ToLower(strings.TrimSpace(p)) {
+			case "read":
+				permissions.Read = true
+			case "write":
+				permissions.Write = true
+			case "delete":
+				permissions.Delete = true
+			default:
+				return sas.BlobPermissions{}, 0, fmt.Errorf("invalid %s: %s; allowed: read, write, delete", metadataKeySASPermissions, p)
+			}
+		}
+	}
+
+	expiry := defaultSASExpiry
+	if val, ok := md[metadataKeySASExpiry]; ok && val != "" {
+		var err error
+		expiry, err = time.ParseDuration(val)
+		if err != nil {
+			return sas.BlobPermissions{}, 0, fmt.Errorf("error parsing %s: %w", metadataKeySASExpiry, err)
+		}
+		if expiry <= 0 || expiry > maxSASExpiry {
+			return sas.BlobPermissions{}, 0, fmt.Errorf("invalid %s: must be positive and at most %s", metadataKeySASExpiry, maxSASExpiry)
+		}
+	}
+	return permissions, expiry, nil
+}
+
+func (a *AzureBlobStorage) createSnapshot(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	blobClient, err := a.blobClient(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := blobClient.CreateSnapshot(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, errors.New("blob not found")
+		}
+		return nil, fmt.Errorf("error creating blob snapshot: %w", err)
+	}
+
+	var metadata map[string]string
+	if resp.Snapshot != nil {
+		metadata = map[string]string{
+			metadataKeySnapshot: *resp.Snapshot,
+		}
+	}
+	return &bindings.InvokeResponse{
+		Metadata: metadata,
+	}, nil
+}
+
+func (a *AzureBlobStorage) copy(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name, ok := req.Metadata[metadataKeyBlobName]
+	if !ok || name == "" {
+		return nil, ErrMissingBlobName
+	}
+	source := req.Metadata[metadataKeySourceURL]
+	if source == "" {
+		sourceName := req.Metadata[metadataKeySourceBlobName]
+		if sourceName == "" {
+			return nil, fmt.Errorf("%s or %s is a required attribute", metadataKeySourceURL, metadataKeySourceBlobName)
+		}
+		source = a.containerClient.NewBlobClient(sourceName).URL()
+	}
+	wait := true
+	if _, ok := req.Metadata[metadataKeyWaitForCompletion]; ok {
+		var err error
+		wait, err = req.GetMetadataAsBool(metadataKeyWaitForCompletion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", metadataKeyWaitForCompletion, err)
+		}
+	}
+
+	blobClient := a.containerClient.NewBlobClient(name)
+	resp, err := blobClient.StartCopyFromURL(ctx, source, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.CannotVerifyCopySource) {
+			return nil, fmt.Errorf("source blob not found or not accessible: %w", err)
+		}
+		return nil, fmt.Errorf("error starting blob copy: %w", err)
+	}
+
+	var res copyResponse
+	if resp.CopyID != nil {
+		res.CopyID = *resp.CopyID
+	}
+	if resp.CopyStatus != nil {
+		res.CopyStatus = string(*resp.CopyStatus)
+	}
+	if wait && res.CopyStatus == string(blob.CopyStatusTypePending) {
+		res.CopyStatus, err = a.waitForCopy(ctx, blobClient, res.CopyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if res.CopyStatus == string(blob.CopyStatusTypeFailed) || res.CopyStatus == string(blob.CopyStatusTypeAborted) {
+		return nil, fmt.Errorf("blob copy %s %s", res.CopyID, res.CopyStatus)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling copy response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: b,
+	}, nil
+}
+
+// waitForCopy polls the properties of the destination blob until the copy is no longer pending, and returns its
+// final status.
+func (a *AzureBlobStorage) waitForCopy(ctx context.Context, blobClient *blob.Client, copyID string) (string, error) {
+	ticker := time.NewTicker(copyPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("error waiting for blob copy %s: %w", copyID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		props, err := blobClient.GetProperties(ctx, nil)
+		if err != nil {
+			return "", fmt.Errorf("error reading status of blob copy %s: %w", copyID, err)
+		}
+		// Another copy to the same blob replaces this one.
+		if props.CopyID == nil || *props.CopyID != copyID {
+			return string(blob.CopyStatusTypeAborted), nil
+		}
+		if props.CopyStatus != nil && *props.CopyStatus != blob.CopyStatusTypePending {
+			return string(*props.CopyStatus), nil
+		}
+	}
+}
+
+func (a *AzureBlobStorage) setTier(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	val, ok := req.Metadata[metadataKeyAccessTier]
+	if !ok || val == "" {
+		return nil, fmt.Errorf("%s is a required attribute", metadataKeyAccessTier)
+	}
+	i := slices.IndexFunc(blob.PossibleAccessTierValues(), func(tier blob.AccessTier) bool {
+		return strings.EqualFold(string(tier), val)
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("invalid access tier: %s; allowed: %s", val, blob.PossibleAccessTierValues())
+	}
+
+	blobClient, err := a.blobClient(req)
+	if err != nil {
+		return nil, err
+	}
+	_, err = blobClient.SetTier(ctx, blob.PossibleAccessTierValues()[i], nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, errors.New("blob not found")
+	}
+
+	return nil, err
+}
+
+// blobClient returns the client for the blob referenced by the request, optionally for a specific version.
+func (a *AzureBlobStorage) blobClient(req *bindings.InvokeRequest) (*blob.Client, error) {
+	name, ok := req.Metadata[metadataKeyBlobName]
+	if !ok || name == "" {
+		return nil, ErrMissingBlobName
+	}
+
+	blobClient := a.containerClient.NewBlobClient(name)
+	if versionID, ok := req.Metadata[metadataKeyVersionID]; ok && versionID != "" {
+		return blobClient.WithVersionID(versionID)
+	}
+	return blobClient, nil
+}
+
 func (a *AzureBlobStorage) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	switch req.Operation {
 	case bindings.CreateOperation:
@@ -354,6 +863,22 @@ func (a *AzureBlobStorage) Invoke(ctx context.Context, req *bindings.InvokeReque
 		return a.delete(ctx, req)
 	case bindings.ListOperation:
 		return a.list(ctx, req)
+	case setImmutabilityPolicyOperation:
+		return a.setImmutabilityPolicy(ctx, req)
+	case deleteImmutabilityPolicyOperation:
+		return a.deleteImmutabilityPolicy(ctx, req)
+	case setLegalHoldOperation:
+		return a.setLegalHold(ctx, req)
+	case getSASURLOperation:
+		return a.getSASURL(ctx, req)
+	case createSnapshotOperation:
+		return a.createSnapshot(ctx, req)
+	case copyOperation:
+		return a.copy(ctx, req)
+	case setTierOperation:
+		return a.setTier(ctx, req)
+	case acquireLeaseOperation, renewLeaseOperation, releaseLeaseOperation, breakLeaseOperation:
+		return a.handleLease(ctx, req)
 	default:
 		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
 	}
@@ -378,5 +903,13 @@ func (a *AzureBlobStorage) GetComponentMetadata() (metadataInfo contribMetadata.
 }
 
 func (a *AzureBlobStorage) Close() error {
+	if a.closed.CompareAndSwap(false, true) {
+		close(a.closeCh)
+	}
+	a.wg.Wait()
+
+	if a.encryption != nil {
+		return a.encryption.Close()
+	}
 	return nil
 }
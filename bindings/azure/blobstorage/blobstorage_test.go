@@ -14,11 +14,22 @@ limitations under the License.
 package blobstorage
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/bindings"
+	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
 	"github.com/dapr/kit/logger"
 )
 
@@ -53,3 +64,296 @@ func TestDeleteOption(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestImmutabilityOptions(t *testing.T) {
+	blobStorage := NewAzureBlobStorage(logger.NewLogger("test")).(*AzureBlobStorage)
+
+	t.Run("return error if expiry is missing", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		r.Metadata = map[string]string{
+			"blobName": "foo",
+		}
+		_, err := blobStorage.setImmutabilityPolicy(t.Context(), &r)
+		require.ErrorContains(t, err, "immutabilityPolicyExpiry is a required attribute")
+	})
+
+	t.Run("return error for invalid expiry", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		r.Metadata = map[string]string{
+			"blobName":                 "foo",
+			"immutabilityPolicyExpiry": "tomorrow",
+		}
+		_, err := blobStorage.setImmutabilityPolicy(t.Context(), &r)
+		require.Error(t, err)
+	})
+
+	t.Run("return error for invalid mode", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		r.Metadata = map[string]string{
+			"blobName":                 "foo",
+			"immutabilityPolicyExpiry": "2030-01-02T03:04:05Z",
+			"immutabilityPolicyMode":   "Mutable",
+		}
+		_, err := blobStorage.setImmutabilityPolicy(t.Context(), &r)
+		require.ErrorContains(t, err, "invalid immutability policy mode")
+	})
+
+	t.Run("return error if blobName is missing", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		_, err := blobStorage.deleteImmutabilityPolicy(t.Context(), &r)
+		require.ErrorIs(t, err, ErrMissingBlobName)
+	})
+
+	t.Run("return error for invalid legalHold", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		r.Metadata = map[string]string{
+			"blobName":  "foo",
+			"legalHold": "maybe",
+		}
+		_, err := blobStorage.setLegalHold(t.Context(), &r)
+		require.Error(t, err)
+	})
+}
+
+func TestListOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		options, err := listOptions(nil)
+		require.NoError(t, err)
+		require.Equal(t, maxResults, *options.MaxResults)
+		require.Empty(t, *options.Marker)
+		require.Nil(t, options.Prefix)
+	})
+
+	t.Run("prefix, pagination and include options", func(t *testing.T) {
+		options, err := listOptions([]byte(`{"prefix": "logs/", "maxResults": 10, "marker": "next", "include": {"metadata": true, "snapshots": true, "versions": true}}`))
+		require.NoError(t, err)
+		require.Equal(t, "logs/", *options.Prefix)
+		require.Equal(t, int32(10), *options.MaxResults)
+		require.Equal(t, "next", *options.Marker)
+		require.Equal(t, container.ListBlobsInclude{Metadata: true, Snapshots: true, Versions: true}, options.Include)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := listOptions([]byte(`{"maxResults": -1}`))
+		require.Error(t, err)
+		_, err = listOptions([]byte(`not json`))
+		require.Error(t, err)
+	})
+}
+
+func TestUploadReader(t *testing.T) {
+	read := func(r io.Reader) string {
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	require.Equal(t, "hello world", read(uploadReader([]byte("hello world"), false)))
+	require.Equal(t, "hello \"world\"", read(uploadReader([]byte(`"hello \"world\""`), false)))
+	require.Equal(t, "hello world", read(uploadReader([]byte("aGVsbG8gd29ybGQ="), true)))
+	require.Equal(t, "hello world", read(uploadReader([]byte(`"aGVsbG8gd29ybGQ="`), true)))
+
+	_, err := io.ReadAll(uploadReader([]byte("not base64!"), true))
+	require.Error(t, err)
+}
+
+func TestCreateInBlocks(t *testing.T) {
+	var (
+		lock      sync.Mutex
+		blocks    []int
+		committed bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Query().Get("comp") {
+		case "block":
+			blocks = append(blocks, len(body))
+		case "blocklist":
+			committed = true
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	containerClient, err := container.NewClientFromConnectionString("DefaultEndpointsProtocol=http;AccountName=account;AccountKey=a2V5;BlobEndpoint="+srv.URL+"/account", "test", nil)
+	require.NoError(t, err)
+	blobStorage := &AzureBlobStorage{
+		metadata:        &storagecommon.BlobStorageMetadata{UploadBlockSize: 1 << 20, UploadConcurrency: 2},
+		containerClient: containerClient,
+		logger:          logger.NewLogger("test"),
+	}
+
+	_, err = blobStorage.create(t.Context(), &bindings.InvokeRequest{
+		Data:     bytes.Repeat([]byte("a"), 5<<19),
+		Metadata: map[string]string{"blobName": "large"},
+	})
+	require.NoError(t, err)
+	slices.Sort(blocks)
+	require.Equal(t, []int{1 << 19, 1 << 20, 1 << 20}, blocks)
+	require.True(t, committed)
+}
+
+func TestSASOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		permissions, expiry, err := sasOptions(map[string]string{})
+		require.NoError(t, err)
+		require.Equal(t, "r", permissions.String())
+		require.Equal(t, time.Hour, expiry)
+	})
+
+	t.Run("valid options", func(t *testing.T) {
+		permissions, expiry, err := sasOptions(map[string]string{
+			metadataKeySASPermissions: "read, Write,delete",
+			metadataKeySASExpiry:      "15m",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "rwd", permissions.String())
+		require.Equal(t, 15*time.Minute, expiry)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, md := range []map[string]string{
+			{metadataKeySASPermissions: "list"},
+			{metadataKeySASExpiry: "soon"},
+			{metadataKeySASExpiry: "-1h"},
+			{metadataKeySASExpiry: "200h"},
+		} {
+			_, _, err := sasOptions(md)
+			require.Error(t, err, md)
+		}
+	})
+}
+
+func TestGetSASURL(t *testing.T) {
+	client, err := container.NewClientFromConnectionString(
+		"DefaultEndpointsProtocol=https;AccountName=account;AccountKey=a2V5;EndpointSuffix=core.windows.net", "container", nil)
+	require.NoError(t, err)
+	blobStorage := &AzureBlobStorage{
+		metadata:        &storagecommon.BlobStorageMetadata{},
+		containerClient: client,
+		logger:          logger.NewLogger("test"),
+	}
+
+	t.Run("return error if blobName is missing", func(t *testing.T) {
+		_, err := blobStorage.getSASURL(t.Context(), &bindings.InvokeRequest{})
+		require.ErrorIs(t, err, ErrMissingBlobName)
+	})
+
+	t.Run("sign with the account key", func(t *testing.T) {
+		res, err := blobStorage.getSASURL(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{
+				metadataKeyBlobName:       "foo.txt",
+				metadataKeySASPermissions: "read,write",
+				metadataKeySASExpiry:      "30m",
+			},
+		})
+		require.NoError(t, err)
+
+		var payload sasURLResponse
+		require.NoError(t, json.Unmarshal(res.Data, &payload))
+		require.WithinDuration(t, time.Now().Add(30*time.Minute), payload.ExpiresOn, time.Minute)
+
+		u, err := url.Parse(payload.SASURL)
+		require.NoError(t, err)
+		require.Equal(t, "/container/foo.txt", u.Path)
+		require.Equal(t, "rw", u.Query().Get("sp"))
+		require.NotEmpty(t, u.Query().Get("sig"))
+	})
+}
+
+func TestCopy(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		source string
+		polls  int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("x-ms-copy-id", "copy-1")
+		switch r.Method {
+		case http.MethodPut:
+			source = r.Header.Get("x-ms-copy-source")
+			w.Header().Set("x-ms-copy-status", "pending")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodHead:
+			polls++
+			if polls < 2 {
+				w.Header().Set("x-ms-copy-status", "pending")
+			} else {
+				w.Header().Set("x-ms-copy-status", "success")
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	copyPollInterval = time.Millisecond
+	defer func() { copyPollInterval = time.Second }()
+
+	containerClient, err := container.NewClientFromConnectionString("DefaultEndpointsProtocol=http;AccountName=account;AccountKey=a2V5;BlobEndpoint="+srv.URL+"/account", "test", nil)
+	require.NoError(t, err)
+	blobStorage := &AzureBlobStorage{
+		metadata:        &storagecommon.BlobStorageMetadata{},
+		containerClient: containerClient,
+		logger:          logger.NewLogger("test"),
+	}
+
+	t.Run("return error if the source is missing", func(t *testing.T) {
+		_, err := blobStorage.copy(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{metadataKeyBlobName: "dst"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("wait for completion", func(t *testing.T) {
+		res, err := blobStorage.copy(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{
+				metadataKeyBlobName:       "dst",
+				metadataKeySourceBlobName: "src",
+			},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"copyId":"copy-1","copyStatus":"success"}`, string(res.Data))
+		require.Equal(t, srv.URL+"/account/test/src", source)
+		require.Equal(t, 2, polls)
+	})
+
+	t.Run("without waiting", func(t *testing.T) {
+		polls = 0
+		res, err := blobStorage.copy(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{
+				metadataKeyBlobName:          "dst",
+				metadataKeySourceURL:         "https://other.blob.core.windows.net/c/src",
+				metadataKeyWaitForCompletion: "false",
+			},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"copyId":"copy-1","copyStatus":"pending"}`, string(res.Data))
+		require.Equal(t, "https://other.blob.core.windows.net/c/src", source)
+		require.Zero(t, polls)
+	})
+}
+
+func TestSetTier(t *testing.T) {
+	blobStorage := NewAzureBlobStorage(logger.NewLogger("test")).(*AzureBlobStorage)
+
+	t.Run("return error if accessTier is missing", func(t *testing.T) {
+		_, err := blobStorage.setTier(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{metadataKeyBlobName: "foo"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("return error for invalid accessTier", func(t *testing.T) {
+		_, err := blobStorage.setTier(t.Context(), &bindings.InvokeRequest{
+			Metadata: map[string]string{
+				metadataKeyBlobName:   "foo",
+				metadataKeyAccessTier: "frozen",
+			},
+		})
+		require.ErrorContains(t, err, "invalid access tier")
+	})
+}
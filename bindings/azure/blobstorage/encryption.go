@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/crypto/azure/keyvault"
+	"github.com/dapr/components-contrib/crypto/jwks"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	// Blob metadata of encrypted blobs, with the content key wrapped with the key encryption key, and what's needed to
+	// unwrap it and decrypt the content.
+	blobMetadataEncryptedKey        = "daprencryptedkey"
+	blobMetadataEncryptionKeyName   = "daprencryptionkeyname"
+	blobMetadataEncryptionAlgorithm = "daprencryptionalgorithm"
+	blobMetadataEncryptionNonce     = "daprencryptionnonce"
+	blobMetadataEncryptionCipher    = "daprencryptioncipher"
+
+	// Content is encrypted with AES-256-GCM.
+	contentCipher        = "A256GCM"
+	contentKeySize       = 32
+	defaultWrapAlgorithm = "RSA-OAEP-256"
+)
+
+// blobEncryption performs the client-side envelope encryption of blobs: each blob is encrypted with a random content
+// key, which is stored in the blob metadata wrapped with a key encryption key held by a crypto component.
+type blobEncryption struct {
+	crypto    contribCrypto.SubtleCrypto
+	keyName   string
+	algorithm string
+}
+
+// newBlobEncryption returns the blobEncryption configured in the metadata, or nil if encryption is disabled.
+func newBlobEncryption(ctx context.Context, log logger.Logger, md *storagecommon.BlobStorageMetadata, props map[string]string) (*blobEncryption, error) {
+	e := &blobEncryption{
+		algorithm: md.EncryptionKeyWrapAlgorithm,
+	}
+	if e.algorithm == "" {
+		e.algorithm = defaultWrapAlgorithm
+	}
+
+	cryptoProps := make(map[string]string, len(props)+1)
+	switch {
+	case md.EncryptionKeyID != "":
+		vaultName, keyName, err := parseKeyVaultKeyID(md.EncryptionKeyID)
+		if err != nil {
+			return nil, err
+		}
+		// The Azure AD credentials of the component are used for Key Vault too
+		maps.Copy(cryptoProps, props)
+		cryptoProps["vaultName"] = vaultName
+		e.crypto = keyvault.NewAzureKeyvaultCrypto(log)
+		e.keyName = keyName
+	case md.EncryptionJWKS != "":
+		cryptoProps["jwks"] = md.EncryptionJWKS
+		e.crypto = jwks.NewJWKSCrypto(log)
+		e.keyName = md.EncryptionKeyName
+	default:
+		return nil, nil
+	}
+
+	err := e.crypto.Init(ctx, contribCrypto.Metadata{Base: contribMetadata.Base{Properties: cryptoProps}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to init the encryption key provider: %w", err)
+	}
+	return e, nil
+}
+
+// parseKeyVaultKeyID returns the vault name and the key name, with its version if any, of a Key Vault key ID such as
+// "https://myvault.vault.azure.net/keys/mykey/version".
+func parseKeyVaultKeyID(keyID string) (vaultName string, keyName string, err error) {
+	u, err := url.Parse(keyID)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid encryptionKeyId: %w", err)
+	}
+	vaultName, _, _ = strings.Cut(u.Hostname(), ".")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if vaultName == "" || len(segments) < 2 || len(segments) > 3 || segments[0] != "keys" || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid encryptionKeyId %q: must be in the format https://<vault>.vault.azure.net/keys/<name>[/<version>]", keyID)
+	}
+	return vaultName, strings.Join(segments[1:], "/"), nil
+}
+
+// encrypt returns the encrypted content, and the blob metadata needed to decrypt it.
+func (e *blobEncryption) encrypt(ctx context.Context, plaintext []byte) ([]byte, map[string]*string, error) {
+	contentKey := make([]byte, contentKeySize)
+	_, err := rand.Read(contentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate the content key: %w", err)
+	}
+	aead, err := newContentCipher(contentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate the nonce: %w", err)
+	}
+
+	jwkKey, err := jwk.FromRaw(contentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the content key: %w", err)
+	}
+	wrappedKey, _, err := e.crypto.WrapKey(ctx, jwkKey, e.algorithm, e.keyName, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap the content key: %w", err)
+	}
+
+	metadata := map[string]*string{
+		blobMetadataEncryptedKey:        ptr.Of(b64.StdEncoding.EncodeToString(wrappedKey)),
+		blobMetadataEncryptionKeyName:   ptr.Of(e.keyName),
+		blobMetadataEncryptionAlgorithm: ptr.Of(e.algorithm),
+		blobMetadataEncryptionNonce:     ptr.Of(b64.StdEncoding.EncodeToString(nonce)),
+		blobMetadataEncryptionCipher:    ptr.Of(contentCipher),
+	}
+	return aead.Seal(nil, nonce, plaintext, nil), metadata, nil
+}
+
+// decrypt returns the content of a blob encrypted by encrypt, given the metadata of the blob.
+func (e *blobEncryption) decrypt(ctx context.Context, ciphertext []byte, metadata map[string]*string) ([]byte, error) {
+	get := func(key string) string {
+		for k, v := range metadata {
+			if v != nil && strings.EqualFold(k, key) {
+				return *v
+			}
+		}
+		return ""
+	}
+
+	if cipherName := get(blobMetadataEncryptionCipher); cipherName != contentCipher {
+		return nil, fmt.Errorf("unsupported blob encryption cipher: %q", cipherName)
+	}
+	wrappedKey, err := b64.StdEncoding.DecodeString(get(blobMetadataEncryptedKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted content key: %w", err)
+	}
+	nonce, err := b64.StdEncoding.DecodeString(get(blobMetadataEncryptionNonce))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption nonce: %w", err)
+	}
+
+	// The key the content key was wrapped with may be an older version than the configured one
+	jwkKey, err := e.crypto.UnwrapKey(ctx, wrappedKey, get(blobMetadataEncryptionAlgorithm), get(blobMetadataEncryptionKeyName), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the content key: %w", err)
+	}
+	var contentKey []byte
+	err = jwkKey.Raw(&contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content key: %w", err)
+	}
+
+	aead, err := newContentCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid encryption nonce size")
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the blob: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (e *blobEncryption) Close() error {
+	return e.crypto.Close()
+}
+
+func newContentCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// isEncryptedBlob returns true if the metadata of a blob contains a wrapped content key.
+func isEncryptedBlob(metadata map[string]*string) bool {
+	for k := range metadata {
+		if strings.EqualFold(k, blobMetadataEncryptedKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
+	"github.com/dapr/kit/logger"
+)
+
+const testEncryptionJWKS = `{"keys":[{"kty":"oct","kid":"mykey","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}]}`
+
+func newTestBlobEncryption(t *testing.T) *blobEncryption {
+	t.Helper()
+	e, err := newBlobEncryption(t.Context(), logger.NewLogger("test"), &storagecommon.BlobStorageMetadata{
+		EncryptionJWKS:             testEncryptionJWKS,
+		EncryptionKeyName:          "mykey",
+		EncryptionKeyWrapAlgorithm: "A256KW",
+	}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestParseKeyVaultKeyID(t *testing.T) {
+	vaultName, keyName, err := parseKeyVaultKeyID("https://myvault.vault.azure.net/keys/mykey")
+	require.NoError(t, err)
+	assert.Equal(t, "myvault", vaultName)
+	assert.Equal(t, "mykey", keyName)
+
+	vaultName, keyName, err = parseKeyVaultKeyID("https://myvault.vault.azure.net/keys/mykey/0123456789abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, "myvault", vaultName)
+	assert.Equal(t, "mykey/0123456789abcdef", keyName)
+
+	for _, keyID := range []string{
+		"https://myvault.vault.azure.net/secrets/mykey",
+		"https://myvault.vault.azure.net/keys/",
+		"mykey",
+	} {
+		_, _, err = parseKeyVaultKeyID(keyID)
+		require.Error(t, err, keyID)
+	}
+}
+
+func TestBlobEncryption(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		e, err := newBlobEncryption(t.Context(), logger.NewLogger("test"), &storagecommon.BlobStorageMetadata{}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, e)
+	})
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		e := newTestBlobEncryption(t)
+
+		ciphertext, metadata, err := e.encrypt(t.Context(), []byte("hello world"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(ciphertext), "hello world")
+		assert.True(t, isEncryptedBlob(metadata))
+		assert.Equal(t, "mykey", *metadata[blobMetadataEncryptionKeyName])
+
+		// Azure may return the metadata keys with a different case
+		returned := map[string]*string{}
+		for k, v := range metadata {
+			returned["Dapr"+k[4:]] = v
+		}
+		plaintext, err := e.decrypt(t.Context(), ciphertext, returned)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(plaintext))
+
+		ciphertext[0] ^= 0xff
+		_, err = e.decrypt(t.Context(), ciphertext, metadata)
+		require.Error(t, err)
+	})
+}
+
+func TestGetEncrypted(t *testing.T) {
+	e := newTestBlobEncryption(t)
+	ciphertext, metadata, err := e.encrypt(t.Context(), []byte("hello world"))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range metadata {
+			w.Header().Set("x-ms-meta-"+k, *v)
+		}
+		w.Write(ciphertext)
+	}))
+	defer srv.Close()
+
+	containerClient, err := container.NewClientFromConnectionString("DefaultEndpointsProtocol=http;AccountName=account;AccountKey=a2V5;BlobEndpoint="+srv.URL+"/account", "test", nil)
+	require.NoError(t, err)
+	blobStorage := &AzureBlobStorage{
+		metadata:        &storagecommon.BlobStorageMetadata{},
+		containerClient: containerClient,
+		logger:          logger.NewLogger("test"),
+	}
+	req := &bindings.InvokeRequest{
+		Metadata: map[string]string{metadataKeyBlobName: "foo"},
+	}
+
+	_, err = blobStorage.get(t.Context(), req)
+	require.ErrorContains(t, err, "encrypted")
+
+	blobStorage.encryption = e
+	res, err := blobStorage.get(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(res.Data))
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"bytes"
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
+
+	"github.com/dapr/components-contrib/bindings"
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	blobCreatedEventType = "Microsoft.Storage.BlobCreated"
+	blobDeletedEventType = "Microsoft.Storage.BlobDeleted"
+
+	// Defines the response metadata keys of the events delivered by the input binding.
+	metadataKeyEventType = "eventType"
+
+	defaultEventsPollingInterval   = 10 * time.Second
+	defaultEventsVisibilityTimeout = 30 * time.Second
+	eventsBatchSize                = 32
+)
+
+// eventsQueue is the storage queue Event Grid delivers the blob events to; it's an interface so tests can replace it.
+type eventsQueue interface {
+	DequeueMessages(ctx context.Context, o *azqueue.DequeueMessagesOptions) (azqueue.DequeueMessagesResponse, error)
+	DeleteMessage(ctx context.Context, messageID string, popReceipt string, o *azqueue.DeleteMessageOptions) (azqueue.DeleteMessageResponse, error)
+}
+
+// eventGridEvent is a blob event, in the Event Grid or in the CloudEvents schema.
+type eventGridEvent struct {
+	EventType string     `json:"eventType"`
+	Type      string     `json:"type"`
+	Subject   string     `json:"subject"`
+	EventTime *time.Time `json:"eventTime"`
+	Time      *time.Time `json:"time"`
+	Data      struct {
+		URL           string `json:"url"`
+		ContentType   string `json:"contentType"`
+		ContentLength int64  `json:"contentLength"`
+		ETag          string `json:"eTag"`
+	} `json:"data"`
+}
+
+// blobEvent is the event delivered to the app.
+type blobEvent struct {
+	EventType     string     `json:"eventType"`
+	BlobName      string     `json:"blobName"`
+	BlobURL       string     `json:"blobURL"`
+	ContentType   string     `json:"contentType,omitempty"`
+	ContentLength int64      `json:"contentLength,omitempty"`
+	ETag          string     `json:"eTag,omitempty"`
+	EventTime     *time.Time `json:"eventTime,omitempty"`
+	// Content of created blobs up to eventsContentMaxSize bytes, base64-encoded in JSON.
+	Content []byte `json:"content,omitempty"`
+}
+
+func (a *AzureBlobStorage) newEventsQueue(props map[string]string) (eventsQueue, error) {
+	options := &azqueue.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Telemetry: policy.TelemetryOptions{
+				ApplicationID: "dapr-" + logger.DaprVersion,
+			},
+		},
+	}
+
+	if a.metadata.ConnectionString != "" {
+		client, err := azqueue.NewQueueClientFromConnectionString(a.metadata.ConnectionString, a.metadata.EventsQueueName, options)
+		if err != nil {
+			return nil, fmt.Errorf("cannot init storage queue client with connection string: %w", err)
+		}
+		return client, nil
+	}
+
+	azEnvSettings, err := azauth.NewEnvironmentSettings(props)
+	if err != nil {
+		return nil, err
+	}
+	queueURL := fmt.Sprintf("https://%s.queue.%s/%s", a.metadata.AccountName, azEnvSettings.EndpointSuffix(azauth.ServiceAzureStorage), a.metadata.EventsQueueName)
+
+	if a.metadata.AccountKey != "" {
+		credential, cErr := azqueue.NewSharedKeyCredential(a.metadata.AccountName, a.metadata.AccountKey)
+		if cErr != nil {
+			return nil, fmt.Errorf("invalid shared key credentials with error: %w", cErr)
+		}
+		client, cErr := azqueue.NewQueueClientWithSharedKeyCredential(queueURL, credential, options)
+		if cErr != nil {
+			return nil, fmt.Errorf("cannot init storage queue client with shared key: %w", cErr)
+		}
+		return client, nil
+	}
+
+	credential, err := azEnvSettings.GetTokenCredential()
+	if err != nil {
+		return nil, fmt.Errorf("invalid token credentials with error: %w", err)
+	}
+	client, err := azqueue.NewQueueClient(queueURL, credential, options)
+	if err != nil {
+		return nil, fmt.Errorf("cannot init storage queue client with Azure AD token: %w", err)
+	}
+	return client, nil
+}
+
+// Read delivers the Blob Created and Deleted events of the container to the handler.
+func (a *AzureBlobStorage) Read(ctx context.Context, handler bindings.Handler) error {
+	if a.eventsQueue == nil {
+		return errors.New("eventsQueueName is required to use the binding as input")
+	}
+	if a.closed.Load() {
+		return errors.New("input binding is closed")
+	}
+
+	pollingInterval := a.metadata.EventsPollingInterval
+	if pollingInterval == 0 {
+		pollingInterval = defaultEventsPollingInterval
+	}
+
+	// Close read context when binding is closed.
+	readCtx, cancel := context.WithCancel(ctx)
+	a.wg.Add(2)
+	go func() {
+		defer a.wg.Done()
+		defer cancel()
+
+		select {
+		case <-a.closeCh:
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer a.wg.Done()
+		for readCtx.Err() == nil {
+			n, err := a.readEvents(readCtx, handler)
+			if err != nil {
+				a.logger.Errorf("error reading blob events: %v", err)
+			}
+			if n == 0 || err != nil {
+				// Queue was empty so back off before trying again
+				select {
+				case <-time.After(pollingInterval):
+				case <-readCtx.Done():
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// readEvents reads a batch of messages from the events queue, and returns the number of messages read.
+// Messages are deleted once handled; failed ones are delivered again after the visibility timeout.
+func (a *AzureBlobStorage) readEvents(ctx context.Context, handler bindings.Handler) (int, error) {
+	visibilityTimeout := a.metadata.EventsVisibilityTimeout
+	if visibilityTimeout == 0 {
+		visibilityTimeout = defaultEventsVisibilityTimeout
+	}
+
+	res, err := a.eventsQueue.DequeueMessages(ctx, &azqueue.DequeueMessagesOptions{
+		NumberOfMessages:  ptr.Of(int32(eventsBatchSize)),
+		VisibilityTimeout: ptr.Of(int32(visibilityTimeout.Seconds())),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range res.Messages {
+		if msg.MessageID == nil || msg.PopReceipt == nil {
+			continue
+		}
+
+		var text string
+		if msg.MessageText != nil {
+			text = *msg.MessageText
+		}
+		err = a.handleEvent(ctx, text, handler)
+		if err != nil {
+			a.logger.Errorf("error handling blob event %s: %v", *msg.MessageID, err)
+			continue
+		}
+
+		_, err = a.eventsQueue.DeleteMessage(ctx, *msg.MessageID, *msg.PopReceipt, nil)
+		if err != nil {
+			a.logger.Errorf("error deleting blob event %s: %v", *msg.MessageID, err)
+		}
+	}
+	return len(res.Messages), nil
+}
+
+// handleEvent delivers the blob event in the text of a queue message to the handler.
+// Messages that aren't events of blobs of the container are skipped.
+func (a *AzureBlobStorage) handleEvent(ctx context.Context, text string, handler bindings.Handler) error {
+	ev, err := parseEventGridEvent(text)
+	if err != nil {
+		a.logger.Warnf("Skipping queue message that isn't a blob event: %v", err)
+		return nil
+	}
+
+	eventType := ev.EventType
+	if eventType == "" {
+		eventType = ev.Type
+	}
+	if eventType != blobCreatedEventType && eventType != blobDeletedEventType {
+		return nil
+	}
+	container, blobName, ok := parseBlobSubject(ev.Subject)
+	if !ok || container != a.metadata.ContainerName {
+		return nil
+	}
+
+	out := blobEvent{
+		EventType:     eventType,
+		BlobName:      blobName,
+		BlobURL:       ev.Data.URL,
+		ContentType:   ev.Data.ContentType,
+		ContentLength: ev.Data.ContentLength,
+		ETag:          ev.Data.ETag,
+		EventTime:     ev.EventTime,
+	}
+	if out.EventTime == nil {
+		out.EventTime = ev.Time
+	}
+	if eventType == blobCreatedEventType && a.metadata.EventsContentMaxSize > 0 && ev.Data.ContentLength <= a.metadata.EventsContentMaxSize {
+		out.Content, err = a.download(ctx, a.containerClient.NewBlockBlobClient(blobName))
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("error marshalling blob event: %w", err)
+	}
+	_, err = handler(ctx, &bindings.ReadResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataKeyEventType: eventType,
+			metadataKeyBlobName:  blobName,
+		},
+	})
+	return err
+}
+
+// parseEventGridEvent parses the event in a queue message, which Event Grid encodes with base64 unless configured
+// otherwise.
+func parseEventGridEvent(text string) (*eventGridEvent, error) {
+	data := bytes.TrimSpace([]byte(text))
+	if len(data) > 0 && data[0] != '{' {
+		decoded, err := b64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+
+	var ev eventGridEvent
+	err := json.Unmarshal(data, &ev)
+	if err != nil {
+		return nil, err
+	}
+	return &ev, nil
+}
+
+// parseBlobSubject returns the container and the blob of the subject of a blob event, in the format
+// "/blobServices/default/containers/<container>/blobs/<blob>".
+func parseBlobSubject(subject string) (container string, blobName string, ok bool) {
+	rest, ok := strings.CutPrefix(subject, "/blobServices/default/containers/")
+	if !ok {
+		return "", "", false
+	}
+	container, blobName, ok = strings.Cut(rest, "/blobs/")
+	return container, blobName, ok && blobName != ""
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+type fakeEventsQueue struct {
+	lock     sync.Mutex
+	messages []*azqueue.DequeuedMessage
+	deleted  []string
+}
+
+func (q *fakeEventsQueue) DequeueMessages(ctx context.Context, o *azqueue.DequeueMessagesOptions) (azqueue.DequeueMessagesResponse, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	res := azqueue.DequeueMessagesResponse{}
+	res.Messages = q.messages
+	q.messages = nil
+	return res, nil
+}
+
+func (q *fakeEventsQueue) DeleteMessage(ctx context.Context, messageID string, popReceipt string, o *azqueue.DeleteMessageOptions) (azqueue.DeleteMessageResponse, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.deleted = append(q.deleted, messageID)
+	return azqueue.DeleteMessageResponse{}, nil
+}
+
+func (q *fakeEventsQueue) enqueue(id string, text string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.messages = append(q.messages, &azqueue.DequeuedMessage{
+		MessageID:   ptr.Of(id),
+		PopReceipt:  ptr.Of("receipt"),
+		MessageText: ptr.Of(text),
+	})
+}
+
+func (q *fakeEventsQueue) deletedMessages() []string {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deleted
+}
+
+func TestParseEventGridEvent(t *testing.T) {
+	event := `{"eventType":"Microsoft.Storage.BlobCreated","subject":"/blobServices/default/containers/test/blobs/dir/foo.txt","data":{"url":"https://account.blob.core.windows.net/test/dir/foo.txt","contentLength":5}}`
+
+	for _, text := range []string{event, b64.StdEncoding.EncodeToString([]byte(event))} {
+		ev, err := parseEventGridEvent(text)
+		require.NoError(t, err)
+		assert.Equal(t, blobCreatedEventType, ev.EventType)
+		assert.Equal(t, int64(5), ev.Data.ContentLength)
+
+		container, blobName, ok := parseBlobSubject(ev.Subject)
+		require.True(t, ok)
+		assert.Equal(t, "test", container)
+		assert.Equal(t, "dir/foo.txt", blobName)
+	}
+
+	_, err := parseEventGridEvent("not an event")
+	require.Error(t, err)
+
+	_, _, ok := parseBlobSubject("/blobServices/default/containers/test")
+	assert.False(t, ok)
+}
+
+func TestReadEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	containerClient, err := container.NewClientFromConnectionString("DefaultEndpointsProtocol=http;AccountName=account;AccountKey=a2V5;BlobEndpoint="+srv.URL+"/account", "test", nil)
+	require.NoError(t, err)
+	queue := &fakeEventsQueue{}
+	blobStorage := NewAzureBlobStorage(logger.NewLogger("test")).(*AzureBlobStorage)
+	blobStorage.metadata = &storagecommon.BlobStorageMetadata{
+		EventsPollingInterval: 10 * time.Millisecond,
+		EventsContentMaxSize:  10,
+	}
+	blobStorage.metadata.ContainerName = "test"
+	blobStorage.containerClient = containerClient
+	blobStorage.eventsQueue = queue
+	defer blobStorage.Close()
+
+	queue.enqueue("created", b64.StdEncoding.EncodeToString([]byte(`{"eventType":"Microsoft.Storage.BlobCreated","subject":"/blobServices/default/containers/test/blobs/foo.txt","data":{"contentLength":5}}`)))
+	queue.enqueue("large", `{"type":"Microsoft.Storage.BlobCreated","subject":"/blobServices/default/containers/test/blobs/large.bin","data":{"contentLength":100}}`)
+	queue.enqueue("deleted", `{"eventType":"Microsoft.Storage.BlobDeleted","subject":"/blobServices/default/containers/test/blobs/foo.txt","data":{}}`)
+	queue.enqueue("other-container", `{"eventType":"Microsoft.Storage.BlobCreated","subject":"/blobServices/default/containers/other/blobs/foo.txt","data":{}}`)
+	queue.enqueue("failed", `{"eventType":"Microsoft.Storage.BlobDeleted","subject":"/blobServices/default/containers/test/blobs/fail","data":{}}`)
+
+	var (
+		lock   sync.Mutex
+		events []blobEvent
+	)
+	err = blobStorage.Read(t.Context(), func(ctx context.Context, msg *bindings.ReadResponse) ([]byte, error) {
+		if msg.Metadata[metadataKeyBlobName] == "fail" {
+			return nil, errors.New("failed")
+		}
+		var ev blobEvent
+		require.NoError(t, json.Unmarshal(msg.Data, &ev))
+		lock.Lock()
+		events = append(events, ev)
+		lock.Unlock()
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.ElementsMatch(c, []string{"created", "large", "deleted", "other-container"}, queue.deletedMessages())
+	}, time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, events, 3)
+	assert.Equal(t, blobEvent{EventType: blobCreatedEventType, BlobName: "foo.txt", ContentLength: 5, Content: []byte("hello")}, events[0])
+	assert.Equal(t, blobEvent{EventType: blobCreatedEventType, BlobName: "large.bin", ContentLength: 100}, events[1])
+	assert.Equal(t, blobEvent{EventType: blobDeletedEventType, BlobName: "foo.txt"}, events[2])
+}
+
+func TestReadWithoutEventsQueue(t *testing.T) {
+	blobStorage := NewAzureBlobStorage(logger.NewLogger("test")).(*AzureBlobStorage)
+	err := blobStorage.Read(t.Context(), func(ctx context.Context, msg *bindings.ReadResponse) ([]byte, error) {
+		return nil, nil
+	})
+	require.Error(t, err)
+}
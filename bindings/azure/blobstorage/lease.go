@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/lease"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	acquireLeaseOperation bindings.OperationKind = "acquireLease"
+	renewLeaseOperation   bindings.OperationKind = "renewLease"
+	releaseLeaseOperation bindings.OperationKind = "releaseLease"
+	breakLeaseOperation   bindings.OperationKind = "breakLease"
+)
+
+const (
+	// ID of the lease, returned in the response metadata of the acquireLease operation and required to renew or
+	// release it. When acquiring a lease, it's the proposed ID; a random one is generated by Azure if it's not set.
+	metadataKeyLeaseID = "leaseId"
+	// Duration of the acquired lease in seconds, between 15 and 60, or -1 for a lease that never expires.
+	// Defaults to 60.
+	metadataKeyLeaseDuration = "leaseDuration"
+	// Seconds the lease continues before it's broken by the breakLease operation, between 0 and 60.
+	// If it's not set, the lease breaks when its remaining time elapses, or immediately if it's infinite.
+	metadataKeyLeaseBreakPeriod = "leaseBreakPeriod"
+	// Defines the response metadata key for the seconds until the lease broken by the breakLease operation is broken.
+	metadataKeyLeaseTime = "leaseTime"
+
+	defaultLeaseDuration = 60
+)
+
+// leaseClient is implemented by the lease clients of blobs and containers.
+type leaseClient interface {
+	acquire(ctx context.Context, duration int32) (*string, error)
+	renew(ctx context.Context) (*string, error)
+	release(ctx context.Context) error
+	breakLease(ctx context.Context, breakPeriod *int32) (*int32, error)
+}
+
+type blobLeaseClient struct {
+	client *lease.BlobClient
+}
+
+func (c blobLeaseClient) acquire(ctx context.Context, duration int32) (*string, error) {
+	resp, err := c.client.AcquireLease(ctx, duration, nil)
+	return resp.LeaseID, err
+}
+
+func (c blobLeaseClient) renew(ctx context.Context) (*string, error) {
+	resp, err := c.client.RenewLease(ctx, nil)
+	return resp.LeaseID, err
+}
+
+func (c blobLeaseClient) release(ctx context.Context) error {
+	_, err := c.client.ReleaseLease(ctx, nil)
+	return err
+}
+
+func (c blobLeaseClient) breakLease(ctx context.Context, breakPeriod *int32) (*int32, error) {
+	resp, err := c.client.BreakLease(ctx, &lease.BlobBreakOptions{BreakPeriod: breakPeriod})
+	return resp.LeaseTime, err
+}
+
+type containerLeaseClient struct {
+	client *lease.ContainerClient
+}
+
+func (c containerLeaseClient) acquire(ctx context.Context, duration int32) (*string, error) {
+	resp, err := c.client.AcquireLease(ctx, duration, nil)
+	return resp.LeaseID, err
+}
+
+func (c containerLeaseClient) renew(ctx context.Context) (*string, error) {
+	resp, err := c.client.RenewLease(ctx, nil)
+	return resp.LeaseID, err
+}
+
+func (c containerLeaseClient) release(ctx context.Context) error {
+	_, err := c.client.ReleaseLease(ctx, nil)
+	return err
+}
+
+func (c containerLeaseClient) breakLease(ctx context.Context, breakPeriod *int32) (*int32, error) {
+	resp, err := c.client.BreakLease(ctx, &lease.ContainerBreakOptions{BreakPeriod: breakPeriod})
+	return resp.LeaseTime, err
+}
+
+// leaseClient returns the lease client of the blob referenced by the request, or of the container if the request
+// doesn't reference a blob.
+func (a *AzureBlobStorage) leaseClient(req *bindings.InvokeRequest) (leaseClient, error) {
+	var leaseID *string
+	if val := req.Metadata[metadataKeyLeaseID]; val != "" {
+		leaseID = &val
+	}
+
+	name := req.Metadata[metadataKeyBlobName]
+	if name == "" {
+		client, err := lease.NewContainerClient(a.containerClient, &lease.ContainerClientOptions{LeaseID: leaseID})
+		if err != nil {
+			return nil, fmt.Errorf("error creating container lease client: %w", err)
+		}
+		return containerLeaseClient{client: client}, nil
+	}
+
+	client, err := lease.NewBlobClient(a.containerClient.NewBlobClient(name), &lease.BlobClientOptions{LeaseID: leaseID})
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob lease client: %w", err)
+	}
+	return blobLeaseClient{client: client}, nil
+}
+
+func (a *AzureBlobStorage) handleLease(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if req.Operation != acquireLeaseOperation && req.Operation != breakLeaseOperation && req.Metadata[metadataKeyLeaseID] == "" {
+		return nil, fmt.Errorf("%s is a required attribute", metadataKeyLeaseID)
+	}
+
+	client, err := a.leaseClient(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		leaseID   *string
+		leaseTime *int32
+	)
+	switch req.Operation {
+	case acquireLeaseOperation:
+		var duration int32
+		duration, err = leaseDuration(req.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		leaseID, err = client.acquire(ctx, duration)
+	case renewLeaseOperation:
+		leaseID, err = client.renew(ctx)
+	case releaseLeaseOperation:
+		err = client.release(ctx)
+	case breakLeaseOperation:
+		var breakPeriod *int32
+		breakPeriod, err = leaseBreakPeriod(req.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		leaseTime, err = client.breakLease(ctx, breakPeriod)
+	}
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, errors.New("blob not found")
+		}
+		return nil, fmt.Errorf("error executing %s: %w", req.Operation, err)
+	}
+
+	metadata := map[string]string{}
+	if leaseID != nil {
+		metadata[metadataKeyLeaseID] = *leaseID
+	}
+	if leaseTime != nil {
+		metadata[metadataKeyLeaseTime] = strconv.FormatInt(int64(*leaseTime), 10)
+	}
+	return &bindings.InvokeResponse{
+		Metadata: metadata,
+	}, nil
+}
+
+func leaseDuration(md map[string]string) (int32, error) {
+	val, ok := md[metadataKeyLeaseDuration]
+	if !ok || val == "" {
+		return defaultLeaseDuration, nil
+	}
+	duration, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s: %w", metadataKeyLeaseDuration, err)
+	}
+	if duration != -1 && (duration < 15 || duration > 60) {
+		return 0, fmt.Errorf("invalid %s: must be between 15 and 60 seconds, or -1 for an infinite lease", metadataKeyLeaseDuration)
+	}
+	return int32(duration), nil
+}
+
+func leaseBreakPeriod(md map[string]string) (*int32, error) {
+	val, ok := md[metadataKeyLeaseBreakPeriod]
+	if !ok || val == "" {
+		return nil, nil
+	}
+	period, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", metadataKeyLeaseBreakPeriod, err)
+	}
+	if period < 0 || period > 60 {
+		return nil, fmt.Errorf("invalid %s: must be between 0 and 60 seconds", metadataKeyLeaseBreakPeriod)
+	}
+	return ptr.Of(int32(period)), nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	storagecommon "github.com/dapr/components-contrib/common/component/azure/blobstorage"
+	"github.com/dapr/kit/logger"
+)
+
+func TestLease(t *testing.T) {
+	const leaseID = "6e7c4d5a-4f8b-4f4e-9d4c-3f1a2b3c4d5e"
+
+	var (
+		lock     sync.Mutex
+		requests []*http.Request
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r)
+		switch r.Header.Get("x-ms-lease-action") {
+		case "acquire":
+			w.Header().Set("x-ms-lease-id", leaseID)
+			w.WriteHeader(http.StatusCreated)
+		case "renew":
+			w.Header().Set("x-ms-lease-id", r.Header.Get("x-ms-lease-id"))
+			w.WriteHeader(http.StatusOK)
+		case "break":
+			w.Header().Set("x-ms-lease-time", "10")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	containerClient, err := container.NewClientFromConnectionString("DefaultEndpointsProtocol=http;AccountName=account;AccountKey=a2V5;BlobEndpoint="+srv.URL+"/account", "test", nil)
+	require.NoError(t, err)
+	blobStorage := &AzureBlobStorage{
+		metadata:        &storagecommon.BlobStorageMetadata{},
+		containerClient: containerClient,
+		logger:          logger.NewLogger("test"),
+	}
+
+	t.Run("acquire blob lease", func(t *testing.T) {
+		requests = nil
+		res, err := blobStorage.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: acquireLeaseOperation,
+			Metadata: map[string]string{
+				metadataKeyBlobName:      "foo",
+				metadataKeyLeaseDuration: "30",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{metadataKeyLeaseID: leaseID}, res.Metadata)
+		require.Len(t, requests, 1)
+		require.Equal(t, "/account/test/foo", requests[0].URL.Path)
+		require.Equal(t, "30", requests[0].Header.Get("x-ms-lease-duration"))
+	})
+
+	t.Run("renew container lease", func(t *testing.T) {
+		requests = nil
+		res, err := blobStorage.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: renewLeaseOperation,
+			Metadata: map[string]string{
+				metadataKeyLeaseID: leaseID,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{metadataKeyLeaseID: leaseID}, res.Metadata)
+		require.Len(t, requests, 1)
+		require.Equal(t, "/account/test", requests[0].URL.Path)
+		require.Equal(t, "container", requests[0].URL.Query().Get("restype"))
+	})
+
+	t.Run("release requires the lease ID", func(t *testing.T) {
+		_, err := blobStorage.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: releaseLeaseOperation,
+			Metadata: map[string]string{
+				metadataKeyBlobName: "foo",
+			},
+		})
+		require.ErrorContains(t, err, metadataKeyLeaseID)
+	})
+
+	t.Run("break blob lease", func(t *testing.T) {
+		requests = nil
+		res, err := blobStorage.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: breakLeaseOperation,
+			Metadata: map[string]string{
+				metadataKeyBlobName:         "foo",
+				metadataKeyLeaseBreakPeriod: "10",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{metadataKeyLeaseTime: "10"}, res.Metadata)
+		require.Len(t, requests, 1)
+		require.Equal(t, "10", requests[0].Header.Get("x-ms-lease-break-period"))
+	})
+
+	t.Run("invalid durations", func(t *testing.T) {
+		for _, md := range []map[string]string{
+			{metadataKeyLeaseDuration: "5"},
+			{metadataKeyLeaseDuration: "forever"},
+			{metadataKeyLeaseBreakPeriod: "61"},
+		} {
+			op := acquireLeaseOperation
+			if _, ok := md[metadataKeyLeaseBreakPeriod]; ok {
+				op = breakLeaseOperation
+			}
+			_, err := blobStorage.Invoke(t.Context(), &bindings.InvokeRequest{Operation: op, Metadata: md})
+			require.Error(t, err, md)
+		}
+	})
+}
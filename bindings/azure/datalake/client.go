@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// Version of the Data Lake Storage Gen2 REST API.
+	// See: https://learn.microsoft.com/en-us/rest/api/storageservices/data-lake-storage-gen2
+	serviceVersion = "2023-11-03"
+	storageScope   = "https://storage.azure.com/.default"
+
+	headerVersion      = "x-ms-version"
+	headerDate         = "x-ms-date"
+	headerContinuation = "x-ms-continuation"
+	headerRenameSource = "x-ms-rename-source"
+
+	// Validity of the SAS tokens, which are signed for each request
+	sasValidity = 15 * time.Minute
+)
+
+// fileSystemClient sends the Data Lake Storage Gen2 REST requests for the paths of a file system.
+type fileSystemClient struct {
+	// URL of the file system, such as "https://account.dfs.core.windows.net/filesystem".
+	url      string
+	pipeline runtime.Pipeline
+}
+
+func newFileSystemClient(fileSystemURL string, authPolicy policy.Policy) *fileSystemClient {
+	options := policy.ClientOptions{
+		Telemetry: policy.TelemetryOptions{
+			ApplicationID: "dapr-" + logger.DaprVersion,
+		},
+	}
+	return &fileSystemClient{
+		url: fileSystemURL,
+		pipeline: runtime.NewPipeline("datalake", logger.DaprVersion, runtime.PipelineOptions{
+			PerRetry: []policy.Policy{authPolicy},
+		}, &options),
+	}
+}
+
+// do sends a request for the path, or for the file system if path is empty, and returns the response if its status
+// code is one of the expected ones; the caller must close its body.
+func (c *fileSystemClient) do(ctx context.Context, method string, path string, query url.Values, header http.Header, body []byte, statusCodes ...int) (*http.Response, error) {
+	u := c.url
+	if path != "" {
+		u += "/" + escapePath(path)
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := runtime.NewRequest(ctx, method, u)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Raw().Header[k] = v
+	}
+	req.Raw().Header.Set(headerVersion, serviceVersion)
+	if body != nil {
+		err = req.SetBody(streaming.NopCloser(bytes.NewReader(body)), "application/octet-stream")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, statusCodes...) {
+		defer resp.Body.Close()
+		return nil, runtime.NewResponseError(resp)
+	}
+	return resp, nil
+}
+
+// escapePath escapes the segments of a path, keeping its slashes.
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// hasErrorCode returns true if err is a response error of the service with one of the given codes.
+func hasErrorCode(err error, codes ...string) bool {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	return slices.Contains(codes, respErr.ErrorCode)
+}
+
+// isNotFound returns true if err is a response error of the service for a path or file system that doesn't exist.
+func isNotFound(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// sasPolicy authorizes requests with SAS tokens signed with the account key by the Blob Storage SDK, which are also
+// accepted by the Data Lake Storage endpoint: a token of the file system for its paths, and a token of the account to
+// create the file system.
+// See: https://learn.microsoft.com/en-us/rest/api/storageservices/delegate-access-with-shared-access-signature
+type sasPolicy struct {
+	fileSystem string
+	credential *sas.SharedKeyCredential
+}
+
+func newSASPolicy(accountName string, accountKey string, fileSystem string) (*sasPolicy, error) {
+	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, err
+	}
+	return &sasPolicy{
+		fileSystem: fileSystem,
+		credential: credential,
+	}, nil
+}
+
+func (p *sasPolicy) Do(req *policy.Request) (*http.Response, error) {
+	raw := req.Raw()
+	query := raw.URL.Query()
+	token, err := p.token(query.Get("resource") == "filesystem")
+	if err != nil {
+		return nil, fmt.Errorf("error signing SAS token: %w", err)
+	}
+
+	// The parameters of the token are replaced when the request is retried
+	sasQuery, err := url.ParseQuery(token)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(query, sasQuery)
+	raw.URL.RawQuery = query.Encode()
+
+	// The source of a rename is authorized with its own token
+	if source := raw.Header.Get(headerRenameSource); source != "" {
+		source, _, _ = strings.Cut(source, "?")
+		raw.Header.Set(headerRenameSource, source+"?"+token)
+	}
+
+	return req.Next()
+}
+
+func (p *sasPolicy) token(fileSystem bool) (string, error) {
+	expiry := time.Now().UTC().Add(sasValidity)
+
+	var (
+		params sas.QueryParameters
+		err    error
+	)
+	if fileSystem {
+		params, err = sas.AccountSignatureValues{
+			ExpiryTime:    expiry,
+			Permissions:   (&sas.AccountPermissions{Read: true, Create: true, Write: true}).String(),
+			ResourceTypes: (&sas.AccountResourceTypes{Container: true}).String(),
+		}.SignWithSharedKey(p.credential)
+	} else {
+		params, err = sas.BlobSignatureValues{
+			ExpiryTime:    expiry,
+			ContainerName: p.fileSystem,
+			Permissions: (&sas.ContainerPermissions{
+				Read: true, Add: true, Create: true, Write: true, Delete: true, List: true, Move: true,
+				Execute: true, ModifyOwnership: true, ModifyPermissions: true,
+			}).String(),
+		}.SignWithSharedKey(p.credential)
+	}
+	if err != nil {
+		return "", err
+	}
+	return params.Encode(), nil
+}
@@ -0,0 +1,615 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalake
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/google/uuid"
+
+	"github.com/dapr/components-contrib/bindings"
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitstrings "github.com/dapr/kit/strings"
+)
+
+const (
+	// Path of the file or directory relative to the file system.
+	metadataKeyPath = "path"
+	// New path of the file or directory renamed by the rename operation.
+	metadataKeyDestinationPath = "destinationPath"
+	// Offset in the file of the data appended by the append operation, or length of the file committed by the flush
+	// operation. The append operation defaults to the committed length of the file.
+	metadataKeyPosition = "position"
+	// Defines if the append operation commits the appended data. Defaults to true.
+	metadataKeyFlush = "flush"
+	// Defines if the delete operation deletes the content of directories. Defaults to false.
+	metadataKeyRecursive = "recursive"
+	// Defines if the getAccessControl operation returns user principal names instead of object IDs. Defaults to false.
+	metadataKeyUPN = "upn"
+	// Access control set by the setAccessControl operation.
+	// See: https://learn.microsoft.com/en-us/azure/storage/blobs/data-lake-storage-access-control
+	metadataKeyOwner       = "owner"
+	metadataKeyGroup       = "group"
+	metadataKeyPermissions = "permissions"
+	metadataKeyACL         = "acl"
+	// Content type of the files created by the create operation.
+	metadataKeyContentType = "contentType"
+)
+
+const (
+	appendOperation           bindings.OperationKind = "append"
+	flushOperation            bindings.OperationKind = "flush"
+	createDirectoryOperation  bindings.OperationKind = "createDirectory"
+	renameOperation           bindings.OperationKind = "rename"
+	getAccessControlOperation bindings.OperationKind = "getAccessControl"
+	setAccessControlOperation bindings.OperationKind = "setAccessControl"
+)
+
+var ErrMissingPath = errors.New("path is a required attribute")
+
+// AzureDataLake allows managing the files and directories of an Azure Data Lake Storage Gen2 file system.
+type AzureDataLake struct {
+	metadata *dataLakeMetadata
+	client   *fileSystemClient
+
+	logger logger.Logger
+}
+
+type createResponse struct {
+	Path string `json:"path"`
+}
+
+type listPayload struct {
+	// Directory listed, defaults to the root of the file system.
+	Directory    string `json:"directory"`
+	Recursive    bool   `json:"recursive"`
+	MaxResults   int32  `json:"maxResults"`
+	Continuation string `json:"continuation"`
+	UPN          bool   `json:"upn"`
+}
+
+type listResponse struct {
+	Paths        []json.RawMessage `json:"paths"`
+	Continuation string            `json:"continuation,omitempty"`
+}
+
+type accessControlResponse struct {
+	Owner       string `json:"owner"`
+	Group       string `json:"group"`
+	Permissions string `json:"permissions"`
+	ACL         string `json:"acl"`
+}
+
+// NewAzureDataLake returns a new Azure Data Lake Storage Gen2 binding instance.
+func NewAzureDataLake(logger logger.Logger) bindings.OutputBinding {
+	return &AzureDataLake{logger: logger}
+}
+
+// Init performs metadata parsing.
+func (a *AzureDataLake) Init(ctx context.Context, metadata bindings.Metadata) error {
+	m, err := parseMetadata(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	a.metadata = m
+
+	azEnvSettings, err := azauth.NewEnvironmentSettings(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	var authPolicy policy.Policy
+	if m.AccountKey != "" {
+		authPolicy, err = newSASPolicy(m.AccountName, m.AccountKey, m.FileSystem)
+		if err != nil {
+			return fmt.Errorf("invalid shared key credentials with error: %w", err)
+		}
+	} else {
+		credential, tokenErr := azEnvSettings.GetTokenCredential()
+		if tokenErr != nil {
+			return fmt.Errorf("invalid token credentials with error: %w", tokenErr)
+		}
+		authPolicy = runtime.NewBearerTokenPolicy(credential, []string{storageScope}, nil)
+	}
+	a.client = newFileSystemClient(m.fileSystemURL(azEnvSettings), authPolicy)
+
+	if !m.DisableEntityManagement {
+		createCtx, createCancel := context.WithTimeout(ctx, 2*time.Minute)
+		resp, err := a.client.do(createCtx, http.MethodPut, "", url.Values{"resource": {"filesystem"}}, nil, nil, http.StatusCreated)
+		createCancel()
+		if err != nil && !hasErrorCode(err, "FilesystemAlreadyExists") {
+			return fmt.Errorf("error creating file system %s: %w", m.FileSystem, err)
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	return nil
+}
+
+func (a *AzureDataLake) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{
+		bindings.CreateOperation,
+		bindings.GetOperation,
+		bindings.DeleteOperation,
+		bindings.ListOperation,
+		appendOperation,
+		flushOperation,
+		createDirectoryOperation,
+		renameOperation,
+		getAccessControlOperation,
+		setAccessControlOperation,
+	}
+}
+
+func (a *AzureDataLake) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case bindings.CreateOperation:
+		return a.create(ctx, req)
+	case bindings.GetOperation:
+		return a.get(ctx, req)
+	case bindings.DeleteOperation:
+		return a.delete(ctx, req)
+	case bindings.ListOperation:
+		return a.list(ctx, req)
+	case appendOperation:
+		return a.append(ctx, req)
+	case flushOperation:
+		return a.flush(ctx, req)
+	case createDirectoryOperation:
+		return a.createDirectory(ctx, req)
+	case renameOperation:
+		return a.rename(ctx, req)
+	case getAccessControlOperation:
+		return a.getAccessControl(ctx, req)
+	case setAccessControlOperation:
+		return a.setAccessControl(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
+	}
+}
+
+// create creates or replaces a file with the data of the request, generating a path if none is set.
+func (a *AzureDataLake) create(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return nil, err
+		}
+		path = id.String()
+	}
+
+	data, err := a.requestData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.do(ctx, http.MethodPut, path, url.Values{"resource": {"file"}}, nil, nil, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("error creating file %s: %w", path, err)
+	}
+	resp.Body.Close()
+
+	if len(data) > 0 {
+		err = a.appendData(ctx, path, 0, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = a.flushData(ctx, path, int64(len(data)), req.Metadata[metadataKeyContentType])
+	if err != nil {
+		return nil, err
+	}
+
+	resData, err := json.Marshal(createResponse{Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling create response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: resData,
+		Metadata: map[string]string{
+			metadataKeyPath: path,
+		},
+	}, nil
+}
+
+// append appends the data of the request to an existing file, at the given position or at the end of its committed
+// data, and commits it unless flush is false; the response metadata contains the position of the end of the data.
+func (a *AzureDataLake) append(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+
+	data, err := a.requestData(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("the data to append is empty")
+	}
+
+	var position int64
+	if val, ok := req.Metadata[metadataKeyPosition]; ok && val != "" {
+		position, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || position < 0 {
+			return nil, fmt.Errorf("invalid %s %s: must be a non-negative integer", metadataKeyPosition, val)
+		}
+	} else {
+		resp, err := a.client.do(ctx, http.MethodHead, path, nil, nil, nil, http.StatusOK)
+		if err != nil {
+			if isNotFound(err) {
+				return nil, errors.New("file not found")
+			}
+			return nil, fmt.Errorf("error getting properties of file %s: %w", path, err)
+		}
+		resp.Body.Close()
+		position = resp.ContentLength
+	}
+
+	err = a.appendData(ctx, path, position, data)
+	if err != nil {
+		return nil, err
+	}
+	end := position + int64(len(data))
+
+	if val, ok := req.Metadata[metadataKeyFlush]; !ok || val == "" || kitstrings.IsTruthy(val) {
+		err = a.flushData(ctx, path, end, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			metadataKeyPath:     path,
+			metadataKeyPosition: strconv.FormatInt(end, 10),
+		},
+	}, nil
+}
+
+// flush commits the data appended to a file up to the given position.
+func (a *AzureDataLake) flush(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+	position, err := strconv.ParseInt(req.Metadata[metadataKeyPosition], 10, 64)
+	if err != nil || position < 0 {
+		return nil, fmt.Errorf("invalid %s %s: must be a non-negative integer", metadataKeyPosition, req.Metadata[metadataKeyPosition])
+	}
+
+	err = a.flushData(ctx, path, position, "")
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (a *AzureDataLake) appendData(ctx context.Context, path string, position int64, data []byte) error {
+	query := url.Values{
+		"action":   {"append"},
+		"position": {strconv.FormatInt(position, 10)},
+	}
+	resp, err := a.client.do(ctx, http.MethodPatch, path, query, nil, data, http.StatusAccepted)
+	if err != nil {
+		return fmt.Errorf("error appending to file %s: %w", path, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (a *AzureDataLake) flushData(ctx context.Context, path string, position int64, contentType string) error {
+	query := url.Values{
+		"action":   {"flush"},
+		"position": {strconv.FormatInt(position, 10)},
+	}
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("x-ms-content-type", contentType)
+	}
+	resp, err := a.client.do(ctx, http.MethodPatch, path, query, header, nil, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("error flushing file %s: %w", path, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (a *AzureDataLake) get(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+
+	resp, err := a.client.do(ctx, http.MethodGet, path, nil, nil, nil, http.StatusOK)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("error reading file %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", path, err)
+	}
+
+	encodeBase64 := a.metadata.EncodeBase64
+	if val, ok := req.Metadata["encodeBase64"]; ok && val != "" {
+		encodeBase64 = kitstrings.IsTruthy(val)
+	}
+	if encodeBase64 {
+		data = []byte(b64.StdEncoding.EncodeToString(data))
+	}
+
+	return &bindings.InvokeResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataKeyPath: path,
+		},
+	}, nil
+}
+
+// delete deletes a file, or a directory; directories that aren't empty are only deleted if recursive is true.
+func (a *AzureDataLake) delete(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+
+	query := url.Values{}
+	if val, ok := req.Metadata[metadataKeyRecursive]; ok && val != "" {
+		query.Set("recursive", strconv.FormatBool(kitstrings.IsTruthy(val)))
+	}
+
+	// Deleting large directories is done in several requests, continuing where the previous one stopped
+	for {
+		resp, err := a.client.do(ctx, http.MethodDelete, path, query, nil, nil, http.StatusOK)
+		if err != nil {
+			if isNotFound(err) {
+				return nil, errors.New("path not found")
+			}
+			if hasErrorCode(err, "DirectoryNotEmpty") {
+				return nil, fmt.Errorf("directory %s is not empty; set %s to delete its content", path, metadataKeyRecursive)
+			}
+			return nil, fmt.Errorf("error deleting %s: %w", path, err)
+		}
+		resp.Body.Close()
+
+		continuation := resp.Header.Get(headerContinuation)
+		if continuation == "" {
+			return nil, nil
+		}
+		query.Set("continuation", continuation)
+	}
+}
+
+func (a *AzureDataLake) list(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	payload := listPayload{}
+	if req.Data != nil {
+		if err := json.Unmarshal(req.Data, &payload); err != nil {
+			return nil, fmt.Errorf("unable to parse list request: %w", err)
+		}
+	}
+
+	query := url.Values{
+		"resource":  {"filesystem"},
+		"recursive": {strconv.FormatBool(payload.Recursive)},
+	}
+	if payload.Directory != "" {
+		query.Set("directory", payload.Directory)
+	}
+	if payload.MaxResults > 0 {
+		query.Set("maxResults", strconv.FormatInt(int64(payload.MaxResults), 10))
+	}
+	if payload.Continuation != "" {
+		query.Set("continuation", payload.Continuation)
+	}
+	if payload.UPN {
+		query.Set("upn", "true")
+	}
+
+	resp, err := a.client.do(ctx, http.MethodGet, "", query, nil, nil, http.StatusOK)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("directory not found")
+		}
+		return nil, fmt.Errorf("error listing paths: %w", err)
+	}
+	defer resp.Body.Close()
+
+	res := listResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding list response: %w", err)
+	}
+	if res.Paths == nil {
+		res.Paths = []json.RawMessage{}
+	}
+	res.Continuation = resp.Header.Get(headerContinuation)
+
+	resData, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling list response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: resData,
+	}, nil
+}
+
+func (a *AzureDataLake) createDirectory(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+
+	resp, err := a.client.do(ctx, http.MethodPut, path, url.Values{"resource": {"directory"}}, nil, nil, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("error creating directory %s: %w", path, err)
+	}
+	resp.Body.Close()
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			metadataKeyPath: path,
+		},
+	}, nil
+}
+
+// rename moves a file or a directory, with its content, to destinationPath in the same file system.
+func (a *AzureDataLake) rename(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		return nil, ErrMissingPath
+	}
+	destination := req.Metadata[metadataKeyDestinationPath]
+	if destination == "" {
+		return nil, fmt.Errorf("%s is a required attribute", metadataKeyDestinationPath)
+	}
+
+	header := http.Header{}
+	header.Set(headerRenameSource, "/"+url.PathEscape(a.metadata.FileSystem)+"/"+escapePath(path))
+	resp, err := a.client.do(ctx, http.MethodPut, destination, nil, header, nil, http.StatusCreated)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("path not found")
+		}
+		return nil, fmt.Errorf("error renaming %s to %s: %w", path, destination, err)
+	}
+	resp.Body.Close()
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			metadataKeyPath: destination,
+		},
+	}, nil
+}
+
+func (a *AzureDataLake) getAccessControl(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		// The root directory of the file system
+		path = "/"
+	}
+
+	query := url.Values{"action": {"getAccessControl"}}
+	if val, ok := req.Metadata[metadataKeyUPN]; ok && val != "" {
+		query.Set("upn", strconv.FormatBool(kitstrings.IsTruthy(val)))
+	}
+	resp, err := a.client.do(ctx, http.MethodHead, path, query, nil, nil, http.StatusOK)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("path not found")
+		}
+		return nil, fmt.Errorf("error getting access control of %s: %w", path, err)
+	}
+	resp.Body.Close()
+
+	resData, err := json.Marshal(accessControlResponse{
+		Owner:       resp.Header.Get("x-ms-owner"),
+		Group:       resp.Header.Get("x-ms-group"),
+		Permissions: resp.Header.Get("x-ms-permissions"),
+		ACL:         resp.Header.Get("x-ms-acl"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling access control response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: resData,
+	}, nil
+}
+
+// setAccessControl sets the owner, group, and permissions or ACL of a file or directory; the ACL replaces the
+// existing one, including the permissions.
+func (a *AzureDataLake) setAccessControl(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	path := req.Metadata[metadataKeyPath]
+	if path == "" {
+		path = "/"
+	}
+
+	header := http.Header{}
+	for key, h := range map[string]string{
+		metadataKeyOwner:       "x-ms-owner",
+		metadataKeyGroup:       "x-ms-group",
+		metadataKeyPermissions: "x-ms-permissions",
+		metadataKeyACL:         "x-ms-acl",
+	} {
+		if val := req.Metadata[key]; val != "" {
+			header.Set(h, val)
+		}
+	}
+	if len(header) == 0 {
+		return nil, fmt.Errorf("at least one of %s, %s, %s and %s is required", metadataKeyOwner, metadataKeyGroup, metadataKeyPermissions, metadataKeyACL)
+	}
+	if req.Metadata[metadataKeyPermissions] != "" && req.Metadata[metadataKeyACL] != "" {
+		return nil, fmt.Errorf("only one of %s and %s can be set", metadataKeyPermissions, metadataKeyACL)
+	}
+
+	resp, err := a.client.do(ctx, http.MethodPatch, path, url.Values{"action": {"setAccessControl"}}, header, nil, http.StatusOK)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("path not found")
+		}
+		return nil, fmt.Errorf("error setting access control of %s: %w", path, err)
+	}
+	resp.Body.Close()
+
+	return nil, nil
+}
+
+// requestData returns the data of the request, decoded from base64 if enabled.
+func (a *AzureDataLake) requestData(req *bindings.InvokeRequest) ([]byte, error) {
+	decodeBase64 := a.metadata.DecodeBase64
+	if val, ok := req.Metadata["decodeBase64"]; ok && val != "" {
+		decodeBase64 = kitstrings.IsTruthy(val)
+	}
+	if !decodeBase64 {
+		return req.Data, nil
+	}
+
+	data, err := b64.StdEncoding.DecodeString(commonutils.Unquote(req.Data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding base64 data: %w", err)
+	}
+	return data, nil
+}
+
+func (a *AzureDataLake) Close() error {
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (a *AzureDataLake) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := dataLakeMetadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.BindingType)
+	return
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalake
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+const testAccountKey = "a2V5"
+
+func TestParseMetadata(t *testing.T) {
+	m, err := parseMetadata(map[string]string{
+		"storageAccount": "account",
+		"accountKey":     testAccountKey,
+		"fileSystem":     "fs",
+		"endpoint":       "http://127.0.0.1:10000/",
+		"decodeBase64":   "true",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "account", m.AccountName)
+	assert.Equal(t, testAccountKey, m.AccountKey)
+	assert.Equal(t, "fs", m.FileSystem)
+	assert.True(t, m.DecodeBase64)
+	assert.Equal(t, "http://127.0.0.1:10000/account/fs", m.fileSystemURL(azauthSettings(t)))
+
+	m, err = parseMetadata(map[string]string{"accountName": "account", "containerName": "fs"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://account.dfs.core.windows.net/fs", m.fileSystemURL(azauthSettings(t)))
+
+	_, err = parseMetadata(map[string]string{"fileSystem": "fs"})
+	require.ErrorContains(t, err, "accountName")
+	_, err = parseMetadata(map[string]string{"accountName": "account"})
+	require.ErrorContains(t, err, "fileSystem")
+}
+
+func TestSASPolicy(t *testing.T) {
+	var signed []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signed = append(signed, r)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	p, err := newSASPolicy("account", testAccountKey, "fs")
+	require.NoError(t, err)
+	client := newFileSystemClient(srv.URL+"/account/fs", p)
+
+	for _, do := range []func() (*http.Response, error){
+		func() (*http.Response, error) {
+			return client.do(t.Context(), http.MethodPut, "", url.Values{"resource": {"filesystem"}}, nil, nil, http.StatusCreated)
+		},
+		func() (*http.Response, error) {
+			header := http.Header{}
+			header.Set(headerRenameSource, "/fs/dir/a.txt")
+			return client.do(t.Context(), http.MethodPut, "dir/b.txt", nil, header, nil, http.StatusCreated)
+		},
+	} {
+		resp, err := do()
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.Len(t, signed, 2)
+
+	// The file system is created with a token of the account
+	q := signed[0].URL.Query()
+	assert.Equal(t, "filesystem", q.Get("resource"))
+	assert.Equal(t, "b", q.Get("ss"))
+	assert.Equal(t, "c", q.Get("srt"))
+	assert.NotEmpty(t, q.Get("sig"))
+
+	// The paths are authorized with a token of the file system, also added to the source of renames
+	q = signed[1].URL.Query()
+	assert.Equal(t, "c", q.Get("sr"))
+	assert.Equal(t, "racwdlmeop", q.Get("sp"))
+	assert.NotEmpty(t, q.Get("sig"))
+	source, token, ok := strings.Cut(signed[1].Header.Get(headerRenameSource), "?")
+	require.True(t, ok)
+	assert.Equal(t, "/fs/dir/a.txt", source)
+	sourceQuery, err := url.ParseQuery(token)
+	require.NoError(t, err)
+	assert.Equal(t, q.Get("sig"), sourceQuery.Get("sig"))
+
+	_, err = newSASPolicy("account", "not base64!", "fs")
+	require.Error(t, err)
+}
+
+// fakeDataLake is an in-memory file system served with the Data Lake Storage Gen2 REST API.
+type fakeDataLake struct {
+	lock      sync.Mutex
+	files     map[string][]byte
+	appended  map[string][]byte
+	dirs      map[string]bool
+	acl       map[string]http.Header
+	requests  []string
+	fileSysOK bool
+}
+
+func (f *fakeDataLake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	q := r.URL.Query()
+	if q.Get("sig") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path+" "+q.Get("action")+q.Get("resource"))
+	path := strings.TrimPrefix(r.URL.Path, "/account/fs")
+	path = strings.TrimPrefix(path, "/")
+	notFound := func() {
+		w.Header().Set("x-ms-error-code", "PathNotFound")
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	switch {
+	case r.Method == http.MethodPut && q.Get("resource") == "filesystem":
+		if f.fileSysOK {
+			w.Header().Set("x-ms-error-code", "FilesystemAlreadyExists")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.fileSysOK = true
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && q.Get("resource") == "file":
+		f.files[path] = []byte{}
+		f.appended[path] = nil
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && q.Get("resource") == "directory":
+		f.dirs[path] = true
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.Header.Get("x-ms-rename-source") != "":
+		source, _, _ := strings.Cut(strings.TrimPrefix(r.Header.Get("x-ms-rename-source"), "/fs/"), "?")
+		if data, ok := f.files[source]; ok {
+			f.files[path] = data
+			delete(f.files, source)
+		} else if f.dirs[source] {
+			f.dirs[path] = true
+			delete(f.dirs, source)
+		} else {
+			w.Header().Set("x-ms-error-code", "SourcePathNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPatch && q.Get("action") == "append":
+		if _, ok := f.files[path]; !ok {
+			notFound()
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		if q.Get("position") != strconv.Itoa(len(f.files[path])+len(f.appended[path])) {
+			w.Header().Set("x-ms-error-code", "InvalidFlushPosition")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.appended[path] = append(f.appended[path], data...)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch && q.Get("action") == "flush":
+		f.files[path] = append(f.files[path], f.appended[path]...)
+		f.appended[path] = nil
+		if q.Get("position") != strconv.Itoa(len(f.files[path])) {
+			w.Header().Set("x-ms-error-code", "InvalidFlushPosition")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	case r.Method == http.MethodPatch && q.Get("action") == "setAccessControl":
+		f.acl[path] = r.Header.Clone()
+	case r.Method == http.MethodHead && q.Get("action") == "getAccessControl":
+		h, ok := f.acl[path]
+		if !ok {
+			notFound()
+			return
+		}
+		for _, k := range []string{"x-ms-owner", "x-ms-group", "x-ms-permissions", "x-ms-acl"} {
+			w.Header().Set(k, h.Get(k))
+		}
+	case r.Method == http.MethodHead:
+		data, ok := f.files[path]
+		if !ok {
+			notFound()
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	case r.Method == http.MethodGet && q.Get("resource") == "filesystem":
+		paths := []map[string]string{}
+		for name := range f.dirs {
+			paths = append(paths, map[string]string{"name": name, "isDirectory": "true"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"paths": paths})
+	case r.Method == http.MethodGet:
+		data, ok := f.files[path]
+		if !ok {
+			notFound()
+			return
+		}
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		if f.dirs[path] {
+			if q.Get("recursive") != "true" {
+				w.Header().Set("x-ms-error-code", "DirectoryNotEmpty")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			delete(f.dirs, path)
+			return
+		}
+		if _, ok := f.files[path]; !ok {
+			notFound()
+			return
+		}
+		delete(f.files, path)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestDataLake(t *testing.T) {
+	fake := &fakeDataLake{
+		files:    map[string][]byte{},
+		appended: map[string][]byte{},
+		dirs:     map[string]bool{},
+		acl:      map[string]http.Header{},
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	dl := NewAzureDataLake(logger.NewLogger("test")).(*AzureDataLake)
+	props := map[string]string{
+		"accountName": "account",
+		"accountKey":  testAccountKey,
+		"fileSystem":  "fs",
+		"endpoint":    srv.URL,
+	}
+	require.NoError(t, dl.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: props}}))
+	// The file system already exists
+	require.NoError(t, dl.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: props}}))
+
+	invoke := func(op bindings.OperationKind, data string, md map[string]string) (*bindings.InvokeResponse, error) {
+		return dl.Invoke(t.Context(), &bindings.InvokeRequest{Operation: op, Data: []byte(data), Metadata: md})
+	}
+
+	t.Run("create, append and get a file", func(t *testing.T) {
+		res, err := invoke(bindings.CreateOperation, "hello", map[string]string{"path": "logs/a.txt"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"path":"logs/a.txt"}`, string(res.Data))
+
+		res, err = invoke(appendOperation, " world", map[string]string{"path": "logs/a.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "11", res.Metadata["position"])
+
+		// Appending without flushing doesn't commit the data
+		res, err = invoke(appendOperation, "!", map[string]string{"path": "logs/a.txt", "position": "11", "flush": "false"})
+		require.NoError(t, err)
+		assert.Equal(t, "12", res.Metadata["position"])
+		res, err = invoke(bindings.GetOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(res.Data))
+
+		_, err = invoke(flushOperation, "", map[string]string{"path": "logs/a.txt", "position": "12"})
+		require.NoError(t, err)
+		res, err = invoke(bindings.GetOperation, "", map[string]string{"path": "logs/a.txt", "encodeBase64": "true"})
+		require.NoError(t, err)
+		assert.Equal(t, b64.StdEncoding.EncodeToString([]byte("hello world!")), string(res.Data))
+	})
+
+	t.Run("create a file with a generated path", func(t *testing.T) {
+		res, err := invoke(bindings.CreateOperation, `"aGk="`, map[string]string{"decodeBase64": "true"})
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(fake.files[res.Metadata["path"]]))
+	})
+
+	t.Run("append to a missing file", func(t *testing.T) {
+		_, err := invoke(appendOperation, "x", map[string]string{"path": "missing.txt"})
+		require.ErrorContains(t, err, "file not found")
+		_, err = invoke(appendOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.ErrorContains(t, err, "empty")
+		_, err = invoke(flushOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.ErrorContains(t, err, "position")
+	})
+
+	t.Run("create, rename, list and delete a directory", func(t *testing.T) {
+		_, err := invoke(createDirectoryOperation, "", map[string]string{"path": "raw/2026"})
+		require.NoError(t, err)
+		res, err := invoke(renameOperation, "", map[string]string{"path": "raw/2026", "destinationPath": "archive/2026"})
+		require.NoError(t, err)
+		assert.Equal(t, "archive/2026", res.Metadata["path"])
+
+		res, err = invoke(bindings.ListOperation, `{"recursive":true}`, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"paths":[{"name":"archive/2026","isDirectory":"true"}]}`, string(res.Data))
+
+		_, err = invoke(renameOperation, "", map[string]string{"path": "raw/2026", "destinationPath": "x"})
+		require.ErrorContains(t, err, "path not found")
+
+		_, err = invoke(bindings.DeleteOperation, "", map[string]string{"path": "archive/2026"})
+		require.ErrorContains(t, err, "recursive")
+		_, err = invoke(bindings.DeleteOperation, "", map[string]string{"path": "archive/2026", "recursive": "true"})
+		require.NoError(t, err)
+		assert.Empty(t, fake.dirs)
+	})
+
+	t.Run("set and get access control", func(t *testing.T) {
+		_, err := invoke(setAccessControlOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.ErrorContains(t, err, "at least one")
+		_, err = invoke(setAccessControlOperation, "", map[string]string{"path": "logs/a.txt", "permissions": "rwx------", "acl": "user::rwx"})
+		require.ErrorContains(t, err, "only one")
+
+		_, err = invoke(setAccessControlOperation, "", map[string]string{"path": "logs/a.txt", "owner": "user1", "acl": "user::rw-,group::r--,other::---"})
+		require.NoError(t, err)
+		res, err := invoke(getAccessControlOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"owner":"user1","group":"","permissions":"","acl":"user::rw-,group::r--,other::---"}`, string(res.Data))
+
+		_, err = invoke(getAccessControlOperation, "", map[string]string{"path": "missing"})
+		require.ErrorContains(t, err, "path not found")
+	})
+
+	t.Run("delete a file", func(t *testing.T) {
+		_, err := invoke(bindings.DeleteOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.NoError(t, err)
+		_, err = invoke(bindings.GetOperation, "", map[string]string{"path": "logs/a.txt"})
+		require.ErrorContains(t, err, "file not found")
+		_, err = invoke(bindings.DeleteOperation, "", map[string]string{})
+		require.ErrorIs(t, err, ErrMissingPath)
+	})
+}
+
+func azauthSettings(t *testing.T) azauth.EnvironmentSettings {
+	t.Helper()
+	s, err := azauth.NewEnvironmentSettings(map[string]string{})
+	require.NoError(t, err)
+	return s
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalake
+
+import (
+	"fmt"
+	"strings"
+
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+type dataLakeMetadata struct {
+	AccountName string `json:"accountName" mapstructure:"accountName" mdignore:"true"`
+	AccountKey  string `json:"accountKey" mapstructure:"accountKey" mdignore:"true"`
+	FileSystem  string `json:"fileSystem" mapstructure:"fileSystem" mapstructurealiases:"containerName"`
+	// Base URL of the service, such as "http://127.0.0.1:10000", used instead of the public Azure endpoint.
+	Endpoint                string `json:"endpoint" mapstructure:"endpoint"`
+	DecodeBase64            bool   `json:"decodeBase64,string" mapstructure:"decodeBase64"`
+	EncodeBase64            bool   `json:"encodeBase64,string" mapstructure:"encodeBase64"`
+	DisableEntityManagement bool   `json:"disableEntityManagement,string" mapstructure:"disableEntityManagement"`
+}
+
+func parseMetadata(meta map[string]string) (*dataLakeMetadata, error) {
+	m := dataLakeMetadata{}
+	err := kitmd.DecodeMetadata(meta, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if val, ok := contribMetadata.GetMetadataProperty(meta, azauth.MetadataKeys["StorageAccountName"]...); ok && val != "" {
+		m.AccountName = val
+	} else {
+		return nil, fmt.Errorf("missing or empty %s field from metadata", azauth.MetadataKeys["StorageAccountName"][0])
+	}
+
+	if val, ok := contribMetadata.GetMetadataProperty(meta, azauth.MetadataKeys["StorageAccountKey"]...); ok && val != "" {
+		m.AccountKey = val
+	}
+
+	if m.FileSystem == "" {
+		return nil, fmt.Errorf("missing or empty %s field from metadata", "fileSystem")
+	}
+
+	m.Endpoint = strings.TrimSuffix(m.Endpoint, "/")
+
+	return &m, nil
+}
+
+// fileSystemURL returns the URL of the file system: with a custom endpoint, the account is the first segment of the
+// path, as with the Azurite emulator.
+func (m *dataLakeMetadata) fileSystemURL(azEnvSettings azauth.EnvironmentSettings) string {
+	if m.Endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", m.Endpoint, m.AccountName, m.FileSystem)
+	}
+	return fmt.Sprintf("https://%s.dfs.%s/%s", m.AccountName, azEnvSettings.EndpointSuffix(azauth.ServiceAzureStorage), m.FileSystem)
+}
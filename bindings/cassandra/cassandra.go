@@ -0,0 +1,342 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+// List of operations.
+const (
+	execOperation  bindings.OperationKind = "exec"
+	queryOperation bindings.OperationKind = "query"
+	batchOperation bindings.OperationKind = "batch"
+	closeOperation bindings.OperationKind = "close"
+
+	commandCQLKey         = "cql"
+	commandParamsKey      = "params"
+	commandConsistencyKey = "consistency"
+	commandBatchTypeKey   = "batchType"
+)
+
+// Cassandra represents an Apache Cassandra (or ScyllaDB) output binding.
+type Cassandra struct {
+	logger  logger.Logger
+	session *gocql.Session
+	closed  atomic.Bool
+}
+
+// batchStatement is a statement of a batch operation, sent as an element of the request data.
+type batchStatement struct {
+	CQL    string          `json:"cql"`
+	Params json.RawMessage `json:"params"`
+}
+
+// NewCassandra returns a new Cassandra output binding.
+func NewCassandra(logger logger.Logger) bindings.OutputBinding {
+	return &Cassandra{
+		logger: logger,
+	}
+}
+
+// Init initializes the Cassandra binding.
+func (c *Cassandra) Init(_ context.Context, meta bindings.Metadata) error {
+	if c.closed.Load() {
+		return errors.New("cannot initialize a previously-closed component")
+	}
+
+	m := cassandraMetadata{}
+	err := m.InitWithMetadata(meta.Properties)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := m.clusterConfig()
+	if err != nil {
+		return fmt.Errorf("error creating cluster config: %w", err)
+	}
+
+	c.session, err = cluster.CreateSession()
+	if err != nil {
+		return fmt.Errorf("error creating session: %w", err)
+	}
+
+	return nil
+}
+
+// Operations returns list of operations supported by Cassandra binding.
+func (c *Cassandra) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{
+		execOperation,
+		queryOperation,
+		batchOperation,
+		closeOperation,
+	}
+}
+
+// Invoke handles all invoke operations.
+func (c *Cassandra) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if req == nil {
+		return nil, errors.New("invoke request required")
+	}
+
+	// We let the "close" operation here succeed even if the component has been closed already
+	if req.Operation == closeOperation {
+		return nil, c.Close()
+	}
+
+	if c.closed.Load() {
+		return nil, errors.New("component is closed")
+	}
+
+	var consistency *gocql.Consistency
+	if val := req.Metadata[commandConsistencyKey]; val != "" {
+		cons, err := parseConsistency(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata property %s: %w", commandConsistencyKey, err)
+		}
+		consistency = &cons
+	}
+
+	startTime := time.Now().UTC()
+	resp := &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			"operation":  string(req.Operation),
+			"start-time": startTime.Format(time.RFC3339Nano),
+		},
+	}
+
+	switch req.Operation { //nolint:exhaustive
+	case execOperation, queryOperation:
+		// Metadata property "cql" contains the statement to execute
+		cql := req.Metadata[commandCQLKey]
+		if cql == "" {
+			return nil, fmt.Errorf("required metadata not set: %s", commandCQLKey)
+		}
+		resp.Metadata["cql"] = cql
+
+		// Metadata property "params" contains JSON-encoded parameters, and it's optional
+		params, err := parseParams([]byte(req.Metadata[commandParamsKey]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata property %s: %w", commandParamsKey, err)
+		}
+
+		q := c.session.Query(cql, params...).WithContext(ctx)
+		if consistency != nil {
+			q = q.Consistency(*consistency)
+		}
+
+		if req.Operation == execOperation {
+			err = q.Exec()
+			if err != nil {
+				return nil, fmt.Errorf("error executing statement: %w", err)
+			}
+		} else {
+			resp.Data, err = query(q)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case batchOperation:
+		batchType, err := parseBatchType(req.Metadata[commandBatchTypeKey])
+		if err != nil {
+			return nil, err
+		}
+		statements, err := parseBatch(req.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		b := c.session.NewBatch(batchType).WithContext(ctx)
+		if consistency != nil {
+			b.SetConsistency(*consistency)
+		}
+		for i, stmt := range statements {
+			params, err := parseParams(stmt.Params)
+			if err != nil {
+				return nil, fmt.Errorf("invalid params of batch statement %d: %w", i, err)
+			}
+			b.Query(stmt.CQL, params...)
+		}
+
+		err = c.session.ExecuteBatch(b)
+		if err != nil {
+			return nil, fmt.Errorf("error executing batch: %w", err)
+		}
+		resp.Metadata["statements"] = strconv.Itoa(len(statements))
+
+	default:
+		return nil, fmt.Errorf(
+			"invalid operation type: %s. Expected %s, %s, %s, or %s",
+			req.Operation, execOperation, queryOperation, batchOperation, closeOperation,
+		)
+	}
+
+	endTime := time.Now().UTC()
+	resp.Metadata["end-time"] = endTime.Format(time.RFC3339Nano)
+	resp.Metadata["duration"] = endTime.Sub(startTime).String()
+
+	return resp, nil
+}
+
+// Close closes the Cassandra session.
+func (c *Cassandra) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		// If this failed, the component has already been closed
+		// We allow multiple calls to close
+		return nil
+	}
+
+	if c.session != nil {
+		c.session.Close()
+		c.session = nil
+	}
+
+	return nil
+}
+
+func query(q *gocql.Query) ([]byte, error) {
+	rows, err := q.Iter().SliceMap()
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	if rows == nil {
+		rows = make([]map[string]any, 0)
+	}
+
+	result, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing results: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseParams unserializes a JSON array of positional parameters.
+// Numbers are kept as cqlNumber so they can be converted to the type of the bound column.
+func parseParams(data []byte) ([]any, error) {
+	if len(bytes.TrimSpace(data)) == 0 || bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	var params []any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unserialize into an array: %w", err)
+	}
+	for i, p := range params {
+		if n, ok := p.(json.Number); ok {
+			params[i] = cqlNumber(n)
+		}
+	}
+	return params, nil
+}
+
+func parseBatch(data []byte) ([]batchStatement, error) {
+	var statements []batchStatement
+	err := json.Unmarshal(data, &statements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch data: failed to unserialize into an array of statements: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("invalid batch data: no statements")
+	}
+	for i, stmt := range statements {
+		if stmt.CQL == "" {
+			return nil, fmt.Errorf("invalid batch data: statement %d has no cql", i)
+		}
+	}
+	return statements, nil
+}
+
+func parseBatchType(val string) (gocql.BatchType, error) {
+	switch strings.ToLower(val) {
+	case "", "logged":
+		return gocql.LoggedBatch, nil
+	case "unlogged":
+		return gocql.UnloggedBatch, nil
+	case "counter":
+		return gocql.CounterBatch, nil
+	default:
+		return 0, fmt.Errorf("invalid metadata property %s: %s. Expected logged, unlogged, or counter", commandBatchTypeKey, val)
+	}
+}
+
+// cqlNumber is a JSON number parameter, marshaled according to the type of the column it is bound to.
+type cqlNumber json.Number
+
+func (n cqlNumber) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	s := string(n)
+	switch info.Type() { //nolint:exhaustive
+	case gocql.TypeFloat:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return nil, err
+		}
+		return gocql.Marshal(info, float32(f))
+	case gocql.TypeDouble:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return gocql.Marshal(info, f)
+	case gocql.TypeDecimal:
+		d, ok := new(inf.Dec).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("can not marshal %s into %s", s, info)
+		}
+		return gocql.Marshal(info, d)
+	case gocql.TypeVarint:
+		i, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("can not marshal %s into %s", s, info)
+		}
+		return gocql.Marshal(info, i)
+	case gocql.TypeInt, gocql.TypeBigInt, gocql.TypeSmallInt, gocql.TypeTinyInt, gocql.TypeCounter:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return gocql.Marshal(info, i)
+	default:
+		return gocql.Marshal(info, s)
+	}
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (c *Cassandra) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := cassandraMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/logger"
+)
+
+func TestMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{"hosts": "a,b"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, m.Hosts)
+		assert.Equal(t, defaultPort, m.Port)
+		assert.Equal(t, defaultProtoVersion, m.ProtoVersion)
+		assert.Equal(t, gocql.LocalQuorum, m.internalConsistency)
+		assert.Equal(t, defaultTimeout, m.Timeout)
+		assert.True(t, m.TokenAwareRouting)
+	})
+
+	t.Run("missing hosts", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid consistency", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{"hosts": "a", "consistency": "Most"})
+		require.Error(t, err)
+	})
+
+	t.Run("client cert without key", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{"hosts": "a", "enableTLS": "true", "clientCert": "cert"})
+		require.Error(t, err)
+	})
+
+	t.Run("certificates without TLS", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{"hosts": "a", "caCert": "cert"})
+		require.Error(t, err)
+	})
+}
+
+func TestClusterConfig(t *testing.T) {
+	t.Run("routing and auth", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{
+			"hosts":       "a",
+			"keyspace":    "orders",
+			"username":    "user",
+			"password":    "pass",
+			"consistency": "Quorum",
+			"localDC":     "dc1",
+			"timeout":     "5s",
+		})
+		require.NoError(t, err)
+
+		cluster, err := m.clusterConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "orders", cluster.Keyspace)
+		assert.Equal(t, gocql.Quorum, cluster.Consistency)
+		assert.Equal(t, 5*time.Second, cluster.Timeout)
+		assert.Equal(t, gocql.PasswordAuthenticator{Username: "user", Password: "pass"}, cluster.Authenticator)
+		assert.NotNil(t, cluster.PoolConfig.HostSelectionPolicy)
+		assert.Nil(t, cluster.SslOpts)
+	})
+
+	t.Run("TLS with client certificate", func(t *testing.T) {
+		certPEM, keyPEM := generateCertificate(t)
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{
+			"hosts":                  "a",
+			"enableTLS":              "true",
+			"enableHostVerification": "true",
+			"caCert":                 certPEM,
+			"clientCert":             certPEM,
+			"clientKey":              keyPEM,
+		})
+		require.NoError(t, err)
+
+		cluster, err := m.clusterConfig()
+		require.NoError(t, err)
+		require.NotNil(t, cluster.SslOpts)
+		assert.True(t, cluster.SslOpts.EnableHostVerification)
+		assert.False(t, cluster.SslOpts.Config.InsecureSkipVerify)
+		assert.NotNil(t, cluster.SslOpts.Config.RootCAs)
+		assert.Len(t, cluster.SslOpts.Config.Certificates, 1)
+	})
+
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		m := cassandraMetadata{}
+		err := m.InitWithMetadata(map[string]string{"hosts": "a", "enableTLS": "true", "caCert": "not a cert"})
+		require.NoError(t, err)
+
+		_, err = m.clusterConfig()
+		require.Error(t, err)
+	})
+}
+
+func TestParseParams(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		params, err := parseParams(nil)
+		require.NoError(t, err)
+		assert.Nil(t, params)
+	})
+
+	t.Run("numbers are kept as cqlNumber", func(t *testing.T) {
+		params, err := parseParams([]byte(`["a", 1, 2.5, true, null]`))
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", cqlNumber("1"), cqlNumber("2.5"), true, nil}, params)
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		_, err := parseParams([]byte(`{"a": 1}`))
+		require.Error(t, err)
+	})
+}
+
+func TestCQLNumber(t *testing.T) {
+	tests := []struct {
+		typ   gocql.Type
+		value string
+		exp   any
+	}{
+		{gocql.TypeInt, "42", 42},
+		{gocql.TypeBigInt, "-9000000000", int64(-9000000000)},
+		{gocql.TypeDouble, "2.5", 2.5},
+		{gocql.TypeFloat, "1.5", float32(1.5)},
+		{gocql.TypeVarint, "123456789012345678901234567890", func() *big.Int { i, _ := new(big.Int).SetString("123456789012345678901234567890", 10); return i }()},
+		{gocql.TypeText, "12", "12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			info := gocql.NewNativeType(4, tt.typ, "")
+			act, err := cqlNumber(tt.value).MarshalCQL(info)
+			require.NoError(t, err)
+			exp, err := gocql.Marshal(info, tt.exp)
+			require.NoError(t, err)
+			assert.Equal(t, exp, act)
+		})
+	}
+
+	t.Run("fraction into int", func(t *testing.T) {
+		_, err := cqlNumber("1.5").MarshalCQL(gocql.NewNativeType(4, gocql.TypeInt, ""))
+		require.Error(t, err)
+	})
+}
+
+func TestParseBatch(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		statements, err := parseBatch([]byte(`[{"cql": "INSERT INTO t (k) VALUES (?)", "params": [1]}, {"cql": "DELETE FROM t WHERE k = 2"}]`))
+		require.NoError(t, err)
+		require.Len(t, statements, 2)
+		assert.Equal(t, "DELETE FROM t WHERE k = 2", statements[1].CQL)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := parseBatch([]byte(`[]`))
+		require.Error(t, err)
+	})
+
+	t.Run("missing cql", func(t *testing.T) {
+		_, err := parseBatch([]byte(`[{"params": [1]}]`))
+		require.Error(t, err)
+	})
+
+	t.Run("batch types", func(t *testing.T) {
+		bt, err := parseBatchType("")
+		require.NoError(t, err)
+		assert.Equal(t, gocql.LoggedBatch, bt)
+		bt, err = parseBatchType("Unlogged")
+		require.NoError(t, err)
+		assert.Equal(t, gocql.UnloggedBatch, bt)
+		bt, err = parseBatchType("counter")
+		require.NoError(t, err)
+		assert.Equal(t, gocql.CounterBatch, bt)
+		_, err = parseBatchType("other")
+		require.Error(t, err)
+	})
+}
+
+func TestInvokeValidation(t *testing.T) {
+	c := NewCassandra(logger.NewLogger("test")).(*Cassandra)
+
+	t.Run("invalid operation", func(t *testing.T) {
+		_, err := c.Invoke(t.Context(), &bindings.InvokeRequest{Operation: "delete"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid consistency", func(t *testing.T) {
+		_, err := c.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: queryOperation,
+			Metadata:  map[string]string{commandCQLKey: "SELECT now() FROM system.local", commandConsistencyKey: "Most"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("missing cql", func(t *testing.T) {
+		_, err := c.Invoke(t.Context(), &bindings.InvokeRequest{Operation: execOperation})
+		require.Error(t, err)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		_, err := c.Invoke(t.Context(), &bindings.InvokeRequest{Operation: closeOperation})
+		require.NoError(t, err)
+		_, err = c.Invoke(t.Context(), &bindings.InvokeRequest{Operation: execOperation})
+		require.ErrorContains(t, err, "component is closed")
+	})
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cassandra"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultPort         = 9042
+	defaultProtoVersion = 4
+	defaultConsistency  = "LocalQuorum"
+	defaultTimeout      = 20 * time.Second
+)
+
+type cassandraMetadata struct {
+	// Hosts is the list of contact points of the cluster.
+	Hosts []string `mapstructure:"hosts"`
+
+	// Port is the CQL native transport port.
+	Port int `mapstructure:"port"`
+
+	// Keyspace is the default keyspace for statements that don't qualify table names.
+	Keyspace string `mapstructure:"keyspace"`
+
+	// Username and Password enable password authentication when both are set.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// ProtoVersion is the CQL protocol version.
+	ProtoVersion int `mapstructure:"protoVersion"`
+
+	// Consistency is the default consistency level of statements.
+	Consistency string `mapstructure:"consistency"`
+
+	// Timeout is the timeout for connecting and for each statement.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TokenAwareRouting sends statements directly to a replica owning the partition.
+	TokenAwareRouting bool `mapstructure:"tokenAwareRouting"`
+
+	// LocalDC restricts routing to the hosts of a data center, falling back to remote ones only if none is available.
+	LocalDC string `mapstructure:"localDC"`
+
+	// EnableTLS connects to the cluster over TLS.
+	EnableTLS bool `mapstructure:"enableTLS"`
+
+	// EnableHostVerification validates the server certificate against the host name.
+	EnableHostVerification bool `mapstructure:"enableHostVerification"`
+
+	// CACert, ClientCert and ClientKey are PEM-encoded certificates used for TLS.
+	CACert     string `mapstructure:"caCert"`
+	ClientCert string `mapstructure:"clientCert"`
+	ClientKey  string `mapstructure:"clientKey"`
+
+	internalConsistency gocql.Consistency `mapstructure:"-"`
+}
+
+func (m *cassandraMetadata) InitWithMetadata(meta map[string]string) error {
+	// Reset the object
+	*m = cassandraMetadata{
+		Port:              defaultPort,
+		ProtoVersion:      defaultProtoVersion,
+		Consistency:       defaultConsistency,
+		Timeout:           defaultTimeout,
+		TokenAwareRouting: true,
+	}
+
+	err := kitmd.DecodeMetadata(meta, m)
+	if err != nil {
+		return err
+	}
+
+	if len(m.Hosts) == 0 {
+		return errors.New("missing or empty hosts field from metadata")
+	}
+
+	m.internalConsistency, err = parseConsistency(m.Consistency)
+	if err != nil {
+		return err
+	}
+
+	if m.Timeout <= 0 {
+		return errors.New("invalid value for 'timeout': must be greater than 0")
+	}
+
+	if (m.ClientCert == "") != (m.ClientKey == "") {
+		return errors.New("clientCert and clientKey must be set together")
+	}
+	if !m.EnableTLS && (m.CACert != "" || m.ClientCert != "" || m.EnableHostVerification) {
+		return errors.New("caCert, clientCert, clientKey and enableHostVerification require enableTLS to be set")
+	}
+
+	return nil
+}
+
+// clusterConfig returns the gocql cluster configuration for the metadata.
+func (m *cassandraMetadata) clusterConfig() (*gocql.ClusterConfig, error) {
+	cluster := gocql.NewCluster(m.Hosts...)
+	cluster.Port = m.Port
+	cluster.Keyspace = m.Keyspace
+	cluster.ProtoVersion = m.ProtoVersion
+	cluster.Consistency = m.internalConsistency
+	cluster.Timeout = m.Timeout
+	cluster.ConnectTimeout = m.Timeout
+
+	if m.Username != "" && m.Password != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: m.Username, Password: m.Password}
+	}
+
+	var policy gocql.HostSelectionPolicy
+	if m.LocalDC != "" {
+		policy = gocql.DCAwareRoundRobinPolicy(m.LocalDC)
+	} else {
+		policy = gocql.RoundRobinHostPolicy()
+	}
+	if m.TokenAwareRouting {
+		policy = gocql.TokenAwareHostPolicy(policy)
+	}
+	cluster.PoolConfig.HostSelectionPolicy = policy
+
+	if m.EnableTLS {
+		tlsConfig, err := m.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		cluster.SslOpts = &gocql.SslOptions{
+			Config:                 tlsConfig,
+			EnableHostVerification: m.EnableHostVerification,
+		}
+	}
+
+	return cluster, nil
+}
+
+func (m *cassandraMetadata) tlsConfig() (*tls.Config, error) {
+	//nolint:gosec
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Server certificates are only verified when enableHostVerification is set, as in the state store
+		InsecureSkipVerify: !m.EnableHostVerification,
+	}
+
+	if m.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(m.CACert)) {
+			return nil, errors.New("invalid caCert: no PEM-encoded certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if m.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(m.ClientCert), []byte(m.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid clientCert or clientKey: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func parseConsistency(consistency string) (gocql.Consistency, error) {
+	switch consistency {
+	case "All":
+		return gocql.All, nil
+	case "One":
+		return gocql.One, nil
+	case "Two":
+		return gocql.Two, nil
+	case "Three":
+		return gocql.Three, nil
+	case "Quorum":
+		return gocql.Quorum, nil
+	case "LocalQuorum":
+		return gocql.LocalQuorum, nil
+	case "EachQuorum":
+		return gocql.EachQuorum, nil
+	case "LocalOne":
+		return gocql.LocalOne, nil
+	case "Any":
+		return gocql.Any, nil
+	}
+
+	return 0, fmt.Errorf("consistency mode %s not found", consistency)
+}
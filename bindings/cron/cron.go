@@ -15,6 +15,7 @@ package cron
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -33,18 +34,35 @@ import (
 
 // Binding represents Cron input binding.
 type Binding struct {
-	logger   logger.Logger
-	name     string
-	schedule string
-	parser   cron.Parser
-	clk      clock.Clock
-	closed   atomic.Bool
-	closeCh  chan struct{}
-	wg       sync.WaitGroup
+	logger    logger.Logger
+	name      string
+	schedules []schedule
+	parser    cron.Parser
+	clk       clock.Clock
+	closed    atomic.Bool
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
 }
 
 type metadata struct {
 	Schedule string
+	// JSON array of named schedules, each with its own payload, triggered by the same component.
+	Schedules string
+}
+
+// schedule is a cron expression and the payload delivered to the app when it fires.
+type schedule struct {
+	// Name of the schedule, sent in the "scheduleName" metadata. Empty for the "schedule" property.
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	// Time zone of the schedule, such as "America/New_York". Defaults to the local time zone.
+	TimeZone string `json:"timeZone"`
+	// Data delivered to the app: JSON strings are delivered as-is, other values as JSON.
+	Data     json.RawMessage   `json:"data"`
+	Metadata map[string]string `json:"metadata"`
+
+	location *time.Location
+	data     []byte
 }
 
 // NewCron returns a new Cron event input binding.
@@ -75,15 +93,71 @@ func (b *Binding) Init(ctx context.Context, meta bindings.Metadata) error {
 	if err != nil {
 		return err
 	}
-	if m.Schedule == "" {
+
+	var schedules []schedule
+	if m.Schedule != "" {
+		schedules = append(schedules, schedule{Schedule: m.Schedule})
+	}
+	if m.Schedules != "" {
+		var named []schedule
+		err = json.Unmarshal([]byte(m.Schedules), &named)
+		if err != nil {
+			return fmt.Errorf("invalid schedules: %w", err)
+		}
+		names := make(map[string]struct{}, len(named))
+		for _, s := range named {
+			if s.Name == "" {
+				return errors.New("invalid schedules: name not set")
+			}
+			if _, ok := names[s.Name]; ok {
+				return fmt.Errorf("invalid schedules: duplicate name '%s'", s.Name)
+			}
+			names[s.Name] = struct{}{}
+		}
+		schedules = append(schedules, named...)
+	}
+	if len(schedules) == 0 {
 		return errors.New("schedule not set")
 	}
-	_, err = b.parser.Parse(m.Schedule)
+
+	for i := range schedules {
+		err = b.parseSchedule(&schedules[i])
+		if err != nil {
+			return err
+		}
+	}
+	b.schedules = schedules
+
+	return nil
+}
+
+// parseSchedule validates the expression and time zone of a schedule, and decodes its data.
+func (b *Binding) parseSchedule(s *schedule) error {
+	if s.Schedule == "" {
+		return fmt.Errorf("schedule '%s': schedule not set", s.Name)
+	}
+	if s.TimeZone != "" {
+		loc, err := time.LoadLocation(s.TimeZone)
+		if err != nil {
+			return fmt.Errorf("invalid time zone '%s': %w", s.TimeZone, err)
+		}
+		s.location = loc
+		// The parser supports the time zone of the schedule as a prefix
+		s.Schedule = "CRON_TZ=" + s.TimeZone + " " + s.Schedule
+	}
+	_, err := b.parser.Parse(s.Schedule)
 	if err != nil {
-		return fmt.Errorf("invalid schedule format '%s': %w", m.Schedule, err)
+		return fmt.Errorf("invalid schedule format '%s': %w", s.Schedule, err)
 	}
-	b.schedule = m.Schedule
 
+	if len(s.Data) > 0 {
+		var str string
+		if json.Unmarshal(s.Data, &str) == nil {
+			s.data = []byte(str)
+		} else {
+			s.data = s.Data
+		}
+	}
 	return nil
 }
 
@@ -94,20 +168,37 @@ func (b *Binding) Read(ctx context.Context, handler bindings.Handler) error {
 	}
 
 	c := cron.New(cron.WithParser(b.parser), cron.WithClock(b.clk))
-	id, err := c.AddFunc(b.schedule, func() {
-		b.logger.Debugf("name: %s, schedule fired: %v", b.name, time.Now())
-		handler(ctx, &bindings.ReadResponse{
-			Metadata: map[string]string{
-				"timeZone":    c.Location().String(),
-				"readTimeUTC": time.Now().UTC().String(),
-			},
+	ids := make([]cron.EntryID, len(b.schedules))
+	for i, s := range b.schedules {
+		loc := c.Location()
+		if s.location != nil {
+			loc = s.location
+		}
+		var err error
+		ids[i], err = c.AddFunc(s.Schedule, func() {
+			b.logger.Debugf("name: %s, schedule %s fired: %v", b.name, s.Name, time.Now())
+			md := make(map[string]string, len(s.Metadata)+3)
+			for k, v := range s.Metadata {
+				md[k] = v
+			}
+			md["timeZone"] = loc.String()
+			md["readTimeUTC"] = time.Now().UTC().String()
+			if s.Name != "" {
+				md["scheduleName"] = s.Name
+			}
+			handler(ctx, &bindings.ReadResponse{
+				Data:     s.data,
+				Metadata: md,
+			})
 		})
-	})
-	if err != nil {
-		return fmt.Errorf("name: %s, error scheduling %s: %w", b.name, b.schedule, err)
+		if err != nil {
+			return fmt.Errorf("name: %s, error scheduling %s: %w", b.name, s.Schedule, err)
+		}
 	}
 	c.Start()
-	b.logger.Debugf("name: %s, next run: %v", b.name, time.Until(c.Entry(id).Next))
+	for i, s := range b.schedules {
+		b.logger.Debugf("name: %s, schedule: %s, next run: %v", b.name, s.Name, time.Until(c.Entry(ids[i]).Next))
+	}
 
 	b.wg.Add(1)
 	go func() {
@@ -117,7 +208,7 @@ func (b *Binding) Read(ctx context.Context, handler bindings.Handler) error {
 		case <-ctx.Done():
 		case <-b.closeCh:
 		}
-		b.logger.Debugf("name: %s, stopping schedule: %s", b.name, b.schedule)
+		b.logger.Debugf("name: %s, stopping schedules", b.name)
 		c.Stop()
 	}()
 
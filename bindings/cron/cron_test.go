@@ -17,6 +17,7 @@ import (
 	"context"
 	"os"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 	clocktesting "k8s.io/utils/clock/testing"
 
 	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 )
 
@@ -156,3 +158,90 @@ func TestCronReadWithContextCancellation(t *testing.T) {
 	require.NoErrorf(t, err, "error on read")
 	require.NoError(t, c.Close())
 }
+
+func TestCronInitSchedules(t *testing.T) {
+	tests := map[string]struct {
+		props         map[string]string
+		errorExpected bool
+	}{
+		"named schedules": {
+			props: map[string]string{"schedules": `[{"name":"a","schedule":"@every 1s"},{"name":"b","schedule":"0 9 * * *","timeZone":"Europe/Paris"}]`},
+		},
+		"named schedules with schedule": {
+			props: map[string]string{"schedule": "@every 1s", "schedules": `[{"name":"a","schedule":"@every 1s"}]`},
+		},
+		"invalid JSON": {
+			props:         map[string]string{"schedules": `{"name":"a"}`},
+			errorExpected: true,
+		},
+		"missing name": {
+			props:         map[string]string{"schedules": `[{"schedule":"@every 1s"}]`},
+			errorExpected: true,
+		},
+		"duplicate name": {
+			props:         map[string]string{"schedules": `[{"name":"a","schedule":"@every 1s"},{"name":"a","schedule":"@every 2s"}]`},
+			errorExpected: true,
+		},
+		"invalid schedule": {
+			props:         map[string]string{"schedules": `[{"name":"a","schedule":"INVALID_SCHEDULE"}]`},
+			errorExpected: true,
+		},
+		"invalid time zone": {
+			props:         map[string]string{"schedules": `[{"name":"a","schedule":"@every 1s","timeZone":"Nowhere/City"}]`},
+			errorExpected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := getNewCron()
+			err := c.Init(t.Context(), bindings.Metadata{Base: contribMetadata.Base{Properties: test.props}})
+			if test.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCronReadSchedules(t *testing.T) {
+	clk := clocktesting.NewFakeClock(time.Now())
+	c := getNewCronWithClock(clk)
+	require.NoError(t, c.Init(t.Context(), bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{
+		"schedules": `[
+			{"name":"fast","schedule":"@every 1s","data":"ping"},
+			{"name":"slow","schedule":"@every 2s","timeZone":"Asia/Tokyo","data":{"report":true},"metadata":{"kind":"report"}}
+		]`,
+	}}}))
+
+	var lock sync.Mutex
+	received := make(map[string][]*bindings.ReadResponse)
+	err := c.Read(t.Context(), func(ctx context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		received[res.Metadata["scheduleName"]] = append(received[res.Metadata["scheduleName"]], res)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		clk.Step(time.Second)
+		runtime.Gosched()
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Len(c, received["fast"], 2)
+		assert.Len(c, received["slow"], 1)
+	}, time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	assert.Equal(t, "ping", string(received["fast"][0].Data))
+	assert.JSONEq(t, `{"report":true}`, string(received["slow"][0].Data))
+	assert.Equal(t, "report", received["slow"][0].Metadata["kind"])
+	assert.Equal(t, "Asia/Tokyo", received["slow"][0].Metadata["timeZone"])
+	lock.Unlock()
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,520 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ftp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	ftpClient "github.com/jlaffaye/ftp"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	metadataRootPath    = "rootPath"
+	metadataFileName    = "fileName"
+	metadataNewFileName = "newFileName"
+
+	renameOperation bindings.OperationKind = "rename"
+
+	// TLS modes of the connection.
+	tlsModeNone     = "none"
+	tlsModeExplicit = "explicit"
+	tlsModeImplicit = "implicit"
+
+	defaultPort         = "21"
+	defaultImplicitPort = "990"
+	defaultTimeout      = 30 * time.Second
+	anonymousUser       = "anonymous"
+)
+
+// Ftp is a binding for file operations on FTP and FTPS servers.
+type Ftp struct {
+	metadata *ftpMetadata
+	logger   logger.Logger
+	dial     func() (*ftpClient.ServerConn, error)
+
+	// The connection is opened on the first request, and reopened when it's lost: FTP servers close idle
+	// connections, and a connection serves a single command at a time.
+	lock sync.Mutex
+	conn *ftpClient.ServerConn
+}
+
+// ftpMetadata defines the ftp metadata.
+type ftpMetadata struct {
+	RootPath string `json:"rootPath"`
+	// Address of the server, as host:port: the port defaults to 21, or 990 with implicit TLS.
+	Address string `json:"address"`
+	// Username and password of the account: anonymous login is used when no username is set.
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// TLS mode of the connection: none for plain FTP, explicit for FTPS upgrading the connection with AUTH TLS, or
+	// implicit for FTPS on a dedicated port.
+	TLSMode string `json:"tlsMode"`
+	// PEM-encoded certificates of the CAs trusted in addition to the system ones, for servers with private CAs.
+	CACert string `json:"caCert"`
+	// Name checked in the certificate of the server, when different from the host of the address.
+	ServerName         string `json:"serverName"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+	// Timeout of the connection to the server.
+	Timeout time.Duration `json:"timeout"`
+	// Disables the EPSV command, for servers behind NATs that only support PASV.
+	DisableEPSV bool `json:"disableEPSV"`
+}
+
+type createResponse struct {
+	FileName string `json:"fileName"`
+}
+
+type listResponse struct {
+	FileName    string    `json:"fileName"`
+	IsDirectory bool      `json:"isDirectory"`
+	Size        uint64    `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+}
+
+type renameResponse struct {
+	FileName string `json:"fileName"`
+}
+
+func NewFtp(logger logger.Logger) bindings.OutputBinding {
+	return &Ftp{
+		logger: logger,
+	}
+}
+
+func (ftp *Ftp) Init(_ context.Context, metadata bindings.Metadata) error {
+	m, err := ftp.parseMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	options := []ftpClient.DialOption{
+		ftpClient.DialWithTimeout(m.Timeout),
+		ftpClient.DialWithDisabledEPSV(m.DisableEPSV),
+	}
+	if m.TLSMode != tlsModeNone {
+		tlsConfig, err := m.tlsConfig()
+		if err != nil {
+			return fmt.Errorf("ftp binding error: %w", err)
+		}
+		if m.TLSMode == tlsModeExplicit {
+			options = append(options, ftpClient.DialWithExplicitTLS(tlsConfig))
+		} else {
+			options = append(options, ftpClient.DialWithTLS(tlsConfig))
+		}
+	}
+
+	ftp.metadata = m
+	ftp.dial = func() (*ftpClient.ServerConn, error) {
+		return dial(m, options)
+	}
+
+	// Check the address and the credentials
+	err = ftp.withConn(func(*ftpClient.ServerConn) error {
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	return nil
+}
+
+func (ftp *Ftp) parseMetadata(meta bindings.Metadata) (*ftpMetadata, error) {
+	m := ftpMetadata{
+		TLSMode: tlsModeNone,
+		Timeout: defaultTimeout,
+	}
+	err := kitmd.DecodeMetadata(meta.Properties, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Address == "" {
+		return nil, errors.New("ftp binding error: address is required")
+	}
+	m.TLSMode = strings.ToLower(m.TLSMode)
+	switch m.TLSMode {
+	case tlsModeNone, tlsModeExplicit, tlsModeImplicit:
+	default:
+		return nil, fmt.Errorf("ftp binding error: invalid tlsMode: %s", m.TLSMode)
+	}
+	if _, _, err = net.SplitHostPort(m.Address); err != nil {
+		port := defaultPort
+		if m.TLSMode == tlsModeImplicit {
+			port = defaultImplicitPort
+		}
+		m.Address = net.JoinHostPort(m.Address, port)
+	}
+	if m.Timeout <= 0 {
+		m.Timeout = defaultTimeout
+	}
+	if m.Username == "" {
+		m.Username = anonymousUser
+		if m.Password == "" {
+			m.Password = anonymousUser
+		}
+	}
+
+	return &m, nil
+}
+
+// tlsConfig returns the TLS configuration of FTPS connections.
+func (metadata ftpMetadata) tlsConfig() (*tls.Config, error) {
+	serverName := metadata.ServerName
+	if serverName == "" {
+		serverName, _, _ = net.SplitHostPort(metadata.Address)
+	}
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: metadata.InsecureSkipVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+		// Data connections resume the TLS session of the control connection, as most servers require it
+		ClientSessionCache: tls.NewLRUClientSessionCache(0),
+	}
+	if metadata.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(metadata.CACert)) {
+			return nil, errors.New("invalid caCert: no PEM certificate found")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func dial(metadata *ftpMetadata, options []ftpClient.DialOption) (*ftpClient.ServerConn, error) {
+	conn, err := ftpClient.Dial(metadata.Address, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", metadata.Address, err)
+	}
+	err = conn.Login(metadata.Username, metadata.Password)
+	if err != nil {
+		conn.Quit()
+		return nil, fmt.Errorf("error logging in as %s: %w", metadata.Username, err)
+	}
+	return conn, nil
+}
+
+// withConn runs fn with the connection to the server, connecting first if needed.
+// When fn fails for another reason than an error reply of the server, the connection is considered lost: it's
+// reopened and fn runs again.
+func (ftp *Ftp) withConn(fn func(conn *ftpClient.ServerConn) error) error {
+	ftp.lock.Lock()
+	defer ftp.lock.Unlock()
+
+	reconnected := false
+	for {
+		if ftp.conn == nil {
+			conn, err := ftp.dial()
+			if err != nil {
+				return err
+			}
+			ftp.conn = conn
+			reconnected = true
+		}
+
+		err := fn(ftp.conn)
+		var replyErr *textproto.Error
+		if err == nil || errors.As(err, &replyErr) || reconnected {
+			return err
+		}
+
+		ftp.logger.Debugf("FTP connection lost, reconnecting: %v", err)
+		ftp.conn.Quit()
+		ftp.conn = nil
+	}
+}
+
+func (ftp *Ftp) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{
+		bindings.CreateOperation,
+		bindings.GetOperation,
+		bindings.DeleteOperation,
+		bindings.ListOperation,
+		renameOperation,
+	}
+}
+
+func (ftp *Ftp) create(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata := ftp.metadata.mergeWithRequestMetadata(req)
+
+	filePath, err := metadata.getPath(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	dir, fileName := path.Split(filePath)
+
+	err = ftp.withConn(func(conn *ftpClient.ServerConn) error {
+		err := makeDirAll(conn, dir)
+		if err != nil {
+			return fmt.Errorf("error create dir %s: %w", dir, err)
+		}
+		err = conn.Stor(filePath, bytes.NewReader(req.Data))
+		if err != nil {
+			return fmt.Errorf("error create file %s: %w", filePath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(createResponse{
+		FileName: fileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error marshalling create response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+		Metadata: map[string]string{
+			metadataFileName: fileName,
+		},
+	}, nil
+}
+
+// makeDirAll creates a directory with its parents if they don't exist.
+// Servers reply with an error to the creation of existing directories, so error replies are ignored: if the directory
+// can't be created, storing the file fails.
+func makeDirAll(conn *ftpClient.ServerConn, dir string) error {
+	dir = path.Clean(dir)
+	if dir == "/" || dir == "." {
+		return nil
+	}
+
+	current := ""
+	if path.IsAbs(dir) {
+		current = "/"
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(dir, "/"), "/") {
+		current = path.Join(current, segment)
+		err := conn.MakeDir(current)
+		var replyErr *textproto.Error
+		if err != nil && !errors.As(err, &replyErr) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ftp *Ftp) list(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata := ftp.metadata.mergeWithRequestMetadata(req)
+
+	dirPath, err := metadata.getPath(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	var entries []*ftpClient.Entry
+	err = ftp.withConn(func(conn *ftpClient.ServerConn) error {
+		var err error
+		entries, err = conn.List(dirPath)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error read dir %s: %w", dirPath, err)
+	}
+
+	resp := make([]listResponse, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "." || entry.Name == ".." {
+			continue
+		}
+		resp = append(resp, listResponse{
+			FileName:    entry.Name,
+			IsDirectory: entry.Type == ftpClient.EntryTypeFolder,
+			Size:        entry.Size,
+			ModTime:     entry.Time,
+		})
+	}
+
+	jsonResponse, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: cannot marshal list to json: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
+func (ftp *Ftp) get(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata := ftp.metadata.mergeWithRequestMetadata(req)
+
+	filePath, err := metadata.getPath(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	var b []byte
+	err = ftp.withConn(func(conn *ftpClient.ServerConn) error {
+		resp, err := conn.Retr(filePath)
+		if err != nil {
+			return err
+		}
+		b, err = io.ReadAll(resp)
+		if err != nil {
+			resp.Close()
+			return err
+		}
+		return resp.Close()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error read file %s: %w", filePath, err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: b,
+	}, nil
+}
+
+func (ftp *Ftp) delete(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata := ftp.metadata.mergeWithRequestMetadata(req)
+
+	filePath, err := metadata.getPath(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: %w", err)
+	}
+
+	err = ftp.withConn(func(conn *ftpClient.ServerConn) error {
+		return conn.Delete(filePath)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error remove file %s: %w", filePath, err)
+	}
+
+	return nil, nil
+}
+
+// rename renames or moves the file named after the fileName request metadata to newFileName, also relative to the
+// root path.
+func (ftp *Ftp) rename(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata := ftp.metadata.mergeWithRequestMetadata(req)
+
+	newFileName, ok := kitmd.GetMetadataProperty(req.Metadata, metadataNewFileName)
+	if !ok || newFileName == "" {
+		return nil, errors.New("ftp binding error: required metadata newFileName missing")
+	}
+	fileName, ok := kitmd.GetMetadataProperty(req.Metadata, metadataFileName)
+	if !ok || fileName == "" {
+		return nil, errors.New("ftp binding error: required metadata fileName missing")
+	}
+	from := path.Join(metadata.RootPath, fileName)
+	to := path.Join(metadata.RootPath, newFileName)
+
+	err := ftp.withConn(func(conn *ftpClient.ServerConn) error {
+		return conn.Rename(from, to)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error rename file %s to %s: %w", from, to, err)
+	}
+
+	jsonResponse, err := json.Marshal(renameResponse{
+		FileName: newFileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ftp binding error: error marshalling rename response: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+		Metadata: map[string]string{
+			metadataFileName: newFileName,
+		},
+	}, nil
+}
+
+func (ftp *Ftp) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case bindings.CreateOperation:
+		return ftp.create(ctx, req)
+	case bindings.GetOperation:
+		return ftp.get(ctx, req)
+	case bindings.DeleteOperation:
+		return ftp.delete(ctx, req)
+	case bindings.ListOperation:
+		return ftp.list(ctx, req)
+	case renameOperation:
+		return ftp.rename(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
+	}
+}
+
+func (ftp *Ftp) Close() error {
+	ftp.lock.Lock()
+	defer ftp.lock.Unlock()
+
+	if ftp.conn == nil {
+		return nil
+	}
+	err := ftp.conn.Quit()
+	ftp.conn = nil
+	return err
+}
+
+func (metadata ftpMetadata) getPath(requestMetadata map[string]string) (filePath string, err error) {
+	if val, ok := kitmd.GetMetadataProperty(requestMetadata, metadataFileName); ok && val != "" {
+		filePath = path.Join(metadata.RootPath, val)
+	} else {
+		filePath = metadata.RootPath
+	}
+
+	if filePath == "" {
+		err = errors.New("required metadata rootPath or fileName missing")
+	}
+
+	return
+}
+
+// Helper to merge config and request metadata.
+func (metadata ftpMetadata) mergeWithRequestMetadata(req *bindings.InvokeRequest) ftpMetadata {
+	merged := metadata
+
+	if val, ok := kitmd.GetMetadataProperty(req.Metadata, metadataRootPath); ok && val != "" {
+		merged.RootPath = val
+	}
+
+	return merged
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (ftp *Ftp) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := ftpMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
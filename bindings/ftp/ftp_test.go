@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ftp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func TestParseMeta(t *testing.T) {
+	t.Run("Has correct metadata", func(t *testing.T) {
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{
+			"rootPath":           "path",
+			"address":            "ftp.example.com:2121",
+			"username":           "user",
+			"password":           "pass",
+			"tlsMode":            "Explicit",
+			"insecureSkipVerify": "true",
+			"timeout":            "10s",
+			"disableEPSV":        "true",
+		}
+		ftp := Ftp{}
+		meta, err := ftp.parseMetadata(m)
+
+		require.NoError(t, err)
+		assert.Equal(t, "path", meta.RootPath)
+		assert.Equal(t, "ftp.example.com:2121", meta.Address)
+		assert.Equal(t, "user", meta.Username)
+		assert.Equal(t, "pass", meta.Password)
+		assert.Equal(t, tlsModeExplicit, meta.TLSMode)
+		assert.True(t, meta.InsecureSkipVerify)
+		assert.Equal(t, 10*time.Second, meta.Timeout)
+		assert.True(t, meta.DisableEPSV)
+	})
+
+	t.Run("Has default values", func(t *testing.T) {
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{
+			"address": "ftp.example.com",
+		}
+		ftp := Ftp{}
+		meta, err := ftp.parseMetadata(m)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ftp.example.com:21", meta.Address)
+		assert.Equal(t, tlsModeNone, meta.TLSMode)
+		assert.Equal(t, defaultTimeout, meta.Timeout)
+		assert.Equal(t, anonymousUser, meta.Username)
+		assert.Equal(t, anonymousUser, meta.Password)
+	})
+
+	t.Run("Has default implicit TLS port", func(t *testing.T) {
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{
+			"address": "ftp.example.com",
+			"tlsMode": "implicit",
+		}
+		ftp := Ftp{}
+		meta, err := ftp.parseMetadata(m)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ftp.example.com:990", meta.Address)
+	})
+
+	t.Run("Has invalid metadata", func(t *testing.T) {
+		ftp := Ftp{}
+		_, err := ftp.parseMetadata(bindings.Metadata{})
+		require.ErrorContains(t, err, "address is required")
+
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{
+			"address": "ftp.example.com",
+			"tlsMode": "ssl",
+		}
+		_, err = ftp.parseMetadata(m)
+		require.ErrorContains(t, err, "invalid tlsMode: ssl")
+	})
+
+	t.Run("Has invalid CA certificate", func(t *testing.T) {
+		meta := ftpMetadata{Address: "ftp.example.com:21", CACert: "not a certificate"}
+		_, err := meta.tlsConfig()
+		require.ErrorContains(t, err, "invalid caCert")
+
+		meta.CACert = ""
+		config, err := meta.tlsConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "ftp.example.com", config.ServerName)
+	})
+}
+
+func TestMergeWithRequestMetadata(t *testing.T) {
+	meta := ftpMetadata{RootPath: "path"}
+	merged := meta.mergeWithRequestMetadata(&bindings.InvokeRequest{
+		Metadata: map[string]string{"rootPath": "changedpath"},
+	})
+	assert.Equal(t, "changedpath", merged.RootPath)
+	assert.Equal(t, "path", meta.RootPath)
+
+	filePath, err := merged.getPath(map[string]string{"fileName": "file.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, "changedpath/file.txt", filePath)
+
+	_, err = ftpMetadata{}.getPath(nil)
+	require.ErrorContains(t, err, "required metadata rootPath or fileName missing")
+}
+
+func TestOperations(t *testing.T) {
+	server := newFakeServer(t)
+	ftp := NewFtp(logger.NewLogger("test")).(*Ftp)
+	err := ftp.Init(context.Background(), bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{
+		"address":  server.addr,
+		"username": "user",
+		"password": "pass",
+		"rootPath": "/upload",
+	}}})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ftp.Close()
+	})
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		res, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.CreateOperation,
+			Data:      []byte("id,amount\n1,10\n"),
+			Metadata:  map[string]string{"fileName": "2026/10/orders.csv"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "orders.csv", res.Metadata["fileName"])
+		assert.JSONEq(t, `{"fileName":"orders.csv"}`, string(res.Data))
+		assert.Equal(t, "id,amount\n1,10\n", server.file("/upload/2026/10/orders.csv"))
+	})
+
+	t.Run("get", func(t *testing.T) {
+		res, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.GetOperation,
+			Metadata:  map[string]string{"fileName": "2026/10/orders.csv"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "id,amount\n1,10\n", string(res.Data))
+
+		_, err = ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.GetOperation,
+			Metadata:  map[string]string{"fileName": "missing.csv"},
+		})
+		require.ErrorContains(t, err, "error read file /upload/missing.csv")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		res, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.ListOperation,
+			Metadata:  map[string]string{"fileName": "2026"},
+		})
+		require.NoError(t, err)
+		var entries []listResponse
+		require.NoError(t, json.Unmarshal(res.Data, &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "10", entries[0].FileName)
+		assert.True(t, entries[0].IsDirectory)
+
+		res, err = ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.ListOperation,
+			Metadata:  map[string]string{"rootPath": "/upload/2026/10"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(res.Data, &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "orders.csv", entries[0].FileName)
+		assert.False(t, entries[0].IsDirectory)
+		assert.Equal(t, uint64(15), entries[0].Size)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		_, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: renameOperation,
+			Metadata:  map[string]string{"fileName": "2026/10/orders.csv"},
+		})
+		require.ErrorContains(t, err, "required metadata newFileName missing")
+
+		res, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: renameOperation,
+			Metadata:  map[string]string{"fileName": "2026/10/orders.csv", "newFileName": "2026/orders.csv"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "2026/orders.csv", res.Metadata["fileName"])
+		assert.Equal(t, "id,amount\n1,10\n", server.file("/upload/2026/orders.csv"))
+		assert.Empty(t, server.file("/upload/2026/10/orders.csv"))
+	})
+
+	t.Run("reconnect", func(t *testing.T) {
+		server.dropConnections()
+
+		res, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.GetOperation,
+			Metadata:  map[string]string{"fileName": "2026/orders.csv"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "id,amount\n1,10\n", string(res.Data))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.DeleteOperation,
+			Metadata:  map[string]string{"fileName": "2026/orders.csv"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, server.file("/upload/2026/orders.csv"))
+
+		_, err = ftp.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: bindings.DeleteOperation,
+			Metadata:  map[string]string{"fileName": "2026/orders.csv"},
+		})
+		require.ErrorContains(t, err, "error remove file /upload/2026/orders.csv")
+	})
+}
+
+func TestInitInvalidCredentials(t *testing.T) {
+	server := newFakeServer(t)
+	ftp := NewFtp(logger.NewLogger("test")).(*Ftp)
+	err := ftp.Init(context.Background(), bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{
+		"address":  server.addr,
+		"username": "user",
+		"password": "wrong",
+	}}})
+	require.ErrorContains(t, err, "error logging in as user")
+}
+
+// fakeServer is an in-memory FTP server, implementing the commands sent by the client of the binding.
+type fakeServer struct {
+	t     *testing.T
+	addr  string
+	lock  sync.Mutex
+	files map[string]string
+	dirs  map[string]bool
+	conns []net.Conn
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{
+		t:     t,
+		addr:  listener.Addr().String(),
+		files: map[string]string{},
+		dirs:  map[string]bool{"/": true, "/upload": true},
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		s.dropConnections()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.lock.Lock()
+			s.conns = append(s.conns, conn)
+			s.lock.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) file(name string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.files[name]
+}
+
+func (s *fakeServer) dropConnections() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...any) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var (
+		user     string
+		data     net.Listener
+		renaming string
+	)
+	defer func() {
+		if data != nil {
+			data.Close()
+		}
+	}()
+	// transfer accepts the data connection opened by the client and runs fn with it.
+	transfer := func(fn func(c net.Conn)) {
+		if data == nil {
+			reply("425 no data connection")
+			return
+		}
+		c, err := data.Accept()
+		data.Close()
+		data = nil
+		if err != nil {
+			reply("425 no data connection")
+			return
+		}
+		reply("150 opening data connection")
+		fn(c)
+		c.Close()
+		reply("226 transfer complete")
+	}
+
+	reply("220 fake FTP server")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		name := path.Join("/", arg)
+
+		s.lock.Lock()
+		_, isFile := s.files[name]
+		isDir := s.dirs[name]
+		s.lock.Unlock()
+
+		switch strings.ToUpper(cmd) {
+		case "USER":
+			user = arg
+			reply("331 password required")
+		case "PASS":
+			if user != "user" || arg != "pass" {
+				reply("530 login incorrect")
+			} else {
+				reply("230 logged in")
+			}
+		case "TYPE", "OPTS", "NOOP":
+			reply("200 ok")
+		case "EPSV":
+			data, err = net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(s.t, err)
+			reply("229 entering extended passive mode (|||%d|)", data.Addr().(*net.TCPAddr).Port)
+		case "LIST":
+			if !isDir {
+				reply("550 no such directory")
+				continue
+			}
+			transfer(func(c net.Conn) {
+				s.lock.Lock()
+				defer s.lock.Unlock()
+				var lines []string
+				for dir := range s.dirs {
+					if dir != name && path.Dir(dir) == name {
+						lines = append(lines, "drwxr-xr-x 1 owner group 0 Oct 15 10:00 "+path.Base(dir))
+					}
+				}
+				for file, content := range s.files {
+					if path.Dir(file) == name {
+						lines = append(lines, fmt.Sprintf("-rw-r--r-- 1 owner group %d Oct 15 10:00 %s", len(content), path.Base(file)))
+					}
+				}
+				sort.Strings(lines)
+				for _, l := range lines {
+					fmt.Fprintf(c, "%s\r\n", l)
+				}
+			})
+		case "RETR":
+			if !isFile {
+				reply("550 no such file")
+				continue
+			}
+			transfer(func(c net.Conn) {
+				io.WriteString(c, s.file(name))
+			})
+		case "STOR":
+			s.lock.Lock()
+			parent := s.dirs[path.Dir(name)]
+			s.lock.Unlock()
+			if !parent {
+				reply("550 no such directory")
+				continue
+			}
+			transfer(func(c net.Conn) {
+				content, _ := io.ReadAll(c)
+				s.lock.Lock()
+				s.files[name] = string(content)
+				s.lock.Unlock()
+			})
+		case "MKD":
+			if isDir || isFile {
+				reply("550 already exists")
+				continue
+			}
+			s.lock.Lock()
+			s.dirs[name] = true
+			s.lock.Unlock()
+			reply(`257 "%s" created`, name)
+		case "DELE":
+			if !isFile {
+				reply("550 no such file")
+				continue
+			}
+			s.lock.Lock()
+			delete(s.files, name)
+			s.lock.Unlock()
+			reply("250 deleted")
+		case "RNFR":
+			if !isFile {
+				reply("550 no such file")
+				continue
+			}
+			renaming = name
+			reply("350 ready for destination")
+		case "RNTO":
+			s.lock.Lock()
+			s.files[name] = s.files[renaming]
+			delete(s.files, renaming)
+			s.lock.Unlock()
+			reply("250 renamed")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
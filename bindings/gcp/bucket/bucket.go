@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,7 +39,7 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
-	"github.com/dapr/kit/strings"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 const (
@@ -56,13 +57,25 @@ const (
 	copyOperation    = "copy"
 	renameOperation  = "rename"
 	moveOperation    = "move"
+
+	metadataChunkSize    = "chunkSize"
+	metadataMethod       = "method"
+	metadataResumable    = "resumable"
+	signedURLOperation   = "signedURL"
+	maxSignedURLDuration = 7 * 24 * time.Hour
 )
 
 // GCPStorage allows saving data to GCP bucket storage.
 type GCPStorage struct {
 	metadata *gcpMetadata
 	client   *storage.Client
-	logger   logger.Logger
+	// Used to start resumable upload sessions, which the storage client doesn't expose.
+	uploadClient *http.Client
+	uploadURL    string
+	// Used to upload to resumable upload sessions: their URL authorizes the requests, so they're sent without
+	// credentials.
+	sessionClient *http.Client
+	logger        logger.Logger
 }
 
 type gcpMetadata struct {
@@ -82,6 +95,8 @@ type gcpMetadata struct {
 	DecodeBase64 bool   `json:"decodeBase64,string" mapstructure:"decodeBase64"`
 	EncodeBase64 bool   `json:"encodeBase64,string" mapstructure:"encodeBase64"`
 	SignTTL      string `json:"signTTL" mapstructure:"signTTL"  mdignore:"true"`
+	// Objects larger than ChunkSize bytes are uploaded with resumable uploads, in chunks retried individually.
+	ChunkSize int `json:"chunkSize,string,omitempty" mapstructure:"chunkSize"`
 }
 
 type listPayload struct {
@@ -94,6 +109,12 @@ type signResponse struct {
 	SignURL string `json:"signURL"`
 }
 
+type signedURLResponse struct {
+	SignURL string    `json:"signURL"`
+	Method  string    `json:"method"`
+	Expires time.Time `json:"expires"`
+}
+
 type createResponse struct {
 	ObjectURL string `json:"objectURL"`
 }
@@ -121,8 +142,16 @@ func (g *GCPStorage) Init(ctx context.Context, metadata bindings.Metadata) error
 		return err
 	}
 
+	uploadClient, uploadURL, err := newUploadClient(ctx, clientOptions)
+	if err != nil {
+		return err
+	}
+
 	g.metadata = m
 	g.client = client
+	g.uploadClient = uploadClient
+	g.uploadURL = uploadURL
+	g.sessionClient = &http.Client{}
 
 	return nil
 }
@@ -133,6 +162,9 @@ func (g *GCPStorage) parseMetadata(meta bindings.Metadata) (*gcpMetadata, error)
 	if err != nil {
 		return nil, err
 	}
+	if m.ChunkSize < 0 {
+		return nil, fmt.Errorf("invalid %s %d: must not be negative", metadataChunkSize, m.ChunkSize)
+	}
 
 	return &m, nil
 }
@@ -148,6 +180,10 @@ func (g *GCPStorage) Operations() []bindings.OperationKind {
 		copyOperation,
 		renameOperation,
 		moveOperation,
+		signedURLOperation,
+		createResumableUploadOperation,
+		uploadChunkOperation,
+		cancelResumableUploadOperation,
 	}
 }
 
@@ -173,6 +209,14 @@ func (g *GCPStorage) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*
 		return g.rename(ctx, req)
 	case moveOperation:
 		return g.move(ctx, req)
+	case signedURLOperation:
+		return g.signedURL(ctx, req)
+	case createResumableUploadOperation:
+		return g.createResumableUpload(ctx, req)
+	case uploadChunkOperation:
+		return g.uploadChunk(ctx, req)
+	case cancelResumableUploadOperation:
+		return g.cancelResumableUpload(ctx, req)
 	default:
 		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
 	}
@@ -204,6 +248,9 @@ func (g *GCPStorage) create(ctx context.Context, req *bindings.InvokeRequest) (*
 	}
 
 	h := g.client.Bucket(g.metadata.Bucket).Object(name).NewWriter(ctx)
+	if metadata.ChunkSize > 0 {
+		h.ChunkSize = metadata.ChunkSize
+	}
 	// Cannot do `defer h.Close()` as Close() will flush the bytes and need to have error handling.
 	if _, err = io.Copy(h, r); err != nil {
 		cerr := h.Close()
@@ -343,15 +390,22 @@ func (metadata gcpMetadata) mergeWithRequestMetadata(req *bindings.InvokeRequest
 	merged := metadata
 
 	if val, ok := req.Metadata[metadataDecodeBase64]; ok && val != "" {
-		merged.DecodeBase64 = strings.IsTruthy(val)
+		merged.DecodeBase64 = kitstrings.IsTruthy(val)
 	}
 
 	if val, ok := req.Metadata[metadataEncodeBase64]; ok && val != "" {
-		merged.EncodeBase64 = strings.IsTruthy(val)
+		merged.EncodeBase64 = kitstrings.IsTruthy(val)
 	}
 	if val, ok := req.Metadata[metadataSignTTL]; ok && val != "" {
 		merged.SignTTL = val
 	}
+	if val, ok := req.Metadata[metadataChunkSize]; ok && val != "" {
+		chunkSize, err := strconv.Atoi(val)
+		if err != nil || chunkSize < 0 {
+			return merged, fmt.Errorf("invalid %s %s: must be a non-negative integer", metadataChunkSize, val)
+		}
+		merged.ChunkSize = chunkSize
+	}
 	return merged, nil
 }
 
@@ -423,6 +477,70 @@ func (g *GCPStorage) signObject(bucket, object, ttl string) (string, error) {
 	return u, nil
 }
 
+// signedURL returns a V4 signed URL of an object for the given method, valid for signTTL. With contentType, requests
+// must have this content type; with resumable, the URL starts a resumable upload session with a POST request.
+func (g *GCPStorage) signedURL(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata, err := g.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return nil, fmt.Errorf("gcp binding error while merging metadata : %w", err)
+	}
+
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, errors.New("gcp bucket binding error: can't read key value")
+	}
+	if metadata.SignTTL == "" {
+		return nil, fmt.Errorf("gcp bucket binding error: required metadata '%s' missing", metadataSignTTL)
+	}
+	ttl, err := time.ParseDuration(metadata.SignTTL)
+	if err != nil || ttl <= 0 || ttl > maxSignedURLDuration {
+		return nil, fmt.Errorf("gcp bucket binding error: invalid %s %s: must be a duration up to %s", metadataSignTTL, metadata.SignTTL, maxSignedURLDuration)
+	}
+
+	opts := &storage.SignedURLOptions{
+		Scheme:      storage.SigningSchemeV4,
+		Method:      http.MethodGet,
+		Expires:     time.Now().Add(ttl),
+		ContentType: req.Metadata[metadataContentType],
+	}
+	if method := req.Metadata[metadataMethod]; method != "" {
+		opts.Method = strings.ToUpper(method)
+		switch opts.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete:
+		default:
+			return nil, fmt.Errorf("gcp bucket binding error: invalid %s %s; allowed: GET, HEAD, PUT, POST, DELETE", metadataMethod, method)
+		}
+	}
+	if kitstrings.IsTruthy(req.Metadata[metadataResumable]) {
+		if opts.Method != http.MethodPost {
+			return nil, fmt.Errorf("gcp bucket binding error: resumable signed URLs must use the %s method", http.MethodPost)
+		}
+		opts.Headers = []string{"x-goog-resumable:start"}
+	}
+	// Sign with the private key of the service account when set, rather than with the IAM credentials API
+	if g.metadata.PrivateKey != "" && g.metadata.ClientEmail != "" {
+		opts.GoogleAccessID = g.metadata.ClientEmail
+		opts.PrivateKey = []byte(g.metadata.PrivateKey)
+	}
+
+	u, err := g.client.Bucket(g.metadata.Bucket).SignedURL(key, opts)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while signing url: %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(signedURLResponse{
+		SignURL: u,
+		Method:  opts.Method,
+		Expires: opts.Expires.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while marshalling sign response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
 type objectData struct {
 	Name  string              `json:"name"`
 	Data  []byte              `json:"data"`
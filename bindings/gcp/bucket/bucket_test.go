@@ -14,13 +14,27 @@ limitations under the License.
 package bucket
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 )
 
@@ -374,3 +388,192 @@ func TestMoveOption(t *testing.T) {
 		assert.Equal(t, "gcp bucket binding error: required 'destinationBucket' missing", err.Error())
 	})
 }
+
+func TestSignedURLOption(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	gs := NewGCPStorage(logger.NewLogger("test")).(*GCPStorage)
+	err = gs.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"type":         "service_account",
+		"projectID":    "my_project_id",
+		"privateKeyID": "my_private_key_id",
+		"privateKey":   string(privateKey),
+		"clientEmail":  "my_email@my_project_id.iam.gserviceaccount.com",
+		"clientID":     "my_client_id",
+		"tokenURI":     "https://oauth2.googleapis.com/token",
+		"bucket":       "my_bucket",
+	}}})
+	require.NoError(t, err)
+	defer gs.Close()
+
+	t.Run("return error if signTTL is missing or invalid", func(t *testing.T) {
+		_, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{Operation: signedURLOperation, Metadata: map[string]string{"key": "foo"}})
+		require.ErrorContains(t, err, "signTTL")
+		_, err = gs.Invoke(t.Context(), &bindings.InvokeRequest{Operation: signedURLOperation, Metadata: map[string]string{"key": "foo", "signTTL": "8d"}})
+		require.ErrorContains(t, err, "signTTL")
+	})
+
+	t.Run("return error if method is invalid", func(t *testing.T) {
+		_, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{Operation: signedURLOperation, Metadata: map[string]string{"key": "foo", "signTTL": "1h", "method": "PATCH"}})
+		require.ErrorContains(t, err, "method")
+		_, err = gs.Invoke(t.Context(), &bindings.InvokeRequest{Operation: signedURLOperation, Metadata: map[string]string{"key": "foo", "signTTL": "1h", "resumable": "true"}})
+		require.ErrorContains(t, err, "POST")
+	})
+
+	t.Run("sign an upload URL", func(t *testing.T) {
+		res, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: signedURLOperation,
+			Metadata:  map[string]string{"key": "dir/foo.png", "signTTL": "15m", "method": "put", "contentType": "image/png"},
+		})
+		require.NoError(t, err)
+
+		var payload signedURLResponse
+		require.NoError(t, json.Unmarshal(res.Data, &payload))
+		assert.Equal(t, "PUT", payload.Method)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), payload.Expires, time.Minute)
+		u, err := url.Parse(payload.SignURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/my_bucket/dir/foo.png", u.Path)
+		assert.Equal(t, "GOOG4-RSA-SHA256", u.Query().Get("X-Goog-Algorithm"))
+		assert.Equal(t, "content-type;host", u.Query().Get("X-Goog-SignedHeaders"))
+	})
+
+	t.Run("sign a resumable upload URL", func(t *testing.T) {
+		res, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: signedURLOperation,
+			Metadata:  map[string]string{"key": "foo", "signTTL": "1h", "method": "POST", "resumable": "true"},
+		})
+		require.NoError(t, err)
+
+		var payload signedURLResponse
+		require.NoError(t, json.Unmarshal(res.Data, &payload))
+		u, err := url.Parse(payload.SignURL)
+		require.NoError(t, err)
+		assert.Equal(t, "host;x-goog-resumable", u.Query().Get("X-Goog-SignedHeaders"))
+	})
+}
+
+func TestResumableUpload(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests []string
+		uploaded []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Content-Range"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Query().Get("uploadType") == "resumable":
+			assert.Equal(t, "foo", r.URL.Query().Get("name"))
+			assert.Equal(t, "text/plain", r.Header.Get("X-Upload-Content-Type"))
+			w.Header().Set("Location", "http://"+r.Host+"/upload/storage/v1/b/my_bucket/o?uploadType=resumable&upload_id=1")
+		case r.Method == http.MethodPut && r.URL.Query().Get("upload_id") == "1":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			data, _ := io.ReadAll(r.Body)
+			uploaded = append(uploaded, data...)
+			if strings.HasSuffix(r.Header.Get("Content-Range"), "/*") {
+				if len(uploaded) > 0 {
+					w.Header().Set("Range", "bytes=0-"+strconv.Itoa(len(uploaded)-1))
+				}
+				w.WriteHeader(308)
+				return
+			}
+			w.Write([]byte(`{"name":"foo","size":"` + strconv.Itoa(len(uploaded)) + `"}`))
+		case r.Method == http.MethodDelete && r.URL.Query().Get("upload_id") == "1":
+			w.WriteHeader(499)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("STORAGE_EMULATOR_HOST", srv.URL)
+
+	gs := NewGCPStorage(logger.NewLogger("test")).(*GCPStorage)
+	err := gs.Init(t.Context(), bindings.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"bucket": "my_bucket",
+	}}})
+	require.NoError(t, err)
+	defer gs.Close()
+
+	res, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: createResumableUploadOperation,
+		Metadata:  map[string]string{"key": "foo", "contentType": "text/plain"},
+	})
+	require.NoError(t, err)
+	sessionURL := res.Metadata["sessionURL"]
+	assert.Equal(t, srv.URL+"/upload/storage/v1/b/my_bucket/o?uploadType=resumable&upload_id=1", sessionURL)
+
+	t.Run("return error if session is not a session of the bucket", func(t *testing.T) {
+		for _, u := range []string{
+			"https://attacker.example.com/upload/storage/v1/b/my_bucket/o?upload_id=1",
+			"https://" + strings.TrimPrefix(srv.URL, "http://") + "/upload/storage/v1/b/my_bucket/o?upload_id=1",
+			srv.URL + "/upload/storage/v1/b/other_bucket/o?upload_id=1",
+			srv.URL + "/session/1",
+		} {
+			_, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+				Operation: uploadChunkOperation,
+				Metadata:  map[string]string{"sessionURL": u},
+			})
+			require.ErrorContains(t, err, "is not a resumable upload session", u)
+
+			_, err = gs.Invoke(t.Context(), &bindings.InvokeRequest{
+				Operation: cancelResumableUploadOperation,
+				Metadata:  map[string]string{"sessionURL": u},
+			})
+			require.ErrorContains(t, err, "is not a resumable upload session", u)
+		}
+	})
+
+	t.Run("return error if chunk is not aligned", func(t *testing.T) {
+		_, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: uploadChunkOperation,
+			Data:      []byte("abc"),
+			Metadata:  map[string]string{"sessionURL": sessionURL},
+		})
+		require.ErrorContains(t, err, "multiple")
+	})
+
+	t.Run("query the committed size", func(t *testing.T) {
+		res, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: uploadChunkOperation,
+			Metadata:  map[string]string{"sessionURL": sessionURL},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"committedSize": "0", "complete": "false"}, res.Metadata)
+	})
+
+	t.Run("upload chunks", func(t *testing.T) {
+		chunk := bytes.Repeat([]byte("a"), resumableChunkAlignment)
+		res, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: uploadChunkOperation,
+			Data:      chunk,
+			Metadata:  map[string]string{"sessionURL": sessionURL},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "262144", res.Metadata["committedSize"])
+
+		res, err = gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: uploadChunkOperation,
+			Data:      []byte("end"),
+			Metadata:  map[string]string{"sessionURL": sessionURL, "offset": "262144", "final": "true"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "foo", "committedSize": "262147", "complete": "true"}, res.Metadata)
+		assert.JSONEq(t, `{"objectURL":"https://storage.googleapis.com/my_bucket/foo"}`, string(res.Data))
+		assert.Equal(t, []string{
+			"PUT /upload/storage/v1/b/my_bucket/o bytes 0-262143/*",
+			"PUT /upload/storage/v1/b/my_bucket/o bytes 262144-262146/262147",
+		}, requests[len(requests)-2:])
+	})
+
+	t.Run("cancel the upload", func(t *testing.T) {
+		_, err := gs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: cancelResumableUploadOperation,
+			Metadata:  map[string]string{"sessionURL": sessionURL},
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"bytes"
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+
+	"github.com/dapr/components-contrib/bindings"
+	kitstrings "github.com/dapr/kit/strings"
+)
+
+const (
+	// URL of the resumable upload session, returned by createResumableUpload and required by the other operations.
+	metadataSessionURL = "sessionURL"
+	// Offset in the object of the chunk uploaded by uploadChunk.
+	metadataOffset = "offset"
+	// Defines if the chunk uploaded by uploadChunk is the last one, completing the object.
+	metadataFinal = "final"
+	// Total size in bytes of the object uploaded in the session, if known when it's created.
+	metadataSize = "size"
+	// Content type of the object.
+	metadataContentType = "contentType"
+	// Defines the response metadata key for the number of bytes persisted by the session.
+	metadataCommittedSize = "committedSize"
+	// Defines the response metadata key set to true when the object is complete.
+	metadataComplete = "complete"
+
+	createResumableUploadOperation = "createResumableUpload"
+	uploadChunkOperation           = "uploadChunk"
+	cancelResumableUploadOperation = "cancelResumableUpload"
+
+	// Chunks of resumable uploads, except the last one, must be a multiple of 256 KiB.
+	resumableChunkAlignment = 256 * 1024
+	// Status code of incomplete resumable uploads.
+	statusResumeIncomplete = 308
+	// Status code returned when resumable uploads are canceled.
+	statusClientClosedRequest = 499
+)
+
+type resumableUploadResponse struct {
+	SessionURL string `json:"sessionURL"`
+}
+
+// newUploadClient returns the HTTP client used to start resumable upload sessions, and the base URL of the upload
+// API, honouring the storage emulator like the storage client.
+func newUploadClient(ctx context.Context, opts ...option.ClientOption) (*http.Client, string, error) {
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		client, _, err := htransport.NewClient(ctx, option.WithoutAuthentication())
+		if err != nil {
+			return nil, "", err
+		}
+		return client, strings.TrimSuffix(host, "/"), nil
+	}
+
+	opts = append([]option.ClientOption{option.WithScopes(storage.ScopeReadWrite)}, opts...)
+	client, _, err := htransport.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, "https://storage.googleapis.com", nil
+}
+
+// createResumableUpload starts a resumable upload session for an object and returns its URL. Chunks of the object
+// can then be uploaded to it with uploadChunk, or directly by clients, which don't need credentials.
+// See: https://cloud.google.com/storage/docs/performing-resumable-uploads
+func (g *GCPStorage) createResumableUpload(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key := req.Metadata[metadataKey]
+	if key == "" {
+		return nil, errors.New("gcp bucket binding error: can't read key value")
+	}
+
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s",
+		g.uploadURL, url.PathEscape(g.metadata.Bucket), url.QueryEscape(key))
+	body, err := json.Marshal(map[string]string{"name": key})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while creating resumable upload: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	if contentType := req.Metadata[metadataContentType]; contentType != "" {
+		httpReq.Header.Set("X-Upload-Content-Type", contentType)
+	}
+	if size := req.Metadata[metadataSize]; size != "" {
+		if _, err = strconv.ParseUint(size, 10, 64); err != nil {
+			return nil, fmt.Errorf("gcp bucket binding error: invalid %s %s", metadataSize, size)
+		}
+		httpReq.Header.Set("X-Upload-Content-Length", size)
+	}
+
+	res, err := g.uploadClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while creating resumable upload: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gcp bucket binding error while creating resumable upload: %w", uploadError(res))
+	}
+	sessionURL := res.Header.Get("Location")
+	if sessionURL == "" {
+		return nil, errors.New("gcp bucket binding error while creating resumable upload: no session URL returned")
+	}
+
+	jsonResponse, err := json.Marshal(resumableUploadResponse{
+		SessionURL: sessionURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while marshalling resumable upload response: %w", err)
+	}
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+		Metadata: map[string]string{
+			metadataKey:        key,
+			metadataSessionURL: sessionURL,
+		},
+	}, nil
+}
+
+// uploadChunk uploads the data of the request to a resumable upload session at the given offset. The last chunk must
+// have final set to true, and the others must be a multiple of 256 KiB. Without data and final, it only returns the
+// size committed by the session, so interrupted uploads can be resumed from there.
+func (g *GCPStorage) uploadChunk(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	metadata, err := g.metadata.mergeWithRequestMetadata(req)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while merging metadata : %w", err)
+	}
+
+	sessionURL, err := g.sessionURL(req)
+	if err != nil {
+		return nil, err
+	}
+	var offset int64
+	if val := req.Metadata[metadataOffset]; val != "" {
+		offset, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("gcp bucket binding error: invalid %s %s", metadataOffset, val)
+		}
+	}
+	final := kitstrings.IsTruthy(req.Metadata[metadataFinal])
+
+	data := req.Data
+	if metadata.DecodeBase64 {
+		data, err = decodeBase64Data(data)
+		if err != nil {
+			return nil, fmt.Errorf("gcp bucket binding error while decoding data: %w", err)
+		}
+	}
+	if !final && len(data)%resumableChunkAlignment != 0 {
+		return nil, fmt.Errorf("gcp bucket binding error: chunks other than the last one must be a multiple of %d bytes", resumableChunkAlignment)
+	}
+
+	var contentRange string
+	switch {
+	case len(data) == 0 && final:
+		contentRange = fmt.Sprintf("bytes */%d", offset)
+	case len(data) == 0:
+		contentRange = "bytes */*"
+	case final:
+		contentRange = fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(data))-1, offset+int64(len(data)))
+	default:
+		contentRange = fmt.Sprintf("bytes %d-%d/*", offset, offset+int64(len(data))-1)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while uploading chunk: %w", err)
+	}
+	httpReq.Header.Set("Content-Range", contentRange)
+
+	res, err := g.sessionClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while uploading chunk: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case statusResumeIncomplete:
+		// The Range header, such as "bytes=0-262143", is missing if nothing was persisted yet
+		var committed int64
+		if r := res.Header.Get("Range"); r != "" {
+			_, end, ok := strings.Cut(r, "-")
+			last, parseErr := strconv.ParseInt(end, 10, 64)
+			if !ok || parseErr != nil {
+				return nil, fmt.Errorf("gcp bucket binding error: invalid range %s returned by the resumable upload", r)
+			}
+			committed = last + 1
+		}
+		return &bindings.InvokeResponse{
+			Metadata: map[string]string{
+				metadataCommittedSize: strconv.FormatInt(committed, 10),
+				metadataComplete:      "false",
+			},
+		}, nil
+	case http.StatusOK, http.StatusCreated:
+		var attrs struct {
+			Name string `json:"name"`
+			Size string `json:"size"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&attrs)
+		if err != nil {
+			return nil, fmt.Errorf("gcp bucket binding error while decoding uploaded object: %w", err)
+		}
+		jsonResponse, err := json.Marshal(createResponse{
+			ObjectURL: fmt.Sprintf(objectURLBase, g.metadata.Bucket, attrs.Name),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gcp bucket binding error while marshalling the create response: %w", err)
+		}
+		return &bindings.InvokeResponse{
+			Data: jsonResponse,
+			Metadata: map[string]string{
+				metadataKey:           attrs.Name,
+				metadataCommittedSize: attrs.Size,
+				metadataComplete:      "true",
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("gcp bucket binding error while uploading chunk: %w", uploadError(res))
+	}
+}
+
+// cancelResumableUpload cancels a resumable upload session, deleting the chunks it persisted.
+func (g *GCPStorage) cancelResumableUpload(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	sessionURL, err := g.sessionURL(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, sessionURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while canceling resumable upload: %w", err)
+	}
+	res, err := g.sessionClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gcp bucket binding error while canceling resumable upload: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != statusClientClosedRequest && res.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("gcp bucket binding error while canceling resumable upload: %w", uploadError(res))
+	}
+
+	return nil, nil
+}
+
+// sessionURL returns the URL of the resumable upload session of a request, which must be a session of the bucket.
+func (g *GCPStorage) sessionURL(req *bindings.InvokeRequest) (string, error) {
+	sessionURL := req.Metadata[metadataSessionURL]
+	if sessionURL == "" {
+		return "", fmt.Errorf("gcp bucket binding error: required metadata '%s' missing", metadataSessionURL)
+	}
+
+	u, err := url.Parse(sessionURL)
+	if err != nil {
+		return "", fmt.Errorf("gcp bucket binding error: invalid %s: %w", metadataSessionURL, err)
+	}
+	base, err := url.Parse(g.uploadURL)
+	if err != nil {
+		return "", fmt.Errorf("gcp bucket binding error: invalid upload URL: %w", err)
+	}
+	prefix := "/upload/storage/v1/b/" + url.PathEscape(g.metadata.Bucket) + "/"
+	if u.Scheme != base.Scheme || u.Host != base.Host || u.User != nil || !strings.HasPrefix(u.EscapedPath(), prefix) {
+		return "", fmt.Errorf("gcp bucket binding error: %s is not a resumable upload session of bucket %s", metadataSessionURL, g.metadata.Bucket)
+	}
+	return sessionURL, nil
+}
+
+func uploadError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// decodeBase64Data decodes the base64 data of a request, which may be quoted.
+func decodeBase64Data(data []byte) ([]byte, error) {
+	if d, err := strconv.Unquote(string(data)); err == nil {
+		data = []byte(d)
+	}
+	return b64.StdEncoding.AppendDecode(nil, data)
+}
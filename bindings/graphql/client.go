@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// graphQLRequest is the body of a GraphQL request, also used as the payload of subscriptions.
+// See: https://graphql.github.io/graphql-over-http/draft/#sec-Request-Parameters
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   any            `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+func (e graphQLError) Error() string {
+	return "graphql: " + e.Message
+}
+
+// do sends a request to the endpoint, and unmarshals the data field of the response into the response object.
+// If the server returns errors, the first one is returned.
+func (gql *GraphQL) do(ctx context.Context, request *graphQLRequest, header http.Header, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("encode body: %w", err)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, gql.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header = header
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.Header.Set("Accept", "application/json; charset=utf-8")
+
+	res, err := gql.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading body: %w", err)
+	}
+
+	gr := graphQLResponse{
+		Data: response,
+	}
+	err = json.Unmarshal(resBody, &gr)
+	if err != nil {
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("graphql: server returned a non-200 status code: %d", res.StatusCode)
+		}
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if len(gr.Errors) > 0 {
+		// Return the first error
+		return gr.Errors[0]
+	}
+	return nil
+}
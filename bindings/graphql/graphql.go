@@ -18,13 +18,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	graphql "github.com/machinebox/graphql"
-
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
@@ -34,8 +35,10 @@ import (
 const (
 
 	// keys from request's metadata.
-	commandQuery    = "query"
-	commandMutation = "mutation"
+	commandQuery         = "query"
+	commandMutation      = "mutation"
+	commandVariables     = "variables"
+	commandOperationName = "operationName"
 
 	// keys from response's metadata.
 	respOpKey        = "operation"
@@ -49,25 +52,47 @@ const (
 
 type graphQLMetadata struct {
 	Endpoint string `mapstructure:"endpoint"`
+	// WebSocket endpoint of the subscription of the input binding.
+	// Default: the endpoint, with the ws or wss scheme
+	SubscriptionEndpoint string `mapstructure:"subscriptionEndpoint"`
+	// Subscription whose events the input binding delivers.
+	Subscription              string `mapstructure:"subscription"`
+	SubscriptionVariables     string `mapstructure:"subscriptionVariables"` // JSON object
+	SubscriptionOperationName string `mapstructure:"subscriptionOperationName"`
+	// Payload of the connection_init message, such as the authentication token expected by the server.
+	ConnectionInitPayload string `mapstructure:"connectionInitPayload"` // JSON object
+	// Time to wait before re-subscribing when the connection is lost or the subscription ends.
+	// Default: 5s
+	ReconnectInterval time.Duration `mapstructure:"reconnectInterval"`
 }
 
-// GraphQL represents GraphQL output bindings.
+// GraphQL represents GraphQL bindings.
 type GraphQL struct {
-	client *graphql.Client
-	header map[string]string
-	logger logger.Logger
+	endpoint   string
+	httpClient *http.Client
+	header     map[string]string
+	metadata   graphQLMetadata
+	logger     logger.Logger
+	closeCh    chan struct{}
+	closed     atomic.Bool
+	wg         sync.WaitGroup
 }
 
 // NewGraphQL returns a new GraphQL binding instance.
-func NewGraphQL(logger logger.Logger) bindings.OutputBinding {
-	return &GraphQL{logger: logger}
+func NewGraphQL(logger logger.Logger) bindings.InputOutputBinding {
+	return &GraphQL{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
 }
 
 // Init initializes the GraphQL binding.
 func (gql *GraphQL) Init(_ context.Context, meta bindings.Metadata) error {
 	gql.logger.Debug("GraphQL Error: Initializing GraphQL binding")
 
-	m := graphQLMetadata{}
+	m := graphQLMetadata{
+		ReconnectInterval: defaultReconnectInterval,
+	}
 	err := kitmd.DecodeMetadata(meta.Properties, &m)
 	if err != nil {
 		return err
@@ -76,11 +101,13 @@ func (gql *GraphQL) Init(_ context.Context, meta bindings.Metadata) error {
 	if m.Endpoint == "" {
 		return errors.New("GraphQL Error: Missing GraphQL URL")
 	}
+	if m.ReconnectInterval <= 0 {
+		m.ReconnectInterval = defaultReconnectInterval
+	}
 
-	// Connect to GraphQL Server
-	client := graphql.NewClient(m.Endpoint)
-
-	gql.client = client
+	gql.endpoint = m.Endpoint
+	gql.httpClient = http.DefaultClient
+	gql.metadata = m
 	gql.header = make(map[string]string)
 	for k, v := range meta.Properties {
 		if strings.HasPrefix(k, "header:") {
@@ -166,21 +193,36 @@ func (gql *GraphQL) runRequest(ctx context.Context, requestKey string, req *bind
 		return fmt.Errorf("GraphQL Error: command is not a %s", requestKey)
 	}
 
-	request := graphql.NewRequest(requestString)
+	request := &graphQLRequest{
+		Query:         requestString,
+		OperationName: req.Metadata[commandOperationName],
+	}
 
+	// Variables are a JSON object in the "variables" metadata, and strings in the "variable:" metadata, which take
+	// precedence.
+	if variables := req.Metadata[commandVariables]; variables != "" {
+		if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			return fmt.Errorf("GraphQL Error: %q is not a valid JSON object: %w", commandVariables, err)
+		}
+	}
+
+	header := make(http.Header)
 	for headerKey, headerValue := range gql.header {
-		request.Header.Set(headerKey, headerValue)
+		header.Set(headerKey, headerValue)
 	}
 
 	for k, v := range req.Metadata {
 		if strings.HasPrefix(k, "header:") {
-			request.Header.Set(strings.TrimPrefix(k, "header:"), v)
+			header.Set(strings.TrimPrefix(k, "header:"), v)
 		} else if strings.HasPrefix(k, "variable:") {
-			request.Var(strings.TrimPrefix(k, "variable:"), v)
+			if request.Variables == nil {
+				request.Variables = make(map[string]any)
+			}
+			request.Variables[strings.TrimPrefix(k, "variable:")] = v
 		}
 	}
 
-	if err := gql.client.Run(ctx, request, response); err != nil {
+	if err := gql.do(ctx, request, header, response); err != nil {
 		return fmt.Errorf("GraphQL Error: %w", err)
 	}
 
@@ -195,5 +237,9 @@ func (gql *GraphQL) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 }
 
 func (gql *GraphQL) Close() error {
+	if gql.closed.CompareAndSwap(false, true) {
+		close(gql.closeCh)
+	}
+	gql.wg.Wait()
 	return nil
 }
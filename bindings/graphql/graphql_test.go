@@ -18,8 +18,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -111,3 +114,145 @@ func TestGraphQlRequestHeadersAndVariables(t *testing.T) {
 	_, err = gql.Invoke(t.Context(), req)
 	require.NoError(t, err)
 }
+
+func TestGraphQlRequestStructuredVariablesAndOperationName(t *testing.T) {
+	var received map[string]any
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"hero": {"name": "R2-D2"}}}`))
+	}))
+	defer s.Close()
+
+	gql, err := InitBinding(s, nil)
+	require.NoError(t, err)
+
+	res, err := gql.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: "query",
+		Metadata: map[string]string{
+			"query":            `query Hero($episode: Episode, $first: Int) { hero(episode: $episode) { name } } query Other { other }`,
+			"operationName":    "Hero",
+			"variables":        `{"episode": "EMPIRE", "first": 2, "filter": {"tags": ["a"]}}`,
+			"variable:episode": "JEDI",
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hero": {"name": "R2-D2"}}`, string(res.Data))
+	assert.Equal(t, "Hero", received["operationName"])
+	assert.Equal(t, map[string]any{
+		"episode": "JEDI",
+		"first":   float64(2),
+		"filter":  map[string]any{"tags": []any{"a"}},
+	}, received["variables"])
+
+	_, err = gql.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: "query",
+		Metadata: map[string]string{
+			"query":     `query { hero { name } }`,
+			"variables": `["not", "an", "object"]`,
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestGraphQlRequestErrors(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": null, "errors": [{"message": "unknown field"}]}`))
+	}))
+	defer s.Close()
+
+	gql, err := InitBinding(s, nil)
+	require.NoError(t, err)
+
+	_, err = gql.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: "query",
+		Metadata:  map[string]string{"query": `query { unknown }`},
+	})
+	require.ErrorContains(t, err, "graphql: unknown field")
+}
+
+func TestGraphQlSubscription(t *testing.T) {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"graphql-transport-ws"}}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		assert.Equal(t, "graphql-transport-ws", conn.Subprotocol())
+
+		var msg map[string]any
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, "connection_init", msg["type"])
+		assert.Equal(t, map[string]any{"token": "abc"}, msg["payload"])
+		require.NoError(t, conn.WriteJSON(map[string]any{"type": "connection_ack"}))
+
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, "subscribe", msg["type"])
+		assert.Equal(t, map[string]any{
+			"query":         "subscription Reviews($episode: Episode) { reviewAdded(episode: $episode) { stars } }",
+			"variables":     map[string]any{"episode": "JEDI"},
+			"operationName": "Reviews",
+		}, msg["payload"])
+		id := msg["id"]
+
+		require.NoError(t, conn.WriteJSON(map[string]any{"type": "ping"}))
+		require.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, "pong", msg["type"])
+
+		for stars := range 2 {
+			require.NoError(t, conn.WriteJSON(map[string]any{
+				"id":      id,
+				"type":    "next",
+				"payload": map[string]any{"data": map[string]any{"reviewAdded": map[string]any{"stars": stars}}},
+			}))
+		}
+
+		// Wait for the client to close the connection
+		conn.ReadMessage()
+	}))
+	defer s.Close()
+
+	b, err := InitBinding(s, map[string]string{
+		"header:Authorization":      "Bearer token",
+		"subscription":              "subscription Reviews($episode: Episode) { reviewAdded(episode: $episode) { stars } }",
+		"subscriptionVariables":     `{"episode": "JEDI"}`,
+		"subscriptionOperationName": "Reviews",
+		"connectionInitPayload":     `{"token": "abc"}`,
+	})
+	require.NoError(t, err)
+	gql := b.(*GraphQL)
+
+	events := make(chan string, 2)
+	err = gql.Read(t.Context(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		events <- string(res.Data)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	for stars := range 2 {
+		select {
+		case event := <-events:
+			assert.JSONEq(t, `{"data": {"reviewAdded": {"stars": `+strconv.Itoa(stars)+`}}}`, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for subscription events")
+		}
+	}
+
+	require.NoError(t, gql.Close())
+}
+
+func TestGraphQlSubscriptionRequired(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	b, err := InitBinding(s, map[string]string{"subscription": "query { hero { name } }"})
+	require.NoError(t, err)
+	require.Error(t, b.(*GraphQL).Read(t.Context(), nil))
+
+	b, err = InitBinding(s, nil)
+	require.NoError(t, err)
+	require.Error(t, b.(*GraphQL).Read(t.Context(), nil))
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	defaultReconnectInterval = 5 * time.Second
+	connectionAckTimeout     = 10 * time.Second
+
+	// Subprotocol of the GraphQL over WebSocket protocol.
+	// See: https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+	subscriptionProtocol = "graphql-transport-ws"
+	subscriptionID       = "1"
+
+	// Types of the messages of the protocol.
+	messageConnectionInit = "connection_init"
+	messageConnectionAck  = "connection_ack"
+	messagePing           = "ping"
+	messagePong           = "pong"
+	messageSubscribe      = "subscribe"
+	messageNext           = "next"
+	messageError          = "error"
+	messageComplete       = "complete"
+)
+
+type subscriptionMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Read subscribes to the subscription of the metadata over WebSocket, and delivers its events to the handler: the
+// data of each event is the execution result sent by the server, with its data and errors fields.
+// The subscription is started again after the reconnect interval when the connection is lost or the server completes
+// it.
+func (gql *GraphQL) Read(ctx context.Context, handler bindings.Handler) error {
+	if gql.closed.Load() {
+		return errors.New("GraphQL Error: binding is closed")
+	}
+
+	request, endpoint, err := gql.subscriptionRequest()
+	if err != nil {
+		return err
+	}
+	var initPayload json.RawMessage
+	if gql.metadata.ConnectionInitPayload != "" {
+		if !json.Valid([]byte(gql.metadata.ConnectionInitPayload)) {
+			return errors.New("GraphQL Error: connectionInitPayload is not valid JSON")
+		}
+		initPayload = json.RawMessage(gql.metadata.ConnectionInitPayload)
+	}
+
+	// Close read context when binding is closed.
+	readCtx, cancel := context.WithCancel(ctx)
+	gql.wg.Add(2)
+	go func() {
+		defer gql.wg.Done()
+		defer cancel()
+
+		select {
+		case <-gql.closeCh:
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer gql.wg.Done()
+		for readCtx.Err() == nil {
+			err := gql.subscribe(readCtx, endpoint, request, initPayload, handler)
+			if readCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				gql.logger.Errorf("GraphQL Error: subscription failed, subscribing again in %v: %v", gql.metadata.ReconnectInterval, err)
+			} else {
+				gql.logger.Infof("GraphQL subscription completed by the server, subscribing again in %v", gql.metadata.ReconnectInterval)
+			}
+			select {
+			case <-time.After(gql.metadata.ReconnectInterval):
+			case <-readCtx.Done():
+			}
+		}
+	}()
+
+	return nil
+}
+
+// subscriptionRequest returns the request of the subscription and the WebSocket URL it's sent to.
+func (gql *GraphQL) subscriptionRequest() (*graphQLRequest, string, error) {
+	subscription := strings.TrimSpace(gql.metadata.Subscription)
+	if subscription == "" {
+		return nil, "", errors.New("GraphQL Error: subscription is required to use the binding as input")
+	}
+	if loc := regexp.MustCompile(`subscription\b`).FindStringIndex(subscription); loc == nil || loc[0] != 0 {
+		return nil, "", errors.New("GraphQL Error: command is not a subscription")
+	}
+
+	request := &graphQLRequest{
+		Query:         subscription,
+		OperationName: gql.metadata.SubscriptionOperationName,
+	}
+	if gql.metadata.SubscriptionVariables != "" {
+		err := json.Unmarshal([]byte(gql.metadata.SubscriptionVariables), &request.Variables)
+		if err != nil {
+			return nil, "", fmt.Errorf("GraphQL Error: subscriptionVariables is not a valid JSON object: %w", err)
+		}
+	}
+
+	endpoint := gql.metadata.SubscriptionEndpoint
+	if endpoint == "" {
+		u, err := url.Parse(gql.endpoint)
+		if err != nil {
+			return nil, "", fmt.Errorf("GraphQL Error: invalid endpoint: %w", err)
+		}
+		switch u.Scheme {
+		case "https":
+			u.Scheme = "wss"
+		default:
+			u.Scheme = "ws"
+		}
+		endpoint = u.String()
+	}
+
+	return request, endpoint, nil
+}
+
+// subscribe connects to the server and delivers the events of the subscription until the connection is closed, or
+// the server completes the subscription, in which case nil is returned.
+func (gql *GraphQL) subscribe(ctx context.Context, endpoint string, request *graphQLRequest, initPayload json.RawMessage, handler bindings.Handler) error {
+	header := make(http.Header)
+	for k, v := range gql.header {
+		header.Set(k, v)
+	}
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: connectionAckTimeout,
+		Subprotocols:     []string{subscriptionProtocol},
+	}
+	conn, _, err := dialer.DialContext(ctx, endpoint, header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", endpoint, err)
+	}
+	defer conn.Close()
+	// Unblock reads when the binding is closed
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	err = conn.WriteJSON(subscriptionMessage{Type: messageConnectionInit, Payload: initPayload})
+	if err != nil {
+		return err
+	}
+	conn.SetReadDeadline(time.Now().Add(connectionAckTimeout))
+	var msg subscriptionMessage
+	err = conn.ReadJSON(&msg)
+	if err != nil {
+		return fmt.Errorf("failed to read connection acknowledgement: %w", err)
+	}
+	if msg.Type != messageConnectionAck {
+		return fmt.Errorf("expected %s message, received %s", messageConnectionAck, msg.Type)
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	err = conn.WriteJSON(subscriptionMessage{ID: subscriptionID, Type: messageSubscribe, Payload: payload})
+	if err != nil {
+		return err
+	}
+	gql.logger.Infof("Subscribed to GraphQL subscription at %s", endpoint)
+
+	for {
+		msg = subscriptionMessage{}
+		err = conn.ReadJSON(&msg)
+		if err != nil {
+			return err
+		}
+
+		switch msg.Type {
+		case messagePing:
+			err = conn.WriteJSON(subscriptionMessage{Type: messagePong})
+			if err != nil {
+				return err
+			}
+		case messageNext:
+			_, err = handler(ctx, &bindings.ReadResponse{
+				Data: msg.Payload,
+			})
+			if err != nil {
+				gql.logger.Errorf("GraphQL Error: failed to handle subscription event: %v", err)
+			}
+		case messageError:
+			return fmt.Errorf("subscription error: %s", string(msg.Payload))
+		case messageComplete:
+			return nil
+		}
+	}
+}
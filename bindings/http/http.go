@@ -24,16 +24,22 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
 	"github.com/dapr/components-contrib/bindings"
+	commonoauth2 "github.com/dapr/components-contrib/common/authentication/oauth2"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
+	"github.com/dapr/kit/retry"
 	kitstrings "github.com/dapr/kit/strings"
 )
 
@@ -42,13 +48,17 @@ const (
 	MTLSClientCert = "MTLSClientCert"
 	MTLSClientKey  = "MTLSClientKey"
 
-	TraceparentHeaderKey            = "traceparent"
-	TracestateHeaderKey             = "tracestate"
-	BaggageHeaderKey                = "baggage"
-	TraceMetadataKey                = "traceHeaders"
-	securityToken                   = "securityToken"
-	securityTokenHeader             = "securityTokenHeader"
-	defaultMaxResponseBodySizeBytes = 100 << 20 // 100 MB
+	TraceparentHeaderKey             = "traceparent"
+	TracestateHeaderKey              = "tracestate"
+	BaggageHeaderKey                 = "baggage"
+	TraceMetadataKey                 = "traceHeaders"
+	securityToken                    = "securityToken"
+	securityTokenHeader              = "securityTokenHeader"
+	defaultMaxResponseBodySizeBytes  = 100 << 20 // 100 MB
+	defaultStreamChunkSizeBytes      = 64 << 10  // 64 KB
+	defaultMTLSReloadInterval        = time.Minute
+	defaultOAuth2RefreshBeforeExpiry = 30 * time.Second
+	oauth2TokenRequestTimeout        = 30 * time.Second
 )
 
 // HTTPSource is a binding for an http url endpoint invocation
@@ -59,6 +69,10 @@ type HTTPSource struct {
 	client        *http.Client
 	errorIfNot2XX bool
 	logger        logger.Logger
+
+	backOffConfig    retry.Config
+	retryStatusCodes []int
+	breaker          *circuitBreaker
 }
 
 type httpMetadata struct {
@@ -75,8 +89,42 @@ type httpMetadata struct {
 	// A value <= 0 means no limit.
 	// Default: 100MB
 	MaxResponseBodySize kitmd.ByteSize `mapstructure:"maxResponseBodySize"`
+	// Maximum size of the chunks of response bodies delivered by InvokeStream.
+	// Default: 64KB
+	StreamChunkSize kitmd.ByteSize `mapstructure:"streamChunkSize"`
+	// How often the certificate files are checked for changes, such as when the secret they are mounted from rotates.
+	// A value of 0 disables reloading.
+	// Default: 1m
+	MTLSReloadInterval time.Duration `mapstructure:"mtlsReloadInterval"`
+
+	// Status codes of the responses that are retried, like errors sending idempotent requests, as a comma-separated list.
+	// Retries are configured with the "backOff" properties, and disabled by default.
+	// Default: 429,502,503,504
+	RetryStatusCodes string `mapstructure:"retryStatusCodes"`
+	// If true, requests with methods that aren't idempotent, like POST and PATCH, are retried after network errors too.
+	// They are retried after responses with a retriable status code regardless.
+	RetryNonIdempotentRequests bool `mapstructure:"retryNonIdempotentRequests"`
+	// Number of consecutive failed attempts, including 5xx and retriable responses, after which the circuit breaker opens
+	// and requests fail without being sent.
+	// A value of 0 disables the circuit breaker.
+	CircuitBreakerFailureThreshold int `mapstructure:"circuitBreakerFailureThreshold"`
+	// How long the circuit breaker stays open before a request is allowed to try again.
+	// Default: 30s
+	CircuitBreakerOpenTimeout time.Duration `mapstructure:"circuitBreakerOpenTimeout"`
+
+	// OAuth2 client_credentials settings: when a token URL is set, a token is requested from it and sent as the Authorization header.
+	commonoauth2.ClientCredentialsMetadata `mapstructure:",squash"`
+	// How long before the token expires it should be renewed.
+	// Default: 30s
+	OAuth2RefreshBeforeExpiry time.Duration `mapstructure:"oauth2RefreshBeforeExpiry"`
+
+	// Signing of the requests: "awsSigV4" or "hmac". Requests are not signed by default.
+	SigningMode         string `mapstructure:"signingMode"`
+	AWSSigningMetadata  `mapstructure:",squash"`
+	HMACSigningMetadata `mapstructure:",squash"`
 
 	maxResponseBodySizeBytes int64
+	streamChunkSizeBytes     int64
 }
 
 // NewHTTP returns a new HTTPSource.
@@ -89,38 +137,125 @@ func NewHTTP(logger logger.Logger) bindings.OutputBinding {
 // Init performs metadata parsing.
 func (h *HTTPSource) Init(_ context.Context, meta bindings.Metadata) error {
 	h.metadata = httpMetadata{
-		MaxResponseBodySize: kitmd.NewByteSize(defaultMaxResponseBodySizeBytes),
+		MaxResponseBodySize:       kitmd.NewByteSize(defaultMaxResponseBodySizeBytes),
+		StreamChunkSize:           kitmd.NewByteSize(defaultStreamChunkSizeBytes),
+		MTLSReloadInterval:        defaultMTLSReloadInterval,
+		RetryStatusCodes:          defaultRetryStatusCodes,
+		CircuitBreakerOpenTimeout: defaultCircuitBreakerOpenTimeout,
+		HMACSigningMetadata: HMACSigningMetadata{
+			Algorithm: defaultHMACAlgorithm,
+			Header:    defaultHMACHeader,
+			Encoding:  defaultHMACEncoding,
+		},
+		OAuth2RefreshBeforeExpiry: defaultOAuth2RefreshBeforeExpiry,
 	}
 	err := kitmd.DecodeMetadata(meta.Properties, &h.metadata)
 	if err != nil {
 		return err
 	}
 
-	tlsConfig, err := h.addRootCAToCertPool()
+	h.metadata.maxResponseBodySizeBytes, err = h.metadata.MaxResponseBodySize.GetBytes()
+	if err != nil {
+		return fmt.Errorf("invalid value for maxResponseBodySize: %w", err)
+	}
+	h.metadata.streamChunkSizeBytes, err = h.metadata.StreamChunkSize.GetBytes()
+	if err != nil {
+		return fmt.Errorf("invalid value for streamChunkSize: %w", err)
+	}
+	if h.metadata.streamChunkSizeBytes <= 0 {
+		return errors.New("streamChunkSize must be greater than zero")
+	}
+
+	if h.metadata.MTLSReloadInterval < 0 {
+		return errors.New("mtlsReloadInterval must not be negative")
+	}
+
+	netTransport, err := h.newTransport()
+	if err != nil {
+		return err
+	}
+	var transport http.RoundTripper = netTransport
+	if files := h.certificateFiles(); len(files) > 0 && h.metadata.MTLSReloadInterval > 0 {
+		transport, err = newReloadingTransport(netTransport, h.newTransport, files, h.metadata.MTLSReloadInterval, h.logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	h.client = &http.Client{
+		Timeout:   0, // no time out here, we use request timeouts instead
+		Transport: transport,
+	}
+
+	if h.metadata.TokenURL != "" {
+		h.client.Transport, err = h.newOAuth2Transport(transport)
+		if err != nil {
+			return err
+		}
+	}
+
+	signer, err := h.newSigner()
 	if err != nil {
 		return err
 	}
+	if signer != nil {
+		h.client.Transport = &signingTransport{
+			base: h.client.Transport,
+			sign: signer,
+		}
+	}
+
+	// Requests are not retried unless backOff properties are set.
+	h.backOffConfig = retry.DefaultConfigWithNoRetry()
+	err = retry.DecodeConfigWithPrefix(&h.backOffConfig, meta.Properties, "backOff")
+	if err != nil {
+		return fmt.Errorf("error decoding backOff config: %w", err)
+	}
+	h.retryStatusCodes, err = parseStatusCodes(h.metadata.RetryStatusCodes)
+	if err != nil {
+		return fmt.Errorf("invalid value for retryStatusCodes: %w", err)
+	}
+	if h.metadata.CircuitBreakerFailureThreshold < 0 {
+		return errors.New("circuitBreakerFailureThreshold must not be negative")
+	}
+	if h.metadata.CircuitBreakerOpenTimeout <= 0 {
+		return errors.New("circuitBreakerOpenTimeout must be greater than zero")
+	}
+	h.breaker = newCircuitBreaker(h.metadata.CircuitBreakerFailureThreshold, h.metadata.CircuitBreakerOpenTimeout)
+
+	if val := meta.Properties["errorIfNot2XX"]; val != "" {
+		h.errorIfNot2XX = kitstrings.IsTruthy(val)
+	} else {
+		// Default behavior
+		h.errorIfNot2XX = true
+	}
+
+	return nil
+}
+
+// newTransport returns the transport of the HTTP client, with the TLS configuration built from the current
+// certificates.
+func (h *HTTPSource) newTransport() (*http.Transport, error) {
+	tlsConfig, err := h.addRootCAToCertPool()
+	if err != nil {
+		return nil, err
+	}
 	if tlsConfig == nil {
 		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
 	if h.metadata.MTLSClientCert != "" && h.metadata.MTLSClientKey != "" {
 		err = h.readMTLSClientCertificates(tlsConfig)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if h.metadata.MTLSRenegotiation != "" {
 		err = h.setTLSRenegotiation(tlsConfig)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	h.metadata.maxResponseBodySizeBytes, err = h.metadata.MaxResponseBodySize.GetBytes()
-	if err != nil {
-		return fmt.Errorf("invalid value for maxResponseBodySize: %w", err)
-	}
-
 	// See guidance on proper HTTP client settings here:
 	// https://medium.com/@nate510/don-t-use-go-s-default-http-client-4804cb19f779
 	dialer := &net.Dialer{
@@ -130,20 +265,68 @@ func (h *HTTPSource) Init(_ context.Context, meta bindings.Metadata) error {
 	netTransport.DialContext = dialer.DialContext
 	netTransport.TLSHandshakeTimeout = 15 * time.Second
 	netTransport.TLSClientConfig = tlsConfig
+	return netTransport, nil
+}
 
-	h.client = &http.Client{
-		Timeout:   0, // no time out here, we use request timeouts instead
-		Transport: netTransport,
+// newOAuth2Transport returns a RoundTripper that adds a bearer token obtained with the OAuth2 client_credentials grant to each request.
+// Tokens are cached and renewed when they are about to expire.
+func (h *HTTPSource) newOAuth2Transport(base http.RoundTripper) (http.RoundTripper, error) {
+	md := h.metadata.ClientCredentialsMetadata
+	if md.ClientID == "" {
+		return nil, errors.New("oauth2ClientID is required when oauth2TokenURL is set")
+	}
+	if _, err := url.Parse(md.TokenURL); err != nil {
+		return nil, fmt.Errorf("invalid value for oauth2TokenURL: %w", err)
+	}
+	if h.metadata.OAuth2RefreshBeforeExpiry < 0 {
+		return nil, errors.New("oauth2RefreshBeforeExpiry must not be negative")
 	}
 
-	if val := meta.Properties["errorIfNot2XX"]; val != "" {
-		h.errorIfNot2XX = kitstrings.IsTruthy(val)
-	} else {
-		// Default behavior
-		h.errorIfNot2XX = true
+	conf := &clientcredentials.Config{
+		ClientID:     md.ClientID,
+		ClientSecret: md.ClientSecret,
+		TokenURL:     md.TokenURL,
+		Scopes:       md.Scopes,
+	}
+	if len(md.Audiences) > 0 {
+		conf.EndpointParams = url.Values{"audience": md.Audiences}
 	}
 
-	return nil
+	tokenClient := &http.Client{
+		Timeout: oauth2TokenRequestTimeout,
+	}
+	if md.TokenCAPEM != "" {
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM([]byte(md.TokenCAPEM)) {
+			return nil, errors.New("failed to parse oauth2TokenCAPEM")
+		}
+		tokenTransport := http.DefaultTransport.(*http.Transport).Clone()
+		tokenTransport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    caPool,
+		}
+		tokenClient.Transport = tokenTransport
+	}
+
+	source := clientCredentialsSource{
+		ctx:  context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient),
+		conf: conf,
+	}
+	return &oauth2.Transport{
+		Source: oauth2.ReuseTokenSourceWithExpiry(nil, source, h.metadata.OAuth2RefreshBeforeExpiry),
+		Base:   base,
+	}, nil
+}
+
+// clientCredentialsSource requests a new token on every call.
+// Caching is performed by the ReuseTokenSource wrapping it, which allows controlling how early tokens are renewed.
+type clientCredentialsSource struct {
+	ctx  context.Context
+	conf *clientcredentials.Config
+}
+
+func (s clientCredentialsSource) Token() (*oauth2.Token, error) {
+	return s.conf.Token(s.ctx)
 }
 
 // readMTLSClientCertificates reads the certificates and key from the metadata and returns a tls.Config.
@@ -239,6 +422,34 @@ func (h *HTTPSource) Operations() []bindings.OperationKind {
 
 // Invoke performs an HTTP request to the configured HTTP endpoint.
 func (h *HTTPSource) Invoke(parentCtx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	ctx := parentCtx
+	if h.metadata.ResponseTimeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parentCtx, *h.metadata.ResponseTimeout)
+		defer cancel()
+	}
+
+	request, errorIfNot2XX, err := h.newRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the question
+	resp, err := h.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Drain before closing
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	return h.readResponse(resp, errorIfNot2XX)
+}
+
+// newRequest returns the HTTP request for an invocation, and whether non-2XX responses are errors.
+func (h *HTTPSource) newRequest(ctx context.Context, req *bindings.InvokeRequest) (*http.Request, bool, error) {
 	u := h.metadata.URL
 
 	errorIfNot2XX := h.errorIfNot2XX // Default to the component config (default is true)
@@ -266,19 +477,12 @@ func (h *HTTPSource) Invoke(parentCtx context.Context, req *bindings.InvokeReque
 		body = bytes.NewBuffer(req.Data)
 	case "GET", "HEAD", "DELETE", "OPTIONS", "TRACE":
 	default:
-		return nil, fmt.Errorf("invalid operation: %s", req.Operation)
-	}
-
-	ctx := parentCtx
-	if h.metadata.ResponseTimeout != nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(parentCtx, *h.metadata.ResponseTimeout)
-		defer cancel()
+		return nil, false, fmt.Errorf("invalid operation: %s", req.Operation)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Set default values for Content-Type and Accept headers.
@@ -327,17 +531,11 @@ func (h *HTTPSource) Invoke(parentCtx context.Context, req *bindings.InvokeReque
 		request.Header.Set(BaggageHeaderKey, baggage)
 	}
 
-	// Send the question
-	resp, err := h.client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		// Drain before closing
-		_, _ = io.Copy(io.Discard, resp.Body)
-		resp.Body.Close()
-	}()
+	return request, errorIfNot2XX, nil
+}
 
+// readResponse reads the body of a response, up to the maximum size, and returns it with the response metadata.
+func (h *HTTPSource) readResponse(resp *http.Response, errorIfNot2XX bool) (*bindings.InvokeResponse, error) {
 	var respBody io.Reader = resp.Body
 	if h.metadata.maxResponseBodySizeBytes > 0 {
 		respBody = io.LimitReader(resp.Body, h.metadata.maxResponseBodySizeBytes)
@@ -350,6 +548,19 @@ func (h *HTTPSource) Invoke(parentCtx context.Context, req *bindings.InvokeReque
 		return nil, err
 	}
 
+	// Create an error for non-200 status codes unless suppressed.
+	if errorIfNot2XX && resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+
+	return &bindings.InvokeResponse{
+		Data:     b,
+		Metadata: responseMetadata(resp),
+	}, err
+}
+
+// responseMetadata returns the status and the headers of a response.
+func responseMetadata(resp *http.Response) map[string]string {
 	metadata := make(map[string]string, len(resp.Header)+2)
 	// Include status code & desc
 	metadata["statusCode"] = strconv.Itoa(resp.StatusCode)
@@ -360,16 +571,7 @@ func (h *HTTPSource) Invoke(parentCtx context.Context, req *bindings.InvokeReque
 	for key, values := range resp.Header {
 		metadata[key] = strings.Join(values, ", ")
 	}
-
-	// Create an error for non-200 status codes unless suppressed.
-	if errorIfNot2XX && resp.StatusCode/100 != 2 {
-		err = fmt.Errorf("received status code %d", resp.StatusCode)
-	}
-
-	return &bindings.InvokeResponse{
-		Data:     b,
-		Metadata: metadata,
-	}, err
+	return metadata
 }
 
 // GetComponentMetadata returns the metadata of the component.
@@ -17,11 +17,15 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -32,9 +36,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -461,6 +468,75 @@ func TestSecurityTokenHeaderForwarded(t *testing.T) {
 	})
 }
 
+func TestOAuth2TokenInjected(t *testing.T) {
+	handler := NewHTTPHandler()
+	s := httptest.NewServer(handler)
+	defer s.Close()
+
+	var tokenRequests atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token%d","token_type":"Bearer","expires_in":%d}`, tokenRequests.Load(), 3600)
+	}))
+	defer tokenServer.Close()
+
+	req := TestCase{
+		input:      "GET",
+		operation:  "get",
+		path:       "/",
+		statusCode: 200,
+	}.ToInvokeRequest()
+
+	t.Run("token is cached", func(t *testing.T) {
+		tokenRequests.Store(0)
+		hs, err := InitBinding(s, map[string]string{
+			"oauth2TokenURL":     tokenServer.URL,
+			"oauth2ClientID":     "client",
+			"oauth2ClientSecret": "secret",
+			"oauth2Scopes":       "read,write",
+		})
+		require.NoError(t, err)
+
+		for range 3 {
+			_, err = hs.Invoke(t.Context(), &req)
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer token1", handler.Headers["Authorization"])
+		}
+		assert.Equal(t, int32(1), tokenRequests.Load())
+	})
+
+	t.Run("token is renewed before expiry", func(t *testing.T) {
+		tokenRequests.Store(0)
+		hs, err := InitBinding(s, map[string]string{
+			"oauth2TokenURL":            tokenServer.URL,
+			"oauth2ClientID":            "client",
+			"oauth2ClientSecret":        "secret",
+			"oauth2Scopes":              "read,write",
+			"oauth2RefreshBeforeExpiry": "2h",
+		})
+		require.NoError(t, err)
+
+		_, err = hs.Invoke(t.Context(), &req)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token1", handler.Headers["Authorization"])
+		_, err = hs.Invoke(t.Context(), &req)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token2", handler.Headers["Authorization"])
+	})
+
+	t.Run("client ID is required", func(t *testing.T) {
+		_, err := InitBinding(s, map[string]string{
+			"oauth2TokenURL": tokenServer.URL,
+		})
+		require.Error(t, err)
+	})
+}
+
 func TestTraceHeadersForwarded(t *testing.T) {
 	handler := NewHTTPHandler()
 	s := httptest.NewServer(handler)
@@ -1009,3 +1085,413 @@ func TestMaxBodySizeHonored(t *testing.T) {
 	// Should have only read 1KB
 	assert.Len(t, response.Data, 1<<10)
 }
+
+func TestInvokeStream(t *testing.T) {
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events":
+			w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			io.WriteString(w, ": comment\n\ndata: first\n\n")
+			w.(http.Flusher).Flush()
+			// The rest of the stream is sent once the first event was delivered
+			<-release
+			io.WriteString(w, "event: update\r\nid: 42\r\nretry: 1000\r\ndata: line 1\r\ndata:line 2\r\n\r\n")
+			io.WriteString(w, "id: 43\rdata\r\rdata: not dispatched")
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, "failed")
+		default:
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(make([]byte, 10<<10))
+		}
+	}))
+	defer s.Close()
+
+	hs, err := InitBinding(s, map[string]string{"streamChunkSize": "4Ki"})
+	require.NoError(t, err)
+	streamer := hs.(bindings.StreamingOutputBinding)
+
+	t.Run("server-sent events are delivered as they arrive", func(t *testing.T) {
+		var events []*bindings.InvokeResponse
+		res, err := streamer.InvokeStream(t.Context(), &bindings.InvokeRequest{
+			Operation: "get",
+			Metadata:  map[string]string{"path": "/events", "Accept": "text/event-stream"},
+		}, func(_ context.Context, chunk *bindings.InvokeResponse) error {
+			if len(events) == 0 {
+				close(release)
+			}
+			events = append(events, chunk)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "200", res.Metadata["statusCode"])
+		assert.Empty(t, res.Data)
+
+		require.Len(t, events, 3)
+		assert.Equal(t, "first", string(events[0].Data))
+		assert.Equal(t, map[string]string{"event": "message"}, events[0].Metadata)
+		assert.Equal(t, "line 1\nline 2", string(events[1].Data))
+		assert.Equal(t, map[string]string{"event": "update", "id": "42", "retry": "1000"}, events[1].Metadata)
+		assert.Empty(t, events[2].Data)
+		assert.Equal(t, map[string]string{"event": "message", "id": "43"}, events[2].Metadata)
+	})
+
+	t.Run("response body is delivered in chunks", func(t *testing.T) {
+		var size int
+		res, err := streamer.InvokeStream(t.Context(), &bindings.InvokeRequest{
+			Operation: "get",
+			Metadata:  map[string]string{"path": "/large"},
+		}, func(_ context.Context, chunk *bindings.InvokeResponse) error {
+			assert.LessOrEqual(t, len(chunk.Data), 4<<10)
+			assert.Equal(t, strconv.Itoa(size), chunk.Metadata["offset"])
+			size += len(chunk.Data)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "200", res.Metadata["statusCode"])
+		assert.Equal(t, 10<<10, size)
+	})
+
+	t.Run("handler errors stop the stream", func(t *testing.T) {
+		var calls int
+		_, err := streamer.InvokeStream(t.Context(), &bindings.InvokeRequest{
+			Operation: "get",
+			Metadata:  map[string]string{"path": "/large"},
+		}, func(_ context.Context, chunk *bindings.InvokeResponse) error {
+			calls++
+			return errors.New("stop")
+		})
+		require.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("error responses are not streamed", func(t *testing.T) {
+		res, err := streamer.InvokeStream(t.Context(), &bindings.InvokeRequest{
+			Operation: "get",
+			Metadata:  map[string]string{"path": "/error"},
+		}, func(_ context.Context, chunk *bindings.InvokeResponse) error {
+			t.Fatal("handler must not be called")
+			return nil
+		})
+		require.EqualError(t, err, "received status code 500")
+		assert.Equal(t, "failed", string(res.Data))
+	})
+}
+
+func TestMTLSCertificatesReloaded(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/testmTLS", mTLSHandler)
+	server := setupHTTPSServer(t, true, handler)
+	defer server.Close()
+
+	// The client starts with a self-signed certificate, which the server rejects
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "untrusted"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	hs, err := InitBindingForHTTPS(server, map[string]string{
+		"MTLSRootCA":         filepath.Join(".", "testdata", "ca.pem"),
+		"MTLSClientCert":     certFile,
+		"MTLSClientKey":      keyFile,
+		"mtlsReloadInterval": "10ms",
+	})
+	require.NoError(t, err)
+
+	req := bindings.InvokeRequest{
+		Operation: "get",
+		Metadata:  map[string]string{"path": "/testmTLS"},
+	}
+	_, err = hs.Invoke(t.Context(), &req)
+	require.Error(t, err)
+
+	// The certificate is rotated with one signed by the CA
+	for src, dst := range map[string]string{"client.pem": certFile, "client.key": keyFile} {
+		data, rErr := os.ReadFile(filepath.Join(".", "testdata", src))
+		require.NoError(t, rErr)
+		require.NoError(t, os.WriteFile(dst, data, 0o600))
+		require.NoError(t, os.Chtimes(dst, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	}
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, iErr := hs.Invoke(t.Context(), &req)
+		require.NoError(c, iErr)
+		assert.Equal(c, "1", string(res.Data))
+	}, 5*time.Second, 20*time.Millisecond)
+
+	t.Run("reload disabled", func(t *testing.T) {
+		hs, err := InitBindingForHTTPS(server, map[string]string{
+			"MTLSRootCA":         filepath.Join(".", "testdata", "ca.pem"),
+			"MTLSClientCert":     certFile,
+			"MTLSClientKey":      keyFile,
+			"mtlsReloadInterval": "0",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &http.Transport{}, hs.(*HTTPSource).client.Transport)
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		_, err := InitBindingForHTTPS(server, map[string]string{"mtlsReloadInterval": "-1s"})
+		require.Error(t, err)
+	})
+}
+
+func TestRetryAndCircuitBreaker(t *testing.T) {
+	var (
+		attempts atomic.Int32
+		failures atomic.Int32
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(body)
+	}))
+	defer s.Close()
+
+	invoke := func(hs bindings.OutputBinding) (*bindings.InvokeResponse, error) {
+		return hs.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: "post",
+			Data:      []byte("payload"),
+		})
+	}
+
+	t.Run("retriable responses are retried", func(t *testing.T) {
+		hs, err := InitBinding(s, map[string]string{
+			"backOffMaxRetries": "3",
+			"backOffDuration":   "1ms",
+		})
+		require.NoError(t, err)
+
+		attempts.Store(0)
+		failures.Store(2)
+		res, err := invoke(hs)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(res.Data))
+		assert.Equal(t, int32(3), attempts.Load())
+
+		attempts.Store(0)
+		failures.Store(10)
+		res, err = invoke(hs)
+		require.EqualError(t, err, "received status code 503")
+		assert.Equal(t, "503", res.Metadata["statusCode"])
+		assert.Equal(t, int32(4), attempts.Load())
+	})
+
+	t.Run("requests are not retried by default", func(t *testing.T) {
+		hs, err := InitBinding(s, nil)
+		require.NoError(t, err)
+
+		attempts.Store(0)
+		failures.Store(1)
+		_, err = invoke(hs)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("only the configured status codes are retried", func(t *testing.T) {
+		hs, err := InitBinding(s, map[string]string{
+			"backOffMaxRetries": "3",
+			"backOffDuration":   "1ms",
+			"retryStatusCodes":  "429",
+		})
+		require.NoError(t, err)
+
+		attempts.Store(0)
+		failures.Store(1)
+		_, err = invoke(hs)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("circuit breaker opens after consecutive failures", func(t *testing.T) {
+		hs, err := InitBinding(s, map[string]string{
+			"circuitBreakerFailureThreshold": "2",
+			"circuitBreakerOpenTimeout":      "100ms",
+		})
+		require.NoError(t, err)
+
+		attempts.Store(0)
+		failures.Store(3)
+		for range 2 {
+			_, err = invoke(hs)
+			require.EqualError(t, err, "received status code 503")
+		}
+		_, err = invoke(hs)
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen)
+		assert.Equal(t, int32(2), attempts.Load())
+
+		// After the timeout a failed request opens the circuit again, and a successful one closes it
+		time.Sleep(150 * time.Millisecond)
+		_, err = invoke(hs)
+		require.EqualError(t, err, "received status code 503")
+		_, err = invoke(hs)
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen)
+
+		time.Sleep(150 * time.Millisecond)
+		res, err := invoke(hs)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(res.Data))
+		_, err = invoke(hs)
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), attempts.Load())
+	})
+
+	t.Run("every 5xx response is a circuit breaker failure", func(t *testing.T) {
+		es := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer es.Close()
+		hs, err := InitBinding(es, map[string]string{
+			"circuitBreakerFailureThreshold": "2",
+		})
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err = invoke(hs)
+			require.EqualError(t, err, "received status code 500")
+		}
+		_, err = invoke(hs)
+		require.ErrorIs(t, err, ErrCircuitBreakerOpen)
+	})
+
+	t.Run("only idempotent requests are retried after errors by default", func(t *testing.T) {
+		var closed atomic.Int32
+		cs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			closed.Add(1)
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}))
+		defer cs.Close()
+		props := map[string]string{
+			"backOffMaxRetries": "2",
+			"backOffDuration":   "1ms",
+		}
+
+		hs, err := InitBinding(cs, props)
+		require.NoError(t, err)
+		closed.Store(0)
+		_, err = invoke(hs)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), closed.Load())
+
+		closed.Store(0)
+		_, err = hs.Invoke(t.Context(), &bindings.InvokeRequest{Operation: "put", Data: []byte("payload")})
+		require.Error(t, err)
+		assert.GreaterOrEqual(t, closed.Load(), int32(3))
+
+		props["retryNonIdempotentRequests"] = "true"
+		hs, err = InitBinding(cs, props)
+		require.NoError(t, err)
+		closed.Store(0)
+		_, err = invoke(hs)
+		require.Error(t, err)
+		assert.GreaterOrEqual(t, closed.Load(), int32(3))
+	})
+
+	t.Run("invalid status codes", func(t *testing.T) {
+		_, err := InitBinding(s, map[string]string{"retryStatusCodes": "503,abc"})
+		require.Error(t, err)
+	})
+}
+
+func TestRequestSigning(t *testing.T) {
+	var received atomic.Pointer[http.Request]
+	var receivedBody atomic.Pointer[[]byte]
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received.Store(r)
+		receivedBody.Store(&body)
+	}))
+	defer s.Close()
+
+	req := bindings.InvokeRequest{
+		Operation: "post",
+		Data:      []byte(`{"hello":"world"}`),
+		Metadata:  map[string]string{"path": "/items?id=1", "X-Custom": "custom"},
+	}
+
+	t.Run("aws sigv4", func(t *testing.T) {
+		hs, err := InitBinding(s, map[string]string{
+			"signingMode":  "awsSigV4",
+			"awsService":   "execute-api",
+			"awsRegion":    "us-west-2",
+			"awsAccessKey": "AKIDEXAMPLE",
+			"awsSecretKey": "secret",
+		})
+		require.NoError(t, err)
+
+		_, err = hs.Invoke(t.Context(), &req)
+		require.NoError(t, err)
+		r := received.Load()
+		assert.Equal(t, `{"hello":"world"}`, string(*receivedBody.Load()))
+		assert.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/")
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-west-2/execute-api/aws4_request")
+
+		// The signature is verified by signing the received request again at the same time
+		signTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+		require.NoError(t, err)
+		expected, err := http.NewRequest(r.Method, s.URL+r.RequestURI, nil)
+		require.NoError(t, err)
+		for _, k := range []string{"Content-Type", "Accept", "X-Custom"} {
+			expected.Header.Set(k, r.Header.Get(k))
+		}
+		_, err = v4.NewSigner(credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "")).
+			Sign(expected, strings.NewReader(`{"hello":"world"}`), "execute-api", "us-west-2", signTime)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Header.Get("Authorization"), r.Header.Get("Authorization"))
+	})
+
+	t.Run("hmac", func(t *testing.T) {
+		hs, err := InitBinding(s, map[string]string{
+			"signingMode":         "hmac",
+			"hmacSecret":          "secret",
+			"hmacHeader":          "X-Hub-Signature-256",
+			"hmacSignaturePrefix": "sha256=",
+			"hmacSignedHeaders":   "X-Custom",
+			"hmacTimestampHeader": "X-Timestamp",
+		})
+		require.NoError(t, err)
+
+		_, err = hs.Invoke(t.Context(), &req)
+		require.NoError(t, err)
+		r := received.Load()
+		require.NotEmpty(t, r.Header.Get("X-Timestamp"))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte("POST\n/items?id=1\nx-timestamp:" + r.Header.Get("X-Timestamp") + "\nx-custom:custom\n"))
+		mac.Write([]byte(`{"hello":"world"}`))
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Hub-Signature-256"))
+		assert.Equal(t, `{"hello":"world"}`, string(*receivedBody.Load()))
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{"signingMode": "unknown"},
+			{"signingMode": "hmac"},
+			{"signingMode": "hmac", "hmacSecret": "secret", "hmacAlgorithm": "md5"},
+			{"signingMode": "awsSigV4", "awsRegion": "us-west-2"},
+		} {
+			_, err := InitBinding(s, props)
+			require.Error(t, err, props)
+		}
+	})
+}
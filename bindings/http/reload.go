@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/kit/logger"
+)
+
+// certificateFiles returns the paths of the certificates and key that are read from files rather than inlined as
+// PEM in the metadata, for example when they are mounted from a secret.
+func (h *HTTPSource) certificateFiles() []string {
+	var files []string
+	for _, val := range []string{h.metadata.MTLSRootCA, h.metadata.MTLSClientCert, h.metadata.MTLSClientKey} {
+		if val != "" && !isValidPEM(val) {
+			files = append(files, val)
+		}
+	}
+	return files
+}
+
+// fileVersion identifies the content of a file without reading it.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// reloadingTransport is a RoundTripper that rebuilds its transport when the certificate files change, so rotated
+// certificates are used without re-initializing the component.
+// Files are checked at most once per interval, when a request is sent.
+type reloadingTransport struct {
+	newTransport func() (*http.Transport, error)
+	files        []string
+	interval     time.Duration
+	logger       logger.Logger
+
+	transport atomic.Pointer[http.Transport]
+	lastCheck atomic.Int64
+	lock      sync.Mutex
+	versions  map[string]fileVersion
+}
+
+func newReloadingTransport(transport *http.Transport, newTransport func() (*http.Transport, error), files []string, interval time.Duration, log logger.Logger) (*reloadingTransport, error) {
+	versions, err := statFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &reloadingTransport{
+		newTransport: newTransport,
+		files:        files,
+		interval:     interval,
+		logger:       log,
+		versions:     versions,
+	}
+	t.transport.Store(transport)
+	t.lastCheck.Store(time.Now().UnixNano())
+	return t, nil
+}
+
+func (t *reloadingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.reloadIfChanged()
+	return t.transport.Load().RoundTrip(req)
+}
+
+// reloadIfChanged rebuilds the transport if the interval elapsed since the last check and a file changed.
+// If the new certificates can't be loaded, for example because a file is being written, the current transport is
+// kept and the files are checked again after the next interval.
+func (t *reloadingTransport) reloadIfChanged() {
+	last := t.lastCheck.Load()
+	now := time.Now().UnixNano()
+	if now-last < t.interval.Nanoseconds() || !t.lastCheck.CompareAndSwap(last, now) {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	versions, err := statFiles(t.files)
+	if err != nil {
+		t.logger.Warnf("Failed to check the mTLS certificates for changes: %v", err)
+		return
+	}
+	changed := false
+	for file, v := range versions {
+		if t.versions[file] != v {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+
+	transport, err := t.newTransport()
+	if err != nil {
+		t.logger.Warnf("Failed to reload the mTLS certificates, the previous ones are still used: %v", err)
+		return
+	}
+	t.versions = versions
+	old := t.transport.Swap(transport)
+	old.CloseIdleConnections()
+	t.logger.Info("Reloaded the mTLS certificates")
+}
+
+func statFiles(files []string) (map[string]fileVersion, error) {
+	versions := make(map[string]fileVersion, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate file %q: %w", file, err)
+		}
+		versions[file] = fileVersion{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defaultRetryStatusCodes          = "429,502,503,504"
+	defaultCircuitBreakerOpenTimeout = 30 * time.Second
+)
+
+// ErrCircuitBreakerOpen is returned without sending the request while the circuit breaker is open.
+var ErrCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+// parseStatusCodes parses a comma-separated list of status codes.
+func parseStatusCodes(val string) ([]int, error) {
+	var codes []int
+	for _, s := range strings.Split(val, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		code, err := strconv.Atoi(s)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q", s)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// isIdempotent returns true if the request can be sent again after a network error without risking applying it twice.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// do sends the request, retrying it with the configured backoff after responses with a retriable status code, and
+// after network errors if the request is idempotent or retryNonIdempotentRequests is set. When retries are exhausted,
+// the last response or error is returned.
+// Errors, 5xx responses and responses with a retriable status code are failures for the circuit breaker: while it's
+// open, requests fail immediately.
+func (h *HTTPSource) do(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	b := h.backOffConfig.NewBackOffWithContext(ctx)
+	req := request
+	for {
+		if !h.breaker.allow() {
+			return nil, ErrCircuitBreakerOpen
+		}
+
+		resp, err := h.client.Do(req)
+		var retriable bool
+		if err != nil {
+			// The request may have reached the server before the error, so sending it again could apply it twice
+			retriable = h.metadata.RetryNonIdempotentRequests || isIdempotent(request)
+			h.breaker.record(false)
+		} else {
+			retriable = slices.Contains(h.retryStatusCodes, resp.StatusCode)
+			h.breaker.record(!retriable && resp.StatusCode < http.StatusInternalServerError)
+		}
+		if !retriable {
+			return resp, err
+		}
+
+		next := b.NextBackOff()
+		if next == backoff.Stop || (req.Body != nil && request.GetBody == nil) {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain before closing
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			h.logger.Debugf("Received status code %d, retrying in %v", resp.StatusCode, next)
+		} else {
+			h.logger.Debugf("Request failed, retrying in %v: %v", next, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		case <-time.After(next):
+		}
+
+		req = request.Clone(ctx)
+		if request.GetBody != nil {
+			req.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// circuitBreaker stops sending requests for a while after too many consecutive failures.
+// Once the open timeout elapsed, a single request is allowed: the circuit closes if it succeeds, and opens again
+// otherwise.
+// A nil circuitBreaker is disabled.
+type circuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+	// True while the request allowed after the open timeout is in flight.
+	probing bool
+}
+
+func newCircuitBreaker(failureThreshold int, openTimeout time.Duration) *circuitBreaker {
+	if failureThreshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+// allow returns true if a request can be sent.
+func (c *circuitBreaker) allow() bool {
+	if c == nil {
+		return true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.failures < c.failureThreshold {
+		return true
+	}
+	if c.probing || time.Now().Before(c.openUntil) {
+		return false
+	}
+	c.probing = true
+	return true
+}
+
+// record counts the outcome of a request.
+func (c *circuitBreaker) record(success bool) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.probing = false
+	if success {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.failures >= c.failureThreshold {
+		c.openUntil = time.Now().Add(c.openTimeout)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	signingModeAWSSigV4 = "awsSigV4"
+	signingModeHMAC     = "hmac"
+
+	defaultHMACAlgorithm = "sha256"
+	defaultHMACHeader    = "X-Signature"
+	defaultHMACEncoding  = "hex"
+)
+
+// AWSSigningMetadata configures the signing of requests with AWS Signature Version 4.
+type AWSSigningMetadata struct {
+	// Name of the service requests are signed for, such as "execute-api" or "lambda".
+	Service       string `mapstructure:"awsService"`
+	Region        string `mapstructure:"awsRegion"`
+	AccessKey     string `mapstructure:"awsAccessKey"`
+	SecretKey     string `mapstructure:"awsSecretKey"`
+	SessionToken  string `mapstructure:"awsSessionToken"`
+	Profile       string `mapstructure:"awsProfile"`
+	AssumeRoleARN string `mapstructure:"awsIamRoleArn"`
+	SessionName   string `mapstructure:"awsStsSessionName"`
+}
+
+// HMACSigningMetadata configures the signing of requests with an HMAC header.
+type HMACSigningMetadata struct {
+	Secret string `mapstructure:"hmacSecret"`
+	// Hash function of the HMAC: sha256, sha512 or sha1.
+	// Default: sha256
+	Algorithm string `mapstructure:"hmacAlgorithm"`
+	// Header set to the signature.
+	// Default: X-Signature
+	Header string `mapstructure:"hmacHeader"`
+	// Prefix of the signature in the header, such as "sha256=".
+	SignaturePrefix string `mapstructure:"hmacSignaturePrefix"`
+	// Encoding of the signature: hex or base64.
+	// Default: hex
+	Encoding string `mapstructure:"hmacEncoding"`
+	// Comma-separated names of the request headers that are signed.
+	SignedHeaders string `mapstructure:"hmacSignedHeaders"`
+	// Header set to the Unix time of the request, which is signed, so receivers can reject replayed requests.
+	TimestampHeader string `mapstructure:"hmacTimestampHeader"`
+}
+
+// requestSigner signs a request whose body is given.
+type requestSigner func(req *http.Request, body []byte) error
+
+// signingTransport is a RoundTripper that signs each request before sending it.
+// Requests are signed on every attempt, so retries get fresh signatures.
+type signingTransport struct {
+	base http.RoundTripper
+	sign requestSigner
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request they are given
+	signed := req.Clone(req.Context())
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		signed.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	err := t.sign(signed, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return t.base.RoundTrip(signed)
+}
+
+// newSigner returns the signer of the configured signing mode, or nil if requests are not signed.
+func (h *HTTPSource) newSigner() (requestSigner, error) {
+	switch h.metadata.SigningMode {
+	case "":
+		return nil, nil
+	case signingModeAWSSigV4:
+		if h.metadata.TokenURL != "" {
+			return nil, errors.New("signingMode awsSigV4 can't be used with oauth2TokenURL, as both set the Authorization header")
+		}
+		return h.newAWSSigV4Signer()
+	case signingModeHMAC:
+		return h.newHMACSigner()
+	default:
+		return nil, fmt.Errorf("invalid value for signingMode: %s", h.metadata.SigningMode)
+	}
+}
+
+// newAWSSigV4Signer returns a signer using AWS Signature Version 4, with the static credentials of the metadata or
+// the default credential chain, optionally from a named profile, and assuming a role if set.
+// See: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv.html
+func (h *HTTPSource) newAWSSigV4Signer() (requestSigner, error) {
+	md := h.metadata.AWSSigningMetadata
+	if md.Service == "" {
+		return nil, errors.New("awsService is required when signingMode is awsSigV4")
+	}
+
+	cfg := aws.NewConfig()
+	if md.Region != "" {
+		cfg = cfg.WithRegion(md.Region)
+	}
+	if md.AccessKey != "" && md.SecretKey != "" {
+		// session token is an option field
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(md.AccessKey, md.SecretKey, md.SessionToken))
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		Profile:           md.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	region := aws.StringValue(sess.Config.Region)
+	if region == "" {
+		return nil, errors.New("awsRegion is required when signingMode is awsSigV4")
+	}
+
+	creds := sess.Config.Credentials
+	if md.AssumeRoleARN != "" {
+		creds = stscreds.NewCredentials(sess, md.AssumeRoleARN, func(p *stscreds.AssumeRoleProvider) {
+			if md.SessionName != "" {
+				p.RoleSessionName = md.SessionName
+			}
+		})
+	}
+
+	signer := v4.NewSigner(creds)
+	return func(req *http.Request, body []byte) error {
+		_, err := signer.Sign(req, bytes.NewReader(body), md.Service, region, time.Now())
+		return err
+	}, nil
+}
+
+// newHMACSigner returns a signer that adds an HMAC of the request to a header.
+// The signed string is the method, the request URI and the signed headers, each on its own line as "name:value"
+// with the lowercase name, followed by the body:
+//
+//	POST
+//	/path?query
+//	x-timestamp:1700000000
+//	content-type:application/json
+//	{"body": true}
+func (h *HTTPSource) newHMACSigner() (requestSigner, error) {
+	md := h.metadata.HMACSigningMetadata
+	if md.Secret == "" {
+		return nil, errors.New("hmacSecret is required when signingMode is hmac")
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(md.Algorithm) {
+	case "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	case "sha1":
+		newHash = sha1.New
+	default:
+		return nil, fmt.Errorf("invalid value for hmacAlgorithm: %s", md.Algorithm)
+	}
+
+	var encode func([]byte) string
+	switch strings.ToLower(md.Encoding) {
+	case "hex":
+		encode = hex.EncodeToString
+	case "base64":
+		encode = b64.StdEncoding.EncodeToString
+	default:
+		return nil, fmt.Errorf("invalid value for hmacEncoding: %s", md.Encoding)
+	}
+
+	var signedHeaders []string
+	if md.TimestampHeader != "" {
+		signedHeaders = append(signedHeaders, md.TimestampHeader)
+	}
+	for _, header := range strings.Split(md.SignedHeaders, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			signedHeaders = append(signedHeaders, header)
+		}
+	}
+
+	return func(req *http.Request, body []byte) error {
+		if md.TimestampHeader != "" {
+			req.Header.Set(md.TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+		}
+
+		mac := hmac.New(newHash, []byte(md.Secret))
+		mac.Write([]byte(req.Method + "\n" + req.URL.RequestURI() + "\n"))
+		for _, header := range signedHeaders {
+			mac.Write([]byte(strings.ToLower(header) + ":" + strings.Join(req.Header.Values(header), ",") + "\n"))
+		}
+		mac.Write(body)
+
+		req.Header.Set(md.Header, md.SignaturePrefix+encode(mac.Sum(nil)))
+		return nil
+	}, nil
+}
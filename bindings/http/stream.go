@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+
+	// Metadata of the chunks of streamed response bodies.
+	metadataOffset = "offset"
+	// Metadata of the events of event streams.
+	metadataEvent = "event"
+	metadataID    = "id"
+	metadataRetry = "retry"
+)
+
+var _ bindings.StreamingOutputBinding = (*HTTPSource)(nil)
+
+// InvokeStream performs an HTTP request to the configured HTTP endpoint, delivering the response body to the handler
+// in chunks as it's received. If the response is an event stream (text/event-stream), the handler is called with the
+// data of each server-sent event instead, as soon as it's dispatched.
+// Non-2XX responses that are errors are not streamed: they are returned with their body, like with Invoke.
+func (h *HTTPSource) InvokeStream(parentCtx context.Context, req *bindings.InvokeRequest, handler bindings.StreamHandler) (*bindings.InvokeResponse, error) {
+	ctx := parentCtx
+	if h.metadata.ResponseTimeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parentCtx, *h.metadata.ResponseTimeout)
+		defer cancel()
+	}
+
+	request, errorIfNot2XX, err := h.newRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if errorIfNot2XX && resp.StatusCode/100 != 2 {
+		defer func() {
+			// Drain before closing
+			_, _ = io.Copy(io.Discard, resp.Body)
+		}()
+		return h.readResponse(resp, errorIfNot2XX)
+	}
+
+	if isEventStream(resp) {
+		err = h.streamEvents(ctx, resp.Body, handler)
+	} else {
+		err = h.streamChunks(ctx, resp.Body, handler)
+	}
+
+	return &bindings.InvokeResponse{
+		Metadata: responseMetadata(resp),
+	}, err
+}
+
+// streamChunks calls the handler with the data read from the body, in chunks of at most the configured size.
+// Chunks are delivered as soon as they are read, without waiting for them to be full.
+func (h *HTTPSource) streamChunks(ctx context.Context, body io.Reader, handler bindings.StreamHandler) error {
+	buf := make([]byte, h.metadata.streamChunkSizeBytes)
+	var offset int64
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			handlerErr := handler(ctx, &bindings.InvokeResponse{
+				Data: bytes.Clone(buf[:n]),
+				Metadata: map[string]string{
+					metadataOffset: strconv.FormatInt(offset, 10),
+				},
+			})
+			if handlerErr != nil {
+				return handlerErr
+			}
+			offset += int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// streamEvents parses the body as an event stream and calls the handler with each event it dispatches.
+// Lines and events larger than the maximum response body size are errors.
+// See: https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+func (h *HTTPSource) streamEvents(ctx context.Context, body io.Reader, handler bindings.StreamHandler) error {
+	maxSize := h.metadata.maxResponseBodySizeBytes
+	if maxSize <= 0 || maxSize > math.MaxInt {
+		maxSize = math.MaxInt
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, min(maxSize, bufio.MaxScanTokenSize)), int(maxSize))
+	scanner.Split(scanEventStreamLines)
+
+	var (
+		eventType   string
+		data        []byte
+		lastEventID string
+		retry       string
+		first       = true
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			// The stream may start with a byte order mark
+			line = strings.TrimPrefix(line, "\ufeff")
+			first = false
+		}
+
+		if line == "" {
+			// A blank line dispatches the event, if it has data
+			if len(data) > 0 {
+				md := map[string]string{
+					metadataEvent: "message",
+				}
+				if eventType != "" {
+					md[metadataEvent] = eventType
+				}
+				if lastEventID != "" {
+					md[metadataID] = lastEventID
+				}
+				if retry != "" {
+					md[metadataRetry] = retry
+				}
+				err := handler(ctx, &bindings.InvokeResponse{
+					Data:     bytes.TrimSuffix(data, []byte{'\n'}),
+					Metadata: md,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			eventType, data, retry = "", nil, ""
+			continue
+		}
+
+		// Lines starting with a colon are comments
+		field, value, _ := strings.Cut(line, ":")
+		if field == "" {
+			continue
+		}
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			if int64(len(data)+len(value)+1) > maxSize {
+				return fmt.Errorf("event larger than the maximum response body size of %d bytes", maxSize)
+			}
+			data = append(data, value...)
+			data = append(data, '\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastEventID = value
+			}
+		case "retry":
+			if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+				retry = value
+			}
+		default:
+			// Unknown fields are ignored
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading event stream: %w", err)
+	}
+
+	// An event that is not followed by a blank line at the end of the stream is discarded
+	return nil
+}
+
+// scanEventStreamLines is a bufio.SplitFunc for the lines of event streams, which end with CRLF, LF or CR.
+func scanEventStreamLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		// A CR may be followed by a LF, which needs more data to know
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// isEventStream returns true if the response is an event stream.
+func isEventStream(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == eventStreamContentType
+}
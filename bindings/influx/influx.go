@@ -19,6 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api"
@@ -29,17 +32,28 @@ import (
 	kitmd "github.com/dapr/kit/metadata"
 )
 
-const queryOperation bindings.OperationKind = "query"
+const (
+	queryOperation bindings.OperationKind = "query"
+	writeOperation bindings.OperationKind = "write"
+)
 
 const (
 	rawQueryKey     = "raw"
+	formatKey       = "format"
 	respOperatorKey = "operation"
+	respPointsKey   = "points"
+
+	// Formats of the results of queries.
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	defaultBatchSize = 5000
 )
 
 var (
 	ErrInvalidRequestData      = errors.New("influx error: Cannot convert request data")
 	ErrCannotWriteRecord       = errors.New("influx error: Cannot write point")
-	ErrInvalidRequestOperation = errors.New("invalid operation type. Expected " + string(queryOperation) + " or " + string(bindings.CreateOperation))
+	ErrInvalidRequestOperation = errors.New("invalid operation type. Expected " + string(queryOperation) + ", " + string(writeOperation) + " or " + string(bindings.CreateOperation))
 	ErrMetadataMissing         = errors.New("metadata required")
 	ErrMetadataRawNotFound     = errors.New("required metadata not set: " + rawQueryKey)
 )
@@ -58,6 +72,12 @@ type influxMetadata struct {
 	Token  string `json:"token"`
 	Org    string `json:"org"`
 	Bucket string `json:"bucket"`
+	// Precision of the timestamps of the points written: ns, us, ms or s.
+	Precision string `json:"precision"`
+	// Compress the points written with gzip.
+	UseGzip bool `json:"useGzip"`
+	// Maximum number of points sent in a request by the write operation.
+	BatchSize int `json:"batchSize"`
 }
 
 // NewInflux returns a new kafka binding instance.
@@ -89,7 +109,19 @@ func (i *Influx) Init(_ context.Context, metadata bindings.Metadata) error {
 		return errors.New("influx error: Bucket required")
 	}
 
-	client := influxdb2.NewClient(i.metadata.URL, i.metadata.Token)
+	precision, err := parsePrecision(i.metadata.Precision)
+	if err != nil {
+		return err
+	}
+
+	if i.metadata.BatchSize <= 0 {
+		i.metadata.BatchSize = defaultBatchSize
+	}
+
+	options := influxdb2.DefaultOptions().
+		SetPrecision(precision).
+		SetUseGZip(i.metadata.UseGzip)
+	client := influxdb2.NewClientWithOptions(i.metadata.URL, i.metadata.Token, options)
 	i.client = client
 	i.writeAPI = i.client.WriteAPIBlocking(i.metadata.Org, i.metadata.Bucket)
 	i.queryAPI = i.client.QueryAPI(i.metadata.Org)
@@ -108,9 +140,25 @@ func (i *Influx) getInfluxMetadata(meta bindings.Metadata) (*influxMetadata, err
 	return &iMetadata, nil
 }
 
+// parsePrecision returns the precision of the timestamps of the points written.
+func parsePrecision(precision string) (time.Duration, error) {
+	switch precision {
+	case "", "ns":
+		return time.Nanosecond, nil
+	case "us":
+		return time.Microsecond, nil
+	case "ms":
+		return time.Millisecond, nil
+	case "s":
+		return time.Second, nil
+	default:
+		return 0, fmt.Errorf("influx error: invalid precision %q, expected ns, us, ms or s", precision)
+	}
+}
+
 // Operations returns supported operations.
 func (i *Influx) Operations() []bindings.OperationKind {
-	return []bindings.OperationKind{bindings.CreateOperation, queryOperation}
+	return []bindings.OperationKind{bindings.CreateOperation, writeOperation, queryOperation}
 }
 
 // Invoke called on supported operations.
@@ -131,6 +179,8 @@ func (i *Influx) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bind
 			return nil, ErrCannotWriteRecord
 		}
 		return nil, nil
+	case writeOperation:
+		return i.write(ctx, req)
 	case queryOperation:
 		if req.Metadata == nil {
 			return nil, ErrMetadataMissing
@@ -141,13 +191,26 @@ func (i *Influx) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bind
 			return nil, ErrMetadataRawNotFound
 		}
 
-		res, err := i.queryAPI.QueryRaw(ctx, s, influxdb2.DefaultDialect())
-		if err != nil {
-			return nil, fmt.Errorf("do query influx err: %w", err)
+		var res []byte
+		switch req.Metadata[formatKey] {
+		case "", formatCSV:
+			csv, err := i.queryAPI.QueryRaw(ctx, s, influxdb2.DefaultDialect())
+			if err != nil {
+				return nil, fmt.Errorf("do query influx err: %w", err)
+			}
+			res = []byte(csv)
+		case formatJSON:
+			var err error
+			res, err = i.queryJSON(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("influx error: invalid format %q, expected %s or %s", req.Metadata[formatKey], formatCSV, formatJSON)
 		}
 
 		resp := &bindings.InvokeResponse{
-			Data: []byte(res),
+			Data: res,
 			Metadata: map[string]string{
 				respOperatorKey: string(req.Operation),
 				rawQueryKey:     s,
@@ -160,6 +223,57 @@ func (i *Influx) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bind
 	}
 }
 
+// write writes the points of the request data, in line protocol with one point per line, in batches of at most
+// batchSize points.
+// See: https://docs.influxdata.com/influxdb/v2/reference/syntax/line-protocol/
+func (i *Influx) write(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var lines []string
+	for _, line := range strings.Split(string(req.Data), "\n") {
+		line = strings.TrimSpace(line)
+		// Skip empty lines and comments
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, ErrInvalidRequestData
+	}
+
+	for start := 0; start < len(lines); start += i.metadata.BatchSize {
+		end := min(start+i.metadata.BatchSize, len(lines))
+		err := i.writeAPI.WriteRecord(ctx, lines[start:end]...)
+		if err != nil {
+			return nil, fmt.Errorf("influx error: cannot write points %d to %d: %w", start, end-1, err)
+		}
+	}
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			respOperatorKey: string(req.Operation),
+			respPointsKey:   strconv.Itoa(len(lines)),
+		},
+	}, nil
+}
+
+// queryJSON runs a Flux query and returns its records as a JSON array of objects, with the values of their columns.
+func (i *Influx) queryJSON(ctx context.Context, q string) ([]byte, error) {
+	result, err := i.queryAPI.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("do query influx err: %w", err)
+	}
+	defer result.Close()
+
+	records := make([]map[string]any, 0)
+	for result.Next() {
+		records = append(records, result.Record().Values())
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("do query influx err: %w", result.Err())
+	}
+
+	return json.Marshal(records)
+}
+
 func (i *Influx) Close() error {
 	i.client.Close()
 	i.writeAPI = nil
@@ -14,10 +14,14 @@ limitations under the License.
 package influx
 
 import (
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -42,6 +46,7 @@ func TestOperations(t *testing.T) {
 	opers := (*Influx)(nil).Operations()
 	assert.Equal(t, []bindings.OperationKind{
 		bindings.CreateOperation,
+		writeOperation,
 		queryOperation,
 	}, opers)
 }
@@ -61,6 +66,78 @@ func TestInflux_Init(t *testing.T) {
 	assert.NotNil(t, influx.writeAPI)
 	assert.NotNil(t, influx.metadata)
 	assert.NotNil(t, influx.client)
+	assert.Equal(t, defaultBatchSize, influx.metadata.BatchSize)
+
+	m.Properties["precision"] = "ms"
+	m.Properties["useGzip"] = "true"
+	err = influx.Init(t.Context(), m)
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, influx.client.Options().Precision())
+	assert.True(t, influx.client.Options().UseGZip())
+
+	m.Properties["precision"] = "h"
+	err = influx.Init(t.Context(), m)
+	require.Error(t, err)
+}
+
+func TestInflux_Invoke_BindingWriteOperation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := NewMockWriteAPIBlocking(ctrl)
+	gomock.InOrder(
+		w.EXPECT().WriteRecord(gomock.Eq(t.Context()), "cpu,host=a usage=1 1", "cpu,host=b usage=2 2").Return(nil),
+		w.EXPECT().WriteRecord(gomock.Eq(t.Context()), "cpu,host=c usage=3 3").Return(nil),
+	)
+	influx := &Influx{
+		writeAPI: w,
+		metadata: &influxMetadata{BatchSize: 2},
+	}
+
+	resp, err := influx.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: writeOperation,
+		Data:      []byte("# comment\ncpu,host=a usage=1 1\n\ncpu,host=b usage=2 2\r\ncpu,host=c usage=3 3\n"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{respOperatorKey: "write", respPointsKey: "3"}, resp.Metadata)
+
+	_, err = influx.Invoke(t.Context(), &bindings.InvokeRequest{Operation: writeOperation, Data: []byte("\n")})
+	require.ErrorIs(t, err, ErrInvalidRequestData)
+}
+
+func TestInflux_Invoke_BindingQueryJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	csv := `#datatype,string,long,dateTime:RFC3339,double,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,_field
+,,0,2026-01-01T00:00:00Z,1.5,usage
+,,0,2026-01-01T00:01:00Z,2.5,usage
+`
+	q := NewMockQueryAPI(ctrl)
+	q.EXPECT().Query(gomock.Eq(t.Context()), "a").Return(api.NewQueryTableResult(io.NopCloser(strings.NewReader(csv))), nil)
+	influx := &Influx{
+		queryAPI: q,
+		logger:   logger.NewLogger("test"),
+	}
+
+	resp, err := influx.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: queryOperation,
+		Metadata:  map[string]string{"raw": "a", "format": "json"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"result": "_result", "table": 0, "_time": "2026-01-01T00:00:00Z", "_value": 1.5, "_field": "usage"},
+		{"result": "_result", "table": 0, "_time": "2026-01-01T00:01:00Z", "_value": 2.5, "_field": "usage"}
+	]`, string(resp.Data))
+
+	_, err = influx.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: queryOperation,
+		Metadata:  map[string]string{"raw": "a", "format": "xml"},
+	})
+	require.Error(t, err)
 }
 
 func TestInflux_Invoke_BindingCreateOperation(t *testing.T) {
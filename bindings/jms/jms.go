@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	sendOperation bindings.OperationKind = "send"
+
+	// Prefixes of the destinations, telling queues and topics apart: destinations without prefix are queues.
+	queuePrefix = "queue:"
+	topicPrefix = "topic:"
+
+	// Link capabilities telling the broker the type of the destination, as defined by the JMS mapping of AMQP.
+	queueCapability = "queue"
+	topicCapability = "topic"
+
+	defaultReconnectInterval = 5 * time.Second
+	closeTimeout             = 5 * time.Second
+)
+
+// JMS is a binding sending and receiving JMS messages over AMQP 1.0, with brokers such as ActiveMQ Artemis.
+type JMS struct {
+	metadata jmsMetadata
+	logger   logger.Logger
+	closeCh  chan struct{}
+	closed   atomic.Bool
+	wg       sync.WaitGroup
+
+	// Connection of the output binding, reopened when it's lost, and its senders by address.
+	lock    sync.Mutex
+	conn    *amqp.Conn
+	session *amqp.Session
+	senders map[string]*amqp.Sender
+}
+
+type jmsMetadata struct {
+	// URL of the broker, such as amqp://localhost:5672 or amqps://broker:5671.
+	URL      string `mapstructure:"url"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// PEM-encoded certificate of the CA of the broker, and certificate and key of the client, for amqps URLs.
+	CACert     string `mapstructure:"caCert"`
+	ClientCert string `mapstructure:"clientCert"`
+	ClientKey  string `mapstructure:"clientKey"`
+	// JMS client ID, sent as the container ID of the connection: required for durable subscriptions.
+	ClientID string `mapstructure:"clientID"`
+
+	// Destination messages are sent to and received from: "queue:name" or "topic:name", or a queue name.
+	Destination string `mapstructure:"destination"`
+	// Prefixes of the addresses of queues and topics, for brokers such as IBM MQ whose addresses have the type of
+	// the destination.
+	QueueAddressPrefix string `mapstructure:"queueAddressPrefix"`
+	TopicAddressPrefix string `mapstructure:"topicAddressPrefix"`
+
+	// Input binding: JMS message selector, a SQL-92 expression on the headers and properties of the messages.
+	Selector string `mapstructure:"selector"`
+	// Input binding: name of the durable subscription of a topic, retaining messages while the app is disconnected.
+	SubscriptionName string `mapstructure:"subscriptionName"`
+	// Input binding: maximum number of messages the broker sends before they're acknowledged.
+	Prefetch int32 `mapstructure:"prefetch"`
+	// Input binding: time waited before connecting again when the connection is lost.
+	ReconnectInterval time.Duration `mapstructure:"reconnectInterval"`
+
+	// Default JMS message type of the messages sent: text or bytes.
+	MessageType string `mapstructure:"messageType"`
+	// Default delivery mode of the messages sent: persistent messages survive broker restarts.
+	Persistent bool `mapstructure:"persistent"`
+}
+
+// NewJMS returns a new JMS binding.
+func NewJMS(logger logger.Logger) bindings.InputOutputBinding {
+	return &JMS{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+		senders: map[string]*amqp.Sender{},
+	}
+}
+
+func (j *JMS) Init(ctx context.Context, meta bindings.Metadata) error {
+	err := j.parseMetadata(meta)
+	if err != nil {
+		return fmt.Errorf("jms binding error: %w", err)
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	err = j.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("jms binding error: %w", err)
+	}
+
+	return nil
+}
+
+func (j *JMS) parseMetadata(meta bindings.Metadata) error {
+	// Set default values
+	j.metadata = jmsMetadata{
+		MessageType:       messageTypeText,
+		Persistent:        true,
+		ReconnectInterval: defaultReconnectInterval,
+	}
+
+	// Decode
+	err := kitmd.DecodeMetadata(meta.Properties, &j.metadata)
+	if err != nil {
+		return err
+	}
+
+	// Validate
+	if j.metadata.URL == "" {
+		return errors.New("missing url")
+	}
+	u, err := url.Parse(j.metadata.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		return fmt.Errorf("invalid url scheme %s: use amqp or amqps", u.Scheme)
+	}
+	for name, val := range map[string]string{"caCert": j.metadata.CACert, "clientCert": j.metadata.ClientCert, "clientKey": j.metadata.ClientKey} {
+		if val != "" {
+			if block, _ := pem.Decode([]byte(val)); block == nil {
+				return fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+	j.metadata.MessageType = strings.ToLower(j.metadata.MessageType)
+	if j.metadata.MessageType != messageTypeText && j.metadata.MessageType != messageTypeBytes {
+		return fmt.Errorf("invalid messageType %s: use text or bytes", j.metadata.MessageType)
+	}
+	if j.metadata.SubscriptionName != "" {
+		if j.metadata.ClientID == "" {
+			return errors.New("missing clientID, required for durable subscriptions")
+		}
+		if !strings.HasPrefix(j.metadata.Destination, topicPrefix) {
+			return errors.New("durable subscriptions require a topic destination")
+		}
+	}
+	if j.metadata.Prefetch < 0 {
+		return errors.New("prefetch must not be negative")
+	}
+	if j.metadata.ReconnectInterval <= 0 {
+		j.metadata.ReconnectInterval = defaultReconnectInterval
+	}
+
+	return nil
+}
+
+// dial opens a connection to the broker, with a session.
+func (j *JMS) dial(ctx context.Context) (*amqp.Conn, *amqp.Session, error) {
+	opts := &amqp.ConnOptions{
+		ContainerID: j.metadata.ClientID,
+	}
+	if j.metadata.Username != "" {
+		opts.SASLType = amqp.SASLTypePlain(j.metadata.Username, j.metadata.Password)
+	} else {
+		opts.SASLType = amqp.SASLTypeAnonymous()
+	}
+	if strings.HasPrefix(j.metadata.URL, "amqps:") {
+		tlsConfig, err := j.tlsConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	conn, err := amqp.Dial(ctx, j.metadata.URL, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to the broker: %w", err)
+	}
+	session, err := conn.NewSession(ctx, nil)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("error creating session: %w", err)
+	}
+	return conn, session, nil
+}
+
+func (j *JMS) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if j.metadata.CACert != "" {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(j.metadata.CACert)) {
+			return nil, errors.New("unable to load caCert")
+		}
+	}
+	if j.metadata.ClientCert != "" && j.metadata.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(j.metadata.ClientCert), []byte(j.metadata.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate and key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// connect opens the connection of the output binding: the lock must be held.
+func (j *JMS) connect(ctx context.Context) error {
+	conn, session, err := j.dial(ctx)
+	if err != nil {
+		return err
+	}
+	j.conn = conn
+	j.session = session
+	j.senders = map[string]*amqp.Sender{}
+	return nil
+}
+
+// disconnect closes the connection of the output binding: the lock must be held.
+func (j *JMS) disconnect() error {
+	if j.conn == nil {
+		return nil
+	}
+	err := j.conn.Close()
+	j.conn = nil
+	j.session = nil
+	j.senders = map[string]*amqp.Sender{}
+	return err
+}
+
+// address returns the address of a destination, and the capability of its type.
+func (j *JMS) address(destination string) (string, string, error) {
+	var name, capability, prefix string
+	switch {
+	case strings.HasPrefix(destination, topicPrefix):
+		name, capability, prefix = strings.TrimPrefix(destination, topicPrefix), topicCapability, j.metadata.TopicAddressPrefix
+	case strings.HasPrefix(destination, queuePrefix):
+		name, capability, prefix = strings.TrimPrefix(destination, queuePrefix), queueCapability, j.metadata.QueueAddressPrefix
+	default:
+		name, capability, prefix = destination, queueCapability, j.metadata.QueueAddressPrefix
+	}
+	if name == "" {
+		return "", "", errors.New("missing destination")
+	}
+	return prefix + name, capability, nil
+}
+
+func (j *JMS) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation, sendOperation}
+}
+
+// Invoke sends the data of the request as a JMS message, to the destination of the request or of the component.
+func (j *JMS) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case bindings.CreateOperation, sendOperation:
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
+	}
+	if j.closed.Load() {
+		return nil, errors.New("jms binding error: binding is closed")
+	}
+
+	destination := j.metadata.Destination
+	if val := req.Metadata[metadataDestination]; val != "" {
+		destination = val
+	}
+	addr, capability, err := j.address(destination)
+	if err != nil {
+		return nil, fmt.Errorf("jms binding error: %w", err)
+	}
+	msg, err := j.newMessage(req, capability)
+	if err != nil {
+		return nil, fmt.Errorf("jms binding error: %w", err)
+	}
+
+	err = j.send(ctx, addr, capability, msg)
+	if err != nil {
+		return nil, fmt.Errorf("jms binding error: error sending message to %s: %w", destination, err)
+	}
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			metadataMessageID: msg.Properties.MessageID.(string),
+		},
+	}, nil
+}
+
+// send sends a message, waiting for the broker to accept it.
+// If the link or the connection was lost, it's opened again and the message is sent again once.
+func (j *JMS) send(ctx context.Context, addr string, capability string, msg *amqp.Message) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if j.conn == nil {
+			err = j.connect(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		sender, ok := j.senders[addr]
+		if !ok {
+			sender, err = j.session.NewSender(ctx, addr, &amqp.SenderOptions{
+				TargetCapabilities: []string{capability},
+			})
+			if err != nil {
+				if !isConnectionLost(err) {
+					return err
+				}
+				j.disconnect()
+				continue
+			}
+			j.senders[addr] = sender
+		}
+
+		err = sender.Send(ctx, msg, nil)
+		if err == nil {
+			return nil
+		}
+		var linkErr *amqp.LinkError
+		switch {
+		case isConnectionLost(err):
+			j.logger.Warnf("JMS connection lost, reconnecting: %v", err)
+			j.disconnect()
+		case errors.As(err, &linkErr):
+			delete(j.senders, addr)
+		default:
+			return err
+		}
+	}
+	return err
+}
+
+func isConnectionLost(err error) bool {
+	var connErr *amqp.ConnError
+	var sessionErr *amqp.SessionError
+	return errors.As(err, &connErr) || errors.As(err, &sessionErr)
+}
+
+func (j *JMS) Close() error {
+	if j.closed.CompareAndSwap(false, true) {
+		close(j.closeCh)
+	}
+	j.wg.Wait()
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.disconnect()
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (j *JMS) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := jmsMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
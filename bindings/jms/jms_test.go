@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func newTestJMS(t *testing.T, props map[string]string) *JMS {
+	t.Helper()
+
+	j := NewJMS(logger.NewLogger("test")).(*JMS)
+	err := j.parseMetadata(bindings.Metadata{Base: contribMetadata.Base{Properties: props}})
+	require.NoError(t, err)
+	return j
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		j := newTestJMS(t, map[string]string{
+			"url":         "amqp://localhost:5672",
+			"destination": "orders",
+		})
+		assert.Equal(t, messageTypeText, j.metadata.MessageType)
+		assert.True(t, j.metadata.Persistent)
+		assert.Equal(t, defaultReconnectInterval, j.metadata.ReconnectInterval)
+	})
+
+	t.Run("all fields", func(t *testing.T) {
+		j := newTestJMS(t, map[string]string{
+			"url":               "amqps://broker:5671",
+			"username":          "user",
+			"password":          "pass",
+			"clientID":          "app",
+			"destination":       "topic:prices",
+			"selector":          "region = 'EU'",
+			"subscriptionName":  "prices-eu",
+			"prefetch":          "50",
+			"reconnectInterval": "10s",
+			"messageType":       "Bytes",
+			"persistent":        "false",
+		})
+		assert.Equal(t, "app", j.metadata.ClientID)
+		assert.Equal(t, "region = 'EU'", j.metadata.Selector)
+		assert.Equal(t, int32(50), j.metadata.Prefetch)
+		assert.Equal(t, 10*time.Second, j.metadata.ReconnectInterval)
+		assert.Equal(t, messageTypeBytes, j.metadata.MessageType)
+		assert.False(t, j.metadata.Persistent)
+	})
+
+	tests := map[string]struct {
+		props map[string]string
+		err   string
+	}{
+		"missing url": {
+			props: map[string]string{"destination": "orders"},
+			err:   "missing url",
+		},
+		"invalid scheme": {
+			props: map[string]string{"url": "tcp://localhost:61616"},
+			err:   "invalid url scheme",
+		},
+		"invalid message type": {
+			props: map[string]string{"url": "amqp://localhost", "messageType": "map"},
+			err:   "invalid messageType",
+		},
+		"invalid caCert": {
+			props: map[string]string{"url": "amqps://localhost", "caCert": "not a certificate"},
+			err:   "invalid caCert",
+		},
+		"durable subscription without client ID": {
+			props: map[string]string{"url": "amqp://localhost", "destination": "topic:prices", "subscriptionName": "sub"},
+			err:   "missing clientID",
+		},
+		"durable subscription of a queue": {
+			props: map[string]string{"url": "amqp://localhost", "destination": "orders", "subscriptionName": "sub", "clientID": "app"},
+			err:   "require a topic destination",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			j := NewJMS(logger.NewLogger("test")).(*JMS)
+			err := j.parseMetadata(bindings.Metadata{Base: contribMetadata.Base{Properties: tc.props}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestAddress(t *testing.T) {
+	j := newTestJMS(t, map[string]string{
+		"url":                "amqp://localhost",
+		"queueAddressPrefix": "/queue/",
+		"topicAddressPrefix": "/topic/",
+	})
+
+	tests := []struct {
+		destination string
+		address     string
+		capability  string
+	}{
+		{"orders", "/queue/orders", queueCapability},
+		{"queue:orders", "/queue/orders", queueCapability},
+		{"topic:prices", "/topic/prices", topicCapability},
+	}
+	for _, tc := range tests {
+		t.Run(tc.destination, func(t *testing.T) {
+			addr, capability, err := j.address(tc.destination)
+			require.NoError(t, err)
+			assert.Equal(t, tc.address, addr)
+			assert.Equal(t, tc.capability, capability)
+		})
+	}
+
+	_, _, err := j.address("topic:")
+	require.Error(t, err)
+}
+
+func TestNewMessage(t *testing.T) {
+	j := newTestJMS(t, map[string]string{
+		"url":         "amqp://localhost",
+		"destination": "orders",
+	})
+
+	t.Run("text message", func(t *testing.T) {
+		msg, err := j.newMessage(&bindings.InvokeRequest{
+			Data: []byte(`{"id":1}`),
+			Metadata: map[string]string{
+				metadataCorrelationID: "corr-1",
+				metadataJMSType:       "OrderCreated",
+				metadataPriority:      "7",
+				metadataReplyTo:       "topic:replies",
+				"ttlInSeconds":        "60",
+				"region":              "EU",
+			},
+		}, queueCapability)
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"id":1}`, msg.Value)
+		assert.Equal(t, jmsTextMessage, msg.Annotations[annotationMessageType])
+		assert.Equal(t, jmsQueue, msg.Annotations[annotationDestinationType])
+		assert.Equal(t, jmsTopic, msg.Annotations[annotationReplyToType])
+		assert.True(t, msg.Header.Durable)
+		assert.Equal(t, uint8(7), msg.Header.Priority)
+		assert.Equal(t, time.Minute, msg.Header.TTL)
+		assert.True(t, strings.HasPrefix(msg.Properties.MessageID.(string), "ID:"))
+		assert.Equal(t, "corr-1", msg.Properties.CorrelationID)
+		assert.Equal(t, "OrderCreated", *msg.Properties.Subject)
+		assert.Equal(t, "replies", *msg.Properties.ReplyTo)
+		assert.Equal(t, map[string]any{"region": "EU"}, msg.ApplicationProperties)
+	})
+
+	t.Run("bytes message", func(t *testing.T) {
+		msg, err := j.newMessage(&bindings.InvokeRequest{
+			Data: []byte{0x01, 0x02},
+			Metadata: map[string]string{
+				metadataMessageType: "bytes",
+				metadataPersistent:  "false",
+			},
+		}, topicCapability)
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]byte{{0x01, 0x02}}, msg.Data)
+		assert.Nil(t, msg.Value)
+		assert.Equal(t, jmsBytesMessage, msg.Annotations[annotationMessageType])
+		assert.Equal(t, jmsTopic, msg.Annotations[annotationDestinationType])
+		assert.Equal(t, "application/octet-stream", *msg.Properties.ContentType)
+		assert.False(t, msg.Header.Durable)
+		assert.Equal(t, uint8(defaultPriority), msg.Header.Priority)
+		assert.Empty(t, msg.ApplicationProperties)
+	})
+
+	t.Run("invalid priority", func(t *testing.T) {
+		_, err := j.newMessage(&bindings.InvokeRequest{
+			Metadata: map[string]string{metadataPriority: "10"},
+		}, queueCapability)
+		require.Error(t, err)
+	})
+}
+
+func TestReadResponse(t *testing.T) {
+	subject := "OrderCreated"
+	replyTo := "replies"
+	msg := &amqp.Message{
+		Header: &amqp.MessageHeader{
+			Durable:       true,
+			Priority:      6,
+			DeliveryCount: 1,
+		},
+		Properties: &amqp.MessageProperties{
+			MessageID:     "ID:1",
+			CorrelationID: "corr-1",
+			ReplyTo:       &replyTo,
+			Subject:       &subject,
+		},
+		ApplicationProperties: map[string]any{
+			"region": "EU",
+			"count":  int32(3),
+		},
+		Value: "hello",
+	}
+
+	res := readResponse(msg, "queue:orders")
+	assert.Equal(t, []byte("hello"), res.Data)
+	assert.Equal(t, map[string]string{
+		metadataDestination:   "queue:orders",
+		metadataMessageID:     "ID:1",
+		metadataCorrelationID: "corr-1",
+		metadataReplyTo:       "replies",
+		metadataJMSType:       "OrderCreated",
+		metadataPriority:      "6",
+		metadataPersistent:    "true",
+		metadataRedelivered:   "true",
+		"region":              "EU",
+		"count":               "3",
+	}, res.Metadata)
+
+	res = readResponse(&amqp.Message{Data: [][]byte{[]byte("he"), []byte("llo")}}, "orders")
+	assert.Equal(t, []byte("hello"), res.Data)
+	assert.Equal(t, "false", res.Metadata[metadataRedelivered])
+}
+
+func TestReceiverOptions(t *testing.T) {
+	j := newTestJMS(t, map[string]string{
+		"url":              "amqp://localhost",
+		"clientID":         "app",
+		"destination":      "topic:prices",
+		"selector":         "region = 'EU'",
+		"subscriptionName": "prices-eu",
+		"prefetch":         "20",
+	})
+
+	opts := j.receiverOptions(topicCapability)
+	assert.Equal(t, []string{topicCapability}, opts.SourceCapabilities)
+	assert.Equal(t, int32(20), opts.Credit)
+	assert.Len(t, opts.Filters, 1)
+	assert.Equal(t, "prices-eu", opts.Name)
+	assert.Equal(t, amqp.DurabilityUnsettledState, opts.Durability)
+	assert.Equal(t, amqp.ExpiryPolicyNever, opts.ExpiryPolicy)
+
+	j = newTestJMS(t, map[string]string{
+		"url":         "amqp://localhost",
+		"destination": "orders",
+	})
+	opts = j.receiverOptions(queueCapability)
+	assert.Empty(t, opts.Filters)
+	assert.Empty(t, opts.Name)
+}
+
+func TestInvokeUnsupportedOperation(t *testing.T) {
+	j := newTestJMS(t, map[string]string{
+		"url":         "amqp://localhost",
+		"destination": "orders",
+	})
+	_, err := j.Invoke(t.Context(), &bindings.InvokeRequest{Operation: bindings.GetOperation})
+	require.Error(t, err)
+}
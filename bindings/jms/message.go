@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	amqp "github.com/Azure/go-amqp"
+	"github.com/google/uuid"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	kitstrings "github.com/dapr/kit/strings"
+)
+
+const (
+	// JMS message types.
+	messageTypeText  = "text"
+	messageTypeBytes = "bytes"
+
+	// Metadata of the requests and of the messages delivered to the app: the other metadata of the requests are sent
+	// as properties of the messages, and the properties of the messages received are delivered as metadata.
+	metadataDestination   = "destination"
+	metadataMessageID     = "messageID"
+	metadataCorrelationID = "correlationID"
+	metadataReplyTo       = "replyTo"
+	metadataJMSType       = "jmsType"
+	metadataPriority      = "priority"
+	metadataPersistent    = "persistent"
+	metadataMessageType   = "messageType"
+	metadataRedelivered   = "redelivered"
+
+	// Message annotations of the JMS mapping of AMQP, read by the JMS clients of the brokers.
+	// See: https://www.oasis-open.org/committees/download.php/53086/amqp-bindmap-jms-v1.0-wd05.pdf
+	annotationMessageType     = "x-opt-jms-msg-type"
+	annotationDestinationType = "x-opt-jms-dest"
+	annotationReplyToType     = "x-opt-jms-reply-to"
+
+	// Values of the x-opt-jms-msg-type annotation.
+	jmsBytesMessage int8 = 3
+	jmsTextMessage  int8 = 5
+
+	// Values of the x-opt-jms-dest and x-opt-jms-reply-to annotations.
+	jmsQueue int8 = 0
+	jmsTopic int8 = 1
+
+	defaultPriority = 4
+	maxPriority     = 9
+)
+
+// Metadata of the requests that are not sent as properties of the messages.
+var reservedMetadata = map[string]struct{}{
+	metadataDestination:                     {},
+	metadataMessageID:                       {},
+	metadataCorrelationID:                   {},
+	metadataReplyTo:                         {},
+	metadataJMSType:                         {},
+	metadataPriority:                        {},
+	metadataPersistent:                      {},
+	metadataMessageType:                     {},
+	contribMetadata.TTLMetadataKey:          {},
+	contribMetadata.TTLInSecondsMetadataKey: {},
+}
+
+// newMessage returns the JMS message of a request, to a destination with the given capability.
+func (j *JMS) newMessage(req *bindings.InvokeRequest, capability string) (*amqp.Message, error) {
+	messageType := j.metadata.MessageType
+	if val := req.Metadata[metadataMessageType]; val != "" {
+		messageType = strings.ToLower(val)
+	}
+	persistent := j.metadata.Persistent
+	if val := req.Metadata[metadataPersistent]; val != "" {
+		persistent = kitstrings.IsTruthy(val)
+	}
+	priority := uint8(defaultPriority)
+	if val := req.Metadata[metadataPriority]; val != "" {
+		p, err := strconv.ParseUint(val, 10, 8)
+		if err != nil || p > maxPriority {
+			return nil, fmt.Errorf("invalid priority %s: must be between 0 and %d", val, maxPriority)
+		}
+		priority = uint8(p)
+	}
+
+	destinationType := jmsQueue
+	if capability == topicCapability {
+		destinationType = jmsTopic
+	}
+	msg := &amqp.Message{
+		Header: &amqp.MessageHeader{
+			Durable:  persistent,
+			Priority: priority,
+		},
+		Annotations: amqp.Annotations{
+			annotationDestinationType: destinationType,
+		},
+		Properties: &amqp.MessageProperties{
+			MessageID: "ID:" + uuid.NewString(),
+		},
+		ApplicationProperties: map[string]any{},
+	}
+
+	switch messageType {
+	case messageTypeText:
+		msg.Annotations[annotationMessageType] = jmsTextMessage
+		msg.Value = string(req.Data)
+	case messageTypeBytes:
+		msg.Annotations[annotationMessageType] = jmsBytesMessage
+		contentType := "application/octet-stream"
+		msg.Properties.ContentType = &contentType
+		msg.Data = [][]byte{req.Data}
+	default:
+		return nil, fmt.Errorf("invalid messageType %s: use text or bytes", messageType)
+	}
+
+	ttl, ok, err := contribMetadata.TryGetTTL(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		msg.Header.TTL = ttl
+	}
+	if val := req.Metadata[metadataCorrelationID]; val != "" {
+		msg.Properties.CorrelationID = val
+	}
+	if val := req.Metadata[metadataJMSType]; val != "" {
+		msg.Properties.Subject = &val
+	}
+	if val := req.Metadata[metadataReplyTo]; val != "" {
+		addr, replyToCapability, err := j.address(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replyTo: %w", err)
+		}
+		msg.Properties.ReplyTo = &addr
+		msg.Annotations[annotationReplyToType] = jmsQueue
+		if replyToCapability == topicCapability {
+			msg.Annotations[annotationReplyToType] = jmsTopic
+		}
+	}
+	for k, v := range req.Metadata {
+		if _, ok := reservedMetadata[k]; !ok {
+			msg.ApplicationProperties[k] = v
+		}
+	}
+
+	return msg, nil
+}
+
+// readResponse returns the response delivered to the app for a message received.
+func readResponse(msg *amqp.Message, destination string) *bindings.ReadResponse {
+	md := make(map[string]string, len(msg.ApplicationProperties)+8)
+	for k, v := range msg.ApplicationProperties {
+		md[k] = fmt.Sprint(v)
+	}
+	md[metadataDestination] = destination
+
+	if msg.Properties != nil {
+		if msg.Properties.MessageID != nil {
+			md[metadataMessageID] = fmt.Sprint(msg.Properties.MessageID)
+		}
+		if msg.Properties.CorrelationID != nil {
+			md[metadataCorrelationID] = fmt.Sprint(msg.Properties.CorrelationID)
+		}
+		if msg.Properties.ReplyTo != nil {
+			md[metadataReplyTo] = *msg.Properties.ReplyTo
+		}
+		if msg.Properties.Subject != nil {
+			md[metadataJMSType] = *msg.Properties.Subject
+		}
+	}
+	if msg.Header != nil {
+		md[metadataPriority] = strconv.Itoa(int(msg.Header.Priority))
+		md[metadataPersistent] = strconv.FormatBool(msg.Header.Durable)
+		md[metadataRedelivered] = strconv.FormatBool(msg.Header.DeliveryCount > 0)
+	} else {
+		md[metadataPriority] = strconv.Itoa(defaultPriority)
+		md[metadataPersistent] = "false"
+		md[metadataRedelivered] = "false"
+	}
+
+	return &bindings.ReadResponse{
+		Data:     messageBody(msg),
+		Metadata: md,
+	}
+}
+
+// messageBody returns the body of a message: the text of text messages, or the bytes of bytes messages.
+func messageBody(msg *amqp.Message) []byte {
+	if len(msg.Data) > 0 {
+		size := 0
+		for _, d := range msg.Data {
+			size += len(d)
+		}
+		body := make([]byte, 0, size)
+		for _, d := range msg.Data {
+			body = append(body, d...)
+		}
+		return body
+	}
+	switch v := msg.Value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
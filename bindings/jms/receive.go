@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/Azure/go-amqp"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+// Read receives the messages of the destination of the component, and delivers them to the handler.
+// Messages are acknowledged once the handler succeeds; when it fails, they're returned to the broker as failed, so
+// they're redelivered, or moved to the dead letter queue once they reach the maximum number of deliveries.
+// When the connection is lost, it's opened again after the reconnect interval.
+func (j *JMS) Read(ctx context.Context, handler bindings.Handler) error {
+	if j.closed.Load() {
+		return errors.New("binding is closed")
+	}
+
+	addr, capability, err := j.address(j.metadata.Destination)
+	if err != nil {
+		return fmt.Errorf("jms binding error: %w", err)
+	}
+	opts := j.receiverOptions(capability)
+
+	// Close read context when binding is closed.
+	readCtx, cancel := context.WithCancel(ctx)
+	j.wg.Add(2)
+	go func() {
+		defer j.wg.Done()
+		defer cancel()
+
+		select {
+		case <-j.closeCh:
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer j.wg.Done()
+
+		for {
+			err := j.receive(readCtx, addr, opts, handler)
+			if readCtx.Err() != nil {
+				return
+			}
+			j.logger.Errorf("Error receiving messages from %s, reconnecting in %v: %v", j.metadata.Destination, j.metadata.ReconnectInterval, err)
+
+			select {
+			case <-readCtx.Done():
+				return
+			case <-time.After(j.metadata.ReconnectInterval):
+			}
+		}
+	}()
+
+	return nil
+}
+
+// receiverOptions returns the options of the receiver of a destination with the given capability.
+func (j *JMS) receiverOptions(capability string) *amqp.ReceiverOptions {
+	opts := &amqp.ReceiverOptions{
+		SourceCapabilities: []string{capability},
+		Credit:             j.metadata.Prefetch,
+	}
+	if j.metadata.Selector != "" {
+		opts.Filters = []amqp.LinkFilter{amqp.NewSelectorFilter(j.metadata.Selector)}
+	}
+	if j.metadata.SubscriptionName != "" {
+		// Durable subscriptions are links whose name is the name of the subscription, and whose state outlives them.
+		opts.Name = j.metadata.SubscriptionName
+		opts.Durability = amqp.DurabilityUnsettledState
+		opts.ExpiryPolicy = amqp.ExpiryPolicyNever
+	}
+	return opts
+}
+
+// receive opens a connection and delivers the messages received to the handler, until the context is canceled or the
+// connection is lost.
+func (j *JMS) receive(ctx context.Context, addr string, opts *amqp.ReceiverOptions, handler bindings.Handler) error {
+	conn, session, err := j.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	receiver, err := session.NewReceiver(ctx, addr, opts)
+	if err != nil {
+		return fmt.Errorf("error creating receiver: %w", err)
+	}
+	// Closing the link of a durable subscription unsubscribes, deleting the subscription and its messages: the link
+	// is dropped with the connection instead, so the subscription keeps the messages sent while the app is
+	// disconnected.
+	if j.metadata.SubscriptionName == "" {
+		defer func() {
+			// The context is canceled when the binding is closed, so use a new one to detach the link.
+			closeCtx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+			defer cancel()
+			receiver.Close(closeCtx)
+		}()
+	}
+	j.logger.Infof("Receiving messages from %s", j.metadata.Destination)
+
+	for {
+		msg, err := receiver.Receive(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = handler(ctx, readResponse(msg, j.metadata.Destination))
+		if err != nil {
+			j.logger.Errorf("Error handling message from %s: %v", j.metadata.Destination, err)
+			err = receiver.ModifyMessage(ctx, msg, &amqp.ModifyMessageOptions{
+				DeliveryFailed: true,
+			})
+		} else {
+			err = receiver.AcceptMessage(ctx, msg)
+		}
+		if err != nil {
+			return fmt.Errorf("error settling message: %w", err)
+		}
+	}
+}
@@ -16,10 +16,15 @@ package kafka
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/components-contrib/common/component/kafka"
@@ -28,18 +33,27 @@ import (
 )
 
 const (
-	publishTopic = "publishTopic"
-	topics       = "topics"
+	publishTopic         = "publishTopic"
+	topics               = "topics"
+	topicPattern         = "topicPattern"
+	topicRefreshInterval = "topicRefreshInterval"
+
+	defaultTopicRefreshInterval = time.Minute
 )
 
 type Binding struct {
-	kafka        *kafka.Kafka
-	publishTopic string
-	topics       []string
-	logger       logger.Logger
-	closeCh      chan struct{}
-	closed       atomic.Bool
-	wg           sync.WaitGroup
+	kafka                *kafka.Kafka
+	publishTopic         string
+	topics               []string
+	topicPattern         *regexp.Regexp
+	topicRefreshInterval time.Duration
+	logger               logger.Logger
+	closeCh              chan struct{}
+	closed               atomic.Bool
+	wg                   sync.WaitGroup
+
+	// Function used to list the topics in the cluster; can be replaced in tests
+	listTopicsFn func() ([]string, error)
 }
 
 // NewKafka returns a new kafka binding instance.
@@ -48,9 +62,11 @@ func NewKafka(logger logger.Logger) bindings.InputOutputBinding {
 	// in kafka binding component, disable consumer retry by default
 	k.DefaultConsumeRetryEnabled = false
 	return &Binding{
-		kafka:   k,
-		logger:  logger,
-		closeCh: make(chan struct{}),
+		kafka:                k,
+		logger:               logger,
+		closeCh:              make(chan struct{}),
+		topicRefreshInterval: defaultTopicRefreshInterval,
+		listTopicsFn:         k.ListTopics,
 	}
 }
 
@@ -70,9 +86,31 @@ func (b *Binding) Init(ctx context.Context, metadata bindings.Metadata) error {
 		b.topics = strings.Split(val, ",")
 	}
 
+	val, ok = metadata.Properties[topicPattern]
+	if ok && val != "" {
+		b.topicPattern, err = compileTopicPattern(val)
+		if err != nil {
+			return fmt.Errorf("invalid value for '%s': %w", topicPattern, err)
+		}
+	}
+
+	val, ok = metadata.Properties[topicRefreshInterval]
+	if ok && val != "" {
+		b.topicRefreshInterval, err = time.ParseDuration(val)
+		if err != nil || b.topicRefreshInterval <= 0 {
+			return fmt.Errorf("invalid value for '%s': must be a positive duration", topicRefreshInterval)
+		}
+	}
+
 	return nil
 }
 
+// compileTopicPattern compiles the topic pattern anchored at both ends: like the Java client, the pattern must match the
+// whole topic name.
+func compileTopicPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 func (b *Binding) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
@@ -95,8 +133,8 @@ func (b *Binding) Read(ctx context.Context, handler bindings.Handler) error {
 		return errors.New("error: binding is closed")
 	}
 
-	if len(b.topics) == 0 {
-		b.logger.Warnf("kafka binding: no topic defined, input bindings will not be started")
+	if len(b.topics) == 0 && b.topicPattern == nil {
+		b.logger.Warnf("kafka binding: no topic or topic pattern defined, input bindings will not be started")
 		return nil
 	}
 
@@ -117,7 +155,82 @@ func (b *Binding) Read(ctx context.Context, handler bindings.Handler) error {
 		Handler:         adaptHandler(handler),
 	}
 
-	b.kafka.Subscribe(ctx, handlerConfig, b.topics...)
+	if len(b.topics) > 0 {
+		b.kafka.Subscribe(ctx, handlerConfig, b.topics...)
+	}
+
+	if b.topicPattern != nil {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.watchTopicPattern(ctx, handlerConfig)
+		}()
+	}
+
+	return nil
+}
+
+// watchTopicPattern periodically discovers the topics matching the pattern, and updates the subscription as topics are created or deleted.
+func (b *Binding) watchTopicPattern(ctx context.Context, handlerConfig kafka.SubscriptionHandlerConfig) {
+	subscribed := map[string]struct{}{}
+	defer func() {
+		b.kafka.RemoveTopics(slices.Collect(maps.Keys(subscribed))...)
+	}()
+
+	ticker := time.NewTicker(b.topicRefreshInterval)
+	defer ticker.Stop()
+	for {
+		err := b.refreshPatternTopics(handlerConfig, subscribed)
+		if err != nil {
+			b.logger.Errorf("kafka binding: failed to discover topics matching pattern '%s': %v", b.topicPattern, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshPatternTopics subscribes to new topics matching the pattern, and unsubscribes from those that no longer exist.
+func (b *Binding) refreshPatternTopics(handlerConfig kafka.SubscriptionHandlerConfig, subscribed map[string]struct{}) error {
+	all, err := b.listTopicsFn()
+	if err != nil {
+		return err
+	}
+
+	matched := make(map[string]struct{}, len(all))
+	for _, t := range all {
+		// Skip internal topics, and topics that are subscribed explicitly
+		if strings.HasPrefix(t, "__") || slices.Contains(b.topics, t) || !b.topicPattern.MatchString(t) {
+			continue
+		}
+		matched[t] = struct{}{}
+	}
+
+	var added, removed []string
+	for t := range matched {
+		if _, ok := subscribed[t]; !ok {
+			added = append(added, t)
+			subscribed[t] = struct{}{}
+		}
+	}
+	for t := range subscribed {
+		if _, ok := matched[t]; !ok {
+			removed = append(removed, t)
+			delete(subscribed, t)
+		}
+	}
+
+	if len(added) > 0 {
+		b.logger.Infof("kafka binding: subscribing to topics matching pattern '%s': %v", b.topicPattern, added)
+		b.kafka.AddTopics(handlerConfig, added...)
+	}
+	if len(removed) > 0 {
+		b.logger.Infof("kafka binding: unsubscribing from topics that no longer exist: %v", removed)
+		b.kafka.RemoveTopics(removed...)
+	}
 
 	return nil
 }
@@ -133,6 +246,17 @@ func adaptHandler(handler bindings.Handler) kafka.EventHandler {
 	}
 }
 
+// Ping checks the connectivity to the brokers and that no input topic is stalled.
+// The lag of each partition is available with ConsumerHealth.
+func (b *Binding) Ping(ctx context.Context) error {
+	return b.kafka.Ping(ctx)
+}
+
+// ConsumerHealth returns the lag of the consumed partitions and the number of consumer group rebalances.
+func (b *Binding) ConsumerHealth() kafka.ConsumerHealth {
+	return b.kafka.ConsumerHealth()
+}
+
 // GetComponentMetadata returns the metadata of the component.
 func (b *Binding) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
 	metadataStruct := kafka.KafkaMetadata{}
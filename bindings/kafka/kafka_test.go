@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileTopicPattern(t *testing.T) {
+	t.Run("matches the whole topic name", func(t *testing.T) {
+		re, err := compileTopicPattern("orders-.*")
+		require.NoError(t, err)
+
+		assert.True(t, re.MatchString("orders-eu"))
+		assert.True(t, re.MatchString("orders-"))
+		assert.False(t, re.MatchString("archived-orders-eu"))
+		assert.False(t, re.MatchString("orders"))
+	})
+
+	t.Run("alternatives are anchored too", func(t *testing.T) {
+		re, err := compileTopicPattern("orders|payments")
+		require.NoError(t, err)
+
+		assert.True(t, re.MatchString("orders"))
+		assert.True(t, re.MatchString("payments"))
+		assert.False(t, re.MatchString("orders-eu"))
+		assert.False(t, re.MatchString("old-payments"))
+	})
+
+	t.Run("explicit anchors are allowed", func(t *testing.T) {
+		re, err := compileTopicPattern("^orders-.*$")
+		require.NoError(t, err)
+
+		assert.True(t, re.MatchString("orders-eu"))
+		assert.False(t, re.MatchString("archived-orders-eu"))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := compileTopicPattern("orders-(")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+// Read watches the jobs created by the binding, and delivers an event to the handler each time one of them succeeds
+// or fails, with the status of the job as data.
+// Only the jobs finishing while the binding is reading are reported: jobs that had already finished are not.
+func (j *Jobs) Read(ctx context.Context, handler bindings.Handler) error {
+	if j.closed.Load() {
+		return errors.New("binding is closed")
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{componentLabel: j.componentName}).String()
+	jobsClient := j.kubeClient.BatchV1().Jobs(j.metadata.Namespace)
+	watchlist := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return jobsClient.List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return jobsClient.Watch(context.Background(), options)
+		},
+	}
+
+	readCtx, cancel := context.WithCancel(ctx)
+	_, controller := cache.NewInformer(
+		watchlist,
+		&batchv1.Job{},
+		j.metadata.ResyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldJob, ok := oldObj.(*batchv1.Job)
+				if !ok {
+					return
+				}
+				newJob, ok := newObj.(*batchv1.Job)
+				if !ok {
+					return
+				}
+				if finishedCondition(oldJob) != nil || finishedCondition(newJob) == nil {
+					return
+				}
+				j.deliver(readCtx, handler, newJob)
+			},
+		},
+	)
+
+	j.wg.Add(2)
+
+	// catch when binding is closed.
+	go func() {
+		defer j.wg.Done()
+		defer cancel()
+		select {
+		case <-readCtx.Done():
+		case <-j.closeCh:
+		}
+	}()
+
+	// Start the controller in background
+	go func() {
+		defer j.wg.Done()
+		controller.Run(readCtx.Done())
+	}()
+
+	return nil
+}
+
+// deliver sends the event of a finished job to the handler.
+func (j *Jobs) deliver(ctx context.Context, handler bindings.Handler, job *batchv1.Job) {
+	status := newJobStatus(job)
+	data, err := json.Marshal(status)
+	if err != nil {
+		j.logger.Errorf("Error marshalling status of job %s: %v", job.Name, err)
+		return
+	}
+
+	_, err = handler(ctx, &bindings.ReadResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataJobName:   job.Name,
+			metadataJobStatus: status.Status,
+		},
+	})
+	if err != nil {
+		j.logger.Errorf("Error handling event of job %s: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/components-contrib/bindings"
+	kubeclient "github.com/dapr/components-contrib/common/authentication/kubernetes"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	// Operations of the binding.
+	createOperation bindings.OperationKind = "create"
+	getOperation    bindings.OperationKind = "get"
+	deleteOperation bindings.OperationKind = "delete"
+
+	// Request and response metadata with the name of the job.
+	metadataJobName = "jobName"
+	// Response metadata with the status of the job.
+	metadataJobStatus = "jobStatus"
+
+	// Label set on the jobs created by the binding, with the name of the component as value: the input binding only
+	// reports the events of these jobs.
+	componentLabel = "dapr.io/jobs-binding"
+
+	// Statuses of a job.
+	statusActive    = "active"
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+)
+
+type jobsMetadata struct {
+	Namespace      string `mapstructure:"namespace"`
+	KubeconfigPath string `mapstructure:"kubeconfigPath"`
+	// Job created by the binding, as YAML or JSON: its containers get the env and args of the requests.
+	JobTemplate  string        `mapstructure:"jobTemplate"`
+	ResyncPeriod time.Duration `mapstructure:"resyncPeriod" mapstructurealiases:"resyncPeriodInSec"`
+}
+
+// Jobs is a binding launching Kubernetes Jobs from a template, and reporting their completion and failure.
+type Jobs struct {
+	metadata      jobsMetadata
+	componentName string
+	template      *batchv1.Job
+	kubeClient    kubernetes.Interface
+	logger        logger.Logger
+	closed        atomic.Bool
+	closeCh       chan struct{}
+	wg            sync.WaitGroup
+}
+
+// createPayload is the data of create requests: all fields are optional.
+type createPayload struct {
+	// Environment variables set in the containers, replacing the variables of the template with the same name.
+	Env map[string]string `json:"env"`
+	// Arguments of the containers, replacing the arguments of the template when set.
+	Args []string `json:"args"`
+	// Labels added to the job.
+	Labels map[string]string `json:"labels"`
+	// Name of the container the env and args are applied to: all containers by default.
+	Container string `json:"container"`
+}
+
+// jobStatus is the data of the get responses and of the events of the input binding.
+type jobStatus struct {
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace"`
+	UID            string            `json:"uid"`
+	Status         string            `json:"status"`
+	Reason         string            `json:"reason,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	StartTime      *metav1.Time      `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time      `json:"completionTime,omitempty"`
+	Active         int32             `json:"active"`
+	Succeeded      int32             `json:"succeeded"`
+	Failed         int32             `json:"failed"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// NewJobs returns a new Kubernetes Jobs binding.
+func NewJobs(logger logger.Logger) bindings.InputOutputBinding {
+	return &Jobs{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (j *Jobs) Init(ctx context.Context, metadata bindings.Metadata) error {
+	err := j.parseMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	kubeconfigPath := j.metadata.KubeconfigPath
+	if kubeconfigPath == "" {
+		kubeconfigPath = kubeclient.GetKubeconfigPath(j.logger, os.Args)
+	}
+
+	client, err := kubeclient.GetKubeClient(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Kubernetes client: %w", err)
+	}
+	j.kubeClient = client
+
+	return nil
+}
+
+func (j *Jobs) parseMetadata(meta bindings.Metadata) error {
+	// Set default values
+	j.metadata = jobsMetadata{
+		ResyncPeriod: 10 * time.Second,
+	}
+
+	// Decode
+	err := kitmd.DecodeMetadata(meta.Properties, &j.metadata)
+	if err != nil {
+		return err
+	}
+	j.componentName = meta.Name
+
+	// Validate
+	if j.metadata.JobTemplate == "" {
+		return errors.New("jobTemplate is missing in metadata")
+	}
+	template := &batchv1.Job{}
+	err = yaml.UnmarshalStrict([]byte(j.metadata.JobTemplate), template)
+	if err != nil {
+		return fmt.Errorf("invalid jobTemplate: %w", err)
+	}
+	if len(template.Spec.Template.Spec.Containers) == 0 {
+		return errors.New("invalid jobTemplate: the job has no containers")
+	}
+	if j.metadata.Namespace == "" {
+		j.metadata.Namespace = template.Namespace
+	}
+	if j.metadata.Namespace == "" {
+		return errors.New("namespace is missing in metadata")
+	}
+	// Jobs don't accept the default restart policy of pods
+	if template.Spec.Template.Spec.RestartPolicy == "" {
+		template.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	j.template = template
+
+	return nil
+}
+
+func (j *Jobs) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{createOperation, getOperation, deleteOperation}
+}
+
+func (j *Jobs) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case createOperation:
+		return j.create(ctx, req)
+	case getOperation:
+		return j.get(ctx, req)
+	case deleteOperation:
+		return j.delete(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
+	}
+}
+
+// create creates a job from the template, with the env and args of the request.
+// The job is named after the jobName request metadata when set, or gets a name generated from the name of the
+// template (or of the component) otherwise.
+func (j *Jobs) create(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var payload createPayload
+	if len(req.Data) > 0 {
+		err := json.Unmarshal(req.Data, &payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid create request: %w", err)
+		}
+	}
+
+	job, err := j.newJob(req.Metadata[metadataJobName], &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := j.kubeClient.BatchV1().Jobs(j.metadata.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	data, err := json.Marshal(newJobStatus(created))
+	if err != nil {
+		return nil, err
+	}
+	return &bindings.InvokeResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataJobName: created.Name,
+		},
+	}, nil
+}
+
+// newJob returns the job created for a request.
+func (j *Jobs) newJob(name string, payload *createPayload) (*batchv1.Job, error) {
+	job := j.template.DeepCopy()
+	job.Namespace = j.metadata.Namespace
+	job.ResourceVersion = ""
+	job.UID = ""
+	switch {
+	case name != "":
+		job.Name = name
+		job.GenerateName = ""
+	case job.Name != "":
+		job.GenerateName = job.Name + "-"
+		job.Name = ""
+	case job.GenerateName == "":
+		job.GenerateName = j.componentName + "-"
+	}
+
+	if job.Labels == nil {
+		job.Labels = make(map[string]string, len(payload.Labels)+1)
+	}
+	for k, v := range payload.Labels {
+		job.Labels[k] = v
+	}
+	job.Labels[componentLabel] = j.componentName
+
+	found := false
+	containers := job.Spec.Template.Spec.Containers
+	for i := range containers {
+		if payload.Container != "" && containers[i].Name != payload.Container {
+			continue
+		}
+		found = true
+		containers[i].Env = mergeEnv(containers[i].Env, payload.Env)
+		if payload.Args != nil {
+			containers[i].Args = payload.Args
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("container %s not found in jobTemplate", payload.Container)
+	}
+
+	return job, nil
+}
+
+// mergeEnv returns the environment variables of a container with the variables of a request: variables of the
+// container with the same name are replaced, and the others are added in alphabetical order.
+func mergeEnv(env []corev1.EnvVar, values map[string]string) []corev1.EnvVar {
+	if len(values) == 0 {
+		return env
+	}
+
+	merged := make([]corev1.EnvVar, 0, len(env)+len(values))
+	for _, e := range env {
+		if v, ok := values[e.Name]; ok {
+			e = corev1.EnvVar{Name: e.Name, Value: v}
+		}
+		merged = append(merged, e)
+	}
+
+	existing := make(map[string]struct{}, len(env))
+	for _, e := range env {
+		existing[e.Name] = struct{}{}
+	}
+	names := make([]string, 0, len(values))
+	for k := range values {
+		if _, ok := existing[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		merged = append(merged, corev1.EnvVar{Name: k, Value: values[k]})
+	}
+
+	return merged
+}
+
+// get returns the status of the job named after the jobName request metadata.
+func (j *Jobs) get(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name := req.Metadata[metadataJobName]
+	if name == "" {
+		return nil, fmt.Errorf("required metadata %s not set", metadataJobName)
+	}
+
+	job, err := j.kubeClient.BatchV1().Jobs(j.metadata.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", name, err)
+	}
+
+	status := newJobStatus(job)
+	data, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+	return &bindings.InvokeResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataJobName:   job.Name,
+			metadataJobStatus: status.Status,
+		},
+	}, nil
+}
+
+// delete deletes the job named after the jobName request metadata, with its pods.
+func (j *Jobs) delete(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name := req.Metadata[metadataJobName]
+	if name == "" {
+		return nil, fmt.Errorf("required metadata %s not set", metadataJobName)
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err := j.kubeClient.BatchV1().Jobs(j.metadata.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete job %s: %w", name, err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+// newJobStatus returns the status of a job: succeeded or failed once it has finished, active otherwise.
+func newJobStatus(job *batchv1.Job) *jobStatus {
+	status := &jobStatus{
+		Name:           job.Name,
+		Namespace:      job.Namespace,
+		UID:            string(job.UID),
+		Status:         statusActive,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+		Labels:         job.Labels,
+	}
+	if c := finishedCondition(job); c != nil {
+		if c.Type == batchv1.JobComplete {
+			status.Status = statusSucceeded
+		} else {
+			status.Status = statusFailed
+		}
+		status.Reason = c.Reason
+		status.Message = c.Message
+	}
+	return status
+}
+
+// finishedCondition returns the condition of a job telling it has completed or failed, or nil if it's still running.
+func finishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func (j *Jobs) Close() error {
+	if j.closed.CompareAndSwap(false, true) {
+		close(j.closeCh)
+	}
+	j.wg.Wait()
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (j *Jobs) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := jobsMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
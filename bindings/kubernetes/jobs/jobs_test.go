@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+const testTemplate = `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: report
+  labels:
+    app: reports
+spec:
+  backoffLimit: 2
+  template:
+    spec:
+      containers:
+      - name: main
+        image: reports:latest
+        args: ["--all"]
+        env:
+        - name: LEVEL
+          value: info
+        - name: REGION
+          value: eu
+      - name: sidecar
+        image: proxy:latest
+`
+
+func newTestJobs(t *testing.T, properties map[string]string) *Jobs {
+	t.Helper()
+
+	j := NewJobs(logger.NewLogger("test")).(*Jobs)
+	err := j.parseMetadata(bindings.Metadata{Base: metadata.Base{
+		Name:       "reports",
+		Properties: properties,
+	}})
+	require.NoError(t, err)
+	j.kubeClient = fake.NewSimpleClientset()
+	t.Cleanup(func() {
+		j.Close()
+	})
+	return j
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("parse metadata", func(t *testing.T) {
+		j := newTestJobs(t, map[string]string{"namespace": "jobs", "jobTemplate": testTemplate, "resyncPeriodInSec": "15"})
+
+		assert.Equal(t, "jobs", j.metadata.Namespace)
+		assert.Equal(t, 15*time.Second, j.metadata.ResyncPeriod)
+		assert.Equal(t, "report", j.template.Name)
+		assert.Equal(t, corev1.RestartPolicyNever, j.template.Spec.Template.Spec.RestartPolicy)
+	})
+	t.Run("namespace of the template", func(t *testing.T) {
+		j := newTestJobs(t, map[string]string{"jobTemplate": `{"metadata":{"namespace":"batch"},"spec":{"template":{"spec":{"containers":[{"name":"main","image":"busybox"}]}}}}`})
+
+		assert.Equal(t, "batch", j.metadata.Namespace)
+	})
+
+	tests := map[string]struct {
+		properties map[string]string
+		err        string
+	}{
+		"no template": {
+			properties: map[string]string{"namespace": "jobs"},
+			err:        "jobTemplate is missing in metadata",
+		},
+		"invalid template": {
+			properties: map[string]string{"namespace": "jobs", "jobTemplate": "spec:\n  parallelism: many\n"},
+			err:        "invalid jobTemplate",
+		},
+		"unknown field": {
+			properties: map[string]string{"namespace": "jobs", "jobTemplate": "spec:\n  paralelism: 1\n"},
+			err:        "invalid jobTemplate",
+		},
+		"no containers": {
+			properties: map[string]string{"namespace": "jobs", "jobTemplate": "spec:\n  parallelism: 1\n"},
+			err:        "the job has no containers",
+		},
+		"no namespace": {
+			properties: map[string]string{"jobTemplate": testTemplate},
+			err:        "namespace is missing in metadata",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			j := NewJobs(logger.NewLogger("test")).(*Jobs)
+			err := j.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: tc.properties}})
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	j := NewJobs(logger.NewLogger("test")).(*Jobs)
+	assert.Equal(t, []bindings.OperationKind{createOperation, getOperation, deleteOperation}, j.Operations())
+}
+
+func TestCreate(t *testing.T) {
+	properties := map[string]string{"namespace": "jobs", "jobTemplate": testTemplate}
+
+	t.Run("template only", func(t *testing.T) {
+		j := newTestJobs(t, properties)
+
+		job, err := j.newJob("", &createPayload{})
+		require.NoError(t, err)
+		assert.Empty(t, job.Name)
+		assert.Equal(t, "report-", job.GenerateName)
+		assert.Equal(t, "jobs", job.Namespace)
+		assert.Equal(t, map[string]string{"app": "reports", componentLabel: "reports"}, job.Labels)
+		assert.Equal(t, j.template.Spec.Template.Spec.Containers, job.Spec.Template.Spec.Containers)
+	})
+
+	t.Run("env and args", func(t *testing.T) {
+		j := newTestJobs(t, properties)
+
+		res, err := j.Invoke(context.Background(), &bindings.InvokeRequest{
+			Operation: createOperation,
+			Data:      []byte(`{"env":{"REGION":"us","DATE":"2026-10-15","BUCKET":"out"},"args":["--date","$(DATE)"],"labels":{"tenant":"a"},"container":"main"}`),
+			Metadata:  map[string]string{metadataJobName: "report-1"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "report-1", res.Metadata[metadataJobName])
+
+		var status jobStatus
+		require.NoError(t, json.Unmarshal(res.Data, &status))
+		assert.Equal(t, "report-1", status.Name)
+		assert.Equal(t, statusActive, status.Status)
+
+		job, err := j.kubeClient.BatchV1().Jobs("jobs").Get(context.Background(), "report-1", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a", job.Labels["tenant"])
+		main := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, []string{"--date", "$(DATE)"}, main.Args)
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "LEVEL", Value: "info"},
+			{Name: "REGION", Value: "us"},
+			{Name: "BUCKET", Value: "out"},
+			{Name: "DATE", Value: "2026-10-15"},
+		}, main.Env)
+		sidecar := job.Spec.Template.Spec.Containers[1]
+		assert.Empty(t, sidecar.Env)
+		assert.Empty(t, sidecar.Args)
+
+		// The template is not modified
+		assert.Len(t, j.template.Spec.Template.Spec.Containers[0].Env, 2)
+	})
+
+	t.Run("all containers", func(t *testing.T) {
+		j := newTestJobs(t, properties)
+
+		job, err := j.newJob("", &createPayload{Env: map[string]string{"RUN": "1"}})
+		require.NoError(t, err)
+		for _, c := range job.Spec.Template.Spec.Containers {
+			assert.Contains(t, c.Env, corev1.EnvVar{Name: "RUN", Value: "1"})
+		}
+	})
+
+	t.Run("unknown container", func(t *testing.T) {
+		j := newTestJobs(t, properties)
+
+		_, err := j.Invoke(context.Background(), &bindings.InvokeRequest{
+			Operation: createOperation,
+			Data:      []byte(`{"container":"other"}`),
+		})
+		require.ErrorContains(t, err, "container other not found")
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		j := newTestJobs(t, properties)
+
+		_, err := j.Invoke(context.Background(), &bindings.InvokeRequest{
+			Operation: createOperation,
+			Data:      []byte(`{"args":"--all"}`),
+		})
+		require.ErrorContains(t, err, "invalid create request")
+	})
+}
+
+func TestGetAndDelete(t *testing.T) {
+	j := newTestJobs(t, map[string]string{"namespace": "jobs", "jobTemplate": testTemplate})
+	ctx := context.Background()
+
+	_, err := j.Invoke(ctx, &bindings.InvokeRequest{Operation: getOperation})
+	require.ErrorContains(t, err, "required metadata jobName not set")
+
+	_, err = j.Invoke(ctx, &bindings.InvokeRequest{
+		Operation: createOperation,
+		Metadata:  map[string]string{metadataJobName: "report-1"},
+	})
+	require.NoError(t, err)
+	_, err = j.kubeClient.BatchV1().Jobs("jobs").UpdateStatus(ctx, finishedJob("report-1", batchv1.JobFailed), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	res, err := j.Invoke(ctx, &bindings.InvokeRequest{
+		Operation: getOperation,
+		Metadata:  map[string]string{metadataJobName: "report-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, statusFailed, res.Metadata[metadataJobStatus])
+	var status jobStatus
+	require.NoError(t, json.Unmarshal(res.Data, &status))
+	assert.Equal(t, "BackoffLimitExceeded", status.Reason)
+	assert.Equal(t, int32(3), status.Failed)
+
+	_, err = j.Invoke(ctx, &bindings.InvokeRequest{
+		Operation: deleteOperation,
+		Metadata:  map[string]string{metadataJobName: "report-1"},
+	})
+	require.NoError(t, err)
+
+	_, err = j.Invoke(ctx, &bindings.InvokeRequest{
+		Operation: getOperation,
+		Metadata:  map[string]string{metadataJobName: "report-1"},
+	})
+	require.ErrorContains(t, err, "failed to get job report-1")
+}
+
+func TestRead(t *testing.T) {
+	j := newTestJobs(t, map[string]string{"namespace": "jobs", "jobTemplate": testTemplate})
+	ctx := context.Background()
+
+	events := make(chan *bindings.ReadResponse, 10)
+	err := j.Read(ctx, func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		events <- res
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"report-1", "report-2"} {
+		_, err = j.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: createOperation,
+			Metadata:  map[string]string{metadataJobName: name},
+		})
+		require.NoError(t, err)
+	}
+	// Jobs not created by the binding are ignored
+	other := finishedJob("other", batchv1.JobComplete)
+	other.Status = batchv1.JobStatus{}
+	_, err = j.kubeClient.BatchV1().Jobs("jobs").Create(ctx, other, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Wait for the informer to know the jobs before they finish
+	require.Eventually(t, func() bool {
+		list, err := j.kubeClient.BatchV1().Jobs("jobs").List(ctx, metav1.ListOptions{})
+		return err == nil && len(list.Items) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+
+	for _, job := range []*batchv1.Job{
+		finishedJob("report-1", batchv1.JobComplete),
+		finishedJob("report-2", batchv1.JobFailed),
+		finishedJob("other", batchv1.JobComplete),
+	} {
+		_, err = j.kubeClient.BatchV1().Jobs("jobs").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	received := map[string]string{}
+	for len(received) < 2 {
+		select {
+		case res := <-events:
+			received[res.Metadata[metadataJobName]] = res.Metadata[metadataJobStatus]
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for job events, received %v", received)
+		}
+	}
+	assert.Equal(t, map[string]string{"report-1": statusSucceeded, "report-2": statusFailed}, received)
+
+	// Further updates of finished jobs are not reported
+	_, err = j.kubeClient.BatchV1().Jobs("jobs").UpdateStatus(ctx, finishedJob("report-1", batchv1.JobComplete), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case res := <-events:
+		t.Fatalf("unexpected event for job %s", res.Metadata[metadataJobName])
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func finishedJob(name string, condition batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "jobs",
+			Labels:    map[string]string{componentLabel: "reports"},
+		},
+	}
+	if name == "other" {
+		job.Labels = nil
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+	if condition == batchv1.JobComplete {
+		job.Status.Succeeded = 1
+	} else {
+		job.Status.Failed = 3
+		job.Status.Conditions[0].Reason = "BackoffLimitExceeded"
+	}
+	return job
+}
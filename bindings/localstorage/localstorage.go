@@ -25,6 +25,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/google/uuid"
@@ -33,10 +36,13 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 const (
-	fileNameMetadataKey = "fileName"
+	fileNameMetadataKey  = "fileName"
+	patternMetadataKey   = "pattern"
+	recursiveMetadataKey = "recursive"
 )
 
 // List of root paths that are disallowed
@@ -52,11 +58,21 @@ var disallowedRootPaths = []string{
 type LocalStorage struct {
 	metadata *Metadata
 	logger   logger.Logger
+	closeCh  chan struct{}
+	closed   atomic.Bool
+	wg       sync.WaitGroup
 }
 
 // Metadata defines the metadata.
 type Metadata struct {
 	RootPath string `json:"rootPath"`
+
+	// Input binding: glob pattern of the files whose events are delivered, such as "*.csv" or "orders/**/*.json".
+	WatchPattern string `json:"watchPattern"`
+	// Input binding: watch the subdirectories of the root path too.
+	WatchRecursive bool `json:"watchRecursive"`
+	// Input binding: time a file must not change for before its event is delivered.
+	WatchDebounce time.Duration `json:"watchDebounce"`
 }
 
 type createResponse struct {
@@ -64,8 +80,11 @@ type createResponse struct {
 }
 
 // NewLocalStorage returns a new LocalStorage instance.
-func NewLocalStorage(logger logger.Logger) bindings.OutputBinding {
-	return &LocalStorage{logger: logger}
+func NewLocalStorage(logger logger.Logger) bindings.InputOutputBinding {
+	return &LocalStorage{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
 }
 
 // Init performs metadata parsing.
@@ -85,7 +104,10 @@ func (ls *LocalStorage) Init(_ context.Context, metadata bindings.Metadata) erro
 }
 
 func (ls *LocalStorage) parseMetadata(meta bindings.Metadata) (*Metadata, error) {
-	var m Metadata
+	m := Metadata{
+		WatchRecursive: true,
+		WatchDebounce:  defaultWatchDebounce,
+	}
 	err := kitmd.DecodeMetadata(meta.Properties, &m)
 	if err != nil {
 		return nil, err
@@ -96,6 +118,13 @@ func (ls *LocalStorage) parseMetadata(meta bindings.Metadata) (*Metadata, error)
 		return nil, err
 	}
 
+	if _, err = newPathMatcher(m.WatchPattern); err != nil {
+		return nil, fmt.Errorf("invalid watchPattern: %w", err)
+	}
+	if m.WatchDebounce < 0 {
+		return nil, errors.New("property watchDebounce must not be negative")
+	}
+
 	return &m, nil
 }
 
@@ -270,7 +299,16 @@ func (ls *LocalStorage) list(filename string, req *bindings.InvokeRequest) (*bin
 		return nil, fmt.Errorf("unable to list files as the file specified is not a directory: %s", absPath)
 	}
 
-	files, err := walkPath(absPath)
+	matcher, err := newPathMatcher(req.Metadata[patternMetadataKey])
+	if err != nil {
+		return nil, err
+	}
+	recursive := true
+	if val := req.Metadata[recursiveMetadataKey]; val != "" {
+		recursive = kitstrings.IsTruthy(val)
+	}
+
+	files, err := walkPath(absPath, matcher, recursive)
 	if err != nil {
 		return nil, fmt.Errorf("error listing files in the directory %s: %w", absPath, err)
 	}
@@ -298,10 +336,25 @@ func getSecureAbsRelPath(rootPath string, filename string) (absPath string, relP
 	return
 }
 
-func walkPath(root string) ([]string, error) {
+// walkPath returns the files of a directory matching the pattern, in its subdirectories too if recursive.
+func walkPath(root string, matcher *pathMatcher, recursive bool) ([]string, error) {
 	var files []string
 	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if !info.IsDir() {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != root && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if matcher.match(relPath) {
 			files = append(files, path)
 		}
 
@@ -344,5 +397,9 @@ func (ls *LocalStorage) GetComponentMetadata() (metadataInfo metadata.MetadataMa
 }
 
 func (ls *LocalStorage) Close() error {
+	if ls.closed.CompareAndSwap(false, true) {
+		close(ls.closeCh)
+	}
+	ls.wg.Wait()
 	return nil
 }
@@ -14,15 +14,19 @@ limitations under the License.
 package localstorage
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 )
 
@@ -37,6 +41,12 @@ func TestParseMetadata(t *testing.T) {
 	meta, err := localStorage.parseMetadata(m)
 	require.NoError(t, err)
 	assert.Equal(t, path, meta.RootPath)
+	assert.True(t, meta.WatchRecursive)
+	assert.Equal(t, defaultWatchDebounce, meta.WatchDebounce)
+
+	m.Properties["watchPattern"] = "[*.csv"
+	_, err = localStorage.parseMetadata(m)
+	require.ErrorContains(t, err, "invalid watchPattern")
 }
 
 func TestValidateRootPath(t *testing.T) {
@@ -114,6 +124,165 @@ func TestValidateRootPath(t *testing.T) {
 	}
 }
 
+func newTestLocalStorage(t *testing.T, properties map[string]string) *LocalStorage {
+	t.Helper()
+
+	properties["rootPath"] = t.TempDir()
+	ls := NewLocalStorage(logger.NewLogger("test")).(*LocalStorage)
+	err := ls.Init(context.Background(), bindings.Metadata{Base: contribMetadata.Base{Properties: properties}})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ls.Close()
+	})
+	return ls
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestList(t *testing.T) {
+	ls := newTestLocalStorage(t, map[string]string{})
+	root := ls.metadata.RootPath
+	for _, name := range []string{"a.csv", "b.json", "orders/c.csv", "orders/2026/d.csv", "orders/2026/e.json"} {
+		writeFile(t, filepath.Join(root, name), name)
+	}
+
+	list := func(md map[string]string) []string {
+		t.Helper()
+		res, err := ls.Invoke(context.Background(), &bindings.InvokeRequest{
+			Operation: bindings.ListOperation,
+			Metadata:  md,
+		})
+		require.NoError(t, err)
+		var files []string
+		require.NoError(t, json.Unmarshal(res.Data, &files))
+		for i, f := range files {
+			files[i], err = filepath.Rel(root, f)
+			require.NoError(t, err)
+			files[i] = filepath.ToSlash(files[i])
+		}
+		return files
+	}
+
+	assert.ElementsMatch(t, []string{"a.csv", "b.json", "orders/c.csv", "orders/2026/d.csv", "orders/2026/e.json"}, list(nil))
+	assert.ElementsMatch(t, []string{"a.csv", "b.json"}, list(map[string]string{"recursive": "false"}))
+	assert.ElementsMatch(t, []string{"a.csv", "orders/c.csv", "orders/2026/d.csv"}, list(map[string]string{"pattern": "*.csv"}))
+	assert.ElementsMatch(t, []string{"a.csv"}, list(map[string]string{"pattern": "*.csv", "recursive": "false"}))
+	assert.ElementsMatch(t, []string{"orders/2026/d.csv", "orders/2026/e.json"}, list(map[string]string{"pattern": "orders/*/*"}))
+	assert.ElementsMatch(t, []string{"orders/c.csv", "orders/2026/d.csv"}, list(map[string]string{"pattern": "orders/**.csv"}))
+	assert.ElementsMatch(t, []string{"orders/2026/e.json"}, list(map[string]string{"fileName": "orders", "pattern": "2026/*.json"}))
+
+	_, err := ls.Invoke(context.Background(), &bindings.InvokeRequest{
+		Operation: bindings.ListOperation,
+		Metadata:  map[string]string{"pattern": "[*.csv"},
+	})
+	require.ErrorContains(t, err, "invalid pattern")
+}
+
+func TestRead(t *testing.T) {
+	ls := newTestLocalStorage(t, map[string]string{
+		"watchPattern":  "*.csv",
+		"watchDebounce": "100ms",
+	})
+	root := ls.metadata.RootPath
+	writeFile(t, filepath.Join(root, "existing.csv"), "old")
+
+	events := make(chan fileEvent, 10)
+	err := ls.Read(context.Background(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		var ev fileEvent
+		require.NoError(t, json.Unmarshal(res.Data, &ev))
+		assert.Equal(t, ev.FileName, res.Metadata["fileName"])
+		assert.Equal(t, ev.Event, res.Metadata["event"])
+		events <- ev
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	receive := func() fileEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for file event")
+			return fileEvent{}
+		}
+	}
+
+	// Several writes of a file are a single event
+	f, err := os.Create(filepath.Join(root, "new.csv"))
+	require.NoError(t, err)
+	for range 3 {
+		_, err = f.WriteString("line\n")
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	ev := receive()
+	assert.Equal(t, eventCreated, ev.Event)
+	assert.Equal(t, "new.csv", ev.FileName)
+	assert.Equal(t, int64(15), ev.Size)
+
+	writeFile(t, filepath.Join(root, "existing.csv"), "new")
+	ev = receive()
+	assert.Equal(t, eventModified, ev.Event)
+	assert.Equal(t, "existing.csv", ev.FileName)
+
+	// Files in new directories are watched
+	writeFile(t, filepath.Join(root, "orders", "2026", "order.csv"), "order")
+	ev = receive()
+	assert.Equal(t, eventCreated, ev.Event)
+	assert.Equal(t, filepath.Join("orders", "2026", "order.csv"), ev.FileName)
+
+	// Files not matching the pattern are ignored
+	writeFile(t, filepath.Join(root, "ignored.json"), "{}")
+	select {
+	case ev = <-events:
+		t.Fatalf("unexpected event for file %s", ev.FileName)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.NoError(t, ls.Close())
+	require.Error(t, ls.Read(context.Background(), nil))
+}
+
+func TestWatchStability(t *testing.T) {
+	ls := newTestLocalStorage(t, map[string]string{
+		"watchDebounce": "100ms",
+	})
+	path := filepath.Join(ls.metadata.RootPath, "a.csv")
+	writeFile(t, path, "a")
+
+	var delivered []string
+	matcher, err := newPathMatcher("")
+	require.NoError(t, err)
+	w := &dirWatcher{
+		ls:      ls,
+		matcher: matcher,
+		handler: func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+			delivered = append(delivered, res.Metadata["fileName"])
+			return nil, nil
+		},
+		pending: map[string]*pendingEvent{},
+	}
+	w.add(path, eventCreated)
+
+	// The file changed without the change being reported: the event is delayed again
+	writeFile(t, path, "ab")
+	w.pending[path].deadline = time.Now()
+	next := w.flush(t.Context())
+	assert.False(t, next.IsZero())
+	assert.Empty(t, delivered)
+
+	w.pending[path].deadline = time.Now()
+	next = w.flush(t.Context())
+	assert.True(t, next.IsZero())
+	assert.Equal(t, []string{"a.csv"}, delivered)
+}
+
 func joinWithMustEvalSymlinks(v ...string) string {
 	r, err := filepath.EvalSymlinks(filepath.Join(v...))
 	if err != nil {
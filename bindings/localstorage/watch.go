@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gobwas/glob"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	// Events delivered by the input binding.
+	eventCreated  = "created"
+	eventModified = "modified"
+
+	defaultWatchDebounce = 500 * time.Millisecond
+
+	// Defines the metadata keys of the events delivered by the input binding.
+	metadataEvent = "event"
+)
+
+// fileEvent is the data of the events delivered by the input binding.
+type fileEvent struct {
+	Event string `json:"event"`
+	// Path of the file relative to the root path.
+	FileName string    `json:"fileName"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+}
+
+// pathMatcher matches the paths of files with a glob pattern: patterns without separator, such as "*.csv", match the
+// name of the files, and the others match their path, where "**" matches any number of directories.
+type pathMatcher struct {
+	glob     glob.Glob
+	baseName bool
+}
+
+func newPathMatcher(pattern string) (*pathMatcher, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	pattern = filepath.ToSlash(pattern)
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	return &pathMatcher{
+		glob:     g,
+		baseName: !strings.Contains(pattern, "/"),
+	}, nil
+}
+
+// match returns true if the path of a file, relative to the directory it's found in, matches the pattern.
+// A nil matcher matches all files.
+func (m *pathMatcher) match(relPath string) bool {
+	if m == nil {
+		return true
+	}
+	relPath = filepath.ToSlash(relPath)
+	if m.baseName {
+		relPath = relPath[strings.LastIndex(relPath, "/")+1:]
+	}
+	return m.glob.Match(relPath)
+}
+
+// Read watches the root path, and delivers an event to the handler each time a file is created or modified, with the
+// name of the file relative to the root path, its size and modification time.
+// Events are delivered once the file has not changed for the watchDebounce interval, so files are complete when
+// they're delivered while they're written. The size and modification time of the file are checked too, as changes
+// are not reported by all file systems.
+func (ls *LocalStorage) Read(ctx context.Context, handler bindings.Handler) error {
+	if ls.closed.Load() {
+		return errors.New("binding is closed")
+	}
+
+	matcher, err := newPathMatcher(ls.metadata.WatchPattern)
+	if err != nil {
+		return fmt.Errorf("invalid watchPattern: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating watcher: %w", err)
+	}
+	w := &dirWatcher{
+		ls:      ls,
+		watcher: watcher,
+		matcher: matcher,
+		handler: handler,
+		pending: map[string]*pendingEvent{},
+	}
+	err = w.addDir(ls.metadata.RootPath, false)
+	if err != nil {
+		watcher.Close()
+		return err
+	}
+
+	// Close read context when binding is closed.
+	readCtx, cancel := context.WithCancel(ctx)
+	ls.wg.Add(2)
+	go func() {
+		defer ls.wg.Done()
+		defer cancel()
+
+		select {
+		case <-ls.closeCh:
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer ls.wg.Done()
+		defer watcher.Close()
+		w.run(readCtx)
+	}()
+
+	return nil
+}
+
+// pendingEvent is an event of a file that is delivered once the file has not changed for the debounce interval.
+type pendingEvent struct {
+	event    string
+	deadline time.Time
+	// Size and modification time of the file when the deadline was set.
+	size    int64
+	modTime time.Time
+}
+
+// dirWatcher delivers the events of the files of the root path.
+type dirWatcher struct {
+	ls      *LocalStorage
+	watcher *fsnotify.Watcher
+	matcher *pathMatcher
+	handler bindings.Handler
+	pending map[string]*pendingEvent
+}
+
+// addDir watches a directory, and its subdirectories if the watch is recursive.
+// When the directory is new, the files it already contains are reported as created, as they were created before the
+// directory was watched.
+func (w *dirWatcher) addDir(dir string, isNew bool) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The directory may have been deleted in the meantime
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			if isNew {
+				w.add(path, eventCreated)
+			}
+			return nil
+		}
+		if path != w.ls.metadata.RootPath && !w.ls.metadata.WatchRecursive {
+			return filepath.SkipDir
+		}
+		err = w.watcher.Add(path)
+		if err != nil {
+			return fmt.Errorf("error watching directory %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// add adds an event of a file to the pending events: a created event is not turned into a modified one.
+func (w *dirWatcher) add(path string, event string) {
+	relPath, err := filepath.Rel(w.ls.metadata.RootPath, path)
+	if err != nil || !w.matcher.match(relPath) {
+		return
+	}
+
+	p, ok := w.pending[path]
+	if !ok {
+		p = &pendingEvent{event: event}
+		w.pending[path] = p
+	}
+	p.deadline = time.Now().Add(w.ls.metadata.WatchDebounce)
+	if fi, err := os.Stat(path); err == nil {
+		p.size, p.modTime = fi.Size(), fi.ModTime()
+	}
+}
+
+func (w *dirWatcher) run(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.ls.logger.Errorf("Error watching %s: %v", w.ls.metadata.RootPath, err)
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(ev)
+		case <-timer.C:
+		}
+
+		next := w.flush(ctx)
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+func (w *dirWatcher) handleEvent(ev fsnotify.Event) {
+	switch {
+	case ev.Has(fsnotify.Create):
+		fi, err := os.Stat(ev.Name)
+		if err != nil {
+			return
+		}
+		if fi.IsDir() {
+			if w.ls.metadata.WatchRecursive {
+				err = w.addDir(ev.Name, true)
+				if err != nil {
+					w.ls.logger.Errorf("Error watching new directory: %v", err)
+				}
+			}
+			return
+		}
+		w.add(ev.Name, eventCreated)
+	case ev.Has(fsnotify.Write):
+		w.add(ev.Name, eventModified)
+	case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+		// Files removed before they're delivered are not reported
+		delete(w.pending, ev.Name)
+	}
+}
+
+// flush delivers the pending events whose deadline has passed, and returns the deadline of the next one, if any.
+// Events of files whose size or modification time changed since the deadline was set are delayed again.
+func (w *dirWatcher) flush(ctx context.Context) time.Time {
+	var next time.Time
+	now := time.Now()
+	for path, p := range w.pending {
+		if !p.deadline.After(now) {
+			if fi, err := os.Stat(path); err == nil && (fi.Size() != p.size || !fi.ModTime().Equal(p.modTime)) {
+				p.deadline = now.Add(w.ls.metadata.WatchDebounce)
+				p.size, p.modTime = fi.Size(), fi.ModTime()
+			}
+		}
+		if p.deadline.After(now) {
+			if next.IsZero() || p.deadline.Before(next) {
+				next = p.deadline
+			}
+			continue
+		}
+		delete(w.pending, path)
+		w.deliver(ctx, path, p.event)
+	}
+	return next
+}
+
+func (w *dirWatcher) deliver(ctx context.Context, path string, event string) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return
+	}
+	relPath, err := filepath.Rel(w.ls.metadata.RootPath, path)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(fileEvent{
+		Event:    event,
+		FileName: relPath,
+		Size:     fi.Size(),
+		ModTime:  fi.ModTime(),
+	})
+	if err != nil {
+		w.ls.logger.Errorf("Error marshalling event of file %s: %v", relPath, err)
+		return
+	}
+
+	_, err = w.handler(ctx, &bindings.ReadResponse{
+		Data: data,
+		Metadata: map[string]string{
+			fileNameMetadataKey: relPath,
+			metadataEvent:       event,
+		},
+	})
+	if err != nil {
+		w.ls.logger.Errorf("Error handling event of file %s: %v", relPath, err)
+	}
+}
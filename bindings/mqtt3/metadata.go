@@ -16,31 +16,44 @@ package mqtt
 import (
 	"encoding/pem"
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/metadata"
 )
 
 const (
 	// Keys.
-	mqttURL               = "url"
-	mqttTopic             = "topic"
-	mqttQOS               = "qos" // This key is deprecated
-	mqttRetain            = "retain"
-	mqttClientID          = "consumerID"
-	mqttCleanSession      = "cleanSession"
-	mqttCACert            = "caCert"
-	mqttClientCert        = "clientCert"
-	mqttClientKey         = "clientKey"
-	mqttBackOffMaxRetries = "backOffMaxRetries"
+	mqttURL                   = "url"
+	mqttTopic                 = "topic"
+	mqttQOS                   = "qos" // This key is deprecated
+	mqttRetain                = "retain"
+	mqttClientID              = "consumerID"
+	mqttCleanSession          = "cleanSession"
+	mqttCACert                = "caCert"
+	mqttClientCert            = "clientCert"
+	mqttClientKey             = "clientKey"
+	mqttBackOffMaxRetries     = "backOffMaxRetries"
+	mqttSessionExpiry         = "sessionExpiryInterval"
+	mqttMaxConcurrentHandlers = "maxConcurrentHandlers"
+
+	// Supported protocol versions.
+	protocolVersion311 = "3.1.1"
+	protocolVersion5   = "5"
 
 	// Defaults.
-	defaultQOS          = 1
-	defaultRetain       = false
-	defaultWait         = 10 * time.Second
-	defaultCleanSession = false
+	defaultQOS             = 1
+	defaultRetain          = false
+	defaultWait            = 10 * time.Second
+	defaultCleanSession    = false
+	defaultProtocolVersion = protocolVersion311
+
+	// With MQTT 5, a session expiry interval of 0xFFFFFFFF seconds means the session never expires.
+	maxSessionExpiryInterval = math.MaxUint32 * time.Second
 )
 
 //nolint:stylecheck
@@ -53,6 +66,12 @@ type mqtt3Metadata struct {
 	CleanSession      bool   `mapstructure:"cleanSession"`
 	BackOffMaxRetries int    `mapstructure:"backOffMaxRetries"`
 	Topic             string `mapstructure:"topic"`
+
+	// Options below are used with MQTT 5 only.
+	ProtocolVersion       string        `mapstructure:"protocolVersion"`
+	SessionExpiryInterval time.Duration `mapstructure:"sessionExpiryInterval"`
+	TopicAliasMaximum     uint16        `mapstructure:"topicAliasMaximum"`
+	MaxConcurrentHandlers int           `mapstructure:"maxConcurrentHandlers"`
 }
 
 type tlsCfg struct {
@@ -63,8 +82,10 @@ type tlsCfg struct {
 
 func parseMQTTMetaData(md bindings.Metadata, log logger.Logger) (mqtt3Metadata, error) {
 	m := mqtt3Metadata{
-		Retain:       defaultRetain,
-		CleanSession: defaultCleanSession,
+		Retain:                defaultRetain,
+		CleanSession:          defaultCleanSession,
+		ProtocolVersion:       defaultProtocolVersion,
+		MaxConcurrentHandlers: mqttcomponent.DefaultMaxConcurrentHandlers,
 	}
 
 	err := metadata.DecodeMetadata(md.Properties, &m)
@@ -101,6 +122,26 @@ func parseMQTTMetaData(md bindings.Metadata, log logger.Logger) (mqtt3Metadata,
 		}
 	}
 
+	switch m.ProtocolVersion {
+	case protocolVersion311:
+		if m.SessionExpiryInterval != 0 || m.TopicAliasMaximum != 0 || md.Properties[mqttMaxConcurrentHandlers] != "" {
+			log.Warn("Metadata properties 'sessionExpiryInterval', 'topicAliasMaximum' and 'maxConcurrentHandlers' are only used with MQTT 5 and are ignored")
+		}
+	case protocolVersion5:
+		if m.SessionExpiryInterval < 0 || m.SessionExpiryInterval > maxSessionExpiryInterval {
+			return m, fmt.Errorf("invalid sessionExpiryInterval: must be between 0 and %d seconds", uint32(math.MaxUint32))
+		}
+		// When the session is persistent and no expiry is set, keep the session forever like MQTT 3.1.1 does
+		if md.Properties[mqttSessionExpiry] == "" && !m.CleanSession {
+			m.SessionExpiryInterval = maxSessionExpiryInterval
+		}
+		if m.MaxConcurrentHandlers <= 0 {
+			return m, fmt.Errorf("invalid maxConcurrentHandlers %d: must be greater than 0", m.MaxConcurrentHandlers)
+		}
+	default:
+		return m, fmt.Errorf("invalid protocolVersion '%s': supported values are '%s' and '%s'", m.ProtocolVersion, protocolVersion311, protocolVersion5)
+	}
+
 	// Deprecated options
 	m.Qos = defaultQOS
 	if val, ok := md.Properties[mqttQOS]; ok && val != "" {
@@ -26,9 +26,11 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/eclipse/paho.golang/autopaho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 
 	"github.com/dapr/components-contrib/bindings"
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/retry"
@@ -37,6 +39,8 @@ import (
 // MQTT allows sending and receiving data to/from an MQTT broker.
 type MQTT struct {
 	producer     mqtt.Client
+	producer5    *autopaho.ConnectionManager
+	aliases      *topicAliases
 	producerLock sync.RWMutex
 	metadata     mqtt3Metadata
 	logger       logger.Logger
@@ -106,6 +110,10 @@ func (m *MQTT) getProducer() (mqtt.Client, error) {
 }
 
 func (m *MQTT) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if m.metadata.ProtocolVersion == protocolVersion5 {
+		return nil, m.invoke5(ctx, req)
+	}
+
 	producer, err := m.getProducer()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create producer connection: %w", err)
@@ -174,9 +182,23 @@ func (m *MQTT) Read(ctx context.Context, handler bindings.Handler) error {
 
 	// Establish the connection
 	// This will also create the subscription in the OnConnect handler
-	consumer, err := m.connect(consumerClientID, true)
-	if err != nil {
-		return err
+	var disconnect func()
+	if m.metadata.ProtocolVersion == protocolVersion5 {
+		consumer, err := m.connect5(consumerClientID, true)
+		if err != nil {
+			return err
+		}
+		disconnect = func() {
+			mqttcomponent.Disconnect(consumer)
+		}
+	} else {
+		consumer, err := m.connect(consumerClientID, true)
+		if err != nil {
+			return err
+		}
+		disconnect = func() {
+			consumer.Disconnect(200)
+		}
 	}
 
 	// In background, watch for contexts cancelation and stop the connection
@@ -195,7 +217,7 @@ func (m *MQTT) Read(ctx context.Context, handler bindings.Handler) error {
 		m.logger.Infof("Disconnecting and stopping subscription to topic %s", m.metadata.Topic)
 
 		// Disconnect and then release the "lock"
-		consumer.Disconnect(200)
+		disconnect()
 		m.isSubscribed.Store(false)
 	}()
 
@@ -297,45 +319,54 @@ func (m *MQTT) createClientOptions(uri *url.URL, clientID string) *mqtt.ClientOp
 
 func (m *MQTT) handleMessage() func(client mqtt.Client, mqttMsg mqtt.Message) {
 	return func(client mqtt.Client, mqttMsg mqtt.Message) {
-		bo := m.backOff
-		if m.metadata.BackOffMaxRetries >= 0 {
-			bo = backoff.WithMaxRetries(bo, uint64(m.metadata.BackOffMaxRetries)) //nolint:gosec
+		err := m.processMessage(mqttMsg.Topic(), mqttMsg.MessageID(), mqttMsg.Payload(), nil)
+		if err == nil {
+			// Ack the message on success
+			mqttMsg.Ack()
 		}
+	}
+}
 
-		err := retry.NotifyRecover(
-			func() error {
-				m.logger.Debugf("Processing MQTT message %s/%d", mqttMsg.Topic(), mqttMsg.MessageID())
-				// Use a background context here so that the context is not tied to the
-				// first Invoke first created the producer.
-				// TODO: add context to mqtt library, and add a OnConnectWithContext option
-				// to change this func signature to
-				// func(c mqtt.Client, ctx context.Context)
-				_, err := m.readHandler(context.Background(), &bindings.ReadResponse{
-					Data: mqttMsg.Payload(),
-					Metadata: map[string]string{
-						mqttTopic: mqttMsg.Topic(),
-					},
-				})
-				if err != nil {
-					return err
-				}
-
-				// Ack the message on success
-				mqttMsg.Ack()
-				return nil
-			},
-			bo,
-			func(err error, d time.Duration) {
-				m.logger.Errorf("Error processing MQTT message: %s/%d. Retrying…", mqttMsg.Topic(), mqttMsg.MessageID())
-			},
-			func() {
-				m.logger.Infof("Successfully processed MQTT message after it previously failed: %s/%d", mqttMsg.Topic(), mqttMsg.MessageID())
-			},
-		)
-		if err != nil {
-			m.logger.Errorf("Failed processing MQTT message: %s/%d: %v", mqttMsg.Topic(), mqttMsg.MessageID(), err)
-		}
+// processMessage invokes the handler with the message, retrying on failure.
+// Metadata, if any, is included in the response together with the topic.
+func (m *MQTT) processMessage(topic string, messageID uint16, payload []byte, md map[string]string) error {
+	bo := m.backOff
+	if m.metadata.BackOffMaxRetries >= 0 {
+		bo = backoff.WithMaxRetries(bo, uint64(m.metadata.BackOffMaxRetries)) //nolint:gosec
+	}
+
+	responseMetadata := make(map[string]string, len(md)+1)
+	for k, v := range md {
+		responseMetadata[k] = v
 	}
+	responseMetadata[mqttTopic] = topic
+
+	err := retry.NotifyRecover(
+		func() error {
+			m.logger.Debugf("Processing MQTT message %s/%d", topic, messageID)
+			// Use a background context here so that the context is not tied to the
+			// first Invoke first created the producer.
+			// TODO: add context to mqtt library, and add a OnConnectWithContext option
+			// to change this func signature to
+			// func(c mqtt.Client, ctx context.Context)
+			_, err := m.readHandler(context.Background(), &bindings.ReadResponse{
+				Data:     payload,
+				Metadata: responseMetadata,
+			})
+			return err
+		},
+		bo,
+		func(err error, d time.Duration) {
+			m.logger.Errorf("Error processing MQTT message: %s/%d. Retrying…", topic, messageID)
+		},
+		func() {
+			m.logger.Infof("Successfully processed MQTT message after it previously failed: %s/%d", topic, messageID)
+		},
+	)
+	if err != nil {
+		m.logger.Errorf("Failed processing MQTT message: %s/%d: %v", topic, messageID, err)
+	}
+	return err
 }
 
 // Extends createClientOptions with options for subscribers only
@@ -378,6 +409,11 @@ func (m *MQTT) Close() error {
 		m.producer = nil
 	}
 
+	if m.producer5 != nil {
+		mqttcomponent.Disconnect(m.producer5)
+		m.producer5 = nil
+	}
+
 	m.wg.Wait()
 
 	return nil
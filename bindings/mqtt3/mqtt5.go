@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/dapr/components-contrib/bindings"
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
+)
+
+func (m *MQTT) getProducer5() (*autopaho.ConnectionManager, error) {
+	// Get the producer from the cache
+	m.producerLock.RLock()
+	producer := m.producer5
+	m.producerLock.RUnlock()
+	if producer != nil {
+		return producer, nil
+	}
+
+	// Must create a new producer
+	m.producerLock.Lock()
+	defer m.producerLock.Unlock()
+
+	// Check again in case another goroutine created it in the meanwhile
+	producer = m.producer5
+	if producer != nil {
+		return producer, nil
+	}
+
+	// mqtt broker allows only one connection at a given time from a clientID.
+	producerClientID := m.metadata.ClientID + "-producer"
+	p, err := m.connect5(producerClientID, false)
+	if err != nil {
+		return nil, err
+	}
+	m.producer5 = p
+
+	return p, nil
+}
+
+// invoke5 publishes a message using MQTT 5.
+// Request metadata, except for the topic, is sent as user properties.
+func (m *MQTT) invoke5(ctx context.Context, req *bindings.InvokeRequest) error {
+	producer, err := m.getProducer5()
+	if err != nil {
+		return fmt.Errorf("failed to create producer connection: %w", err)
+	}
+
+	topic, ok := req.Metadata[mqttTopic]
+	if !ok || topic == "" {
+		// If user does not specify a topic, publish via the component's default topic.
+		topic = m.metadata.Topic
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultWait)
+	defer cancel()
+	_, err = producer.Publish(ctx, &paho.Publish{
+		QoS:     m.metadata.Qos,
+		Retain:  m.metadata.Retain,
+		Topic:   topic,
+		Payload: req.Data,
+		Properties: &paho.PublishProperties{
+			User: mqttcomponent.UserProperties(req.Metadata, mqttTopic),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	return nil
+}
+
+// connect5 connects to the broker using MQTT 5.
+// The returned connection manager re-establishes the connection when it's lost, until it's disconnected.
+func (m *MQTT) connect5(clientID string, isSubscriber bool) (*autopaho.ConnectionManager, error) {
+	uri, err := url.Parse(m.metadata.Url)
+	if err != nil {
+		return nil, err
+	}
+	var cfg autopaho.ClientConfig
+	if isSubscriber {
+		cfg = m.createSubscriberClientConfig5(uri, clientID)
+	} else {
+		cfg = m.createProducerClientConfig5(uri, clientID)
+	}
+
+	cm, err := autopaho.NewConnection(context.Background(), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+	defer cancel()
+	err = cm.AwaitConnection(ctx)
+	if err != nil {
+		mqttcomponent.Disconnect(cm)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = errors.New("mqtt client timed out connecting")
+		}
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	return cm, nil
+}
+
+// Returns the configuration for clients for both publisher and subscriber
+func (m *MQTT) createClientConfig5(uri *url.URL, clientID string) autopaho.ClientConfig {
+	return mqttcomponent.NewClientConfig(mqttcomponent.ClientOptions{
+		URL:                   uri,
+		ClientID:              clientID,
+		TLSConfig:             m.newTLSConfig(),
+		CleanSession:          m.metadata.CleanSession,
+		SessionExpiryInterval: m.metadata.SessionExpiryInterval,
+		ConnectTimeout:        defaultWait,
+	}, m.logger)
+}
+
+// Extends createClientConfig5 with topic aliases, which are used by producers only
+func (m *MQTT) createProducerClientConfig5(uri *url.URL, clientID string) autopaho.ClientConfig {
+	cfg := m.createClientConfig5(uri, clientID)
+	if m.metadata.TopicAliasMaximum == 0 {
+		return cfg
+	}
+
+	m.aliases = newTopicAliases(m.metadata.TopicAliasMaximum)
+	cfg.ConnectPacketBuilder = func(c *paho.Connect, _ *url.URL) (*paho.Connect, error) {
+		m.aliases.reset()
+		return c, nil
+	}
+	cfg.OnConnectionUp = func(_ *autopaho.ConnectionManager, connAck *paho.Connack) {
+		var serverMax uint16
+		if connAck.Properties != nil && connAck.Properties.TopicAliasMaximum != nil {
+			serverMax = *connAck.Properties.TopicAliasMaximum
+		}
+		m.aliases.connected(serverMax)
+	}
+	cfg.PublishHook = m.aliases.apply
+
+	return cfg
+}
+
+// Extends createClientConfig5 with options for subscribers only
+func (m *MQTT) createSubscriberClientConfig5(uri *url.URL, clientID string) autopaho.ClientConfig {
+	cfg := m.createClientConfig5(uri, clientID)
+
+	// On (re-)connection, add the topic subscription
+	cfg.OnConnectionUp = func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+		defer cancel()
+		_, err := cm.Subscribe(ctx, &paho.Subscribe{
+			Subscriptions: []paho.SubscribeOptions{
+				{Topic: m.metadata.Topic, QoS: m.metadata.Qos},
+			},
+		})
+
+		// Nothing we can do in case of errors besides logging them
+		if err != nil {
+			m.logger.Errorf("Error starting subscriptions in the OnConnectionUp handler: %v", err)
+		}
+	}
+
+	dispatcher := mqttcomponent.NewDispatcher(m.metadata.MaxConcurrentHandlers, m.handlePublish5, m.logger)
+	cfg.OnPublishReceived = []func(paho.PublishReceived) (bool, error){dispatcher.OnPublishReceived}
+
+	return cfg
+}
+
+// handlePublish5 processes a message received with MQTT 5, including its user properties in the metadata.
+// The dispatcher acknowledges the message afterwards, even if it couldn't be processed.
+func (m *MQTT) handlePublish5(p *paho.Publish) {
+	_ = m.processMessage(p.Topic, p.PacketID, p.Payload, mqttcomponent.UserPropertiesMetadata(p))
+}
+
+// topicAliases assigns topic aliases to the topics a producer publishes to, so the topic name is sent
+// only with the first message to each topic.
+// Aliases are valid for a single network connection, so they are reset when connecting, and none is used
+// until the broker has acknowledged the connection with the maximum number of aliases it accepts.
+type topicAliases struct {
+	lock    sync.Mutex
+	limit   uint16
+	max     uint16
+	aliases map[string]uint16
+}
+
+func newTopicAliases(limit uint16) *topicAliases {
+	return &topicAliases{
+		limit:   limit,
+		aliases: make(map[string]uint16),
+	}
+}
+
+func (a *topicAliases) reset() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.max = 0
+	clear(a.aliases)
+}
+
+func (a *topicAliases) connected(serverMax uint16) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.max = min(a.limit, serverMax)
+}
+
+// apply is invoked before a message is published.
+func (a *topicAliases) apply(p *paho.Publish) {
+	if p.Topic == "" || (p.Properties != nil && p.Properties.TopicAlias != nil) {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	alias, ok := a.aliases[p.Topic]
+	if ok {
+		// The broker already knows the alias, so the topic can be omitted
+		p.Topic = ""
+	} else {
+		if len(a.aliases) >= int(a.max) {
+			return
+		}
+		alias = uint16(len(a.aliases) + 1) //nolint:gosec
+		a.aliases[p.Topic] = alias
+	}
+
+	if p.Properties == nil {
+		p.Properties = &paho.PublishProperties{}
+	}
+	p.Properties.TopicAlias = &alias
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/eclipse/paho.golang/paho"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -222,6 +223,154 @@ func TestParseMetadata(t *testing.T) {
 	})
 }
 
+func TestParseMetadataProtocolVersion(t *testing.T) {
+	t.Run("defaults to MQTT 3.1.1", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, protocolVersion311, m.ProtocolVersion)
+		assert.Equal(t, time.Duration(0), m.SessionExpiryInterval)
+	})
+
+	t.Run("MQTT 5 with persistent session never expires by default", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, protocolVersion5, m.ProtocolVersion)
+		assert.Equal(t, maxSessionExpiryInterval, m.SessionExpiryInterval)
+		assert.Equal(t, uint16(0), m.TopicAliasMaximum)
+	})
+
+	t.Run("MQTT 5 with clean session", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties[mqttCleanSession] = "true"
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), m.SessionExpiryInterval)
+	})
+
+	t.Run("MQTT 5 options", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties[mqttSessionExpiry] = "1h"
+		fakeMetaData.Properties["topicAliasMaximum"] = "10"
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, m.SessionExpiryInterval)
+		assert.Equal(t, uint16(10), m.TopicAliasMaximum)
+	})
+
+	t.Run("invalid session expiry interval", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties[mqttSessionExpiry] = "-1s"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid sessionExpiryInterval")
+	})
+
+	t.Run("invalid max concurrent handlers", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties["maxConcurrentHandlers"] = "0"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid maxConcurrentHandlers")
+	})
+
+	t.Run("invalid protocol version", func(t *testing.T) {
+		fakeMetaData := bindings.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "4"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid protocolVersion")
+	})
+}
+
+func TestMQTT5(t *testing.T) {
+	t.Run("user properties are included in the response metadata", func(t *testing.T) {
+		m := NewMQTT(logger.NewLogger("test")).(*MQTT)
+		m.backOff = backoff.NewConstantBackOff(time.Millisecond)
+		called := false
+		m.readHandler = func(ctx context.Context, r *bindings.ReadResponse) ([]byte, error) {
+			called = true
+			assert.Equal(t, []byte("hello world"), r.Data)
+			assert.Equal(t, map[string]string{
+				mqttTopic: "my/topic",
+				"foo":     "bar",
+			}, r.Metadata)
+			return nil, nil
+		}
+
+		m.handlePublish5(&paho.Publish{
+			Topic:   "my/topic",
+			Payload: []byte("hello world"),
+			Properties: &paho.PublishProperties{
+				User: paho.UserProperties{
+					{Key: "foo", Value: "bar"},
+					{Key: mqttTopic, Value: "ignored"},
+				},
+			},
+		})
+		assert.True(t, called)
+	})
+
+	t.Run("topic aliases", func(t *testing.T) {
+		aliases := newTopicAliases(2)
+		publish := func(topic string) *paho.Publish {
+			p := &paho.Publish{Topic: topic}
+			aliases.apply(p)
+			return p
+		}
+
+		// No alias is used until the connection is acknowledged
+		p := publish("a")
+		assert.Equal(t, "a", p.Topic)
+		assert.Nil(t, p.Properties)
+
+		// The broker accepts more aliases than configured
+		aliases.connected(5)
+
+		p = publish("a")
+		assert.Equal(t, "a", p.Topic)
+		assert.Equal(t, uint16(1), *p.Properties.TopicAlias)
+		p = publish("a")
+		assert.Empty(t, p.Topic)
+		assert.Equal(t, uint16(1), *p.Properties.TopicAlias)
+		p = publish("b")
+		assert.Equal(t, "b", p.Topic)
+		assert.Equal(t, uint16(2), *p.Properties.TopicAlias)
+
+		// All aliases are taken
+		p = publish("c")
+		assert.Equal(t, "c", p.Topic)
+		assert.Nil(t, p.Properties)
+
+		// Aliases are forgotten when reconnecting
+		aliases.reset()
+		aliases.connected(1)
+		p = publish("b")
+		assert.Equal(t, "b", p.Topic)
+		assert.Equal(t, uint16(1), *p.Properties.TopicAlias)
+		p = publish("a")
+		assert.Equal(t, "a", p.Topic)
+		assert.Nil(t, p.Properties)
+	})
+}
+
 type mqttMockMessage struct {
 	duplicate         bool
 	qos               byte
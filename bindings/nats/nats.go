@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	requestOperation bindings.OperationKind = "request"
+
+	defaultRequestTimeout = 5 * time.Second
+
+	// Metadata of the requests, and of the messages delivered to the app: the other metadata are sent and delivered
+	// as headers of the messages.
+	metadataSubject = "subject"
+	metadataReply   = "reply"
+	metadataTimeout = "timeout"
+
+	// Headers of the replies of failed requests, as set by the services of the NATS micro framework.
+	errorHeader     = "Nats-Service-Error"
+	errorCodeHeader = "Nats-Service-Error-Code"
+)
+
+// Nats is a binding publishing messages and sending requests to core NATS subjects, and receiving the messages of a
+// subject, replying to the requests with the response of the app.
+type Nats struct {
+	metadata natsMetadata
+	nc       *nats.Conn
+	logger   logger.Logger
+	closeCh  chan struct{}
+	closed   atomic.Bool
+	wg       sync.WaitGroup
+}
+
+type natsMetadata struct {
+	NatsURL string `mapstructure:"natsURL"`
+	Name    string `mapstructure:"name"`
+
+	Jwt     string `mapstructure:"jwt"`
+	SeedKey string `mapstructure:"seedKey"`
+	Token   string `mapstructure:"token"`
+
+	TLSClientCert string `mapstructure:"tls_client_cert"`
+	TLSClientKey  string `mapstructure:"tls_client_key"`
+
+	// Subject messages are published to, and received from by the input binding.
+	Subject string `mapstructure:"subject"`
+	// Input binding: queue group of the subscription, so each message is delivered to a single member of the group.
+	QueueGroupName string `mapstructure:"queueGroupName"`
+	// Time the replies of requests are waited for.
+	RequestTimeout time.Duration `mapstructure:"requestTimeout"`
+}
+
+// NewNats returns a new NATS binding.
+func NewNats(logger logger.Logger) bindings.InputOutputBinding {
+	return &Nats{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (n *Nats) Init(_ context.Context, meta bindings.Metadata) error {
+	err := n.parseMetadata(meta)
+	if err != nil {
+		return fmt.Errorf("nats binding error: %w", err)
+	}
+
+	opts := []nats.Option{nats.Name(n.metadata.Name)}
+	// Set nats.UserJWT options when jwt and seed key is provided.
+	if n.metadata.Jwt != "" && n.metadata.SeedKey != "" {
+		opts = append(opts, nats.UserJWT(func() (string, error) {
+			return n.metadata.Jwt, nil
+		}, func(nonce []byte) ([]byte, error) {
+			return sigHandler(n.metadata.SeedKey, nonce)
+		}))
+	} else if n.metadata.TLSClientCert != "" && n.metadata.TLSClientKey != "" {
+		opts = append(opts, nats.ClientCert(n.metadata.TLSClientCert, n.metadata.TLSClientKey))
+	} else if n.metadata.Token != "" {
+		opts = append(opts, nats.Token(n.metadata.Token))
+	}
+
+	n.nc, err = nats.Connect(n.metadata.NatsURL, opts...)
+	if err != nil {
+		return fmt.Errorf("nats binding error: error connecting to %s: %w", n.metadata.NatsURL, err)
+	}
+
+	return nil
+}
+
+func (n *Nats) parseMetadata(meta bindings.Metadata) error {
+	// Set default values
+	n.metadata = natsMetadata{
+		NatsURL:        nats.DefaultURL,
+		Name:           "dapr.io - bindings.nats",
+		RequestTimeout: defaultRequestTimeout,
+	}
+
+	// Decode
+	err := kitmd.DecodeMetadata(meta.Properties, &n.metadata)
+	if err != nil {
+		return err
+	}
+
+	// Validate
+	if n.metadata.RequestTimeout <= 0 {
+		return errors.New("requestTimeout must be greater than zero")
+	}
+
+	return nil
+}
+
+func (n *Nats) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation, requestOperation}
+}
+
+// Invoke publishes the data of the request to the subject, or sends it as a request and returns the reply.
+func (n *Nats) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	msg, err := n.newMessage(req)
+	if err != nil {
+		return nil, fmt.Errorf("nats binding error: %w", err)
+	}
+
+	switch req.Operation {
+	case bindings.CreateOperation:
+		err = n.nc.PublishMsg(msg)
+		if err != nil {
+			return nil, fmt.Errorf("nats binding error: error publishing to %s: %w", msg.Subject, err)
+		}
+		return nil, nil
+	case requestOperation:
+		return n.request(ctx, msg, req.Metadata)
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", req.Operation)
+	}
+}
+
+// newMessage returns the message of a request, to the subject of the request or of the component.
+func (n *Nats) newMessage(req *bindings.InvokeRequest) (*nats.Msg, error) {
+	subject := n.metadata.Subject
+	if val := req.Metadata[metadataSubject]; val != "" {
+		subject = val
+	}
+	if subject == "" {
+		return nil, errors.New("missing subject")
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = req.Data
+	for k, v := range req.Metadata {
+		switch k {
+		case metadataSubject, metadataTimeout:
+		default:
+			msg.Header.Set(k, v)
+		}
+	}
+	return msg, nil
+}
+
+// request sends a request, and returns its reply with the headers of the reply as metadata.
+func (n *Nats) request(ctx context.Context, msg *nats.Msg, md map[string]string) (*bindings.InvokeResponse, error) {
+	timeout := n.metadata.RequestTimeout
+	if val := md[metadataTimeout]; val != "" {
+		var err error
+		timeout, err = time.ParseDuration(val)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("nats binding error: invalid timeout %s", val)
+		}
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	reply, err := n.nc.RequestMsgWithContext(reqCtx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("nats binding error: error sending request to %s: %w", msg.Subject, err)
+	}
+	if errMsg := reply.Header.Get(errorHeader); errMsg != "" {
+		return nil, fmt.Errorf("nats binding error: request to %s failed: %s", msg.Subject, errMsg)
+	}
+
+	return &bindings.InvokeResponse{
+		Data:     reply.Data,
+		Metadata: headerMetadata(reply.Header),
+	}, nil
+}
+
+// Read subscribes to the subject, in the queue group if any, and delivers the messages to the handler.
+// When a message is a request, the response of the handler is sent as its reply; if the handler fails, the reply has
+// the error in its Nats-Service-Error header.
+func (n *Nats) Read(ctx context.Context, handler bindings.Handler) error {
+	if n.closed.Load() {
+		return errors.New("binding is closed")
+	}
+	if n.metadata.Subject == "" {
+		return errors.New("nats binding error: missing subject")
+	}
+
+	sub, err := n.nc.QueueSubscribe(n.metadata.Subject, n.metadata.QueueGroupName, func(msg *nats.Msg) {
+		n.handleMessage(ctx, handler, msg)
+	})
+	if err != nil {
+		return fmt.Errorf("nats binding error: error subscribing to %s: %w", n.metadata.Subject, err)
+	}
+	n.logger.Debugf("Subscribed to %s", n.metadata.Subject)
+
+	// Unsubscribe when context is canceled or binding closed.
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-n.closeCh:
+		}
+		err := sub.Unsubscribe()
+		if err != nil && !errors.Is(err, nats.ErrConnectionClosed) {
+			n.logger.Errorf("Error unsubscribing from %s: %v", n.metadata.Subject, err)
+		}
+	}()
+
+	return nil
+}
+
+func (n *Nats) handleMessage(ctx context.Context, handler bindings.Handler, msg *nats.Msg) {
+	md := headerMetadata(msg.Header)
+	md[metadataSubject] = msg.Subject
+	if msg.Reply != "" {
+		md[metadataReply] = msg.Reply
+	}
+
+	res, err := handler(ctx, &bindings.ReadResponse{
+		Data:     msg.Data,
+		Metadata: md,
+	})
+	if err != nil {
+		n.logger.Errorf("Error handling message from %s: %v", msg.Subject, err)
+	}
+	if msg.Reply == "" {
+		return
+	}
+
+	reply := nats.NewMsg(msg.Reply)
+	if err != nil {
+		reply.Header.Set(errorHeader, err.Error())
+		reply.Header.Set(errorCodeHeader, "500")
+	} else {
+		reply.Data = res
+	}
+	err = msg.RespondMsg(reply)
+	if err != nil {
+		n.logger.Errorf("Error replying to message from %s: %v", msg.Subject, err)
+	}
+}
+
+// headerMetadata returns the first value of each header.
+func headerMetadata(header nats.Header) map[string]string {
+	md := make(map[string]string, len(header)+2)
+	for k, v := range header {
+		if len(v) > 0 {
+			md[k] = v[0]
+		}
+	}
+	return md
+}
+
+func (n *Nats) Close() error {
+	if n.closed.CompareAndSwap(false, true) {
+		close(n.closeCh)
+	}
+	n.wg.Wait()
+
+	if n.nc != nil {
+		return n.nc.Drain()
+	}
+	return nil
+}
+
+func sigHandler(seedKey string, nonce []byte) ([]byte, error) {
+	kp, err := nkeys.FromSeed([]byte(seedKey))
+	if err != nil {
+		return nil, err
+	}
+	// Wipe our key on exit.
+	defer kp.Wipe()
+
+	sig, _ := kp.Sign(nonce)
+	return sig, nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (n *Nats) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := natsMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func newTestNats(t *testing.T, props map[string]string) *Nats {
+	t.Helper()
+
+	n := NewNats(logger.NewLogger("test")).(*Nats)
+	err := n.Init(t.Context(), bindings.Metadata{Base: contribMetadata.Base{Properties: props}})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		n.Close()
+	})
+	return n
+}
+
+func TestParseMetadata(t *testing.T) {
+	n := NewNats(logger.NewLogger("test")).(*Nats)
+	err := n.parseMetadata(bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{
+		"natsURL":        "nats://broker:4222",
+		"subject":        "orders",
+		"queueGroupName": "workers",
+		"requestTimeout": "2s",
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, "nats://broker:4222", n.metadata.NatsURL)
+	assert.Equal(t, "orders", n.metadata.Subject)
+	assert.Equal(t, "workers", n.metadata.QueueGroupName)
+	assert.Equal(t, 2*time.Second, n.metadata.RequestTimeout)
+
+	err = n.parseMetadata(bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{}}})
+	require.NoError(t, err)
+	assert.Equal(t, nats.DefaultURL, n.metadata.NatsURL)
+	assert.Equal(t, defaultRequestTimeout, n.metadata.RequestTimeout)
+
+	err = n.parseMetadata(bindings.Metadata{Base: contribMetadata.Base{Properties: map[string]string{
+		"requestTimeout": "-1s",
+	}}})
+	require.Error(t, err)
+}
+
+func TestPublishAndRead(t *testing.T) {
+	s := natsserver.RunRandClientPortServer()
+	defer s.Shutdown()
+
+	props := map[string]string{
+		"natsURL": s.ClientURL(),
+		"subject": "orders",
+	}
+	input := newTestNats(t, props)
+	output := newTestNats(t, props)
+
+	received := make(chan *bindings.ReadResponse, 1)
+	err := input.Read(t.Context(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		received <- res
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, input.nc.Flush())
+
+	_, err = output.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: bindings.CreateOperation,
+		Data:      []byte("order 1"),
+		Metadata: map[string]string{
+			"Trace-Id": "abc",
+		},
+	})
+	require.NoError(t, err)
+
+	select {
+	case res := <-received:
+		assert.Equal(t, []byte("order 1"), res.Data)
+		assert.Equal(t, "orders", res.Metadata[metadataSubject])
+		assert.Equal(t, "abc", res.Metadata["Trace-Id"])
+		assert.NotContains(t, res.Metadata, metadataReply)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+func TestRequestReply(t *testing.T) {
+	s := natsserver.RunRandClientPortServer()
+	defer s.Shutdown()
+
+	input := newTestNats(t, map[string]string{
+		"natsURL": s.ClientURL(),
+		"subject": "prices.>",
+	})
+	output := newTestNats(t, map[string]string{
+		"natsURL":        s.ClientURL(),
+		"requestTimeout": "200ms",
+	})
+
+	err := input.Read(t.Context(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		if res.Metadata[metadataSubject] == "prices.unknown" {
+			return nil, errors.New("unknown product")
+		}
+		assert.NotEmpty(t, res.Metadata[metadataReply])
+		return append([]byte("price of "), res.Data...), nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, input.nc.Flush())
+
+	t.Run("reply", func(t *testing.T) {
+		res, err := output.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: requestOperation,
+			Data:      []byte("apples"),
+			Metadata:  map[string]string{metadataSubject: "prices.fruits"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("price of apples"), res.Data)
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		_, err := output.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: requestOperation,
+			Metadata:  map[string]string{metadataSubject: "prices.unknown"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown product")
+	})
+
+	t.Run("no responders", func(t *testing.T) {
+		_, err := output.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: requestOperation,
+			Metadata:  map[string]string{metadataSubject: "stock.apples"},
+		})
+		require.ErrorIs(t, err, nats.ErrNoResponders)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		sub, err := output.nc.Subscribe("slow", func(*nats.Msg) {})
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		_, err = output.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: requestOperation,
+			Metadata: map[string]string{
+				metadataSubject: "slow",
+				metadataTimeout: "50ms",
+			},
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		_, err := output.Invoke(t.Context(), &bindings.InvokeRequest{Operation: requestOperation})
+		require.Error(t, err)
+	})
+}
+
+func TestQueueGroup(t *testing.T) {
+	s := natsserver.RunRandClientPortServer()
+	defer s.Shutdown()
+
+	props := map[string]string{
+		"natsURL":        s.ClientURL(),
+		"subject":        "jobs",
+		"queueGroupName": "workers",
+	}
+	var count atomic.Int32
+	handler := func(context.Context, *bindings.ReadResponse) ([]byte, error) {
+		count.Add(1)
+		return nil, nil
+	}
+	for range 3 {
+		worker := newTestNats(t, props)
+		require.NoError(t, worker.Read(t.Context(), handler))
+		require.NoError(t, worker.nc.Flush())
+	}
+
+	output := newTestNats(t, props)
+	for range 10 {
+		_, err := output.Invoke(t.Context(), &bindings.InvokeRequest{Operation: bindings.CreateOperation, Data: []byte("job")})
+		require.NoError(t, err)
+	}
+	require.NoError(t, output.nc.Flush())
+
+	// Each message is delivered to a single worker
+	assert.Eventually(t, func() bool {
+		return count.Load() == 10
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(10), count.Load())
+}
@@ -32,6 +32,17 @@ type OutputBinding interface {
 	io.Closer
 }
 
+// StreamingOutputBinding is implemented by output bindings that can deliver the response of an invocation to the
+// caller as it's received, instead of buffering it until the end.
+type StreamingOutputBinding interface {
+	// InvokeStream performs the invocation like Invoke, calling handler with each chunk of the response data as it
+	// arrives. The returned response has the metadata of the response and no data.
+	InvokeStream(ctx context.Context, req *InvokeRequest, handler StreamHandler) (*InvokeResponse, error)
+}
+
+// StreamHandler handles a chunk of a streamed response. Returning an error stops the stream.
+type StreamHandler func(ctx context.Context, chunk *InvokeResponse) error
+
 func PingOutBinding(ctx context.Context, outputBinding OutputBinding) error {
 	// checks if this output binding has the ping option then executes
 	if outputBindingWithPing, ok := outputBinding.(health.Pinger); ok {
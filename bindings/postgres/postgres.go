@@ -23,6 +23,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -30,18 +32,54 @@ import (
 	pgauth "github.com/dapr/components-contrib/common/authentication/postgresql"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 // List of operations.
 const (
-	execOperation  bindings.OperationKind = "exec"
-	queryOperation bindings.OperationKind = "query"
-	closeOperation bindings.OperationKind = "close"
-
-	commandSQLKey  = "sql"
-	commandArgsKey = "params"
+	execOperation        bindings.OperationKind = "exec"
+	queryOperation       bindings.OperationKind = "query"
+	transactionOperation bindings.OperationKind = "transaction"
+	closeOperation       bindings.OperationKind = "close"
+
+	commandSQLKey         = "sql"
+	commandArgsKey        = "params"
+	commandColumnTypesKey = "columnTypes"
 )
 
+// querier is implemented by the connection pool and by transactions.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// queryResult is the result of a query: when the column types are requested, the query operation returns it as a
+// JSON object rather than only the array of rows.
+type queryResult struct {
+	Columns []queryColumn `json:"columns,omitempty"`
+	Rows    []any         `json:"rows"`
+}
+
+type queryColumn struct {
+	Name string `json:"name"`
+	// Name of the PostgreSQL data type, such as "int8" or "varchar", or its OID if the type is unknown.
+	Type string `json:"type"`
+}
+
+// transactionStatement is a statement of the transaction operation, whose data is a JSON array of statements.
+type transactionStatement struct {
+	SQL    string `json:"sql"`
+	Params []any  `json:"params"`
+	// "exec" (default) or "query"
+	Operation bindings.OperationKind `json:"operation"`
+}
+
+// transactionResult is the result of a statement of the transaction operation.
+type transactionResult struct {
+	RowsAffected *int64 `json:"rowsAffected,omitempty"`
+	*queryResult
+}
+
 // Postgres represents PostgreSQL output binding.
 type Postgres struct {
 	logger logger.Logger
@@ -120,6 +158,7 @@ func (p *Postgres) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{
 		execOperation,
 		queryOperation,
+		transactionOperation,
 		closeOperation,
 	}
 }
@@ -140,6 +179,10 @@ func (p *Postgres) Invoke(ctx context.Context, req *bindings.InvokeRequest) (res
 		return nil, errors.New("component is closed")
 	}
 
+	if req.Operation == transactionOperation {
+		return p.transaction(ctx, req)
+	}
+
 	if req.Metadata == nil {
 		return nil, errors.New("metadata required")
 	}
@@ -171,23 +214,32 @@ func (p *Postgres) Invoke(ctx context.Context, req *bindings.InvokeRequest) (res
 
 	switch req.Operation { //nolint:exhaustive
 	case execOperation:
-		r, err := p.exec(ctx, sql, args...)
+		r, err := exec(ctx, p.db, sql, args...)
 		if err != nil {
 			return nil, err
 		}
 		resp.Metadata["rows-affected"] = strconv.FormatInt(r, 10) // 0 if error
 
 	case queryOperation:
-		d, err := p.query(ctx, sql, args...)
+		// Metadata property "columnTypes" returns the columns and their types alongside the rows
+		columnTypes := kitstrings.IsTruthy(req.Metadata[commandColumnTypesKey])
+		r, err := query(ctx, p.db, columnTypes, sql, args...)
 		if err != nil {
 			return nil, err
 		}
-		resp.Data = d
+		if columnTypes {
+			resp.Data, err = json.Marshal(r)
+		} else {
+			resp.Data, err = json.Marshal(r.Rows)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error serializing results: %w", err)
+		}
 
 	default:
 		return nil, fmt.Errorf(
-			"invalid operation type: %s. Expected %s, %s, or %s",
-			req.Operation, execOperation, queryOperation, closeOperation,
+			"invalid operation type: %s. Expected %s, %s, %s, or %s",
+			req.Operation, execOperation, queryOperation, transactionOperation, closeOperation,
 		)
 	}
 
@@ -218,31 +270,108 @@ func (p *Postgres) Close() error {
 	return errors.Join(errs...)
 }
 
-func (p *Postgres) query(ctx context.Context, sql string, args ...any) (result []byte, err error) {
-	rows, err := p.db.Query(ctx, sql, args...)
+// transaction executes the statements in the data of the request in a single transaction, which is rolled back if
+// any of them fails. The response data is the JSON array of the results of the statements.
+func (p *Postgres) transaction(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var statements []transactionStatement
+	err := json.Unmarshal(req.Data, &statements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: failed to unserialize into an array of statements: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("invalid data: no statements")
+	}
+	for i, s := range statements {
+		if s.SQL == "" {
+			return nil, fmt.Errorf("invalid statement %d: %s is required", i, commandSQLKey)
+		}
+		switch s.Operation {
+		case "":
+			statements[i].Operation = execOperation
+		case execOperation, queryOperation:
+		default:
+			return nil, fmt.Errorf("invalid statement %d: invalid operation type: %s. Expected %s or %s", i, s.Operation, execOperation, queryOperation)
+		}
+	}
+	columnTypes := kitstrings.IsTruthy(req.Metadata[commandColumnTypesKey])
+
+	startTime := time.Now().UTC()
+	results := make([]transactionResult, len(statements))
+	err = pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		for i, s := range statements {
+			if s.Operation == queryOperation {
+				results[i].queryResult, err = query(ctx, tx, columnTypes, s.SQL, s.Params...)
+			} else {
+				var r int64
+				r, err = exec(ctx, tx, s.SQL, s.Params...)
+				results[i].RowsAffected = &r
+			}
+			if err != nil {
+				return fmt.Errorf("statement %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transaction rolled back: %w", err)
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing results: %w", err)
+	}
+	endTime := time.Now().UTC()
+	return &bindings.InvokeResponse{
+		Data: data,
+		Metadata: map[string]string{
+			"operation":  string(req.Operation),
+			"statements": strconv.Itoa(len(statements)),
+			"start-time": startTime.Format(time.RFC3339Nano),
+			"end-time":   endTime.Format(time.RFC3339Nano),
+			"duration":   endTime.Sub(startTime).String(),
+		},
+	}, nil
+}
+
+func query(ctx context.Context, db querier, columnTypes bool, sql string, args ...any) (*queryResult, error) {
+	rows, err := db.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
+	defer rows.Close()
+
+	result := &queryResult{
+		Rows: make([]any, 0),
+	}
+	if columnTypes {
+		typeMap := rows.Conn().TypeMap()
+		for _, fd := range rows.FieldDescriptions() {
+			column := queryColumn{Name: fd.Name}
+			if t, ok := typeMap.TypeForOID(fd.DataTypeOID); ok {
+				column.Type = t.Name
+			} else {
+				column.Type = strconv.FormatUint(uint64(fd.DataTypeOID), 10)
+			}
+			result.Columns = append(result.Columns, column)
+		}
+	}
 
-	rs := make([]any, 0)
 	for rows.Next() {
 		val, rowErr := rows.Values()
 		if rowErr != nil {
 			return nil, fmt.Errorf("error reading result '%v': %w", rows.Err(), rowErr)
 		}
-		rs = append(rs, val) //nolint:asasalint
+		result.Rows = append(result.Rows, val) //nolint:asasalint
 	}
-
-	result, err = json.Marshal(rs)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing results: %w", err)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading results: %w", err)
 	}
 
 	return result, nil
 }
 
-func (p *Postgres) exec(ctx context.Context, sql string, args ...any) (result int64, err error) {
-	res, err := p.db.Exec(ctx, sql, args...)
+func exec(ctx context.Context, db querier, sql string, args ...any) (result int64, err error) {
+	res, err := db.Exec(ctx, sql, args...)
 	if err != nil {
 		return 0, fmt.Errorf("error executing query: %w", err)
 	}
@@ -45,10 +45,22 @@ func TestOperations(t *testing.T) {
 		b := NewPostgres(nil)
 		assert.NotNil(t, b)
 		l := b.Operations()
-		assert.Len(t, l, 3)
+		assert.Len(t, l, 4)
 	})
 }
 
+func TestInvalidTransaction(t *testing.T) {
+	t.Parallel()
+	b := NewPostgres(logger.NewLogger("test"))
+	for _, data := range []string{``, `{}`, `[]`, `[{"params": [1]}]`, `[{"sql": "SELECT 1", "operation": "close"}]`} {
+		_, err := b.Invoke(t.Context(), &bindings.InvokeRequest{
+			Operation: transactionOperation,
+			Data:      []byte(data),
+		})
+		require.Error(t, err, data)
+	}
+}
+
 // SETUP TESTS
 // 1. `createdb daprtest`
 // 2. `createuser daprtest`
@@ -113,6 +125,46 @@ func TestPostgresIntegration(t *testing.T) {
 		assertResponse(t, res, err)
 	})
 
+	t.Run("Invoke select with params and column types", func(t *testing.T) {
+		req.Operation = queryOperation
+		req.Metadata[commandSQLKey] = "SELECT id, v1 FROM foo WHERE id = $1"
+		req.Metadata[commandArgsKey] = "[1]"
+		req.Metadata[commandColumnTypesKey] = "true"
+		res, err := b.Invoke(ctx, req)
+		assertResponse(t, res, err)
+		assert.JSONEq(t, `{"columns": [{"name": "id", "type": "int8"}, {"name": "v1", "type": "varchar"}], "rows": [[1, "test-1"]]}`, string(res.Data))
+		delete(req.Metadata, commandArgsKey)
+		delete(req.Metadata, commandColumnTypesKey)
+	})
+
+	t.Run("Invoke transaction", func(t *testing.T) {
+		res, err := b.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: transactionOperation,
+			Data: []byte(`[
+				{"sql": "UPDATE foo SET v1 = $1 WHERE id = $2", "params": ["updated", 1]},
+				{"sql": "SELECT v1 FROM foo WHERE id = $1", "params": [1], "operation": "query"}
+			]`),
+		})
+		assertResponse(t, res, err)
+		assert.JSONEq(t, `[{"rowsAffected": 1}, {"rows": [["updated"]]}]`, string(res.Data))
+
+		// The failing statement rolls back the update
+		_, err = b.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: transactionOperation,
+			Data: []byte(`[
+				{"sql": "UPDATE foo SET v1 = $1 WHERE id = $2", "params": ["rolled back", 1]},
+				{"sql": "SELECT * FROM missing_table", "operation": "query"}
+			]`),
+		})
+		require.Error(t, err)
+		res, err = b.Invoke(ctx, &bindings.InvokeRequest{
+			Operation: queryOperation,
+			Metadata:  map[string]string{commandSQLKey: "SELECT v1 FROM foo WHERE id = 1"},
+		})
+		assertResponse(t, res, err)
+		assert.JSONEq(t, `[["updated"]]`, string(res.Data))
+	})
+
 	t.Run("Invoke delete", func(t *testing.T) {
 		req.Operation = execOperation
 		req.Metadata[commandSQLKey] = testDelete
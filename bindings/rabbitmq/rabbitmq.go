@@ -24,10 +24,12 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -35,7 +37,7 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
-	"github.com/dapr/kit/strings"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 const (
@@ -49,6 +51,9 @@ const (
 	reconnectWaitSecondsKey    = "reconnectWaitInSeconds"
 	rabbitMQQueueMessageTTLKey = "x-message-ttl"
 	rabbitMQMaxPriorityKey     = "x-max-priority"
+	rabbitMQDeadLetterKey      = "x-dead-letter-exchange"
+	rabbitMQQueueModeKey       = "x-queue-mode"
+	rabbitMQQueueModeLazy      = "lazy"
 	caCert                     = "caCert"
 	clientCert                 = "clientCert"
 	clientKey                  = "clientKey"
@@ -56,8 +61,17 @@ const (
 	defaultBase                = 10
 	defaultBitSize             = 0
 
-	errorChannelConnection = "channel/connection is not open"
-	defaultReconnectWait   = 5 * time.Second
+	errorChannelConnection  = "channel/connection is not open"
+	defaultReconnectWait    = 5 * time.Second
+	defaultMaxReconnectWait = time.Minute
+
+	// Same naming as the dead letter exchanges and queues of the pubsub component
+	deadLetterExchangeFormat = "dlx-%s"
+	deadLetterQueueFormat    = "dlq-%s"
+
+	// Request metadata of the output binding to publish to an exchange
+	reqMetadataExchange   = "exchange"
+	reqMetadataRoutingKey = "routingKey"
 )
 
 var errClosed = errors.New("component is stopped")
@@ -88,11 +102,18 @@ type rabbitMQMetadata struct {
 	PrefetchCount    int            `mapstructure:"prefetchCount"`
 	MaxPriority      *uint8         `mapstructure:"maxPriority"` // Priority Queue deactivated if nil
 	ReconnectWait    time.Duration  `mapstructure:"reconnectWaitInSeconds"`
+	MaxReconnectWait time.Duration  `mapstructure:"maxReconnectWaitInSeconds"`
+	QueueType        string         `mapstructure:"queueType"` // Classic if empty
+	EnableDeadLetter bool           `mapstructure:"enableDeadLetter"`
+	PublisherConfirm bool           `mapstructure:"publisherConfirm"`
 	DefaultQueueTTL  *time.Duration `mapstructure:"ttl" mapstructurealiases:"ttlInSeconds"`
 	CaCert           string         `mapstructure:"caCert"`
 	ClientCert       string         `mapstructure:"clientCert"`
 	ClientKey        string         `mapstructure:"clientKey"`
 	ExternalSasl     bool           `mapstructure:"externalSasl"`
+	ExchangeName     string         `mapstructure:"exchangeName"`
+	ExchangeKind     string         `mapstructure:"exchangeKind"`
+	RoutingKey       string         `mapstructure:"routingKey"`
 }
 
 // NewRabbitMQ returns a new rabbitmq instance.
@@ -142,16 +163,21 @@ func (r *RabbitMQ) reconnectWhenNecessary() {
 			if r.connection != nil && !r.connection.IsClosed() {
 				ch, err := r.connection.Channel()
 				if err == nil {
-					r.notifyRabbitChannelClose = make(chan *amqp.Error, 1)
-					ch.NotifyClose(r.notifyRabbitChannelClose)
-					r.channel = ch
-					r.channelMutex.Unlock()
-					continue
+					err = r.setupChannel(ch)
+					if err == nil {
+						r.notifyRabbitChannelClose = make(chan *amqp.Error, 1)
+						ch.NotifyClose(r.notifyRabbitChannelClose)
+						r.channel = ch
+						r.channelMutex.Unlock()
+						continue
+					}
+					_ = ch.Close()
 				}
 				// if encounter err fallback to reconnect connection
 			}
 			r.channelMutex.Unlock()
-			// keep trying to reconnect
+			// keep trying to reconnect, waiting longer after each failure
+			bo := r.newReconnectBackOff()
 			for {
 				err := r.connect()
 				if err == nil {
@@ -160,10 +186,11 @@ func (r *RabbitMQ) reconnectWhenNecessary() {
 				if err == errClosed {
 					return
 				}
-				r.logger.Warnf("Reconnect failed: %v", err)
+				wait := bo.NextBackOff()
+				r.logger.Warnf("Reconnect failed, retrying in %v: %v", wait, err)
 
 				select {
-				case <-time.After(r.metadata.ReconnectWait):
+				case <-time.After(wait):
 				case <-r.closeCh:
 					return
 				}
@@ -172,6 +199,17 @@ func (r *RabbitMQ) reconnectWhenNecessary() {
 	}
 }
 
+// newReconnectBackOff returns the exponential backoff between reconnection attempts,
+// starting at reconnectWaitInSeconds and capped at maxReconnectWaitInSeconds, with jitter.
+func (r *RabbitMQ) newReconnectBackOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = r.metadata.ReconnectWait
+	bo.MaxInterval = max(r.metadata.MaxReconnectWait, r.metadata.ReconnectWait)
+	bo.MaxElapsedTime = 0
+	bo.Reset()
+	return bo
+}
+
 func dial(uri string) (conn *amqp.Connection, ch *amqp.Channel, err error) {
 	conn, err = amqp.Dial(uri)
 	if err != nil {
@@ -250,17 +288,40 @@ func (r *RabbitMQ) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bi
 
 	common.ApplyMetadataToPublishing(req.Metadata, &pub)
 
-	err = ch.PublishWithContext(ctx, "", r.metadata.QueueName, false, false, pub)
+	// Messages are sent to the queue through the default exchange, unless an exchange is set in the request
+	exchange, routingKey := "", r.metadata.QueueName
+	if val, ok := common.TryGetProperty(req.Metadata, reqMetadataExchange); ok {
+		exchange, routingKey = val, ""
+	}
+	if val, ok := common.TryGetProperty(req.Metadata, reqMetadataRoutingKey); ok {
+		routingKey = val
+	}
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, pub)
 	if err != nil {
 		return nil, fmt.Errorf("failed to publish message: %w", err)
 	}
 
+	// confirm is nil if publisher confirms are not enabled
+	if confirm != nil {
+		// Blocks until the server confirms
+		ok, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for publish confirmation: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("message was not acknowledged by the server")
+		}
+	}
+
 	return nil, nil
 }
 
 func (r *RabbitMQ) parseMetadata(meta bindings.Metadata) error {
 	m := rabbitMQMetadata{
-		ReconnectWait: defaultReconnectWait,
+		ReconnectWait:    defaultReconnectWait,
+		MaxReconnectWait: defaultMaxReconnectWait,
+		ExchangeKind:     amqp.ExchangeDirect,
 	}
 
 	decodeErr := kitmd.DecodeMetadata(meta.Properties, &m)
@@ -311,30 +372,34 @@ func (r *RabbitMQ) parseMetadata(meta bindings.Metadata) error {
 	}
 
 	if val, ok := meta.Properties[externalSasl]; ok && val != "" {
-		m.ExternalSasl = strings.IsTruthy(val)
+		m.ExternalSasl = kitstrings.IsTruthy(val)
 	}
 
-	if val, ok := meta.Properties[caCert]; ok && val != "" {
-		if !isValidPEM(val) {
-			return errors.New("invalid ca certificate")
-		}
-		m.CaCert = val
+	if (m.ClientCert == "") != (m.ClientKey == "") {
+		return errors.New("clientCert and clientKey must be set together")
 	}
-	if val, ok := meta.Properties[clientCert]; ok && val != "" {
-		if !isValidPEM(val) {
-			return errors.New("invalid client certificate")
-		}
-		m.ClientCert = val
+	if m.ExternalSasl && (m.CaCert == "" || m.ClientCert == "" || m.ClientKey == "") {
+		return errors.New("externalSasl can only be set to true when caCert, clientCert and clientKey are set")
 	}
-	if val, ok := meta.Properties[clientKey]; ok && val != "" {
-		if !isValidPEM(val) {
-			return errors.New("invalid client certificate key")
+
+	switch m.QueueType {
+	case "", amqp.QueueTypeClassic:
+	case amqp.QueueTypeQuorum:
+		// Quorum queues are always replicated and persisted
+		if !m.Durable || m.Exclusive || m.DeleteWhenUnused {
+			return errors.New("quorum queues must be durable, and can't be exclusive or deleted when unused")
 		}
-		m.ClientKey = val
+	default:
+		return fmt.Errorf("invalid queue type %s. Valid types are %s and %s", m.QueueType, amqp.QueueTypeClassic, amqp.QueueTypeQuorum)
 	}
 
-	if val, ok := meta.Properties[externalSasl]; ok && val != "" {
-		m.ExternalSasl = strings.IsTruthy(val)
+	switch m.ExchangeKind {
+	case amqp.ExchangeDirect, amqp.ExchangeFanout, amqp.ExchangeTopic, amqp.ExchangeHeaders:
+	default:
+		return fmt.Errorf("invalid exchange kind %s", m.ExchangeKind)
+	}
+	if m.ExchangeName == "" && m.RoutingKey != "" {
+		return errors.New("routingKey can only be set when exchangeName is set")
 	}
 
 	ttl, ok, err := metadata.TryGetTTL(meta.Properties)
@@ -350,6 +415,68 @@ func (r *RabbitMQ) parseMetadata(meta bindings.Metadata) error {
 }
 
 func (r *RabbitMQ) declareQueue(channel *amqp.Channel) (amqp.Queue, error) {
+	if r.metadata.EnableDeadLetter {
+		// Messages rejected by the input binding are routed to the dead letter queue through the dead letter exchange
+		dlxName := fmt.Sprintf(deadLetterExchangeFormat, r.metadata.QueueName)
+		dlqName := fmt.Sprintf(deadLetterQueueFormat, r.metadata.QueueName)
+		err := channel.ExchangeDeclare(dlxName, amqp.ExchangeFanout, true, false, false, false, nil)
+		if err != nil {
+			return amqp.Queue{}, fmt.Errorf("failed to declare dead letter exchange %s: %w", dlxName, err)
+		}
+		_, err = channel.QueueDeclare(dlqName, true, false, false, false, r.deadLetterQueueArgs())
+		if err != nil {
+			return amqp.Queue{}, fmt.Errorf("failed to declare dead letter queue %s: %w", dlqName, err)
+		}
+		err = channel.QueueBind(dlqName, "", dlxName, false, nil)
+		if err != nil {
+			return amqp.Queue{}, fmt.Errorf("failed to bind dead letter queue %s: %w", dlqName, err)
+		}
+	}
+
+	q, err := channel.QueueDeclare(r.metadata.QueueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, r.metadata.Exclusive, false, r.queueArgs())
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+
+	if r.metadata.ExchangeName != "" {
+		err = r.bindQueue(channel, q.Name)
+		if err != nil {
+			return amqp.Queue{}, err
+		}
+	}
+
+	return q, nil
+}
+
+// bindQueue declares the exchange and binds the queue to it with each routing key, so the input binding receives the
+// messages published to the exchange.
+func (r *RabbitMQ) bindQueue(channel *amqp.Channel, queueName string) error {
+	err := channel.ExchangeDeclare(r.metadata.ExchangeName, r.metadata.ExchangeKind, r.metadata.Durable, r.metadata.DeleteWhenUnused, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", r.metadata.ExchangeName, err)
+	}
+
+	for _, routingKey := range r.routingKeys() {
+		err = channel.QueueBind(queueName, routingKey, r.metadata.ExchangeName, false, nil)
+		if err != nil {
+			return fmt.Errorf("failed to bind queue %s to exchange %s with routing key '%s': %w", queueName, r.metadata.ExchangeName, routingKey, err)
+		}
+	}
+
+	return nil
+}
+
+// routingKeys returns the routing keys the queue is bound with. Without routing keys, the queue is bound with an empty one.
+func (r *RabbitMQ) routingKeys() []string {
+	keys := strings.Split(r.metadata.RoutingKey, ",")
+	for i := range keys {
+		keys[i] = strings.TrimSpace(keys[i])
+	}
+	return keys
+}
+
+// queueArgs returns the arguments used to declare the queue.
+func (r *RabbitMQ) queueArgs() amqp.Table {
 	args := amqp.Table{}
 	if r.metadata.DefaultQueueTTL != nil {
 		// Value in ms
@@ -361,7 +488,25 @@ func (r *RabbitMQ) declareQueue(channel *amqp.Channel) (amqp.Queue, error) {
 		args[rabbitMQMaxPriorityKey] = *r.metadata.MaxPriority
 	}
 
-	return channel.QueueDeclare(r.metadata.QueueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, r.metadata.Exclusive, false, args)
+	if r.metadata.EnableDeadLetter {
+		args[rabbitMQDeadLetterKey] = fmt.Sprintf(deadLetterExchangeFormat, r.metadata.QueueName)
+	}
+
+	// The argument is omitted for classic queues so existing queues can still be declared
+	if r.metadata.QueueType != "" {
+		args[amqp.QueueTypeArg] = r.metadata.QueueType
+	}
+
+	return args
+}
+
+// deadLetterQueueArgs returns the arguments used to declare the dead letter queue.
+func (r *RabbitMQ) deadLetterQueueArgs() amqp.Table {
+	if r.metadata.QueueType == amqp.QueueTypeQuorum {
+		return amqp.Table{amqp.QueueTypeArg: amqp.QueueTypeQuorum}
+	}
+	// Classic dead letter queues use lazy mode, keeping as many messages as possible on disk to reduce RAM usage
+	return amqp.Table{rabbitMQQueueModeKey: rabbitMQQueueModeLazy}
 }
 
 func (r *RabbitMQ) Read(ctx context.Context, handler bindings.Handler) error {
@@ -427,14 +572,15 @@ func (r *RabbitMQ) Read(ctx context.Context, handler bindings.Handler) error {
 	return nil
 }
 
-func (r *RabbitMQ) newTLSConfig() *tls.Config {
-	tlsConfig := new(tls.Config)
+func (r *RabbitMQ) newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
 
 	if r.metadata.ClientCert != "" && r.metadata.ClientKey != "" {
 		cert, err := tls.X509KeyPair([]byte(r.metadata.ClientCert), []byte(r.metadata.ClientKey))
 		if err != nil {
-			r.logger.Warnf("Unable to load client certificate and key pair. Err: %v", err)
-			return tlsConfig
+			return nil, fmt.Errorf("unable to load client certificate and key pair: %w", err)
 		}
 		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
@@ -442,10 +588,19 @@ func (r *RabbitMQ) newTLSConfig() *tls.Config {
 	if r.metadata.CaCert != "" {
 		tlsConfig.RootCAs = x509.NewCertPool()
 		if ok := tlsConfig.RootCAs.AppendCertsFromPEM([]byte(r.metadata.CaCert)); !ok {
-			r.logger.Warnf("Unable to load CA certificate.")
+			return nil, errors.New("unable to load CA certificate")
 		}
 	}
-	return tlsConfig
+	return tlsConfig, nil
+}
+
+// useTLS returns true if the connection uses TLS, either because certificates are set or because of the amqps scheme.
+func (r *RabbitMQ) useTLS() bool {
+	if r.metadata.CaCert != "" || r.metadata.ClientCert != "" {
+		return true
+	}
+	u, err := url.Parse(r.metadata.Host)
+	return err == nil && u.Scheme == "amqps"
 }
 
 // isValidPEM validates the provided input has PEM formatted block.
@@ -482,7 +637,8 @@ func (r *RabbitMQ) handleMessage(ctx context.Context, handler bindings.Handler,
 				Metadata: metadata,
 			})
 			if err != nil {
-				ch.Nack(d.DeliveryTag, false, true)
+				// With dead lettering, failed messages are moved to the dead letter queue instead of being requeued
+				ch.Nack(d.DeliveryTag, false, !r.metadata.EnableDeadLetter)
 			} else {
 				ch.Ack(d.DeliveryTag, false)
 			}
@@ -508,8 +664,11 @@ func (r *RabbitMQ) connect() error {
 	var conn *amqp.Connection
 	var ch *amqp.Channel
 	var err error
-	if r.metadata.ClientCert != "" && r.metadata.ClientKey != "" && r.metadata.CaCert != "" {
-		tlsConfig := r.newTLSConfig()
+	if r.useTLS() {
+		tlsConfig, tlsErr := r.newTLSConfig()
+		if tlsErr != nil {
+			return tlsErr
+		}
 		conn, ch, err = dialTLS(r.metadata.Host, tlsConfig, r.metadata.ExternalSasl)
 	} else {
 		conn, ch, err = dial(r.metadata.Host)
@@ -519,9 +678,14 @@ func (r *RabbitMQ) connect() error {
 		return err
 	}
 
-	ch.Qos(r.metadata.PrefetchCount, 0, true)
+	err = r.setupChannel(ch)
+	if err != nil {
+		conn.Close()
+		return err
+	}
 	q, err := r.declareQueue(ch)
 	if err != nil {
+		conn.Close()
 		return err
 	}
 
@@ -539,6 +703,17 @@ func (r *RabbitMQ) connect() error {
 	return nil
 }
 
+// setupChannel applies the prefetch count and enables publisher confirms on a new channel.
+func (r *RabbitMQ) setupChannel(ch *amqp.Channel) error {
+	ch.Qos(r.metadata.PrefetchCount, 0, true)
+	if r.metadata.PublisherConfirm {
+		if err := ch.Confirm(false); err != nil {
+			return fmt.Errorf("failed to enable publisher confirms: %w", err)
+		}
+	}
+	return nil
+}
+
 // reset the channel and the connection when encountered a connection error.
 // this function call should be wrapped by channelMutex.
 func (r *RabbitMQ) reset() (err error) {
@@ -233,3 +233,103 @@ func TestParseMetadataWithInvalidMaxPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMetadataQueueOptions(t *testing.T) {
+	const queueName = "test-queue"
+	const host = "test-host"
+
+	t.Run("quorum queue with dead letter", func(t *testing.T) {
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{"queueName": queueName, "host": host, "durable": "true", "queueType": "quorum", "enableDeadLetter": "true", "publisherConfirm": "true"}
+		r := RabbitMQ{logger: logger.NewLogger("test")}
+		err := r.parseMetadata(m)
+		require.NoError(t, err)
+		assert.True(t, r.metadata.PublisherConfirm)
+
+		args := r.queueArgs()
+		assert.Equal(t, "quorum", args["x-queue-type"])
+		assert.Equal(t, "dlx-"+queueName, args["x-dead-letter-exchange"])
+		assert.Equal(t, "quorum", r.deadLetterQueueArgs()["x-queue-type"])
+	})
+
+	t.Run("classic queue omits the queue type", func(t *testing.T) {
+		m := bindings.Metadata{}
+		m.Properties = map[string]string{"queueName": queueName, "host": host, "enableDeadLetter": "true"}
+		r := RabbitMQ{logger: logger.NewLogger("test")}
+		err := r.parseMetadata(m)
+		require.NoError(t, err)
+
+		args := r.queueArgs()
+		assert.NotContains(t, args, "x-queue-type")
+		assert.Equal(t, "lazy", r.deadLetterQueueArgs()["x-queue-mode"])
+	})
+
+	t.Run("invalid queue options", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{"queueType": "stream", "durable": "true"},
+			{"queueType": "quorum", "durable": "false"},
+			{"queueType": "quorum", "durable": "true", "exclusive": "true"},
+			{"clientCert": getFakeClientCert()},
+			{"externalSasl": "true", "caCert": getFakeCaCert()},
+			{"exchangeName": "myexchange", "exchangeKind": "invalid"},
+			{"routingKey": "mykey"},
+		} {
+			props["queueName"] = queueName
+			props["host"] = host
+			r := RabbitMQ{logger: logger.NewLogger("test")}
+			err := r.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: props}})
+			require.Error(t, err, props)
+		}
+	})
+}
+
+func TestParseMetadataExchange(t *testing.T) {
+	t.Run("exchange defaults", func(t *testing.T) {
+		r := RabbitMQ{logger: logger.NewLogger("test")}
+		err := r.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: map[string]string{"queueName": "myqueue", "host": "test-host", "exchangeName": "myexchange"}}})
+		require.NoError(t, err)
+		assert.Equal(t, "myexchange", r.metadata.ExchangeName)
+		assert.Equal(t, "direct", r.metadata.ExchangeKind)
+		assert.Equal(t, []string{""}, r.routingKeys())
+	})
+
+	t.Run("multiple routing keys", func(t *testing.T) {
+		r := RabbitMQ{logger: logger.NewLogger("test")}
+		err := r.parseMetadata(bindings.Metadata{Base: metadata.Base{Properties: map[string]string{"queueName": "myqueue", "host": "test-host", "exchangeName": "myexchange", "exchangeKind": "topic", "routingKey": "orders.*, payments.#"}}})
+		require.NoError(t, err)
+		assert.Equal(t, "topic", r.metadata.ExchangeKind)
+		assert.Equal(t, []string{"orders.*", "payments.#"}, r.routingKeys())
+	})
+}
+
+func TestTLS(t *testing.T) {
+	r := RabbitMQ{logger: logger.NewLogger("test")}
+
+	r.metadata = rabbitMQMetadata{Host: "amqp://localhost"}
+	assert.False(t, r.useTLS())
+	r.metadata = rabbitMQMetadata{Host: "amqps://localhost"}
+	assert.True(t, r.useTLS())
+
+	r.metadata = rabbitMQMetadata{Host: "amqp://localhost", CaCert: getFakeCaCert(), ClientCert: getFakeClientCert(), ClientKey: getFakeClientKey()}
+	assert.True(t, r.useTLS())
+	tlsConfig, err := r.newTLSConfig()
+	require.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+	assert.NotNil(t, tlsConfig.RootCAs)
+
+	r.metadata.ClientKey = getFakeCaCert()
+	_, err = r.newTLSConfig()
+	require.Error(t, err)
+}
+
+func TestReconnectBackOff(t *testing.T) {
+	r := RabbitMQ{metadata: rabbitMQMetadata{ReconnectWait: time.Second, MaxReconnectWait: 4 * time.Second}}
+	bo := r.newReconnectBackOff()
+	var last time.Duration
+	for range 10 {
+		last = bo.NextBackOff()
+		// Jitter is applied around the capped interval
+		assert.LessOrEqual(t, last, 6*time.Second)
+	}
+	assert.GreaterOrEqual(t, last, 2*time.Second)
+}
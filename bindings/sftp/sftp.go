@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	sftpClient "github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
@@ -28,6 +31,9 @@ type Sftp struct {
 	metadata   *sftpMetadata
 	logger     logger.Logger
 	sftpClient *sftpClient.Client
+	closeCh    chan struct{}
+	closed     atomic.Bool
+	wg         sync.WaitGroup
 }
 
 // sftpMetadata defines the sftp metadata.
@@ -41,6 +47,17 @@ type sftpMetadata struct {
 	HostPublicKey         []byte `json:"hostPublicKey"`
 	KnownHostsFile        string `json:"knownHostsFile"`
 	InsecureIgnoreHostKey bool   `json:"insecureIgnoreHostKey"`
+
+	// Input binding: interval between polls of the root path for new and changed files.
+	PollInterval time.Duration `json:"pollInterval"`
+	// Input binding: glob pattern of the names of the files that are delivered, such as "*.csv".
+	FilePattern string `json:"filePattern"`
+	// Input binding: action applied to the files once the app processed them: none, delete, move or rename.
+	PostProcessAction string `json:"postProcessAction"`
+	// Input binding: directory processed files are moved to with the move action.
+	ArchivePath string `json:"archivePath"`
+	// Input binding: suffix appended to the name of processed files with the rename action; files with it are skipped.
+	RenameSuffix string `json:"renameSuffix"`
 }
 
 type createResponse struct {
@@ -52,8 +69,11 @@ type listResponse struct {
 	IsDirectory bool   `json:"isDirectory"`
 }
 
-func NewSftp(logger logger.Logger) bindings.OutputBinding {
-	return &Sftp{logger: logger}
+func NewSftp(logger logger.Logger) bindings.InputOutputBinding {
+	return &Sftp{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
 }
 
 func (sftp *Sftp) Init(_ context.Context, metadata bindings.Metadata) error {
@@ -61,6 +81,10 @@ func (sftp *Sftp) Init(_ context.Context, metadata bindings.Metadata) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse metadata: %w", err)
 	}
+	err = m.validateWatchMetadata()
+	if err != nil {
+		return fmt.Errorf("sftp binding error: %w", err)
+	}
 
 	var auth []ssh.AuthMethod
 	var hostKeyCallback ssh.HostKeyCallback
@@ -289,6 +313,14 @@ func (sftp *Sftp) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bin
 }
 
 func (sftp *Sftp) Close() error {
+	if sftp.closed.CompareAndSwap(false, true) {
+		close(sftp.closeCh)
+	}
+	sftp.wg.Wait()
+
+	if sftp.sftpClient == nil {
+		return nil
+	}
 	return sftp.sftpClient.Close()
 }
 
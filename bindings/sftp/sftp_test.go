@@ -1,12 +1,19 @@
 package sftp
 
 import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	sftpClient "github.com/pkg/sftp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/logger"
 )
 
 func TestParseMeta(t *testing.T) {
@@ -100,3 +107,186 @@ func TestGetPath(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// newInMemorySftp returns a binding connected to an in-memory SFTP server.
+func newInMemorySftp(t *testing.T, meta *sftpMetadata) *Sftp {
+	t.Helper()
+	require.NoError(t, meta.validateWatchMetadata())
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	server := sftpClient.NewRequestServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverReader, serverWriter}, sftpClient.InMemHandler())
+	go func() {
+		// Closing the pipe ends the client once it's closed
+		_ = server.Serve()
+		serverWriter.Close()
+	}()
+
+	client, err := sftpClient.NewClientPipe(clientReader, clientWriter)
+	require.NoError(t, err)
+
+	sftp := NewSftp(logger.NewLogger("test")).(*Sftp)
+	sftp.metadata = meta
+	sftp.sftpClient = client
+	return sftp
+}
+
+func writeFile(t *testing.T, sftp *Sftp, path string, data string) {
+	t.Helper()
+	_, err := sftp.Invoke(t.Context(), &bindings.InvokeRequest{
+		Operation: bindings.CreateOperation,
+		Data:      []byte(data),
+		Metadata:  map[string]string{"fileName": path},
+	})
+	require.NoError(t, err)
+}
+
+func TestRead(t *testing.T) {
+	read := func(t *testing.T, sftp *Sftp, fail *atomic.Bool) chan *bindings.ReadResponse {
+		ch := make(chan *bindings.ReadResponse, 10)
+		err := sftp.Read(t.Context(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+			if fail != nil && fail.Load() {
+				return nil, errors.New("failed")
+			}
+			ch <- res
+			return nil, nil
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { sftp.Close() })
+		return ch
+	}
+	receive := func(t *testing.T, ch chan *bindings.ReadResponse) *bindings.ReadResponse {
+		t.Helper()
+		select {
+		case res := <-ch:
+			return res
+		case <-time.After(5 * time.Second):
+			t.Fatal("no file delivered")
+			return nil
+		}
+	}
+	exists := func(t *testing.T, sftp *Sftp, path string) bool {
+		_, err := sftp.sftpClient.Stat(path)
+		return err == nil
+	}
+
+	t.Run("new and changed files are delivered", func(t *testing.T) {
+		sftp := newInMemorySftp(t, &sftpMetadata{
+			RootPath:     "/inbox",
+			PollInterval: 10 * time.Millisecond,
+			FilePattern:  "*.csv",
+		})
+		writeFile(t, sftp, "skipped.txt", "skipped")
+		writeFile(t, sftp, "a.csv", "a")
+		ch := read(t, sftp, nil)
+
+		res := receive(t, ch)
+		assert.Equal(t, "a", string(res.Data))
+		assert.Equal(t, "a.csv", res.Metadata["fileName"])
+		assert.Equal(t, "/inbox/a.csv", res.Metadata["filePath"])
+		assert.Equal(t, "1", res.Metadata["fileSize"])
+
+		writeFile(t, sftp, "a.csv", "changed")
+		res = receive(t, ch)
+		assert.Equal(t, "changed", string(res.Data))
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Empty(t, ch)
+	})
+
+	t.Run("files are delivered once stable", func(t *testing.T) {
+		sftp := newInMemorySftp(t, &sftpMetadata{
+			RootPath: "/inbox",
+		})
+		var received []string
+		handler := func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+			received = append(received, string(res.Data))
+			return nil, nil
+		}
+		state := newWatchState()
+
+		// The file is still being uploaded at the first two polls
+		writeFile(t, sftp, "a.csv", "a")
+		require.NoError(t, sftp.poll(t.Context(), handler, state))
+		writeFile(t, sftp, "a.csv", "ab")
+		require.NoError(t, sftp.poll(t.Context(), handler, state))
+		assert.Empty(t, received)
+
+		require.NoError(t, sftp.poll(t.Context(), handler, state))
+		require.NoError(t, sftp.poll(t.Context(), handler, state))
+		assert.Equal(t, []string{"ab"}, received)
+	})
+
+	t.Run("processed files are deleted", func(t *testing.T) {
+		sftp := newInMemorySftp(t, &sftpMetadata{
+			RootPath:          "/inbox",
+			PollInterval:      10 * time.Millisecond,
+			PostProcessAction: "delete",
+		})
+		var fail atomic.Bool
+		fail.Store(true)
+		writeFile(t, sftp, "a.csv", "a")
+		ch := read(t, sftp, &fail)
+
+		// Files the app fails to process are kept and delivered again
+		time.Sleep(50 * time.Millisecond)
+		assert.True(t, exists(t, sftp, "/inbox/a.csv"))
+		fail.Store(false)
+
+		assert.Equal(t, "a", string(receive(t, ch).Data))
+		assert.Eventually(t, func() bool { return !exists(t, sftp, "/inbox/a.csv") }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("processed files are moved", func(t *testing.T) {
+		sftp := newInMemorySftp(t, &sftpMetadata{
+			RootPath:          "/inbox",
+			PollInterval:      10 * time.Millisecond,
+			PostProcessAction: "move",
+			ArchivePath:       "/archive",
+		})
+		writeFile(t, sftp, "a.csv", "a")
+		ch := read(t, sftp, nil)
+
+		assert.Equal(t, "a", string(receive(t, ch).Data))
+		assert.Eventually(t, func() bool { return exists(t, sftp, "/archive/a.csv") }, 5*time.Second, 10*time.Millisecond)
+		assert.False(t, exists(t, sftp, "/inbox/a.csv"))
+	})
+
+	t.Run("processed files are renamed", func(t *testing.T) {
+		sftp := newInMemorySftp(t, &sftpMetadata{
+			RootPath:          "/inbox",
+			PollInterval:      10 * time.Millisecond,
+			PostProcessAction: "rename",
+		})
+		writeFile(t, sftp, "a.csv", "a")
+		ch := read(t, sftp, nil)
+
+		assert.Equal(t, "a", string(receive(t, ch).Data))
+		assert.Eventually(t, func() bool { return exists(t, sftp, "/inbox/a.csv.processed") }, 5*time.Second, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		assert.Empty(t, ch)
+	})
+}
+
+func TestValidateWatchMetadata(t *testing.T) {
+	m := &sftpMetadata{}
+	require.NoError(t, m.validateWatchMetadata())
+	assert.Equal(t, defaultPollInterval, m.PollInterval)
+	assert.Equal(t, "none", m.PostProcessAction)
+
+	m = &sftpMetadata{PostProcessAction: "rename"}
+	require.NoError(t, m.validateWatchMetadata())
+	assert.Equal(t, ".processed", m.RenameSuffix)
+
+	for _, m := range []*sftpMetadata{
+		{PostProcessAction: "move"},
+		{PostProcessAction: "copy"},
+		{FilePattern: "[a-"},
+		{PollInterval: -time.Second},
+	} {
+		require.Error(t, m.validateWatchMetadata())
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	sftpClient "github.com/pkg/sftp"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	// Post-processing actions of the files delivered by the input binding.
+	postProcessNone   = "none"
+	postProcessDelete = "delete"
+	postProcessMove   = "move"
+	postProcessRename = "rename"
+
+	defaultPollInterval = 30 * time.Second
+	defaultRenameSuffix = ".processed"
+
+	// Defines the metadata keys of the files delivered by the input binding.
+	metadataFilePath    = "filePath"
+	metadataFileSize    = "fileSize"
+	metadataFileModTime = "fileModTime"
+)
+
+// fileVersion identifies the content of a file, to detect new and changed files.
+type fileVersion struct {
+	size    int64
+	modTime time.Time
+}
+
+// watchState is the state of the input binding between polls.
+type watchState struct {
+	// Versions of the files at the previous poll: files are delivered once their version is the same at two polls in
+	// a row, so files still being uploaded aren't delivered.
+	seen map[string]fileVersion
+	// Versions of the files delivered, which aren't delivered again until they change.
+	delivered map[string]fileVersion
+}
+
+func newWatchState() *watchState {
+	return &watchState{
+		seen:      make(map[string]fileVersion),
+		delivered: make(map[string]fileVersion),
+	}
+}
+
+// validateWatchMetadata validates the metadata of the input binding and sets its defaults.
+func (metadata *sftpMetadata) validateWatchMetadata() error {
+	if metadata.PollInterval == 0 {
+		metadata.PollInterval = defaultPollInterval
+	} else if metadata.PollInterval < 0 {
+		return errors.New("pollInterval must be greater than zero")
+	}
+	if metadata.FilePattern != "" {
+		if _, err := path.Match(metadata.FilePattern, ""); err != nil {
+			return fmt.Errorf("invalid filePattern: %w", err)
+		}
+	}
+
+	switch metadata.PostProcessAction {
+	case "":
+		metadata.PostProcessAction = postProcessNone
+	case postProcessNone, postProcessDelete:
+	case postProcessMove:
+		if metadata.ArchivePath == "" {
+			return errors.New("archivePath is required when postProcessAction is move")
+		}
+	case postProcessRename:
+		if metadata.RenameSuffix == "" {
+			metadata.RenameSuffix = defaultRenameSuffix
+		}
+	default:
+		return fmt.Errorf("invalid postProcessAction: %s", metadata.PostProcessAction)
+	}
+	return nil
+}
+
+// Read polls the root path for new and changed files, and delivers their content to the handler.
+// Files are delivered once their size and modification time didn't change between two polls, so files that are
+// still being uploaded aren't delivered.
+// Files the handler fails to process are delivered again at the next poll; processed files are post-processed
+// according to postProcessAction.
+// Without a post-processing action, the files already delivered are tracked in memory only: existing files are
+// delivered again when the component starts.
+func (sftp *Sftp) Read(ctx context.Context, handler bindings.Handler) error {
+	if sftp.metadata.RootPath == "" {
+		return errors.New("sftp binding error: rootPath is required to use the binding as input")
+	}
+	if sftp.closed.Load() {
+		return errors.New("sftp binding error: input binding is closed")
+	}
+
+	// Close read context when binding is closed.
+	readCtx, cancel := context.WithCancel(ctx)
+	sftp.wg.Add(2)
+	go func() {
+		defer sftp.wg.Done()
+		defer cancel()
+
+		select {
+		case <-sftp.closeCh:
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer sftp.wg.Done()
+		state := newWatchState()
+		for readCtx.Err() == nil {
+			err := sftp.poll(readCtx, handler, state)
+			if err != nil {
+				sftp.logger.Errorf("sftp binding error: error polling %s: %v", sftp.metadata.RootPath, err)
+			}
+			select {
+			case <-time.After(sftp.metadata.PollInterval):
+			case <-readCtx.Done():
+			}
+		}
+	}()
+
+	return nil
+}
+
+// poll delivers the new and changed files of the root path that are stable, and forgets the deleted ones.
+func (sftp *Sftp) poll(ctx context.Context, handler bindings.Handler, state *watchState) error {
+	files, err := sftp.sftpClient.ReadDir(sftp.metadata.RootPath)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !sftp.metadata.watches(file) {
+			continue
+		}
+
+		name := file.Name()
+		found[name] = struct{}{}
+		version := fileVersion{
+			size:    file.Size(),
+			modTime: file.ModTime(),
+		}
+		if v, ok := state.seen[name]; !ok || v != version {
+			// New or changed since the previous poll: wait for the next poll to check the file is complete
+			state.seen[name] = version
+			continue
+		}
+		if v, ok := state.delivered[name]; ok && v == version {
+			continue
+		}
+
+		err = sftp.deliverFile(ctx, handler, file)
+		if err != nil {
+			sftp.logger.Errorf("sftp binding error: error delivering file %s: %v", name, err)
+			continue
+		}
+		if sftp.metadata.PostProcessAction == postProcessNone {
+			state.delivered[name] = version
+		}
+	}
+
+	for name := range state.seen {
+		if _, ok := found[name]; !ok {
+			delete(state.seen, name)
+			delete(state.delivered, name)
+		}
+	}
+	return nil
+}
+
+// watches returns true if the file is delivered by the input binding.
+func (metadata *sftpMetadata) watches(file os.FileInfo) bool {
+	if !file.Mode().IsRegular() {
+		return false
+	}
+	if metadata.PostProcessAction == postProcessRename && strings.HasSuffix(file.Name(), metadata.RenameSuffix) {
+		return false
+	}
+	if metadata.FilePattern != "" {
+		// The pattern was validated in Init
+		ok, _ := path.Match(metadata.FilePattern, file.Name())
+		return ok
+	}
+	return true
+}
+
+// deliverFile reads a file, delivers it to the handler and post-processes it.
+func (sftp *Sftp) deliverFile(ctx context.Context, handler bindings.Handler, file os.FileInfo) error {
+	filePath := sftpClient.Join(sftp.metadata.RootPath, file.Name())
+
+	f, err := sftp.sftpClient.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error open file: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("error read file: %w", err)
+	}
+
+	_, err = handler(ctx, &bindings.ReadResponse{
+		Data: data,
+		Metadata: map[string]string{
+			metadataFileName:    file.Name(),
+			metadataFilePath:    filePath,
+			metadataFileSize:    strconv.FormatInt(file.Size(), 10),
+			metadataFileModTime: file.ModTime().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error handling file: %w", err)
+	}
+
+	switch sftp.metadata.PostProcessAction {
+	case postProcessDelete:
+		err = sftp.sftpClient.Remove(filePath)
+	case postProcessMove:
+		err = sftp.sftpClient.MkdirAll(sftp.metadata.ArchivePath)
+		if err == nil {
+			err = sftp.sftpClient.PosixRename(filePath, sftpClient.Join(sftp.metadata.ArchivePath, file.Name()))
+		}
+	case postProcessRename:
+		err = sftp.sftpClient.PosixRename(filePath, filePath+sftp.metadata.RenameSuffix)
+	}
+	if err != nil {
+		return fmt.Errorf("error post-processing file with action %s: %w", sftp.metadata.PostProcessAction, err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"gopkg.in/gomail.v2"
+
+	"github.com/dapr/components-contrib/bindings"
+	kitstrings "github.com/dapr/kit/strings"
+)
+
+const (
+	// Request metadata set to "json" when the data is an emailMessage.
+	metadataMessageFormat = "messageFormat"
+	messageFormatJSON     = "json"
+	// Request metadata overriding renderTemplates.
+	metadataRenderTemplates = "renderTemplates"
+
+	defaultMaxAttachmentSize = 10 << 20 // 10 MB
+)
+
+// emailMessage is the data of requests with the json message format.
+type emailMessage struct {
+	// HTML body of the email.
+	HTML string `json:"html"`
+	// Plain text body of the email: with an HTML body too, the email has both as alternative parts.
+	Text        string       `json:"text"`
+	Attachments []attachment `json:"attachments"`
+	// Values available in the templates, in addition to the request metadata.
+	TemplateData map[string]any `json:"templateData"`
+}
+
+// attachment is a file attached to an email, either with its content or fetched from a URL.
+type attachment struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	// Content of the attachment, base64-encoded in JSON.
+	Content []byte `json:"content"`
+	// URL the content is fetched from, such as a pre-signed URL of a blob; requires allowAttachmentURLs.
+	URL string `json:"url"`
+}
+
+// newMessage composes the email of a request.
+func (s *Mailer) newMessage(ctx context.Context, metadata *Metadata, req *bindings.InvokeRequest) (*gomail.Message, error) {
+	var email emailMessage
+	if req.Metadata[metadataMessageFormat] == messageFormatJSON {
+		err := json.Unmarshal(req.Data, &email)
+		if err != nil {
+			return nil, fmt.Errorf("smtp binding error: invalid message: %w", err)
+		}
+		if email.HTML == "" && email.Text == "" {
+			return nil, errors.New("smtp binding error: message has no html or text body")
+		}
+	} else {
+		body, err := strconv.Unquote(string(req.Data))
+		if err != nil {
+			// When data arrives over gRPC it's not quoted. Unquoting the original data will result in an error.
+			// Instead of unquoting it we'll just use the raw string as that one's already in the right format.
+			body = string(req.Data)
+		}
+		email.HTML = body
+	}
+
+	subject := metadata.Subject
+	renderTemplates := metadata.RenderTemplates
+	if val := req.Metadata[metadataRenderTemplates]; val != "" {
+		renderTemplates = kitstrings.IsTruthy(val)
+	}
+	if renderTemplates {
+		data := make(map[string]any, len(req.Metadata)+len(email.TemplateData))
+		for k, v := range req.Metadata {
+			data[k] = v
+		}
+		for k, v := range email.TemplateData {
+			data[k] = v
+		}
+
+		var err error
+		subject, err = renderText("subject", subject, data)
+		if err != nil {
+			return nil, err
+		}
+		email.Text, err = renderText("text", email.Text, data)
+		if err != nil {
+			return nil, err
+		}
+		email.HTML, err = renderHTML(email.HTML, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", metadata.EmailFrom)
+	msg.SetHeader("To", metadata.parseAddresses(metadata.EmailTo)...)
+	if metadata.EmailCC != "" {
+		msg.SetHeader("Cc", metadata.parseAddresses(metadata.EmailCC)...)
+	}
+	if metadata.EmailBCC != "" {
+		msg.SetHeader("Bcc", metadata.parseAddresses(metadata.EmailBCC)...)
+	}
+
+	msg.SetHeader("Subject", subject)
+	msg.SetHeader("X-priority", strconv.Itoa(metadata.Priority))
+
+	switch {
+	case email.Text != "" && email.HTML != "":
+		// Clients show the last alternative they support, so HTML is preferred
+		msg.SetBody("text/plain", email.Text)
+		msg.AddAlternative("text/html", email.HTML)
+	case email.Text != "":
+		msg.SetBody("text/plain", email.Text)
+	default:
+		msg.SetBody("text/html", email.HTML)
+	}
+
+	for i, a := range email.Attachments {
+		if a.FileName == "" && a.URL != "" {
+			a.FileName = path.Base(strings.SplitN(a.URL, "?", 2)[0])
+		}
+		if a.FileName == "" {
+			return nil, fmt.Errorf("smtp binding error: attachment %d: fileName is required", i)
+		}
+		content, err := s.attachmentContent(ctx, metadata, a)
+		if err != nil {
+			return nil, fmt.Errorf("smtp binding error: attachment %d: %w", i, err)
+		}
+		settings := []gomail.FileSetting{
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		}
+		if a.ContentType != "" {
+			settings = append(settings, gomail.SetHeader(map[string][]string{"Content-Type": {a.ContentType}}))
+		}
+		msg.Attach(a.FileName, settings...)
+	}
+
+	return msg, nil
+}
+
+// attachmentContent returns the content of an attachment, fetching it from its URL if it has one.
+func (s *Mailer) attachmentContent(ctx context.Context, metadata *Metadata, a attachment) ([]byte, error) {
+	maxSize := metadata.maxAttachmentSizeBytes
+	if maxSize <= 0 {
+		maxSize = defaultMaxAttachmentSize
+	}
+
+	if a.URL == "" {
+		if int64(len(a.Content)) > maxSize {
+			return nil, fmt.Errorf("content larger than %d bytes", maxSize)
+		}
+		return a.Content, nil
+	}
+	if len(a.Content) > 0 {
+		return nil, errors.New("content and url can't be both set")
+	}
+	if !metadata.AllowAttachmentURLs {
+		return nil, errors.New("attachments from URLs are not allowed: set allowAttachmentURLs to enable them")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching content: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching content: received status code %d", res.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching content: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("content larger than %d bytes", maxSize)
+	}
+	return content, nil
+}
+
+func renderText(name string, text string, data map[string]any) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tpl, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("smtp binding error: invalid %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	err = tpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("smtp binding error: error rendering %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// renderHTML renders an HTML template, escaping the values it includes.
+func renderHTML(text string, data map[string]any) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("smtp binding error: invalid html template: %w", err)
+	}
+	var buf bytes.Buffer
+	err = tpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("smtp binding error: error rendering html template: %w", err)
+	}
+	return buf.String(), nil
+}
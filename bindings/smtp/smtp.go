@@ -18,9 +18,11 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/gomail.v2"
 
@@ -35,12 +37,15 @@ const (
 	lowestPriority  = 1
 	highestPriority = 5
 	mailSeparator   = ";"
+
+	attachmentRequestTimeout = 30 * time.Second
 )
 
 // Mailer allows sending of emails using the Simple Mail Transfer Protocol.
 type Mailer struct {
-	metadata Metadata
-	logger   logger.Logger
+	metadata   Metadata
+	logger     logger.Logger
+	httpClient *http.Client
 }
 
 // Metadata holds standard email properties.
@@ -56,11 +61,26 @@ type Metadata struct {
 	EmailBCC      string `mapstructure:"emailBCC"`
 	Subject       string `mapstructure:"subject"`
 	Priority      int    `mapstructure:"priority"`
+
+	// Defines if the subject and bodies are Go templates, rendered with the request metadata.
+	RenderTemplates bool `mapstructure:"renderTemplates"`
+	// Defines if attachments can be fetched from URLs.
+	AllowAttachmentURLs bool `mapstructure:"allowAttachmentURLs"`
+	// Maximum size of each attachment.
+	// Default: 10MB
+	MaxAttachmentSize kitmd.ByteSize `mapstructure:"maxAttachmentSize"`
+
+	maxAttachmentSizeBytes int64
 }
 
 // NewSMTP returns a new smtp binding instance.
 func NewSMTP(logger logger.Logger) bindings.OutputBinding {
-	return &Mailer{logger: logger}
+	return &Mailer{
+		logger: logger,
+		httpClient: &http.Client{
+			Timeout: attachmentRequestTimeout,
+		},
+	}
 }
 
 // Init smtp component (parse metadata).
@@ -81,7 +101,7 @@ func (s *Mailer) Operations() []bindings.OperationKind {
 }
 
 // Invoke sends an email message.
-func (s *Mailer) Invoke(_ context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+func (s *Mailer) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	// Merge config metadata with request metadata
 	metadata, err := s.metadata.mergeWithRequestMetadata(req)
 	if err != nil {
@@ -98,28 +118,9 @@ func (s *Mailer) Invoke(_ context.Context, req *bindings.InvokeRequest) (*bindin
 	}
 
 	// Compose message
-	msg := gomail.NewMessage()
-	msg.SetHeader("From", metadata.EmailFrom)
-	msg.SetHeader("To", metadata.parseAddresses(metadata.EmailTo)...)
-	if metadata.EmailCC != "" {
-		msg.SetHeader("Cc", metadata.parseAddresses(metadata.EmailCC)...)
-	}
-	if metadata.EmailBCC != "" {
-		msg.SetHeader("Bcc", metadata.parseAddresses(metadata.EmailBCC)...)
-	}
-
-	msg.SetHeader("Subject", metadata.Subject)
-	msg.SetHeader("X-priority", strconv.Itoa(metadata.Priority))
-
-	body, err := strconv.Unquote(string(req.Data))
-
+	msg, err := s.newMessage(ctx, metadata, req)
 	if err != nil {
-		// When data arrives over gRPC it's not quoted. Unquoting the original data will result in an error.
-		// Instead of unquoting it we'll just use the raw string as that one's already in the right format.
-
-		msg.SetBody("text/html", string(req.Data))
-	} else {
-		msg.SetBody("text/html", body)
+		return nil, err
 	}
 
 	// Send message
@@ -140,7 +141,9 @@ func (s *Mailer) Invoke(_ context.Context, req *bindings.InvokeRequest) (*bindin
 
 // Helper to parse metadata.
 func (s *Mailer) parseMetadata(meta bindings.Metadata) (Metadata, error) {
-	smtpMeta := Metadata{}
+	smtpMeta := Metadata{
+		MaxAttachmentSize: kitmd.NewByteSize(defaultMaxAttachmentSize),
+	}
 	err := kitmd.DecodeMetadata(meta.Properties, &smtpMeta)
 	if err != nil {
 		return smtpMeta, err
@@ -172,6 +175,14 @@ func (s *Mailer) parseMetadata(meta bindings.Metadata) (Metadata, error) {
 		return smtpMeta, err
 	}
 
+	smtpMeta.maxAttachmentSizeBytes, err = smtpMeta.MaxAttachmentSize.GetBytes()
+	if err != nil {
+		return smtpMeta, fmt.Errorf("smtp binding error: invalid maxAttachmentSize: %w", err)
+	}
+	if smtpMeta.maxAttachmentSizeBytes <= 0 {
+		smtpMeta.maxAttachmentSizeBytes = defaultMaxAttachmentSize
+	}
+
 	return smtpMeta, nil
 }
 
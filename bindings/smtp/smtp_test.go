@@ -14,10 +14,21 @@ limitations under the License.
 package smtp
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/gomail.v2"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/kit/logger"
@@ -321,3 +332,135 @@ func TestMergeWithRequestMetadata_invalidPriorityNotNumber(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// parseMessage returns the headers of a message and its parts, by content type.
+func parseMessage(t *testing.T, msg *gomail.Message) (mail.Header, map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	_, err := msg.WriteTo(&buf)
+	require.NoError(t, err)
+	m, err := mail.ReadMessage(&buf)
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	var readParts func(contentType string, body io.Reader)
+	readParts = func(contentType string, body io.Reader) {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		require.NoError(t, err)
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			data, err := io.ReadAll(body)
+			require.NoError(t, err)
+			parts[mediaType] = string(data)
+			return
+		}
+		r := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := r.NextPart()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			require.NoError(t, err)
+			var partBody io.Reader = p
+			if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+				partBody = base64.NewDecoder(base64.StdEncoding, p)
+			}
+			readParts(p.Header.Get("Content-Type"), partBody)
+		}
+	}
+	readParts(m.Header.Get("Content-Type"), m.Body)
+	return m.Header, parts
+}
+
+func TestNewMessage(t *testing.T) {
+	s := NewSMTP(logger.NewLogger("test")).(*Mailer)
+	meta := &Metadata{
+		EmailFrom: "from@dapr.io",
+		EmailTo:   "to@dapr.io",
+		Subject:   "Order {{.orderId}}",
+		Priority:  3,
+	}
+
+	t.Run("raw data is the html body", func(t *testing.T) {
+		msg, err := s.newMessage(t.Context(), meta, &bindings.InvokeRequest{
+			Data:     []byte(`"<b>hello</b>"`),
+			Metadata: map[string]string{},
+		})
+		require.NoError(t, err)
+		header, parts := parseMessage(t, msg)
+		assert.Equal(t, "Order {{.orderId}}", header.Get("Subject"))
+		assert.Equal(t, map[string]string{"text/html": "<b>hello</b>"}, parts)
+	})
+
+	t.Run("text and html alternatives with attachments", func(t *testing.T) {
+		msg, err := s.newMessage(t.Context(), meta, &bindings.InvokeRequest{
+			Data: []byte(`{
+				"text": "hello",
+				"html": "<b>hello</b>",
+				"attachments": [
+					{"fileName": "report.csv", "contentType": "text/csv", "content": "YSxiCjEsMgo="},
+					{"fileName": "data.json", "contentType": "application/json", "content": "e30="}
+				]
+			}`),
+			Metadata: map[string]string{"messageFormat": "json"},
+		})
+		require.NoError(t, err)
+		_, parts := parseMessage(t, msg)
+		assert.Equal(t, map[string]string{
+			"text/plain":       "hello",
+			"text/html":        "<b>hello</b>",
+			"text/csv":         "a,b\n1,2\n",
+			"application/json": "{}",
+		}, parts)
+	})
+
+	t.Run("templates are rendered", func(t *testing.T) {
+		msg, err := s.newMessage(t.Context(), meta, &bindings.InvokeRequest{
+			Data: []byte(`{
+				"text": "Hi {{.name}}, order {{.orderId}} shipped",
+				"html": "<p>Hi {{.name}}</p>"
+			}`),
+			Metadata: map[string]string{
+				"messageFormat":   "json",
+				"renderTemplates": "true",
+				"orderId":         "42",
+				"name":            "<Jane>",
+			},
+		})
+		require.NoError(t, err)
+		header, parts := parseMessage(t, msg)
+		assert.Equal(t, "Order 42", header.Get("Subject"))
+		assert.Equal(t, "Hi <Jane>, order 42 shipped", parts["text/plain"])
+		assert.Equal(t, "<p>Hi &lt;Jane&gt;</p>", parts["text/html"])
+
+		_, err = s.newMessage(t.Context(), meta, &bindings.InvokeRequest{
+			Data:     []byte(`Hi {{.missing}}`),
+			Metadata: map[string]string{"renderTemplates": "true", "orderId": "42"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("attachments from URLs", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("remote content"))
+		}))
+		defer srv.Close()
+		req := &bindings.InvokeRequest{
+			Data:     []byte(`{"text": "hello", "attachments": [{"url": "` + srv.URL + `/files/remote.txt?sig=abc", "contentType": "application/octet-stream"}]}`),
+			Metadata: map[string]string{"messageFormat": "json"},
+		}
+
+		_, err := s.newMessage(t.Context(), meta, req)
+		require.ErrorContains(t, err, "allowAttachmentURLs")
+
+		allowed := *meta
+		allowed.AllowAttachmentURLs = true
+		msg, err := s.newMessage(t.Context(), &allowed, req)
+		require.NoError(t, err)
+		_, parts := parseMessage(t, msg)
+		assert.Equal(t, "remote content", parts["application/octet-stream"])
+
+		allowed.maxAttachmentSizeBytes = 5
+		_, err = s.newMessage(t.Context(), &allowed, req)
+		require.ErrorContains(t, err, "larger than 5 bytes")
+	})
+}
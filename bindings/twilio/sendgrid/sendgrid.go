@@ -15,10 +15,13 @@ package sendgrid
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -29,6 +32,7 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 // SendGrid allows sending of emails using the 3rd party SendGrid service.
@@ -49,10 +53,43 @@ type sendGridMetadata struct {
 	EmailBcc            string `mapstructure:"emailBcc"`
 	DynamicTemplateData string `mapstructure:"dynamicTemplateData"`
 	DynamicTemplateID   string `mapstructure:"dynamicTemplateId"`
+	Categories          string `mapstructure:"categories"` // Comma-separated list of categories
+	CustomArgs          string `mapstructure:"customArgs"` // JSON object of string values
+	SandboxMode         bool   `mapstructure:"sandboxMode"`
 
-	dynamicTemplateDataCache map[string]any // Cache the unmarshalled dynamic template data
+	dynamicTemplateDataCache map[string]any    // Cache the unmarshalled dynamic template data
+	customArgsCache          map[string]string // Cache the unmarshalled custom args
 }
 
+// Attachment of an email, passed as an element of a JSON array in the "attachments" request metadata.
+type sendGridAttachment struct {
+	Content     string `json:"content"` // Base64-encoded
+	Filename    string `json:"filename"`
+	Type        string `json:"type"`
+	Disposition string `json:"disposition"`
+	ContentID   string `json:"contentId"`
+}
+
+// Personalization of an email, passed as an element of a JSON array in the "personalizations" request metadata.
+// Each personalization is a copy of the email sent to its own recipients, with its own subject and substitution data.
+type sendGridPersonalization struct {
+	To      []sendGridRecipient `json:"to"`
+	Cc      []sendGridRecipient `json:"cc"`
+	Bcc     []sendGridRecipient `json:"bcc"`
+	Subject string              `json:"subject"` // Defaults to the subject of the email
+	// Merged with the dynamic template data of the email, overriding its values
+	DynamicTemplateData map[string]any    `json:"dynamicTemplateData"`
+	CustomArgs          map[string]string `json:"customArgs"`
+}
+
+type sendGridRecipient struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// SendGrid accepts up to 1000 personalizations per request.
+const maxPersonalizations = 1000
+
 // Wrapper to help decode SendGrid API errors.
 type sendGridRestError struct {
 	Errors []struct {
@@ -89,6 +126,14 @@ func (sg *SendGrid) parseMetadata(meta bindings.Metadata) (sendGridMetadata, err
 		}
 	}
 
+	// Cache the unmarshalled custom args if present
+	if sgMeta.CustomArgs != "" {
+		err = unmarshalCustomArgs(sgMeta.CustomArgs, &sgMeta.customArgsCache)
+		if err != nil {
+			return sgMeta, err
+		}
+	}
+
 	return sgMeta, nil
 }
 
@@ -112,6 +157,34 @@ func (sg *SendGrid) Operations() []bindings.OperationKind {
 
 // Write does the work of sending message to SendGrid API.
 func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	email, err := sg.buildEmail(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the email
+	client := sendgrid.NewSendClient(sg.metadata.APIKey)
+	resp, err := client.SendWithContext(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("error from SendGrid: sending email failed: %w", err)
+	}
+
+	// Check SendGrid response is OK
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		// Extract the underlying error message(s) returned from SendGrid REST API
+		sendGridError := sendGridRestError{}
+		json.NewDecoder(strings.NewReader(resp.Body)).Decode(&sendGridError)
+		// Pass it back to the caller, so they have some idea what went wrong
+		return nil, fmt.Errorf("error from SendGrid: sending email failed: %d %+v", resp.StatusCode, sendGridError)
+	}
+
+	sg.logger.Info("sent email with SendGrid")
+
+	return nil, nil
+}
+
+// buildEmail builds the email message from the component and request metadata.
+func (sg *SendGrid) buildEmail(req *bindings.InvokeRequest) (*mail.SGMailV3, error) {
 	// We allow two possible sources of the properties we need,
 	// the component metadata or request metadata, request takes priority if present
 
@@ -159,11 +232,33 @@ func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*b
 
 		toAddress = mail.NewEmail(toName, req.Metadata["emailTo"])
 	}
-	if toAddress == nil {
+
+	// Build email personalizations, this is optional: when set, they replace the to, cc and bcc addresses
+	var personalizations []sendGridPersonalization
+	if req.Metadata["personalizations"] != "" {
+		err := json.Unmarshal([]byte(req.Metadata["personalizations"]), &personalizations)
+		if err != nil {
+			return nil, fmt.Errorf("error from SendGrid binding, personalizations are not a valid JSON array: %w", err)
+		}
+		if len(personalizations) == 0 || len(personalizations) > maxPersonalizations {
+			return nil, fmt.Errorf("error from SendGrid binding, personalizations must have between 1 and %d elements", maxPersonalizations)
+		}
+		for i, p := range personalizations {
+			if len(p.To) == 0 {
+				return nil, fmt.Errorf("error from SendGrid binding, personalization %d has no to email", i)
+			}
+			for _, r := range slices.Concat(p.To, p.Cc, p.Bcc) {
+				if r.Email == "" {
+					return nil, fmt.Errorf("error from SendGrid binding, personalization %d has a recipient without email", i)
+				}
+			}
+		}
+	}
+	if toAddress == nil && personalizations == nil {
 		return nil, errors.New("error SendGrid to email not supplied")
 	}
 
-	// Build email subject, this is required
+	// Build email subject, this is optional
 	subject := ""
 	if sg.metadata.Subject != "" {
 		subject = sg.metadata.Subject
@@ -171,9 +266,6 @@ func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*b
 	if req.Metadata["subject"] != "" {
 		subject = req.Metadata["subject"]
 	}
-	if subject == "" {
-		return nil, errors.New("error SendGrid subject not supplied")
-	}
 
 	// Build email cc address, this is optional
 	var ccAddress *mail.Email
@@ -201,6 +293,18 @@ func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*b
 		templateID = sg.metadata.DynamicTemplateID
 	}
 
+	// Subject is required, unless it's defined by the dynamic template
+	if subject == "" && templateID == "" {
+		if personalizations == nil {
+			return nil, errors.New("error SendGrid subject not supplied")
+		}
+		for i, p := range personalizations {
+			if p.Subject == "" {
+				return nil, fmt.Errorf("error SendGrid subject not supplied for personalization %d", i)
+			}
+		}
+	}
+
 	// Build email dynamic template, this is optional
 	var templateData map[string]any
 	if req.Metadata["dynamicTemplateData"] != "" {
@@ -212,6 +316,45 @@ func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*b
 		templateData = sg.metadata.dynamicTemplateDataCache
 	}
 
+	// Build email categories, this is optional
+	categories := sg.metadata.Categories
+	if req.Metadata["categories"] != "" {
+		categories = req.Metadata["categories"]
+	}
+
+	// Build email custom args, this is optional
+	customArgs := sg.metadata.customArgsCache
+	if req.Metadata["customArgs"] != "" {
+		customArgs = nil
+		err := unmarshalCustomArgs(req.Metadata["customArgs"], &customArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Build email sandbox mode, this is optional
+	sandboxMode := sg.metadata.SandboxMode
+	if req.Metadata["sandboxMode"] != "" {
+		sandboxMode = kitstrings.IsTruthy(req.Metadata["sandboxMode"])
+	}
+
+	// Build email attachments, this is optional
+	var attachments []sendGridAttachment
+	if req.Metadata["attachments"] != "" {
+		err := json.Unmarshal([]byte(req.Metadata["attachments"]), &attachments)
+		if err != nil {
+			return nil, fmt.Errorf("error from SendGrid binding, attachments are not a valid JSON array: %w", err)
+		}
+		for i, a := range attachments {
+			if a.Filename == "" {
+				return nil, fmt.Errorf("error from SendGrid binding, attachment %d has no filename", i)
+			}
+			if _, err = base64.StdEncoding.DecodeString(a.Content); err != nil || a.Content == "" {
+				return nil, fmt.Errorf("error from SendGrid binding, attachment %d content is not valid base64", i)
+			}
+		}
+	}
+
 	// Email body is held in req.Data, after we tidy it up a bit
 	emailBody, err := strconv.Unquote(string(req.Data))
 	if err != nil {
@@ -222,46 +365,79 @@ func (sg *SendGrid) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*b
 	// Construct email message
 	email := mail.NewV3Mail()
 	email.SetFrom(fromAddress)
-	email.AddContent(mail.NewContent("text/html", emailBody))
+	// Dynamic templates provide their own content
+	if emailBody != "" || templateID == "" {
+		email.AddContent(mail.NewContent("text/html", emailBody))
+	}
 
 	// Add other fields to email
-	personalization := mail.NewPersonalization()
-	personalization.AddTos(toAddress)
-	personalization.Subject = subject
-	if ccAddress != nil {
-		personalization.AddCCs(ccAddress)
+	if personalizations == nil {
+		personalization := mail.NewPersonalization()
+		personalization.AddTos(toAddress)
+		personalization.Subject = subject
+		if ccAddress != nil {
+			personalization.AddCCs(ccAddress)
+		}
+		if bccAddress != nil {
+			personalization.AddBCCs(bccAddress)
+		}
+		if templateData != nil {
+			personalization.DynamicTemplateData = templateData
+		}
+		email.AddPersonalizations(personalization)
 	}
-	if bccAddress != nil {
-		personalization.AddBCCs(bccAddress)
+	for _, p := range personalizations {
+		personalization := mail.NewPersonalization()
+		personalization.AddTos(newEmails(p.To)...)
+		personalization.AddCCs(newEmails(p.Cc)...)
+		personalization.AddBCCs(newEmails(p.Bcc)...)
+		personalization.Subject = subject
+		if p.Subject != "" {
+			personalization.Subject = p.Subject
+		}
+		if templateData != nil || p.DynamicTemplateData != nil {
+			data := make(map[string]any, len(templateData)+len(p.DynamicTemplateData))
+			maps.Copy(data, templateData)
+			maps.Copy(data, p.DynamicTemplateData)
+			personalization.DynamicTemplateData = data
+		}
+		for k, v := range p.CustomArgs {
+			personalization.SetCustomArg(k, v)
+		}
+		email.AddPersonalizations(personalization)
 	}
 	if templateID != "" {
 		email.TemplateID = templateID
 	}
-	if templateData != nil {
-		personalization.DynamicTemplateData = templateData
-	}
-
-	email.AddPersonalizations(personalization)
 
-	// Send the email
-	client := sendgrid.NewSendClient(sg.metadata.APIKey)
-	resp, err := client.SendWithContext(ctx, email)
-	if err != nil {
-		return nil, fmt.Errorf("error from SendGrid: sending email failed: %w", err)
+	for _, c := range strings.Split(categories, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			email.AddCategories(c)
+		}
 	}
-
-	// Check SendGrid response is OK
-	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-		// Extract the underlying error message(s) returned from SendGrid REST API
-		sendGridError := sendGridRestError{}
-		json.NewDecoder(strings.NewReader(resp.Body)).Decode(&sendGridError)
-		// Pass it back to the caller, so they have some idea what went wrong
-		return nil, fmt.Errorf("error from SendGrid: sending email failed: %d %+v", resp.StatusCode, sendGridError)
+	for k, v := range customArgs {
+		email.SetCustomArg(k, v)
+	}
+	for _, a := range attachments {
+		attachment := mail.NewAttachment()
+		attachment.SetContent(a.Content)
+		attachment.SetFilename(a.Filename)
+		if a.Type != "" {
+			attachment.SetType(a.Type)
+		}
+		if a.Disposition != "" {
+			attachment.SetDisposition(a.Disposition)
+		}
+		if a.ContentID != "" {
+			attachment.SetContentID(a.ContentID)
+		}
+		email.AddAttachment(attachment)
+	}
+	if sandboxMode {
+		email.SetMailSettings(mail.NewMailSettings().SetSandboxMode(mail.NewSetting(true)))
 	}
 
-	sg.logger.Info("sent email with SendGrid")
-
-	return nil, nil
+	return email, nil
 }
 
 // GetComponentMetadata returns the metadata of the component.
@@ -284,6 +460,23 @@ func UnmarshalDynamicTemplateData(jsonString string, result *map[string]any) err
 	return nil
 }
 
+// unmarshalCustomArgs unmarshals the custom args JSON string into a map[string]string.
+func unmarshalCustomArgs(jsonString string, result *map[string]string) error {
+	err := json.Unmarshal([]byte(jsonString), result)
+	if err != nil {
+		return fmt.Errorf("error from SendGrid binding, custom args are not a valid JSON object of strings: %w", err)
+	}
+	return nil
+}
+
+func newEmails(recipients []sendGridRecipient) []*mail.Email {
+	emails := make([]*mail.Email, len(recipients))
+	for i, r := range recipients {
+		emails[i] = mail.NewEmail(r.Name, r.Email)
+	}
+	return emails
+}
+
 func Close() error {
 	return nil
 }
@@ -111,3 +111,115 @@ func TestUnmarshalDynamicTemplateData(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestBuildEmail(t *testing.T) {
+	sg := SendGrid{
+		logger: logger.NewLogger("test"),
+		metadata: sendGridMetadata{
+			APIKey:     "123",
+			EmailFrom:  "test1@example.net",
+			EmailTo:    "test2@example.net",
+			Categories: "a, b",
+		},
+	}
+
+	t.Run("subject is required without template", func(t *testing.T) {
+		_, err := sg.buildEmail(&bindings.InvokeRequest{Data: []byte("body")})
+		require.ErrorContains(t, err, "subject")
+	})
+
+	t.Run("dynamic template without subject and body", func(t *testing.T) {
+		email, err := sg.buildEmail(&bindings.InvokeRequest{
+			Metadata: map[string]string{
+				"dynamicTemplateId":   "d-456",
+				"dynamicTemplateData": `{"name":"MyName"}`,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "d-456", email.TemplateID)
+		assert.Empty(t, email.Content)
+		require.Len(t, email.Personalizations, 1)
+		assert.Equal(t, map[string]any{"name": "MyName"}, email.Personalizations[0].DynamicTemplateData)
+		assert.Equal(t, []string{"a", "b"}, email.Categories)
+		assert.Nil(t, email.MailSettings)
+	})
+
+	t.Run("attachments, categories and sandbox mode", func(t *testing.T) {
+		email, err := sg.buildEmail(&bindings.InvokeRequest{
+			Data: []byte("body"),
+			Metadata: map[string]string{
+				"subject":     "hello",
+				"categories":  "c",
+				"sandboxMode": "true",
+				"attachments": `[{"content": "aGVsbG8=", "filename": "hello.txt", "type": "text/plain", "disposition": "inline", "contentId": "hello"}]`,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c"}, email.Categories)
+		require.NotNil(t, email.MailSettings)
+		require.NotNil(t, email.MailSettings.SandboxMode)
+		assert.True(t, *email.MailSettings.SandboxMode.Enable)
+		require.Len(t, email.Attachments, 1)
+		assert.Equal(t, "aGVsbG8=", email.Attachments[0].Content)
+		assert.Equal(t, "hello.txt", email.Attachments[0].Filename)
+		assert.Equal(t, "text/plain", email.Attachments[0].Type)
+		assert.Equal(t, "inline", email.Attachments[0].Disposition)
+		assert.Equal(t, "hello", email.Attachments[0].ContentID)
+	})
+
+	t.Run("personalizations and custom args", func(t *testing.T) {
+		sg := sg
+		sg.metadata.customArgsCache = map[string]string{"app": "orders"}
+		email, err := sg.buildEmail(&bindings.InvokeRequest{
+			Metadata: map[string]string{
+				"dynamicTemplateId":   "d-456",
+				"dynamicTemplateData": `{"shop":"MyShop","name":"Customer"}`,
+				"customArgs":          `{"batch":"42"}`,
+				"personalizations": `[
+					{"to": [{"email": "a@example.net", "name": "A"}], "dynamicTemplateData": {"name": "A"}, "customArgs": {"orderId": "1"}},
+					{"to": [{"email": "b@example.net"}], "bcc": [{"email": "c@example.net"}], "subject": "For B"}
+				]`,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, email.Personalizations, 2)
+
+		p := email.Personalizations[0]
+		require.Len(t, p.To, 1)
+		assert.Equal(t, "a@example.net", p.To[0].Address)
+		assert.Equal(t, "A", p.To[0].Name)
+		assert.Empty(t, p.Subject)
+		assert.Equal(t, map[string]any{"shop": "MyShop", "name": "A"}, p.DynamicTemplateData)
+		assert.Equal(t, map[string]string{"orderId": "1"}, p.CustomArgs)
+
+		p = email.Personalizations[1]
+		require.Len(t, p.To, 1)
+		assert.Equal(t, "b@example.net", p.To[0].Address)
+		require.Len(t, p.BCC, 1)
+		assert.Equal(t, "c@example.net", p.BCC[0].Address)
+		assert.Equal(t, "For B", p.Subject)
+		assert.Equal(t, map[string]any{"shop": "MyShop", "name": "Customer"}, p.DynamicTemplateData)
+
+		assert.Equal(t, map[string]string{"batch": "42"}, email.CustomArgs)
+		assert.Equal(t, map[string]string{"app": "orders"}, sg.metadata.customArgsCache)
+	})
+
+	t.Run("invalid personalizations", func(t *testing.T) {
+		for _, personalizations := range []string{`{}`, `[]`, `[{"subject": "hello"}]`, `[{"to": [{"name": "A"}]}]`, `[{"to": [{"email": "a@example.net"}]}]`} {
+			_, err := sg.buildEmail(&bindings.InvokeRequest{
+				Data:     []byte("body"),
+				Metadata: map[string]string{"personalizations": personalizations},
+			})
+			require.Error(t, err, personalizations)
+		}
+	})
+
+	t.Run("invalid attachments", func(t *testing.T) {
+		for _, attachments := range []string{`{}`, `[{"content": "aGVsbG8="}]`, `[{"content": "not base64!", "filename": "a"}]`} {
+			_, err := sg.buildEmail(&bindings.InvokeRequest{
+				Metadata: map[string]string{"subject": "hello", "attachments": attachments},
+			})
+			require.Error(t, err, attachments)
+		}
+	})
+}
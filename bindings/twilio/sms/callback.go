@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+const (
+	signatureHeader = "X-Twilio-Signature"
+
+	// Status callbacks are small forms: larger requests are rejected.
+	maxCallbackSize = 64 << 10
+)
+
+// Read starts a server receiving the status callbacks of the messages sent with the binding, and delivers them to the
+// handler as JSON objects with the parameters sent by Twilio, such as MessageSid, MessageStatus and ErrorCode.
+// Callbacks are authenticated with their X-Twilio-Signature header.
+// See: https://www.twilio.com/docs/usage/webhooks/webhooks-security
+func (t *SMS) Read(ctx context.Context, handler bindings.Handler) error {
+	if t.metadata.StatusCallbackURL == "" {
+		return errors.New(`"statusCallbackURL" is required to use the binding as input`)
+	}
+	if t.metadata.CallbackPort == "" {
+		return errors.New(`"callbackPort" is required to use the binding as input`)
+	}
+	if t.closed.Load() {
+		return errors.New("binding is closed")
+	}
+
+	listener, err := net.Listen("tcp", ":"+t.metadata.CallbackPort)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           t.callbackHandler(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Run the server in background
+	t.wg.Add(2)
+	go func() {
+		defer t.wg.Done()
+		t.logger.Infof("Listening for Twilio status callbacks on port %s", t.metadata.CallbackPort)
+		srvErr := srv.Serve(listener)
+		if srvErr != nil && !errors.Is(srvErr, http.ErrServerClosed) {
+			t.logger.Errorf("Error serving status callbacks: %v", srvErr)
+		}
+	}()
+	// Close the server when context is canceled or binding closed.
+	go func() {
+		defer t.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-t.closeCh:
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srvErr := srv.Shutdown(shutdownCtx)
+		if srvErr != nil {
+			t.logger.Errorf("Error shutting down server: %v", srvErr)
+		}
+	}()
+
+	return nil
+}
+
+// callbackHandler returns the handler of the status callbacks.
+func (t *SMS) callbackHandler(handler bindings.Handler) http.Handler {
+	// Callbacks are only accepted on the path of the status callback URL, which was validated in Init
+	callbackURL, _ := url.Parse(t.metadata.StatusCallbackURL)
+	callbackPath := callbackURL.Path
+	if callbackPath == "" {
+		callbackPath = "/"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != callbackPath {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxCallbackSize)
+		err := r.ParseForm()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if !t.validSignature(r.Header.Get(signatureHeader), r.PostForm) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		params := make(map[string]string, len(r.PostForm))
+		for k := range r.PostForm {
+			params[k] = r.PostForm.Get(k)
+		}
+		data, err := json.Marshal(params)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_, err = handler(r.Context(), &bindings.ReadResponse{
+			Data: data,
+			Metadata: map[string]string{
+				metadataMessageSid:    params["MessageSid"],
+				metadataMessageStatus: params["MessageStatus"],
+			},
+		})
+		if err != nil {
+			// Twilio retries callbacks that fail
+			t.logger.Errorf("Error handling status callback of message %s: %v", params["MessageSid"], err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// validSignature returns true if the signature of a callback is the signature computed by Twilio with the auth token:
+// the base64-encoded HMAC-SHA1 of the callback URL followed by each parameter name and value, sorted by name.
+func (t *SMS) validSignature(signature string, params url.Values) bool {
+	if signature == "" {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, t.signature(params))
+}
+
+func (t *SMS) signature(params url.Values) []byte {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha1.New, []byte(t.metadata.AuthToken))
+	mac.Write([]byte(t.metadata.StatusCallbackURL))
+	for _, k := range keys {
+		for _, v := range params[k] {
+			mac.Write([]byte(k + v))
+		}
+	}
+	return mac.Sum(nil)
+}
@@ -15,6 +15,7 @@ package sms
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -22,6 +23,8 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
@@ -37,13 +40,29 @@ const (
 	accountSid    = "accountSid"
 	authToken     = "authToken"
 	timeout       = "timeout"
+	channel       = "channel"
+	mediaURLs     = "mediaUrls"
 	twilioURLBase = "https://api.twilio.com/2010-04-01/Accounts/"
+
+	channelSMS      = "sms"
+	channelWhatsApp = "whatsapp"
+	whatsAppPrefix  = "whatsapp:"
+
+	// Twilio accepts up to 10 media URLs per message.
+	maxMediaURLs = 10
+
+	// Defines the metadata keys of the response of the create operation.
+	metadataMessageSid    = "messageSid"
+	metadataMessageStatus = "messageStatus"
 )
 
 type SMS struct {
 	metadata   twilioMetadata
 	logger     logger.Logger
 	httpClient *http.Client
+	closeCh    chan struct{}
+	closed     atomic.Bool
+	wg         sync.WaitGroup
 }
 
 type twilioMetadata struct {
@@ -52,14 +71,22 @@ type twilioMetadata struct {
 	AccountSid string        `mapstructure:"accountSid"`
 	AuthToken  string        `mapstructure:"authToken"`
 	Timeout    time.Duration `mapstructure:"timeout"`
+	// Channel messages are sent on: "sms" (also used for MMS) or "whatsapp". Can be overridden per request.
+	Channel string `mapstructure:"channel"`
+	// Public URL Twilio sends the status callbacks of the messages to. When the binding is used as input, it's also
+	// the URL the signature of the callbacks is validated against.
+	StatusCallbackURL string `mapstructure:"statusCallbackURL"`
+	// Port the input binding listens on for status callbacks.
+	CallbackPort string `mapstructure:"callbackPort"`
 }
 
-func NewSMS(logger logger.Logger) bindings.OutputBinding {
+func NewSMS(logger logger.Logger) bindings.InputOutputBinding {
 	return &SMS{
 		logger: logger,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		closeCh: make(chan struct{}),
 	}
 }
 
@@ -82,6 +109,18 @@ func (t *SMS) Init(_ context.Context, meta bindings.Metadata) error {
 	if twilioM.AuthToken == "" {
 		return errors.New(`"authToken" is a required field`)
 	}
+	switch twilioM.Channel {
+	case "":
+		twilioM.Channel = channelSMS
+	case channelSMS, channelWhatsApp:
+	default:
+		return fmt.Errorf(`invalid value for "channel": %s`, twilioM.Channel)
+	}
+	if twilioM.StatusCallbackURL != "" {
+		if _, err = url.ParseRequestURI(twilioM.StatusCallbackURL); err != nil {
+			return fmt.Errorf(`invalid value for "statusCallbackURL": %w`, err)
+		}
+	}
 
 	t.metadata = twilioM
 	t.httpClient.Timeout = twilioM.Timeout
@@ -103,12 +142,39 @@ func (t *SMS) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*binding
 		toNumberValue = toNumberFromRequest
 	}
 
+	fromNumberValue := t.metadata.FromNumber
+	channelValue := t.metadata.Channel
+	if val := req.Metadata[channel]; val != "" {
+		channelValue = val
+	}
+	switch channelValue {
+	case channelSMS:
+	case channelWhatsApp:
+		toNumberValue = withWhatsAppPrefix(toNumberValue)
+		fromNumberValue = withWhatsAppPrefix(fromNumberValue)
+	default:
+		return nil, fmt.Errorf("twilio invalid \"channel\" field: %s", channelValue)
+	}
+
 	body := commonutils.Unquote(req.Data)
 
 	v := url.Values{}
 	v.Set("To", toNumberValue)
-	v.Set("From", t.metadata.FromNumber)
+	v.Set("From", fromNumberValue)
 	v.Set("Body", body)
+	if val := req.Metadata[mediaURLs]; val != "" {
+		for _, mediaURL := range strings.Split(val, ",") {
+			if mediaURL = strings.TrimSpace(mediaURL); mediaURL != "" {
+				v.Add("MediaUrl", mediaURL)
+			}
+		}
+		if len(v["MediaUrl"]) > maxMediaURLs {
+			return nil, fmt.Errorf("twilio \"mediaUrls\" field has more than %d URLs", maxMediaURLs)
+		}
+	}
+	if t.metadata.StatusCallbackURL != "" {
+		v.Set("StatusCallback", t.metadata.StatusCallbackURL)
+	}
 
 	twilioURL := twilioURLBase + t.metadata.AccountSid + "/Messages.json"
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, twilioURL, strings.NewReader(v.Encode()))
@@ -132,7 +198,28 @@ func (t *SMS) Invoke(ctx context.Context, req *bindings.InvokeRequest) (*binding
 		return nil, fmt.Errorf("error from Twilio (%d): %s", resp.StatusCode, resp.Status)
 	}
 
-	return nil, nil
+	// Return the SID of the message, which status callbacks refer to
+	var message struct {
+		Sid    string `json:"sid"`
+		Status string `json:"status"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&message) != nil || message.Sid == "" {
+		return nil, nil
+	}
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			metadataMessageSid:    message.Sid,
+			metadataMessageStatus: message.Status,
+		},
+	}, nil
+}
+
+// withWhatsAppPrefix returns the number as a WhatsApp address.
+func withWhatsAppPrefix(number string) string {
+	if strings.HasPrefix(number, whatsAppPrefix) {
+		return number
+	}
+	return whatsAppPrefix + number
 }
 
 // GetComponentMetadata returns the metadata of the component.
@@ -143,5 +230,9 @@ func (t *SMS) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 }
 
 func (t *SMS) Close() error {
+	if t.closed.CompareAndSwap(false, true) {
+		close(t.closeCh)
+	}
+	t.wg.Wait()
 	return nil
 }
@@ -14,9 +14,14 @@ limitations under the License.
 package sms
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync/atomic"
@@ -220,3 +225,138 @@ func TestMessageBody(t *testing.T) {
 		t.Run("Message body is empty", tester([]byte(""), ""))
 	})
 }
+
+func TestWriteChannelsAndMedia(t *testing.T) {
+	httpTransport := &mockTransport{}
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{
+		"fromNumber":        "+15550000000",
+		"accountSid":        "accountSid",
+		"authToken":         "authToken",
+		"statusCallbackURL": "https://example.com/twilio/status",
+	}
+	tw := NewSMS(logger.NewLogger("test")).(*SMS)
+	tw.httpClient = &http.Client{
+		Transport: httpTransport,
+	}
+	err := tw.Init(t.Context(), m)
+	require.NoError(t, err)
+
+	invoke := func(t *testing.T, md map[string]string) (*bindings.InvokeResponse, url.Values) {
+		t.Helper()
+		httpTransport.reset()
+		httpTransport.response = &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"sid": "SM123", "status": "queued"}`)),
+		}
+		res, err := tw.Invoke(t.Context(), &bindings.InvokeRequest{
+			Data:     []byte("hello world"),
+			Metadata: md,
+		})
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpTransport.request.Body)
+		require.NoError(t, err)
+		q, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+		return res, q
+	}
+
+	t.Run("SMS with status callback", func(t *testing.T) {
+		res, q := invoke(t, map[string]string{toNumber: "+15551111111"})
+		assert.Equal(t, "+15551111111", q.Get("To"))
+		assert.Equal(t, "+15550000000", q.Get("From"))
+		assert.Equal(t, "https://example.com/twilio/status", q.Get("StatusCallback"))
+		require.NotNil(t, res)
+		assert.Equal(t, map[string]string{"messageSid": "SM123", "messageStatus": "queued"}, res.Metadata)
+	})
+
+	t.Run("WhatsApp", func(t *testing.T) {
+		_, q := invoke(t, map[string]string{toNumber: "+15551111111", "channel": "whatsapp"})
+		assert.Equal(t, "whatsapp:+15551111111", q.Get("To"))
+		assert.Equal(t, "whatsapp:+15550000000", q.Get("From"))
+
+		_, q = invoke(t, map[string]string{toNumber: "whatsapp:+15551111111", "channel": "whatsapp"})
+		assert.Equal(t, "whatsapp:+15551111111", q.Get("To"))
+	})
+
+	t.Run("MMS", func(t *testing.T) {
+		_, q := invoke(t, map[string]string{
+			toNumber:    "+15551111111",
+			"mediaUrls": "https://example.com/a.png, https://example.com/b.jpg",
+		})
+		assert.Equal(t, []string{"https://example.com/a.png", "https://example.com/b.jpg"}, q["MediaUrl"])
+	})
+
+	t.Run("Invalid channel", func(t *testing.T) {
+		_, err := tw.Invoke(t.Context(), &bindings.InvokeRequest{
+			Data:     []byte("hello world"),
+			Metadata: map[string]string{toNumber: "+15551111111", "channel": "fax"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestStatusCallbacks(t *testing.T) {
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{
+		"fromNumber":        "+15550000000",
+		"accountSid":        "accountSid",
+		"authToken":         "12345",
+		"statusCallbackURL": "https://mycompany.com/myapp.php?foo=1&bar=2",
+	}
+	tw := NewSMS(logger.NewLogger("test")).(*SMS)
+	err := tw.Init(t.Context(), m)
+	require.NoError(t, err)
+
+	var received []*bindings.ReadResponse
+	handlerErr := error(nil)
+	h := tw.callbackHandler(func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		received = append(received, res)
+		return nil, handlerErr
+	})
+
+	params := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+	mac := hmac.New(sha1.New, []byte("12345"))
+	mac.Write([]byte("https://mycompany.com/myapp.php?foo=1&bar=2CallSidCA1234567890ABCDECaller+12349013030Digits1234From+12349013030To+18005551212"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	send := func(path string, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(params.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Twilio-Signature", signature)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, send("/myapp.php?foo=1&bar=2", signature))
+	require.Len(t, received, 1)
+	assert.JSONEq(t, `{"CallSid":"CA1234567890ABCDE","Caller":"+12349013030","Digits":"1234","From":"+12349013030","To":"+18005551212"}`, string(received[0].Data))
+
+	assert.Equal(t, http.StatusForbidden, send("/myapp.php", "invalid"))
+	assert.Equal(t, http.StatusNotFound, send("/other", signature))
+	assert.Len(t, received, 1)
+
+	handlerErr = errors.New("handler error")
+	assert.Equal(t, http.StatusInternalServerError, send("/myapp.php", signature))
+}
+
+func TestReadRequiresCallbackMetadata(t *testing.T) {
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{
+		"fromNumber": "+15550000000",
+		"accountSid": "accountSid",
+		"authToken":  "authToken",
+	}
+	tw := NewSMS(logger.NewLogger("test"))
+	err := tw.Init(t.Context(), m)
+	require.NoError(t, err)
+	err = tw.Read(t.Context(), nil)
+	require.Error(t, err)
+	require.NoError(t, tw.Close())
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Signature schemes of the webhooks.
+	schemeGitHub = "github"
+	schemeStripe = "stripe"
+	schemeSlack  = "slack"
+	schemeHMAC   = "hmac"
+	schemeNone   = "none"
+
+	githubSignatureHeader = "X-Hub-Signature-256"
+	stripeSignatureHeader = "Stripe-Signature"
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+
+	defaultSignatureHeader = "X-Signature"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// verifier verifies the signature of webhooks.
+type verifier interface {
+	verify(header http.Header, body []byte) error
+}
+
+// newVerifier returns the verifier of the signature scheme of the metadata.
+func newVerifier(m *webhookMetadata) (verifier, error) {
+	scheme := strings.ToLower(m.SignatureScheme)
+	if scheme == schemeNone {
+		return noneVerifier{}, nil
+	}
+	if scheme == "" {
+		return nil, errors.New("signatureScheme is required: use none to accept webhooks without signature")
+	}
+	if m.Secret == "" {
+		return nil, errors.New("secret is required to verify signatures")
+	}
+
+	switch scheme {
+	case schemeGitHub:
+		return &hmacVerifier{
+			secret:   []byte(m.Secret),
+			header:   githubSignatureHeader,
+			hash:     sha256.New,
+			encoding: "hex",
+			prefix:   "sha256=",
+		}, nil
+	case schemeStripe:
+		return &stripeVerifier{secret: []byte(m.Secret), tolerance: m.Tolerance}, nil
+	case schemeSlack:
+		return &slackVerifier{secret: []byte(m.Secret), tolerance: m.Tolerance}, nil
+	case schemeHMAC:
+		v := &hmacVerifier{
+			secret:   []byte(m.Secret),
+			header:   m.SignatureHeader,
+			encoding: strings.ToLower(m.SignatureEncoding),
+			prefix:   m.SignaturePrefix,
+		}
+		if v.header == "" {
+			v.header = defaultSignatureHeader
+		}
+		switch strings.ToLower(m.SignatureAlgorithm) {
+		case "sha1":
+			v.hash = sha1.New
+		case "sha256", "":
+			v.hash = sha256.New
+		case "sha512":
+			v.hash = sha512.New
+		default:
+			return nil, fmt.Errorf("invalid signatureAlgorithm: %s", m.SignatureAlgorithm)
+		}
+		switch v.encoding {
+		case "":
+			v.encoding = "hex"
+		case "hex", "base64":
+		default:
+			return nil, fmt.Errorf("invalid signatureEncoding: %s", m.SignatureEncoding)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("invalid signatureScheme: %s", m.SignatureScheme)
+	}
+}
+
+type noneVerifier struct{}
+
+func (noneVerifier) verify(http.Header, []byte) error {
+	return nil
+}
+
+// hmacVerifier verifies signatures that are the HMAC of the body, in a header.
+// See: https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+type hmacVerifier struct {
+	secret   []byte
+	header   string
+	hash     func() hash.Hash
+	encoding string
+	prefix   string
+}
+
+func (v *hmacVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(v.header)
+	if value == "" {
+		return fmt.Errorf("missing %s header", v.header)
+	}
+	signature, ok := strings.CutPrefix(value, v.prefix)
+	if !ok {
+		return errInvalidSignature
+	}
+
+	var (
+		expected []byte
+		err      error
+	)
+	if v.encoding == "base64" {
+		expected, err = base64.StdEncoding.DecodeString(signature)
+	} else {
+		expected, err = hex.DecodeString(signature)
+	}
+	if err != nil || !hmac.Equal(expected, computeHMAC(v.hash, v.secret, body)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// stripeVerifier verifies the signatures of Stripe: the header has the timestamp of the webhook and one or more
+// signatures, which are the HMAC-SHA256 of the timestamp and the body.
+// See: https://docs.stripe.com/webhooks#verify-manually
+type stripeVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+}
+
+func (v *stripeVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(stripeSignatureHeader)
+	if value == "" {
+		return fmt.Errorf("missing %s header", stripeSignatureHeader)
+	}
+
+	var (
+		timestamp  string
+		signatures [][]byte
+	)
+	for _, item := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch k {
+		case "t":
+			timestamp = v
+		case "v1":
+			signature, err := hex.DecodeString(v)
+			if err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	err := checkTimestamp(timestamp, v.tolerance)
+	if err != nil {
+		return err
+	}
+
+	expected := computeHMAC(sha256.New, v.secret, []byte(timestamp+"."), body)
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}
+
+// slackVerifier verifies the signatures of Slack: the HMAC-SHA256 of the version, the timestamp of the webhook and the
+// body.
+// See: https://api.slack.com/authentication/verifying-requests-from-slack
+type slackVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+}
+
+func (v *slackVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(slackSignatureHeader)
+	if value == "" {
+		return fmt.Errorf("missing %s header", slackSignatureHeader)
+	}
+	timestamp := header.Get(slackTimestampHeader)
+	err := checkTimestamp(timestamp, v.tolerance)
+	if err != nil {
+		return err
+	}
+
+	signature, ok := strings.CutPrefix(value, "v0=")
+	if !ok {
+		return errInvalidSignature
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, computeHMAC(sha256.New, v.secret, []byte("v0:"+timestamp+":"), body)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// checkTimestamp checks that a Unix timestamp is within the tolerance of the current time, if any.
+func checkTimestamp(timestamp string, tolerance time.Duration) error {
+	if timestamp == "" {
+		return errors.New("missing timestamp")
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+	if tolerance == 0 {
+		return nil
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("timestamp %s is outside of the tolerance", timestamp)
+	}
+	return nil
+}
+
+func computeHMAC(h func() hash.Hash, secret []byte, data ...[]byte) []byte {
+	mac := hmac.New(h, secret)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
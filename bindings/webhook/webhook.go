@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultPath        = "/"
+	defaultMaxBodySize = 1 << 20 // 1 MB
+	defaultTolerance   = 5 * time.Minute
+
+	// Metadata of the requests delivered to the app, in addition to their headers.
+	metadataMethod = "method"
+	metadataPath   = "path"
+)
+
+// Webhook is an input binding receiving webhooks, and delivering them to the app once their signature is verified.
+type Webhook struct {
+	metadata webhookMetadata
+	verifier verifier
+	logger   logger.Logger
+	closeCh  chan struct{}
+	closed   atomic.Bool
+	wg       sync.WaitGroup
+}
+
+type webhookMetadata struct {
+	// Port the server listens on.
+	Port int `mapstructure:"port"`
+	// Path webhooks are received on: requests to other paths are rejected.
+	Path string `mapstructure:"path"`
+	// Scheme of the signature of the webhooks: github, stripe, slack, hmac or none.
+	SignatureScheme string `mapstructure:"signatureScheme"`
+	// Secret shared with the sender, signing the webhooks.
+	Secret string `mapstructure:"secret"`
+	// hmac scheme: header with the signature.
+	SignatureHeader string `mapstructure:"signatureHeader"`
+	// hmac scheme: hash function of the HMAC: sha1, sha256 or sha512.
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+	// hmac scheme: encoding of the signature: hex or base64.
+	SignatureEncoding string `mapstructure:"signatureEncoding"`
+	// hmac scheme: prefix of the signature in the header, such as "sha256=".
+	SignaturePrefix string `mapstructure:"signaturePrefix"`
+	// stripe and slack schemes: maximum age of the timestamp of the webhooks, protecting against replays; 0 disables
+	// the check.
+	Tolerance time.Duration `mapstructure:"tolerance"`
+	// Maximum size of the body of the webhooks, in bytes.
+	MaxBodySize int64 `mapstructure:"maxBodySize"`
+}
+
+// NewWebhook returns a new webhook input binding.
+func NewWebhook(logger logger.Logger) bindings.InputBinding {
+	return &Webhook{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (w *Webhook) Init(_ context.Context, meta bindings.Metadata) error {
+	err := w.parseMetadata(meta)
+	if err != nil {
+		return fmt.Errorf("webhook binding error: %w", err)
+	}
+
+	w.verifier, err = newVerifier(&w.metadata)
+	if err != nil {
+		return fmt.Errorf("webhook binding error: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Webhook) parseMetadata(meta bindings.Metadata) error {
+	// Set default values
+	w.metadata = webhookMetadata{
+		Path:        defaultPath,
+		Tolerance:   defaultTolerance,
+		MaxBodySize: defaultMaxBodySize,
+	}
+
+	// Decode
+	err := kitmd.DecodeMetadata(meta.Properties, &w.metadata)
+	if err != nil {
+		return err
+	}
+
+	// Validate
+	if w.metadata.Port <= 0 || w.metadata.Port > 65535 {
+		return errors.New("port is missing or invalid in metadata")
+	}
+	if !strings.HasPrefix(w.metadata.Path, "/") {
+		w.metadata.Path = "/" + w.metadata.Path
+	}
+	if w.metadata.MaxBodySize <= 0 {
+		w.metadata.MaxBodySize = defaultMaxBodySize
+	}
+	if w.metadata.Tolerance < 0 {
+		return errors.New("tolerance must not be negative")
+	}
+
+	return nil
+}
+
+// Read starts the server receiving the webhooks.
+// Each webhook with a valid signature is delivered to the handler with its body as data, and its headers, method and
+// path as metadata; the response of the handler is the body of the response to the sender.
+// Webhooks with an invalid signature are rejected with a 401 status code, and the ones the handler fails to process
+// with a 500 status code, so senders retry them.
+func (w *Webhook) Read(ctx context.Context, handler bindings.Handler) error {
+	if w.closed.Load() {
+		return errors.New("binding is closed")
+	}
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(w.metadata.Port))
+	if err != nil {
+		return fmt.Errorf("webhook binding error: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           w.webhookHandler(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Run the server in background
+	w.wg.Add(2)
+	go func() {
+		defer w.wg.Done()
+		w.logger.Infof("Listening for webhooks on port %d, path %s", w.metadata.Port, w.metadata.Path)
+		srvErr := srv.Serve(listener)
+		if srvErr != nil && !errors.Is(srvErr, http.ErrServerClosed) {
+			w.logger.Errorf("Error serving webhooks: %v", srvErr)
+		}
+	}()
+	// Close the server when context is canceled or binding closed.
+	go func() {
+		defer w.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-w.closeCh:
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srvErr := srv.Shutdown(shutdownCtx)
+		if srvErr != nil {
+			w.logger.Errorf("Error shutting down server: %v", srvErr)
+		}
+	}()
+
+	return nil
+}
+
+// webhookHandler returns the handler of the webhooks.
+func (w *Webhook) webhookHandler(handler bindings.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != w.metadata.Path {
+			http.NotFound(rw, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, w.metadata.MaxBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(rw, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		err = w.verifier.verify(r.Header, body)
+		if err != nil {
+			w.logger.Warnf("Rejected webhook from %s: %v", r.RemoteAddr, err)
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		md := make(map[string]string, len(r.Header)+2)
+		for k := range r.Header {
+			md[k] = r.Header.Get(k)
+		}
+		md[metadataMethod] = r.Method
+		md[metadataPath] = r.URL.Path
+
+		res, err := handler(r.Context(), &bindings.ReadResponse{
+			Data:     body,
+			Metadata: md,
+		})
+		if err != nil {
+			w.logger.Errorf("Error handling webhook: %v", err)
+			http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if len(res) == 0 {
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		rw.Header().Set("Content-Type", http.DetectContentType(res))
+		rw.Write(res)
+	})
+}
+
+func (w *Webhook) Close() error {
+	if w.closed.CompareAndSwap(false, true) {
+		close(w.closeCh)
+	}
+	w.wg.Wait()
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (w *Webhook) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := webhookMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
+	return
+}
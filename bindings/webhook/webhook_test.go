@@ -0,0 +1,332 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	testSecret = "s3cr3t"
+	testBody   = `{"action":"opened","number":1}`
+)
+
+func newTestWebhook(t *testing.T, properties map[string]string) *Webhook {
+	t.Helper()
+
+	w := NewWebhook(logger.NewLogger("test")).(*Webhook)
+	err := w.Init(context.Background(), bindings.Metadata{Base: contribMetadata.Base{Properties: properties}})
+	require.NoError(t, err)
+	return w
+}
+
+func sign(secret string, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("parse metadata", func(t *testing.T) {
+		w := newTestWebhook(t, map[string]string{
+			"port":            "8080",
+			"path":            "hooks/github",
+			"signatureScheme": "GitHub",
+			"secret":          testSecret,
+			"tolerance":       "1m",
+			"maxBodySize":     "1024",
+		})
+
+		assert.Equal(t, 8080, w.metadata.Port)
+		assert.Equal(t, "/hooks/github", w.metadata.Path)
+		assert.Equal(t, time.Minute, w.metadata.Tolerance)
+		assert.Equal(t, int64(1024), w.metadata.MaxBodySize)
+		assert.IsType(t, &hmacVerifier{}, w.verifier)
+	})
+
+	t.Run("default values", func(t *testing.T) {
+		w := newTestWebhook(t, map[string]string{"port": "8080", "signatureScheme": "none"})
+
+		assert.Equal(t, defaultPath, w.metadata.Path)
+		assert.Equal(t, defaultTolerance, w.metadata.Tolerance)
+		assert.Equal(t, int64(defaultMaxBodySize), w.metadata.MaxBodySize)
+		assert.IsType(t, noneVerifier{}, w.verifier)
+	})
+
+	tests := map[string]struct {
+		properties map[string]string
+		err        string
+	}{
+		"no port": {
+			properties: map[string]string{"signatureScheme": "none"},
+			err:        "port is missing or invalid in metadata",
+		},
+		"no scheme": {
+			properties: map[string]string{"port": "8080"},
+			err:        "signatureScheme is required",
+		},
+		"invalid scheme": {
+			properties: map[string]string{"port": "8080", "signatureScheme": "jwt", "secret": testSecret},
+			err:        "invalid signatureScheme: jwt",
+		},
+		"no secret": {
+			properties: map[string]string{"port": "8080", "signatureScheme": "stripe"},
+			err:        "secret is required",
+		},
+		"invalid algorithm": {
+			properties: map[string]string{"port": "8080", "signatureScheme": "hmac", "secret": testSecret, "signatureAlgorithm": "md5"},
+			err:        "invalid signatureAlgorithm: md5",
+		},
+		"invalid encoding": {
+			properties: map[string]string{"port": "8080", "signatureScheme": "hmac", "secret": testSecret, "signatureEncoding": "base32"},
+			err:        "invalid signatureEncoding: base32",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := NewWebhook(logger.NewLogger("test")).(*Webhook)
+			err := w.Init(context.Background(), bindings.Metadata{Base: contribMetadata.Base{Properties: tc.properties}})
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	sha1Mac := hmac.New(sha1.New, []byte(testSecret))
+	sha1Mac.Write([]byte(testBody))
+	sha1Signature := base64.StdEncoding.EncodeToString(sha1Mac.Sum(nil))
+
+	tests := map[string]struct {
+		properties map[string]string
+		header     http.Header
+		err        string
+	}{
+		"github": {
+			properties: map[string]string{"signatureScheme": "github"},
+			header:     http.Header{"X-Hub-Signature-256": {"sha256=" + sign(testSecret, testBody)}},
+		},
+		"github wrong secret": {
+			properties: map[string]string{"signatureScheme": "github"},
+			header:     http.Header{"X-Hub-Signature-256": {"sha256=" + sign("other", testBody)}},
+			err:        "invalid signature",
+		},
+		"github missing header": {
+			properties: map[string]string{"signatureScheme": "github"},
+			header:     http.Header{},
+			err:        "missing X-Hub-Signature-256 header",
+		},
+		"stripe": {
+			properties: map[string]string{"signatureScheme": "stripe"},
+			header:     http.Header{"Stripe-Signature": {"t=" + now + ",v1=" + sign("old", now+"."+testBody) + ",v1=" + sign(testSecret, now+"."+testBody)}},
+		},
+		"stripe wrong timestamp": {
+			properties: map[string]string{"signatureScheme": "stripe"},
+			header:     http.Header{"Stripe-Signature": {"t=" + now + ",v1=" + sign(testSecret, old+"."+testBody)}},
+			err:        "invalid signature",
+		},
+		"stripe replayed": {
+			properties: map[string]string{"signatureScheme": "stripe"},
+			header:     http.Header{"Stripe-Signature": {"t=" + old + ",v1=" + sign(testSecret, old+"."+testBody)}},
+			err:        "outside of the tolerance",
+		},
+		"stripe no tolerance": {
+			properties: map[string]string{"signatureScheme": "stripe", "tolerance": "0"},
+			header:     http.Header{"Stripe-Signature": {"t=" + old + ",v1=" + sign(testSecret, old+"."+testBody)}},
+		},
+		"slack": {
+			properties: map[string]string{"signatureScheme": "slack"},
+			header: http.Header{
+				"X-Slack-Signature":         {"v0=" + sign(testSecret, "v0:"+now+":"+testBody)},
+				"X-Slack-Request-Timestamp": {now},
+			},
+		},
+		"slack missing timestamp": {
+			properties: map[string]string{"signatureScheme": "slack"},
+			header: http.Header{
+				"X-Slack-Signature": {"v0=" + sign(testSecret, "v0:"+now+":"+testBody)},
+			},
+			err: "missing timestamp",
+		},
+		"hmac": {
+			properties: map[string]string{"signatureScheme": "hmac"},
+			header:     http.Header{"X-Signature": {sign(testSecret, testBody)}},
+		},
+		"hmac custom": {
+			properties: map[string]string{
+				"signatureScheme":    "hmac",
+				"signatureHeader":    "X-Shopify-Hmac-Sha1",
+				"signatureAlgorithm": "sha1",
+				"signatureEncoding":  "base64",
+				"signaturePrefix":    "sha1=",
+			},
+			header: http.Header{"X-Shopify-Hmac-Sha1": {"sha1=" + sha1Signature}},
+		},
+		"hmac missing prefix": {
+			properties: map[string]string{"signatureScheme": "hmac", "signaturePrefix": "sha256="},
+			header:     http.Header{"X-Signature": {sign(testSecret, testBody)}},
+			err:        "invalid signature",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.properties["port"] = "8080"
+			tc.properties["secret"] = testSecret
+			w := newTestWebhook(t, tc.properties)
+
+			err := w.verifier.verify(tc.header, []byte(testBody))
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	w := newTestWebhook(t, map[string]string{
+		"port":            "8080",
+		"path":            "/hooks/github",
+		"signatureScheme": "github",
+		"secret":          testSecret,
+		"maxBodySize":     "64",
+	})
+
+	var received *bindings.ReadResponse
+	handlerErr := error(nil)
+	handlerRes := []byte(nil)
+	handler := w.webhookHandler(func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		received = res
+		return handlerRes, handlerErr
+	})
+
+	send := func(method string, path string, body string, signature string) *httptest.ResponseRecorder {
+		received = nil
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Hub-Signature-256", "sha256="+signature)
+		req.Header.Set("X-GitHub-Event", "pull_request")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("valid webhook", func(t *testing.T) {
+		rec := send(http.MethodPost, "/hooks/github", testBody, sign(testSecret, testBody))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		require.NotNil(t, received)
+		assert.Equal(t, testBody, string(received.Data))
+		assert.Equal(t, "pull_request", received.Metadata["X-Github-Event"])
+		assert.Equal(t, http.MethodPost, received.Metadata[metadataMethod])
+		assert.Equal(t, "/hooks/github", received.Metadata[metadataPath])
+	})
+
+	t.Run("response of the app", func(t *testing.T) {
+		handlerRes = []byte(`{"challenge":"abc"}`)
+		defer func() { handlerRes = nil }()
+
+		rec := send(http.MethodPost, "/hooks/github", testBody, sign(testSecret, testBody))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"challenge":"abc"}`, rec.Body.String())
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		rec := send(http.MethodPost, "/hooks/github", testBody, sign("other", testBody))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Nil(t, received)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		handlerErr = errors.New("failed")
+		defer func() { handlerErr = nil }()
+
+		rec := send(http.MethodPost, "/hooks/github", testBody, sign(testSecret, testBody))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("wrong path or method", func(t *testing.T) {
+		rec := send(http.MethodPost, "/hooks/stripe", testBody, sign(testSecret, testBody))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = send(http.MethodGet, "/hooks/github", "", sign(testSecret, ""))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Nil(t, received)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		body := strings.Repeat("a", 65)
+		rec := send(http.MethodPost, "/hooks/github", body, sign(testSecret, body))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Nil(t, received)
+	})
+}
+
+func TestRead(t *testing.T) {
+	// Reserve a free port
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	w := newTestWebhook(t, map[string]string{
+		"port":            strconv.Itoa(port),
+		"signatureScheme": "hmac",
+		"secret":          testSecret,
+	})
+	received := make(chan []byte, 1)
+	err = w.Read(context.Background(), func(_ context.Context, res *bindings.ReadResponse) ([]byte, error) {
+		received <- res.Data
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/", port)
+	var res *http.Response
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(testBody))
+		req.Header.Set("X-Signature", sign(testSecret, testBody))
+		res, err = http.DefaultClient.Do(req)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, testBody, string(<-received))
+
+	require.NoError(t, w.Close())
+	_, err = http.Post(url, "application/json", strings.NewReader(testBody))
+	require.Error(t, err)
+	require.Error(t, w.Read(context.Background(), nil))
+}
@@ -17,7 +17,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"reflect"
+	"strings"
 
 	"github.com/camunda/zeebe/clients/go/v8/pkg/zbc"
 
@@ -25,6 +28,7 @@ import (
 	"github.com/dapr/components-contrib/bindings/zeebe"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
 )
 
 const (
@@ -42,6 +46,11 @@ const (
 	FailJobOperation          bindings.OperationKind = "fail-job"
 	UpdateJobRetriesOperation bindings.OperationKind = "update-job-retries"
 	ThrowErrorOperation       bindings.OperationKind = "throw-error"
+	EvaluateDecisionOperation bindings.OperationKind = "evaluate-decision"
+	AssignUserTaskOperation   bindings.OperationKind = "assign-user-task"
+	UnassignUserTaskOperation bindings.OperationKind = "unassign-user-task"
+	CompleteUserTaskOperation bindings.OperationKind = "complete-user-task"
+	UpdateUserTaskOperation   bindings.OperationKind = "update-user-task"
 )
 
 var (
@@ -51,11 +60,20 @@ var (
 	}
 )
 
+type commandMetadata struct {
+	zeebe.ClientMetadata `mapstructure:",squash"`
+	// Address of the REST API of the gateway, used by the user task operations.
+	RestAddress string `mapstructure:"restAddress"`
+}
+
 // ZeebeCommand executes Zeebe commands.
 type ZeebeCommand struct {
-	clientFactory zeebe.ClientFactory
-	client        zbc.Client
-	logger        logger.Logger
+	clientFactory       zeebe.ClientFactory
+	client              zbc.Client
+	metadata            commandMetadata
+	httpClient          *http.Client
+	credentialsProvider zbc.CredentialsProvider
+	logger              logger.Logger
 }
 
 // NewZeebeCommand returns a new ZeebeCommand instance.
@@ -72,6 +90,22 @@ func (z *ZeebeCommand) Init(ctx context.Context, metadata bindings.Metadata) err
 
 	z.client = client
 
+	err = kitmd.DecodeMetadata(metadata.Properties, &z.metadata)
+	if err != nil {
+		return err
+	}
+	z.httpClient = http.DefaultClient
+
+	// Like the gRPC client, the REST API is authenticated with OAuth when the client ID is set in the environment
+	if z.metadata.RestAddress != "" && os.Getenv(zbc.OAuthClientIdEnvVar) != "" {
+		z.credentialsProvider, err = zbc.NewOAuthCredentialsProvider(&zbc.OAuthProviderConfig{
+			Audience: strings.Split(z.metadata.GatewayAddr, ":")[0],
+		})
+		if err != nil {
+			return fmt.Errorf("cannot create OAuth credentials provider: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -90,6 +124,11 @@ func (z *ZeebeCommand) Operations() []bindings.OperationKind {
 		FailJobOperation,
 		UpdateJobRetriesOperation,
 		ThrowErrorOperation,
+		EvaluateDecisionOperation,
+		AssignUserTaskOperation,
+		UnassignUserTaskOperation,
+		CompleteUserTaskOperation,
+		UpdateUserTaskOperation,
 	}
 }
 
@@ -119,6 +158,16 @@ func (z *ZeebeCommand) Invoke(ctx context.Context, req *bindings.InvokeRequest)
 		return z.updateJobRetries(ctx, req)
 	case ThrowErrorOperation:
 		return z.throwError(ctx, req)
+	case EvaluateDecisionOperation:
+		return z.evaluateDecision(ctx, req)
+	case AssignUserTaskOperation:
+		return z.assignUserTask(ctx, req)
+	case UnassignUserTaskOperation:
+		return z.unassignUserTask(ctx, req)
+	case CompleteUserTaskOperation:
+		return z.completeUserTask(ctx, req)
+	case UpdateUserTaskOperation:
+		return z.updateUserTask(ctx, req)
 	case bindings.GetOperation:
 		fallthrough
 	case bindings.CreateOperation:
@@ -134,7 +183,7 @@ func (z *ZeebeCommand) Invoke(ctx context.Context, req *bindings.InvokeRequest)
 
 // GetComponentMetadata returns the metadata of the component.
 func (z *ZeebeCommand) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
-	metadataStruct := zeebe.ClientMetadata{}
+	metadataStruct := commandMetadata{}
 	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.BindingType)
 	return
 }
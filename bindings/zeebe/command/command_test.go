@@ -92,7 +92,7 @@ func TestInvoke(t *testing.T) {
 func TestOperations(t *testing.T) {
 	testBinding := ZeebeCommand{logger: logger.NewLogger("test")}
 	operations := testBinding.Operations()
-	require.Len(t, operations, 13)
+	require.Len(t, operations, 18)
 	assert.Equal(t, TopologyOperation, operations[0])
 	assert.Equal(t, DeployProcessOperation, operations[1])
 	assert.Equal(t, DeployResourceOperation, operations[2])
@@ -106,4 +106,9 @@ func TestOperations(t *testing.T) {
 	assert.Equal(t, FailJobOperation, operations[10])
 	assert.Equal(t, UpdateJobRetriesOperation, operations[11])
 	assert.Equal(t, ThrowErrorOperation, operations[12])
+	assert.Equal(t, EvaluateDecisionOperation, operations[13])
+	assert.Equal(t, AssignUserTaskOperation, operations[14])
+	assert.Equal(t, UnassignUserTaskOperation, operations[15])
+	assert.Equal(t, CompleteUserTaskOperation, operations[16])
+	assert.Equal(t, UpdateUserTaskOperation, operations[17])
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/camunda/zeebe/clients/go/v8/pkg/commands"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+var ErrMissingDecision = errors.New("decisionId or decisionKey is a required attribute")
+
+type evaluateDecisionPayload struct {
+	DecisionID  string      `json:"decisionId"`
+	DecisionKey *int64      `json:"decisionKey"`
+	Variables   interface{} `json:"variables"`
+}
+
+func (z *ZeebeCommand) evaluateDecision(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var payload evaluateDecisionPayload
+	err := json.Unmarshal(req.Data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd1 := z.client.NewEvaluateDecisionCommand()
+	var cmd2 commands.EvaluateDecisionCommandStep2
+	var errorDetail string
+
+	if payload.DecisionID != "" {
+		cmd2 = cmd1.DecisionId(payload.DecisionID)
+		errorDetail = "decisionId " + payload.DecisionID
+	} else if payload.DecisionKey != nil {
+		cmd2 = cmd1.DecisionKey(*payload.DecisionKey)
+		errorDetail = fmt.Sprintf("decisionKey %d", *payload.DecisionKey)
+	} else {
+		return nil, ErrMissingDecision
+	}
+
+	if payload.Variables != nil {
+		cmd2, err = cmd2.VariablesFromObject(payload.Variables)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := cmd2.Send(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate decision for %s: %w", errorDetail, err)
+	}
+
+	jsonResponse, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal response to json: %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
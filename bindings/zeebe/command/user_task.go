@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dapr/components-contrib/bindings"
+)
+
+var (
+	ErrMissingUserTaskKey = errors.New("userTaskKey is a required attribute")
+	ErrMissingAssignee    = errors.New("assignee is a required attribute")
+	ErrMissingRestAddress = errors.New("restAddress is required for user task operations")
+)
+
+// User tasks are managed with the REST API of the gateway, as the gRPC API doesn't support them.
+// See: https://docs.camunda.io/docs/apis-tools/zeebe-api-rest/zeebe-api-rest-overview/
+const userTasksPath = "/v1/user-tasks/"
+
+type assignUserTaskPayload struct {
+	UserTaskKey   *int64 `json:"userTaskKey"`
+	Assignee      string `json:"assignee"`
+	AllowOverride *bool  `json:"allowOverride"`
+	Action        string `json:"action"`
+}
+
+type unassignUserTaskPayload struct {
+	UserTaskKey *int64 `json:"userTaskKey"`
+}
+
+type completeUserTaskPayload struct {
+	UserTaskKey *int64      `json:"userTaskKey"`
+	Variables   interface{} `json:"variables"`
+	Action      string      `json:"action"`
+}
+
+type updateUserTaskPayload struct {
+	UserTaskKey     *int64   `json:"userTaskKey"`
+	CandidateGroups []string `json:"candidateGroups"`
+	CandidateUsers  []string `json:"candidateUsers"`
+	DueDate         string   `json:"dueDate"`
+	FollowUpDate    string   `json:"followUpDate"`
+	Action          string   `json:"action"`
+}
+
+// userTaskChangeset holds the attributes of a user task that are updated: nil attributes are left unchanged, and
+// empty ones are cleared.
+type userTaskChangeset struct {
+	CandidateGroups *[]string `json:"candidateGroups,omitempty"`
+	CandidateUsers  *[]string `json:"candidateUsers,omitempty"`
+	DueDate         *string   `json:"dueDate,omitempty"`
+	FollowUpDate    *string   `json:"followUpDate,omitempty"`
+}
+
+// problemDetail is the body of the error responses of the REST API.
+type problemDetail struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+func (z *ZeebeCommand) assignUserTask(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var payload assignUserTaskPayload
+	err := json.Unmarshal(req.Data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserTaskKey == nil {
+		return nil, ErrMissingUserTaskKey
+	}
+	if payload.Assignee == "" {
+		return nil, ErrMissingAssignee
+	}
+
+	body := map[string]interface{}{
+		"assignee": payload.Assignee,
+	}
+	if payload.AllowOverride != nil {
+		body["allowOverride"] = *payload.AllowOverride
+	}
+	if payload.Action != "" {
+		body["action"] = payload.Action
+	}
+
+	err = z.sendUserTaskRequest(ctx, http.MethodPost, *payload.UserTaskKey, "/assignment", body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot assign user task for key %d: %w", *payload.UserTaskKey, err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+func (z *ZeebeCommand) unassignUserTask(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var payload unassignUserTaskPayload
+	err := json.Unmarshal(req.Data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserTaskKey == nil {
+		return nil, ErrMissingUserTaskKey
+	}
+
+	err = z.sendUserTaskRequest(ctx, http.MethodDelete, *payload.UserTaskKey, "/assignee", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unassign user task for key %d: %w", *payload.UserTaskKey, err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+func (z *ZeebeCommand) completeUserTask(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var payload completeUserTaskPayload
+	err := json.Unmarshal(req.Data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserTaskKey == nil {
+		return nil, ErrMissingUserTaskKey
+	}
+
+	body := map[string]interface{}{}
+	if payload.Variables != nil {
+		body["variables"] = payload.Variables
+	}
+	if payload.Action != "" {
+		body["action"] = payload.Action
+	}
+
+	err = z.sendUserTaskRequest(ctx, http.MethodPost, *payload.UserTaskKey, "/completion", body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot complete user task for key %d: %w", *payload.UserTaskKey, err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+func (z *ZeebeCommand) updateUserTask(ctx context.Context, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	// Attributes missing from the request are left unchanged, so they are told apart from empty ones
+	var attributes map[string]json.RawMessage
+	err := json.Unmarshal(req.Data, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	var payload updateUserTaskPayload
+	err = json.Unmarshal(req.Data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.UserTaskKey == nil {
+		return nil, ErrMissingUserTaskKey
+	}
+
+	var changeset userTaskChangeset
+	if _, ok := attributes["candidateGroups"]; ok {
+		changeset.CandidateGroups = nonNil(payload.CandidateGroups)
+	}
+	if _, ok := attributes["candidateUsers"]; ok {
+		changeset.CandidateUsers = nonNil(payload.CandidateUsers)
+	}
+	if _, ok := attributes["dueDate"]; ok {
+		changeset.DueDate = &payload.DueDate
+	}
+	if _, ok := attributes["followUpDate"]; ok {
+		changeset.FollowUpDate = &payload.FollowUpDate
+	}
+
+	body := map[string]interface{}{
+		"changeset": changeset,
+	}
+	if payload.Action != "" {
+		body["action"] = payload.Action
+	}
+
+	err = z.sendUserTaskRequest(ctx, http.MethodPatch, *payload.UserTaskKey, "", body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update user task for key %d: %w", *payload.UserTaskKey, err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+func nonNil(values []string) *[]string {
+	if values == nil {
+		values = []string{}
+	}
+	return &values
+}
+
+// sendUserTaskRequest sends a request to the user task endpoint of the REST API, with the OAuth credentials of the
+// client if configured.
+func (z *ZeebeCommand) sendUserTaskRequest(ctx context.Context, method string, userTaskKey int64, path string, body interface{}) error {
+	if z.metadata.RestAddress == "" {
+		return ErrMissingRestAddress
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	url := strings.TrimSuffix(z.metadata.RestAddress, "/") + userTasksPath + strconv.FormatInt(userTaskKey, 10) + path
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+
+	if z.credentialsProvider != nil {
+		headers := map[string]string{}
+		err = z.credentialsProvider.ApplyCredentials(ctx, headers)
+		if err != nil {
+			return fmt.Errorf("cannot get credentials: %w", err)
+		}
+		for k, v := range headers {
+			httpReq.Header.Set(k, v)
+		}
+	}
+
+	resp, err := z.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var problem problemDetail
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&problem)
+	if problem.Detail != "" {
+		return fmt.Errorf("status code %d: %s: %s", resp.StatusCode, problem.Title, problem.Detail)
+	}
+	return fmt.Errorf("status code %d", resp.StatusCode)
+}
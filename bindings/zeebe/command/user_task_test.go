@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/kit/logger"
+)
+
+func TestUserTasks(t *testing.T) {
+	testLogger := logger.NewLogger("test")
+
+	var method, path, body string
+	status := http.StatusNoContent
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		if status != http.StatusNoContent {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"title": "NOT_FOUND", "detail": "User task with key 1 not found"}`))
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer s.Close()
+
+	cmd := ZeebeCommand{
+		logger:     testLogger,
+		httpClient: s.Client(),
+		metadata:   commandMetadata{RestAddress: s.URL + "/"},
+	}
+	invoke := func(operation bindings.OperationKind, data string) error {
+		_, err := cmd.Invoke(t.Context(), &bindings.InvokeRequest{Data: []byte(data), Operation: operation})
+		return err
+	}
+
+	t.Run("userTaskKey is mandatory", func(t *testing.T) {
+		for _, operation := range []bindings.OperationKind{AssignUserTaskOperation, UnassignUserTaskOperation, CompleteUserTaskOperation, UpdateUserTaskOperation} {
+			require.ErrorIs(t, invoke(operation, `{"assignee": "demo"}`), ErrMissingUserTaskKey)
+		}
+	})
+
+	t.Run("assign a user task", func(t *testing.T) {
+		require.ErrorIs(t, invoke(AssignUserTaskOperation, `{"userTaskKey": 1}`), ErrMissingAssignee)
+
+		require.NoError(t, invoke(AssignUserTaskOperation, `{"userTaskKey": 1, "assignee": "demo", "allowOverride": false}`))
+		assert.Equal(t, http.MethodPost, method)
+		assert.Equal(t, "/v1/user-tasks/1/assignment", path)
+		assert.JSONEq(t, `{"assignee": "demo", "allowOverride": false}`, body)
+	})
+
+	t.Run("unassign a user task", func(t *testing.T) {
+		require.NoError(t, invoke(UnassignUserTaskOperation, `{"userTaskKey": 2}`))
+		assert.Equal(t, http.MethodDelete, method)
+		assert.Equal(t, "/v1/user-tasks/2/assignee", path)
+		assert.Empty(t, body)
+	})
+
+	t.Run("complete a user task", func(t *testing.T) {
+		require.NoError(t, invoke(CompleteUserTaskOperation, `{"userTaskKey": 3, "variables": {"approved": true}}`))
+		assert.Equal(t, http.MethodPost, method)
+		assert.Equal(t, "/v1/user-tasks/3/completion", path)
+		assert.JSONEq(t, `{"variables": {"approved": true}}`, body)
+	})
+
+	t.Run("update a user task", func(t *testing.T) {
+		require.NoError(t, invoke(UpdateUserTaskOperation, `{"userTaskKey": 4, "candidateGroups": ["sales"], "candidateUsers": [], "action": "escalate"}`))
+		assert.Equal(t, http.MethodPatch, method)
+		assert.Equal(t, "/v1/user-tasks/4", path)
+		assert.JSONEq(t, `{"changeset": {"candidateGroups": ["sales"], "candidateUsers": []}, "action": "escalate"}`, body)
+	})
+
+	t.Run("errors of the REST API are returned", func(t *testing.T) {
+		status = http.StatusNotFound
+		err := invoke(CompleteUserTaskOperation, `{"userTaskKey": 1}`)
+		require.ErrorContains(t, err, "status code 404: NOT_FOUND: User task with key 1 not found")
+	})
+
+	t.Run("restAddress is required", func(t *testing.T) {
+		cmd := ZeebeCommand{logger: testLogger}
+		_, err := cmd.Invoke(t.Context(), &bindings.InvokeRequest{Data: []byte(`{"userTaskKey": 1}`), Operation: CompleteUserTaskOperation})
+		require.ErrorIs(t, err, ErrMissingRestAddress)
+	})
+}
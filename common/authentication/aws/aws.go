@@ -89,6 +89,7 @@ type Provider interface {
 	ParameterStore() *ParameterStoreClients
 	Kinesis() *KinesisClients
 	Ses() *SesClients
+	EventBridge() *EventBridgeClients
 	Kafka(KafkaOptions) (*KafkaClients, error)
 
 	// Postgres is an outlier to the others in the sense that we can update only it's config,
@@ -28,6 +28,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -56,6 +58,7 @@ type Clients struct {
 	ParameterStore *ParameterStoreClients
 	kinesis        *KinesisClients
 	ses            *SesClients
+	eventBridge    *EventBridgeClients
 	kafka          *KafkaClients
 }
 
@@ -85,6 +88,8 @@ func (c *Clients) refresh(session *session.Session) error {
 		c.kinesis.New(session)
 	case c.ses != nil:
 		c.ses.New(session)
+	case c.eventBridge != nil:
+		c.eventBridge.New(session)
 	case c.kafka != nil:
 		// Note: we pass in nil for token provider
 		// as there are no special fields for x509 auth for it.
@@ -139,6 +144,11 @@ type SesClients struct {
 	Ses *ses.SES
 }
 
+type EventBridgeClients struct {
+	EventBridge eventbridgeiface.EventBridgeAPI
+	Sqs         sqsiface.SQSAPI
+}
+
 type KafkaClients struct {
 	config          *sarama.Config
 	consumerGroup   *string
@@ -233,6 +243,11 @@ func (c *SesClients) New(session *session.Session) {
 	c.Ses = ses.New(session, session.Config)
 }
 
+func (c *EventBridgeClients) New(session *session.Session) {
+	c.EventBridge = eventbridge.New(session, session.Config)
+	c.Sqs = sqs.New(session, session.Config)
+}
+
 type KafkaOptions struct {
 	Config          *sarama.Config
 	ConsumerGroup   string
@@ -291,6 +306,10 @@ func (c *KafkaClients) New(session *session.Session, tokenProvider *mskTokenProv
 }
 
 // Kafka specific
+
+// MSK IAM tokens are regenerated this long before they expire.
+const mskTokenRefreshBuffer = time.Minute
+
 type mskTokenProvider struct {
 	generateTokenTimeout time.Duration
 	accessKey            string
@@ -299,9 +318,33 @@ type mskTokenProvider struct {
 	awsIamRoleArn        string
 	awsStsSessionName    string
 	region               string
+
+	lock      sync.Mutex
+	token     string
+	expiresOn time.Time
 }
 
+// Token returns the cached token, or generates a new one if it is about to expire.
+// Sarama requests a token every time it authenticates a connection to a broker.
 func (m *mskTokenProvider) Token() (*sarama.AccessToken, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.token != "" && time.Now().Add(mskTokenRefreshBuffer).Before(m.expiresOn) {
+		return &sarama.AccessToken{Token: m.token}, nil
+	}
+
+	token, expirationMs, err := m.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	m.token = token
+	m.expiresOn = time.UnixMilli(expirationMs)
+	return &sarama.AccessToken{Token: token}, nil
+}
+
+func (m *mskTokenProvider) generateToken() (string, int64, error) {
 	// this function can't use the context passed on Init because that context would be cancelled right after Init
 	ctx, cancel := context.WithTimeout(context.Background(), m.generateTokenTimeout)
 	defer cancel()
@@ -309,21 +352,18 @@ func (m *mskTokenProvider) Token() (*sarama.AccessToken, error) {
 	switch {
 	// we must first check if we are using the assume role auth profile
 	case m.awsIamRoleArn != "" && m.awsStsSessionName != "":
-		token, _, err := signer.GenerateAuthTokenFromRole(ctx, m.region, m.awsIamRoleArn, m.awsStsSessionName)
-		return &sarama.AccessToken{Token: token}, err
+		return signer.GenerateAuthTokenFromRole(ctx, m.region, m.awsIamRoleArn, m.awsStsSessionName)
 	case m.accessKey != "" && m.secretKey != "":
-		token, _, err := signer.GenerateAuthTokenFromCredentialsProvider(ctx, m.region, aws2.CredentialsProviderFunc(func(ctx context.Context) (aws2.Credentials, error) {
+		return signer.GenerateAuthTokenFromCredentialsProvider(ctx, m.region, aws2.CredentialsProviderFunc(func(ctx context.Context) (aws2.Credentials, error) {
 			return aws2.Credentials{
 				AccessKeyID:     m.accessKey,
 				SecretAccessKey: m.secretKey,
 				SessionToken:    m.sessionToken,
 			}, nil
 		}))
-		return &sarama.AccessToken{Token: token}, err
 
 	default: // load default aws creds
-		token, _, err := signer.GenerateAuthToken(ctx, m.region)
-		return &sarama.AccessToken{Token: token}, err
+		return signer.GenerateAuthToken(ctx, m.region)
 	}
 }
 
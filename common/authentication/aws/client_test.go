@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -263,3 +264,32 @@ func TestKinesisClients_WorkerCfg(t *testing.T) {
 		})
 	}
 }
+
+func TestMskTokenProvider_Token(t *testing.T) {
+	m := &mskTokenProvider{
+		generateTokenTimeout: 10 * time.Second,
+		accessKey:            "accessKey",
+		secretKey:            "secretKey",
+		region:               "us-east-1",
+	}
+
+	t.Run("token is generated and cached until it expires", func(t *testing.T) {
+		token, err := m.Token()
+		require.NoError(t, err)
+		assert.NotEmpty(t, token.Token)
+		assert.True(t, m.expiresOn.After(time.Now()))
+
+		m.token = "cached"
+		token, err = m.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "cached", token.Token)
+	})
+
+	t.Run("token is regenerated before it expires", func(t *testing.T) {
+		m.token = "cached"
+		m.expiresOn = time.Now().Add(mskTokenRefreshBuffer / 2)
+		token, err := m.Token()
+		require.NoError(t, err)
+		assert.NotEqual(t, "cached", token.Token)
+	})
+}
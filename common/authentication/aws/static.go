@@ -234,6 +234,20 @@ func (a *StaticAuth) Ses() *SesClients {
 	return a.clients.ses
 }
 
+func (a *StaticAuth) EventBridge() *EventBridgeClients {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.clients.eventBridge != nil {
+		return a.clients.eventBridge
+	}
+
+	clients := EventBridgeClients{}
+	a.clients.eventBridge = &clients
+	a.clients.eventBridge.New(a.session)
+	return a.clients.eventBridge
+}
+
 func (a *StaticAuth) UpdatePostgres(ctx context.Context, poolConfig *pgxpool.Config) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -308,6 +308,20 @@ func (a *x509) Ses() *SesClients {
 	return a.clients.ses
 }
 
+func (a *x509) EventBridge() *EventBridgeClients {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.clients.eventBridge != nil {
+		return a.clients.eventBridge
+	}
+
+	clients := EventBridgeClients{}
+	a.clients.eventBridge = &clients
+	a.clients.eventBridge.New(a.session)
+	return a.clients.eventBridge
+}
+
 // https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/UsingWithRDS.IAMDBAuth.Connecting.Go.html
 func (a *x509) getDatabaseToken(ctx context.Context, poolConfig *pgxpool.Config) (string, error) {
 	dbEndpoint := poolConfig.ConnConfig.Host + ":" + strconv.Itoa(int(poolConfig.ConnConfig.Port))
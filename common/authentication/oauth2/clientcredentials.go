@@ -126,18 +126,52 @@ func (c *ClientCredentialsOptions) toConfig() (*ccreds.Config, *http.Client, err
 }
 
 func (c *ClientCredentials) Token() (string, error) {
+	token, err := c.validToken()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// TokenWithExpiry returns the current token, fetching a new one if it has expired, and the time it expires at.
+// The expiry time is zero if the token doesn't expire.
+func (c *ClientCredentials) TokenWithExpiry() (string, time.Time, error) {
+	token, err := c.validToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token.AccessToken, token.Expiry, nil
+}
+
+// RenewToken fetches a new token, even if the current one is still valid.
+// This allows replacing tokens before they expire.
+func (c *ClientCredentials) RenewToken(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.fetchToken(ctx)
+}
+
+func (c *ClientCredentials) validToken() (*oauth2.Token, error) {
 	c.lock.RLock()
-	defer c.lock.RUnlock()
+	token := c.currentToken
+	c.lock.RUnlock()
 
-	if !c.currentToken.Valid() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-		defer cancel()
-		if err := c.renewToken(ctx); err != nil {
-			return "", err
-		}
+	if token.Valid() {
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if err := c.renewToken(ctx); err != nil {
+		return nil, err
 	}
 
-	return c.currentToken.AccessToken, nil
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.currentToken, nil
 }
 
 func (c *ClientCredentials) renewToken(ctx context.Context) error {
@@ -151,12 +185,17 @@ func (c *ClientCredentials) renewToken(ctx context.Context) error {
 		return nil
 	}
 
+	return c.fetchToken(ctx)
+}
+
+// this function call should be wrapped by lock.
+func (c *ClientCredentials) fetchToken(ctx context.Context) error {
 	token, err := c.fetchTokenFn(context.WithValue(ctx, oauth2.HTTPClient, c.httpClient))
 	if err != nil {
 		return err
 	}
 
-	if !c.currentToken.Valid() {
+	if !token.Valid() {
 		return errors.New("oauth2 client_credentials token source returned an invalid token")
 	}
 
@@ -14,10 +14,15 @@ limitations under the License.
 package oauth2
 
 import (
+	"context"
 	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 	ccreds "golang.org/x/oauth2/clientcredentials"
 )
 
@@ -93,3 +98,43 @@ func Test_toConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClientCredentialsToken(t *testing.T) {
+	var fetched int
+	expiry := time.Now().Add(time.Hour)
+	cc := &ClientCredentials{
+		currentToken: &oauth2.Token{
+			AccessToken: "expired",
+			Expiry:      time.Now().Add(-time.Minute),
+		},
+		fetchTokenFn: func(context.Context) (*oauth2.Token, error) {
+			fetched++
+			return &oauth2.Token{
+				AccessToken: "token" + strconv.Itoa(fetched),
+				Expiry:      expiry,
+			}, nil
+		},
+	}
+
+	t.Run("expired token is renewed", func(t *testing.T) {
+		token, err := cc.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token1", token)
+
+		token, tokenExpiry, err := cc.TokenWithExpiry()
+		require.NoError(t, err)
+		assert.Equal(t, "token1", token)
+		assert.Equal(t, expiry, tokenExpiry)
+		assert.Equal(t, 1, fetched)
+	})
+
+	t.Run("valid token can be renewed", func(t *testing.T) {
+		err := cc.RenewToken(t.Context())
+		require.NoError(t, err)
+
+		token, err := cc.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token2", token)
+		assert.Equal(t, 2, fetched)
+	})
+}
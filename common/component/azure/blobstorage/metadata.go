@@ -14,10 +14,13 @@ limitations under the License.
 package blobstorage
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 
 	azauth "github.com/dapr/components-contrib/common/authentication/azure"
 	mdutils "github.com/dapr/components-contrib/metadata"
@@ -29,6 +32,21 @@ type BlobStorageMetadata struct {
 	DecodeBase64            bool `json:"decodeBase64,string" mapstructure:"decodeBase64" mdonly:"bindings"`
 	PublicAccessLevel       azblob.PublicAccessType
 	DisableEntityManagement bool `json:"disableEntityManagement,string" mapstructure:"disableEntityManagement"`
+	// Blobs are uploaded in blocks of UploadBlockSize bytes, UploadConcurrency blocks at a time
+	UploadBlockSize   int64 `json:"uploadBlockSize,string" mapstructure:"uploadBlockSize" mdonly:"bindings"`
+	UploadConcurrency int   `json:"uploadConcurrency,string" mapstructure:"uploadConcurrency" mdonly:"bindings"`
+	// Blobs are encrypted client-side with a content key wrapped with the Key Vault key EncryptionKeyID, or with the
+	// key EncryptionKeyName of the JWKS EncryptionJWKS
+	EncryptionKeyID            string `json:"encryptionKeyId" mapstructure:"encryptionKeyId" mdonly:"bindings"`
+	EncryptionJWKS             string `json:"encryptionJWKS" mapstructure:"encryptionJWKS" mdonly:"bindings"`
+	EncryptionKeyName          string `json:"encryptionKeyName" mapstructure:"encryptionKeyName" mdonly:"bindings"`
+	EncryptionKeyWrapAlgorithm string `json:"encryptionKeyWrapAlgorithm" mapstructure:"encryptionKeyWrapAlgorithm" mdonly:"bindings"`
+	// The input binding reads the Blob Created and Deleted events that Event Grid delivers to the storage queue
+	// EventsQueueName, including the content of created blobs of up to EventsContentMaxSize bytes
+	EventsQueueName         string        `json:"eventsQueueName" mapstructure:"eventsQueueName" mdonly:"bindings"`
+	EventsPollingInterval   time.Duration `json:"eventsPollingInterval" mapstructure:"eventsPollingInterval" mdonly:"bindings"`
+	EventsVisibilityTimeout time.Duration `json:"eventsVisibilityTimeout" mapstructure:"eventsVisibilityTimeout" mdonly:"bindings"`
+	EventsContentMaxSize    int64         `json:"eventsContentMaxSize,string" mapstructure:"eventsContentMaxSize" mdonly:"bindings"`
 }
 
 type ContainerClientOpts struct {
@@ -82,6 +100,24 @@ func parseMetadata(meta map[string]string) (*BlobStorageMetadata, error) {
 			m.PublicAccessLevel, azblob.PossiblePublicAccessTypeValues())
 	}
 
+	if m.UploadBlockSize < 0 || m.UploadBlockSize > blockblob.MaxStageBlockBytes {
+		return nil, fmt.Errorf("invalid uploadBlockSize %d: must be between 0 and %d", m.UploadBlockSize, int64(blockblob.MaxStageBlockBytes))
+	}
+	if m.UploadConcurrency < 0 {
+		return nil, fmt.Errorf("invalid uploadConcurrency %d: must not be negative", m.UploadConcurrency)
+	}
+
+	if m.EncryptionKeyID != "" && m.EncryptionJWKS != "" {
+		return nil, errors.New("only one of encryptionKeyId and encryptionJWKS can be set")
+	}
+	if m.EncryptionJWKS != "" && m.EncryptionKeyName == "" {
+		return nil, errors.New("encryptionKeyName is required with encryptionJWKS")
+	}
+
+	if m.EventsPollingInterval < 0 || m.EventsVisibilityTimeout < 0 || m.EventsContentMaxSize < 0 {
+		return nil, errors.New("eventsPollingInterval, eventsVisibilityTimeout and eventsContentMaxSize must not be negative")
+	}
+
 	// we need this key for backwards compatibility
 	if val, ok := meta["getBlobRetryCount"]; ok && val != "" {
 		// convert val from string to int32
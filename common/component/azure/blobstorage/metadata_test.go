@@ -16,6 +16,7 @@ package blobstorage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,71 @@ func TestParseMetadata(t *testing.T) {
 		assert.Equal(t, "", string(meta.PublicAccessLevel))
 	})
 
+	t.Run("parse upload options", func(t *testing.T) {
+		m = map[string]string{
+			"storageAccount":    "account",
+			"container":         "test",
+			"uploadBlockSize":   "8388608",
+			"uploadConcurrency": "4",
+		}
+		meta, err := parseMetadata(m)
+		require.NoError(t, err)
+		assert.Equal(t, int64(8388608), meta.UploadBlockSize)
+		assert.Equal(t, 4, meta.UploadConcurrency)
+
+		m["uploadBlockSize"] = "-1"
+		_, err = parseMetadata(m)
+		require.Error(t, err)
+
+		m["uploadBlockSize"] = "0"
+		m["uploadConcurrency"] = "-1"
+		_, err = parseMetadata(m)
+		require.Error(t, err)
+	})
+
+	t.Run("parse encryption options", func(t *testing.T) {
+		m = map[string]string{
+			"storageAccount":    "account",
+			"container":         "test",
+			"encryptionJWKS":    `{"keys":[]}`,
+			"encryptionKeyName": "mykey",
+		}
+		meta, err := parseMetadata(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"keys":[]}`, meta.EncryptionJWKS)
+		assert.Equal(t, "mykey", meta.EncryptionKeyName)
+
+		m["encryptionKeyId"] = "https://myvault.vault.azure.net/keys/mykey"
+		_, err = parseMetadata(m)
+		require.Error(t, err)
+
+		delete(m, "encryptionKeyId")
+		delete(m, "encryptionKeyName")
+		_, err = parseMetadata(m)
+		require.Error(t, err)
+	})
+
+	t.Run("parse events options", func(t *testing.T) {
+		m = map[string]string{
+			"storageAccount":          "account",
+			"container":               "test",
+			"eventsQueueName":         "blob-events",
+			"eventsPollingInterval":   "5s",
+			"eventsVisibilityTimeout": "1m",
+			"eventsContentMaxSize":    "1024",
+		}
+		meta, err := parseMetadata(m)
+		require.NoError(t, err)
+		assert.Equal(t, "blob-events", meta.EventsQueueName)
+		assert.Equal(t, 5*time.Second, meta.EventsPollingInterval)
+		assert.Equal(t, time.Minute, meta.EventsVisibilityTimeout)
+		assert.Equal(t, int64(1024), meta.EventsContentMaxSize)
+
+		m["eventsContentMaxSize"] = "-1"
+		_, err = parseMetadata(m)
+		require.Error(t, err)
+	})
+
 	t.Run("parse metadata with publicAccessLevel = blob", func(t *testing.T) {
 		m = map[string]string{
 			"storageAccount":    "account",
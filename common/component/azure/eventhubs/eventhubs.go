@@ -70,7 +70,12 @@ type SubscribeConfig struct {
 	MaxBulkSubCount                 int
 	MaxBulkSubAwaitDurationMs       int
 	CheckPointFrequencyPerPartition int
-	Handler                         HandlerFn
+	// CheckPointInterval, if set, also updates the checkpoint of a partition once the interval has elapsed since the last one.
+	CheckPointInterval time.Duration
+	// StartPositions are the positions partitions are consumed from if they don't have a checkpoint.
+	// Partitions start from the latest event if unset.
+	StartPositions azeventhubs.StartPositions
+	Handler        HandlerFn
 }
 
 // NewAzureEventHubs returns a new Azure Event hubs instance.
@@ -131,6 +136,18 @@ func (aeh *AzureEventHubs) GetAllMessageProperties() bool {
 	return aeh.metadata.GetAllMessageProperties
 }
 
+// CheckPointFrequencyPerPartition returns the number of events after which the checkpoint of a partition is updated,
+// as set in the component metadata. It's used by the pubsub only.
+func (aeh *AzureEventHubs) CheckPointFrequencyPerPartition() int {
+	return aeh.metadata.CheckPointFrequencyPerPartition
+}
+
+// CheckPointInterval returns the interval after which the checkpoint of a partition is updated, as set in the
+// component metadata. It's used by the pubsub only.
+func (aeh *AzureEventHubs) CheckPointInterval() time.Duration {
+	return time.Duration(aeh.metadata.CheckPointIntervalInSec) * time.Second
+}
+
 // Publish a batch of messages.
 func (aeh *AzureEventHubs) Publish(ctx context.Context, topic string, messages []*azeventhubs.EventData, batchOpts *azeventhubs.EventDataBatchOptions) error {
 	// Get the producer client
@@ -270,6 +287,8 @@ func (aeh *AzureEventHubs) Subscribe(subscribeCtx context.Context, config Subscr
 		MaxBulkSubCount:                 config.MaxBulkSubCount,
 		MaxBulkSubAwaitDurationMs:       config.MaxBulkSubAwaitDurationMs,
 		CheckPointFrequencyPerPartition: config.CheckPointFrequencyPerPartition,
+		CheckPointInterval:              config.CheckPointInterval,
+		StartPositions:                  config.StartPositions,
 		Handler:                         retryHandler,
 	}
 
@@ -279,7 +298,7 @@ func (aeh *AzureEventHubs) Subscribe(subscribeCtx context.Context, config Subscr
 	go func() {
 		for {
 			// Get the processor client
-			processor, err := aeh.getProcessorForTopic(subscribeCtx, topic)
+			processor, err := aeh.getProcessorForTopic(subscribeCtx, topic, config.StartPositions)
 			if err != nil {
 				aeh.logger.Errorf("error trying to establish a connection: %w", err)
 			} else {
@@ -357,11 +376,24 @@ func (aeh *AzureEventHubs) handleAsync(ctx context.Context, topic string, messag
 	return err
 }
 
-func (aeh *AzureEventHubs) processEvents(subscribeCtx context.Context, partitionClient *azeventhubs.ProcessorPartitionClient, config SubscribeConfig) error {
+func (aeh *AzureEventHubs) processEvents(subscribeCtx context.Context, partitionClient *azeventhubs.ProcessorPartitionClient, config SubscribeConfig) (err error) {
+	checkpoints := newCheckpointTracker(config.CheckPointFrequencyPerPartition, config.CheckPointInterval, time.Now())
+
 	// At the end of the method we need to do some cleanup and close the partition client
 	defer func() {
 		closeCtx, closeCancel := context.WithTimeout(context.Background(), resourceGetTimeout)
 		defer closeCancel()
+
+		// Save the checkpoint of the events received since the last one, unless the partition is now owned by another client
+		if err == nil || errors.Is(err, context.Canceled) {
+			if last := checkpoints.pending(); last != nil {
+				checkpointErr := partitionClient.UpdateCheckpoint(closeCtx, last, nil)
+				if checkpointErr != nil {
+					aeh.logger.Warnf("Failed to update checkpoint of partition %s for topic %s: %v", partitionClient.PartitionID(), config.Topic, checkpointErr)
+				}
+			}
+		}
+
 		closeErr := partitionClient.Close(closeCtx)
 		if closeErr != nil {
 			aeh.logger.Errorf("Error while closing partition client: %v", closeErr)
@@ -369,11 +401,7 @@ func (aeh *AzureEventHubs) processEvents(subscribeCtx context.Context, partition
 	}()
 
 	// Loop to receive messages
-	var (
-		events []*azeventhubs.ReceivedEventData
-		err    error
-	)
-	counter := 0
+	var events []*azeventhubs.ReceivedEventData
 	for {
 		// Maximum duration to wait till bulk message is sent to app is `maxBulkSubAwaitDurationMs`
 		ctx, cancel := context.WithTimeout(subscribeCtx, time.Duration(config.MaxBulkSubAwaitDurationMs)*time.Millisecond)
@@ -388,11 +416,13 @@ func (aeh *AzureEventHubs) processEvents(subscribeCtx context.Context, partition
 			eventHubError := (*azeventhubs.Error)(nil)
 			if errors.As(err, &eventHubError) && eventHubError.Code == azeventhubs.ErrorCodeOwnershipLost {
 				aeh.logger.Debugf("Client lost ownership of partition %s for topic %s", partitionClient.PartitionID(), config.Topic)
+				checkpoints.reset(time.Now())
 				return nil
 			}
 
 			return fmt.Errorf("error receiving events: %w", err)
 		}
+		err = nil
 
 		aeh.logger.Debugf("Received batch with %d events on topic %s, partition %s", len(events), config.Topic, partitionClient.PartitionID())
 
@@ -403,27 +433,74 @@ func (aeh *AzureEventHubs) processEvents(subscribeCtx context.Context, partition
 			} else {
 				go aeh.handleAsync(subscribeCtx, config.Topic, events, config.Handler)
 			}
+			checkpoints.add(events)
+		}
 
-			// Checkpointing disabled for CheckPointFrequencyPerPartition == 0
-			if config.CheckPointFrequencyPerPartition > 0 {
-				// Update checkpoint with frequency of `checkpointFrequencyPerPartition` for a given partition
-				if counter%config.CheckPointFrequencyPerPartition == 0 {
-					// Update the checkpoint with the last event received. If we lose ownership of this partition or have to restart the next owner will start from this point.
-					// This context inherits from the background one in case subscriptionCtx gets canceled
-					ctx, cancel = context.WithTimeout(context.Background(), resourceCreationTimeout)
-					err = partitionClient.UpdateCheckpoint(ctx, events[len(events)-1], nil)
-					cancel()
-					if err != nil {
-						return fmt.Errorf("failed to update checkpoint: %w", err)
-					}
-				}
-				// Update counter
-				counter = (counter + 1) % config.CheckPointFrequencyPerPartition
+		// Update the checkpoint with the last event received. If we lose ownership of this partition or have to restart the next owner will start from this point.
+		// Checking even when no event was received allows checkpointing on the interval while the partition is idle.
+		now := time.Now()
+		if checkpoints.due(now) {
+			// This context inherits from the background one in case subscriptionCtx gets canceled
+			ctx, cancel = context.WithTimeout(context.Background(), resourceCreationTimeout)
+			err = partitionClient.UpdateCheckpoint(ctx, checkpoints.pending(), nil)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to update checkpoint: %w", err)
 			}
+			checkpoints.reset(now)
 		}
 	}
 }
 
+// checkpointTracker keeps track of the events received on a partition since its checkpoint was last updated.
+type checkpointTracker struct {
+	frequency      int
+	interval       time.Duration
+	count          int
+	last           *azeventhubs.ReceivedEventData
+	lastCheckpoint time.Time
+}
+
+// newCheckpointTracker returns a tracker that is due after `frequency` events or once `interval` has elapsed,
+// whichever comes first. Checkpointing is disabled if both are 0.
+func newCheckpointTracker(frequency int, interval time.Duration, now time.Time) *checkpointTracker {
+	return &checkpointTracker{
+		frequency:      frequency,
+		interval:       interval,
+		lastCheckpoint: now,
+	}
+}
+
+func (t *checkpointTracker) add(events []*azeventhubs.ReceivedEventData) {
+	if len(events) == 0 {
+		return
+	}
+	t.count += len(events)
+	t.last = events[len(events)-1]
+}
+
+// pending returns the last event received since the checkpoint was updated, if any.
+func (t *checkpointTracker) pending() *azeventhubs.ReceivedEventData {
+	if t.frequency <= 0 && t.interval <= 0 {
+		return nil
+	}
+	return t.last
+}
+
+func (t *checkpointTracker) due(now time.Time) bool {
+	if t.pending() == nil {
+		return false
+	}
+	return (t.frequency > 0 && t.count >= t.frequency) ||
+		(t.interval > 0 && now.Sub(t.lastCheckpoint) >= t.interval)
+}
+
+func (t *checkpointTracker) reset(now time.Time) {
+	t.count = 0
+	t.last = nil
+	t.lastCheckpoint = now
+}
+
 func (aeh *AzureEventHubs) Close() (err error) {
 	// Acquire locks
 	aeh.checkpointStoreLock.Lock()
@@ -516,7 +593,7 @@ func (aeh *AzureEventHubs) getProducerClientForTopic(ctx context.Context, topic
 }
 
 // Creates a processor for a given topic.
-func (aeh *AzureEventHubs) getProcessorForTopic(ctx context.Context, topic string) (*azeventhubs.Processor, error) {
+func (aeh *AzureEventHubs) getProcessorForTopic(ctx context.Context, topic string, startPositions azeventhubs.StartPositions) (*azeventhubs.Processor, error) {
 	// Get the checkpoint store
 	checkpointStore, err := aeh.getCheckpointStore(ctx)
 	if err != nil {
@@ -573,7 +650,9 @@ func (aeh *AzureEventHubs) getProcessorForTopic(ctx context.Context, topic strin
 	}
 
 	// Create the processor from the consumer client and checkpoint store
-	processor, err := azeventhubs.NewProcessor(consumerClient, checkpointStore, nil)
+	processor, err := azeventhubs.NewProcessor(consumerClient, checkpointStore, &azeventhubs.ProcessorOptions{
+		StartPositions: startPositions,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create the processor: %w", err)
 	}
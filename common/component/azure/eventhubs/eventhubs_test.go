@@ -15,7 +15,9 @@ package eventhubs
 
 import (
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -84,6 +86,28 @@ func TestParseEventHubsMetadata(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, m.EnableInOrderMessageDelivery)
 	})
+
+	t.Run("test checkpoint settings", func(t *testing.T) {
+		m, err := parseEventHubsMetadata(map[string]string{"connectionString": "fake"}, false, testLogger)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultCheckpointFrequencyPerPartition, m.CheckPointFrequencyPerPartition)
+		assert.Equal(t, 0, m.CheckPointIntervalInSec)
+
+		m, err = parseEventHubsMetadata(map[string]string{
+			"connectionString":                "fake",
+			"checkPointFrequencyPerPartition": "0",
+			"checkPointIntervalInSec":         "30",
+		}, false, testLogger)
+		require.NoError(t, err)
+		assert.Equal(t, 0, m.CheckPointFrequencyPerPartition)
+		assert.Equal(t, 30, m.CheckPointIntervalInSec)
+
+		_, err = parseEventHubsMetadata(map[string]string{
+			"connectionString":        "fake",
+			"checkPointIntervalInSec": "-1",
+		}, false, testLogger)
+		require.ErrorContains(t, err, "checkPointIntervalInSec")
+	})
 }
 
 func TestConstructConnectionStringFromTopic(t *testing.T) {
@@ -136,3 +160,58 @@ func TestConstructConnectionStringFromTopic(t *testing.T) {
 		assert.Equal(t, "", c)
 	})
 }
+
+func TestCheckpointTracker(t *testing.T) {
+	start := time.Now()
+	events := func(seq ...int64) []*azeventhubs.ReceivedEventData {
+		res := make([]*azeventhubs.ReceivedEventData, len(seq))
+		for i, s := range seq {
+			res[i] = &azeventhubs.ReceivedEventData{SequenceNumber: s}
+		}
+		return res
+	}
+
+	t.Run("every N events", func(t *testing.T) {
+		tracker := newCheckpointTracker(3, 0, start)
+		assert.False(t, tracker.due(start))
+		tracker.add(events(1, 2))
+		assert.False(t, tracker.due(start.Add(time.Hour)))
+		tracker.add(events(3))
+		require.True(t, tracker.due(start))
+		assert.Equal(t, int64(3), tracker.pending().SequenceNumber)
+
+		tracker.reset(start)
+		assert.Nil(t, tracker.pending())
+		assert.False(t, tracker.due(start))
+	})
+
+	t.Run("every T seconds", func(t *testing.T) {
+		tracker := newCheckpointTracker(0, 10*time.Second, start)
+		tracker.add(events(1, 2, 3, 4))
+		assert.False(t, tracker.due(start.Add(5*time.Second)))
+		require.True(t, tracker.due(start.Add(10*time.Second)))
+		assert.Equal(t, int64(4), tracker.pending().SequenceNumber)
+
+		// Nothing to checkpoint while idle
+		tracker.reset(start.Add(10 * time.Second))
+		assert.False(t, tracker.due(start.Add(time.Hour)))
+	})
+
+	t.Run("whichever comes first", func(t *testing.T) {
+		tracker := newCheckpointTracker(100, 10*time.Second, start)
+		tracker.add(events(1))
+		assert.False(t, tracker.due(start.Add(time.Second)))
+		assert.True(t, tracker.due(start.Add(10*time.Second)))
+		for i := int64(2); i <= 100; i++ {
+			tracker.add(events(i))
+		}
+		assert.True(t, tracker.due(start.Add(time.Second)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tracker := newCheckpointTracker(0, 0, start)
+		tracker.add(events(1))
+		assert.False(t, tracker.due(start.Add(time.Hour)))
+		assert.Nil(t, tracker.pending())
+	})
+}
@@ -41,6 +41,10 @@ type AzureEventHubsMetadata struct {
 	EnableInOrderMessageDelivery bool   `json:"enableInOrderMessageDelivery,string" mapstructure:"enableInOrderMessageDelivery"`
 	GetAllMessageProperties      bool   `json:"getAllMessageProperties,string" mapstructure:"getAllMessageProperties"`
 
+	// PubSub only
+	CheckPointFrequencyPerPartition int `json:"checkPointFrequencyPerPartition,string" mapstructure:"checkPointFrequencyPerPartition" mdonly:"pubsub"`
+	CheckPointIntervalInSec         int `json:"checkPointIntervalInSec,string" mapstructure:"checkPointIntervalInSec" mdonly:"pubsub"`
+
 	// Binding only
 	EventHub      string `json:"eventHub" mapstructure:"eventHub" mdonly:"bindings"`
 	ConsumerGroup string `json:"consumerGroup" mapstructure:"consumerGroup" mdonly:"bindings"` // Alias for ConsumerID
@@ -53,7 +57,9 @@ type AzureEventHubsMetadata struct {
 }
 
 func parseEventHubsMetadata(meta map[string]string, isBinding bool, log logger.Logger) (*AzureEventHubsMetadata, error) {
-	var m AzureEventHubsMetadata
+	m := AzureEventHubsMetadata{
+		CheckPointFrequencyPerPartition: DefaultCheckpointFrequencyPerPartition,
+	}
 	err := metadata.DecodeMetadata(meta, &m)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode metada: %w", err)
@@ -70,6 +76,13 @@ func parseEventHubsMetadata(meta map[string]string, isBinding bool, log logger.L
 		return nil, errors.New("only one of connectionString or eventHubNamespace should be passed")
 	}
 
+	if m.CheckPointFrequencyPerPartition < 0 {
+		return nil, errors.New("property checkPointFrequencyPerPartition must not be negative")
+	}
+	if m.CheckPointIntervalInSec < 0 {
+		return nil, errors.New("property checkPointIntervalInSec must not be negative")
+	}
+
 	// ConsumerGroup is an alias for ConsumerID
 	if m.ConsumerID != "" && m.ConsumerGroup == "" {
 		m.ConsumerGroup = m.ConsumerID
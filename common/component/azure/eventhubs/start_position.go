@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventhubs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
+
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	startPositionKey           = "startPosition"
+	partitionStartPositionsKey = "partitionStartPositions"
+)
+
+// ParseStartPositions returns the positions partitions are consumed from when they don't have a checkpoint yet, from
+// the metadata of a subscription.
+// The `startPosition` property applies to all partitions, and `partitionStartPositions` overrides it for some, as a
+// comma-separated list of "<partition ID>=<position>".
+// A position is "earliest", "latest", an RFC 3339 timestamp of the first event to consume by enqueued time, or the
+// sequence number of the first event to consume, which is the offset of the event when using the Kafka endpoint.
+// Partitions start from the latest event by default.
+func ParseStartPositions(reqMetadata map[string]string) (azeventhubs.StartPositions, error) {
+	positions := azeventhubs.StartPositions{
+		Default: azeventhubs.StartPosition{Latest: ptr.Of(true)},
+	}
+
+	if val := strings.TrimSpace(reqMetadata[startPositionKey]); val != "" {
+		pos, err := parseStartPosition(val)
+		if err != nil {
+			return positions, fmt.Errorf("invalid value for the '%s' metadata property: %w", startPositionKey, err)
+		}
+		positions.Default = pos
+	}
+
+	if val := strings.TrimSpace(reqMetadata[partitionStartPositionsKey]); val != "" {
+		positions.PerPartition = map[string]azeventhubs.StartPosition{}
+		for _, entry := range strings.Split(val, ",") {
+			partitionID, posStr, ok := strings.Cut(entry, "=")
+			partitionID = strings.TrimSpace(partitionID)
+			if !ok || partitionID == "" {
+				return positions, fmt.Errorf("invalid entry '%s' in the '%s' metadata property: the format is <partition ID>=<position>", entry, partitionStartPositionsKey)
+			}
+			pos, err := parseStartPosition(strings.TrimSpace(posStr))
+			if err != nil {
+				return positions, fmt.Errorf("invalid position for partition %s in the '%s' metadata property: %w", partitionID, partitionStartPositionsKey, err)
+			}
+			positions.PerPartition[partitionID] = pos
+		}
+	}
+
+	return positions, nil
+}
+
+func parseStartPosition(val string) (azeventhubs.StartPosition, error) {
+	switch strings.ToLower(val) {
+	case "earliest", "oldest":
+		return azeventhubs.StartPosition{Earliest: ptr.Of(true)}, nil
+	case "latest", "newest":
+		return azeventhubs.StartPosition{Latest: ptr.Of(true)}, nil
+	}
+
+	if seq, err := strconv.ParseInt(val, 10, 64); err == nil && seq >= 0 {
+		return azeventhubs.StartPosition{SequenceNumber: ptr.Of(seq), Inclusive: true}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return azeventhubs.StartPosition{EnqueuedTime: ptr.Of(t), Inclusive: true}, nil
+	}
+	return azeventhubs.StartPosition{}, fmt.Errorf("'%s' is not one of earliest, latest, a sequence number, or an RFC 3339 timestamp", val)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventhubs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestParseStartPositions(t *testing.T) {
+	t.Run("latest by default", func(t *testing.T) {
+		positions, err := ParseStartPositions(map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, azeventhubs.StartPositions{Default: azeventhubs.StartPosition{Latest: ptr.Of(true)}}, positions)
+	})
+
+	t.Run("default and per partition", func(t *testing.T) {
+		positions, err := ParseStartPositions(map[string]string{
+			"startPosition":           "earliest",
+			"partitionStartPositions": "0=42, 1=2026-01-02T03:04:05Z,2=latest",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, azeventhubs.StartPosition{Earliest: ptr.Of(true)}, positions.Default)
+		assert.Equal(t, map[string]azeventhubs.StartPosition{
+			"0": {SequenceNumber: ptr.Of(int64(42)), Inclusive: true},
+			"1": {EnqueuedTime: ptr.Of(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)), Inclusive: true},
+			"2": {Latest: ptr.Of(true)},
+		}, positions.PerPartition)
+	})
+
+	t.Run("invalid positions", func(t *testing.T) {
+		for _, md := range []map[string]string{
+			{"startPosition": "beginning"},
+			{"startPosition": "-1"},
+			{"partitionStartPositions": "0"},
+			{"partitionStartPositions": "=earliest"},
+			{"partitionStartPositions": "0=yesterday"},
+		} {
+			_, err := ParseStartPositions(md)
+			require.Error(t, err, md)
+		}
+	})
+}
@@ -15,6 +15,7 @@ package servicebus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -236,15 +237,60 @@ func (c *Client) EnsureTopic(ctx context.Context, topic string) error {
 	return nil
 }
 
+// GetQueueActiveMessageCount returns the number of active messages in a queue.
+func (c *Client) GetQueueActiveMessageCount(parentCtx context.Context, queue string) (int64, error) {
+	if c.adminClient == nil {
+		return 0, errors.New("admin client is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer cancel()
+	res, err := c.adminClient.GetQueueRuntimeProperties(ctx, queue, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not get runtime properties of queue %s: %w", queue, err)
+	}
+	if res == nil {
+		return 0, fmt.Errorf("queue %s does not exist", queue)
+	}
+	return int64(res.ActiveMessageCount), nil
+}
+
+// GetSubscriptionActiveMessageCount returns the number of active messages in a topic subscription.
+func (c *Client) GetSubscriptionActiveMessageCount(parentCtx context.Context, name string, topic string) (int64, error) {
+	if c.adminClient == nil {
+		return 0, errors.New("admin client is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer cancel()
+	res, err := c.adminClient.GetSubscriptionRuntimeProperties(ctx, topic, name, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not get runtime properties of subscription %s on topic %s: %w", name, topic, err)
+	}
+	if res == nil {
+		return 0, fmt.Errorf("subscription %s on topic %s does not exist", name, topic)
+	}
+	return int64(res.ActiveMessageCount), nil
+}
+
 type SubscribeOptions struct {
 	RequireSessions      bool
 	MaxConcurrentSesions int
+
+	// Entities the messages and the dead-lettered messages of the subscription are forwarded to
+	ForwardTo                     string
+	ForwardDeadLetteredMessagesTo string
+	// Rules of the subscription; the rules are not managed if nil
+	Rules []SubscriptionRule
 }
 
-// EnsureSubscription creates the topic subscription if it doesn't exist.
+// EnsureSubscription creates the topic subscription if it doesn't exist, and reconciles its forwarding destinations and rules.
 // Returns with nil error if the admin client doesn't exist.
 func (c *Client) EnsureSubscription(ctx context.Context, name string, topic string, opts SubscribeOptions) error {
 	if c.adminClient == nil {
+		if opts.managesEntity() {
+			return fmt.Errorf("%s, %s and %s require entity management, but disableEntityManagement is true", ForwardToMetadataKey, ForwardDeadLetteredMessagesToMetadataKey, SubscriptionRulesMetadataKey)
+		}
 		return nil
 	}
 
@@ -253,19 +299,21 @@ func (c *Client) EnsureSubscription(ctx context.Context, name string, topic stri
 		return err
 	}
 
-	shouldCreate, err := c.shouldCreateSubscription(ctx, topic, name, opts)
+	existing, err := c.getSubscription(ctx, topic, name, opts)
 	if err != nil {
 		return err
 	}
 
-	if shouldCreate {
+	if existing == nil {
 		err = c.createSubscription(ctx, topic, name, opts)
-		if err != nil {
-			return err
-		}
+	} else {
+		err = c.reconcileForwarding(ctx, topic, name, *existing, opts)
+	}
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return c.reconcileRules(ctx, topic, name, opts.Rules)
 }
 
 // EnsureTopic creates the queue if it doesn't exist.
@@ -316,24 +364,25 @@ func (c *Client) createTopic(parentCtx context.Context, topic string) error {
 	return nil
 }
 
-func (c *Client) shouldCreateSubscription(parentCtx context.Context, topic, subscription string, opts SubscribeOptions) (bool, error) {
+// getSubscription returns the properties of the subscription, or nil if it doesn't exist.
+func (c *Client) getSubscription(parentCtx context.Context, topic, subscription string, opts SubscribeOptions) (*sbadmin.SubscriptionProperties, error) {
 	ctx, cancel := context.WithTimeout(parentCtx, time.Second*time.Duration(c.metadata.TimeoutInSec))
 	defer cancel()
 
 	res, err := c.adminClient.GetSubscription(ctx, topic, subscription, nil)
 	if err != nil {
-		return false, fmt.Errorf("could not get subscription %s: %w", subscription, err)
+		return nil, fmt.Errorf("could not get subscription %s: %w", subscription, err)
 	}
 	if res == nil {
 		// If res is nil, the subscription does not exist
-		return true, nil
+		return nil, nil
 	}
 
 	if notEqual(res.RequiresSession, &opts.RequireSessions) {
-		return false, fmt.Errorf("subscription %s already exists but session requirement doesn't match", subscription)
+		return nil, fmt.Errorf("subscription %s already exists but session requirement doesn't match", subscription)
 	}
 
-	return false, nil
+	return &res.SubscriptionProperties, nil
 }
 
 func (c *Client) createSubscription(parentCtx context.Context, topic, subscription string, opts SubscribeOptions) error {
@@ -72,6 +72,8 @@ const (
 
 	// MessageKeyScheduledEnqueueTimeUtc defines the metadata key for the scheduled enqueue time utc value.
 	MessageKeyScheduledEnqueueTimeUtc = "ScheduledEnqueueTimeUtc" // read, write.
+	// MessageKeyScheduledEnqueueTimeUtcAlias is an alias for "ScheduledEnqueueTimeUtc" for write only
+	MessageKeyScheduledEnqueueTimeUtcAlias = "scheduledEnqueueTimeUtc"
 
 	// MessageKeyReplyToSessionID defines the metadata key for the reply to session id.
 	// Currently unused.
@@ -155,7 +157,7 @@ func addMetadataToMessage(asbMsg *azservicebus.Message, metadata map[string]stri
 			asbMsg.ContentType = ptr.Of(v)
 
 		// Time
-		case MessageKeyScheduledEnqueueTimeUtc:
+		case MessageKeyScheduledEnqueueTimeUtc, MessageKeyScheduledEnqueueTimeUtcAlias:
 			timeVal, err := time.Parse(http.TimeFormat, v)
 			if err == nil {
 				asbMsg.ScheduledEnqueueTime = &timeVal
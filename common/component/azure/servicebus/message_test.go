@@ -77,6 +77,16 @@ func TestAddMetadataToMessage(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Maps scheduled enqueue time alias.",
+			metadata: map[string]string{
+				MessageKeyScheduledEnqueueTimeUtcAlias: testScheduledEnqueueTimeUtc,
+			},
+			expectedAzServiceBusMessage: azservicebus.Message{
+				ScheduledEnqueueTime: &nowUtc,
+			},
+			expectError: false,
+		},
 		{
 			name: "Errors when partition key and session id set but not equal.",
 			metadata: map[string]string{
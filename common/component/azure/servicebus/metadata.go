@@ -49,6 +49,11 @@ type Metadata struct {
 	PublishInitialRetryIntervalInMs int    `mapstructure:"publishInitialRetryIntervalInMs"`
 	NamespaceName                   string `mapstructure:"namespaceName"` // Only for Azure AD
 
+	/** For pubsubs only; can be overridden per subscription **/
+	PrefetchCount         int    `mapstructure:"prefetchCount" mdonly:"pubsub"`
+	ConcurrencyMode       string `mapstructure:"concurrencyMode" mdonly:"pubsub"`
+	MinConcurrentHandlers int    `mapstructure:"minConcurrentHandlers" mdonly:"pubsub"`
+
 	/** For bindings only **/
 	QueueName string `mapstructure:"queueName" mdonly:"bindings"` // Only queues
 }
@@ -74,8 +79,29 @@ const (
 	keyPublishInitialRetryIntervalInMs = "publishInitialRetryIntervalInMs" // Alias: "publishInitialRetryInternalInMs" (backwards compatibility due to typo)
 	keyNamespaceName                   = "namespaceName"
 	keyQueueName                       = "queueName"
+	keyPrefetchCount                   = "prefetchCount"
+	keyConcurrencyMode                 = "concurrencyMode"
+	keyMinConcurrentHandlers           = "minConcurrentHandlers"
 )
 
+// Concurrency modes.
+const (
+	// ConcurrencyModeFixed uses a fixed number of concurrent handlers, set by maxConcurrentHandlers.
+	ConcurrencyModeFixed = "fixed"
+	// ConcurrencyModeAdaptive scales the number of concurrent handlers between minConcurrentHandlers and maxConcurrentHandlers, based on the number of active messages in the entity.
+	ConcurrencyModeAdaptive = "adaptive"
+)
+
+// Metadata keys that can be overridden in the metadata of subscribe requests.
+var subscriptionMetadataKeys = []string{
+	keyLockRenewalInSec,
+	keyMaxActiveMessages,
+	keyMaxConcurrentHandlers,
+	keyPrefetchCount,
+	keyConcurrencyMode,
+	keyMinConcurrentHandlers,
+}
+
 // Defaults.
 const (
 	// Default timeout for network requests.
@@ -107,6 +133,12 @@ const (
 
 	defaultPublishMaxRetries               = 5
 	defaultPublishInitialRetryIntervalInMs = 500
+
+	// Number of messages fetched at once by non-bulk subscriptions.
+	defaultPrefetchCount = 1
+
+	// Minimum number of concurrent handlers in adaptive mode.
+	defaultMinConcurrentHandlers = 1
 )
 
 // Modes for ParseMetadata.
@@ -130,6 +162,9 @@ func ParseMetadata(md map[string]string, logger logger.Logger, mode byte) (m *Me
 		MaxConcurrentHandlers:           defaultMaxConcurrentHandlersPubSub,
 		PublishMaxRetries:               defaultPublishMaxRetries,
 		PublishInitialRetryIntervalInMs: defaultPublishInitialRetryIntervalInMs,
+		PrefetchCount:                   defaultPrefetchCount,
+		ConcurrencyMode:                 ConcurrencyModeFixed,
+		MinConcurrentHandlers:           defaultMinConcurrentHandlers,
 	}
 
 	if (mode & MetadataModeBinding) != 0 {
@@ -188,6 +223,11 @@ func ParseMetadata(md map[string]string, logger logger.Logger, mode byte) (m *Me
 		return m, err
 	}
 
+	err = m.validateSubscriptionOptions()
+	if err != nil {
+		return m, err
+	}
+
 	/* Nullable configuration settings - defaults will be set by the server. */
 
 	if m.DefaultMessageTimeToLiveInSec == nil {
@@ -211,6 +251,67 @@ func ParseMetadata(md map[string]string, logger logger.Logger, mode byte) (m *Me
 	return m, nil
 }
 
+// SubscriptionOptions returns the options for a subscription, applying the overrides set in the metadata of the subscribe request.
+// Callers are responsible for setting the entity and the options that depend on the type of subscription.
+func (a Metadata) SubscriptionOptions(reqMetadata map[string]string) (SubscriptionOptions, error) {
+	m := a
+	overrides := make(map[string]string, len(subscriptionMetadataKeys))
+	for _, k := range subscriptionMetadataKeys {
+		if v, ok := reqMetadata[k]; ok && v != "" {
+			overrides[k] = v
+		}
+	}
+	if len(overrides) > 0 {
+		err := kitmd.DecodeMetadata(overrides, &m)
+		if err != nil {
+			return SubscriptionOptions{}, fmt.Errorf("invalid subscription metadata: %w", err)
+		}
+		if m.MaxActiveMessages < 1 {
+			return SubscriptionOptions{}, errors.New("invalid subscription metadata: maxActiveMessages must be 1 or greater")
+		}
+		err = m.validateSubscriptionOptions()
+		if err != nil {
+			return SubscriptionOptions{}, fmt.Errorf("invalid subscription metadata: %w", err)
+		}
+	}
+
+	return SubscriptionOptions{
+		MaxActiveMessages:     m.MaxActiveMessages,
+		TimeoutInSec:          m.TimeoutInSec,
+		MaxRetriableEPS:       m.MaxRetriableErrorsPerSec,
+		MaxConcurrentHandlers: m.MaxConcurrentHandlers,
+		LockRenewalInSec:      m.LockRenewalInSec,
+		PrefetchCount:         m.PrefetchCount,
+		ConcurrencyMode:       m.ConcurrencyMode,
+		MinConcurrentHandlers: m.MinConcurrentHandlers,
+	}, nil
+}
+
+func (a *Metadata) validateSubscriptionOptions() error {
+	if a.PrefetchCount < 1 {
+		return errors.New("prefetchCount must be 1 or greater")
+	}
+
+	switch a.ConcurrencyMode {
+	case "", ConcurrencyModeFixed:
+		a.ConcurrencyMode = ConcurrencyModeFixed
+	case ConcurrencyModeAdaptive:
+		if a.MaxConcurrentHandlers < 1 {
+			return errors.New("maxConcurrentHandlers must be set when concurrencyMode is 'adaptive'")
+		}
+		if a.MinConcurrentHandlers < 1 || a.MinConcurrentHandlers > a.MaxConcurrentHandlers {
+			return errors.New("minConcurrentHandlers must be between 1 and maxConcurrentHandlers")
+		}
+		if a.DisableEntityManagement {
+			return errors.New("concurrencyMode 'adaptive' requires entity management to read the number of active messages, but disableEntityManagement is true")
+		}
+	default:
+		return fmt.Errorf("invalid concurrencyMode '%s': must be '%s' or '%s'", a.ConcurrencyMode, ConcurrencyModeFixed, ConcurrencyModeAdaptive)
+	}
+
+	return nil
+}
+
 // CreateSubscriptionProperties returns the SubscriptionProperties object to create new Subscriptions to Service Bus topics.
 func (a Metadata) CreateSubscriptionProperties(opts SubscribeOptions) *sbadmin.SubscriptionProperties {
 	properties := &sbadmin.SubscriptionProperties{}
@@ -235,6 +336,14 @@ func (a Metadata) CreateSubscriptionProperties(opts SubscribeOptions) *sbadmin.S
 		properties.RequiresSession = ptr.Of(true)
 	}
 
+	if opts.ForwardTo != "" {
+		properties.ForwardTo = ptr.Of(opts.ForwardTo)
+	}
+
+	if opts.ForwardDeadLetteredMessagesTo != "" {
+		properties.ForwardDeadLetteredMessagesTo = ptr.Of(opts.ForwardDeadLetteredMessagesTo)
+	}
+
 	return properties
 }
 
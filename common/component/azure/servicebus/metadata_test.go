@@ -543,3 +543,66 @@ func TestParseServiceBusMetadata(t *testing.T) {
 		require.Error(t, parseErr3)
 	})
 }
+
+func TestSubscriptionOptions(t *testing.T) {
+	fakeProperties := getFakeProperties()
+	fakeProperties[keyDisableEntityManagement] = "false"
+	m, err := ParseMetadata(fakeProperties, nil, MetadataModeTopics)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.PrefetchCount)
+	assert.Equal(t, ConcurrencyModeFixed, m.ConcurrencyMode)
+
+	t.Run("uses component metadata", func(t *testing.T) {
+		opts, err := m.SubscriptionOptions(map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, 100, opts.MaxActiveMessages)
+		assert.Equal(t, 1, opts.MaxConcurrentHandlers)
+		assert.Equal(t, 15, opts.LockRenewalInSec)
+		assert.Equal(t, 1, opts.PrefetchCount)
+		assert.Equal(t, ConcurrencyModeFixed, opts.ConcurrencyMode)
+	})
+
+	t.Run("applies overrides", func(t *testing.T) {
+		opts, err := m.SubscriptionOptions(map[string]string{
+			keyMaxActiveMessages:     "50",
+			keyMaxConcurrentHandlers: "20",
+			keyLockRenewalInSec:      "5",
+			keyPrefetchCount:         "10",
+			keyConcurrencyMode:       ConcurrencyModeAdaptive,
+			keyMinConcurrentHandlers: "2",
+			keyTimeoutInSec:          "1", // Not overridable
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 50, opts.MaxActiveMessages)
+		assert.Equal(t, 20, opts.MaxConcurrentHandlers)
+		assert.Equal(t, 5, opts.LockRenewalInSec)
+		assert.Equal(t, 10, opts.PrefetchCount)
+		assert.Equal(t, ConcurrencyModeAdaptive, opts.ConcurrencyMode)
+		assert.Equal(t, 2, opts.MinConcurrentHandlers)
+		assert.Equal(t, 90, opts.TimeoutInSec)
+
+		// Component metadata is unchanged
+		assert.Equal(t, 100, m.MaxActiveMessages)
+		assert.Equal(t, ConcurrencyModeFixed, m.ConcurrencyMode)
+	})
+
+	invalid := []map[string]string{
+		{keyPrefetchCount: "0"},
+		{keyMaxActiveMessages: "0"},
+		{keyConcurrencyMode: "foo"},
+		{keyConcurrencyMode: ConcurrencyModeAdaptive, keyMaxConcurrentHandlers: "0"},
+		{keyConcurrencyMode: ConcurrencyModeAdaptive, keyMaxConcurrentHandlers: "5", keyMinConcurrentHandlers: "6"},
+		{keyMaxConcurrentHandlers: invalidNumber},
+	}
+	for _, md := range invalid {
+		_, err := m.SubscriptionOptions(md)
+		require.Error(t, err, md)
+	}
+
+	t.Run("adaptive mode requires entity management", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[keyConcurrencyMode] = ConcurrencyModeAdaptive
+		_, err := ParseMetadata(fakeProperties, nil, MetadataModeTopics)
+		require.ErrorContains(t, err, "disableEntityManagement")
+	})
+}
@@ -24,6 +24,7 @@ type Receiver interface {
 	ReceiveMessages(ctx context.Context, maxMessages int, options *azservicebus.ReceiveMessagesOptions) ([]*azservicebus.ReceivedMessage, error)
 	CompleteMessage(ctx context.Context, m *azservicebus.ReceivedMessage, opts *azservicebus.CompleteMessageOptions) error
 	AbandonMessage(ctx context.Context, m *azservicebus.ReceivedMessage, opts *azservicebus.AbandonMessageOptions) error
+	DeferMessage(ctx context.Context, m *azservicebus.ReceivedMessage, opts *azservicebus.DeferMessageOptions) error
 	Close(ctx context.Context) error
 }
 
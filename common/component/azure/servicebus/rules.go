@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicebus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sbadmin "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	ForwardToMetadataKey                     = "forwardTo"
+	ForwardDeadLetteredMessagesToMetadataKey = "forwardDeadLetteredMessagesTo"
+	SubscriptionRulesMetadataKey             = "subscriptionRules"
+)
+
+// SubscriptionRule is a SQL filter rule of a topic subscription, with an optional SQL action.
+type SubscriptionRule struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+	Action string `json:"action,omitempty"`
+}
+
+// ParseSubscriptionEntityMetadata sets the forwarding destinations and the rules of the subscription from the metadata
+// of the subscribe request.
+// Rules are set in the "subscriptionRules" property as a JSON array, such as `[{"name": "orders", "filter": "type = 'order'"}]`.
+func (o *SubscribeOptions) ParseSubscriptionEntityMetadata(reqMetadata map[string]string) error {
+	o.ForwardTo = reqMetadata[ForwardToMetadataKey]
+	o.ForwardDeadLetteredMessagesTo = reqMetadata[ForwardDeadLetteredMessagesToMetadataKey]
+
+	val := reqMetadata[SubscriptionRulesMetadataKey]
+	if val == "" {
+		o.Rules = nil
+		return nil
+	}
+
+	var rules []SubscriptionRule
+	err := json.Unmarshal([]byte(val), &rules)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", SubscriptionRulesMetadataKey, err)
+	}
+	if len(rules) == 0 {
+		return fmt.Errorf("invalid %s: at least one rule is required", SubscriptionRulesMetadataKey)
+	}
+	names := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		if r.Name == "" || r.Filter == "" {
+			return fmt.Errorf("invalid %s: each rule requires a name and a filter", SubscriptionRulesMetadataKey)
+		}
+		if _, ok := names[r.Name]; ok {
+			return fmt.Errorf("invalid %s: duplicate rule %s", SubscriptionRulesMetadataKey, r.Name)
+		}
+		names[r.Name] = struct{}{}
+	}
+	o.Rules = rules
+	return nil
+}
+
+// managesEntity returns true if the options set properties of the subscription that are reconciled on Service Bus.
+func (o SubscribeOptions) managesEntity() bool {
+	return o.ForwardTo != "" || o.ForwardDeadLetteredMessagesTo != "" || o.Rules != nil
+}
+
+// reconcileForwarding updates the forwarding destinations of an existing subscription, if they changed.
+func (c *Client) reconcileForwarding(parentCtx context.Context, topic string, subscription string, props sbadmin.SubscriptionProperties, opts SubscribeOptions) error {
+	changed := false
+	if opts.ForwardTo != "" && !sameEntity(props.ForwardTo, opts.ForwardTo) {
+		props.ForwardTo = ptr.Of(opts.ForwardTo)
+		changed = true
+	}
+	if opts.ForwardDeadLetteredMessagesTo != "" && !sameEntity(props.ForwardDeadLetteredMessagesTo, opts.ForwardDeadLetteredMessagesTo) {
+		props.ForwardDeadLetteredMessagesTo = ptr.Of(opts.ForwardDeadLetteredMessagesTo)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer cancel()
+
+	_, err := c.adminClient.UpdateSubscription(ctx, topic, subscription, props, nil)
+	if err != nil {
+		return fmt.Errorf("could not update forwarding of subscription %s: %w", subscription, err)
+	}
+	return nil
+}
+
+// reconcileRules creates and updates the rules of the subscription, then deletes the rules that are not in the options,
+// including the default rule that accepts all messages.
+// Rules are not changed if the options don't have any.
+func (c *Client) reconcileRules(parentCtx context.Context, topic string, subscription string, rules []SubscriptionRule) error {
+	if rules == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer cancel()
+
+	existing := map[string]sbadmin.RuleProperties{}
+	pager := c.adminClient.NewListRulesPager(topic, subscription, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("could not list rules of subscription %s: %w", subscription, err)
+		}
+		for _, r := range page.Rules {
+			existing[r.Name] = r
+		}
+	}
+
+	// New rules are created before removing the others, so that messages are not dropped in between
+	var errs []error
+	for _, r := range rules {
+		props := r.properties()
+		current, ok := existing[r.Name]
+		delete(existing, r.Name)
+		var err error
+		switch {
+		case !ok:
+			_, err = c.adminClient.CreateRule(ctx, topic, subscription, &sbadmin.CreateRuleOptions{
+				Name:   ptr.Of(props.Name),
+				Filter: props.Filter,
+				Action: props.Action,
+			})
+		case !r.matches(current):
+			_, err = c.adminClient.UpdateRule(ctx, topic, subscription, props)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not create or update rule %s of subscription %s: %w", r.Name, subscription, err))
+		}
+	}
+	if len(errs) > 0 {
+		// Do not remove the previous rules if the new ones could not be created
+		return errors.Join(errs...)
+	}
+
+	for name := range existing {
+		_, err := c.adminClient.DeleteRule(ctx, topic, subscription, name, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not delete rule %s of subscription %s: %w", name, subscription, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r SubscriptionRule) properties() sbadmin.RuleProperties {
+	props := sbadmin.RuleProperties{
+		Name:   r.Name,
+		Filter: &sbadmin.SQLFilter{Expression: r.Filter},
+	}
+	if r.Action != "" {
+		props.Action = &sbadmin.SQLAction{Expression: r.Action}
+	}
+	return props
+}
+
+// matches returns true if an existing rule has the same filter and action.
+func (r SubscriptionRule) matches(props sbadmin.RuleProperties) bool {
+	filter, ok := props.Filter.(*sbadmin.SQLFilter)
+	if !ok || filter.Expression != r.Filter {
+		return false
+	}
+	action, ok := props.Action.(*sbadmin.SQLAction)
+	if !ok {
+		return r.Action == ""
+	}
+	return action.Expression == r.Action
+}
+
+// sameEntity returns true if a forwarding destination of a subscription points to the entity.
+// Service Bus returns destinations as absolute URIs, such as "sb://mynamespace.servicebus.windows.net/myqueue".
+func sameEntity(current *string, entity string) bool {
+	if current == nil {
+		return false
+	}
+	cur := strings.ToLower(strings.TrimSuffix(*current, "/"))
+	entity = strings.ToLower(entity)
+	return cur == entity || strings.HasSuffix(cur, "/"+entity)
+}
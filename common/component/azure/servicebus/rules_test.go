@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicebus
+
+import (
+	"testing"
+
+	sbadmin "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestParseSubscriptionEntityMetadata(t *testing.T) {
+	t.Run("forwarding and rules", func(t *testing.T) {
+		opts := SubscribeOptions{}
+		err := opts.ParseSubscriptionEntityMetadata(map[string]string{
+			ForwardToMetadataKey:         "orders-queue",
+			SubscriptionRulesMetadataKey: `[{"name": "eu", "filter": "region = 'eu'", "action": "SET sys.Label = 'eu'"}, {"name": "us", "filter": "region = 'us'"}]`,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "orders-queue", opts.ForwardTo)
+		assert.Empty(t, opts.ForwardDeadLetteredMessagesTo)
+		assert.Equal(t, []SubscriptionRule{
+			{Name: "eu", Filter: "region = 'eu'", Action: "SET sys.Label = 'eu'"},
+			{Name: "us", Filter: "region = 'us'"},
+		}, opts.Rules)
+		assert.True(t, opts.managesEntity())
+	})
+
+	t.Run("nothing to manage", func(t *testing.T) {
+		opts := SubscribeOptions{}
+		require.NoError(t, opts.ParseSubscriptionEntityMetadata(map[string]string{}))
+		assert.Nil(t, opts.Rules)
+		assert.False(t, opts.managesEntity())
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, val := range []string{
+			`{"name": "eu"}`,
+			`[]`,
+			`[{"name": "eu"}]`,
+			`[{"filter": "1=1"}]`,
+			`[{"name": "eu", "filter": "1=1"}, {"name": "eu", "filter": "1=1"}]`,
+		} {
+			opts := SubscribeOptions{}
+			err := opts.ParseSubscriptionEntityMetadata(map[string]string{SubscriptionRulesMetadataKey: val})
+			require.Error(t, err, val)
+		}
+	})
+}
+
+func TestSubscriptionRuleMatches(t *testing.T) {
+	rule := SubscriptionRule{Name: "eu", Filter: "region = 'eu'"}
+	assert.True(t, rule.matches(rule.properties()))
+	assert.False(t, rule.matches(sbadmin.RuleProperties{Name: "eu", Filter: &sbadmin.TrueFilter{}}))
+	assert.False(t, rule.matches(sbadmin.RuleProperties{
+		Name:   "eu",
+		Filter: &sbadmin.SQLFilter{Expression: "region = 'eu'"},
+		Action: &sbadmin.SQLAction{Expression: "SET sys.Label = 'eu'"},
+	}))
+
+	rule.Action = "SET sys.Label = 'eu'"
+	assert.True(t, rule.matches(rule.properties()))
+	assert.False(t, rule.matches(sbadmin.RuleProperties{Name: "eu", Filter: &sbadmin.SQLFilter{Expression: "region = 'eu'"}}))
+}
+
+func TestSameEntity(t *testing.T) {
+	assert.True(t, sameEntity(ptr.Of("sb://myns.servicebus.windows.net/orders"), "orders"))
+	assert.True(t, sameEntity(ptr.Of("sb://myns.servicebus.windows.net/Orders/"), "orders"))
+	assert.True(t, sameEntity(ptr.Of("orders"), "orders"))
+	assert.False(t, sameEntity(ptr.Of("sb://myns.servicebus.windows.net/old-orders"), "orders"))
+	assert.False(t, sameEntity(nil, "orders"))
+}
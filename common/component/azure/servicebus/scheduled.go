@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	azservicebus "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+)
+
+// ErrDeferMessage can be returned by handlers, possibly wrapped, to defer a message instead of abandoning it.
+// Deferred messages are not delivered again to the subscription: they are retrieved with HandleDeferredMessages,
+// using the sequence number passed to the handler in the "metadata.SequenceNumber" property.
+var ErrDeferMessage = errors.New("message deferred by the handler")
+
+// SchedulePubSub is used by PubSub components to schedule a message, which is enqueued at the time set in the
+// ScheduledEnqueueTimeUtc metadata property. It returns the sequence number of the message, which can be used to
+// cancel it with CancelScheduledMessages.
+func (c *Client) SchedulePubSub(ctx context.Context, req *pubsub.PublishRequest, ensureFn ensureFn) (int64, error) {
+	msg, err := NewASBMessageFromPubsubRequest(req)
+	if err != nil {
+		return 0, err
+	}
+	if msg.ScheduledEnqueueTime == nil {
+		return 0, fmt.Errorf("the %s metadata property is required to schedule a message", MessageKeyScheduledEnqueueTimeUtc)
+	}
+
+	sender, err := c.GetSender(ctx, req.Topic, ensureFn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create a sender: %w", err)
+	}
+
+	scheduleCtx, scheduleCancel := context.WithTimeout(ctx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer scheduleCancel()
+	sequenceNumbers, err := sender.ScheduleMessages(scheduleCtx, []*azservicebus.Message{msg}, *msg.ScheduledEnqueueTime, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to schedule message: %w", err)
+	}
+	if len(sequenceNumbers) != 1 {
+		return 0, fmt.Errorf("expected 1 sequence number, got %d", len(sequenceNumbers))
+	}
+	return sequenceNumbers[0], nil
+}
+
+// CancelScheduledMessages cancels messages that were scheduled on the queue or topic, by sequence number.
+func (c *Client) CancelScheduledMessages(ctx context.Context, queueOrTopic string, sequenceNumbers []int64) error {
+	if len(sequenceNumbers) == 0 {
+		return nil
+	}
+
+	sender, err := c.GetSender(ctx, queueOrTopic, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create a sender: %w", err)
+	}
+
+	cancelCtx, cancelCancel := context.WithTimeout(ctx, time.Second*time.Duration(c.metadata.TimeoutInSec))
+	defer cancelCancel()
+	err = sender.CancelScheduledMessages(cancelCtx, sequenceNumbers, nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled messages: %w", err)
+	}
+	return nil
+}
+
+// HandleDeferredMessages retrieves deferred messages by sequence number and invokes the handler for each of them.
+// Messages are completed if the handler succeeds; otherwise they are abandoned, and remain deferred.
+// The receiver is closed when the method returns.
+func (c *Client) HandleDeferredMessages(ctx context.Context, receiver *azservicebus.Receiver, sequenceNumbers []int64, handler HandlerFn, log logger.Logger) error {
+	defer func() {
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second*time.Duration(c.metadata.TimeoutInSec))
+		_ = receiver.Close(closeCtx)
+		closeCancel()
+	}()
+
+	if len(sequenceNumbers) == 0 {
+		return nil
+	}
+
+	msgs, err := receiver.ReceiveDeferredMessages(ctx, sequenceNumbers, nil)
+	if err != nil {
+		return fmt.Errorf("failed to receive deferred messages: %w", err)
+	}
+
+	var errs []error
+	for _, msg := range msgs {
+		_, hErr := handler(ctx, []*azservicebus.ReceivedMessage{msg})
+
+		finalizeCtx, finalizeCancel := context.WithTimeout(context.Background(), time.Second*time.Duration(c.metadata.TimeoutInSec))
+		if hErr != nil {
+			log.Errorf("App handler returned an error for deferred message %s: %v", msg.MessageID, hErr)
+			errs = append(errs, fmt.Errorf("message %s: %w", msg.MessageID, hErr))
+			err = receiver.AbandonMessage(finalizeCtx, msg, nil)
+		} else {
+			err = receiver.CompleteMessage(finalizeCtx, msg, nil)
+		}
+		finalizeCancel()
+		if err != nil {
+			log.Warnf("Error settling deferred message %s: %v", msg.MessageID, err)
+		}
+	}
+
+	if len(msgs) < len(sequenceNumbers) {
+		errs = append(errs, fmt.Errorf("found %d deferred messages out of %d", len(msgs), len(sequenceNumbers)))
+	}
+	return errors.Join(errs...)
+}
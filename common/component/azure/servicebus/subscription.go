@@ -40,6 +40,9 @@ const (
 
 	// Maximum number of concurrent operations such as lock renewals or message completion/abandonment
 	maxConcurrentOps = 20
+
+	// Interval for scaling the number of concurrent handlers in adaptive mode
+	adaptiveConcurrencyInterval = 10 * time.Second
 )
 
 // HandlerResponseItem represents a response from the handler for each message.
@@ -62,11 +65,25 @@ type Subscription struct {
 	timeout              time.Duration
 	lockRenewalInterval  time.Duration
 	maxBulkSubCount      int
+	bulk                 bool
+	prefetchCount        int
 	retriableErrLimiter  ratelimit.Limiter
 	handleChan           chan struct{}
+	adaptive             *adaptiveConcurrency
 	logger               logger.Logger
 }
 
+// adaptiveConcurrency contains the state for scaling the number of concurrent handlers based on the number of active messages.
+// The number of handlers is reduced by reserving slots in handleChan.
+type adaptiveConcurrency struct {
+	min                  int
+	max                  int
+	activeMessageCountFn func(ctx context.Context) (int64, error)
+	// Number of slots in handleChan reserved by the scaler; only accessed by the scaler while running is true
+	reserved int
+	running  atomic.Bool
+}
+
 type SubscriptionOptions struct {
 	MaxActiveMessages     int
 	TimeoutInSec          int
@@ -77,11 +94,21 @@ type SubscriptionOptions struct {
 	LockRenewalInSec      int
 	RequireSessions       bool
 	SessionIdleTimeout    time.Duration
+
+	// Number of messages fetched at once by non-bulk subscriptions; each message is still handled individually
+	PrefetchCount int
+	// Concurrency mode: ConcurrencyModeFixed (default) or ConcurrencyModeAdaptive
+	ConcurrencyMode string
+	// Minimum number of concurrent handlers in adaptive mode
+	MinConcurrentHandlers int
+	// Function that returns the number of active messages in the entity; required in adaptive mode
+	ActiveMessageCountFn func(ctx context.Context) (int64, error)
 }
 
 // NewBulkSubscription returns a new Subscription object.
 // Parameter "entity" is usually in the format "topic <topicname>" or "queue <queuename>" and it's only used for logging.
 func NewSubscription(opts SubscriptionOptions, logger logger.Logger) *Subscription {
+	bulk := opts.MaxBulkSubCount != nil
 	if opts.MaxBulkSubCount != nil {
 		if *opts.MaxBulkSubCount < 1 {
 			logger.Warnf("maxBulkSubCount must be greater than 0, setting it to 1")
@@ -104,6 +131,8 @@ func NewSubscription(opts SubscriptionOptions, logger logger.Logger) *Subscripti
 		lockRenewalInterval: time.Duration(opts.LockRenewalInSec) * time.Second,
 		sessionIdleTimeout:  opts.SessionIdleTimeout,
 		maxBulkSubCount:     *opts.MaxBulkSubCount,
+		bulk:                bulk,
+		prefetchCount:       1,
 		requireSessions:     opts.RequireSessions,
 		logger:              logger,
 		// This is a pessimistic estimate of the number of total operations that can be active at any given time.
@@ -117,9 +146,24 @@ func NewSubscription(opts SubscriptionOptions, logger logger.Logger) *Subscripti
 		s.retriableErrLimiter = ratelimit.NewUnlimited()
 	}
 
+	if opts.PrefetchCount > 1 && !bulk {
+		s.prefetchCount = min(opts.PrefetchCount, opts.MaxActiveMessages)
+	}
+
 	if opts.MaxConcurrentHandlers > 0 {
 		s.logger.Debugf("Subscription to %s is limited to %d message handler(s)", opts.Entity, opts.MaxConcurrentHandlers)
 		s.handleChan = make(chan struct{}, opts.MaxConcurrentHandlers)
+
+		if opts.ConcurrencyMode == ConcurrencyModeAdaptive && opts.ActiveMessageCountFn != nil {
+			s.logger.Debugf("Subscription to %s scales between %d and %d message handler(s)", opts.Entity, opts.MinConcurrentHandlers, opts.MaxConcurrentHandlers)
+			s.adaptive = &adaptiveConcurrency{
+				min:                  max(opts.MinConcurrentHandlers, 1),
+				max:                  opts.MaxConcurrentHandlers,
+				activeMessageCountFn: opts.ActiveMessageCountFn,
+			}
+		}
+	} else if opts.ConcurrencyMode == ConcurrencyModeAdaptive {
+		s.logger.Warnf("Adaptive concurrency for %s requires maxConcurrentHandlers to be set; using an unlimited number of handlers", opts.Entity)
 	}
 
 	return s
@@ -194,6 +238,17 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 		s.logger.Debug("Exiting lock renewal loop for " + logMsg)
 	}()
 
+	// Adaptive concurrency loop
+	// When receiving from multiple sessions, there's only one loop per subscription
+	if s.adaptive != nil && s.adaptive.running.CompareAndSwap(false, true) {
+		go func() {
+			defer s.adaptive.running.Store(false)
+			s.logger.Debug("Starting adaptive concurrency loop for " + logMsg)
+			s.scaleConcurrencyBlocking(ctx)
+			s.logger.Debug("Exiting adaptive concurrency loop for " + logMsg)
+		}()
+	}
+
 	// Receiver loop
 	for {
 		// This blocks if there are too many active operations already
@@ -223,8 +278,14 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 			receiverCtx = ctx
 		}
 
+		// When prefetching, each message is one operation, so take more tokens if available without blocking
+		count := s.maxBulkSubCount
+		if s.prefetchCount > 1 {
+			count = 1 + s.takeActiveOperations(s.prefetchCount-1)
+		}
+
 		// This method blocks until we get a message or the context is canceled
-		msgs, err := receiver.ReceiveMessages(receiverCtx, s.maxBulkSubCount, nil)
+		msgs, err := receiver.ReceiveMessages(receiverCtx, count, nil)
 		if receiverCancel != nil {
 			receiverCancel()
 		}
@@ -232,7 +293,7 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 			if err != context.Canceled {
 				s.logger.Errorf("Error reading from %s. %s", s.entity, err.Error())
 			}
-			<-s.activeOperationsChan
+			s.releaseActiveOperations(s.activeOperationsTaken(count))
 			// Return the error. This will cause the Service Bus component to try and reconnect.
 			return err
 		}
@@ -241,11 +302,16 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 			// We got no message, which is unusual too
 			// Treat this as error
 			s.logger.Warn("Received 0 messages from Service Bus")
-			<-s.activeOperationsChan
+			s.releaseActiveOperations(s.activeOperationsTaken(count))
 			// Return an error to force the Service Bus component to try and reconnect.
 			return errors.New("received 0 messages from Service Bus")
 		}
 
+		// Release the tokens that were taken for prefetched messages that weren't received
+		if s.prefetchCount > 1 && len(msgs) < count {
+			s.releaseActiveOperations(count - len(msgs))
+		}
+
 		// Invoke only once
 		if onFirstSuccess != nil {
 			onFirstSuccess()
@@ -254,6 +320,24 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 
 		s.logger.Debugf("Received messages: %d; current active operations usage: %d/%d", len(msgs), len(s.activeOperationsChan), cap(s.activeOperationsChan))
 
+		// Messages received by non-bulk subscriptions are handled individually
+		if !s.bulk {
+			for _, msg := range msgs {
+				err = s.addActiveMessage(msg)
+				if err != nil {
+					// See the comment below
+					s.logger.Errorf("Error adding message: %s", err.Error())
+					<-s.activeOperationsChan
+					continue
+				}
+				s.logger.Debugf("Processing received message: %s", msg.MessageID)
+
+				// Handle the message in background
+				go s.handleAsync(ctx, []*azservicebus.ReceivedMessage{msg}, handler, receiver)
+			}
+			continue
+		}
+
 		skipProcessing := false
 		for _, msg := range msgs {
 			err = s.addActiveMessage(msg)
@@ -278,6 +362,86 @@ func (s *Subscription) ReceiveBlocking(parentCtx context.Context, handler Handle
 	}
 }
 
+// takeActiveOperations takes up to n tokens from activeOperationsChan without blocking, and returns the number of tokens taken.
+func (s *Subscription) takeActiveOperations(n int) int {
+	for i := range n {
+		select {
+		case s.activeOperationsChan <- struct{}{}:
+			// No-op
+		default:
+			return i
+		}
+	}
+	return n
+}
+
+// activeOperationsTaken returns the number of tokens taken for a receive call that requested count messages.
+func (s *Subscription) activeOperationsTaken(count int) int {
+	if s.prefetchCount > 1 {
+		return count
+	}
+	return 1
+}
+
+// releaseActiveOperations releases n tokens from activeOperationsChan.
+func (s *Subscription) releaseActiveOperations(n int) {
+	for range n {
+		<-s.activeOperationsChan
+	}
+}
+
+// scaleConcurrencyBlocking scales the number of concurrent handlers periodically, until the context is canceled.
+func (s *Subscription) scaleConcurrencyBlocking(ctx context.Context) {
+	s.scaleConcurrency(ctx)
+
+	t := time.NewTicker(adaptiveConcurrencyInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.scaleConcurrency(ctx)
+		}
+	}
+}
+
+// scaleConcurrency sets the number of concurrent handlers to the number of active messages in the entity, within the configured bounds.
+func (s *Subscription) scaleConcurrency(ctx context.Context) {
+	count, err := s.adaptive.activeMessageCountFn(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warnf("Error getting the number of active messages for %s: %v", s.entity, err)
+		}
+		return
+	}
+
+	target := int(min(max(count, int64(s.adaptive.min)), int64(s.adaptive.max)))
+	if s.setConcurrency(target) {
+		s.logger.Debugf("Scaled message handlers for %s to %d (active messages: %d)", s.entity, target, count)
+	}
+}
+
+// setConcurrency reserves or releases slots in handleChan so that at most target handlers can run concurrently.
+// Reserving slots doesn't block: if too many handlers are running, it's retried at the next interval.
+// Returns true if the target was reached.
+func (s *Subscription) setConcurrency(target int) bool {
+	wantReserved := s.adaptive.max - target
+	for s.adaptive.reserved < wantReserved {
+		select {
+		case s.handleChan <- struct{}{}:
+			s.adaptive.reserved++
+		default:
+			return false
+		}
+	}
+	for s.adaptive.reserved > wantReserved {
+		<-s.handleChan
+		s.adaptive.reserved--
+	}
+	return true
+}
+
 func (s *Subscription) renewLocksBlocking(ctx context.Context, receiver Receiver) error {
 	if receiver == nil {
 		return nil
@@ -447,10 +611,17 @@ func (s *Subscription) handleAsync(ctx context.Context, msgs []*azservicebus.Rec
 	// Invoke the handler to process the message.
 	resps, err := handler(ctx, msgs)
 	if err != nil {
+		// If we have a response with 0 items (or a nil response), it means the handler was a non-bulk one
+		if len(resps) == 0 && errors.Is(err, ErrDeferMessage) {
+			finalizeCtx, finalizeCancel := context.WithTimeout(context.Background(), s.timeout)
+			s.DeferMessage(finalizeCtx, receiver, msgs[0])
+			finalizeCancel()
+			return
+		}
+
 		// Errors here are from the app, so consume a retriable error token
 		consumeToken = true
 
-		// If we have a response with 0 items (or a nil response), it means the handler was a non-bulk one
 		if len(resps) == 0 {
 			// Log the error only, as we're running asynchronously
 			s.logger.Errorf("App handler returned an error for message %s on %s: %s", msgs[0].MessageID, s.entity, err)
@@ -480,7 +651,9 @@ func (s *Subscription) handleAsync(ctx context.Context, msgs []*azservicebus.Rec
 				// If we fail to finalize the message, this message will eventually be reprocessed (at-least once delivery).
 				// This uses a background context in case ctx has been canceled already.
 				finalizeCtx, finalizeCancel := context.WithTimeout(context.Background(), s.timeout)
-				if resps[i].Error != nil {
+				if errors.Is(resps[i].Error, ErrDeferMessage) {
+					s.DeferMessage(finalizeCtx, receiver, msgs[i])
+				} else if resps[i].Error != nil {
 					// Log the error only, as we're running asynchronously.
 					s.logger.Errorf("App handler returned an error for message %s on %s: %s", msgs[i].MessageID, s.entity, resps[i].Error)
 					s.AbandonMessage(finalizeCtx, receiver, msgs[i])
@@ -534,6 +707,18 @@ func (s *Subscription) AbandonMessage(ctx context.Context, receiver Receiver, m
 	}
 }
 
+// DeferMessage marks a message as deferred, so that it can only be received by sequence number.
+func (s *Subscription) DeferMessage(ctx context.Context, receiver Receiver, m *azservicebus.ReceivedMessage) {
+	s.logger.Debugf("Deferring message %s with sequence number %d on %s", m.MessageID, *m.SequenceNumber, s.entity)
+
+	// Use a background context in case a.ctx has been canceled already
+	err := receiver.DeferMessage(ctx, m, nil)
+	if err != nil {
+		// Log only
+		s.logger.Warnf("Error deferring message %s on %s: %s", m.MessageID, s.entity, err.Error())
+	}
+}
+
 // CompleteMessage marks a message as complete.
 func (s *Subscription) CompleteMessage(ctx context.Context, receiver Receiver, m *azservicebus.ReceivedMessage) {
 	s.logger.Debugf("Completing message %s on %s", m.MessageID, s.entity)
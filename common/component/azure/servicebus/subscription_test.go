@@ -14,7 +14,16 @@ limitations under the License.
 package servicebus
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
 	"testing"
+	"time"
+
+	azservicebus "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -91,3 +100,163 @@ func TestNewSubscription(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefetchCount(t *testing.T) {
+	newSub := func(maxBulkSubCount *int) *Subscription {
+		return NewSubscription(
+			SubscriptionOptions{
+				MaxActiveMessages: 5,
+				MaxBulkSubCount:   maxBulkSubCount,
+				Entity:            "test",
+				PrefetchCount:     10,
+			},
+			logger.NewLogger("test"),
+		)
+	}
+
+	t.Run("limited by maxActiveMessages", func(t *testing.T) {
+		sub := newSub(nil)
+		assert.Equal(t, 5, sub.prefetchCount)
+
+		// Takes only the available tokens
+		sub.activeOperationsChan <- struct{}{}
+		assert.Equal(t, 3, sub.takeActiveOperations(sub.prefetchCount-2))
+		assert.Equal(t, 1, sub.takeActiveOperations(sub.prefetchCount-1))
+		assert.Len(t, sub.activeOperationsChan, 5)
+
+		sub.releaseActiveOperations(sub.activeOperationsTaken(5))
+		assert.Empty(t, sub.activeOperationsChan)
+	})
+
+	t.Run("ignored for bulk subscriptions", func(t *testing.T) {
+		sub := newSub(ptr.Of(2))
+		assert.Equal(t, 1, sub.prefetchCount)
+		assert.Equal(t, 1, sub.activeOperationsTaken(2))
+	})
+}
+
+func TestAdaptiveConcurrency(t *testing.T) {
+	var activeMessages int64
+	sub := NewSubscription(
+		SubscriptionOptions{
+			MaxActiveMessages:     100,
+			MaxConcurrentHandlers: 10,
+			Entity:                "test",
+			ConcurrencyMode:       ConcurrencyModeAdaptive,
+			MinConcurrentHandlers: 2,
+			ActiveMessageCountFn: func(ctx context.Context) (int64, error) {
+				return activeMessages, nil
+			},
+		},
+		logger.NewLogger("test"),
+	)
+	if !assert.NotNil(t, sub.adaptive) {
+		return
+	}
+
+	// Scales down to the minimum
+	sub.scaleConcurrency(t.Context())
+	assert.Equal(t, 8, sub.adaptive.reserved)
+	assert.Len(t, sub.handleChan, 8)
+
+	// Scales with the number of active messages
+	activeMessages = 5
+	sub.scaleConcurrency(t.Context())
+	assert.Equal(t, 5, sub.adaptive.reserved)
+
+	// Up to the maximum
+	activeMessages = 1000
+	sub.scaleConcurrency(t.Context())
+	assert.Equal(t, 0, sub.adaptive.reserved)
+	assert.Empty(t, sub.handleChan)
+
+	// Scaling down doesn't block when handlers are busy
+	for range 9 {
+		sub.handleChan <- struct{}{}
+	}
+	activeMessages = 0
+	assert.False(t, sub.setConcurrency(2))
+	assert.Equal(t, 1, sub.adaptive.reserved)
+
+	// Completes as handlers are released
+	for range 9 {
+		<-sub.handleChan
+	}
+	assert.True(t, sub.setConcurrency(2))
+	assert.Equal(t, 8, sub.adaptive.reserved)
+}
+
+// settlementRecorder records how messages are settled; bulk messages are settled concurrently.
+type settlementRecorder struct {
+	Receiver
+	lock    sync.Mutex
+	settled map[string]string
+}
+
+func (r *settlementRecorder) settle(m *azservicebus.ReceivedMessage, outcome string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.settled[m.MessageID] = outcome
+	return nil
+}
+
+func (r *settlementRecorder) outcomes() map[string]string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return maps.Clone(r.settled)
+}
+
+func (r *settlementRecorder) CompleteMessage(_ context.Context, m *azservicebus.ReceivedMessage, _ *azservicebus.CompleteMessageOptions) error {
+	return r.settle(m, "completed")
+}
+
+func (r *settlementRecorder) AbandonMessage(_ context.Context, m *azservicebus.ReceivedMessage, _ *azservicebus.AbandonMessageOptions) error {
+	return r.settle(m, "abandoned")
+}
+
+func (r *settlementRecorder) DeferMessage(_ context.Context, m *azservicebus.ReceivedMessage, _ *azservicebus.DeferMessageOptions) error {
+	return r.settle(m, "deferred")
+}
+
+func TestDeferMessage(t *testing.T) {
+	newSub := func(maxBulkSubCount *int) *Subscription {
+		return NewSubscription(SubscriptionOptions{
+			MaxActiveMessages: 10,
+			MaxBulkSubCount:   maxBulkSubCount,
+			MaxRetriableEPS:   10,
+			TimeoutInSec:      5,
+			Entity:            "test",
+		}, logger.NewLogger("test"))
+	}
+	newMsg := func(id string, seq int64) *azservicebus.ReceivedMessage {
+		return &azservicebus.ReceivedMessage{MessageID: id, SequenceNumber: ptr.Of(seq)}
+	}
+
+	t.Run("handler defers the message", func(t *testing.T) {
+		sub := newSub(nil)
+		receiver := &settlementRecorder{settled: map[string]string{}}
+		sub.activeOperationsChan <- struct{}{}
+		sub.handleAsync(t.Context(), []*azservicebus.ReceivedMessage{newMsg("a", 1)}, func(context.Context, []*azservicebus.ReceivedMessage) ([]HandlerResponseItem, error) {
+			return nil, fmt.Errorf("not ready: %w", ErrDeferMessage)
+		}, receiver)
+
+		assert.Equal(t, map[string]string{"a": "deferred"}, receiver.outcomes())
+	})
+
+	t.Run("bulk handler defers some messages", func(t *testing.T) {
+		sub := newSub(ptr.Of(3))
+		receiver := &settlementRecorder{settled: map[string]string{}}
+		sub.activeOperationsChan <- struct{}{}
+		sub.handleAsync(t.Context(), []*azservicebus.ReceivedMessage{newMsg("a", 1), newMsg("b", 2), newMsg("c", 3)}, func(context.Context, []*azservicebus.ReceivedMessage) ([]HandlerResponseItem, error) {
+			return []HandlerResponseItem{
+				{EntryId: "a"},
+				{EntryId: "b", Error: ErrDeferMessage},
+				{EntryId: "c", Error: errors.New("failed")},
+			}, errors.New("some messages failed")
+		}, receiver)
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			assert.Equal(c, map[string]string{"a": "completed", "b": "deferred", "c": "abandoned"}, receiver.outcomes())
+		}, time.Second, 10*time.Millisecond)
+	})
+}
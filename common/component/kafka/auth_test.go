@@ -14,6 +14,7 @@ limitations under the License.
 package kafka
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -24,6 +25,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/IBM/sarama"
 	"github.com/stretchr/testify/require"
 )
@@ -123,3 +126,78 @@ func TestAuth(t *testing.T) {
 		require.Nil(t, mockConfig.Net.TLS.Config)
 	})
 }
+
+type fakeTokenCredential struct {
+	scopes []string
+	calls  int
+	expiry time.Duration
+}
+
+func (c *fakeTokenCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.scopes = opts.Scopes
+	c.calls++
+	return azcore.AccessToken{Token: fmt.Sprintf("token-%d", c.calls), ExpiresOn: time.Now().Add(c.expiry)}, nil
+}
+
+func TestAzureADAuth(t *testing.T) {
+	k := getKafka()
+
+	t.Run("scope from broker", func(t *testing.T) {
+		scope, err := azureADScope([]string{"mynamespace.servicebus.windows.net:9093", "other:9093"})
+		require.NoError(t, err)
+		require.Equal(t, "https://mynamespace.servicebus.windows.net/.default", scope)
+
+		scope, err = azureADScope([]string{"mynamespace.servicebus.windows.net"})
+		require.NoError(t, err)
+		require.Equal(t, "https://mynamespace.servicebus.windows.net/.default", scope)
+	})
+
+	t.Run("TLS cannot be disabled", func(t *testing.T) {
+		m := getAuthBaseMetadata()
+		m[authType] = azureADAuthType
+		m["disableTls"] = "true"
+
+		_, err := k.getKafkaMetadata(m)
+		require.Error(t, err)
+	})
+
+	t.Run("SASL OAUTHBEARER is configured", func(t *testing.T) {
+		m := getAuthBaseMetadata()
+		m[authType] = azureADAuthType
+		m["brokers"] = "mynamespace.servicebus.windows.net:9093"
+		m["azureClientId"] = "a"
+		m["azureClientSecret"] = "b"
+		m["azureTenantId"] = "c"
+
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+
+		config := sarama.NewConfig()
+		err = updateAzureADAuthInfo(config, meta, m)
+		require.NoError(t, err)
+		require.True(t, config.Net.SASL.Enable)
+		require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+		require.IsType(t, &AzureADTokenSource{}, config.Net.SASL.TokenProvider)
+	})
+
+	t.Run("tokens are cached until close to expiry", func(t *testing.T) {
+		cred := &fakeTokenCredential{expiry: time.Hour}
+		ts := &AzureADTokenSource{credential: cred, scopes: []string{"https://ns/.default"}}
+
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "token-1", token.Token)
+		token, err = ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "token-1", token.Token)
+		require.Equal(t, []string{"https://ns/.default"}, cred.scopes)
+
+		cred.expiry = time.Minute
+		ts.cachedToken = azcore.AccessToken{}
+		_, err = ts.Token()
+		require.NoError(t, err)
+		token, err = ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "token-3", token.Token)
+	})
+}
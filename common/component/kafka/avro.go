@@ -0,0 +1,539 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	avroLogicalTypeDecimal         = "decimal"
+	avroLogicalTypeDate            = "date"
+	avroLogicalTypeTimestampMillis = "timestamp-millis"
+	avroLogicalTypeTimestampMicros = "timestamp-micros"
+
+	avroDateLayout = "2006-01-02"
+)
+
+// AvroSchema is a parsed Avro schema that can be walked alongside a standard JSON value.
+type AvroSchema struct {
+	root  any
+	names map[string]any
+}
+
+// NewAvroSchema parses and validates an Avro schema.
+func NewAvroSchema(schema string) (*AvroSchema, error) {
+	if _, err := goavro.NewCodecForStandardJSONFull(schema); err != nil {
+		return nil, err
+	}
+	var root any
+	if err := json.Unmarshal([]byte(schema), &root); err != nil {
+		return nil, err
+	}
+	s := &AvroSchema{
+		root:  root,
+		names: make(map[string]any),
+	}
+	s.collectNames(root, "")
+	return s, nil
+}
+
+// GetValueReaderSchema returns the Avro reader schema set with the `avroReaderSchema` metadata property, if any.
+func GetValueReaderSchema(metadata map[string]string) (*AvroSchema, error) {
+	val, ok := kitmd.GetMetadataProperty(metadata, avroReaderSchema)
+	if !ok || val == "" {
+		return nil, nil
+	}
+	s, err := NewAvroSchema(val)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Avro reader schema: %w", err)
+	}
+	return s, nil
+}
+
+// collectNames registers the named types (records, enums and fixed) so that they can be resolved when referenced.
+func (s *AvroSchema) collectNames(schema any, namespace string) {
+	switch sc := schema.(type) {
+	case []any:
+		for _, branch := range sc {
+			s.collectNames(branch, namespace)
+		}
+	case map[string]any:
+		typ, _ := sc["type"].(string)
+		switch typ {
+		case "record", "error", "enum", "fixed":
+			name, _ := sc["name"].(string)
+			if ns, ok := sc["namespace"].(string); ok {
+				namespace = ns
+			}
+			if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+				namespace = name[:idx]
+				name = name[idx+1:]
+			}
+			s.names[name] = sc
+			if namespace != "" {
+				s.names[namespace+"."+name] = sc
+			}
+		}
+		switch typ {
+		case "record", "error":
+			fields, _ := sc["fields"].([]any)
+			for _, f := range fields {
+				if field, ok := f.(map[string]any); ok {
+					s.collectNames(field["type"], namespace)
+				}
+			}
+		case "array":
+			s.collectNames(sc["items"], namespace)
+		case "map":
+			s.collectNames(sc["values"], namespace)
+		default:
+			s.collectNames(sc["type"], namespace)
+		}
+	}
+}
+
+// resolve replaces references to named types with their definition.
+func (s *AvroSchema) resolve(schema any) any {
+	if name, ok := schema.(string); ok {
+		if def, ok := s.names[name]; ok {
+			return def
+		}
+	}
+	return schema
+}
+
+// typeName returns the Avro type name of a (resolved) schema.
+func (s *AvroSchema) typeName(schema any) string {
+	switch sc := s.resolve(schema).(type) {
+	case string:
+		return sc
+	case map[string]any:
+		if typ, ok := sc["type"].(string); ok {
+			return typ
+		}
+		return s.typeName(sc["type"])
+	}
+	return ""
+}
+
+// matches reports whether a standard JSON value can be encoded with the given union branch.
+func (s *AvroSchema) matches(schema any, value any) bool {
+	var logicalType string
+	if sc, ok := s.resolve(schema).(map[string]any); ok {
+		logicalType, _ = sc["logicalType"].(string)
+	}
+	switch s.typeName(schema) {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "int", "long":
+		if _, ok := value.(string); ok {
+			return logicalType == avroLogicalTypeDate || logicalType == avroLogicalTypeTimestampMillis || logicalType == avroLogicalTypeTimestampMicros
+		}
+		_, ok := value.(json.Number)
+		return ok
+	case "float", "double":
+		_, ok := value.(json.Number)
+		return ok
+	case "string", "bytes", "fixed", "enum":
+		if _, ok := value.(json.Number); ok {
+			return logicalType == avroLogicalTypeDecimal
+		}
+		_, ok := value.(string)
+		return ok
+	case "record", "error", "map":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	}
+	return false
+}
+
+// avroLogicalValueFunc converts a value whose schema carries a logical type.
+type avroLogicalValueFunc func(schema map[string]any, logicalType string, value any) (any, error)
+
+// walk visits the value alongside the schema and replaces every value annotated with a logical type with the result of fn.
+func (s *AvroSchema) walk(schema any, value any, fn avroLogicalValueFunc) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch sc := s.resolve(schema).(type) {
+	case []any:
+		for _, branch := range sc {
+			if s.matches(branch, value) {
+				return s.walk(branch, value, fn)
+			}
+		}
+	case map[string]any:
+		if logicalType, ok := sc["logicalType"].(string); ok {
+			return fn(sc, logicalType, value)
+		}
+		switch sc["type"] {
+		case "record", "error":
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return value, nil
+			}
+			fields, _ := sc["fields"].([]any)
+			for _, f := range fields {
+				field, _ := f.(map[string]any)
+				name, _ := field["name"].(string)
+				v, ok := obj[name]
+				if !ok {
+					continue
+				}
+				converted, err := s.walk(field["type"], v, fn)
+				if err != nil {
+					return nil, fmt.Errorf("field '%s': %w", name, err)
+				}
+				obj[name] = converted
+			}
+		case "array":
+			arr, ok := value.([]any)
+			if !ok {
+				return value, nil
+			}
+			for i, v := range arr {
+				converted, err := s.walk(sc["items"], v, fn)
+				if err != nil {
+					return nil, err
+				}
+				arr[i] = converted
+			}
+		case "map":
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return value, nil
+			}
+			for k, v := range obj {
+				converted, err := s.walk(sc["values"], v, fn)
+				if err != nil {
+					return nil, err
+				}
+				obj[k] = converted
+			}
+		default:
+			if _, ok := sc["type"].(string); !ok {
+				return s.walk(sc["type"], value, fn)
+			}
+		}
+	}
+	return value, nil
+}
+
+// project resolves a value written with another schema against this (reader) schema,
+// following the Avro schema resolution rules: fields unknown to the reader are dropped,
+// fields missing from the data are filled with the reader default and unknown enum
+// symbols are replaced with the reader enum default.
+func (s *AvroSchema) project(schema any, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch sc := s.resolve(schema).(type) {
+	case []any:
+		for _, branch := range sc {
+			if s.matches(branch, value) {
+				return s.project(branch, value)
+			}
+		}
+		return nil, errors.New("value does not match any branch of the reader union")
+	case map[string]any:
+		if _, ok := sc["logicalType"]; ok {
+			return value, nil
+		}
+		switch sc["type"] {
+		case "record", "error":
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected a record, got %T", value)
+			}
+			fields, _ := sc["fields"].([]any)
+			res := make(map[string]any, len(fields))
+			for _, f := range fields {
+				field, _ := f.(map[string]any)
+				name, _ := field["name"].(string)
+				v, ok := lookupAvroField(obj, name, field["aliases"])
+				if !ok {
+					def, hasDefault := field["default"]
+					if !hasDefault {
+						return nil, fmt.Errorf("reader field '%s' is missing from the data and has no default", name)
+					}
+					res[name] = def
+					continue
+				}
+				projected, err := s.project(field["type"], v)
+				if err != nil {
+					return nil, fmt.Errorf("field '%s': %w", name, err)
+				}
+				res[name] = projected
+			}
+			return res, nil
+		case "enum":
+			symbol, _ := value.(string)
+			symbols, _ := sc["symbols"].([]any)
+			for _, sym := range symbols {
+				if sym == symbol {
+					return value, nil
+				}
+			}
+			if def, ok := sc["default"]; ok {
+				return def, nil
+			}
+			return nil, fmt.Errorf("enum symbol '%s' is not known to the reader schema", symbol)
+		case "array":
+			arr, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("expected an array, got %T", value)
+			}
+			res := make([]any, len(arr))
+			for i, v := range arr {
+				projected, err := s.project(sc["items"], v)
+				if err != nil {
+					return nil, err
+				}
+				res[i] = projected
+			}
+			return res, nil
+		case "map":
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected a map, got %T", value)
+			}
+			res := make(map[string]any, len(obj))
+			for k, v := range obj {
+				projected, err := s.project(sc["values"], v)
+				if err != nil {
+					return nil, err
+				}
+				res[k] = projected
+			}
+			return res, nil
+		default:
+			if _, ok := sc["type"].(string); !ok {
+				return s.project(sc["type"], value)
+			}
+		}
+	}
+	return value, nil
+}
+
+func lookupAvroField(obj map[string]any, name string, aliases any) (any, bool) {
+	if v, ok := obj[name]; ok {
+		return v, true
+	}
+	list, _ := aliases.([]any)
+	for _, a := range list {
+		if alias, ok := a.(string); ok {
+			if v, ok := obj[alias]; ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// toAvroTextual converts the JSON published by the application into the textual form expected by the Avro codec.
+// Decimals can be given as JSON numbers or strings and timestamps and dates as RFC 3339 strings.
+func (s *AvroSchema) toAvroTextual(data []byte) ([]byte, error) {
+	value, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	value, err = s.walk(s.root, value, avroLogicalValueToTextual)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// fromAvroTextual converts the textual form produced by the Avro codec into the JSON delivered to the application,
+// optionally resolving it against a reader schema first.
+func fromAvroTextual(data []byte, writer *AvroSchema, reader *AvroSchema, logicalTypes bool) ([]byte, error) {
+	value, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	schema := writer
+	if reader != nil {
+		value, err = reader.project(reader.root, value)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving value with the reader schema: %w", err)
+		}
+		schema = reader
+	}
+	if logicalTypes && schema != nil {
+		value, err = schema.walk(schema.root, value, avroLogicalValueFromTextual)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(value)
+}
+
+func decodeJSONValue(data []byte) (any, error) {
+	var value any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func avroLogicalValueToTextual(schema map[string]any, logicalType string, value any) (any, error) {
+	switch logicalType {
+	case avroLogicalTypeDecimal:
+		var str string
+		switch v := value.(type) {
+		case json.Number:
+			str = v.String()
+		case string:
+			str = v
+		default:
+			return value, nil
+		}
+		r, ok := new(big.Rat).SetString(str)
+		if !ok {
+			return nil, fmt.Errorf("invalid decimal value '%s'", str)
+		}
+		precision, scale := avroDecimalPrecisionAndScale(schema)
+		unscaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+		if !unscaled.IsInt() {
+			return nil, fmt.Errorf("decimal value '%s' has more than %d fractional digits", str, scale)
+		}
+		n := unscaled.Num()
+		if precision > 0 && len(new(big.Int).Abs(n).String()) > precision {
+			return nil, fmt.Errorf("decimal value '%s' exceeds precision %d", str, precision)
+		}
+		b := toAvroSignedBytes(n)
+		if schema["type"] == "fixed" {
+			size, _ := schema["size"].(float64)
+			if len(b) > int(size) {
+				return nil, fmt.Errorf("decimal value '%s' does not fit in fixed size %d", str, int(size))
+			}
+			pad := byte(0)
+			if n.Sign() < 0 {
+				pad = 0xff
+			}
+			b = append(bytes.Repeat([]byte{pad}, int(size)-len(b)), b...)
+		}
+		return avroTextualBytes(b), nil
+	case avroLogicalTypeTimestampMillis, avroLogicalTypeTimestampMicros:
+		str, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value '%s': %w", logicalType, str, err)
+		}
+		if logicalType == avroLogicalTypeTimestampMicros {
+			return json.Number(strconv.FormatInt(t.UnixMicro(), 10)), nil
+		}
+		return json.Number(strconv.FormatInt(t.UnixMilli(), 10)), nil
+	case avroLogicalTypeDate:
+		str, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+		t, err := time.Parse(avroDateLayout, str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date value '%s': %w", str, err)
+		}
+		return json.Number(strconv.FormatInt(t.Unix()/(24*60*60), 10)), nil
+	}
+	return value, nil
+}
+
+func avroLogicalValueFromTextual(schema map[string]any, logicalType string, value any) (any, error) {
+	if logicalType != avroLogicalTypeDecimal {
+		return value, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	// Avro encodes bytes in JSON as a string with one code point per byte
+	b := make([]byte, 0, len(str))
+	for _, r := range str {
+		if r > 0xff {
+			return nil, fmt.Errorf("invalid decimal bytes: code point %U is out of range", r)
+		}
+		b = append(b, byte(r))
+	}
+	_, scale := avroDecimalPrecisionAndScale(schema)
+	unscaled := fromAvroSignedBytes(b)
+	return new(big.Rat).SetFrac(unscaled, pow10(scale)).FloatString(scale), nil
+}
+
+func avroDecimalPrecisionAndScale(schema map[string]any) (int, int) {
+	precision, _ := schema["precision"].(float64)
+	scale, _ := schema["scale"].(float64)
+	return int(precision), int(scale)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// toAvroSignedBytes returns the big-endian two's-complement representation of n.
+func toAvroSignedBytes(n *big.Int) []byte {
+	if n.Sign() >= 0 {
+		b := n.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	size := (n.BitLen() + 8) / 8
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(size*8)) //nolint:gosec
+	return new(big.Int).Add(n, mod).Bytes()
+}
+
+// fromAvroSignedBytes parses a big-endian two's-complement integer.
+func fromAvroSignedBytes(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8))) //nolint:gosec
+	}
+	return n
+}
+
+// avroTextualBytes marshals to the Avro JSON encoding of bytes, which uses one escaped code point per byte.
+type avroTextualBytes []byte
+
+func (b avroTextualBytes) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, len(b)*6+2)
+	buf = append(buf, '"')
+	for _, c := range b {
+		buf = fmt.Appendf(buf, `\u%04x`, c)
+	}
+	return append(buf, '"'), nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroSignedBytes(t *testing.T) {
+	for _, n := range []int64{0, 1, -1, 127, 128, -128, -129, 1234, -1234, 1 << 40, -(1 << 40)} {
+		b := toAvroSignedBytes(big.NewInt(n))
+		require.Equal(t, n, fromAvroSignedBytes(b).Int64(), "value %d encoded as %x", n, b)
+	}
+	require.Equal(t, []byte{0x04, 0xd2}, toAvroSignedBytes(big.NewInt(1234)))
+	require.Equal(t, []byte{0x00, 0x80}, toAvroSignedBytes(big.NewInt(128)))
+	require.Equal(t, []byte{0xff}, toAvroSignedBytes(big.NewInt(-1)))
+}
+
+func TestAvroSchemaProject(t *testing.T) {
+	s, err := NewAvroSchema(`{"type": "record", "name": "r", "namespace": "ns", "fields": [
+		{"name": "color", "type": {"type": "enum", "name": "color", "symbols": ["RED", "UNKNOWN"], "default": "UNKNOWN"}},
+		{"name": "renamed", "aliases": ["old"], "type": "int"},
+		{"name": "child", "type": ["null", {"type": "record", "name": "child", "fields": [{"name": "x", "type": "int", "default": 1}]}], "default": null},
+		{"name": "children", "type": {"type": "array", "items": "ns.child"}, "default": []}
+	]}`)
+	require.NoError(t, err)
+
+	value, err := decodeJSONValue([]byte(`{"color": "BLUE", "old": 3, "child": {"y": 2}, "extra": true}`))
+	require.NoError(t, err)
+	act, err := s.project(s.root, value)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"color":    "UNKNOWN",
+		"renamed":  value.(map[string]any)["old"],
+		"child":    map[string]any{"x": float64(1)},
+		"children": []any{},
+	}, act)
+}
@@ -41,6 +41,20 @@ func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 	if err != nil {
 		return fmt.Errorf("error getting bulk handler config for topic %s: %w", claim.Topic(), err)
 	}
+
+	// The partition is tracked from its first message, as the initial offset of the claim may not be resolved
+	var untrackPartition func()
+	defer func() {
+		if untrackPartition != nil {
+			untrackPartition()
+		}
+	}()
+	trackPartition := func(message *sarama.ConsumerMessage) {
+		if untrackPartition == nil {
+			untrackPartition = consumer.k.trackPartition(message.Topic, message.Partition, claim, message.Offset)
+		}
+	}
+
 	if isBulkSubscribe {
 		ticker := time.NewTicker(time.Duration(handlerConfig.SubscribeConfig.MaxAwaitDurationMs) * time.Millisecond)
 		defer ticker.Stop()
@@ -52,6 +66,7 @@ func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 			case message := <-claim.Messages():
 				consumer.mutex.Lock()
 				if message != nil {
+					trackPartition(message)
 					messages = append(messages, message)
 					if len(messages) >= handlerConfig.SubscribeConfig.MaxMessagesCount {
 						consumer.flushBulkMessages(claim, messages, session, handlerConfig.BulkHandler, b)
@@ -82,8 +97,14 @@ func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 				if !ok {
 					return nil
 				}
+				trackPartition(message)
 
-				if consumer.k.consumeRetryEnabled {
+				if consumer.k.deadLetterPolicy.Enabled() {
+					if !consumer.k.handleMessageWithDeadLetter(session.Context(), message) {
+						return nil
+					}
+					session.MarkMessage(message, "")
+				} else if consumer.k.consumeRetryEnabled {
 					if err := retry.NotifyRecover(func() error {
 						return consumer.doCallback(session, message)
 					}, b, func(err error, d time.Duration) {
@@ -106,6 +127,7 @@ func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 						consumer.k.logger.Errorf("Error processing Kafka message: %s/%d/%d [key=%s]. Error: %v.", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), err)
 					}
 				}
+				consumer.k.markProcessed(message)
 			}
 		}
 	}
@@ -116,6 +138,7 @@ func (consumer *consumer) flushBulkMessages(claim sarama.ConsumerGroupClaim,
 	handler BulkEventHandler, b backoff.BackOff,
 ) error {
 	if len(messages) > 0 {
+		defer consumer.k.markProcessed(messages[len(messages)-1])
 		if consumer.k.consumeRetryEnabled {
 			if err := retry.NotifyRecover(func() error {
 				return consumer.doBulkCallback(session, messages, handler, claim.Topic())
@@ -188,8 +211,17 @@ func (consumer *consumer) doBulkCallback(session sarama.ConsumerGroupSession,
 }
 
 func (consumer *consumer) doCallback(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) error {
-	consumer.k.logger.Debugf("Processing Kafka message: %s/%d/%d [key=%s]", message.Topic, message.Partition, message.Offset, asBase64String(message.Key))
-	handlerConfig, err := consumer.k.GetTopicHandlerConfig(message.Topic)
+	err := consumer.k.handleMessage(session.Context(), message)
+	if err == nil {
+		session.MarkMessage(message, "")
+	}
+	return err
+}
+
+// handleMessage delivers a message to the handler of its topic.
+func (k *Kafka) handleMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	k.logger.Debugf("Processing Kafka message: %s/%d/%d [key=%s]", message.Topic, message.Partition, message.Offset, asBase64String(message.Key))
+	handlerConfig, err := k.GetTopicHandlerConfig(message.Topic)
 	if err != nil {
 		return err
 	}
@@ -197,7 +229,7 @@ func (consumer *consumer) doCallback(session sarama.ConsumerGroupSession, messag
 		return errors.New("invalid handler config for subscribe call")
 	}
 
-	messageVal, err := consumer.k.DeserializeValue(message, handlerConfig)
+	messageVal, err := k.DeserializeValue(message, handlerConfig)
 	if err != nil {
 		return err
 	}
@@ -205,13 +237,9 @@ func (consumer *consumer) doCallback(session sarama.ConsumerGroupSession, messag
 		Topic: message.Topic,
 		Data:  messageVal,
 	}
-	event.Metadata = GetEventMetadata(message, consumer.k.escapeHeaders)
+	event.Metadata = GetEventMetadata(message, k.escapeHeaders)
 
-	err = handlerConfig.Handler(session.Context(), &event)
-	if err == nil {
-		session.MarkMessage(message, "")
-	}
-	return err
+	return handlerConfig.Handler(ctx, &event)
 }
 
 func GetEventMetadata(message *sarama.ConsumerMessage, escapeHeaders bool) map[string]string {
@@ -245,6 +273,7 @@ func (consumer *consumer) Cleanup(sarama.ConsumerGroupSession) error {
 }
 
 func (consumer *consumer) Setup(sarama.ConsumerGroupSession) error {
+	consumer.k.markRebalanced()
 	return nil
 }
 
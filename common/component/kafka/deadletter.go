@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// handleMessageWithDeadLetter delivers a message up to maxDeliveries times, waiting the redelivery backoff between
+// deliveries, then publishes it to the dead-letter topic.
+// Kafka has no native dead-letter topics, so the deliveries are counted by the consumer.
+// It returns false if the context was canceled before the message was handled, in which case it must not be marked.
+func (k *Kafka) handleMessageWithDeadLetter(ctx context.Context, message *sarama.ConsumerMessage) bool {
+	msg := deadLetterMessage(message)
+	for deliveries := 1; ; deliveries++ {
+		err := k.handleMessage(ctx, message)
+		if err == nil {
+			if deliveries > 1 {
+				k.logger.Infof("Successfully processed Kafka message after it previously failed: %s/%d/%d [key=%s]", message.Topic, message.Partition, message.Offset, asBase64String(message.Key))
+			}
+			return true
+		}
+		k.logger.Warnf("Error processing Kafka message: %s/%d/%d [key=%s] after %d deliveries. Error: %v.", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), deliveries, err)
+
+		err = k.deadLetterPolicy.HandleFailure(ctx, msg, deliveries, err, k.publishDeadLetter)
+		if err == nil {
+			k.logger.Errorf("Stopped delivering Kafka message: %s/%d/%d [key=%s] after %d deliveries", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), deliveries)
+			return true
+		}
+
+		k.deadLetterPolicy.WaitBackoff(ctx)
+		if ctx.Err() != nil {
+			return false
+		}
+	}
+}
+
+func (k *Kafka) publishDeadLetter(ctx context.Context, req *pubsub.PublishRequest) error {
+	return k.Publish(ctx, req.Topic, req.Data, req.Metadata)
+}
+
+// deadLetterMessage returns the message published to the dead-letter topic: the raw value, with the key and the
+// headers of the consumed message.
+func deadLetterMessage(message *sarama.ConsumerMessage) *pubsub.NewMessage {
+	md := make(map[string]string, len(message.Headers)+1)
+	for _, header := range message.Headers {
+		md[string(header.Key)] = string(header.Value)
+	}
+	if message.Key != nil {
+		md[keyMetadataKey] = string(message.Key)
+	}
+	return &pubsub.NewMessage{
+		Topic:    message.Topic,
+		Data:     message.Value,
+		Metadata: md,
+	}
+}
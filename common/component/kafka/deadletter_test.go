@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+func TestHandleMessageWithDeadLetter(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Topic:   "orders",
+		Key:     []byte("order-1"),
+		Value:   []byte("hello"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("a"), Value: []byte("b")}},
+	}
+
+	t.Run("dead-lettered after max deliveries", func(t *testing.T) {
+		k := arrangeKafkaWithAssertions(t, func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, "orders-dlq", msg.Topic)
+			assert.Equal(t, sarama.StringEncoder("order-1"), msg.Key)
+			value, _ := msg.Value.Encode()
+			assert.Equal(t, []byte("hello"), value)
+			assert.ElementsMatch(t, getSaramaHeadersFromMetadata(map[string]string{
+				"a":                               "b",
+				keyMetadataKey:                    "order-1",
+				pubsub.DeadLetterOriginalTopicKey: "orders",
+				pubsub.DeadLetterDeliveriesKey:    "3",
+				pubsub.DeadLetterErrorKey:         "failed",
+			}), msg.Headers)
+			return nil
+		})
+		k.deadLetterPolicy = pubsub.DeadLetterPolicy{MaxDeliveries: 3, DeadLetterTopic: "orders-dlq"}
+		deliveries := 0
+		k.subscribeTopics = TopicHandlerConfig{"orders": SubscriptionHandlerConfig{
+			Handler: func(ctx context.Context, msg *NewEvent) error {
+				deliveries++
+				return errors.New("failed")
+			},
+		}}
+
+		require.True(t, k.handleMessageWithDeadLetter(t.Context(), message))
+		assert.Equal(t, 3, deliveries)
+	})
+
+	t.Run("succeeds after failing", func(t *testing.T) {
+		k := arrangeKafkaWithAssertions(t)
+		k.deadLetterPolicy = pubsub.DeadLetterPolicy{MaxDeliveries: 3, DeadLetterTopic: "orders-dlq"}
+		deliveries := 0
+		k.subscribeTopics = TopicHandlerConfig{"orders": SubscriptionHandlerConfig{
+			Handler: func(ctx context.Context, msg *NewEvent) error {
+				deliveries++
+				if deliveries == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			},
+		}}
+
+		require.True(t, k.handleMessageWithDeadLetter(t.Context(), message))
+		assert.Equal(t, 2, deliveries)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		k := arrangeKafkaWithAssertions(t)
+		k.deadLetterPolicy = pubsub.DeadLetterPolicy{MaxDeliveries: 3, DeadLetterTopic: "orders-dlq"}
+		ctx, cancel := context.WithCancel(t.Context())
+		k.subscribeTopics = TopicHandlerConfig{"orders": SubscriptionHandlerConfig{
+			Handler: func(ctx context.Context, msg *NewEvent) error {
+				cancel()
+				return errors.New("failed")
+			},
+		}}
+
+		require.False(t, k.handleMessageWithDeadLetter(ctx, message))
+	})
+}
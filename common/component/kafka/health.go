@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// ConsumerHealth is a snapshot of the state of the consumers of the component.
+type ConsumerHealth struct {
+	// Number of times the consumer group session was set up, which happens every time partitions are rebalanced.
+	Rebalances    int64             `json:"rebalances"`
+	LastRebalance time.Time         `json:"lastRebalance,omitzero"`
+	Partitions    []PartitionHealth `json:"partitions"`
+}
+
+// PartitionHealth is the state of the consumption of a partition.
+type PartitionHealth struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Number of messages that have not been consumed yet, or -1 if no message has been consumed yet.
+	Lag int64 `json:"lag"`
+	// Time the partition was claimed or its last message was processed.
+	LastActivity time.Time `json:"lastActivity"`
+	// Stalled is true if there are messages to consume but none was processed for longer than consumerStallTimeout.
+	Stalled bool `json:"stalled"`
+}
+
+// highWaterMarker is implemented by the consumer group claims and the partition consumers.
+type highWaterMarker interface {
+	HighWaterMarkOffset() int64
+}
+
+// partitionProgress tracks the consumption of a partition.
+type partitionProgress struct {
+	hwm          highWaterMarker
+	nextOffset   atomic.Int64
+	lastActivity atomic.Int64
+}
+
+// trackPartition starts tracking the consumption of a partition, from the initial offset if it is known.
+// The returned function stops tracking it.
+func (k *Kafka) trackPartition(topic string, partition int32, hwm highWaterMarker, initialOffset int64) func() {
+	key := topicPartition{topic: topic, partition: partition}
+	progress := &partitionProgress{hwm: hwm}
+	progress.nextOffset.Store(max(initialOffset, -1))
+	progress.lastActivity.Store(time.Now().UnixNano())
+	k.partitionProgress.Store(key, progress)
+	return func() {
+		k.partitionProgress.CompareAndDelete(key, progress)
+	}
+}
+
+// markProcessed records that a message was processed, successfully or not.
+func (k *Kafka) markProcessed(message *sarama.ConsumerMessage) {
+	val, ok := k.partitionProgress.Load(topicPartition{topic: message.Topic, partition: message.Partition})
+	if !ok {
+		return
+	}
+	progress := val.(*partitionProgress)
+	progress.nextOffset.Store(message.Offset + 1)
+	progress.lastActivity.Store(time.Now().UnixNano())
+}
+
+// markRebalanced records that partitions were rebalanced in the consumer group.
+func (k *Kafka) markRebalanced() {
+	k.rebalances.Add(1)
+	k.lastRebalance.Store(time.Now().UnixNano())
+}
+
+// ConsumerHealth returns the lag of the consumed partitions and the number of rebalances.
+func (k *Kafka) ConsumerHealth() ConsumerHealth {
+	health := ConsumerHealth{
+		Rebalances: k.rebalances.Load(),
+		Partitions: []PartitionHealth{},
+	}
+	if last := k.lastRebalance.Load(); last != 0 {
+		health.LastRebalance = time.Unix(0, last)
+	}
+
+	now := time.Now()
+	k.partitionProgress.Range(func(key, val any) bool {
+		tp := key.(topicPartition)
+		progress := val.(*partitionProgress)
+		p := PartitionHealth{
+			Topic:        tp.topic,
+			Partition:    tp.partition,
+			Lag:          -1,
+			LastActivity: time.Unix(0, progress.lastActivity.Load()),
+		}
+		if next := progress.nextOffset.Load(); next >= 0 {
+			p.Lag = max(progress.hwm.HighWaterMarkOffset()-next, 0)
+		}
+		p.Stalled = k.consumerStallTimeout > 0 && p.Lag > 0 && now.Sub(p.LastActivity) > k.consumerStallTimeout
+		health.Partitions = append(health.Partitions, p)
+		return true
+	})
+	slices.SortFunc(health.Partitions, func(a, b PartitionHealth) int {
+		if c := strings.Compare(a.Topic, b.Topic); c != 0 {
+			return c
+		}
+		return int(a.Partition - b.Partition)
+	})
+
+	return health
+}
+
+// Ping checks that the brokers can be reached, and that no consumed partition is stalled.
+func (k *Kafka) Ping(ctx context.Context) error {
+	if k.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	if err := k.pingBrokers(ctx); err != nil {
+		return fmt.Errorf("kafka error: failed to connect to brokers: %w", err)
+	}
+
+	var stalled []string
+	for _, p := range k.ConsumerHealth().Partitions {
+		if p.Stalled {
+			stalled = append(stalled, fmt.Sprintf("%s/%d (lag %d)", p.Topic, p.Partition, p.Lag))
+		}
+	}
+	if len(stalled) > 0 {
+		return fmt.Errorf("kafka error: no message was processed for more than %s on partitions: %s", k.consumerStallTimeout, strings.Join(stalled, ", "))
+	}
+	return nil
+}
+
+// pingBrokers connects to the brokers and fetches the metadata of the cluster.
+func (k *Kafka) pingBrokers(ctx context.Context) error {
+	if k.mockProducer != nil || k.mockConsumerGroup != nil || k.mockConsumer != nil {
+		return nil
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		client, err := sarama.NewClient(k.brokers, k.config)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer client.Close()
+		if len(client.Brokers()) == 0 {
+			errCh <- errors.New("no broker available")
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	saramamocks "github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
+)
+
+type fakeHighWaterMark int64
+
+func (h fakeHighWaterMark) HighWaterMarkOffset() int64 {
+	return int64(h)
+}
+
+func TestConsumerHealth(t *testing.T) {
+	k := &Kafka{
+		logger:               logger.NewLogger("kafka_test"),
+		mockProducer:         saramamocks.NewSyncProducer(t, nil),
+		consumerStallTimeout: time.Minute,
+	}
+
+	t.Run("lag of the tracked partitions", func(t *testing.T) {
+		untrackA := k.trackPartition("a", 1, fakeHighWaterMark(10), 4)
+		untrackB := k.trackPartition("a", 0, fakeHighWaterMark(10), sarama.OffsetNewest)
+		defer untrackB()
+
+		health := k.ConsumerHealth()
+		require.Len(t, health.Partitions, 2)
+		assert.Equal(t, int32(0), health.Partitions[0].Partition)
+		assert.Equal(t, int64(-1), health.Partitions[0].Lag)
+		assert.Equal(t, int32(1), health.Partitions[1].Partition)
+		assert.Equal(t, int64(6), health.Partitions[1].Lag)
+		assert.False(t, health.Partitions[1].Stalled)
+
+		k.markProcessed(&sarama.ConsumerMessage{Topic: "a", Partition: 1, Offset: 8})
+		health = k.ConsumerHealth()
+		assert.Equal(t, int64(1), health.Partitions[1].Lag)
+
+		untrackA()
+		assert.Len(t, k.ConsumerHealth().Partitions, 1)
+	})
+
+	t.Run("partitions with lag and no recent activity are stalled", func(t *testing.T) {
+		defer k.trackPartition("b", 0, fakeHighWaterMark(10), 4)()
+		defer k.trackPartition("c", 0, fakeHighWaterMark(4), 4)()
+		require.NoError(t, k.Ping(t.Context()))
+
+		for _, topic := range []string{"b", "c"} {
+			val, _ := k.partitionProgress.Load(topicPartition{topic: topic})
+			val.(*partitionProgress).lastActivity.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+		}
+
+		health := k.ConsumerHealth()
+		assert.True(t, health.Partitions[0].Stalled)
+		assert.False(t, health.Partitions[1].Stalled)
+
+		err := k.Ping(t.Context())
+		require.ErrorContains(t, err, "b/0 (lag 6)")
+		assert.NotContains(t, err.Error(), "c/0")
+	})
+
+	t.Run("rebalances are counted", func(t *testing.T) {
+		c := &consumer{k: k}
+		require.NoError(t, c.Setup(nil))
+		require.NoError(t, c.Setup(nil))
+
+		health := k.ConsumerHealth()
+		assert.Equal(t, int64(2), health.Rebalances)
+		assert.WithinDuration(t, time.Now(), health.LastRebalance, time.Minute)
+	})
+}
+
+func TestPingBrokers(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Metadata.Retry.Max = 0
+	config.Net.DialTimeout = 100 * time.Millisecond
+	k := &Kafka{
+		logger:  logger.NewLogger("kafka_test"),
+		brokers: []string{"127.0.0.1:1"},
+		config:  config,
+	}
+
+	err := k.Ping(t.Context())
+	require.ErrorContains(t, err, "failed to connect to brokers")
+}
@@ -40,8 +40,12 @@ type Kafka struct {
 	// These are used to inject mocked clients for tests
 	mockConsumerGroup sarama.ConsumerGroup
 	mockProducer      sarama.SyncProducer
+	mockConsumer      sarama.Consumer
 	clients           *clients
 
+	// Transactional producers can only run one transaction at a time
+	producerTxnLock sync.Mutex
+
 	maxMessageBytes int
 	consumerGroup   string
 	brokers         []string
@@ -62,6 +66,9 @@ type Kafka struct {
 	closed          atomic.Bool
 	wg              sync.WaitGroup
 
+	// Offsets the assigned partitions resume from, by topic and partition
+	partitionOffsets sync.Map
+
 	// schema registry settings
 	srClient                   srclient.ISchemaRegistryClient
 	schemaCachingEnabled       bool
@@ -69,6 +76,15 @@ type Kafka struct {
 	latestSchemaCacheTTL       time.Duration
 	latestSchemaCacheWriteLock sync.RWMutex
 	latestSchemaCacheReadLock  sync.Mutex
+	avroSchemas                sync.Map
+	avroLogicalTypesEnabled    bool
+	schemaCompatibilityLevel   srclient.CompatibilityLevel
+	compatibilityLevelSubjects sync.Map
+	subjectNameStrategy        string
+	schemaAutoRegistration     bool
+	registeredSchemas          sync.Map
+	protobufSchemas            sync.Map
+	jsonSchemas                sync.Map
 
 	// used for background logic that cannot use the context passed to the Init function
 	internalContext       context.Context
@@ -81,6 +97,13 @@ type Kafka struct {
 	DefaultConsumeRetryEnabled bool
 	consumeRetryEnabled        bool
 	consumeRetryInterval       time.Duration
+	deadLetterPolicy           pubsub.DeadLetterPolicy
+
+	// consumer health
+	consumerStallTimeout time.Duration
+	partitionProgress    sync.Map
+	rebalances           atomic.Int64
+	lastRebalance        atomic.Int64
 }
 
 type SchemaType int
@@ -88,6 +111,8 @@ type SchemaType int
 const (
 	None SchemaType = iota
 	Avro
+	Protobuf
+	JSONSchema
 )
 
 type SchemaCacheEntry struct {
@@ -109,6 +134,10 @@ func parseSchemaType(sVal string) (SchemaType, error) {
 	switch strings.ToLower(sVal) {
 	case "avro":
 		return Avro, nil
+	case "protobuf":
+		return Protobuf, nil
+	case "jsonschema", "json":
+		return JSONSchema, nil
 	case "none":
 		return None, nil
 	default:
@@ -156,6 +185,21 @@ func (k *Kafka) Init(ctx context.Context, metadata map[string]string) error {
 	config.ChannelBufferSize = meta.channelBufferSize
 
 	config.Producer.Compression = meta.internalCompression
+	config.Producer.CompressionLevel = meta.CompressionLevel
+	config.Producer.Flush.Bytes = meta.ProducerBatchBytes
+	config.Producer.Flush.Messages = meta.ProducerBatchMessages
+	config.Producer.Flush.Frequency = meta.ProducerLinger
+	config.Net.MaxOpenRequests = meta.ProducerMaxInFlightRequests
+	config.Producer.Partitioner = newPartitioner(meta.internalPartitioner)
+	config.Consumer.IsolationLevel = meta.internalConsumerIsolationLevel
+
+	// Transactional producers are always idempotent
+	if meta.ProducerIdempotent || meta.TransactionalID != "" {
+		config.Producer.Idempotent = true
+		// Required by Sarama to guarantee the ordering of the retried messages
+		config.Net.MaxOpenRequests = 1
+	}
+	config.Producer.Transaction.ID = meta.TransactionalID
 
 	config.Net.KeepAlive = meta.ClientConnectionKeepAliveInterval
 	config.Metadata.RefreshFrequency = meta.ClientConnectionTopicMetadataRefreshInterval
@@ -187,6 +231,12 @@ func (k *Kafka) Init(ctx context.Context, metadata map[string]string) error {
 		if err != nil {
 			return err
 		}
+	case azureADAuthType:
+		k.logger.Info("Configuring SASL OAuth2 authentication with Azure AD")
+		err = updateAzureADAuthInfo(config, meta, metadata)
+		if err != nil {
+			return err
+		}
 	case certificateAuthType:
 		// already handled in updateTLSConfig
 	case awsIAMAuthType:
@@ -227,7 +277,12 @@ func (k *Kafka) Init(ctx context.Context, metadata map[string]string) error {
 		return rerr
 	}
 	k.consumeRetryEnabled = meta.ConsumeRetryEnabled
+	k.deadLetterPolicy, err = pubsub.NewDeadLetterPolicy(upgradedMetadata)
+	if err != nil {
+		return err
+	}
 	k.consumeRetryInterval = meta.ConsumeRetryInterval
+	k.consumerStallTimeout = meta.ConsumerStallTimeout
 
 	if meta.SchemaRegistryURL != "" {
 		k.logger.Infof("Schema registry URL '%s' provided. Configuring the Schema Registry client.", meta.SchemaRegistryURL)
@@ -243,6 +298,10 @@ func (k *Kafka) Init(ctx context.Context, metadata map[string]string) error {
 			k.logger.Debugf("Schema cache TTL: %v", meta.SchemaLatestVersionCacheTTL)
 			k.latestSchemaCacheTTL = meta.SchemaLatestVersionCacheTTL
 		}
+		k.avroLogicalTypesEnabled = meta.AvroLogicalTypesEnabled
+		k.schemaCompatibilityLevel = meta.internalSchemaCompatibilityLevel
+		k.subjectNameStrategy = meta.SchemaSubjectNameStrategy
+		k.schemaAutoRegistration = meta.SchemaAutoRegistrationEnabled
 	}
 
 	clients, err := k.latestClients()
@@ -383,19 +442,42 @@ func (k *Kafka) DeserializeValue(message *sarama.ConsumerMessage, config Subscri
 		if err != nil {
 			return nil, err
 		}
-		return value, nil
+		if !k.avroLogicalTypesEnabled && config.ValueReaderSchema == nil {
+			return value, nil
+		}
+		avroSchema, err := k.getAvroSchema(schema)
+		if err != nil {
+			return nil, err
+		}
+		return fromAvroTextual(value, avroSchema, config.ValueReaderSchema, k.avroLogicalTypesEnabled)
+	case Protobuf:
+		schema, payload, err := k.getRecordSchema(message.Value)
+		if err != nil {
+			return nil, err
+		}
+		return k.protobufToJSON(schema, payload)
+	case JSONSchema:
+		// JSON Schema values are JSON documents, which were validated when they were published
+		_, payload, err := k.getRecordSchema(message.Value)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return message.Value, nil
 	}
 }
 
-func (k *Kafka) getLatestSchema(topic string) (*srclient.Schema, *goavro.Codec, error) {
+// getLatestSchema returns the latest schema of the subject, and its codec if it's an Avro schema.
+func (k *Kafka) getLatestSchema(subject string, schemaType SchemaType) (*srclient.Schema, *goavro.Codec, error) {
 	srClient, err := k.getSchemaRegistyClient()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	subject := getSchemaSubject(topic)
+	if err = k.ensureSchemaCompatibilityLevel(srClient, subject); err != nil {
+		return nil, nil, err
+	}
 	if k.schemaCachingEnabled {
 		k.latestSchemaCacheReadLock.Lock()
 		cacheEntry, ok := k.latestSchemaCache[subject]
@@ -410,9 +492,7 @@ func (k *Kafka) getLatestSchema(topic string) (*srclient.Schema, *goavro.Codec,
 		if errSchema != nil {
 			return nil, nil, errSchema
 		}
-		// New JSON standard serialization/Deserialization is not integrated in srclient yet.
-		// Since standard json is passed from dapr, it is needed.
-		codec, errCodec := goavro.NewCodecForStandardJSONFull(schema.Schema())
+		codec, errCodec := newSchemaCodec(schema, schemaType)
 		if errCodec != nil {
 			return nil, nil, errCodec
 		}
@@ -421,11 +501,11 @@ func (k *Kafka) getLatestSchema(topic string) (*srclient.Schema, *goavro.Codec,
 		k.latestSchemaCacheWriteLock.Unlock()
 		return schema, codec, nil
 	}
-	schema, err := srClient.GetLatestSchema(getSchemaSubject(topic))
+	schema, err := srClient.GetLatestSchema(subject)
 	if err != nil {
 		return nil, nil, err
 	}
-	codec, err := goavro.NewCodecForStandardJSONFull(schema.Schema())
+	codec, err := newSchemaCodec(schema, schemaType)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -433,6 +513,45 @@ func (k *Kafka) getLatestSchema(topic string) (*srclient.Schema, *goavro.Codec,
 	return schema, codec, nil
 }
 
+// newSchemaCodec returns the codec of Avro schemas, and nil for the other schema types.
+func newSchemaCodec(schema *srclient.Schema, schemaType SchemaType) (*goavro.Codec, error) {
+	if schemaType != Avro {
+		return nil, nil
+	}
+	// New JSON standard serialization/Deserialization is not integrated in srclient yet.
+	// Since standard json is passed from dapr, it is needed.
+	return goavro.NewCodecForStandardJSONFull(schema.Schema())
+}
+
+// ensureSchemaCompatibilityLevel sets the configured compatibility level on the subject the first time it is used.
+func (k *Kafka) ensureSchemaCompatibilityLevel(srClient srclient.ISchemaRegistryClient, subject string) error {
+	if k.schemaCompatibilityLevel == "" {
+		return nil
+	}
+	if _, ok := k.compatibilityLevelSubjects.Load(subject); ok {
+		return nil
+	}
+	if _, err := srClient.ChangeSubjectCompatibilityLevel(subject, k.schemaCompatibilityLevel); err != nil {
+		return fmt.Errorf("failed to set compatibility level '%s' on subject '%s': %w", k.schemaCompatibilityLevel, subject, err)
+	}
+	k.compatibilityLevelSubjects.Store(subject, struct{}{})
+	return nil
+}
+
+// getAvroSchema returns the parsed form of a registry schema, used to convert logical types and resolve reader schemas.
+// Schema IDs are immutable in the registry, so parsed schemas are kept for the lifetime of the component.
+func (k *Kafka) getAvroSchema(schema *srclient.Schema) (*AvroSchema, error) {
+	if cached, ok := k.avroSchemas.Load(schema.ID()); ok {
+		return cached.(*AvroSchema), nil
+	}
+	avroSchema, err := NewAvroSchema(schema.Schema())
+	if err != nil {
+		return nil, err
+	}
+	k.avroSchemas.Store(schema.ID(), avroSchema)
+	return avroSchema, nil
+}
+
 func (k *Kafka) getSchemaRegistyClient() (srclient.ISchemaRegistryClient, error) {
 	if k.srClient == nil {
 		return nil, errors.New("schema registry details not set")
@@ -455,11 +574,22 @@ func (k *Kafka) SerializeValue(topic string, data []byte, metadata map[string]st
 
 	switch valueSchemaType {
 	case Avro:
-		schema, codec, err := k.getLatestSchema(topic)
+		schema, codec, err := k.getValueSchema(topic, valueSchemaType, metadata)
 		if err != nil {
 			return nil, err
 		}
 
+		if k.avroLogicalTypesEnabled {
+			avroSchema, err := k.getAvroSchema(schema)
+			if err != nil {
+				return nil, err
+			}
+			data, err = avroSchema.toAvroTextual(data)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		native, _, err := codec.NativeFromTextual(data)
 		if err != nil {
 			return nil, err
@@ -469,14 +599,27 @@ func (k *Kafka) SerializeValue(topic string, data []byte, metadata map[string]st
 		if err != nil {
 			return nil, err
 		}
-		schemaIDBytes := make([]byte, 4)
-		binary.BigEndian.PutUint32(schemaIDBytes, uint32(schema.ID())) //nolint:gosec
-
-		recordValue := make([]byte, 0, len(schemaIDBytes)+len(valueBytes)+1)
-		recordValue = append(recordValue, byte(0))
-		recordValue = append(recordValue, schemaIDBytes...)
-		recordValue = append(recordValue, valueBytes...)
-		return recordValue, nil
+		return newRecordValue(schema, valueBytes), nil
+	case Protobuf:
+		schema, _, err := k.getValueSchema(topic, valueSchemaType, metadata)
+		if err != nil {
+			return nil, err
+		}
+		recordName, _ := kitmd.GetMetadataProperty(metadata, valueSchemaRecordName)
+		valueBytes, err := k.protobufFromJSON(schema, recordName, data)
+		if err != nil {
+			return nil, err
+		}
+		return newRecordValue(schema, valueBytes), nil
+	case JSONSchema:
+		schema, _, err := k.getValueSchema(topic, valueSchemaType, metadata)
+		if err != nil {
+			return nil, err
+		}
+		if err = k.validateJSON(schema, data); err != nil {
+			return nil, err
+		}
+		return newRecordValue(schema, data), nil
 	default:
 		return data, nil
 	}
@@ -495,6 +638,10 @@ type SubscriptionHandlerConfig struct {
 	BulkHandler     BulkEventHandler
 	Handler         EventHandler
 	ValueSchemaType SchemaType
+	// ValueReaderSchema is an optional Avro reader schema the consumed values are resolved against.
+	ValueReaderSchema *AvroSchema
+	// PartitionAssignment, if set, consumes the topic from the assigned partitions instead of the consumer group.
+	PartitionAssignment *PartitionAssignment
 }
 
 // NewEvent is an event arriving from a message bus instance.
@@ -44,6 +44,18 @@ func TestGetValueSchemaType(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("valueSchemaType='PROTOBUF', return Protobuf", func(t *testing.T) {
+		act, err := GetValueSchemaType(map[string]string{"valueSchemaType": "PROTOBUF"})
+		require.Equal(t, Protobuf, act)
+		require.NoError(t, err)
+	})
+
+	t.Run("valueSchemaType='JSONSCHEMA', return JSONSchema", func(t *testing.T) {
+		act, err := GetValueSchemaType(map[string]string{"valueSchemaType": "JSONSCHEMA"})
+		require.Equal(t, JSONSchema, act)
+		require.NoError(t, err)
+	})
+
 	t.Run("valueSchemaType='XXX', return Error", func(t *testing.T) {
 		_, err := GetValueSchemaType(map[string]string{"valueSchemaType": "XXX"})
 		require.Error(t, err)
@@ -355,6 +367,154 @@ func TestLatestSchemaCaching(t *testing.T) {
 	})
 }
 
+var testLogicalTypesSchema = `{"type": "record", "name": "order", "fields": [
+	{"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+	{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+	{"name": "discount", "type": ["null", {"type": "fixed", "name": "discount", "size": 4, "logicalType": "decimal", "precision": 6, "scale": 3}], "default": null},
+	{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+]}`
+
+func TestAvroLogicalTypes(t *testing.T) {
+	registry := srclient.CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, _ := registry.CreateSchema("orders-value", testLogicalTypesSchema, srclient.Avro)
+
+	k := Kafka{
+		srClient:                registry,
+		avroLogicalTypesEnabled: true,
+		logger:                  logger.NewLogger("kafka_test"),
+	}
+	handlerConfig := SubscriptionHandlerConfig{
+		ValueSchemaType: Avro,
+	}
+	meta := map[string]string{"valueSchemaType": "Avro"}
+
+	roundTrip := func(t *testing.T, value string) map[string]any {
+		t.Helper()
+		serialized, err := k.SerializeValue("orders", []byte(value), meta)
+		require.NoError(t, err)
+		require.Equal(t, schema.ID(), int(binary.BigEndian.Uint32(serialized[1:5])))
+
+		act, err := k.DeserializeValue(&sarama.ConsumerMessage{Value: serialized, Topic: "orders"}, handlerConfig)
+		require.NoError(t, err)
+		var actMap map[string]any
+		require.NoError(t, json.Unmarshal(act, &actMap))
+		return actMap
+	}
+
+	t.Run("decimals and timestamps round-trip", func(t *testing.T) {
+		act := roundTrip(t, `{"id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "amount": "12.34", "discount": -0.5, "created": 1700000000123}`)
+		require.Equal(t, map[string]any{
+			"id":       "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+			"amount":   "12.34",
+			"discount": "-0.500",
+			"created":  float64(1700000000123),
+		}, act)
+	})
+
+	t.Run("timestamp as RFC 3339 string", func(t *testing.T) {
+		act := roundTrip(t, `{"id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "amount": 1, "discount": null, "created": "2023-11-14T22:13:20.123Z"}`)
+		require.Equal(t, "1.00", act["amount"])
+		require.Nil(t, act["discount"])
+		require.Equal(t, float64(1700000000123), act["created"])
+	})
+
+	t.Run("decimal with too many fractional digits, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "amount": "1.234", "created": 0}`), meta)
+		require.Error(t, err)
+	})
+
+	t.Run("decimal exceeding precision, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "amount": "123456789.00", "created": 0}`), meta)
+		require.Error(t, err)
+	})
+}
+
+func TestAvroReaderSchema(t *testing.T) {
+	registry := srclient.CreateMockSchemaRegistryClient("http://localhost:8081")
+	registry.CreateSchema("my-topic-value", testSchema1, srclient.Avro)
+
+	k := Kafka{
+		srClient: registry,
+		logger:   logger.NewLogger("kafka_test"),
+	}
+	valJSON, _ := json.Marshal(testValue1)
+	serialized, err := k.SerializeValue("my-topic", valJSON, map[string]string{"valueSchemaType": "Avro"})
+	require.NoError(t, err)
+	msg := &sarama.ConsumerMessage{Value: serialized, Topic: "my-topic"}
+
+	t.Run("fields are added with defaults and removed", func(t *testing.T) {
+		readerSchema, err := GetValueReaderSchema(map[string]string{
+			"avroReaderSchema": `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "string", "default": "regular"}]}`,
+		})
+		require.NoError(t, err)
+
+		act, err := k.DeserializeValue(msg, SubscriptionHandlerConfig{ValueSchemaType: Avro, ValueReaderSchema: readerSchema})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"flavor": "chocolate", "size": "regular"}`, string(act))
+	})
+
+	t.Run("missing field without default, return error", func(t *testing.T) {
+		readerSchema, err := GetValueReaderSchema(map[string]string{
+			"avroReaderSchema": `{"type": "record", "name": "cupcake", "fields": [{"name": "size", "type": "string"}]}`,
+		})
+		require.NoError(t, err)
+
+		_, err = k.DeserializeValue(msg, SubscriptionHandlerConfig{ValueSchemaType: Avro, ValueReaderSchema: readerSchema})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid reader schema, return error", func(t *testing.T) {
+		_, err := GetValueReaderSchema(map[string]string{"avroReaderSchema": `{"type": "record"}`})
+		require.Error(t, err)
+	})
+
+	t.Run("no reader schema", func(t *testing.T) {
+		readerSchema, err := GetValueReaderSchema(map[string]string{})
+		require.NoError(t, err)
+		require.Nil(t, readerSchema)
+	})
+}
+
+func TestSchemaCompatibilityLevelApplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	registry := srclient.CreateMockSchemaRegistryClient("http://locahost:8081")
+	m := mock_srclient.NewMockISchemaRegistryClient(ctrl)
+	schema, _ := registry.CreateSchema("my-topic-value", testSchema1, srclient.Avro)
+
+	t.Run("compatibility level set once per subject", func(t *testing.T) {
+		k := Kafka{
+			srClient:                 m,
+			schemaCompatibilityLevel: srclient.Backward,
+			logger:                   logger.NewLogger("kafka_test"),
+		}
+
+		m.EXPECT().ChangeSubjectCompatibilityLevel(gomock.Eq("my-topic-value"), gomock.Eq(srclient.Backward)).Return(nil, nil).Times(1)
+		m.EXPECT().GetLatestSchema(gomock.Eq("my-topic-value")).Return(schema, nil).Times(2)
+
+		valJSON, _ := json.Marshal(testValue1)
+		for range 2 {
+			act, err := k.SerializeValue("my-topic", valJSON, map[string]string{"valueSchemaType": "Avro"})
+			require.NoError(t, err)
+			assertValueSerialized(t, act, valJSON, schema)
+		}
+	})
+
+	t.Run("registry error, return error", func(t *testing.T) {
+		k := Kafka{
+			srClient:                 m,
+			schemaCompatibilityLevel: srclient.Full,
+			logger:                   logger.NewLogger("kafka_test"),
+		}
+
+		m.EXPECT().ChangeSubjectCompatibilityLevel(gomock.Eq("my-topic-value"), gomock.Eq(srclient.Full)).Return(nil, errors.New("forbidden")).Times(1)
+
+		valJSON, _ := json.Marshal(testValue1)
+		_, err := k.SerializeValue("my-topic", valJSON, map[string]string{"valueSchemaType": "Avro"})
+		require.Error(t, err)
+	})
+}
+
 func TestValidateAWS(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -22,12 +22,14 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/riferrei/srclient"
 
 	"github.com/dapr/kit/metadata"
 )
 
 const (
 	key                                      = "partitionKey"
+	partitionNumber                          = "partition"
 	keyMetadataKey                           = "__key"
 	timestampMetadataKey                     = "__timestamp"
 	offsetMetadataKey                        = "__offset"
@@ -44,15 +46,29 @@ const (
 	oidcAuthType                             = "oidc"
 	mtlsAuthType                             = "mtls"
 	awsIAMAuthType                           = "awsiam"
+	azureADAuthType                          = "azuread"
 	noAuthType                               = "none"
 	consumerFetchMin                         = "consumerFetchMin"
 	consumerFetchDefault                     = "consumerFetchDefault"
 	channelBufferSize                        = "channelBufferSize"
 	valueSchemaType                          = "valueSchemaType"
+	avroReaderSchema                         = "avroReaderSchema"
+	valueSchema                              = "valueSchema"
+	valueSchemaRecordName                    = "valueSchemaRecordName"
+	schemaSubjectNameStrategy                = "schemaSubjectNameStrategy"
+	subjectNameStrategyTopic                 = "topic"
+	subjectNameStrategyRecord                = "record"
+	subjectNameStrategyTopicRecord           = "topicrecord"
 	compression                              = "compression"
 	consumerGroupRebalanceStrategyRange      = "range"
 	consumerGroupRebalanceStrategySticky     = "sticky"
 	consumerGroupRebalanceStrategyRoundRobin = "roundrobin"
+	isolationLevelReadUncommitted            = "read_uncommitted"
+	isolationLevelReadCommitted              = "read_committed"
+	partitionerHash                          = "hash"
+	partitionerRoundRobin                    = "roundrobin"
+	partitionerSticky                        = "sticky"
+	partitionerMurmur2                       = "murmur2"
 
 	// Kafka client config default values.
 	// Refresh interval < keep alive time so that way connection can be kept alive indefinitely if desired.
@@ -107,15 +123,35 @@ type KafkaMetadata struct {
 	ConsumerGroupRebalanceStrategy string `mapstructure:"consumerGroupRebalanceStrategy"`
 
 	// configs for kafka producer
-	Compression         string                  `mapstructure:"compression"`
-	internalCompression sarama.CompressionCodec `mapstructure:"-"`
+	Compression                 string                        `mapstructure:"compression"`
+	internalCompression         sarama.CompressionCodec       `mapstructure:"-"`
+	CompressionLevel            int                           `mapstructure:"compressionLevel"`
+	ProducerBatchBytes          int                           `mapstructure:"producerBatchBytes"`
+	ProducerBatchMessages       int                           `mapstructure:"producerBatchMessages"`
+	ProducerLinger              time.Duration                 `mapstructure:"producerLinger"`
+	ProducerMaxInFlightRequests int                           `mapstructure:"producerMaxInFlightRequests"`
+	ProducerIdempotent          bool                          `mapstructure:"producerIdempotent"`
+	TransactionalID             string                        `mapstructure:"transactionalID"`
+	Partitioner                 string                        `mapstructure:"partitioner"`
+	internalPartitioner         sarama.PartitionerConstructor `mapstructure:"-"`
+
+	// configs for kafka consumer
+	ConsumerIsolationLevel         string                `mapstructure:"consumerIsolationLevel"`
+	ConsumerStallTimeout           time.Duration         `mapstructure:"consumerStallTimeout"`
+	internalConsumerIsolationLevel sarama.IsolationLevel `mapstructure:"-"`
 
 	// schema registry
-	SchemaRegistryURL           string        `mapstructure:"schemaRegistryURL"`
-	SchemaRegistryAPIKey        string        `mapstructure:"schemaRegistryAPIKey"`
-	SchemaRegistryAPISecret     string        `mapstructure:"schemaRegistryAPISecret"`
-	SchemaCachingEnabled        bool          `mapstructure:"schemaCachingEnabled"`
-	SchemaLatestVersionCacheTTL time.Duration `mapstructure:"schemaLatestVersionCacheTTL"`
+	SchemaRegistryURL             string        `mapstructure:"schemaRegistryURL"`
+	SchemaRegistryAPIKey          string        `mapstructure:"schemaRegistryAPIKey"`
+	SchemaRegistryAPISecret       string        `mapstructure:"schemaRegistryAPISecret"`
+	SchemaCachingEnabled          bool          `mapstructure:"schemaCachingEnabled"`
+	SchemaLatestVersionCacheTTL   time.Duration `mapstructure:"schemaLatestVersionCacheTTL"`
+	SchemaCompatibilityLevel      string        `mapstructure:"schemaCompatibilityLevel"`
+	AvroLogicalTypesEnabled       bool          `mapstructure:"avroLogicalTypesEnabled"`
+	SchemaSubjectNameStrategy     string        `mapstructure:"schemaSubjectNameStrategy"`
+	SchemaAutoRegistrationEnabled bool          `mapstructure:"schemaAutoRegistrationEnabled"`
+
+	internalSchemaCompatibilityLevel srclient.CompatibilityLevel `mapstructure:"-"`
 }
 
 // upgradeMetadata updates metadata properties based on deprecated usage.
@@ -158,6 +194,9 @@ func (k *Kafka) getKafkaMetadata(meta map[string]string) (*KafkaMetadata, error)
 		ConsumeRetryInterval:                         100 * time.Millisecond,
 		internalVersion:                              sarama.V2_0_0_0, //nolint:nosnakecase
 		internalCompression:                          sarama.CompressionNone,
+		CompressionLevel:                             sarama.CompressionLevelDefault,
+		ProducerMaxInFlightRequests:                  5,
+		internalPartitioner:                          sarama.NewHashPartitioner,
 		channelBufferSize:                            256,
 		consumerFetchMin:                             1,
 		consumerFetchDefault:                         1024 * 1024,
@@ -270,6 +309,11 @@ func (k *Kafka) getKafkaMetadata(meta map[string]string) (*KafkaMetadata, error)
 		k.logger.Debug("Configuring root certificate authentication.")
 	case awsIAMAuthType:
 		k.logger.Debug("Configuring AWS IAM authentication.")
+	case azureADAuthType:
+		if m.TLSDisable {
+			return nil, errors.New("kafka error: TLS cannot be disabled for authType 'azuread'")
+		}
+		k.logger.Debug("Configuring SASL token authentication via Azure AD.")
 	default:
 		return nil, errors.New("kafka error: invalid value for 'authType' attribute")
 	}
@@ -311,6 +355,39 @@ func (k *Kafka) getKafkaMetadata(meta map[string]string) (*KafkaMetadata, error)
 		m.internalCompression = compression
 	}
 
+	// zstd requires Kafka 2.1.0, so the default version is raised unless a version is set explicitly
+	if m.internalCompression == sarama.CompressionZSTD {
+		if m.Version == "" {
+			m.internalVersion = sarama.V2_1_0_0 //nolint:nosnakecase
+		} else if !m.internalVersion.IsAtLeast(sarama.V2_1_0_0) { //nolint:nosnakecase
+			return nil, errors.New("kafka error: zstd compression requires kafka version 2.1.0 or later")
+		}
+	}
+
+	if m.ProducerBatchBytes < 0 || m.ProducerBatchMessages < 0 || m.ProducerLinger < 0 {
+		return nil, errors.New("kafka error: 'producerBatchBytes', 'producerBatchMessages' and 'producerLinger' cannot be negative")
+	}
+
+	if m.ProducerMaxInFlightRequests <= 0 {
+		return nil, fmt.Errorf("kafka error: invalid value for 'producerMaxInFlightRequests' attribute: %d", m.ProducerMaxInFlightRequests)
+	}
+
+	if m.Partitioner != "" {
+		partitioner, err := parsePartitioner(m.Partitioner)
+		if err != nil {
+			return nil, err
+		}
+		m.internalPartitioner = partitioner
+	}
+
+	if m.ConsumerIsolationLevel != "" {
+		level, err := parseIsolationLevel(m.ConsumerIsolationLevel)
+		if err != nil {
+			return nil, err
+		}
+		m.internalConsumerIsolationLevel = level
+	}
+
 	if val, ok := meta[channelBufferSize]; ok && val != "" {
 		v, err := strconv.Atoi(val)
 		if err != nil {
@@ -338,6 +415,19 @@ func (k *Kafka) getKafkaMetadata(meta map[string]string) (*KafkaMetadata, error)
 		m.consumerFetchMin = int32(v)
 	}
 
+	if m.SchemaCompatibilityLevel != "" {
+		level, err := parseSchemaCompatibilityLevel(m.SchemaCompatibilityLevel)
+		if err != nil {
+			return nil, err
+		}
+		m.internalSchemaCompatibilityLevel = level
+	}
+
+	m.SchemaSubjectNameStrategy, err = parseSubjectNameStrategy(m.SchemaSubjectNameStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	// confirm client connection fields are valid
 	if m.ClientConnectionTopicMetadataRefreshInterval <= 0 {
 		m.ClientConnectionTopicMetadataRefreshInterval = defaultClientConnectionTopicMetadataRefreshInterval
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/riferrei/srclient"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/kit/logger"
@@ -301,6 +302,31 @@ func TestInitialOffset(t *testing.T) {
 	require.Equal(t, sarama.OffsetNewest, meta.internalInitialOffset)
 }
 
+func TestSchemaCompatibilityLevel(t *testing.T) {
+	k := getKafka()
+
+	t.Run("not set", func(t *testing.T) {
+		meta, err := k.getKafkaMetadata(getBaseMetadata())
+		require.NoError(t, err)
+		require.Empty(t, meta.internalSchemaCompatibilityLevel)
+	})
+
+	t.Run("valid level", func(t *testing.T) {
+		m := getBaseMetadata()
+		m["schemaCompatibilityLevel"] = "backward_transitive"
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+		require.Equal(t, srclient.BackwardTransitive, meta.internalSchemaCompatibilityLevel)
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		m := getBaseMetadata()
+		m["schemaCompatibilityLevel"] = "sideways"
+		_, err := k.getKafkaMetadata(m)
+		require.Error(t, err)
+	})
+}
+
 func TestTls(t *testing.T) {
 	k := getKafka()
 
@@ -426,6 +452,57 @@ func TestMetadataProducerValues(t *testing.T) {
 		require.Equal(t, defaultClientConnectionKeepAliveInterval, meta.ClientConnectionKeepAliveInterval)
 	})
 
+	t.Run("setting producer batching values explicitly", func(t *testing.T) {
+		k := getKafka()
+		m := getCompleteMetadata()
+		m[compression] = "lz4"
+		m["compressionLevel"] = "9"
+		m["producerBatchBytes"] = "65536"
+		m["producerBatchMessages"] = "500"
+		m["producerLinger"] = "20ms"
+		m["producerMaxInFlightRequests"] = "2"
+
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+		require.Equal(t, sarama.CompressionLZ4, meta.internalCompression)
+		require.Equal(t, 9, meta.CompressionLevel)
+		require.Equal(t, 65536, meta.ProducerBatchBytes)
+		require.Equal(t, 500, meta.ProducerBatchMessages)
+		require.Equal(t, 20*time.Millisecond, meta.ProducerLinger)
+		require.Equal(t, 2, meta.ProducerMaxInFlightRequests)
+	})
+
+	t.Run("zstd compression raises the default version", func(t *testing.T) {
+		k := getKafka()
+		m := getCompleteMetadata()
+		m[compression] = "zstd"
+
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+		require.Equal(t, sarama.CompressionZSTD, meta.internalCompression)
+		require.Equal(t, sarama.V2_1_0_0, meta.internalVersion) //nolint:nosnakecase
+
+		m["version"] = "1.0.0"
+		_, err = k.getKafkaMetadata(m)
+		require.Error(t, err)
+	})
+
+	t.Run("setting invalid producer batching values", func(t *testing.T) {
+		k := getKafka()
+		for key, val := range map[string]string{
+			"producerBatchBytes":          "-1",
+			"producerLinger":              "-1s",
+			"producerMaxInFlightRequests": "0",
+		} {
+			m := getCompleteMetadata()
+			m[key] = val
+
+			meta, err := k.getKafkaMetadata(m)
+			require.Error(t, err, key)
+			require.Nil(t, meta)
+		}
+	})
+
 	t.Run("setting producer invalid compression value", func(t *testing.T) {
 		k := getKafka()
 		m := getCompleteMetadata()
@@ -438,6 +515,43 @@ func TestMetadataProducerValues(t *testing.T) {
 	})
 }
 
+func TestMetadataTransactionValues(t *testing.T) {
+	t.Run("using default values", func(t *testing.T) {
+		k := getKafka()
+		m := getCompleteMetadata()
+
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+		require.False(t, meta.ProducerIdempotent)
+		require.Empty(t, meta.TransactionalID)
+		require.Equal(t, sarama.ReadUncommitted, meta.internalConsumerIsolationLevel)
+	})
+
+	t.Run("setting transaction values explicitly", func(t *testing.T) {
+		k := getKafka()
+		m := getCompleteMetadata()
+		m["producerIdempotent"] = "true"
+		m["transactionalID"] = "myapp-txn"
+		m["consumerIsolationLevel"] = "read_committed"
+
+		meta, err := k.getKafkaMetadata(m)
+		require.NoError(t, err)
+		require.True(t, meta.ProducerIdempotent)
+		require.Equal(t, "myapp-txn", meta.TransactionalID)
+		require.Equal(t, sarama.ReadCommitted, meta.internalConsumerIsolationLevel)
+	})
+
+	t.Run("setting invalid isolation level", func(t *testing.T) {
+		k := getKafka()
+		m := getCompleteMetadata()
+		m["consumerIsolationLevel"] = "serializable"
+
+		meta, err := k.getKafkaMetadata(m)
+		require.Error(t, err)
+		require.Nil(t, meta)
+	})
+}
+
 func TestMetadataChannelBufferSize(t *testing.T) {
 	k := getKafka()
 	m := getCompleteMetadata()
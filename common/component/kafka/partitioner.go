@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// Number of keyless messages the sticky partitioner sends to a partition before switching to another one.
+const stickyPartitionBatchSize = 100
+
+// messageMetadata is passed through the Metadata field of the produced messages.
+type messageMetadata struct {
+	// ID of the bulk message entry, used to map errors to entries
+	entryID string
+	// Partition set with the `partition` metadata property, or -1
+	partition int32
+}
+
+// newMessageMetadata returns the metadata of a message, reading the partition from the `partition` metadata property.
+func newMessageMetadata(entryID string, metadata map[string]string) (messageMetadata, error) {
+	md := messageMetadata{entryID: entryID, partition: -1}
+	val, ok := metadata[partitionNumber]
+	if !ok || val == "" {
+		return md, nil
+	}
+	partition, err := strconv.ParseInt(val, 10, 32)
+	if err != nil || partition < 0 {
+		return md, fmt.Errorf("invalid value '%s' for the '%s' metadata property", val, partitionNumber)
+	}
+	md.partition = int32(partition)
+	return md, nil
+}
+
+// newPartitioner returns a constructor of partitioners that send messages to the partition they were published to,
+// if any, or use the partitioner of the strategy otherwise.
+func newPartitioner(strategy sarama.PartitionerConstructor) sarama.PartitionerConstructor {
+	return func(topic string) sarama.Partitioner {
+		return &explicitPartitioner{strategy: strategy(topic)}
+	}
+}
+
+type explicitPartitioner struct {
+	strategy sarama.Partitioner
+}
+
+func (p *explicitPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if md, ok := message.Metadata.(messageMetadata); ok && md.partition >= 0 {
+		if md.partition >= numPartitions {
+			return -1, fmt.Errorf("partition %d does not exist, the topic has %d partitions", md.partition, numPartitions)
+		}
+		return md.partition, nil
+	}
+	return p.strategy.Partition(message, numPartitions)
+}
+
+func (p *explicitPartitioner) RequiresConsistency() bool {
+	return true
+}
+
+func (p *explicitPartitioner) MessageRequiresConsistency(message *sarama.ProducerMessage) bool {
+	if md, ok := message.Metadata.(messageMetadata); ok && md.partition >= 0 {
+		return true
+	}
+	if dp, ok := p.strategy.(sarama.DynamicConsistencyPartitioner); ok {
+		return dp.MessageRequiresConsistency(message)
+	}
+	return p.strategy.RequiresConsistency()
+}
+
+// NewMurmur2Partitioner returns a partitioner that hashes keys like the default partitioner of the Java client,
+// so that producers written with both clients send messages with the same key to the same partition.
+// Messages without a key are sent to a random partition.
+func NewMurmur2Partitioner(topic string) sarama.Partitioner {
+	return sarama.NewCustomPartitioner(
+		sarama.WithAbsFirst(),
+		sarama.WithCustomHashFunction(newMurmur2),
+	)(topic)
+}
+
+// NewStickyPartitioner returns a partitioner that hashes keys like NewMurmur2Partitioner, and sends batches of
+// messages without a key to the same partition to reduce the number of requests.
+func NewStickyPartitioner(topic string) sarama.Partitioner {
+	return &stickyPartitioner{
+		keyed:  NewMurmur2Partitioner(topic),
+		random: sarama.NewRandomPartitioner(topic),
+	}
+}
+
+type stickyPartitioner struct {
+	keyed     sarama.Partitioner
+	random    sarama.Partitioner
+	partition int32
+	count     int
+}
+
+func (p *stickyPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if message.Key != nil {
+		return p.keyed.Partition(message, numPartitions)
+	}
+	if p.count == 0 || p.count >= stickyPartitionBatchSize || p.partition >= numPartitions {
+		partition, err := p.random.Partition(message, numPartitions)
+		if err != nil {
+			return -1, err
+		}
+		p.partition = partition
+		p.count = 0
+	}
+	p.count++
+	return p.partition, nil
+}
+
+func (p *stickyPartitioner) RequiresConsistency() bool {
+	return true
+}
+
+func (p *stickyPartitioner) MessageRequiresConsistency(message *sarama.ProducerMessage) bool {
+	return message.Key != nil
+}
+
+// murmur2 is the 32-bit MurmurHash2 used by the Java client to partition messages.
+type murmur2 struct {
+	data []byte
+}
+
+func newMurmur2() hash.Hash32 {
+	return &murmur2{}
+}
+
+func (m *murmur2) Write(p []byte) (int, error) {
+	m.data = append(m.data, p...)
+	return len(p), nil
+}
+
+func (m *murmur2) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint32(b, m.Sum32())
+}
+
+func (m *murmur2) Reset() {
+	m.data = m.data[:0]
+}
+
+func (m *murmur2) Size() int {
+	return 4
+}
+
+func (m *murmur2) BlockSize() int {
+	return 4
+}
+
+func (m *murmur2) Sum32() uint32 {
+	const (
+		seed = 0x9747b28c
+		mul  = 0x5bd1e995
+		r    = 24
+	)
+	data := m.data
+	length := len(data)
+	h := uint32(seed) ^ uint32(length) //nolint:gosec
+
+	for ; len(data) >= 4; data = data[4:] {
+		k := binary.LittleEndian.Uint32(data)
+		k *= mul
+		k ^= k >> r
+		k *= mul
+		h *= mul
+		h ^= k
+	}
+
+	switch len(data) {
+	case 3:
+		h ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[0])
+		h *= mul
+	}
+
+	h ^= h >> 13
+	h *= mul
+	h ^= h >> 15
+	return h
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	saramamocks "github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+)
+
+func TestMurmur2(t *testing.T) {
+	// Values computed by the Java client
+	for key, expected := range map[string]int32{
+		"":                           275646681,
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+	} {
+		h := newMurmur2()
+		h.Write([]byte(key))
+		assert.Equal(t, expected, int32(h.Sum32()), key) //nolint:gosec
+	}
+}
+
+func TestPartitioners(t *testing.T) {
+	t.Run("murmur2 partitioner is consistent with the Java client", func(t *testing.T) {
+		p := NewMurmur2Partitioner("my-topic")
+		partition, err := p.Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder("foobar")}, 10)
+		require.NoError(t, err)
+		// (-790332482 & 0x7fffffff) % 10
+		assert.Equal(t, int32(6), partition)
+	})
+
+	t.Run("sticky partitioner sends keyless messages to the same partition", func(t *testing.T) {
+		p := NewStickyPartitioner("my-topic")
+		first, err := p.Partition(&sarama.ProducerMessage{}, 100)
+		require.NoError(t, err)
+		for range stickyPartitionBatchSize - 1 {
+			partition, err := p.Partition(&sarama.ProducerMessage{}, 100)
+			require.NoError(t, err)
+			assert.Equal(t, first, partition)
+		}
+
+		partition, err := p.Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder("foobar")}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int32(6), partition)
+	})
+
+	t.Run("explicit partition overrides the strategy", func(t *testing.T) {
+		p := newPartitioner(sarama.NewHashPartitioner)("my-topic")
+		md, err := newMessageMetadata("", map[string]string{"partition": "3"})
+		require.NoError(t, err)
+		msg := &sarama.ProducerMessage{Key: sarama.StringEncoder("foobar"), Metadata: md}
+
+		partition, err := p.Partition(msg, 4)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), partition)
+
+		_, err = p.Partition(msg, 2)
+		require.Error(t, err)
+	})
+
+	t.Run("parse partitioner", func(t *testing.T) {
+		for _, value := range []string{"hash", "RoundRobin", "sticky", "murmur2"} {
+			_, err := parsePartitioner(value)
+			require.NoError(t, err, value)
+		}
+		_, err := parsePartitioner("random")
+		require.Error(t, err)
+	})
+}
+
+func TestPublishToPartition(t *testing.T) {
+	config := saramamocks.NewTestConfig()
+	config.Producer.Partitioner = newPartitioner(NewMurmur2Partitioner)
+	mockP := saramamocks.NewSyncProducer(t, config)
+	k := &Kafka{
+		mockProducer: mockP,
+		logger:       logger.NewLogger("kafka_test"),
+	}
+
+	t.Run("message is sent to the partition set in metadata", func(t *testing.T) {
+		mockP.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, int32(5), msg.Partition)
+			return nil
+		})
+		err := k.Publish(t.Context(), "my-topic", []byte("hello"), map[string]string{"partitionKey": "foobar", "partition": "5"})
+		require.NoError(t, err)
+	})
+
+	t.Run("message is sent to the partition of its key", func(t *testing.T) {
+		mockP.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			// (-790332482 & 0x7fffffff) % 32
+			assert.Equal(t, int32(30), msg.Partition)
+			return nil
+		})
+		err := k.Publish(t.Context(), "my-topic", []byte("hello"), map[string]string{"partitionKey": "foobar"})
+		require.NoError(t, err)
+	})
+
+	t.Run("bulk messages are sent to the partitions of their entries", func(t *testing.T) {
+		mockP.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, int32(1), msg.Partition)
+			return nil
+		})
+		mockP.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, int32(2), msg.Partition)
+			return nil
+		})
+		_, err := k.BulkPublish(t.Context(), "my-topic", []pubsub.BulkMessageEntry{
+			{EntryId: "0", Event: []byte("a"), Metadata: map[string]string{"partition": "1"}},
+			{EntryId: "1", Event: []byte("b"), Metadata: map[string]string{"partition": "2"}},
+		}, map[string]string{})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid partition, return error", func(t *testing.T) {
+		err := k.Publish(t.Context(), "my-topic", []byte("hello"), map[string]string{"partition": "x"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	kitmd "github.com/dapr/kit/metadata"
+	"github.com/dapr/kit/retry"
+)
+
+const (
+	assignedPartitions = "partitions"
+	startOffset        = "startOffset"
+)
+
+// PartitionAssignment pins a subscription to partitions of its topic, bypassing the consumer group.
+// Offsets are not committed to Kafka: the offset and partition of each message are passed to the handler in the
+// `__offset` and `__partition` metadata, so that applications can checkpoint them and resume from them.
+type PartitionAssignment struct {
+	// Partitions to consume. All partitions of the topic are consumed if empty.
+	Partitions []int32
+	// Offsets to start from, by partition. Partitions without an offset start from StartOffset or StartTime.
+	Offsets map[int32]int64
+	// StartOffset is sarama.OffsetOldest, sarama.OffsetNewest, or an absolute offset.
+	StartOffset int64
+	// StartTime, if set, starts from the first message produced at or after it.
+	StartTime time.Time
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// GetPartitionAssignment returns the partitions assigned with the `partitions` metadata property, if any.
+// Partitions are set as a comma-separated list, or "*" for all partitions of the topic. Each partition can be
+// followed by "=<offset>" to start from an absolute offset.
+// The `startOffset` metadata property sets where the other partitions start from: "earliest", "latest", an RFC 3339
+// timestamp, or an absolute offset. It defaults to the initialOffset of the component.
+func (k *Kafka) GetPartitionAssignment(metadata map[string]string) (*PartitionAssignment, error) {
+	partitionsStr, ok := kitmd.GetMetadataProperty(metadata, assignedPartitions)
+	partitionsStr = strings.TrimSpace(partitionsStr)
+	startOffsetStr, hasStartOffset := kitmd.GetMetadataProperty(metadata, startOffset)
+	if !ok || partitionsStr == "" {
+		if hasStartOffset && startOffsetStr != "" {
+			return nil, fmt.Errorf("the '%s' metadata property requires '%s'", startOffset, assignedPartitions)
+		}
+		return nil, nil
+	}
+
+	assignment := &PartitionAssignment{
+		Offsets:     map[int32]int64{},
+		StartOffset: k.initialOffset,
+	}
+	if partitionsStr != "*" {
+		for _, p := range strings.Split(partitionsStr, ",") {
+			partitionStr, offsetStr, hasOffset := strings.Cut(strings.TrimSpace(p), "=")
+			partition, err := strconv.ParseInt(strings.TrimSpace(partitionStr), 10, 32)
+			if err != nil || partition < 0 {
+				return nil, fmt.Errorf("invalid partition '%s' in the '%s' metadata property", partitionStr, assignedPartitions)
+			}
+			assignment.Partitions = append(assignment.Partitions, int32(partition))
+			if hasOffset {
+				offset, err := strconv.ParseInt(strings.TrimSpace(offsetStr), 10, 64)
+				if err != nil || offset < 0 {
+					return nil, fmt.Errorf("invalid offset '%s' for partition %d in the '%s' metadata property", offsetStr, partition, assignedPartitions)
+				}
+				assignment.Offsets[int32(partition)] = offset
+			}
+		}
+	}
+
+	if hasStartOffset && startOffsetStr != "" {
+		switch strings.ToLower(startOffsetStr) {
+		case "earliest", "oldest":
+			assignment.StartOffset = sarama.OffsetOldest
+		case "latest", "newest":
+			assignment.StartOffset = sarama.OffsetNewest
+		default:
+			if offset, err := strconv.ParseInt(startOffsetStr, 10, 64); err == nil && offset >= 0 {
+				assignment.StartOffset = offset
+			} else if t, err := time.Parse(time.RFC3339, startOffsetStr); err == nil {
+				assignment.StartTime = t
+			} else {
+				return nil, fmt.Errorf("invalid value '%s' for the '%s' metadata property", startOffsetStr, startOffset)
+			}
+		}
+	}
+
+	return assignment, nil
+}
+
+// consumeAssigned consumes the partitions assigned to the topic until the context is canceled.
+func (k *Kafka) consumeAssigned(ctx context.Context, topic string, assignment PartitionAssignment) {
+	for {
+		err := k.consumePartitions(ctx, topic, assignment)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			k.logger.Errorf("Error consuming partitions of %s. Retrying...: %v", topic, err)
+		}
+
+		select {
+		case <-k.closeCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-time.After(k.consumeRetryInterval):
+		}
+	}
+}
+
+// consumePartitions consumes the partitions assigned to the topic until the context is canceled.
+// Partitions resume from the offset following the last message that was processed.
+func (k *Kafka) consumePartitions(ctx context.Context, topic string, assignment PartitionAssignment) error {
+	client, consumer, err := k.newPartitionConsumer()
+	if err != nil {
+		return err
+	}
+	if client != nil {
+		defer client.Close()
+	}
+	defer consumer.Close()
+
+	partitions := assignment.Partitions
+	if len(partitions) == 0 {
+		partitions, err = consumer.Partitions(topic)
+		if err != nil {
+			return fmt.Errorf("failed to list partitions of topic %s: %w", topic, err)
+		}
+	}
+
+	partitionConsumers := make([]sarama.PartitionConsumer, 0, len(partitions))
+	startOffsets := make([]int64, 0, len(partitions))
+	defer func() {
+		for _, pc := range partitionConsumers {
+			pc.AsyncClose()
+		}
+	}()
+	for _, partition := range partitions {
+		offset, err := k.partitionStartOffset(client, topic, partition, assignment)
+		if err != nil {
+			return err
+		}
+		pc, err := consumer.ConsumePartition(topic, partition, offset)
+		if err != nil {
+			return fmt.Errorf("failed to consume partition %d of topic %s: %w", partition, topic, err)
+		}
+		partitionConsumers = append(partitionConsumers, pc)
+		startOffsets = append(startOffsets, offset)
+	}
+
+	k.logger.Debugf("Listening to partitions %v of topic %s", partitions, topic)
+
+	var wg sync.WaitGroup
+	wg.Add(len(partitionConsumers))
+	for i, pc := range partitionConsumers {
+		go func() {
+			defer wg.Done()
+			defer k.trackPartition(topic, partitions[i], pc, startOffsets[i])()
+			k.consumePartition(ctx, pc)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// newPartitionConsumer returns a consumer that is not part of a consumer group, and the client used to look up offsets.
+func (k *Kafka) newPartitionConsumer() (sarama.Client, sarama.Consumer, error) {
+	if k.mockConsumer != nil {
+		return nil, k.mockConsumer, nil
+	}
+
+	client, err := sarama.NewClient(k.brokers, k.config)
+	if err != nil {
+		return nil, nil, err
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return client, consumer, nil
+}
+
+// partitionStartOffset returns the offset a partition is consumed from.
+func (k *Kafka) partitionStartOffset(client sarama.Client, topic string, partition int32, assignment PartitionAssignment) (int64, error) {
+	if offset, ok := k.partitionOffsets.Load(topicPartition{topic: topic, partition: partition}); ok {
+		return offset.(int64), nil
+	}
+	if offset, ok := assignment.Offsets[partition]; ok {
+		return offset, nil
+	}
+	if assignment.StartTime.IsZero() {
+		return assignment.StartOffset, nil
+	}
+
+	if client == nil {
+		return 0, errors.New("no client to look up offsets by timestamp")
+	}
+	// Returns sarama.OffsetNewest if no message was produced after the timestamp
+	offset, err := client.GetOffset(topic, partition, assignment.StartTime.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up offset of partition %d of topic %s at %s: %w", partition, topic, assignment.StartTime, err)
+	}
+	return offset, nil
+}
+
+// consumePartition delivers the messages of a partition in order until the context is canceled.
+func (k *Kafka) consumePartition(ctx context.Context, pc sarama.PartitionConsumer) {
+	b := k.backOffConfig.NewBackOffWithContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-pc.Messages():
+			if !ok {
+				return
+			}
+
+			if k.deadLetterPolicy.Enabled() {
+				if !k.handleMessageWithDeadLetter(ctx, message) {
+					// The message is consumed again when the partition is resumed
+					return
+				}
+			} else if k.consumeRetryEnabled {
+				if err := retry.NotifyRecover(func() error {
+					return k.handleMessage(ctx, message)
+				}, b, func(err error, d time.Duration) {
+					k.logger.Warnf("Error processing Kafka message: %s/%d/%d [key=%s]. Error: %v. Retrying...", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), err)
+				}, func() {
+					k.logger.Infof("Successfully processed Kafka message after it previously failed: %s/%d/%d [key=%s]", message.Topic, message.Partition, message.Offset, asBase64String(message.Key))
+				}); err != nil {
+					k.logger.Errorf("Too many failed attempts at processing Kafka message: %s/%d/%d [key=%s]. Error: %v.", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), err)
+					if ctx.Err() != nil {
+						// The message is consumed again when the partition is resumed
+						return
+					}
+				}
+			} else if err := k.handleMessage(ctx, message); err != nil {
+				k.logger.Errorf("Error processing Kafka message: %s/%d/%d [key=%s]. Error: %v.", message.Topic, message.Partition, message.Offset, asBase64String(message.Key), err)
+			}
+
+			k.partitionOffsets.Store(topicPartition{topic: message.Topic, partition: message.Partition}, message.Offset+1)
+			k.markProcessed(message)
+		}
+	}
+}
+
+// forgetPartitionOffsets removes the offsets partitions of the topic resume from, once it is unsubscribed.
+func (k *Kafka) forgetPartitionOffsets(topic string) {
+	k.partitionOffsets.Range(func(key, _ any) bool {
+		if key.(topicPartition).topic == topic {
+			k.partitionOffsets.Delete(key)
+		}
+		return true
+	})
+}
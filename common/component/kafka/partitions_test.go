@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	saramamocks "github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
+)
+
+func TestGetPartitionAssignment(t *testing.T) {
+	k := &Kafka{initialOffset: sarama.OffsetNewest}
+
+	t.Run("no partitions, return nil", func(t *testing.T) {
+		assignment, err := k.GetPartitionAssignment(map[string]string{})
+		require.NoError(t, err)
+		assert.Nil(t, assignment)
+	})
+
+	t.Run("partitions with offsets", func(t *testing.T) {
+		assignment, err := k.GetPartitionAssignment(map[string]string{"partitions": "0, 2=120"})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{0, 2}, assignment.Partitions)
+		assert.Equal(t, map[int32]int64{2: 120}, assignment.Offsets)
+		assert.Equal(t, sarama.OffsetNewest, assignment.StartOffset)
+	})
+
+	t.Run("all partitions", func(t *testing.T) {
+		assignment, err := k.GetPartitionAssignment(map[string]string{"partitions": "*", "startOffset": "earliest"})
+		require.NoError(t, err)
+		assert.Empty(t, assignment.Partitions)
+		assert.Equal(t, sarama.OffsetOldest, assignment.StartOffset)
+	})
+
+	t.Run("start offsets", func(t *testing.T) {
+		assignment, err := k.GetPartitionAssignment(map[string]string{"partitions": "1", "startOffset": "42"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), assignment.StartOffset)
+
+		assignment, err = k.GetPartitionAssignment(map[string]string{"partitions": "1", "startOffset": "2026-01-02T03:04:05Z"})
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), assignment.StartTime)
+	})
+
+	t.Run("invalid values, return error", func(t *testing.T) {
+		for _, metadata := range []map[string]string{
+			{"partitions": "a"},
+			{"partitions": "-1"},
+			{"partitions": "0=x"},
+			{"partitions": "0", "startOffset": "yesterday"},
+			{"startOffset": "earliest"},
+		} {
+			_, err := k.GetPartitionAssignment(metadata)
+			require.Error(t, err, metadata)
+		}
+	})
+}
+
+func TestConsumeAssignedPartitions(t *testing.T) {
+	mockConsumer := saramamocks.NewConsumer(t, nil)
+	mockConsumer.SetTopicMetadata(map[string][]int32{"my-topic": {0, 1}})
+	mockConsumer.ExpectConsumePartition("my-topic", 0, sarama.OffsetOldest).
+		YieldMessage(&sarama.ConsumerMessage{Value: []byte("a")})
+	mockConsumer.ExpectConsumePartition("my-topic", 1, 5).
+		YieldMessage(&sarama.ConsumerMessage{Value: []byte("b")}).
+		YieldMessage(&sarama.ConsumerMessage{Value: []byte("c")})
+
+	var (
+		lock     sync.Mutex
+		received = map[string]map[string]string{}
+		done     = make(chan struct{})
+	)
+	handler := func(ctx context.Context, msg *NewEvent) error {
+		lock.Lock()
+		defer lock.Unlock()
+		received[string(msg.Data)] = msg.Metadata
+		if len(received) == 3 {
+			close(done)
+		}
+		return nil
+	}
+
+	k := &Kafka{
+		logger:          logger.NewLogger("kafka_test"),
+		mockConsumer:    mockConsumer,
+		subscribeTopics: make(TopicHandlerConfig),
+		closeCh:         make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	k.Subscribe(ctx, SubscriptionHandlerConfig{
+		Handler: handler,
+		PartitionAssignment: &PartitionAssignment{
+			Offsets:     map[int32]int64{1: 5},
+			StartOffset: sarama.OffsetOldest,
+		},
+	}, "my-topic")
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for messages")
+	}
+
+	// Offsets are passed to the handler
+	lock.Lock()
+	assert.Equal(t, "0", received["a"][partitionMetadataKey])
+	assert.Equal(t, "0", received["a"][offsetMetadataKey])
+	assert.Equal(t, "1", received["c"][partitionMetadataKey])
+	assert.Equal(t, "6", received["c"][offsetMetadataKey])
+	lock.Unlock()
+
+	// Partitions resume after the last processed message
+	require.Eventually(t, func() bool {
+		offset, ok := k.partitionOffsets.Load(topicPartition{topic: "my-topic", partition: 1})
+		return ok && offset.(int64) == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, k.Close())
+	_, ok := k.partitionOffsets.Load(topicPartition{topic: "my-topic", partition: 1})
+	assert.False(t, ok)
+}
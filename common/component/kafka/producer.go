@@ -64,9 +64,14 @@ func (k *Kafka) Publish(_ context.Context, topic string, data []byte, metadata m
 	if err != nil {
 		return err
 	}
+	msgMetadata, err := newMessageMetadata("", metadata)
+	if err != nil {
+		return err
+	}
 	msg := &sarama.ProducerMessage{
-		Topic: topic,
-		Value: sarama.ByteEncoder(serializedData),
+		Topic:    topic,
+		Value:    sarama.ByteEncoder(serializedData),
+		Metadata: msgMetadata,
 	}
 
 	for name, value := range metadata {
@@ -84,7 +89,14 @@ func (k *Kafka) Publish(_ context.Context, topic string, data []byte, metadata m
 		})
 	}
 
-	partition, offset, err := clients.producer.SendMessage(msg)
+	var (
+		partition int32
+		offset    int64
+	)
+	err = k.inTransaction(clients.producer, func() (sendErr error) {
+		partition, offset, sendErr = clients.producer.SendMessage(msg)
+		return sendErr
+	})
 
 	k.logger.Debugf("Partition: %v, offset: %v", partition, offset)
 
@@ -117,6 +129,12 @@ func (k *Kafka) BulkPublish(_ context.Context, topic string, entries []pubsub.Bu
 			Topic: topic,
 			Value: sarama.ByteEncoder(serializedData),
 		}
+
+		if entry.Metadata == nil {
+			entry.Metadata = make(map[string]string)
+		}
+		maps.Copy(entry.Metadata, metadata)
+
 		// From Sarama documentation
 		// This field is used to hold arbitrary data you wish to include so it
 		// will be available when receiving on the Successes and Errors channels.
@@ -125,12 +143,11 @@ func (k *Kafka) BulkPublish(_ context.Context, topic string, entries []pubsub.Bu
 		// This pass thorugh field is used for mapping errors, as seen in the mapKafkaProducerErrors method
 		// The EntryId will be unique for this request and the ProducerMessage is returned on the Errros channel,
 		// the metadata in that field is compared to the entry metadata to generate the right response on partial failures
-		msg.Metadata = entry.EntryId
-
-		if entry.Metadata == nil {
-			entry.Metadata = make(map[string]string)
+		msgMetadata, err := newMessageMetadata(entry.EntryId, entry.Metadata)
+		if err != nil {
+			return pubsub.NewBulkPublishResponse(entries, err), err
 		}
-		maps.Copy(entry.Metadata, metadata)
+		msg.Metadata = msgMetadata
 
 		for name, value := range entry.Metadata {
 			switch name {
@@ -150,7 +167,14 @@ func (k *Kafka) BulkPublish(_ context.Context, topic string, entries []pubsub.Bu
 		msgs = append(msgs, msg)
 	}
 
-	if err := clients.producer.SendMessages(msgs); err != nil {
+	err = k.inTransaction(clients.producer, func() error {
+		return clients.producer.SendMessages(msgs)
+	})
+	if err != nil {
+		if clients.producer.IsTransactional() {
+			// The transaction was aborted, so none of the messages are delivered to read_committed consumers
+			return pubsub.NewBulkPublishResponse(entries, err), err
+		}
 		// map the returned error to different entries
 		return k.mapKafkaProducerErrors(err, entries), err
 	}
@@ -158,6 +182,36 @@ func (k *Kafka) BulkPublish(_ context.Context, topic string, entries []pubsub.Bu
 	return pubsub.BulkPublishResponse{}, nil
 }
 
+// inTransaction calls send in a transaction if the producer is transactional, committing the transaction if send
+// succeeds and aborting it otherwise. Messages sent by non-transactional producers are sent right away.
+func (k *Kafka) inTransaction(producer sarama.SyncProducer, send func() error) error {
+	if !producer.IsTransactional() {
+		return send()
+	}
+
+	k.producerTxnLock.Lock()
+	defer k.producerTxnLock.Unlock()
+
+	err := producer.BeginTxn()
+	if err != nil {
+		return fmt.Errorf("failed to begin Kafka transaction: %w", err)
+	}
+
+	err = send()
+	if err == nil {
+		err = producer.CommitTxn()
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("failed to commit Kafka transaction: %w", err)
+	}
+
+	if abortErr := producer.AbortTxn(); abortErr != nil {
+		k.logger.Errorf("Failed to abort Kafka transaction: %v", abortErr)
+	}
+	return err
+}
+
 // mapKafkaProducerErrors to correct response statuses
 func (k *Kafka) mapKafkaProducerErrors(err error, entries []pubsub.BulkMessageEntry) pubsub.BulkPublishResponse {
 	var pErrs sarama.ProducerErrors
@@ -173,15 +227,15 @@ func (k *Kafka) mapKafkaProducerErrors(err error, entries []pubsub.BulkMessageEn
 	alreadySeen := map[string]struct{}{}
 
 	for _, pErr := range pErrs {
-		if entryId, ok := pErr.Msg.Metadata.(string); ok { //nolint:stylecheck
-			alreadySeen[entryId] = struct{}{}
+		if md, ok := pErr.Msg.Metadata.(messageMetadata); ok {
+			alreadySeen[md.entryID] = struct{}{}
 			resp.FailedEntries = append(resp.FailedEntries, pubsub.BulkPublishResponseFailedEntry{
-				EntryId: entryId,
+				EntryId: md.entryID,
 				Error:   pErr.Err,
 			})
 		} else {
 			// Ideally this condition should not be executed, but in the scenario that the Metadata field
-			// is not of string type return a default error that all messages have failed
+			// is not of messageMetadata type return a default error that all messages have failed
 			k.logger.Warnf("error parsing bulk errors from Kafka, returning default error response of all failed")
 			return pubsub.NewBulkPublishResponse(entries, err)
 		}
@@ -1,10 +1,12 @@
 package kafka
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/IBM/sarama"
 	saramamocks "github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/pubsub"
@@ -189,3 +191,80 @@ func TestBulkPublish(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// txnSyncProducer records the transactions of a transactional mock producer.
+type txnSyncProducer struct {
+	*saramamocks.SyncProducer
+	begun, committed, aborted int
+}
+
+func (p *txnSyncProducer) BeginTxn() error {
+	p.begun++
+	return p.SyncProducer.BeginTxn()
+}
+
+func (p *txnSyncProducer) CommitTxn() error {
+	p.committed++
+	return p.SyncProducer.CommitTxn()
+}
+
+func (p *txnSyncProducer) AbortTxn() error {
+	p.aborted++
+	return p.SyncProducer.AbortTxn()
+}
+
+func arrangeTransactionalKafka(t *testing.T) (*Kafka, *txnSyncProducer) {
+	config := saramamocks.NewTestConfig()
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Transaction.ID = "txn"
+	config.Net.MaxOpenRequests = 1
+	config.Version = sarama.V2_0_0_0 //nolint:nosnakecase
+
+	mockP := &txnSyncProducer{SyncProducer: saramamocks.NewSyncProducer(t, config)}
+	return &Kafka{
+		mockProducer: mockP,
+		logger:       logger.NewLogger("kafka_test"),
+	}, mockP
+}
+
+func TestTransactionalPublish(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("message is published in a committed transaction", func(t *testing.T) {
+		k, mockP := arrangeTransactionalKafka(t)
+		mockP.ExpectSendMessageAndSucceed()
+
+		err := k.Publish(ctx, "a", []byte("a"), map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockP.begun)
+		assert.Equal(t, 1, mockP.committed)
+		assert.Equal(t, 0, mockP.aborted)
+	})
+
+	t.Run("transaction is aborted when publishing fails", func(t *testing.T) {
+		k, mockP := arrangeTransactionalKafka(t)
+		mockP.ExpectSendMessageAndFail(errors.New("failed"))
+
+		err := k.Publish(ctx, "a", []byte("a"), map[string]string{})
+		require.Error(t, err)
+		assert.Equal(t, 0, mockP.committed)
+		assert.Equal(t, 1, mockP.aborted)
+	})
+
+	t.Run("all messages fail when the bulk transaction is aborted", func(t *testing.T) {
+		k, mockP := arrangeTransactionalKafka(t)
+		mockP.ExpectSendMessageAndSucceed()
+		mockP.ExpectSendMessageAndFail(errors.New("failed"))
+
+		entries := []pubsub.BulkMessageEntry{
+			{EntryId: "0", Event: []byte("a")},
+			{EntryId: "1", Event: []byte("b")},
+		}
+		res, err := k.BulkPublish(ctx, "a", entries, map[string]string{})
+		require.Error(t, err)
+		assert.Len(t, res.FailedEntries, 2)
+		assert.Equal(t, 1, mockP.begun)
+		assert.Equal(t, 1, mockP.aborted)
+	})
+}
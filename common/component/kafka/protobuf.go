@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/riferrei/srclient"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const protobufSchemaFile = "schema.proto"
+
+// parseProtobufSchema compiles a Protobuf schema.
+// Only the well-known types can be imported, as references to other schemas are not resolved.
+func parseProtobufSchema(schemaStr string) (protoreflect.FileDescriptor, error) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{
+				protobufSchemaFile: schemaStr,
+			}),
+		}),
+	}
+	files, err := compiler.Compile(context.Background(), protobufSchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling Protobuf schema: %w", err)
+	}
+	return files[0], nil
+}
+
+// getProtobufSchema returns the compiled form of a registry Protobuf schema.
+// Schema IDs are immutable in the registry, so compiled schemas are kept for the lifetime of the component.
+func (k *Kafka) getProtobufSchema(schema *srclient.Schema) (protoreflect.FileDescriptor, error) {
+	if cached, ok := k.protobufSchemas.Load(schema.ID()); ok {
+		return cached.(protoreflect.FileDescriptor), nil
+	}
+	file, err := parseProtobufSchema(schema.Schema())
+	if err != nil {
+		return nil, err
+	}
+	k.protobufSchemas.Store(schema.ID(), file)
+	return file, nil
+}
+
+// protobufFromJSON serializes a JSON value to the Protobuf message named recordName, or the first message of the schema.
+// The serialized message is prefixed with the indexes of the message in the schema.
+func (k *Kafka) protobufFromJSON(schema *srclient.Schema, recordName string, data []byte) ([]byte, error) {
+	file, err := k.getProtobufSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	desc, indexes, err := findProtobufMessage(file, recordName)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(desc)
+	if err = protojson.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("value does not match the Protobuf schema: %w", err)
+	}
+	return proto.MarshalOptions{}.MarshalAppend(appendMessageIndexes(nil, indexes), msg)
+}
+
+// protobufToJSON deserializes a Protobuf message to JSON.
+func (k *Kafka) protobufToJSON(schema *srclient.Schema, value []byte) ([]byte, error) {
+	file, err := k.getProtobufSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	indexes, value, err := consumeMessageIndexes(value)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := protobufMessageAt(file, indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(desc)
+	if err = proto.Unmarshal(value, msg); err != nil {
+		return nil, fmt.Errorf("error deserializing Protobuf message: %w", err)
+	}
+	return protojson.Marshal(msg)
+}
+
+// findProtobufMessage returns the message with the fully-qualified name, or the first message if name is empty,
+// along with the indexes locating it in the schema.
+func findProtobufMessage(file protoreflect.FileDescriptor, name string) (protoreflect.MessageDescriptor, []int, error) {
+	if file.Messages().Len() == 0 {
+		return nil, nil, errors.New("the Protobuf schema has no message")
+	}
+	if name == "" {
+		return file.Messages().Get(0), []int{0}, nil
+	}
+
+	var find func(msgs protoreflect.MessageDescriptors, indexes []int) (protoreflect.MessageDescriptor, []int)
+	find = func(msgs protoreflect.MessageDescriptors, indexes []int) (protoreflect.MessageDescriptor, []int) {
+		for i := range msgs.Len() {
+			msg := msgs.Get(i)
+			msgIndexes := append(indexes[:len(indexes):len(indexes)], i)
+			if string(msg.FullName()) == name {
+				return msg, msgIndexes
+			}
+			if found, foundIndexes := find(msg.Messages(), msgIndexes); found != nil {
+				return found, foundIndexes
+			}
+		}
+		return nil, nil
+	}
+	desc, indexes := find(file.Messages(), nil)
+	if desc == nil {
+		return nil, nil, fmt.Errorf("message '%s' not found in the Protobuf schema", name)
+	}
+	return desc, indexes, nil
+}
+
+// protobufMessageAt returns the message located by the indexes in the schema.
+func protobufMessageAt(file protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	msgs := file.Messages()
+	var desc protoreflect.MessageDescriptor
+	for _, i := range indexes {
+		if i < 0 || i >= msgs.Len() {
+			return nil, fmt.Errorf("message index %v not found in the Protobuf schema", indexes)
+		}
+		desc = msgs.Get(i)
+		msgs = desc.Messages()
+	}
+	return desc, nil
+}
+
+// appendMessageIndexes encodes the indexes of a message as zig-zag varints, preceded by their count.
+// The indexes of the first message are encoded as a single 0.
+func appendMessageIndexes(b []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(b, 0)
+	}
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(len(indexes))))
+	for _, i := range indexes {
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(i)))
+	}
+	return b
+}
+
+// consumeMessageIndexes decodes the indexes of a message, and returns the rest of the value.
+func consumeMessageIndexes(b []byte) ([]int, []byte, error) {
+	count, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return nil, nil, errors.New("invalid Protobuf message indexes")
+	}
+	b = b[n:]
+	length := protowire.DecodeZigZag(count)
+	if length == 0 {
+		return []int{0}, b, nil
+	}
+	if length < 0 || length > int64(len(b)) {
+		return nil, nil, errors.New("invalid Protobuf message indexes")
+	}
+
+	indexes := make([]int, length)
+	for i := range indexes {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, nil, errors.New("invalid Protobuf message indexes")
+		}
+		indexes[i] = int(protowire.DecodeZigZag(v))
+		b = b[n:]
+	}
+	return indexes, b, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/IBM/sarama"
+
+	azauth "github.com/dapr/components-contrib/common/authentication/azure"
+)
+
+// Tokens are refreshed this long before they expire.
+const azureADTokenRefreshBuffer = 2 * time.Minute
+
+// AzureADTokenSource provides Microsoft Entra ID access tokens for SASL OAUTHBEARER
+// authentication against the Kafka endpoint of Azure Event Hubs.
+type AzureADTokenSource struct {
+	credential  azcore.TokenCredential
+	scopes      []string
+	lock        sync.Mutex
+	cachedToken azcore.AccessToken
+}
+
+// getAzureADTokenSource returns a token source using the shared Azure credential chain,
+// requesting tokens for the Event Hubs namespace of the first broker.
+func (m KafkaMetadata) getAzureADTokenSource(properties map[string]string) (*AzureADTokenSource, error) {
+	scope, err := azureADScope(m.internalBrokers)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := azauth.NewEnvironmentSettings(properties)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := settings.GetTokenCredential()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Azure credential: %w", err)
+	}
+
+	return &AzureADTokenSource{
+		credential: credential,
+		scopes:     []string{scope},
+	}, nil
+}
+
+// azureADScope returns the token scope of an Event Hubs namespace, such as
+// "https://mynamespace.servicebus.windows.net/.default", from a broker address.
+func azureADScope(brokers []string) (string, error) {
+	if len(brokers) == 0 {
+		return "", errors.New("kafka error: missing 'brokers' attribute")
+	}
+	host, _, err := net.SplitHostPort(brokers[0])
+	if err != nil {
+		// The port is optional
+		host = brokers[0]
+	}
+	if host == "" {
+		return "", fmt.Errorf("kafka error: invalid broker address '%s'", brokers[0])
+	}
+	return "https://" + host + "/.default", nil
+}
+
+func (ts *AzureADTokenSource) Token() (*sarama.AccessToken, error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	if ts.cachedToken.Token != "" && time.Now().Add(azureADTokenRefreshBuffer).Before(ts.cachedToken.ExpiresOn) {
+		return &sarama.AccessToken{Token: ts.cachedToken.Token}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRequestTimeout)
+	defer cancel()
+
+	token, err := ts.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: ts.scopes})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Azure AD token: %w", err)
+	}
+
+	ts.cachedToken = token
+	return &sarama.AccessToken{Token: token.Token}, nil
+}
+
+func updateAzureADAuthInfo(config *sarama.Config, metadata *KafkaMetadata, properties map[string]string) error {
+	tokenProvider, err := metadata.getAzureADTokenSource(properties)
+	if err != nil {
+		return err
+	}
+
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+	config.Net.SASL.TokenProvider = tokenProvider
+
+	return nil
+}
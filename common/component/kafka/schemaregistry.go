@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/riferrei/srclient"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+// Values serialized with a schema start with a magic byte followed by the ID of the schema in the registry.
+const recordValueHeaderSize = 5
+
+// registryType returns the type of the schema in the registry.
+func (s SchemaType) registryType() srclient.SchemaType {
+	switch s {
+	case Protobuf:
+		return srclient.Protobuf
+	case JSONSchema:
+		return srclient.Json
+	default:
+		return srclient.Avro
+	}
+}
+
+// getValueSchema returns the schema used to serialize a value published to the topic, and its codec if it's an Avro schema.
+// The schema set with the `valueSchema` metadata property is registered if auto-registration is enabled, or looked up
+// otherwise. If no schema is set, the latest version of the subject is used.
+func (k *Kafka) getValueSchema(topic string, schemaType SchemaType, metadata map[string]string) (*srclient.Schema, *goavro.Codec, error) {
+	schemaStr, hasSchema := kitmd.GetMetadataProperty(metadata, valueSchema)
+	hasSchema = hasSchema && schemaStr != ""
+
+	strategy := k.subjectNameStrategy
+	if val, ok := kitmd.GetMetadataProperty(metadata, schemaSubjectNameStrategy); ok && val != "" {
+		var err error
+		strategy, err = parseSubjectNameStrategy(val)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	recordName, _ := kitmd.GetMetadataProperty(metadata, valueSchemaRecordName)
+	if recordName == "" && hasSchema && strategy != subjectNameStrategyTopic {
+		var err error
+		recordName, err = schemaRecordName(schemaStr, schemaType)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	subject, err := getValueSubject(strategy, topic, recordName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasSchema {
+		return k.getLatestSchema(subject, schemaType)
+	}
+	return k.getRegisteredSchema(subject, schemaStr, schemaType)
+}
+
+// getValueSubject returns the subject of the value schemas of the topic, named with the strategy.
+func getValueSubject(strategy string, topic string, recordName string) (string, error) {
+	switch strategy {
+	case subjectNameStrategyRecord, subjectNameStrategyTopicRecord:
+		if recordName == "" {
+			return "", fmt.Errorf("the '%s' metadata property or a '%s' is required with the '%s' subject name strategy", valueSchemaRecordName, valueSchema, strategy)
+		}
+		if strategy == subjectNameStrategyRecord {
+			return recordName, nil
+		}
+		return topic + "-" + recordName, nil
+	default:
+		return getSchemaSubject(topic), nil
+	}
+}
+
+// getRegisteredSchema registers the schema under the subject, or only looks it up if auto-registration is disabled.
+// Registering a schema that is already registered returns the existing one, so results are kept for the lifetime of
+// the component.
+func (k *Kafka) getRegisteredSchema(subject string, schemaStr string, schemaType SchemaType) (*srclient.Schema, *goavro.Codec, error) {
+	cacheKey := subject + "\x00" + schemaStr
+	if cached, ok := k.registeredSchemas.Load(cacheKey); ok {
+		entry := cached.(SchemaCacheEntry)
+		return entry.schema, entry.codec, nil
+	}
+
+	srClient, err := k.getSchemaRegistyClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = k.ensureSchemaCompatibilityLevel(srClient, subject); err != nil {
+		return nil, nil, err
+	}
+
+	var schema *srclient.Schema
+	if k.schemaAutoRegistration {
+		schema, err = srClient.CreateSchema(subject, schemaStr, schemaType.registryType())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to register schema on subject '%s': %w", subject, err)
+		}
+	} else {
+		schema, err = srClient.LookupSchema(subject, schemaStr, schemaType.registryType())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to look up schema on subject '%s', enable schema auto-registration to register it: %w", subject, err)
+		}
+	}
+
+	codec, err := newSchemaCodec(schema, schemaType)
+	if err != nil {
+		return nil, nil, err
+	}
+	k.registeredSchemas.Store(cacheKey, SchemaCacheEntry{schema: schema, codec: codec})
+	return schema, codec, nil
+}
+
+// schemaRecordName returns the fully-qualified name of the record described by a schema, used by the record subject
+// name strategies: the name of Avro records, the first message of Protobuf schemas, and the title of JSON schemas.
+func schemaRecordName(schemaStr string, schemaType SchemaType) (string, error) {
+	switch schemaType {
+	case Avro:
+		var record struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		}
+		if err := json.Unmarshal([]byte(schemaStr), &record); err != nil || record.Name == "" {
+			return "", errors.New("the Avro schema is not a named type")
+		}
+		if record.Namespace != "" && !strings.Contains(record.Name, ".") {
+			return record.Namespace + "." + record.Name, nil
+		}
+		return record.Name, nil
+	case Protobuf:
+		file, err := parseProtobufSchema(schemaStr)
+		if err != nil {
+			return "", err
+		}
+		if file.Messages().Len() == 0 {
+			return "", errors.New("the Protobuf schema has no message")
+		}
+		return string(file.Messages().Get(0).FullName()), nil
+	case JSONSchema:
+		var schema struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal([]byte(schemaStr), &schema); err != nil || schema.Title == "" {
+			return "", errors.New("the JSON schema has no title")
+		}
+		return schema.Title, nil
+	default:
+		return "", fmt.Errorf("schema type %d has no record name", schemaType)
+	}
+}
+
+// newRecordValue prepends the header identifying the schema to a serialized value.
+func newRecordValue(schema *srclient.Schema, value []byte) []byte {
+	recordValue := make([]byte, recordValueHeaderSize, recordValueHeaderSize+len(value))
+	recordValue[0] = 0
+	binary.BigEndian.PutUint32(recordValue[1:recordValueHeaderSize], uint32(schema.ID())) //nolint:gosec
+	return append(recordValue, value...)
+}
+
+// getRecordSchema returns the schema a value was serialized with, and the serialized value without the header.
+func (k *Kafka) getRecordSchema(value []byte) (*srclient.Schema, []byte, error) {
+	srClient, err := k.getSchemaRegistyClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(value) < recordValueHeaderSize {
+		return nil, nil, errors.New("value is too short")
+	}
+	schemaID := binary.BigEndian.Uint32(value[1:recordValueHeaderSize])
+	schema, err := srClient.GetSchema(int(schemaID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return schema, value[recordValueHeaderSize:], nil
+}
+
+// validateJSON validates a JSON value against a JSON schema.
+func (k *Kafka) validateJSON(schema *srclient.Schema, data []byte) error {
+	compiled, err := k.getJSONSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err = dec.Decode(&value); err != nil {
+		return fmt.Errorf("value is not valid JSON: %w", err)
+	}
+	if err = compiled.Validate(value); err != nil {
+		return fmt.Errorf("value does not match the JSON schema: %w", err)
+	}
+	return nil
+}
+
+// getJSONSchema returns the compiled form of a registry JSON schema.
+// Schema IDs are immutable in the registry, so compiled schemas are kept for the lifetime of the component.
+func (k *Kafka) getJSONSchema(schema *srclient.Schema) (*jsonschema.Schema, error) {
+	if cached, ok := k.jsonSchemas.Load(schema.ID()); ok {
+		return cached.(*jsonschema.Schema), nil
+	}
+	compiled, err := jsonschema.CompileString(fmt.Sprintf("schema-%d.json", schema.ID()), schema.Schema())
+	if err != nil {
+		return nil, fmt.Errorf("error compiling JSON schema: %w", err)
+	}
+	k.jsonSchemas.Store(schema.ID(), compiled)
+	return compiled, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/riferrei/srclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	testProtobufSchema = `syntax = "proto3";
+package orders;
+
+message Order {
+  string id = 1;
+  int64 quantity = 2;
+
+  message Item {
+    string sku = 1;
+  }
+}
+
+message Refund {
+  string order_id = 1;
+}`
+	testJSONSchema = `{"title": "Order", "type": "object", "properties": {"id": {"type": "string"}}, "required": ["id"]}`
+)
+
+func TestProtobufSchema(t *testing.T) {
+	registry := srclient.CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, err := registry.CreateSchema("orders-value", testProtobufSchema, srclient.Protobuf)
+	require.NoError(t, err)
+
+	k := Kafka{
+		srClient: registry,
+		logger:   logger.NewLogger("kafka_test"),
+	}
+	metadata := map[string]string{"valueSchemaType": "Protobuf"}
+
+	t.Run("value is serialized as the first message", func(t *testing.T) {
+		act, err := k.SerializeValue("orders", []byte(`{"id": "1", "quantity": 2}`), metadata)
+		require.NoError(t, err)
+
+		assert.Equal(t, byte(0), act[0])
+		assert.Equal(t, schema.ID(), int(binary.BigEndian.Uint32(act[1:5])))
+		// Message indexes of the first message
+		assert.Equal(t, byte(0), act[5])
+
+		val, err := k.DeserializeValue(&sarama.ConsumerMessage{Topic: "orders", Value: act}, SubscriptionHandlerConfig{ValueSchemaType: Protobuf})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "1", "quantity": "2"}`, string(val))
+	})
+
+	t.Run("value is serialized as the message set in metadata", func(t *testing.T) {
+		act, err := k.SerializeValue("orders", []byte(`{"sku": "abc"}`), map[string]string{
+			"valueSchemaType":       "Protobuf",
+			"valueSchemaRecordName": "orders.Order.Item",
+		})
+		require.NoError(t, err)
+
+		// Two indexes: 0 then 0, encoded as zig-zag varints
+		assert.Equal(t, []byte{4, 0, 0}, act[5:8])
+
+		val, err := k.DeserializeValue(&sarama.ConsumerMessage{Topic: "orders", Value: act}, SubscriptionHandlerConfig{ValueSchemaType: Protobuf})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"sku": "abc"}`, string(val))
+	})
+
+	t.Run("unknown message, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), map[string]string{
+			"valueSchemaType":       "Protobuf",
+			"valueSchemaRecordName": "orders.Unknown",
+		})
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("value does not match the schema, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"unknown": "1"}`), metadata)
+		require.Error(t, err)
+	})
+}
+
+func TestMessageIndexes(t *testing.T) {
+	for _, indexes := range [][]int{{0}, {1}, {0, 2}, {3, 1, 70}} {
+		b := appendMessageIndexes(nil, indexes)
+		act, rest, err := consumeMessageIndexes(append(b, 0xff))
+		require.NoError(t, err)
+		assert.Equal(t, indexes, act)
+		assert.Equal(t, []byte{0xff}, rest)
+	}
+
+	_, _, err := consumeMessageIndexes([]byte{8, 0})
+	require.Error(t, err)
+}
+
+func TestJSONSchema(t *testing.T) {
+	registry := srclient.CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, err := registry.CreateSchema("orders-value", testJSONSchema, srclient.Json)
+	require.NoError(t, err)
+
+	k := Kafka{
+		srClient: registry,
+		logger:   logger.NewLogger("kafka_test"),
+	}
+	metadata := map[string]string{"valueSchemaType": "JsonSchema"}
+
+	t.Run("valid value is serialized with the schema ID", func(t *testing.T) {
+		act, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), metadata)
+		require.NoError(t, err)
+		assert.Equal(t, schema.ID(), int(binary.BigEndian.Uint32(act[1:5])))
+		assert.JSONEq(t, `{"id": "1"}`, string(act[5:]))
+
+		val, err := k.DeserializeValue(&sarama.ConsumerMessage{Topic: "orders", Value: act}, SubscriptionHandlerConfig{ValueSchemaType: JSONSchema})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "1"}`, string(val))
+	})
+
+	t.Run("invalid value, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": 1}`), metadata)
+		require.ErrorContains(t, err, "does not match the JSON schema")
+	})
+}
+
+func TestSubjectNameStrategy(t *testing.T) {
+	registry := srclient.CreateMockSchemaRegistryClient("http://localhost:8081")
+	k := Kafka{
+		srClient:               registry,
+		subjectNameStrategy:    subjectNameStrategyRecord,
+		schemaAutoRegistration: true,
+		logger:                 logger.NewLogger("kafka_test"),
+	}
+
+	t.Run("schema is registered under the record name", func(t *testing.T) {
+		act, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), map[string]string{
+			"valueSchemaType": "JsonSchema",
+			"valueSchema":     testJSONSchema,
+		})
+		require.NoError(t, err)
+
+		schema, err := registry.GetLatestSchema("Order")
+		require.NoError(t, err)
+		assert.Equal(t, schema.ID(), int(binary.BigEndian.Uint32(act[1:5])))
+
+		// Registered schemas are cached
+		_, err = k.SerializeValue("orders", []byte(`{"id": "2"}`), map[string]string{
+			"valueSchemaType": "JsonSchema",
+			"valueSchema":     testJSONSchema,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("strategy set in metadata", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), map[string]string{
+			"valueSchemaType":           "Protobuf",
+			"valueSchema":               testProtobufSchema,
+			"schemaSubjectNameStrategy": "TopicRecord",
+		})
+		require.NoError(t, err)
+
+		_, err = registry.GetLatestSchema("orders-orders.Order")
+		require.NoError(t, err)
+	})
+
+	t.Run("record name is required, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), map[string]string{"valueSchemaType": "JsonSchema"})
+		require.ErrorContains(t, err, "valueSchemaRecordName")
+	})
+
+	t.Run("invalid strategy, return error", func(t *testing.T) {
+		_, err := k.SerializeValue("orders", []byte(`{"id": "1"}`), map[string]string{
+			"valueSchemaType":           "JsonSchema",
+			"schemaSubjectNameStrategy": "xx",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("subject names", func(t *testing.T) {
+		subject, err := getValueSubject(subjectNameStrategyTopic, "orders", "orders.Order")
+		require.NoError(t, err)
+		assert.Equal(t, "orders-value", subject)
+		subject, err = getValueSubject(subjectNameStrategyRecord, "orders", "orders.Order")
+		require.NoError(t, err)
+		assert.Equal(t, "orders.Order", subject)
+		subject, err = getValueSubject(subjectNameStrategyTopicRecord, "orders", "orders.Order")
+		require.NoError(t, err)
+		assert.Equal(t, "orders-orders.Order", subject)
+	})
+
+	t.Run("Avro record names include the namespace", func(t *testing.T) {
+		name, err := schemaRecordName(`{"type": "record", "name": "Order", "namespace": "orders", "fields": []}`, Avro)
+		require.NoError(t, err)
+		assert.Equal(t, "orders.Order", name)
+	})
+}
@@ -17,6 +17,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/IBM/sarama"
 )
 
 // Subscribe adds a handler and configuration for a topic, and subscribes.
@@ -50,9 +52,63 @@ func (k *Kafka) Subscribe(ctx context.Context, handlerConfig SubscriptionHandler
 		}
 
 		k.reloadConsumerGroup()
+
+		for _, topic := range topics {
+			k.forgetPartitionOffsets(topic)
+		}
 	}()
 }
 
+// AddTopics adds a handler and configuration for the topics, and reloads the consumer group.
+// Unlike Subscribe, the topics are not removed when a context is canceled: callers must invoke RemoveTopics.
+func (k *Kafka) AddTopics(handlerConfig SubscriptionHandlerConfig, topics ...string) {
+	if len(topics) == 0 {
+		return
+	}
+
+	k.subscribeLock.Lock()
+	defer k.subscribeLock.Unlock()
+	for _, topic := range topics {
+		k.subscribeTopics[topic] = handlerConfig
+	}
+
+	k.logger.Debugf("Subscribing to topic: %v", topics)
+
+	k.reloadConsumerGroup()
+}
+
+// RemoveTopics removes the handlers for the topics, and reloads the consumer group.
+func (k *Kafka) RemoveTopics(topics ...string) {
+	if len(topics) == 0 {
+		return
+	}
+
+	k.subscribeLock.Lock()
+	defer k.subscribeLock.Unlock()
+	for _, topic := range topics {
+		delete(k.subscribeTopics, topic)
+	}
+
+	k.logger.Debugf("Unsubscribing to topic: %v", topics)
+
+	k.reloadConsumerGroup()
+
+	for _, topic := range topics {
+		k.forgetPartitionOffsets(topic)
+	}
+}
+
+// ListTopics returns the names of all topics in the cluster.
+func (k *Kafka) ListTopics() ([]string, error) {
+	client, err := sarama.NewClient(k.brokers, k.config)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.Topics()
+}
+
 // reloadConsumerGroup reloads the consumer group with the new topics.
 func (k *Kafka) reloadConsumerGroup() {
 	if k.consumerCancel != nil {
@@ -65,21 +121,41 @@ func (k *Kafka) reloadConsumerGroup() {
 		return
 	}
 
-	topics := k.subscribeTopics.TopicList()
-
-	k.logger.Debugf("Subscribed and listening to topics: %s", topics)
-
-	consumer := &consumer{k: k}
+	// Topics with assigned partitions are consumed outside of the consumer group
+	topics := make([]string, 0, len(k.subscribeTopics))
+	assigned := make(map[string]PartitionAssignment)
+	for topic, handlerConfig := range k.subscribeTopics {
+		if handlerConfig.PartitionAssignment != nil {
+			assigned[topic] = *handlerConfig.PartitionAssignment
+		} else {
+			topics = append(topics, topic)
+		}
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	k.consumerCancel = cancel
 
-	k.consumerWG.Add(1)
-	go func() {
-		defer k.consumerWG.Done()
-		k.consume(ctx, topics, consumer)
-		k.logger.Debugf("Closing ConsumerGroup for topics: %v", topics)
-	}()
+	if len(topics) > 0 {
+		k.logger.Debugf("Subscribed and listening to topics: %s", topics)
+
+		consumer := &consumer{k: k}
+
+		k.consumerWG.Add(1)
+		go func() {
+			defer k.consumerWG.Done()
+			k.consume(ctx, topics, consumer)
+			k.logger.Debugf("Closing ConsumerGroup for topics: %v", topics)
+		}()
+	}
+
+	for topic, assignment := range assigned {
+		k.consumerWG.Add(1)
+		go func() {
+			defer k.consumerWG.Done()
+			k.consumeAssigned(ctx, topic, assignment)
+			k.logger.Debugf("Closing partition consumers for topic: %s", topic)
+		}()
+	}
 }
 
 func (k *Kafka) consume(ctx context.Context, topics []string, consumer *consumer) {
@@ -520,3 +520,50 @@ func Test_Subscribe(t *testing.T) {
 		assert.Equal(t, int64(199), consumeCalled.Load())
 	})
 }
+
+func Test_AddRemoveTopics(t *testing.T) {
+	topicsCh := make(chan []string, 10)
+	cg := mocks.NewConsumerGroup().WithConsumeFn(func(ctx context.Context, topics []string, _ sarama.ConsumerGroupHandler) error {
+		topicsCh <- topics
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	k := &Kafka{
+		logger:            logger.NewLogger("test"),
+		mockConsumerGroup: cg,
+		subscribeTopics:   make(TopicHandlerConfig),
+		closeCh:           make(chan struct{}),
+	}
+	t.Cleanup(func() {
+		k.RemoveTopics("foo", "bar", "baz")
+	})
+
+	k.AddTopics(SubscriptionHandlerConfig{}, "foo", "bar")
+	select {
+	case topics := <-topicsCh:
+		assert.ElementsMatch(t, []string{"foo", "bar"}, topics)
+	case <-time.After(time.Second):
+		t.Fatal("consumer group was not reloaded")
+	}
+
+	k.AddTopics(SubscriptionHandlerConfig{}, "baz")
+	select {
+	case topics := <-topicsCh:
+		assert.ElementsMatch(t, []string{"foo", "bar", "baz"}, topics)
+	case <-time.After(time.Second):
+		t.Fatal("consumer group was not reloaded")
+	}
+
+	k.RemoveTopics("foo")
+	select {
+	case topics := <-topicsCh:
+		assert.ElementsMatch(t, []string{"bar", "baz"}, topics)
+	case <-time.After(time.Second):
+		t.Fatal("consumer group was not reloaded")
+	}
+
+	k.RemoveTopics("bar", "baz")
+	assert.Empty(t, k.subscribeTopics)
+	assert.Nil(t, k.consumerCancel)
+}
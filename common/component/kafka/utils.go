@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/IBM/sarama"
+	"github.com/riferrei/srclient"
 )
 
 const (
@@ -70,6 +71,57 @@ func parseCompression(value string) (compression sarama.CompressionCodec, err er
 	return compression, err
 }
 
+// parsePartitioner returns the constructor of the partitioners of the strategy.
+// The hash partitioner is the default partitioner of Sarama.
+func parsePartitioner(value string) (sarama.PartitionerConstructor, error) {
+	switch strings.ToLower(value) {
+	case partitionerHash:
+		return sarama.NewHashPartitioner, nil
+	case partitionerRoundRobin:
+		return sarama.NewRoundRobinPartitioner, nil
+	case partitionerSticky:
+		return NewStickyPartitioner, nil
+	case partitionerMurmur2:
+		return NewMurmur2Partitioner, nil
+	default:
+		return nil, fmt.Errorf("kafka error: invalid partitioner: %s", value)
+	}
+}
+
+func parseIsolationLevel(value string) (sarama.IsolationLevel, error) {
+	switch strings.ToLower(value) {
+	case isolationLevelReadUncommitted:
+		return sarama.ReadUncommitted, nil
+	case isolationLevelReadCommitted:
+		return sarama.ReadCommitted, nil
+	default:
+		return sarama.ReadUncommitted, fmt.Errorf("kafka error: invalid consumer isolation level: %s", value)
+	}
+}
+
+// parseSubjectNameStrategy returns the strategy naming the subject of the value schemas, which is the topic by default.
+func parseSubjectNameStrategy(value string) (string, error) {
+	switch strategy := strings.ToLower(value); strategy {
+	case "":
+		return subjectNameStrategyTopic, nil
+	case subjectNameStrategyTopic, subjectNameStrategyRecord, subjectNameStrategyTopicRecord:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("kafka error: invalid schema subject name strategy: %s", value)
+	}
+}
+
+func parseSchemaCompatibilityLevel(value string) (srclient.CompatibilityLevel, error) {
+	level := srclient.CompatibilityLevel(strings.ToUpper(value))
+	switch level {
+	case srclient.None, srclient.Backward, srclient.BackwardTransitive, srclient.Forward,
+		srclient.ForwardTransitive, srclient.Full, srclient.FullTransitive:
+		return level, nil
+	default:
+		return "", fmt.Errorf("kafka error: invalid schema compatibility level: %s", value)
+	}
+}
+
 // isValidPEM validates the provided input has PEM formatted block.
 func isValidPEM(val string) bool {
 	block, _ := pem.Decode([]byte(val))
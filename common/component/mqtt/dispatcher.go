@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtt
+
+import (
+	"sync"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/dapr/kit/logger"
+)
+
+// Acknowledger acknowledges received messages; it's implemented by *paho.Client.
+type Acknowledger interface {
+	Ack(p *paho.Publish) error
+}
+
+// Dispatcher processes the messages received by an MQTT 5 client in background, up to a maximum number at the same
+// time, and acknowledges them in the order they were received, as MQTT 5 requires.
+// A message is acknowledged once processed, even if processing failed, otherwise the messages that follow would
+// never be acknowledged.
+type Dispatcher struct {
+	handle func(p *paho.Publish)
+	log    logger.Logger
+	sem    chan struct{}
+
+	// Messages being processed or waiting for the messages received before them to be acknowledged
+	lock    sync.Mutex
+	pending []*delivery
+}
+
+type delivery struct {
+	acknowledger Acknowledger
+	packet       *paho.Publish
+	processed    bool
+}
+
+// NewDispatcher returns a Dispatcher that processes the messages with handle, up to maxConcurrent at the same time.
+func NewDispatcher(maxConcurrent int, handle func(p *paho.Publish), log logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		handle: handle,
+		log:    log,
+		sem:    make(chan struct{}, maxConcurrent),
+	}
+}
+
+// OnPublishReceived is the handler of the received messages, set in the OnPublishReceived client option.
+// It blocks while the maximum number of messages are being processed, so no more messages are read from the broker.
+func (d *Dispatcher) OnPublishReceived(pr paho.PublishReceived) (bool, error) {
+	d.Dispatch(pr.Client, pr.Packet)
+	return true, nil
+}
+
+// Dispatch processes a message in background, and acknowledges it with acknowledger.
+func (d *Dispatcher) Dispatch(acknowledger Acknowledger, p *paho.Publish) {
+	d.sem <- struct{}{}
+
+	dl := &delivery{acknowledger: acknowledger, packet: p}
+	d.lock.Lock()
+	d.pending = append(d.pending, dl)
+	d.lock.Unlock()
+
+	go func() {
+		defer func() { <-d.sem }()
+		d.handle(p)
+		d.processed(dl)
+	}()
+}
+
+func (d *Dispatcher) processed(dl *delivery) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	dl.processed = true
+	for len(d.pending) > 0 && d.pending[0].processed {
+		next := d.pending[0]
+		d.pending[0] = nil
+		d.pending = d.pending[1:]
+
+		// QoS 0 messages are not acknowledged
+		if next.packet.QoS == 0 {
+			continue
+		}
+		err := next.acknowledger.Ack(next.packet)
+		if err != nil {
+			d.log.Errorf("Failed to acknowledge MQTT message %s#%d: %v", next.packet.Topic, next.packet.PacketID, err)
+		}
+	}
+}
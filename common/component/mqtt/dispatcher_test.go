@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtt
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/kit/logger"
+)
+
+type fakeAcknowledger struct {
+	lock  sync.Mutex
+	acked []uint16
+}
+
+func (f *fakeAcknowledger) Ack(p *paho.Publish) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.acked = append(f.acked, p.PacketID)
+	return nil
+}
+
+func (f *fakeAcknowledger) Acked() []uint16 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]uint16(nil), f.acked...)
+}
+
+func TestDispatcher(t *testing.T) {
+	log := logger.NewLogger("test")
+
+	t.Run("acknowledges in the order received", func(t *testing.T) {
+		release := map[uint16]chan struct{}{
+			1: make(chan struct{}),
+			2: make(chan struct{}),
+			3: make(chan struct{}),
+		}
+		d := NewDispatcher(3, func(p *paho.Publish) {
+			<-release[p.PacketID]
+		}, log)
+		ack := &fakeAcknowledger{}
+
+		d.Dispatch(ack, &paho.Publish{PacketID: 1, QoS: 1})
+		d.Dispatch(ack, &paho.Publish{PacketID: 2, QoS: 0})
+		d.Dispatch(ack, &paho.Publish{PacketID: 3, QoS: 1})
+
+		// Messages processed before the first one wait for it to be acknowledged
+		close(release[3])
+		close(release[2])
+		assert.Never(t, func() bool { return len(ack.Acked()) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+
+		// QoS 0 messages are not acknowledged
+		close(release[1])
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			assert.Equal(c, []uint16{1, 3}, ack.Acked())
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("bounds the concurrency", func(t *testing.T) {
+		var running, peak atomic.Int32
+		d := NewDispatcher(2, func(p *paho.Publish) {
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}, log)
+		ack := &fakeAcknowledger{}
+
+		for i := range uint16(10) {
+			d.Dispatch(ack, &paho.Publish{PacketID: i + 1, QoS: 1})
+		}
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			assert.Len(c, ack.Acked(), 10)
+		}, time.Second, 5*time.Millisecond)
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+	})
+}
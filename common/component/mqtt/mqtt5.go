@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mqtt contains the MQTT 5 helpers shared by the MQTT binding and pubsub components.
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+
+	"github.com/dapr/kit/logger"
+)
+
+// DefaultMaxConcurrentHandlers is the default maximum number of messages processed at the same time by a client.
+const DefaultMaxConcurrentHandlers = 100
+
+// ClientOptions configures the connection of an MQTT 5 client.
+type ClientOptions struct {
+	// URL of the broker, with the credentials sent in the CONNECT packet.
+	URL                   *url.URL
+	ClientID              string
+	TLSConfig             *tls.Config
+	CleanSession          bool
+	SessionExpiryInterval time.Duration
+	ConnectTimeout        time.Duration
+}
+
+// NewClientConfig returns the configuration of an MQTT 5 client, which acknowledges the received messages manually.
+// The connection manager re-establishes the connection when it's lost, until it's disconnected.
+func NewClientConfig(opts ClientOptions, log logger.Logger) autopaho.ClientConfig {
+	// Credentials are sent in the CONNECT packet and not as part of the URL
+	serverURL := *opts.URL
+	serverURL.User = nil
+	password, _ := opts.URL.User.Password()
+
+	return autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{&serverURL},
+		TlsCfg:                        opts.TLSConfig,
+		KeepAlive:                     30,
+		CleanStartOnInitialConnection: opts.CleanSession,
+		SessionExpiryInterval:         uint32(opts.SessionExpiryInterval / time.Second), //nolint:gosec
+		ReconnectBackoff:              autopaho.NewConstantBackoff(20 * time.Second),
+		ConnectTimeout:                opts.ConnectTimeout,
+		ConnectUsername:               opts.URL.User.Username(),
+		ConnectPassword:               []byte(password),
+		OnConnectError: func(err error) {
+			log.Errorf("Error connecting to broker with client ID '%s': %v", opts.ClientID, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: opts.ClientID,
+			// Disable automatic ACKs as we need to do it manually
+			EnableManualAcknowledgment: true,
+			OnClientError: func(err error) {
+				log.Errorf("Connection with broker with client ID '%s' lost; error: %v", opts.ClientID, err)
+			},
+			OnServerDisconnect: func(d *paho.Disconnect) {
+				log.Errorf("Broker disconnected client with ID '%s'; reason code: %d", opts.ClientID, d.ReasonCode)
+			},
+		},
+	}
+}
+
+// Disconnect disconnects the client, waiting briefly for the DISCONNECT packet to be sent.
+func Disconnect(cm *autopaho.ConnectionManager) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_ = cm.Disconnect(ctx)
+}
+
+// UserProperties returns the metadata as user properties sorted by key, except for the excluded keys.
+func UserProperties(md map[string]string, exclude ...string) paho.UserProperties {
+	props := make(paho.UserProperties, 0, len(md))
+	for _, k := range slices.Sorted(maps.Keys(md)) {
+		if slices.Contains(exclude, k) {
+			continue
+		}
+		props = append(props, paho.UserProperty{Key: k, Value: md[k]})
+	}
+	return props
+}
+
+// UserPropertiesMetadata returns the user properties of a message as metadata.
+func UserPropertiesMetadata(p *paho.Publish) map[string]string {
+	if p.Properties == nil {
+		return map[string]string{}
+	}
+	md := make(map[string]string, len(p.Properties.User))
+	for _, u := range p.Properties.User {
+		md[u.Key] = u.Value
+	}
+	return md
+}
@@ -39,6 +39,10 @@ type pgMetadata struct {
 	Timeout           time.Duration  `mapstructure:"timeout" mapstructurealiases:"timeoutInSeconds"`
 	CleanupInterval   *time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
 
+	// Connection string of a read replica, used for reads instead of the primary
+	ReadReplicaConnectionString string        `mapstructure:"readReplicaConnectionString"`
+	ReadReplicaMaxLag           time.Duration `mapstructure:"readReplicaMaxLag"`
+
 	aws.DeprecatedPostgresIAM `mapstructure:",squash"`
 }
 
@@ -49,6 +53,8 @@ func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithM
 	m.MetadataTableName = defaultMetadataTableName
 	m.CleanupInterval = ptr.Of(defaultCleanupInternal)
 	m.Timeout = defaultTimeout
+	m.ReadReplicaConnectionString = ""
+	m.ReadReplicaMaxLag = 0
 
 	// Decode the metadata
 	err := metadata.DecodeMetadata(meta.Properties, &m)
@@ -67,6 +73,11 @@ func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithM
 		return errors.New("invalid value for 'timeout': must be greater than 1s")
 	}
 
+	// Read replica
+	if m.ReadReplicaMaxLag < 0 {
+		return errors.New("invalid value for 'readReplicaMaxLag': must not be negative")
+	}
+
 	// Cleanup interval
 	// Non-positive value from meta means disable auto cleanup.
 	// We need to do this check because an empty string and "0" are treated differently by DecodeMetadata
@@ -84,6 +84,33 @@ func TestMetadata(t *testing.T) {
 		assert.Equal(t, defaultTimeout, m.Timeout)
 	})
 
+	t.Run("read replica", func(t *testing.T) {
+		m := pgMetadata{}
+		props := map[string]string{
+			"connectionString":            "foo=bar",
+			"readReplicaConnectionString": "host=replica",
+			"readReplicaMaxLag":           "30s",
+		}
+
+		opts := postgresql.InitWithMetadataOpts{}
+		err := m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "host=replica", m.ReadReplicaConnectionString)
+		assert.Equal(t, 30*time.Second, m.ReadReplicaMaxLag)
+	})
+
+	t.Run("invalid read replica max lag", func(t *testing.T) {
+		m := pgMetadata{}
+		props := map[string]string{
+			"connectionString":  "foo=bar",
+			"readReplicaMaxLag": "-1s",
+		}
+
+		opts := postgresql.InitWithMetadataOpts{}
+		err := m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.ErrorContains(t, err, "readReplicaMaxLag")
+	})
+
 	t.Run("invalid timeout", func(t *testing.T) {
 		m := pgMetadata{}
 		props := map[string]string{
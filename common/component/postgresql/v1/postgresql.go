@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -48,8 +50,16 @@ type PostgreSQL struct {
 	metadata pgMetadata
 	db       pginterfaces.PGXPoolConn
 
+	// Optional read replica, used for reads when replicaHealthy is true
+	replica        pginterfaces.PGXPoolConn
+	replicaHealthy atomic.Bool
+
 	gc commonsql.GarbageCollector
 
+	closeCh chan struct{}
+	closed  atomic.Bool
+	wg      sync.WaitGroup
+
 	migrateFn     func(context.Context, pginterfaces.PGXPoolConn, MigrateOptions) error
 	setQueryFn    func(*state.SetRequest, SetQueryOptions) string
 	etagColumn    string
@@ -87,6 +97,7 @@ func NewPostgreSQLStateStore(logger logger.Logger, opts Options) state.Store {
 		etagColumn:    opts.ETagColumn,
 		enableAzureAD: opts.EnableAzureAD,
 		enableAWSIAM:  opts.EnableAWSIAM,
+		closeCh:       make(chan struct{}),
 	}
 	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
@@ -138,6 +149,13 @@ func (p *PostgreSQL) Init(ctx context.Context, meta state.Metadata) error {
 		return fmt.Errorf("failed to ping the database: %w", err)
 	}
 
+	if p.metadata.ReadReplicaConnectionString != "" {
+		err = p.connectReadReplica(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = p.migrateFn(ctx, p.db, MigrateOptions{
 		Logger:            p.logger,
 		StateTableName:    p.metadata.TableName,
@@ -280,7 +298,7 @@ func (p *PostgreSQL) Get(parentCtx context.Context, req *state.GetRequest) (*sta
 				AND (expiredate IS NULL OR expiredate >= CURRENT_TIMESTAMP)`
 	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
 	defer cancel()
-	row := p.db.QueryRow(ctx, query, req.Key)
+	row := p.readDB(req.Options.Consistency).QueryRow(ctx, query, req.Key)
 	_, value, etag, expireTime, err := readRow(row)
 	if err != nil {
 		// If no rows exist, return an empty response, otherwise return the error.
@@ -310,9 +328,14 @@ func (p *PostgreSQL) BulkGet(parentCtx context.Context, req []state.GetRequest,
 	}
 
 	// Get all keys
+	// If any of the requests asks for strong consistency, read from the primary
 	keys := make([]string, len(req))
+	consistency := state.Eventual
 	for i, r := range req {
 		keys[i] = r.Key
+		if r.Options.Consistency == state.Strong {
+			consistency = state.Strong
+		}
 	}
 
 	// Execute the query
@@ -324,7 +347,7 @@ func (p *PostgreSQL) BulkGet(parentCtx context.Context, req []state.GetRequest,
 				AND (expiredate IS NULL OR expiredate >= CURRENT_TIMESTAMP)`
 	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
 	defer cancel()
-	rows, err := p.db.Query(ctx, query, keys)
+	rows, err := p.readDB(consistency).Query(ctx, query, keys)
 	if err != nil {
 		// If no rows exist, return an empty response, otherwise return the error.
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -504,10 +527,19 @@ func (p *PostgreSQL) CleanupExpired() error {
 
 // Close implements io.Close.
 func (p *PostgreSQL) Close() error {
+	if p.closed.CompareAndSwap(false, true) && p.closeCh != nil {
+		close(p.closeCh)
+	}
+	p.wg.Wait()
+
 	if p.db != nil {
 		p.db.Close()
 		p.db = nil
 	}
+	if p.replica != nil {
+		p.replica.Close()
+		p.replica = nil
+	}
 
 	errs := make([]error, 2)
 	if p.gc != nil {
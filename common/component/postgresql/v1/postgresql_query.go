@@ -69,7 +69,7 @@ func (p *PostgreSQLQuery) Query(parentCtx context.Context, req *state.QueryReque
 	if err := qbuilder.BuildQuery(&req.Query); err != nil {
 		return &state.QueryResponse{}, err
 	}
-	data, token, err := q.execute(parentCtx, p.db)
+	data, token, err := q.execute(parentCtx, p.readDB(req.Metadata[queryConsistencyKey]))
 	if err != nil {
 		return &state.QueryResponse{}, err
 	}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/uuid"
 	pgxmock "github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
@@ -218,6 +219,73 @@ func TestMultiOperationOrder(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReadReplicaRouting(t *testing.T) {
+	// Arrange
+	m, _ := mockDatabase(t)
+	defer m.db.Close()
+
+	replica, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer replica.Close()
+	m.pg.replica = replica
+	m.pg.metadata.ReadReplicaMaxLag = 10 * time.Second
+	m.pg.replicaHealthy.Store(true)
+
+	rows := func() *pgxmock.Rows {
+		return pgxmock.NewRows([]string{"key", "value", "isbinary", "etag", "expiredate"}).
+			AddRow("key1", []byte(`"value1"`), false, "1", nil)
+	}
+
+	t.Run("get reads from the replica", func(t *testing.T) {
+		replica.ExpectQuery("SELECT").WithArgs("key1").WillReturnRows(rows())
+
+		res, err := m.pg.Get(t.Context(), &state.GetRequest{Key: "key1"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`"value1"`), res.Data)
+		require.NoError(t, replica.ExpectationsWereMet())
+	})
+
+	t.Run("strong consistency reads from the primary", func(t *testing.T) {
+		m.db.ExpectQuery("SELECT").WithArgs("key1").WillReturnRows(rows())
+
+		_, err := m.pg.Get(t.Context(), &state.GetRequest{
+			Key:     "key1",
+			Options: state.GetStateOption{Consistency: state.Strong},
+		})
+		require.NoError(t, err)
+		require.NoError(t, m.db.ExpectationsWereMet())
+	})
+
+	t.Run("bulk get with strong consistency reads from the primary", func(t *testing.T) {
+		m.db.ExpectQuery("SELECT").WithArgs([]string{"key1", "key2"}).WillReturnRows(rows())
+
+		res, err := m.pg.BulkGet(t.Context(), []state.GetRequest{
+			{Key: "key1"},
+			{Key: "key2", Options: state.GetStateOption{Consistency: state.Strong}},
+		}, state.BulkGetOpts{})
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+		require.NoError(t, m.db.ExpectationsWereMet())
+	})
+
+	t.Run("replica lagging behind reads from the primary", func(t *testing.T) {
+		replica.ExpectQuery("SELECT").WillReturnRows(pgxmock.NewRows([]string{"lag"}).AddRow(float64(30)))
+		m.pg.checkReplicaLag(t.Context())
+		assert.False(t, m.pg.replicaHealthy.Load())
+
+		m.db.ExpectQuery("SELECT").WithArgs("key1").WillReturnRows(rows())
+		_, err := m.pg.Get(t.Context(), &state.GetRequest{Key: "key1"})
+		require.NoError(t, err)
+		require.NoError(t, m.db.ExpectationsWereMet())
+
+		// Once the replica catches up, reads go to the replica again
+		replica.ExpectQuery("SELECT").WillReturnRows(pgxmock.NewRows([]string{"lag"}).AddRow(float64(1)))
+		m.pg.checkReplicaLag(t.Context())
+		assert.True(t, m.pg.replicaHealthy.Load())
+		require.NoError(t, replica.ExpectationsWereMet())
+	})
+}
+
 func createSetRequest() state.SetRequest {
 	return state.SetRequest{
 		Key:   randomKey(),
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+	"github.com/dapr/components-contrib/state"
+)
+
+// Metadata key for query requests: set to "strong" to read from the primary.
+const queryConsistencyKey = "consistency"
+
+// Interval for checking the replication lag of the read replica.
+const readReplicaLagCheckInterval = 5 * time.Second
+
+// Query that returns the replication lag of the replica, in seconds.
+// When the replica has replayed all the WAL it received, the lag is 0, even if the primary has been idle for a while.
+const readReplicaLagQuery = `SELECT
+		CASE
+			WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		END::float8`
+
+// connectReadReplica connects to the read replica, re-using the credentials configured for the primary.
+func (p *PostgreSQL) connectReadReplica(ctx context.Context) error {
+	replicaMd := p.metadata.PostgresAuthMetadata
+	replicaMd.ConnectionString = p.metadata.ReadReplicaConnectionString
+	// The host and port of the primary must not override those in the replica's connection string
+	replicaMd.Host = ""
+	replicaMd.HostAddr = ""
+	replicaMd.Port = ""
+
+	config, err := replicaMd.GetPgxPoolConfig()
+	if err != nil {
+		return fmt.Errorf("invalid read replica connection string: %w", err)
+	}
+	if p.awsAuthProvider != nil {
+		p.awsAuthProvider.UpdatePostgres(ctx, config)
+	}
+
+	connCtx, connCancel := context.WithTimeout(ctx, p.metadata.Timeout)
+	p.replica, err = pgxpool.NewWithConfig(connCtx, config)
+	connCancel()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the read replica: %w", err)
+	}
+
+	pingCtx, pingCancel := context.WithTimeout(ctx, p.metadata.Timeout)
+	err = p.replica.Ping(pingCtx)
+	pingCancel()
+	if err != nil {
+		return fmt.Errorf("failed to ping the read replica: %w", err)
+	}
+
+	p.replicaHealthy.Store(true)
+	if p.metadata.ReadReplicaMaxLag > 0 {
+		p.checkReplicaLag(ctx)
+
+		p.wg.Add(1)
+		go p.monitorReplicaLag()
+	}
+
+	return nil
+}
+
+// monitorReplicaLag periodically checks the replication lag until the component is closed.
+func (p *PostgreSQL) monitorReplicaLag() {
+	defer p.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.closeCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(readReplicaLagCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkReplicaLag(ctx)
+		}
+	}
+}
+
+// checkReplicaLag marks the read replica as unhealthy if its replication lag exceeds the tolerance, so reads are routed to the primary.
+func (p *PostgreSQL) checkReplicaLag(parentCtx context.Context) {
+	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
+	defer cancel()
+
+	var lagSeconds float64
+	err := p.replica.QueryRow(ctx, readReplicaLagQuery).Scan(&lagSeconds)
+	if err != nil {
+		if parentCtx.Err() != nil {
+			return
+		}
+		p.logger.Warnf("Failed to check the replication lag of the read replica, routing reads to the primary: %v", err)
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	healthy := err == nil && lag <= p.metadata.ReadReplicaMaxLag
+	if p.replicaHealthy.Swap(healthy) != healthy {
+		if healthy {
+			p.logger.Infof("Replication lag of the read replica is %v, routing reads to the read replica", lag)
+		} else if err == nil {
+			p.logger.Warnf("Replication lag of the read replica is %v, exceeding the maximum of %v: routing reads to the primary", lag, p.metadata.ReadReplicaMaxLag)
+		}
+	}
+}
+
+// readDB returns the connection to use for reads with the given consistency.
+// Reads use the read replica, if configured and within the staleness tolerance, unless strong consistency is requested.
+func (p *PostgreSQL) readDB(consistency string) pginterfaces.DBQuerier {
+	if p.replica == nil || consistency == state.Strong || !p.replicaHealthy.Load() {
+		return p.db
+	}
+	return p.replica
+}
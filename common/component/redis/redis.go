@@ -44,6 +44,10 @@ const (
 	maxRetryBackoffKey       = "maxRetryBackoff"
 	redisMaxRetriesKey       = "redisMaxRetries"
 	maxRetriesKey            = "maxRetries"
+
+	// Channel of the invalidations of the keys tracked by the connections of the clients.
+	invalidationChannel       = "__redis__:invalidate"
+	invalidationRetryInterval = time.Second
 )
 
 type RedisXMessage struct {
@@ -63,6 +67,13 @@ type RedisXPendingExt struct {
 	RetryCount int64
 }
 
+// RedisChannelMessage is a message received on a Redis PUB/SUB channel.
+type RedisChannelMessage struct {
+	Channel string
+	Pattern string
+	Payload string
+}
+
 type RedisPipeliner interface {
 	Exec(ctx context.Context) error
 	Do(ctx context.Context, args ...interface{})
@@ -80,6 +91,7 @@ type RedisClient interface {
 	Close() error
 	PingResult(ctx context.Context) (string, error)
 	ConfigurationSubscribe(ctx context.Context, args *ConfigurationSubscribeArgs)
+	ChannelSubscribe(ctx context.Context, channel string, isPattern bool, handler func(msg *RedisChannelMessage)) error
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (*bool, error)
 	EvalInt(ctx context.Context, script string, keys []string, args ...interface{}) (*int, error, error)
 	XAdd(ctx context.Context, stream string, maxLenApprox int64, streamTTL string, values map[string]interface{}) (string, error)
@@ -88,9 +100,23 @@ type RedisClient interface {
 	XReadGroupResult(ctx context.Context, group string, consumer string, streams []string, count int64, block time.Duration) ([]RedisXStream, error)
 	XPendingExtResult(ctx context.Context, stream string, group string, start string, end string, count int64) ([]RedisXPendingExt, error)
 	XClaimResult(ctx context.Context, stream string, group string, consumer string, minIdleTime time.Duration, messageIDs []string) ([]RedisXMessage, error)
+	XAutoClaimResult(ctx context.Context, stream string, group string, consumer string, minIdleTime time.Duration, start string, count int64) ([]RedisXMessage, string, error)
 	TxPipeline() RedisPipeliner
 	TTLResult(ctx context.Context, key string) (time.Duration, error)
 	AuthACL(ctx context.Context, username, password string) error
+	InvalidationSubscribe(ctx context.Context, args *InvalidationSubscribeArgs) error
+}
+
+// InvalidationSubscribeArgs are the arguments of InvalidationSubscribe, which receives the invalidations of the keys
+// modified on the server, tracked in broadcasting mode, until the context is canceled.
+type InvalidationSubscribeArgs struct {
+	// Prefixes of the keys whose invalidations are received: all keys when empty.
+	Prefixes []string
+	// Called each time the connection receiving the invalidations is opened, as invalidations may have been missed
+	// while it was closed.
+	OnConnect func()
+	// Called with the invalidated keys: all keys are invalidated when nil, such as when the server is flushed.
+	Handler func(keys []string)
 }
 
 type ConfigurationSubscribeArgs struct {
@@ -138,6 +164,7 @@ func ParseClientFromProperties(properties map[string]string, componentType metad
 		settings.RedeliverInterval = 15 * time.Second
 		settings.QueueDepth = 100
 		settings.Concurrency = 10
+		settings.PubSubMode = PubSubModeStreams
 	}
 
 	err := settings.Decode(properties)
@@ -147,6 +174,15 @@ func ParseClientFromProperties(properties map[string]string, componentType metad
 
 	switch componentType {
 	case metadata.PubSubType:
+		switch settings.PubSubMode {
+		case PubSubModeStreams, PubSubModeChannels:
+		default:
+			return nil, nil, fmt.Errorf("redis client configuration error: invalid pubSubMode %q, expected %q or %q", settings.PubSubMode, PubSubModeStreams, PubSubModeChannels)
+		}
+		if settings.MaxDeliveryCount < 0 {
+			return nil, nil, errors.New("redis client configuration error: maxDeliveryCount must not be negative")
+		}
+
 		if val, ok := properties[processingTimeoutKey]; ok && val != "" {
 			if processingTimeoutMs, parseErr := strconv.ParseUint(val, 10, 64); parseErr == nil {
 				// because of legacy reasons, we need to interpret a number as milliseconds
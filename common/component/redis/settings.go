@@ -22,6 +22,13 @@ import (
 	"github.com/dapr/kit/config"
 )
 
+const (
+	// PubSubModeStreams delivers pub/sub messages through Redis Streams and consumer groups.
+	PubSubModeStreams = "streams"
+	// PubSubModeChannels delivers pub/sub messages through classic Redis PUB/SUB channels.
+	PubSubModeChannels = "channels"
+)
+
 type Settings struct {
 	// The Redis host
 	Host string `mapstructure:"redisHost"`
@@ -86,6 +93,17 @@ type Settings struct {
 	// == state only properties ==
 	TTLInSeconds *int   `mapstructure:"ttlInSeconds" mdonly:"state"`
 	QueryIndexes string `mapstructure:"queryIndexes" mdonly:"state"`
+	// Stores all values as RedisJSON documents, regardless of the content type of the requests
+	UseJSON bool `mapstructure:"useJSON" mdonly:"state"`
+	// Hash tag strategy of the keys, co-locating the keys of transactions in the same hash slot on Redis Cluster:
+	// none, prefix or parent
+	KeyHashTag string `mapstructure:"keyHashTag" mdonly:"state"`
+	// Enables the client-side cache of the values read, invalidated by the server when their keys are modified
+	ClientCache bool `mapstructure:"clientCache" mdonly:"state"`
+	// Maximum time values are kept in the client-side cache
+	ClientCacheTTL Duration `mapstructure:"clientCacheTTL" mdonly:"state"`
+	// Maximum memory of the values in the client-side cache, in bytes
+	ClientCacheMaxMemory int64 `mapstructure:"clientCacheMaxMemory" mdonly:"state"`
 
 	// == pubsub only properties ==
 	// The consumer identifier
@@ -99,12 +117,26 @@ type Settings struct {
 	// The number of concurrent workers that are processing messages
 	Concurrency uint `mapstructure:"concurrency" mdonly:"pubsub"`
 
+	// Reclaim pending messages with XAUTOCLAIM instead of XPENDING and XCLAIM (requires Redis 6.2+)
+	UseXAutoClaim bool `mapstructure:"useXAutoClaim" mdonly:"pubsub"`
+	// The number of deliveries after which a pending message is moved to the dead-letter stream (0 disables it)
+	MaxDeliveryCount int64 `mapstructure:"maxDeliveryCount" mdonly:"pubsub"`
+	// The stream that messages are moved to after maxDeliveryCount deliveries; defaults to "<topic>-deadletter"
+	DeadLetterStream string `mapstructure:"deadLetterStream" mdonly:"pubsub"`
+
 	// The max len of stream
 	MaxLenApprox int64 `mapstructure:"maxLenApprox" mdonly:"pubsub"`
 
 	// The TTL of stream entries
 	StreamTTL time.Duration `mapstructure:"streamTTL" mdonly:"pubsub"`
 
+	// The delivery mode: "streams" (default) uses Redis Streams with consumer groups,
+	// "channels" uses classic Redis PUB/SUB without persistence or redelivery
+	PubSubMode string `mapstructure:"pubSubMode" mdonly:"pubsub"`
+
+	// The keyspace events to enable with CONFIG SET notify-keyspace-events when using channels
+	NotifyKeyspaceEvents string `mapstructure:"notifyKeyspaceEvents" mdonly:"pubsub"`
+
 	// EntraID / AzureAD Authentication based on the shared code which essentially uses the DefaultAzureCredential
 	// from the official Azure Identity SDK for Go
 	UseEntraID bool `mapstructure:"useEntraID" mapstructurealiases:"useAzureAD"`
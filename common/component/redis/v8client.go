@@ -16,6 +16,7 @@ package redis
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"strings"
 	"time"
 
@@ -94,6 +95,42 @@ func (c v8Client) ConfigurationSubscribe(ctx context.Context, args *Configuratio
 	}
 }
 
+// ChannelSubscribe subscribes to a channel, or to a pattern of channels, and returns once the server confirmed the subscription.
+// Messages are passed to handler from a background goroutine until ctx is canceled.
+func (c v8Client) ChannelSubscribe(ctx context.Context, channel string, isPattern bool, handler func(msg *RedisChannelMessage)) error {
+	var p *v8.PubSub
+	if isPattern {
+		p = c.client.PSubscribe(ctx, channel)
+	} else {
+		p = c.client.Subscribe(ctx, channel)
+	}
+	if _, err := p.Receive(ctx); err != nil {
+		p.Close()
+		return err
+	}
+
+	ch := p.Channel()
+	go func() {
+		defer p.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				handler(&RedisChannelMessage{
+					Channel: msg.Channel,
+					Pattern: msg.Pattern,
+					Payload: msg.Payload,
+				})
+			}
+		}
+	}()
+	return nil
+}
+
 func (c v8Client) Del(ctx context.Context, keys ...string) error {
 	err := c.client.Del(ctx, keys...).Err()
 	if err != nil {
@@ -297,6 +334,38 @@ func (c v8Client) XClaimResult(ctx context.Context, stream string, group string,
 	return redisXMessages, nil
 }
 
+// XAutoClaimResult claims the pending messages idle for at least minIdleTime, starting from the start ID.
+// It returns the claimed messages and the ID to start the next call from, which is "0-0" when the scan is complete.
+func (c v8Client) XAutoClaimResult(ctx context.Context, stream string, group string, consumer string, minIdleTime time.Duration, start string, count int64) ([]RedisXMessage, string, error) {
+	var readCtx context.Context
+	if c.readTimeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(c.readTimeout))
+		defer cancel()
+		readCtx = timeoutCtx
+	} else {
+		readCtx = ctx
+	}
+	res, next, err := c.client.XAutoClaim(readCtx, &v8.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdleTime,
+		Start:    start,
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// convert res to []RedisXMessage
+	redisXMessages := make([]RedisXMessage, len(res))
+	for i, xMessage := range res {
+		redisXMessages[i] = RedisXMessage(xMessage)
+	}
+
+	return redisXMessages, next, nil
+}
+
 func (c v8Client) TxPipeline() RedisPipeliner {
 	return v8Pipeliner{
 		pipeliner:    c.client.TxPipeline(),
@@ -322,6 +391,78 @@ func (c v8Client) AuthACL(ctx context.Context, username, password string) error
 	return statusCmd.Err()
 }
 
+func (c v8Client) InvalidationSubscribe(ctx context.Context, args *InvalidationSubscribeArgs) error {
+	client, ok := c.client.(*v8.Client)
+	if !ok {
+		return errors.New("invalidations are not supported with redis cluster")
+	}
+
+	// Invalidations are received on a dedicated connection, which redirects the invalidations of the keys it tracks to
+	// itself, and receives them as messages of the invalidation channel.
+	opts := *client.Options()
+	opts.PoolSize = 1
+	opts.MinIdleConns = 0
+	opts.OnConnect = func(ctx context.Context, cn *v8.Conn) error {
+		id, err := cn.ClientID(ctx).Result()
+		if err != nil {
+			return err
+		}
+		trackingArgs := []interface{}{"CLIENT", "TRACKING", "ON", "REDIRECT", id, "BCAST"}
+		for _, prefix := range args.Prefixes {
+			trackingArgs = append(trackingArgs, "PREFIX", prefix)
+		}
+		err = cn.Process(ctx, v8.NewCmd(ctx, trackingArgs...))
+		if err != nil {
+			return err
+		}
+		if args.OnConnect != nil {
+			args.OnConnect()
+		}
+		return nil
+	}
+	sub := v8.NewClient(&opts)
+
+	p := sub.Subscribe(ctx, invalidationChannel)
+	if _, err := p.Receive(ctx); err != nil {
+		p.Close()
+		sub.Close()
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		p.Close()
+		sub.Close()
+	}()
+	go func() {
+		for {
+			msg, err := p.Receive(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				// Invalidations of all keys have no payload, which the client fails to parse: so all keys are
+				// invalidated on errors, which also happen when the connection is lost
+				args.Handler(nil)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(invalidationRetryInterval):
+				}
+				continue
+			}
+			if m, ok := msg.(*v8.Message); ok && m.Channel == invalidationChannel {
+				if m.PayloadSlice != nil {
+					args.Handler(m.PayloadSlice)
+				} else {
+					args.Handler([]string{m.Payload})
+				}
+			}
+		}
+	}()
+	return nil
+}
+
 func newV8FailoverClient(s *Settings) (RedisClient, error) {
 	if s == nil {
 		return nil, nil
@@ -16,6 +16,7 @@ package redis
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"strings"
 	"time"
 
@@ -72,6 +73,42 @@ func (c v9Client) DoRead(ctx context.Context, args ...interface{}) (interface{},
 	return c.client.Do(ctx, args...).Result()
 }
 
+// ChannelSubscribe subscribes to a channel, or to a pattern of channels, and returns once the server confirmed the subscription.
+// Messages are passed to handler from a background goroutine until ctx is canceled.
+func (c v9Client) ChannelSubscribe(ctx context.Context, channel string, isPattern bool, handler func(msg *RedisChannelMessage)) error {
+	var p *v9.PubSub
+	if isPattern {
+		p = c.client.PSubscribe(ctx, channel)
+	} else {
+		p = c.client.Subscribe(ctx, channel)
+	}
+	if _, err := p.Receive(ctx); err != nil {
+		p.Close()
+		return err
+	}
+
+	ch := p.Channel()
+	go func() {
+		defer p.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				handler(&RedisChannelMessage{
+					Channel: msg.Channel,
+					Pattern: msg.Pattern,
+					Payload: msg.Payload,
+				})
+			}
+		}
+	}()
+	return nil
+}
+
 func (c v9Client) Del(ctx context.Context, keys ...string) error {
 	err := c.client.Del(ctx, keys...).Err()
 	if err != nil {
@@ -297,6 +334,38 @@ func (c v9Client) XClaimResult(ctx context.Context, stream string, group string,
 	return redisXMessages, nil
 }
 
+// XAutoClaimResult claims the pending messages idle for at least minIdleTime, starting from the start ID.
+// It returns the claimed messages and the ID to start the next call from, which is "0-0" when the scan is complete.
+func (c v9Client) XAutoClaimResult(ctx context.Context, stream string, group string, consumer string, minIdleTime time.Duration, start string, count int64) ([]RedisXMessage, string, error) {
+	var readCtx context.Context
+	if c.readTimeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(c.readTimeout))
+		defer cancel()
+		readCtx = timeoutCtx
+	} else {
+		readCtx = ctx
+	}
+	res, next, err := c.client.XAutoClaim(readCtx, &v9.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdleTime,
+		Start:    start,
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// convert res to []RedisXMessage
+	redisXMessages := make([]RedisXMessage, len(res))
+	for i, xMessage := range res {
+		redisXMessages[i] = RedisXMessage(xMessage)
+	}
+
+	return redisXMessages, next, nil
+}
+
 func (c v9Client) TxPipeline() RedisPipeliner {
 	return v9Pipeliner{
 		pipeliner:    c.client.TxPipeline(),
@@ -322,6 +391,80 @@ func (c v9Client) AuthACL(ctx context.Context, username, password string) error
 	return statusCmd.Err()
 }
 
+func (c v9Client) InvalidationSubscribe(ctx context.Context, args *InvalidationSubscribeArgs) error {
+	client, ok := c.client.(*v9.Client)
+	if !ok {
+		return errors.New("invalidations are not supported with redis cluster")
+	}
+
+	// Invalidations are received on a dedicated connection, which redirects the invalidations of the keys it tracks to
+	// itself, and receives them as messages of the invalidation channel. The RESP2 protocol is used, as the client does not
+	// support the push messages of RESP3 connections.
+	opts := *client.Options()
+	opts.Protocol = 2
+	opts.PoolSize = 1
+	opts.MinIdleConns = 0
+	opts.OnConnect = func(ctx context.Context, cn *v9.Conn) error {
+		id, err := cn.ClientID(ctx).Result()
+		if err != nil {
+			return err
+		}
+		trackingArgs := []interface{}{"CLIENT", "TRACKING", "ON", "REDIRECT", id, "BCAST"}
+		for _, prefix := range args.Prefixes {
+			trackingArgs = append(trackingArgs, "PREFIX", prefix)
+		}
+		err = cn.Process(ctx, v9.NewCmd(ctx, trackingArgs...))
+		if err != nil {
+			return err
+		}
+		if args.OnConnect != nil {
+			args.OnConnect()
+		}
+		return nil
+	}
+	sub := v9.NewClient(&opts)
+
+	p := sub.Subscribe(ctx, invalidationChannel)
+	if _, err := p.Receive(ctx); err != nil {
+		p.Close()
+		sub.Close()
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		p.Close()
+		sub.Close()
+	}()
+	go func() {
+		for {
+			msg, err := p.Receive(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				// Invalidations of all keys have no payload, which the client fails to parse: so all keys are
+				// invalidated on errors, which also happen when the connection is lost
+				args.Handler(nil)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(invalidationRetryInterval):
+				}
+				continue
+			}
+			if m, ok := msg.(*v9.Message); ok && m.Channel == invalidationChannel {
+				if m.PayloadSlice != nil {
+					args.Handler(m.PayloadSlice)
+				} else {
+					args.Handler([]string{m.Payload})
+				}
+			}
+		}
+	}()
+	return nil
+}
+
 func newV9FailoverClient(s *Settings) (RedisClient, error) {
 	if s == nil {
 		return nil, nil
@@ -462,3 +605,9 @@ func newV9Client(s *Settings) (RedisClient, error) {
 		dialTimeout:  s.DialTimeout,
 	}, nil
 }
+
+// ClientFromV9Client wraps an existing go-redis v9 client in a RedisClient, using the default timeouts.
+// It's meant for tests in other packages, which connect to a test server with the v9 client.
+func ClientFromV9Client(client v9.UniversalClient) RedisClient {
+	return v9Client{client: client}
+}
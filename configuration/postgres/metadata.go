@@ -24,7 +24,9 @@ import (
 )
 
 const (
-	defaultTimeout = 20 * time.Second // Default timeout for network requests
+	defaultTimeout              = 20 * time.Second // Default timeout for network requests
+	defaultReconnectMinInterval = 1 * time.Second  // Default initial delay before re-establishing a LISTEN connection
+	defaultReconnectMaxInterval = 60 * time.Second // Default upper bound for the delay between reconnection attempts
 )
 
 type metadata struct {
@@ -33,6 +35,11 @@ type metadata struct {
 	ConfigTable                 string        `mapstructure:"table"`
 	MaxIdleTimeoutOld           time.Duration `mapstructure:"connMaxIdleTime"` // Deprecated alias for "connectionMaxIdleTime"
 	aws.DeprecatedPostgresIAM   `mapstructure:",squash"`
+
+	// Minimum and maximum delay between attempts to re-establish a dropped LISTEN connection.
+	// The actual delay grows exponentially between the two, with random jitter.
+	ReconnectMinInterval time.Duration `mapstructure:"reconnectMinInterval"`
+	ReconnectMaxInterval time.Duration `mapstructure:"reconnectMaxInterval"`
 }
 
 func (m *metadata) InitWithMetadata(meta map[string]string) error {
@@ -41,6 +48,8 @@ func (m *metadata) InitWithMetadata(meta map[string]string) error {
 	m.ConfigTable = ""
 	m.MaxIdleTimeoutOld = 0
 	m.Timeout = defaultTimeout
+	m.ReconnectMinInterval = defaultReconnectMinInterval
+	m.ReconnectMaxInterval = defaultReconnectMaxInterval
 
 	err := kitmd.DecodeMetadata(meta, &m)
 	if err != nil {
@@ -63,6 +72,13 @@ func (m *metadata) InitWithMetadata(meta map[string]string) error {
 		return fmt.Errorf("invalid table name '%s'. non-alphanumerics or upper cased table names are not supported", m.ConfigTable)
 	}
 
+	if m.ReconnectMinInterval <= 0 {
+		return errors.New("reconnectMinInterval must be greater than zero")
+	}
+	if m.ReconnectMaxInterval < m.ReconnectMinInterval {
+		return fmt.Errorf("reconnectMaxInterval (%v) must not be less than reconnectMinInterval (%v)", m.ReconnectMaxInterval, m.ReconnectMinInterval)
+	}
+
 	opts := pgauth.InitWithMetadataOpts{
 		AzureADEnabled: true,
 		AWSIAMEnabled:  true,
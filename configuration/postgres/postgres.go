@@ -25,7 +25,9 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -58,6 +60,12 @@ type ConfigurationStore struct {
 type subscription struct {
 	channel string
 	keys    []string
+
+	// versions holds the last version seen for each key, used to detect notifications missed while the LISTEN connection was down.
+	// It is only accessed by the goroutine that owns the subscription.
+	versions map[string]string
+	// reconnecting is set while the LISTEN connection is down.
+	reconnecting atomic.Bool
 }
 
 type pgResponse struct {
@@ -262,30 +270,104 @@ func (p *ConfigurationStore) Unsubscribe(ctx context.Context, req *configuration
 	return nil
 }
 
-func (p *ConfigurationStore) doSubscribe(ctx context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler, command string, channel string, subscription string) {
+func (p *ConfigurationStore) doSubscribe(ctx context.Context, handler configuration.UpdateHandler, command string, sub *subscription, subscriptionID string) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = p.metadata.ReconnectMinInterval
+	bo.MaxInterval = p.metadata.ReconnectMaxInterval
+	bo.MaxElapsedTime = 0
+
+	resync := false
+	for {
+		err := p.listen(ctx, handler, command, sub, subscriptionID, resync, bo)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The LISTEN connection was lost: any notification sent until we are listening again is dropped by the server, so once reconnected we compare versions against the table
+		sub.reconnecting.Store(true)
+		resync = true
+		delay := bo.NextBackOff()
+		p.logger.Warnf("Connection listening to channel '%s' was lost, reconnecting in %v: %v", sub.channel, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// listen acquires a connection, issues the LISTEN command and dispatches notifications until the connection fails or ctx is canceled.
+func (p *ConfigurationStore) listen(ctx context.Context, handler configuration.UpdateHandler, command string, sub *subscription, subscriptionID string, resync bool, bo backoff.BackOff) error {
 	conn, err := p.client.Acquire(ctx)
 	if err != nil {
-		p.logger.Errorf("error acquiring connection:", err)
-		return
+		return fmt.Errorf("error acquiring connection: %w", err)
 	}
 	defer conn.Release()
 	if _, err = conn.Exec(ctx, command); err != nil {
-		p.logger.Errorf("error listening to channel:", err)
-		return
+		return fmt.Errorf("error listening to channel: %w", err)
+	}
+
+	if sub.reconnecting.Swap(false) {
+		p.logger.Infof("Resumed listening to channel '%s'", sub.channel)
 	}
+	bo.Reset()
+
+	// Load the current versions only after LISTEN succeeded, so that no change can fall in between
+	p.syncVersions(ctx, handler, sub, subscriptionID, resync)
+
 	for {
 		notification, err := conn.Conn().WaitForNotification(ctx)
 		if err != nil {
-			if !pgconn.Timeout(err) && !errors.Is(err, context.Canceled) {
-				p.logger.Errorf("error waiting for notification:", err)
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
-			return
+			return fmt.Errorf("error waiting for notification: %w", err)
 		}
-		p.handleSubscribedChange(ctx, handler, notification, channel, subscription)
+		p.handleSubscribedChange(ctx, handler, notification, sub, subscriptionID)
 	}
 }
 
-func (p *ConfigurationStore) handleSubscribedChange(ctx context.Context, handler configuration.UpdateHandler, msg *pgconn.Notification, channel string, subscriptionID string) {
+// syncVersions reads the subscribed keys from the table and records their versions.
+// When notify is true, items whose version differs from the last one seen are delivered to the handler.
+func (p *ConfigurationStore) syncVersions(ctx context.Context, handler configuration.UpdateHandler, sub *subscription, subscriptionID string, notify bool) {
+	res, err := p.Get(ctx, &configuration.GetRequest{Keys: sub.keys})
+	if err != nil {
+		p.logger.Warnf("Failed to read current versions for subscription '%s': %v", subscriptionID, err)
+		return
+	}
+
+	changed := updateVersions(sub.versions, res.Items)
+	if !notify || len(changed) == 0 {
+		return
+	}
+
+	p.logger.Infof("Delivering %d configuration item(s) changed while subscription '%s' was disconnected", len(changed), subscriptionID)
+	err = handler(ctx, &configuration.UpdateEvent{
+		Items: changed,
+		ID:    subscriptionID,
+	})
+	if err != nil {
+		p.logger.Errorf("failed to call notify event handler : %v", err)
+	}
+}
+
+// updateVersions records the version of each item in versions and returns the items whose version changed.
+func updateVersions(versions map[string]string, items map[string]*configuration.Item) map[string]*configuration.Item {
+	changed := make(map[string]*configuration.Item)
+	for key, item := range items {
+		if last, ok := versions[key]; ok && last == item.Version {
+			continue
+		}
+		versions[key] = item.Version
+		changed[key] = item
+	}
+	return changed
+}
+
+func (p *ConfigurationStore) handleSubscribedChange(ctx context.Context, handler configuration.UpdateHandler, msg *pgconn.Notification, sub *subscription, subscriptionID string) {
 	payload := make(map[string]interface{})
 	err := json.Unmarshal([]byte(msg.Payload), &payload)
 	if err != nil {
@@ -303,7 +385,7 @@ func (p *ConfigurationStore) handleSubscribedChange(ctx context.Context, handler
 			switch strings.ToLower(strKey) {
 			case "key":
 				key = v.Interface().(string)
-				if yes := p.isSubscribed(subscriptionID, channel, key); !yes {
+				if yes := p.isSubscribed(subscriptionID, sub.channel, key); !yes {
 					p.logger.Debugf("ignoring notification for %v", key)
 					return
 				}
@@ -321,6 +403,7 @@ func (p *ConfigurationStore) handleSubscribedChange(ctx context.Context, handler
 				}
 			}
 		}
+		sub.versions[key] = version
 		e := &configuration.UpdateEvent{
 			Items: map[string]*configuration.Item{
 				key: {
@@ -412,14 +495,16 @@ func (p *ConfigurationStore) subscribeToChannel(ctx context.Context, pgNotifyCha
 	childContext, cancel := context.WithCancel(ctx)
 	p.cancelMap.Store(subscribeID, cancel)
 
-	p.ActiveSubscriptions[subscribeID] = &subscription{
-		channel: pgNotifyChannel,
-		keys:    req.Keys,
+	sub := &subscription{
+		channel:  pgNotifyChannel,
+		keys:     req.Keys,
+		versions: make(map[string]string),
 	}
+	p.ActiveSubscriptions[subscribeID] = sub
 
 	p.wg.Add(1)
 	go func() {
-		p.doSubscribe(childContext, req, handler, pgNotifyCmd, pgNotifyChannel, subscribeID)
+		p.doSubscribe(childContext, handler, pgNotifyCmd, sub, subscribeID)
 		p.configLock.Lock()
 		delete(p.ActiveSubscriptions, subscribeID)
 		p.configLock.Unlock()
@@ -429,6 +514,22 @@ func (p *ConfigurationStore) subscribeToChannel(ctx context.Context, pgNotifyCha
 	return subscribeID, nil
 }
 
+// Ping checks the connection to the database and reports an error if any subscription has lost its LISTEN connection.
+func (p *ConfigurationStore) Ping(ctx context.Context) error {
+	if err := p.client.Ping(ctx); err != nil {
+		return fmt.Errorf("PostgreSQL configuration store ping error: %w", err)
+	}
+
+	p.configLock.RLock()
+	defer p.configLock.RUnlock()
+	for id, sub := range p.ActiveSubscriptions {
+		if sub.reconnecting.Load() {
+			return fmt.Errorf("subscription '%s' is not listening to channel '%s'", id, sub.channel)
+		}
+	}
+	return nil
+}
+
 // GetComponentMetadata returns the metadata of the component.
 func (p *ConfigurationStore) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
 	metadataStruct := metadata{}
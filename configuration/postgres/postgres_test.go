@@ -115,3 +115,52 @@ func TestValidateInput(t *testing.T) {
 	keys3 := []string{"Name 1=1"}
 	require.Error(t, validateInput(keys3), "invalid key : 'Name 1=1'")
 }
+
+func TestUpdateVersions(t *testing.T) {
+	versions := map[string]string{}
+
+	changed := updateVersions(versions, map[string]*configuration.Item{
+		"key1": {Value: "a", Version: "1"},
+		"key2": {Value: "b", Version: "1"},
+	})
+	assert.Len(t, changed, 2)
+	assert.Equal(t, map[string]string{"key1": "1", "key2": "1"}, versions)
+
+	changed = updateVersions(versions, map[string]*configuration.Item{
+		"key1": {Value: "a", Version: "1"},
+		"key2": {Value: "c", Version: "2"},
+	})
+	require.Len(t, changed, 1)
+	assert.Equal(t, "c", changed["key2"].Value)
+	assert.Equal(t, "2", versions["key2"])
+
+	changed = updateVersions(versions, map[string]*configuration.Item{
+		"key1": {Value: "a", Version: "1"},
+	})
+	assert.Empty(t, changed)
+}
+
+func TestReconnectIntervals(t *testing.T) {
+	props := map[string]string{
+		"connectionString": "host=localhost",
+		"table":            "cfgtbl",
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		m := metadata{}
+		require.NoError(t, m.InitWithMetadata(props))
+		assert.Equal(t, defaultReconnectMinInterval, m.ReconnectMinInterval)
+		assert.Equal(t, defaultReconnectMaxInterval, m.ReconnectMaxInterval)
+	})
+
+	t.Run("max lower than min", func(t *testing.T) {
+		m := metadata{}
+		err := m.InitWithMetadata(map[string]string{
+			"connectionString":     "host=localhost",
+			"table":                "cfgtbl",
+			"reconnectMinInterval": "10s",
+			"reconnectMaxInterval": "5s",
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+// Algorithms for AES Key Wrap with Padding (RFC 5649).
+// These are not defined by JWA, so they are implemented here rather than in the shared primitives.
+const (
+	Algorithm_A128KWP = "A128KWP" // Encryption: AES Key Wrap with Padding (RFC 5649), 128-bit key
+	Algorithm_A192KWP = "A192KWP" // Encryption: AES Key Wrap with Padding (RFC 5649), 192-bit key
+	Algorithm_A256KWP = "A256KWP" // Encryption: AES Key Wrap with Padding (RFC 5649), 256-bit key
+)
+
+// ErrInvalidWrappedKey is returned when a key wrapped with AES-KWP fails the integrity check.
+var ErrInvalidWrappedKey = errors.New("failed to unwrap key: integrity check failed")
+
+// Alternative initial value prefix, as per RFC 5649 section 3.
+var aeskwpAIV = [4]byte{0xA6, 0x59, 0x59, 0xA6}
+
+// SupportedKeyWrapPaddedAlgorithms returns the list of AES-KWP algorithms.
+func SupportedKeyWrapPaddedAlgorithms() []string {
+	return []string{Algorithm_A128KWP, Algorithm_A192KWP, Algorithm_A256KWP}
+}
+
+// IsKeyWrapPaddedAlgorithm returns true if the algorithm is AES-KWP.
+func IsKeyWrapPaddedAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case Algorithm_A128KWP, Algorithm_A192KWP, Algorithm_A256KWP:
+		return true
+	default:
+		return false
+	}
+}
+
+// EncryptAESKWP wraps the plaintext with AES Key Wrap with Padding (RFC 5649), using a symmetric key.
+func EncryptAESKWP(plaintext []byte, algorithm string, key jwk.Key) (ciphertext []byte, err error) {
+	block, err := aeskwpCipher(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return WrapKeyPadded(block, plaintext)
+}
+
+// DecryptAESKWP unwraps a ciphertext that was wrapped with AES Key Wrap with Padding (RFC 5649), using a symmetric key.
+func DecryptAESKWP(ciphertext []byte, algorithm string, key jwk.Key) (plaintext []byte, err error) {
+	block, err := aeskwpCipher(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return UnwrapKeyPadded(block, ciphertext)
+}
+
+func aeskwpCipher(algorithm string, key jwk.Key) (cipher.Block, error) {
+	var keyBytes []byte
+	if key.KeyType() != jwa.OctetSeq || key.Raw(&keyBytes) != nil {
+		return nil, internals.ErrKeyTypeMismatch
+	}
+
+	var expectedSize int
+	switch algorithm {
+	case Algorithm_A128KWP:
+		expectedSize = 16
+	case Algorithm_A192KWP:
+		expectedSize = 24
+	case Algorithm_A256KWP:
+		expectedSize = 32
+	default:
+		return nil, internals.ErrUnsupportedAlgorithm
+	}
+	if len(keyBytes) != expectedSize {
+		return nil, internals.ErrKeyTypeMismatch
+	}
+
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return nil, internals.ErrKeyTypeMismatch
+	}
+	return block, nil
+}
+
+// WrapKeyPadded wraps a key of any non-zero length using AES Key Wrap with Padding (RFC 5649).
+func WrapKeyPadded(block cipher.Block, plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 || uint64(len(plaintext)) > 0xFFFFFFFF {
+		return nil, errors.New("invalid key length")
+	}
+
+	// Build the alternative initial value, which includes the message length indicator
+	var aiv [8]byte
+	copy(aiv[:4], aeskwpAIV[:])
+	binary.BigEndian.PutUint32(aiv[4:], uint32(len(plaintext))) //nolint:gosec
+
+	// Pad the plaintext with zeros to a multiple of 8 bytes
+	n := (len(plaintext) + 7) / 8
+	out := make([]byte, 8+n*8)
+	copy(out[:8], aiv[:])
+	copy(out[8:], plaintext)
+
+	// If the padded plaintext is a single block, it's encrypted with AES in ECB mode
+	if n == 1 {
+		block.Encrypt(out, out)
+		return out, nil
+	}
+
+	// Otherwise, use the wrapping process from RFC 3394 with the alternative initial value
+	var (
+		b [16]byte
+		t uint64
+	)
+	for j := range 6 {
+		for i := 1; i <= n; i++ {
+			t = uint64(n*j + i) //nolint:gosec
+			copy(b[:8], out[:8])
+			copy(b[8:], out[i*8:(i+1)*8])
+			block.Encrypt(b[:], b[:])
+			binary.BigEndian.PutUint64(out[:8], binary.BigEndian.Uint64(b[:8])^t)
+			copy(out[i*8:(i+1)*8], b[8:])
+		}
+	}
+	return out, nil
+}
+
+// UnwrapKeyPadded unwraps a key that was wrapped using AES Key Wrap with Padding (RFC 5649).
+func UnwrapKeyPadded(block cipher.Block, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 16 || len(ciphertext)%8 != 0 {
+		return nil, ErrInvalidWrappedKey
+	}
+
+	n := len(ciphertext)/8 - 1
+	out := make([]byte, len(ciphertext))
+	copy(out, ciphertext)
+
+	if n == 1 {
+		block.Decrypt(out, out)
+	} else {
+		var (
+			b [16]byte
+			t uint64
+		)
+		for j := 5; j >= 0; j-- {
+			for i := n; i >= 1; i-- {
+				t = uint64(n*j + i) //nolint:gosec
+				binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(out[:8])^t)
+				copy(b[8:], out[i*8:(i+1)*8])
+				block.Decrypt(b[:], b[:])
+				copy(out[:8], b[:8])
+				copy(out[i*8:(i+1)*8], b[8:])
+			}
+		}
+	}
+
+	// Validate the alternative initial value and the padding
+	if subtle.ConstantTimeCompare(out[:4], aeskwpAIV[:]) != 1 {
+		return nil, ErrInvalidWrappedKey
+	}
+	mli := int(binary.BigEndian.Uint32(out[4:8]))
+	if mli <= 8*(n-1) || mli > 8*n {
+		return nil, ErrInvalidWrappedKey
+	}
+	for _, v := range out[8+mli:] {
+		if v != 0 {
+			return nil, ErrInvalidWrappedKey
+		}
+	}
+
+	return out[8 : 8+mli], nil
+}
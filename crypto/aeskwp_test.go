@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+func TestAESKWPVectors(t *testing.T) {
+	// Test vectors from RFC 5649 section 6
+	kek, _ := hex.DecodeString("5840df6e29b02af1ab493b705bf16ea1ae8338f4dcc176a8")
+	block, err := aes.NewCipher(kek)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		plaintext  string
+		ciphertext string
+	}{
+		{name: "20 bytes", plaintext: "c37b7e6492584340bed12207808941155068f738", ciphertext: "138bdeaa9b8fa7fc61f97742e72248ee5ae6ae5360d1ae6a5f54f373fa543b6a"},
+		{name: "7 bytes", plaintext: "466f7250617369", ciphertext: "afbeb0f07dfbf5419200f2ccb50bb24f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, _ := hex.DecodeString(tt.plaintext)
+
+			wrapped, err := WrapKeyPadded(block, plaintext)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ciphertext, hex.EncodeToString(wrapped))
+
+			unwrapped, err := UnwrapKeyPadded(block, wrapped)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, unwrapped)
+		})
+	}
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		wrapped, _ := hex.DecodeString(tests[0].ciphertext)
+		wrapped[len(wrapped)-1] ^= 1
+		_, err := UnwrapKeyPadded(block, wrapped)
+		require.ErrorIs(t, err, ErrInvalidWrappedKey)
+	})
+
+	t.Run("invalid ciphertext length", func(t *testing.T) {
+		_, err := UnwrapKeyPadded(block, make([]byte, 12))
+		require.ErrorIs(t, err, ErrInvalidWrappedKey)
+	})
+
+	t.Run("empty plaintext", func(t *testing.T) {
+		_, err := WrapKeyPadded(block, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestLocalCryptoKeyWrap(t *testing.T) {
+	newKey := func(t *testing.T, size int) jwk.Key {
+		t.Helper()
+		b := make([]byte, size)
+		_, err := rand.Read(b)
+		require.NoError(t, err)
+		key, err := jwk.FromRaw(b)
+		require.NoError(t, err)
+		return key
+	}
+
+	keys := map[string]jwk.Key{
+		"k128": newKey(t, 16),
+		"k256": newKey(t, 32),
+	}
+	component := LocalCryptoBaseComponent{
+		RetrieveKeyFn: func(_ context.Context, key string) (jwk.Key, error) {
+			k, ok := keys[key]
+			if !ok {
+				return nil, ErrKeyNotFound
+			}
+			return k, nil
+		},
+	}
+
+	tests := []struct {
+		algorithm string
+		keyName   string
+		nonceSize int
+		keySize   int
+	}{
+		{algorithm: internals.Algorithm_A256KW, keyName: "k256", keySize: 32},
+		{algorithm: Algorithm_A128KWP, keyName: "k128", keySize: 20},
+		{algorithm: Algorithm_A256KWP, keyName: "k256", keySize: 7},
+		{algorithm: internals.Algorithm_C20PKW, keyName: "k256", nonceSize: 12, keySize: 32},
+		{algorithm: internals.Algorithm_XC20PKW, keyName: "k256", nonceSize: 24, keySize: 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			assert.True(t, slices.Contains(component.SupportedEncryptionAlgorithms(), tt.algorithm))
+
+			plaintextKey := newKey(t, tt.keySize)
+			nonce := make([]byte, tt.nonceSize)
+			_, err := rand.Read(nonce)
+			require.NoError(t, err)
+
+			wrapped, tag, err := component.WrapKey(t.Context(), plaintextKey, tt.algorithm, tt.keyName, nonce, nil)
+			require.NoError(t, err)
+
+			unwrapped, err := component.UnwrapKey(t.Context(), wrapped, tt.algorithm, tt.keyName, nonce, tag, nil)
+			require.NoError(t, err)
+
+			var expect, actual []byte
+			require.NoError(t, plaintextKey.Raw(&expect))
+			require.NoError(t, unwrapped.Raw(&actual))
+			assert.Equal(t, expect, actual)
+		})
+	}
+
+	t.Run("AES-KWP with wrong key size", func(t *testing.T) {
+		_, _, err := component.WrapKey(t.Context(), newKey(t, 16), Algorithm_A256KWP, "k128", nil, nil)
+		require.Error(t, err)
+	})
+}
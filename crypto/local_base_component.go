@@ -77,7 +77,7 @@ func (k LocalCryptoBaseComponent) Encrypt(parentCtx context.Context, plaintext [
 	}
 
 	// Encrypt the data
-	ciphertext, tag, err = internals.Encrypt(plaintext, algorithm, key, nonce, associatedData)
+	ciphertext, tag, err = encrypt(plaintext, algorithm, key, nonce, associatedData)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
@@ -100,7 +100,7 @@ func (k LocalCryptoBaseComponent) Decrypt(parentCtx context.Context, ciphertext
 	}
 
 	// Decrypt the data
-	plaintext, err = internals.Decrypt(ciphertext, algorithm, key, nonce, tag, associatedData)
+	plaintext, err = decrypt(ciphertext, algorithm, key, nonce, tag, associatedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
@@ -129,7 +129,7 @@ func (k LocalCryptoBaseComponent) WrapKey(parentCtx context.Context, plaintextKe
 	}
 
 	// Encrypt the data
-	wrappedKey, tag, err = internals.Encrypt(plaintext, algorithm, kek, nonce, associatedData)
+	wrappedKey, tag, err = encrypt(plaintext, algorithm, kek, nonce, associatedData)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
@@ -152,7 +152,7 @@ func (k LocalCryptoBaseComponent) UnwrapKey(parentCtx context.Context, wrappedKe
 	}
 
 	// Decrypt the data
-	plaintext, err := internals.Decrypt(wrappedKey, algorithm, kek, nonce, tag, associatedData)
+	plaintext, err := decrypt(wrappedKey, algorithm, kek, nonce, tag, associatedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
@@ -224,10 +224,29 @@ func (k LocalCryptoBaseComponent) SupportedSignatureAlgorithms() []string {
 
 func populateSupportedAlgs() {
 	symmetric := internals.SupportedSymmetricAlgorithms()
+	kwp := SupportedKeyWrapPaddedAlgorithms()
 	asymmetric := internals.SupportedAsymmetricAlgorithms()
-	supportedEncryptionAlgorithms = make([]string, len(symmetric)+len(asymmetric))
-	copy(supportedEncryptionAlgorithms[0:len(symmetric)], symmetric)
-	copy(supportedEncryptionAlgorithms[len(symmetric):], asymmetric)
+	supportedEncryptionAlgorithms = make([]string, 0, len(symmetric)+len(kwp)+len(asymmetric))
+	supportedEncryptionAlgorithms = append(supportedEncryptionAlgorithms, symmetric...)
+	supportedEncryptionAlgorithms = append(supportedEncryptionAlgorithms, kwp...)
+	supportedEncryptionAlgorithms = append(supportedEncryptionAlgorithms, asymmetric...)
 
 	supportedSignatureAlgorithms = internals.SupportedSignatureAlgorithms()
 }
+
+// encrypt performs the encryption using the shared primitives, adding support for algorithms that are implemented in this package.
+func encrypt(plaintext []byte, algorithm string, key jwk.Key, nonce []byte, associatedData []byte) (ciphertext []byte, tag []byte, err error) {
+	if IsKeyWrapPaddedAlgorithm(algorithm) {
+		ciphertext, err = EncryptAESKWP(plaintext, algorithm, key)
+		return ciphertext, nil, err
+	}
+	return internals.Encrypt(plaintext, algorithm, key, nonce, associatedData)
+}
+
+// decrypt performs the decryption using the shared primitives, adding support for algorithms that are implemented in this package.
+func decrypt(ciphertext []byte, algorithm string, key jwk.Key, nonce []byte, tag []byte, associatedData []byte) (plaintext []byte, err error) {
+	if IsKeyWrapPaddedAlgorithm(algorithm) {
+		return DecryptAESKWP(ciphertext, algorithm, key)
+	}
+	return internals.Decrypt(ciphertext, algorithm, key, nonce, tag, associatedData)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+// In-memory lock store.
+// Locks are only visible to the current process, so this component is meant for local development and testing.
+type InMemoryLock struct {
+	locks   map[string]*lockItem
+	lock    sync.Mutex
+	log     logger.Logger
+	clock   clock.Clock
+	closeCh chan struct{}
+	closed  atomic.Bool
+	wg      sync.WaitGroup
+}
+
+type lockItem struct {
+	owner  string
+	expire *time.Time
+}
+
+func (i *lockItem) isExpired(now time.Time) bool {
+	return i.expire != nil && !now.Before(*i.expire)
+}
+
+// NewInMemoryLock returns a new in-memory lock store.
+func NewInMemoryLock(log logger.Logger) lock.Store {
+	return newInMemoryLock(log)
+}
+
+func newInMemoryLock(log logger.Logger) *InMemoryLock {
+	return &InMemoryLock{
+		locks:   map[string]*lockItem{},
+		log:     log,
+		clock:   clock.RealClock{},
+		closeCh: make(chan struct{}),
+	}
+}
+
+// InitLockStore initializes the lock store.
+func (l *InMemoryLock) InitLockStore(ctx context.Context, metadata lock.Metadata) error {
+	// start a background go routine to clean expired locks
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		l.startCleanThread()
+	}()
+	return nil
+}
+
+// TryLock tries to acquire a lock.
+// If the lock cannot be acquired, it returns immediately.
+func (l *InMemoryLock) TryLock(ctx context.Context, req *lock.TryLockRequest) (*lock.TryLockResponse, error) {
+	if l.closed.Load() {
+		return &lock.TryLockResponse{}, errors.New("lock store is closed")
+	}
+	if req.ExpiryInSeconds < 0 {
+		return &lock.TryLockResponse{}, errors.New("expiryInSeconds must not be negative")
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	if item, ok := l.locks[req.ResourceID]; ok && !item.isExpired(now) {
+		return &lock.TryLockResponse{
+			Success: false,
+		}, nil
+	}
+
+	item := &lockItem{
+		owner: req.LockOwner,
+	}
+	if req.ExpiryInSeconds > 0 {
+		expire := now.Add(time.Duration(req.ExpiryInSeconds) * time.Second)
+		item.expire = &expire
+	}
+	l.locks[req.ResourceID] = item
+
+	return &lock.TryLockResponse{
+		Success: true,
+	}, nil
+}
+
+// Unlock tries to release a lock if the lock is still valid.
+func (l *InMemoryLock) Unlock(ctx context.Context, req *lock.UnlockRequest) (*lock.UnlockResponse, error) {
+	if l.closed.Load() {
+		return &lock.UnlockResponse{
+			Status: lock.InternalError,
+		}, errors.New("lock store is closed")
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	item, ok := l.locks[req.ResourceID]
+	if !ok || item.isExpired(l.clock.Now()) {
+		return &lock.UnlockResponse{
+			Status: lock.LockDoesNotExist,
+		}, nil
+	}
+	if item.owner != req.LockOwner {
+		return &lock.UnlockResponse{
+			Status: lock.LockBelongsToOthers,
+		}, nil
+	}
+
+	delete(l.locks, req.ResourceID)
+	return &lock.UnlockResponse{
+		Status: lock.Success,
+	}, nil
+}
+
+func (l *InMemoryLock) startCleanThread() {
+	for {
+		select {
+		case <-time.After(time.Second):
+			l.doCleanExpiredLocks()
+		case <-l.closeCh:
+			return
+		}
+	}
+}
+
+func (l *InMemoryLock) doCleanExpiredLocks() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	for resourceID, item := range l.locks {
+		if item.isExpired(now) {
+			delete(l.locks, resourceID)
+		}
+	}
+}
+
+// Close stops the background cleanup and releases all locks.
+func (l *InMemoryLock) Close() error {
+	if l.closed.CompareAndSwap(false, true) {
+		close(l.closeCh)
+	}
+
+	l.wg.Wait()
+
+	// release memory reference
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	clear(l.locks)
+
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (l *InMemoryLock) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	// no metadata, hence no metadata struct to convert here
+	return
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/kit/logger"
+)
+
+func TestInMemoryLock(t *testing.T) {
+	l := newInMemoryLock(logger.NewLogger("test"))
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	l.clock = fakeClock
+	require.NoError(t, l.InitLockStore(t.Context(), lock.Metadata{}))
+	defer l.Close()
+
+	const resourceID = "resource"
+
+	t.Run("acquire lock", func(t *testing.T) {
+		res, err := l.TryLock(t.Context(), &lock.TryLockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 10,
+		})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+	})
+
+	t.Run("lock is held by another owner", func(t *testing.T) {
+		res, err := l.TryLock(t.Context(), &lock.TryLockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       "owner2",
+			ExpiryInSeconds: 10,
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+	})
+
+	t.Run("unlock by another owner fails", func(t *testing.T) {
+		res, err := l.Unlock(t.Context(), &lock.UnlockRequest{
+			ResourceID: resourceID,
+			LockOwner:  "owner2",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockBelongsToOthers, res.Status)
+	})
+
+	t.Run("unlock by owner", func(t *testing.T) {
+		res, err := l.Unlock(t.Context(), &lock.UnlockRequest{
+			ResourceID: resourceID,
+			LockOwner:  "owner1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, lock.Success, res.Status)
+
+		res, err = l.Unlock(t.Context(), &lock.UnlockRequest{
+			ResourceID: resourceID,
+			LockOwner:  "owner1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, res.Status)
+	})
+
+	t.Run("lock expires", func(t *testing.T) {
+		res, err := l.TryLock(t.Context(), &lock.TryLockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 10,
+		})
+		require.NoError(t, err)
+		require.True(t, res.Success)
+
+		fakeClock.Step(10 * time.Second)
+
+		unlockRes, err := l.Unlock(t.Context(), &lock.UnlockRequest{
+			ResourceID: resourceID,
+			LockOwner:  "owner1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, unlockRes.Status)
+
+		res, err = l.TryLock(t.Context(), &lock.TryLockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       "owner2",
+			ExpiryInSeconds: 10,
+		})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+	})
+
+	t.Run("expired locks are cleaned up", func(t *testing.T) {
+		fakeClock.Step(10 * time.Second)
+		l.doCleanExpiredLocks()
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		assert.Empty(t, l.locks)
+	})
+
+	t.Run("negative expiry is rejected", func(t *testing.T) {
+		_, err := l.TryLock(t.Context(), &lock.TryLockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       "owner1",
+			ExpiryInSeconds: -1,
+		})
+		require.Error(t, err)
+	})
+}
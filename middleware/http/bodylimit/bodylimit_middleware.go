@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bodylimit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/dapr/components-contrib/common/httputils"
+	mdutils "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultMaxBodySize        = 4 << 20 // 4 MiB
+	defaultResponseStatusCode = http.StatusRequestEntityTooLarge
+	defaultResponseMessage    = "request body too large"
+)
+
+var errBodyTooLarge = errors.New("body too large")
+
+// Metadata is the bodylimit middleware config.
+type Metadata struct {
+	// Maximum size of the request body, as received on the wire.
+	MaxBodySize kitmd.ByteSize `json:"maxBodySize" mapstructure:"maxBodySize"`
+	// Maximum size of gzip-encoded request bodies after decompression.
+	// If unset, the value of MaxBodySize is used.
+	MaxDecompressedBodySize kitmd.ByteSize `json:"maxDecompressedBodySize" mapstructure:"maxDecompressedBodySize"`
+	// If true, gzip-encoded bodies are forwarded decompressed and the Content-Encoding header is removed.
+	DecompressBody bool `json:"decompressBody" mapstructure:"decompressBody"`
+	// Status code and message sent when a request is rejected.
+	ResponseStatusCode int    `json:"responseStatusCode" mapstructure:"responseStatusCode"`
+	ResponseMessage    string `json:"responseMessage" mapstructure:"responseMessage"`
+
+	maxBodySizeBytes             int64
+	maxDecompressedBodySizeBytes int64
+}
+
+// NewMiddleware returns a new bodylimit middleware.
+func NewMiddleware(logger logger.Logger) middleware.Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is a middleware that rejects requests whose body, before or after decompression, exceeds a limit.
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler returns the HTTP handler provided by the middleware.
+func (m *Middleware) GetHandler(_ context.Context, metadata middleware.Metadata) (func(next http.Handler) http.Handler, error) {
+	meta, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	reject := func(w http.ResponseWriter) {
+		httputils.RespondWithErrorAndMessage(w, meta.ResponseStatusCode, meta.ResponseMessage)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Fail fast when the declared length is already over the limit
+			if r.ContentLength > meta.maxBodySizeBytes {
+				reject(w)
+				return
+			}
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The body is buffered so the request can be rejected before anything is forwarded, even when the length isn't declared
+			body, err := io.ReadAll(io.LimitReader(r.Body, meta.maxBodySizeBytes+1))
+			r.Body.Close()
+			if err != nil {
+				m.logger.Debugf("Failed to read request body: %v", err)
+				httputils.RespondWithErrorAndMessage(w, http.StatusBadRequest, "failed to read request body")
+				return
+			}
+			if int64(len(body)) > meta.maxBodySizeBytes {
+				reject(w)
+				return
+			}
+
+			if isGzip(r.Header.Get("Content-Encoding")) {
+				decompressed, err := decompress(body, meta.maxDecompressedBodySizeBytes, meta.DecompressBody)
+				switch {
+				case errors.Is(err, errBodyTooLarge):
+					reject(w)
+					return
+				case err != nil:
+					m.logger.Debugf("Failed to decompress request body: %v", err)
+					httputils.RespondWithErrorAndMessage(w, http.StatusBadRequest, "invalid gzip-encoded request body")
+					return
+				}
+
+				if meta.DecompressBody {
+					body = decompressed
+					r.Header.Del("Content-Encoding")
+				}
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// decompress reads the gzip-encoded data and returns errBodyTooLarge if it decompresses to more than maxSize bytes.
+// The decompressed data is returned only if keep is true.
+func decompress(data []byte, maxSize int64, keep bool) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	limited := io.LimitReader(gz, maxSize+1)
+	var (
+		out []byte
+		n   int64
+	)
+	if keep {
+		out, err = io.ReadAll(limited)
+		n = int64(len(out))
+	} else {
+		n, err = io.Copy(io.Discard, limited)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n > maxSize {
+		return nil, errBodyTooLarge
+	}
+	return out, nil
+}
+
+func isGzip(contentEncoding string) bool {
+	contentEncoding = strings.ToLower(strings.TrimSpace(contentEncoding))
+	return contentEncoding == "gzip" || contentEncoding == "x-gzip"
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*Metadata, error) {
+	middlewareMetadata := Metadata{
+		MaxBodySize:        kitmd.NewByteSize(defaultMaxBodySize),
+		ResponseStatusCode: defaultResponseStatusCode,
+		ResponseMessage:    defaultResponseMessage,
+	}
+	err := kitmd.DecodeMetadata(metadata.Properties, &middlewareMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	middlewareMetadata.maxBodySizeBytes, err = middlewareMetadata.MaxBodySize.GetBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for maxBodySize: %w", err)
+	}
+	if middlewareMetadata.maxBodySizeBytes <= 0 {
+		return nil, errors.New("metadata property maxBodySize must be a positive value")
+	}
+
+	middlewareMetadata.maxDecompressedBodySizeBytes, err = middlewareMetadata.MaxDecompressedBodySize.GetBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for maxDecompressedBodySize: %w", err)
+	}
+	if middlewareMetadata.maxDecompressedBodySizeBytes <= 0 {
+		middlewareMetadata.maxDecompressedBodySizeBytes = middlewareMetadata.maxBodySizeBytes
+	}
+
+	if middlewareMetadata.ResponseStatusCode < 400 || middlewareMetadata.ResponseStatusCode > 599 {
+		return nil, fmt.Errorf("metadata property responseStatusCode must be an error status code, got %d", middlewareMetadata.ResponseStatusCode)
+	}
+
+	return &middlewareMetadata, nil
+}
+
+func (m *Middleware) GetComponentMetadata() (metadataInfo mdutils.MetadataMap) {
+	metadataStruct := Metadata{}
+	mdutils.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, mdutils.MiddlewareType)
+	return
+}
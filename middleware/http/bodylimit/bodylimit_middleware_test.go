@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bodylimit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/kit/logger"
+)
+
+// echoHandler responds with the request body and its Content-Encoding header.
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("X-Content-Encoding", r.Header.Get("Content-Encoding"))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestBodyLimit(t *testing.T) {
+	newHandler := func(t *testing.T, props map[string]string) http.Handler {
+		m := NewMiddleware(logger.NewLogger("bodylimit.test"))
+		handler, err := m.GetHandler(t.Context(), middleware.Metadata{Base: metadata.Base{Properties: props}})
+		require.NoError(t, err)
+		return handler(http.HandlerFunc(echoHandler))
+	}
+
+	serve := func(h http.Handler, body []byte, encoding string) *http.Response {
+		r := httptest.NewRequest(http.MethodPost, "http://localhost:5001/v1.0/invoke/app/method/test", bytes.NewReader(body))
+		if encoding != "" {
+			r.Header.Set("Content-Encoding", encoding)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	t.Run("body within limit is forwarded", func(t *testing.T) {
+		h := newHandler(t, map[string]string{"maxBodySize": "10"})
+		res := serve(h, []byte("0123456789"), "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, "0123456789", string(body))
+	})
+
+	t.Run("body over limit is rejected", func(t *testing.T) {
+		h := newHandler(t, map[string]string{"maxBodySize": "10"})
+		res := serve(h, []byte("0123456789a"), "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, defaultResponseMessage, string(body))
+	})
+
+	t.Run("body without content length over limit is rejected", func(t *testing.T) {
+		h := newHandler(t, map[string]string{"maxBodySize": "10"})
+		r := httptest.NewRequest(http.MethodPost, "http://localhost:5001/", io.NopCloser(strings.NewReader("0123456789a")))
+		r.ContentLength = -1
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("custom response", func(t *testing.T) {
+		h := newHandler(t, map[string]string{
+			"maxBodySize":        "10",
+			"responseStatusCode": "400",
+			"responseMessage":    "nope",
+		})
+		res := serve(h, []byte("0123456789a"), "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, "nope", string(body))
+	})
+
+	t.Run("decompression bomb is rejected", func(t *testing.T) {
+		compressed := gzipData(t, bytes.Repeat([]byte{'a'}, 1<<20))
+		h := newHandler(t, map[string]string{
+			"maxBodySize":             "64Ki",
+			"maxDecompressedBodySize": "128Ki",
+		})
+		res := serve(h, compressed, "gzip")
+		defer res.Body.Close()
+		assert.Less(t, len(compressed), 64<<10)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+
+	t.Run("gzip body within limit is forwarded compressed", func(t *testing.T) {
+		compressed := gzipData(t, []byte("hello world"))
+		h := newHandler(t, map[string]string{"maxBodySize": "1Ki"})
+		res := serve(h, compressed, "gzip")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "gzip", res.Header.Get("X-Content-Encoding"))
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, compressed, body)
+	})
+
+	t.Run("gzip body is forwarded decompressed", func(t *testing.T) {
+		h := newHandler(t, map[string]string{
+			"maxBodySize":    "1Ki",
+			"decompressBody": "true",
+		})
+		res := serve(h, gzipData(t, []byte("hello world")), "gzip")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, res.Header.Get("X-Content-Encoding"))
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, "hello world", string(body))
+	})
+
+	t.Run("invalid gzip body is rejected", func(t *testing.T) {
+		h := newHandler(t, map[string]string{"maxBodySize": "1Ki"})
+		res := serve(h, []byte("not gzip"), "gzip")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := &Middleware{}
+
+	t.Run("defaults", func(t *testing.T) {
+		meta, err := m.getNativeMetadata(middleware.Metadata{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(defaultMaxBodySize), meta.maxBodySizeBytes)
+		assert.Equal(t, int64(defaultMaxBodySize), meta.maxDecompressedBodySizeBytes)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, meta.ResponseStatusCode)
+	})
+
+	t.Run("invalid status code", func(t *testing.T) {
+		_, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"responseStatusCode": "200",
+		}}})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid max body size", func(t *testing.T) {
+		_, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"maxBodySize": "0",
+		}}})
+		require.Error(t, err)
+	})
+}
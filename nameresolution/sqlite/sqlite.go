@@ -121,14 +121,15 @@ func (s *resolver) initGC() (err error) {
 				VALUES ('nr-last-cleanup', CURRENT_TIMESTAMP)
 				ON CONFLICT (key)
 				DO UPDATE SET value = CURRENT_TIMESTAMP
-					WHERE unixepoch(CURRENT_TIMESTAMP) - unixepoch(value)  > ?;`,
+					WHERE (unixepoch(CURRENT_TIMESTAMP) - unixepoch(value)) * 1000 > ?;`,
 				s.metadata.MetadataTableName,
 			), arg
 		},
+		// Removes the registrations that haven't been renewed within the TTL, such as those of crashed hosts
 		DeleteExpiredValuesQuery: fmt.Sprintf(
-			`DELETE FROM %s WHERE unixepoch(CURRENT_TIMESTAMP) - last_update < %d`,
+			`DELETE FROM %s WHERE unixepoch(CURRENT_TIMESTAMP) - last_update >= %d`,
 			s.metadata.TableName,
-			int(s.metadata.UpdateInterval.Seconds()),
+			int(s.metadata.TTL.Seconds()),
 		),
 		CleanupInterval: s.metadata.CleanupInterval,
 		DB:              commonsql.AdaptDatabaseSQLConn(s.db),
@@ -216,6 +217,9 @@ func (s *resolver) doRenewRegistration(ctx context.Context, addr string) error {
 	// We use string formatting here for the table name only
 	//nolint:gosec
 	query := fmt.Sprintf("UPDATE %s SET last_update = unixepoch(CURRENT_TIMESTAMP) WHERE registration_id = ? AND address = ?", s.metadata.TableName)
+	// If our registration expired (for example because the process was suspended for longer than the TTL) and was removed by the garbage collector, we add it back, unless another host has registered with the same address
+	//nolint:gosec
+	reinsertQuery := fmt.Sprintf("INSERT INTO %s (registration_id, address, app_id, namespace, last_update) VALUES (?, ?, ?, ?, unixepoch(CURRENT_TIMESTAMP)) ON CONFLICT DO NOTHING", s.metadata.TableName)
 
 	b := backoff.WithContext(backoff.NewConstantBackOff(50*time.Millisecond), queryCtx)
 	return backoff.Retry(func() error {
@@ -225,11 +229,21 @@ func (s *resolver) doRenewRegistration(ctx context.Context, addr string) error {
 		}
 
 		n, _ := res.RowsAffected()
+		if n > 0 {
+			return nil
+		}
+
+		res, err = s.db.ExecContext(queryCtx, reinsertQuery, s.registrationID, addr, s.metadata.appID, "")
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		n, _ = res.RowsAffected()
 		if n == 0 {
 			// This is a permanent error
 			return backoff.Permanent(errRegistrationLost)
 		}
 
+		s.logger.Warn("Host registration had expired and was restored")
 		return nil
 	}, b)
 }
@@ -255,7 +269,7 @@ func (s *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequ
 				LIMIT 1
 			)`,
 		s.metadata.TableName,
-		int(s.metadata.UpdateInterval.Seconds()),
+		int(s.metadata.TTL.Seconds()),
 	)
 
 	err = s.db.QueryRowContext(queryCtx, q, req.ID).Scan(&addr)
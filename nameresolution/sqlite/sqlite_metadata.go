@@ -43,6 +43,7 @@ type sqliteMetadata struct {
 	TableName         string        `mapstructure:"tableName"`
 	MetadataTableName string        `mapstructure:"metadataTableName"`
 	UpdateInterval    time.Duration `mapstructure:"updateInterval"` // Units smaller than seconds are not accepted
+	TTL               time.Duration `mapstructure:"ttl"`            // Units smaller than seconds are not accepted
 	CleanupInterval   time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
 
 	// Instance properties - these are passed by the runtime
@@ -98,6 +99,20 @@ func (m *sqliteMetadata) InitWithMetadata(meta nameresolution.Metadata) error {
 		return errors.New("update interval must be at least 1s greater than timeout")
 	}
 
+	// If not set, registrations expire if they're not renewed within two update intervals
+	// Hosts renew their registration up to one update interval after the last renewal, and timestamps are stored with
+	// a resolution of one second, so the TTL must be greater than the update interval, or the garbage collector could
+	// remove the registrations of hosts that are still renewing them
+	if m.TTL == 0 {
+		m.TTL = 2 * m.UpdateInterval
+	}
+	if m.TTL != m.TTL.Truncate(time.Second) {
+		return errors.New("TTL must not contain fractions of seconds")
+	}
+	if m.TTL <= m.UpdateInterval {
+		return errors.New("TTL must be greater than the update interval")
+	}
+
 	return nil
 }
 
@@ -116,6 +131,7 @@ func (m *sqliteMetadata) reset() {
 	m.TableName = defaultTableName
 	m.MetadataTableName = defaultMetadataTableName
 	m.UpdateInterval = defaultUpdateInterval
+	m.TTL = 0
 	m.CleanupInterval = defaultCleanupInternal
 
 	m.appID = ""
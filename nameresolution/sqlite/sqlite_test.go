@@ -46,16 +46,16 @@ func TestSqliteNameResolver(t *testing.T) {
 	require.False(t, t.Failed(), "Cannot continue if init step failed")
 
 	t.Run("Populate test data", func(t *testing.T) {
-		// Note updateInterval is 120s
+		// Note updateInterval is 120s, so the TTL is 240s
 		now := time.Now().Unix()
 		rows := [][]any{
 			{"2cb5f837", "1.1.1.1:1", "app-1", "", now},
 			{"4d1e7b11", "1.1.1.1:2", "app-1", "", now},
 			{"05add1fa", "1.1.1.1:3", "app-1", "", now},
 			{"f1b24d4b", "2.2.2.2:1", "app-2", "", now},
-			{"23fb164f", "2.2.2.2:2", "app-2", "", now - 200},
+			{"23fb164f", "2.2.2.2:2", "app-2", "", now - 300},
 			{"db50a29e", "3.3.3.3:1", "app-3", "", now},
-			{"eef793d4", "4.4.4.4:1", "app-4", "", now - 200},
+			{"eef793d4", "4.4.4.4:1", "app-4", "", now - 300},
 			{"ef06eb49", "5.5.5.5:1", "app-5", "", now},
 			{"b0e6cd89", "6.6.6.6:1", "app-6", "", now},
 			{"36e99c68", "7.7.7.7:1", "app-7", "", now},
@@ -137,6 +137,21 @@ func TestSqliteNameResolver(t *testing.T) {
 			require.Greater(t, newLastUpdate, lastUpdate)
 		})
 
+		t.Run("Expired registration is restored", func(t *testing.T) {
+			const addr = "127.0.0.1:1234"
+
+			_, err := nr.db.Exec("DELETE FROM hosts WHERE address = ?", addr)
+			require.NoError(t, err)
+
+			err = nr.doRenewRegistration(t.Context(), addr)
+			require.NoError(t, err)
+
+			var registrationID string
+			err = nr.db.QueryRow("SELECT registration_id FROM hosts WHERE address = ?", addr).Scan(&registrationID)
+			require.NoError(t, err)
+			require.Equal(t, nr.registrationID, registrationID)
+		})
+
 		t.Run("Lost registration", func(t *testing.T) {
 			// Renew
 			err := nr.doRenewRegistration(t.Context(), "fail")
@@ -150,3 +165,136 @@ func TestSqliteNameResolver(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSqliteNameResolverGarbageCollection(t *testing.T) {
+	nr := NewResolver(logger.NewLogger("test")).(*resolver)
+
+	err := nr.Init(t.Context(), nameresolution.Metadata{
+		Instance: nameresolution.Instance{
+			Address:          "127.0.0.1",
+			DaprInternalPort: 1234,
+			AppID:            "myapp",
+		},
+		Configuration: map[string]string{
+			"connectionString": ":memory:",
+			"cleanupInterval":  "1h",
+			"updateInterval":   "60s",
+			"ttl":              "180s",
+		},
+	})
+	require.NoError(t, err)
+	defer nr.Close()
+
+	now := time.Now().Unix()
+	rows := [][]any{
+		{"2cb5f837", "1.1.1.1:1", "app-1", "", now},
+		{"4d1e7b11", "1.1.1.1:2", "app-1", "", now - 100}, // Missed a heartbeat but within the TTL
+		{"05add1fa", "1.1.1.1:3", "app-1", "", now - 200}, // Expired
+	}
+	for i, r := range rows {
+		_, err = nr.db.Exec("INSERT INTO hosts VALUES (?, ?, ?, ?, ?)", r...)
+		require.NoErrorf(t, err, "Failed to insert row %d", i)
+	}
+
+	for i := range 20 {
+		res, err := nr.ResolveID(t.Context(), nameresolution.ResolveRequest{ID: "app-1"})
+		require.NoErrorf(t, err, "Error on iteration %d", i)
+		require.Contains(t, []string{"1.1.1.1:1", "1.1.1.1:2"}, res)
+	}
+
+	err = nr.gc.CleanupExpired()
+	require.NoError(t, err)
+
+	var addresses []string
+	res, err := nr.db.Query("SELECT address FROM hosts ORDER BY address")
+	require.NoError(t, err)
+	defer res.Close()
+	for res.Next() {
+		var addr string
+		require.NoError(t, res.Scan(&addr))
+		addresses = append(addresses, addr)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []string{"1.1.1.1:1", "1.1.1.1:2", "127.0.0.1:1234"}, addresses)
+}
+
+func TestSqliteNameResolverRenewingHostNotExpired(t *testing.T) {
+	nr := NewResolver(logger.NewLogger("test")).(*resolver)
+
+	err := nr.Init(t.Context(), nameresolution.Metadata{
+		Instance: nameresolution.Instance{
+			Address:          "127.0.0.1",
+			DaprInternalPort: 1234,
+			AppID:            "myapp",
+		},
+		Configuration: map[string]string{
+			"connectionString": ":memory:",
+			"cleanupInterval":  "1h",
+			"updateInterval":   "120s",
+		},
+	})
+	require.NoError(t, err)
+	defer nr.Close()
+
+	// A host renews its registration one update interval after the last renewal at the latest (the ticker fires before
+	// that, but the renewal can take up to the timeout), so this is the oldest its registration can be
+	_, err = nr.db.Exec("UPDATE hosts SET last_update = unixepoch(CURRENT_TIMESTAMP) - 120 WHERE registration_id = ?", nr.registrationID)
+	require.NoError(t, err)
+
+	err = nr.gc.CleanupExpired()
+	require.NoError(t, err)
+
+	var n int
+	err = nr.db.QueryRow("SELECT COUNT(*) FROM hosts WHERE registration_id = ?", nr.registrationID).Scan(&n)
+	require.NoError(t, err)
+	require.Equal(t, 1, n, "registration was removed while the host was renewing it")
+
+	res, err := nr.ResolveID(t.Context(), nameresolution.ResolveRequest{ID: "myapp"})
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:1234", res)
+}
+
+func TestSqliteMetadataTTL(t *testing.T) {
+	instance := nameresolution.Instance{
+		Address:          "127.0.0.1",
+		DaprInternalPort: 1234,
+		AppID:            "myapp",
+	}
+
+	t.Run("Defaults to twice the update interval", func(t *testing.T) {
+		var m sqliteMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      instance,
+			Configuration: map[string]string{"connectionString": ":memory:", "updateInterval": "10s"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 20*time.Second, m.TTL)
+	})
+
+	t.Run("Smaller than update interval", func(t *testing.T) {
+		var m sqliteMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      instance,
+			Configuration: map[string]string{"connectionString": ":memory:", "updateInterval": "10s", "ttl": "5s"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Equal to update interval", func(t *testing.T) {
+		var m sqliteMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      instance,
+			Configuration: map[string]string{"connectionString": ":memory:", "updateInterval": "10s", "ttl": "10s"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Fractions of seconds", func(t *testing.T) {
+		var m sqliteMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      instance,
+			Configuration: map[string]string{"connectionString": ":memory:", "ttl": "10.5s"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,467 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/retry"
+)
+
+const (
+	// publish metadata overriding the source and detail type of an event.
+	sourceKey     = "source"
+	detailTypeKey = "detailType"
+	// subscribe metadata with the event pattern of the rule, as JSON. Defaults to the events whose detail type is the topic.
+	eventPatternKey = "eventPattern"
+
+	// id of the SQS target of the rules.
+	sqsTargetID = "dapr-sqs"
+	// rule names are limited to 64 characters, queue names to 80.
+	maxNameLength = 64
+)
+
+// eventBridge publishes events to an EventBridge event bus, and subscribes to them with a rule per topic whose target is
+// an SQS queue.
+type eventBridge struct {
+	metadata      *eventBridgeMetadata
+	authProvider  awsAuth.Provider
+	dedup         *pubsub.Deduplicator
+	logger        logger.Logger
+	opsTimeout    time.Duration
+	backOffConfig retry.Config
+
+	closed  atomic.Bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+type sqsQueueInfo struct {
+	arn string
+	url string
+}
+
+// event is the envelope of the EventBridge events delivered to SQS queues.
+type event struct {
+	ID         string          `json:"id"`
+	DetailType string          `json:"detail-type"`
+	Source     string          `json:"source"`
+	Detail     json.RawMessage `json:"detail"`
+}
+
+// NewEventBridge returns a new AWS EventBridge pub/sub component.
+func NewEventBridge(l logger.Logger) pubsub.PubSub {
+	return &eventBridge{
+		logger:  l,
+		closeCh: make(chan struct{}),
+	}
+}
+
+func (e *eventBridge) Init(ctx context.Context, meta pubsub.Metadata) error {
+	m, err := parseMetadata(meta)
+	if err != nil {
+		return err
+	}
+	e.metadata = m
+	e.dedup, err = deduplication.New(ctx, meta.Properties, e.logger)
+	if err != nil {
+		return err
+	}
+
+	if e.authProvider == nil {
+		opts := awsAuth.Options{
+			Logger:       e.logger,
+			Properties:   meta.Properties,
+			Region:       m.Region,
+			Endpoint:     m.Endpoint,
+			AccessKey:    m.AccessKey,
+			SecretKey:    m.SecretKey,
+			SessionToken: m.SessionToken,
+		}
+		var provider awsAuth.Provider
+		provider, err = awsAuth.NewProvider(ctx, opts, awsAuth.GetConfig(opts))
+		if err != nil {
+			return err
+		}
+		e.authProvider = provider
+	}
+
+	e.opsTimeout = time.Duration(m.AssetsManagementTimeoutSeconds * float64(time.Second))
+
+	// Default retry configuration is used if no backOff properties are set.
+	e.backOffConfig = retry.DefaultConfig()
+	err = retry.DecodeConfigWithPrefix(&e.backOffConfig, meta.Properties, "backOff")
+	if err != nil {
+		return fmt.Errorf("error decoding backOff config: %w", err)
+	}
+
+	return nil
+}
+
+func (e *eventBridge) Features() []pubsub.Feature {
+	return nil
+}
+
+// Publish puts the message on the event bus, as the detail of an event.
+func (e *eventBridge) Publish(ctx context.Context, req *pubsub.PublishRequest) error {
+	if e.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	entry, err := e.newEventEntry(req)
+	if err != nil {
+		return err
+	}
+
+	res, err := e.authProvider.EventBridge().EventBridge.PutEventsWithContext(ctx, &eventbridge.PutEventsInput{
+		Entries: []*eventbridge.PutEventsRequestEntry{entry},
+	})
+	if err != nil {
+		return fmt.Errorf("error publishing to topic %s on event bus %s: %w", req.Topic, e.metadata.EventBusName, err)
+	}
+	if aws.Int64Value(res.FailedEntryCount) > 0 && len(res.Entries) > 0 {
+		return fmt.Errorf("error publishing to topic %s on event bus %s: %s: %s", req.Topic, e.metadata.EventBusName, aws.StringValue(res.Entries[0].ErrorCode), aws.StringValue(res.Entries[0].ErrorMessage))
+	}
+
+	return nil
+}
+
+// newEventEntry returns the event of the message. The detail of events must be a JSON object, such as a cloud event.
+func (e *eventBridge) newEventEntry(req *pubsub.PublishRequest) (*eventbridge.PutEventsRequestEntry, error) {
+	var detail map[string]json.RawMessage
+	if err := json.Unmarshal(req.Data, &detail); err != nil {
+		return nil, fmt.Errorf("the data published to EventBridge must be a JSON object: %w", err)
+	}
+
+	source := e.metadata.Source
+	if val := req.Metadata[sourceKey]; val != "" {
+		source = val
+	}
+	detailType := req.Topic
+	if e.metadata.DetailType != "" {
+		detailType = e.metadata.DetailType
+	}
+	if val := req.Metadata[detailTypeKey]; val != "" {
+		detailType = val
+	}
+
+	return &eventbridge.PutEventsRequestEntry{
+		EventBusName: aws.String(e.metadata.EventBusName),
+		Source:       aws.String(source),
+		DetailType:   aws.String(detailType),
+		Detail:       aws.String(string(req.Data)),
+	}, nil
+}
+
+// Subscribe creates a rule matching the events of the topic on the event bus, with an SQS queue as its target, and
+// consumes the queue until the subscription is canceled.
+func (e *eventBridge) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	if e.closed.Load() {
+		return errors.New("component is closed")
+	}
+	if req.Topic == "" {
+		return errors.New("topic is required")
+	}
+	handler = e.dedup.Handler(handler)
+
+	pattern, err := e.eventPattern(req)
+	if err != nil {
+		return err
+	}
+
+	name := resourceName(e.metadata.ConsumerID, req.Topic)
+	queueInfo, err := e.getOrCreateQueue(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error retrieving SQS queue %s for topic %s: %w", name, req.Topic, err)
+	}
+
+	if !e.metadata.DisableEntityManagement {
+		err = e.putRule(ctx, name, pattern, queueInfo)
+		if err != nil {
+			return fmt.Errorf("error creating rule %s for topic %s: %w", name, req.Topic, err)
+		}
+	}
+
+	consumeCtx, cancel := context.WithCancel(ctx)
+	e.wg.Add(2)
+	go func() {
+		defer e.wg.Done()
+		defer cancel()
+		select {
+		case <-consumeCtx.Done():
+		case <-e.closeCh:
+		}
+	}()
+	go func() {
+		defer e.wg.Done()
+		e.logger.Infof("Starting consumption of queue %s for topic %s", queueInfo.arn, req.Topic)
+		e.consumeQueue(consumeCtx, queueInfo, req.Topic, handler)
+	}()
+
+	return nil
+}
+
+// eventPattern returns the event pattern of the rule of the subscription.
+func (e *eventBridge) eventPattern(req pubsub.SubscribeRequest) (string, error) {
+	if val := strings.TrimSpace(req.Metadata[eventPatternKey]); val != "" {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(val), &obj); err != nil {
+			return "", fmt.Errorf("%s must be a JSON object: %w", eventPatternKey, err)
+		}
+		return val, nil
+	}
+
+	detailType := req.Topic
+	if e.metadata.DetailType != "" {
+		detailType = e.metadata.DetailType
+	}
+	b, err := json.Marshal(map[string][]string{"detail-type": {detailType}})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (e *eventBridge) getOrCreateQueue(parentCtx context.Context, name string) (*sqsQueueInfo, error) {
+	client := e.authProvider.EventBridge().Sqs
+
+	var url *string
+	ctx, cancel := context.WithTimeout(parentCtx, e.opsTimeout)
+	if e.metadata.DisableEntityManagement {
+		res, err := client.GetQueueUrlWithContext(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		url = res.QueueUrl
+	} else {
+		// creating queues is idempotent
+		res, err := client.CreateQueueWithContext(ctx, &sqs.CreateQueueInput{QueueName: aws.String(name)})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		url = res.QueueUrl
+	}
+
+	ctx, cancel = context.WithTimeout(parentCtx, e.opsTimeout)
+	attrs, err := client.GetQueueAttributesWithContext(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       url,
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameQueueArn)},
+	})
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqsQueueInfo{arn: aws.StringValue(attrs.Attributes[sqs.QueueAttributeNameQueueArn]), url: aws.StringValue(url)}, nil
+}
+
+// putRule creates or updates the rule, allows it to send events to the queue, and sets the queue as its target.
+func (e *eventBridge) putRule(parentCtx context.Context, name string, pattern string, queueInfo *sqsQueueInfo) error {
+	clients := e.authProvider.EventBridge()
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.opsTimeout)
+	rule, err := clients.EventBridge.PutRuleWithContext(ctx, &eventbridge.PutRuleInput{
+		Name:         aws.String(name),
+		EventBusName: aws.String(e.metadata.EventBusName),
+		EventPattern: aws.String(pattern),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+		Description:  aws.String("Managed by Dapr"),
+	})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	policy, err := queuePolicy(queueInfo.arn, aws.StringValue(rule.RuleArn))
+	if err != nil {
+		return err
+	}
+	ctx, cancel = context.WithTimeout(parentCtx, e.opsTimeout)
+	_, err = clients.Sqs.SetQueueAttributesWithContext(ctx, &sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(queueInfo.url),
+		Attributes: map[string]*string{sqs.QueueAttributeNamePolicy: aws.String(policy)},
+	})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("error setting the policy of queue %s: %w", queueInfo.arn, err)
+	}
+
+	ctx, cancel = context.WithTimeout(parentCtx, e.opsTimeout)
+	targets, err := clients.EventBridge.PutTargetsWithContext(ctx, &eventbridge.PutTargetsInput{
+		Rule:         aws.String(name),
+		EventBusName: aws.String(e.metadata.EventBusName),
+		Targets: []*eventbridge.Target{{
+			Id:  aws.String(sqsTargetID),
+			Arn: aws.String(queueInfo.arn),
+		}},
+	})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("error setting the target of the rule: %w", err)
+	}
+	if aws.Int64Value(targets.FailedEntryCount) > 0 && len(targets.FailedEntries) > 0 {
+		return fmt.Errorf("error setting the target of the rule: %s: %s", aws.StringValue(targets.FailedEntries[0].ErrorCode), aws.StringValue(targets.FailedEntries[0].ErrorMessage))
+	}
+
+	return nil
+}
+
+// queuePolicy returns the policy of the queue that allows the rule to send events to it.
+func queuePolicy(queueArn string, ruleArn string) (string, error) {
+	policy := map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []map[string]any{{
+			"Effect":    "Allow",
+			"Principal": map[string]string{"Service": "events.amazonaws.com"},
+			"Action":    "sqs:SendMessage",
+			"Resource":  queueArn,
+			"Condition": map[string]any{
+				"ArnEquals": map[string]string{"aws:SourceArn": ruleArn},
+			},
+		}},
+	}
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// consumeQueue receives the events sent to the queue and passes their detail to the handler, until the context is canceled.
+// Events are deleted from the queue once handled, and received again after the visibility timeout otherwise.
+func (e *eventBridge) consumeQueue(ctx context.Context, queueInfo *sqsQueueInfo, topic string, handler pubsub.Handler) {
+	client := e.authProvider.EventBridge().Sqs
+	b := e.backOffConfig.NewBackOffWithContext(ctx)
+
+	for ctx.Err() == nil {
+		res, err := client.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueInfo.url),
+			MaxNumberOfMessages: aws.Int64(e.metadata.MessageMaxNumber),
+			VisibilityTimeout:   aws.Int64(e.metadata.MessageVisibilityTimeout),
+			WaitTimeSeconds:     aws.Int64(e.metadata.MessageWaitTimeSeconds),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			e.logger.Errorf("Error receiving messages from queue %s: %v. Retrying...", queueInfo.arn, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(b.NextBackOff()):
+			}
+			continue
+		}
+		b.Reset()
+
+		for _, message := range res.Messages {
+			err = e.handleMessage(ctx, message, topic, handler)
+			if err != nil {
+				e.logger.Errorf("Error handling message %s of topic %s: %v", aws.StringValue(message.MessageId), topic, err)
+				continue
+			}
+
+			deleteCtx, deleteCancel := context.WithTimeout(context.Background(), e.opsTimeout)
+			_, err = client.DeleteMessageWithContext(deleteCtx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueInfo.url),
+				ReceiptHandle: message.ReceiptHandle,
+			})
+			deleteCancel()
+			if err != nil {
+				e.logger.Errorf("Error deleting message %s from queue %s: %v", aws.StringValue(message.MessageId), queueInfo.arn, err)
+			}
+		}
+	}
+}
+
+func (e *eventBridge) handleMessage(ctx context.Context, message *sqs.Message, topic string, handler pubsub.Handler) error {
+	msg, err := newMessage(message, topic)
+	if err != nil {
+		return err
+	}
+	return handler(ctx, msg)
+}
+
+// newMessage returns the message with the detail of the event sent to the queue.
+func newMessage(message *sqs.Message, topic string) (*pubsub.NewMessage, error) {
+	var ev event
+	err := json.Unmarshal([]byte(aws.StringValue(message.Body)), &ev)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling event: %w", err)
+	}
+
+	return &pubsub.NewMessage{
+		Data:  ev.Detail,
+		Topic: topic,
+		Metadata: map[string]string{
+			"id":          ev.ID,
+			sourceKey:     ev.Source,
+			detailTypeKey: ev.DetailType,
+		},
+	}, nil
+}
+
+// resourceName returns the name of the rule and queue of a subscription, using only the characters allowed by both.
+func resourceName(consumerID string, topic string) string {
+	name := []rune(consumerID + "-" + topic)
+	for i, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			name[i] = '_'
+		}
+	}
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return string(name)
+}
+
+// Close stops the consumption of the queues and releases the resources used by the component.
+func (e *eventBridge) Close() error {
+	if e.closed.CompareAndSwap(false, true) {
+		close(e.closeCh)
+		e.wg.Wait()
+	}
+
+	err := e.dedup.Close()
+	if e.authProvider != nil {
+		return errors.Join(e.authProvider.Close(), err)
+	}
+	return err
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (e *eventBridge) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := eventBridgeMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.PubSubType)
+	return
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbridge
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		md, err := parseMetadata(pubsub.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"consumerID": "orders-app",
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, "default", md.EventBusName)
+		assert.Equal(t, "orders-app", md.Source)
+		assert.Empty(t, md.DetailType)
+		assert.Equal(t, int64(10), md.MessageMaxNumber)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{},
+			{"consumerID": "a", "source": "aws.s3"},
+			{"consumerID": "a", "messageMaxNumber": "11"},
+			{"consumerID": "a", "messageVisibilityTimeout": "0"},
+		} {
+			_, err := parseMetadata(pubsub.Metadata{Base: metadata.Base{Properties: props}})
+			require.Error(t, err, props)
+		}
+	})
+}
+
+func TestNewEventEntry(t *testing.T) {
+	e := &eventBridge{metadata: &eventBridgeMetadata{EventBusName: "bus", Source: "orders-app"}}
+
+	entry, err := e.newEventEntry(&pubsub.PublishRequest{Topic: "OrderPlaced", Data: []byte(`{"id": 1}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "bus", *entry.EventBusName)
+	assert.Equal(t, "orders-app", *entry.Source)
+	assert.Equal(t, "OrderPlaced", *entry.DetailType)
+	assert.JSONEq(t, `{"id": 1}`, *entry.Detail)
+
+	entry, err = e.newEventEntry(&pubsub.PublishRequest{
+		Topic:    "OrderPlaced",
+		Data:     []byte(`{"id": 1}`),
+		Metadata: map[string]string{"source": "com.example", "detailType": "Order"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "com.example", *entry.Source)
+	assert.Equal(t, "Order", *entry.DetailType)
+
+	_, err = e.newEventEntry(&pubsub.PublishRequest{Topic: "OrderPlaced", Data: []byte(`"text"`)})
+	require.Error(t, err)
+}
+
+func TestEventPattern(t *testing.T) {
+	e := &eventBridge{metadata: &eventBridgeMetadata{}}
+
+	pattern, err := e.eventPattern(pubsub.SubscribeRequest{Topic: "OrderPlaced"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"detail-type": ["OrderPlaced"]}`, pattern)
+
+	pattern, err = e.eventPattern(pubsub.SubscribeRequest{
+		Topic:    "OrderPlaced",
+		Metadata: map[string]string{"eventPattern": `{"source": ["aws.s3"]}`},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"source": ["aws.s3"]}`, pattern)
+
+	_, err = e.eventPattern(pubsub.SubscribeRequest{Topic: "OrderPlaced", Metadata: map[string]string{"eventPattern": `[]`}})
+	require.Error(t, err)
+}
+
+func TestNewMessage(t *testing.T) {
+	msg, err := newMessage(&sqs.Message{
+		Body: aws.String(`{"id": "e1", "detail-type": "OrderPlaced", "source": "orders-app", "detail": {"id": 1}}`),
+	}, "OrderPlaced")
+	require.NoError(t, err)
+	assert.Equal(t, "OrderPlaced", msg.Topic)
+	assert.JSONEq(t, `{"id": 1}`, string(msg.Data))
+	assert.Equal(t, map[string]string{"id": "e1", "source": "orders-app", "detailType": "OrderPlaced"}, msg.Metadata)
+
+	_, err = newMessage(&sqs.Message{Body: aws.String("not json")}, "OrderPlaced")
+	require.Error(t, err)
+}
+
+func TestQueuePolicy(t *testing.T) {
+	policy, err := queuePolicy("arn:aws:sqs:us-east-1:123456789012:q", "arn:aws:events:us-east-1:123456789012:rule/r")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"Service": "events.amazonaws.com"},
+			"Action": "sqs:SendMessage",
+			"Resource": "arn:aws:sqs:us-east-1:123456789012:q",
+			"Condition": {"ArnEquals": {"aws:SourceArn": "arn:aws:events:us-east-1:123456789012:rule/r"}}
+		}]
+	}`, policy)
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "orders-app-order_placed", resourceName("orders-app", "order.placed"))
+	assert.Len(t, resourceName("app", string(make([]byte, 100))), maxNameLength)
+}
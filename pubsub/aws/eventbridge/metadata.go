@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbridge
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/metadata"
+)
+
+const defaultEventBusName = "default"
+
+type eventBridgeMetadata struct {
+	// Ignored by metadata parser because included in built-in authentication profile
+	AccessKey    string `json:"accessKey" mapstructure:"accessKey" mdignore:"true"`
+	SecretKey    string `json:"secretKey" mapstructure:"secretKey" mdignore:"true"`
+	SessionToken string `json:"sessionToken" mapstructure:"sessionToken" mdignore:"true"`
+	Region       string `json:"region" mapstructure:"region" mdignore:"true"`
+
+	// AWS endpoint for the component to use.
+	Endpoint string `mapstructure:"endpoint"`
+	// Name or ARN of the event bus to publish to and to create rules on.
+	EventBusName string `mapstructure:"eventBusName"`
+	// Source of the published events. Defaults to the consumer ID, which is the app ID unless set.
+	Source string `mapstructure:"source"`
+	// Detail type of the published events. Defaults to the name of the topic.
+	DetailType string `mapstructure:"detailType"`
+	// Prefix of the rules and queues created for the subscriptions. It is provided by the runtime as "consumerID".
+	ConsumerID string `mapstructure:"consumerID" mdignore:"true"`
+	// Amount of time in seconds that a message is hidden from receive requests after it is received.
+	MessageVisibilityTimeout int64 `mapstructure:"messageVisibilityTimeout"`
+	// Amount of time in seconds to wait for a message to arrive before making another request.
+	MessageWaitTimeSeconds int64 `mapstructure:"messageWaitTimeSeconds"`
+	// Maximum number of messages to receive from the queue at a time.
+	MessageMaxNumber int64 `mapstructure:"messageMaxNumber"`
+	// Disable the creation of rules, queues and targets. They must exist already.
+	DisableEntityManagement bool `mapstructure:"disableEntityManagement"`
+	// Timeout in seconds of the operations to manage rules and queues.
+	AssetsManagementTimeoutSeconds float64 `mapstructure:"assetsManagementTimeoutSeconds"`
+}
+
+func parseMetadata(meta pubsub.Metadata) (*eventBridgeMetadata, error) {
+	md := &eventBridgeMetadata{
+		EventBusName:                   defaultEventBusName,
+		MessageVisibilityTimeout:       10,
+		MessageWaitTimeSeconds:         2,
+		MessageMaxNumber:               10,
+		AssetsManagementTimeoutSeconds: 5,
+	}
+	err := metadata.DecodeMetadata(meta.Properties, md)
+	if err != nil {
+		return nil, err
+	}
+
+	if md.ConsumerID == "" {
+		return nil, errors.New("consumerID must be set")
+	}
+	if md.Source == "" {
+		md.Source = md.ConsumerID
+	}
+	if strings.HasPrefix(md.Source, "aws.") {
+		return nil, errors.New("source must not start with 'aws.', which is reserved for events of AWS services")
+	}
+	if md.MessageVisibilityTimeout < 1 {
+		return nil, errors.New("messageVisibilityTimeout must be greater than 0")
+	}
+	if md.MessageWaitTimeSeconds < 1 {
+		return nil, errors.New("messageWaitTimeSeconds must be greater than 0")
+	}
+	if md.MessageMaxNumber < 1 || md.MessageMaxNumber > 10 {
+		return nil, errors.New("messageMaxNumber must be between 1 and 10")
+	}
+	if md.AssetsManagementTimeoutSeconds <= 0 {
+		return nil, errors.New("assetsManagementTimeoutSeconds must be greater than 0")
+	}
+
+	return md, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snssqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+const (
+	// subscribe metadata with the SNS filter policy of the subscription, as JSON.
+	filterPolicyKey = "filterPolicy"
+	// subscribe metadata with the part of the messages the filter policy applies to: MessageAttributes or MessageBody.
+	filterPolicyScopeKey = "filterPolicyScope"
+	// publish metadata prefix of the SNS message attributes of a message, such as "messageAttribute.eventType".
+	messageAttributePrefix = "messageAttribute."
+
+	filterPolicyScopeMessageAttributes = "MessageAttributes"
+	filterPolicyScopeMessageBody       = "MessageBody"
+)
+
+type filterPolicy struct {
+	policy string
+	scope  string
+}
+
+// parseFilterPolicy returns the filter policy of the subscription, if any, from its metadata.
+func parseFilterPolicy(reqMetadata map[string]string) (*filterPolicy, error) {
+	policy := strings.TrimSpace(reqMetadata[filterPolicyKey])
+	if policy == "" {
+		return nil, nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(policy), &obj); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON object: %w", filterPolicyKey, err)
+	}
+
+	scope := filterPolicyScopeMessageAttributes
+	if val := reqMetadata[filterPolicyScopeKey]; val != "" {
+		switch {
+		case strings.EqualFold(val, filterPolicyScopeMessageAttributes):
+			scope = filterPolicyScopeMessageAttributes
+		case strings.EqualFold(val, filterPolicyScopeMessageBody):
+			scope = filterPolicyScopeMessageBody
+		default:
+			return nil, fmt.Errorf("%s must be one of %s or %s", filterPolicyScopeKey, filterPolicyScopeMessageAttributes, filterPolicyScopeMessageBody)
+		}
+	}
+
+	return &filterPolicy{policy: policy, scope: scope}, nil
+}
+
+// setSubscriptionFilterPolicy applies the filter policy to the SNS subscription, so that only the matching messages are
+// delivered to the queue.
+func (s *snsSqs) setSubscriptionFilterPolicy(parentCtx context.Context, subscriptionArn string, fp *filterPolicy) error {
+	for _, attr := range []struct{ name, value string }{
+		{"FilterPolicyScope", fp.scope},
+		{"FilterPolicy", fp.policy},
+	} {
+		ctx, cancel := context.WithTimeout(parentCtx, s.opsTimeout)
+		_, err := s.authProvider.SnsSqs().Sns.SetSubscriptionAttributesWithContext(ctx, &sns.SetSubscriptionAttributesInput{
+			AttributeName:   aws.String(attr.name),
+			AttributeValue:  aws.String(attr.value),
+			SubscriptionArn: aws.String(subscriptionArn),
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("error setting %s of subscription arn: %s: %w", attr.name, subscriptionArn, err)
+		}
+	}
+
+	return nil
+}
+
+// messageAttributes returns the SNS message attributes of a message from its publish metadata.
+func messageAttributes(reqMetadata map[string]string) map[string]*sns.MessageAttributeValue {
+	var attrs map[string]*sns.MessageAttributeValue
+	for k, v := range reqMetadata {
+		name, ok := strings.CutPrefix(k, messageAttributePrefix)
+		if !ok || name == "" {
+			continue
+		}
+		if attrs == nil {
+			attrs = map[string]*sns.MessageAttributeValue{}
+		}
+		attrs[name] = &sns.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(v),
+		}
+	}
+	return attrs
+}
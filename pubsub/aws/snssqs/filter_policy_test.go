@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snssqs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFilterPolicy(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	fp, err := parseFilterPolicy(map[string]string{})
+	r.NoError(err)
+	r.Nil(fp)
+
+	fp, err = parseFilterPolicy(map[string]string{"filterPolicy": `{"eventType": ["order_placed"]}`})
+	r.NoError(err)
+	r.Equal(&filterPolicy{policy: `{"eventType": ["order_placed"]}`, scope: "MessageAttributes"}, fp)
+
+	fp, err = parseFilterPolicy(map[string]string{
+		"filterPolicy":      `{"data": {"amount": [{"numeric": [">", 100]}]}}`,
+		"filterPolicyScope": "messagebody",
+	})
+	r.NoError(err)
+	r.Equal("MessageBody", fp.scope)
+
+	_, err = parseFilterPolicy(map[string]string{"filterPolicy": `["order_placed"]`})
+	r.Error(err)
+	_, err = parseFilterPolicy(map[string]string{"filterPolicy": `{}`, "filterPolicyScope": "headers"})
+	r.Error(err)
+}
+
+func Test_messageAttributes(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	r.Nil(messageAttributes(map[string]string{"messageGroupId": "g"}))
+	r.Equal(map[string]*sns.MessageAttributeValue{
+		"eventType": {DataType: aws.String("String"), StringValue: aws.String("order_placed")},
+	}, messageAttributes(map[string]string{
+		"messageGroupId":             "g",
+		"messageAttribute.eventType": "order_placed",
+		"messageAttribute.":          "ignored",
+	}))
+}
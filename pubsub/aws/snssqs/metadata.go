@@ -3,6 +3,7 @@ package snssqs
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/kit/metadata"
@@ -30,6 +31,8 @@ type snsSqsMetadata struct {
 	SqsQueueName string `mapstructure:"consumerID" mdignore:"true"`
 	// name of the dead letter queue for this application.
 	SqsDeadLettersQueueName string `mapstructure:"sqsDeadLettersQueueName"`
+	// publish to and subscribe from an SQS queue named after the topic, without SNS topics and subscriptions.
+	SqsOnly bool `mapstructure:"sqsOnly"`
 	// flag to SNS and SQS FIFO.
 	Fifo bool `mapstructure:"fifo"`
 	// a namespace for SNS SQS FIFO to order messages within that group. limits consumer concurrency if set but guarantees that all
@@ -60,6 +63,8 @@ type snsSqsMetadata struct {
 	ConcurrencyMode pubsub.ConcurrencyMode `mapstructure:"concurrencyMode"`
 	// limits the number of concurrent goroutines
 	ConcurrencyLimit int `mapstructure:"concurrencyLimit"`
+	// time the messages that failed to be processed are hidden before being received again, from the dead-letter policy.
+	redeliveryBackoff time.Duration `mapstructure:"-"`
 }
 
 func maskLeft(s string) string {
@@ -96,6 +101,10 @@ func (s *snsSqs) getSnsSqsMetadata(meta pubsub.Metadata) (*snsSqsMetadata, error
 		return nil, errors.New("consumerID must be set")
 	}
 
+	if err := md.setDeadLetterPolicy(meta.Properties); err != nil {
+		return nil, err
+	}
+
 	if md.MessageVisibilityTimeout < 1 {
 		return nil, errors.New("messageVisibilityTimeout must be greater than 0")
 	}
@@ -142,6 +151,37 @@ func (s *snsSqs) getSnsSqsMetadata(meta pubsub.Metadata) (*snsSqsMetadata, error
 	return md, nil
 }
 
+// setDeadLetterPolicy maps the dead-letter policy to the redrive policy of the queue: messages are moved to the
+// deadLetterTopic queue after maxDeliveries receives, or deleted if no queue is set.
+// messageReceiveLimit, sqsDeadLettersQueueName and messageRetryLimit take precedence.
+func (md *snsSqsMetadata) setDeadLetterPolicy(props map[string]string) error {
+	p, err := pubsub.NewDeadLetterPolicy(props)
+	if err != nil {
+		return err
+	}
+	md.redeliveryBackoff = p.Backoff
+	if !p.Enabled() {
+		return nil
+	}
+
+	if md.SqsDeadLettersQueueName == "" {
+		md.SqsDeadLettersQueueName = p.DeadLetterTopic
+	}
+	if md.SqsDeadLettersQueueName != "" && md.MessageReceiveLimit == 0 {
+		md.MessageReceiveLimit = int64(p.MaxDeliveries)
+	}
+	// messages are deleted when they're received messageRetryLimit times, before being processed; with a dead-letters
+	// queue, SQS moves them before they reach the limit.
+	if props["messageRetryLimit"] == "" {
+		if md.SqsDeadLettersQueueName == "" {
+			md.MessageRetryLimit = int64(p.MaxDeliveries) + 1
+		} else if md.MessageRetryLimit <= md.MessageReceiveLimit {
+			md.MessageRetryLimit = md.MessageReceiveLimit + 1
+		}
+	}
+	return nil
+}
+
 func (md *snsSqsMetadata) setConcurrencyMode(props map[string]string) error {
 	c, err := pubsub.Concurrency(props)
 	if err != nil {
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -38,6 +39,7 @@ import (
 	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 )
 
@@ -51,12 +53,16 @@ type snsSqs struct {
 	subscriptions       map[string]string
 	authProvider        awsAuth.Provider
 	metadata            *snsSqsMetadata
+	dedup               *pubsub.Deduplicator
 	logger              logger.Logger
 	id                  string
 	opsTimeout          time.Duration
 	backOffConfig       retry.Config
 	subscriptionManager SubscriptionManagement
 	closed              atomic.Bool
+	// closeCh and wg manage the consumers of the queues in SQS-only mode.
+	closeCh chan struct{}
+	wg      sync.WaitGroup
 }
 
 type sqsQueueInfo struct {
@@ -81,6 +87,10 @@ const (
 	maxAWSNameLength                      = 80
 	assetsManagementDefaultTimeoutSeconds = 5.0
 	awsAccountIDLength                    = 12
+
+	// publish metadata of messages sent to FIFO topics.
+	messageGroupIDKey         = "messageGroupId"
+	messageDeduplicationIDKey = "messageDeduplicationId"
 )
 
 // NewSnsSqs - constructor for a new snssqs dapr component.
@@ -142,6 +152,10 @@ func (s *snsSqs) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	}
 
 	s.metadata = m
+	s.dedup, err = deduplication.New(ctx, metadata.Properties, s.logger)
+	if err != nil {
+		return err
+	}
 
 	if s.authProvider == nil {
 		opts := awsAuth.Options{
@@ -177,6 +191,7 @@ func (s *snsSqs) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	}
 	// subscription manager responsible for managing the lifecycle of subscriptions.
 	s.subscriptionManager = NewSubscriptionMgmt(s.logger)
+	s.closeCh = make(chan struct{})
 
 	s.queues = make(map[string]*sqsQueueInfo)
 	s.subscriptions = make(map[string]string)
@@ -204,14 +219,21 @@ func (s *snsSqs) buildARN(serviceName, entityName string) string {
 	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", s.metadata.internalPartition, serviceName, s.metadata.Region, s.metadata.AccountID, entityName)
 }
 
+// isFifoTopic returns true if the topic is a FIFO topic, either because FIFO is enabled for the component or because its
+// name has the ".fifo" suffix.
+func (s *snsSqs) isFifoTopic(topic string) bool {
+	return s.metadata.Fifo || strings.HasSuffix(topic, awsSqsFifoSuffix)
+}
+
 func (s *snsSqs) createTopic(parentCtx context.Context, topic string) (string, error) {
-	sanitizedName := nameToAWSSanitizedName(topic, s.metadata.Fifo)
+	isFifo := s.isFifoTopic(topic)
+	sanitizedName := nameToAWSSanitizedName(topic, isFifo)
 	snsCreateTopicInput := &sns.CreateTopicInput{
 		Name: aws.String(sanitizedName),
 		Tags: []*sns.Tag{{Key: aws.String(awsSnsTopicNameKey), Value: aws.String(topic)}},
 	}
 
-	if s.metadata.Fifo {
+	if isFifo {
 		attributes := map[string]*string{"FifoTopic": aws.String("true"), "ContentBasedDeduplication": aws.String("true")}
 		snsCreateTopicInput.SetAttributes(attributes)
 	}
@@ -244,7 +266,7 @@ func (s *snsSqs) getTopicArn(parentCtx context.Context, topic string) (string, e
 // NOTE: This method potentially reads and writes to the topicArns map, which may end up being accessed by multiple goroutines concurrently,
 // therefore it is necessary for its caller to lock the topic.
 func (s *snsSqs) getOrCreateTopic(ctx context.Context, topic string) (topicArn string, sanitizedTopic string, err error) {
-	sanitizedTopic = nameToAWSSanitizedName(topic, s.metadata.Fifo)
+	sanitizedTopic = nameToAWSSanitizedName(topic, s.isFifoTopic(topic))
 
 	var exists bool
 	s.topicLock.RLock()
@@ -376,6 +398,9 @@ func (s *snsSqs) getOrCreateQueue(ctx context.Context, queueName string) (*sqsQu
 }
 
 func (s *snsSqs) getMessageGroupID(req *pubsub.PublishRequest) *string {
+	if groupID := req.Metadata[messageGroupIDKey]; groupID != "" {
+		return &groupID
+	}
 	if len(s.metadata.FifoMessageGroupID) > 0 {
 		return &s.metadata.FifoMessageGroupID
 	}
@@ -471,12 +496,17 @@ func (s *snsSqs) acknowledgeMessage(parentCtx context.Context, queueURL string,
 }
 
 func (s *snsSqs) resetMessageVisibilityTimeout(parentCtx context.Context, queueURL string, receiptHandle *string) error {
-	ctx, cancelFn := context.WithCancel(parentCtx)
 	// reset the timeout to its initial value so that the remaining timeout would be overridden by the initial value for other consumer to attempt processing.
+	return s.changeMessageVisibilityTimeout(parentCtx, queueURL, receiptHandle, 0)
+}
+
+// changeMessageVisibilityTimeout hides the message for the given time, rounded up to seconds, before it can be received again.
+func (s *snsSqs) changeMessageVisibilityTimeout(parentCtx context.Context, queueURL string, receiptHandle *string, timeout time.Duration) error {
+	ctx, cancelFn := context.WithCancel(parentCtx)
 	_, err := s.authProvider.SnsSqs().Sqs.ChangeMessageVisibilityWithContext(ctx, &sqs.ChangeMessageVisibilityInput{
 		QueueUrl:          aws.String(queueURL),
 		ReceiptHandle:     receiptHandle,
-		VisibilityTimeout: aws.Int64(0),
+		VisibilityTimeout: aws.Int64(int64(math.Ceil(timeout.Seconds()))),
 	})
 	cancelFn()
 	if err != nil {
@@ -573,6 +603,11 @@ func (s *snsSqs) callHandler(ctx context.Context, message *sqs.Message, queueInf
 		Topic: handler.requestTopic,
 	})
 	if err != nil {
+		if s.metadata.redeliveryBackoff > 0 {
+			if vErr := s.changeMessageVisibilityTimeout(ctx, queueInfo.url, message.ReceiptHandle, s.metadata.redeliveryBackoff); vErr != nil {
+				s.logger.Warnf("error delaying the redelivery of message id: %s: %v", *message.MessageId, vErr)
+			}
+		}
 		return fmt.Errorf("error handling message: %w", err)
 	}
 	// otherwise, there was no error, acknowledge the message.
@@ -582,12 +617,18 @@ func (s *snsSqs) callHandler(ctx context.Context, message *sqs.Message, queueInf
 // consumeSubscription is responsible for polling messages from the queue and calling the handler.
 // it is being passed as a callback to the subscription manager that initializes the context of the handler.
 func (s *snsSqs) consumeSubscription(ctx context.Context, queueInfo, deadLettersQueueInfo *sqsQueueInfo) {
+	s.consumeQueue(ctx, queueInfo, deadLettersQueueInfo, s.callHandler)
+}
+
+// consumeQueue polls messages from the queue and passes them to handle until the context is canceled.
+func (s *snsSqs) consumeQueue(ctx context.Context, queueInfo, deadLettersQueueInfo *sqsQueueInfo, handle func(context.Context, *sqs.Message, *sqsQueueInfo) error) {
 	sqsPullExponentialBackoff := s.backOffConfig.NewBackOffWithContext(ctx)
 
 	receiveMessageInput := &sqs.ReceiveMessageInput{
 		// use this property to decide when a message should be discarded.
 		AttributeNames: []*string{
 			aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount),
+			aws.String(sqs.MessageSystemAttributeNameMessageGroupId),
 		},
 		MaxNumberOfMessages: aws.Int64(s.metadata.MessageMaxNumber),
 		QueueUrl:            aws.String(queueInfo.url),
@@ -636,21 +677,30 @@ func (s *snsSqs) consumeSubscription(ctx context.Context, queueInfo, deadLetters
 		}
 		s.logger.Debugf("%v message(s) received on queue %s", len(messageResponse.Messages), queueInfo.arn)
 
-		for _, message := range messageResponse.Messages {
-			if err := s.validateMessage(ctx, message, queueInfo, deadLettersQueueInfo); err != nil {
-				s.logger.Errorf("message is not valid for further processing by the handler. error is: %v", err)
-				continue
-			}
+		// messages of a FIFO queue are handled in order within each message group, and the groups are handled in parallel
+		// in parallel mode. messages of a standard queue are each in a group of their own.
+		for _, group := range s.groupMessages(messageResponse.Messages) {
+			f := func(group []*sqs.Message) {
+				for _, message := range group {
+					if err := s.validateMessage(ctx, message, queueInfo, deadLettersQueueInfo); err != nil {
+						s.logger.Errorf("message is not valid for further processing by the handler. error is: %v", err)
+						continue
+					}
 
-			f := func(message *sqs.Message) {
-				if err := s.callHandler(ctx, message, queueInfo); err != nil {
-					s.logger.Errorf("error while handling received message. error is: %v", err)
+					if err := handle(ctx, message, queueInfo); err != nil {
+						s.logger.Errorf("error while handling received message. error is: %v", err)
+						if len(group) > 1 {
+							// the following messages of the group are received again after the failed one, preserving their order.
+							s.logger.Debugf("skipping the remaining messages of message group %s", messageGroupID(message))
+							return
+						}
+					}
 				}
 			}
 
 			switch s.metadata.ConcurrencyMode {
 			case pubsub.Single:
-				f(message)
+				f(group)
 			case pubsub.Parallel:
 				// This is the back pressure mechanism.
 				// It will block until another goroutine frees a slot.
@@ -658,18 +708,46 @@ func (s *snsSqs) consumeSubscription(ctx context.Context, queueInfo, deadLetters
 					sem <- struct{}{}
 				}
 
-				go func(message *sqs.Message) {
+				go func(group []*sqs.Message) {
 					if sem != nil {
 						defer func() { <-sem }()
 					}
 
-					f(message)
-				}(message)
+					f(group)
+				}(group)
 			}
 		}
 	}
 }
 
+// groupMessages splits received messages by message group, keeping the order of the messages within each group.
+// messages that don't have a message group, such as the ones of standard queues, are each in a group of their own.
+func (s *snsSqs) groupMessages(messages []*sqs.Message) [][]*sqs.Message {
+	groups := make([][]*sqs.Message, 0, len(messages))
+	groupIdx := map[string]int{}
+	for _, message := range messages {
+		groupID := messageGroupID(message)
+		if groupID == "" || !s.metadata.Fifo {
+			groups = append(groups, []*sqs.Message{message})
+			continue
+		}
+		if i, ok := groupIdx[groupID]; ok {
+			groups[i] = append(groups[i], message)
+			continue
+		}
+		groupIdx[groupID] = len(groups)
+		groups = append(groups, []*sqs.Message{message})
+	}
+	return groups
+}
+
+func messageGroupID(message *sqs.Message) string {
+	if groupID, ok := message.Attributes[sqs.MessageSystemAttributeNameMessageGroupId]; ok && groupID != nil {
+		return *groupID
+	}
+	return ""
+}
+
 func (s *snsSqs) createDeadLettersQueueAttributes(queueInfo, deadLettersQueueInfo *sqsQueueInfo) (*sqs.SetQueueAttributesInput, error) {
 	policy := map[string]string{
 		"deadLetterTargetArn": deadLettersQueueInfo.arn,
@@ -773,6 +851,24 @@ func (s *snsSqs) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 	if s.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = s.dedup.Handler(handler)
+
+	// SNS FIFO topics can only deliver messages to SQS FIFO queues.
+	if s.isFifoTopic(req.Topic) && !s.metadata.Fifo {
+		return fmt.Errorf("topic %s is a FIFO topic: fifo must be enabled to subscribe to it", req.Topic)
+	}
+
+	fp, err := parseFilterPolicy(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("invalid filter policy for topic %s: %w", req.Topic, err)
+	}
+
+	if s.metadata.SqsOnly {
+		if fp != nil {
+			return fmt.Errorf("filter policies are not supported in SQS-only mode; topic %s", req.Topic)
+		}
+		return s.subscribeToQueue(ctx, req, handler)
+	}
 
 	// subscribers declare a topic ARN and declare a SQS queue to use
 	// these should be idempotent - queues should not be created if they exist.
@@ -827,7 +923,7 @@ func (s *snsSqs) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 	}
 
 	// subscription creation is idempotent. Subscriptions are unique by topic/queue.
-	_, err = s.getOrCreateSnsSqsSubscription(ctx, queueInfo.arn, topicArn)
+	subscriptionArn, err := s.getOrCreateSnsSqsSubscription(ctx, queueInfo.arn, topicArn)
 	if err != nil {
 		wrappedErr := fmt.Errorf("error subscribing topic: %s, to queue: %s, with error: %w", topicArn, queueInfo.arn, err)
 		s.logger.Error(wrappedErr)
@@ -835,6 +931,18 @@ func (s *snsSqs) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 		return wrappedErr
 	}
 
+	// the filter policy of the subscription is left unchanged when the subscription doesn't declare one.
+	if fp != nil {
+		if s.metadata.DisableEntityManagement {
+			s.logger.Warnf("Entity management is disabled: the filter policy of the subscription to topic %s is not applied", req.Topic)
+		} else if err = s.setSubscriptionFilterPolicy(ctx, subscriptionArn, fp); err != nil {
+			wrappedErr := fmt.Errorf("error applying the filter policy of topic: %s, to queue: %s, with error: %w", topicArn, queueInfo.arn, err)
+			s.logger.Error(wrappedErr)
+
+			return wrappedErr
+		}
+	}
+
 	// start the subscription manager
 	s.subscriptionManager.Init(queueInfo, deadLettersQueueInfo, s.consumeSubscription)
 
@@ -853,6 +961,13 @@ func (s *snsSqs) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 		return errors.New("component is closed")
 	}
 
+	if s.metadata.SqsOnly {
+		if s.isFifoTopic(req.Topic) && !s.metadata.Fifo {
+			return fmt.Errorf("topic %s is a FIFO topic: fifo must be enabled to publish to its queue", req.Topic)
+		}
+		return s.publishToQueue(ctx, req)
+	}
+
 	topicArn, _, err := s.getOrCreateTopic(ctx, req.Topic)
 	if err != nil {
 		s.logger.Errorf("error getting topic ARN for %s: %v", req.Topic, err)
@@ -860,11 +975,16 @@ func (s *snsSqs) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 
 	message := string(req.Data)
 	snsPublishInput := &sns.PublishInput{
-		Message:  aws.String(message),
-		TopicArn: aws.String(topicArn),
+		Message:           aws.String(message),
+		MessageAttributes: messageAttributes(req.Metadata),
+		TopicArn:          aws.String(topicArn),
 	}
-	if s.metadata.Fifo {
+	if s.isFifoTopic(req.Topic) {
 		snsPublishInput.MessageGroupId = s.getMessageGroupID(req)
+		// topics created by Dapr use content-based deduplication, so the deduplication ID is only required for topics that don't.
+		if dedupID := req.Metadata[messageDeduplicationIDKey]; dedupID != "" {
+			snsPublishInput.MessageDeduplicationId = aws.String(dedupID)
+		}
 	}
 
 	// sns client has internal exponential backoffs.
@@ -884,12 +1004,17 @@ func (s *snsSqs) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 func (s *snsSqs) Close() error {
 	if s.closed.CompareAndSwap(false, true) {
 		s.subscriptionManager.Close()
+		if s.closeCh != nil {
+			close(s.closeCh)
+		}
+		s.wg.Wait()
 	}
 
+	err := s.dedup.Close()
 	if s.authProvider != nil {
-		return s.authProvider.Close()
+		return errors.Join(s.authProvider.Close(), err)
 	}
-	return nil
+	return err
 }
 
 func (s *snsSqs) Features() []pubsub.Feature {
@@ -14,9 +14,14 @@ limitations under the License.
 package snssqs
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/metadata"
@@ -62,6 +67,7 @@ func Test_getSnsSqsMetadata_AllConfiguration(t *testing.T) {
 		"messageWaitTimeSeconds":   "4",
 		"messageMaxNumber":         "5",
 		"messageReceiveLimit":      "6",
+		"sqsOnly":                  "true",
 	}}})
 
 	r.NoError(err)
@@ -80,6 +86,7 @@ func Test_getSnsSqsMetadata_AllConfiguration(t *testing.T) {
 	r.Equal(int64(4), md.MessageWaitTimeSeconds)
 	r.Equal(int64(5), md.MessageMaxNumber)
 	r.Equal(int64(6), md.MessageReceiveLimit)
+	r.True(md.SqsOnly)
 }
 
 func Test_getSnsSqsMetadata_defaults(t *testing.T) {
@@ -117,6 +124,52 @@ func Test_getSnsSqsMetadata_defaults(t *testing.T) {
 	r.False(md.DisableDeleteOnRetryLimit)
 }
 
+func Test_getSnsSqsMetadata_deadLetterPolicy(t *testing.T) {
+	t.Parallel()
+	ps := snsSqs{
+		logger: logger.NewLogger("SnsSqs unit test"),
+	}
+	getMetadata := func(props map[string]string) (*snsSqsMetadata, error) {
+		props["consumerID"] = "c"
+		return ps.getSnsSqsMetadata(pubsub.Metadata{Base: metadata.Base{Properties: props}})
+	}
+
+	t.Run("dead-letters queue", func(t *testing.T) {
+		md, err := getMetadata(map[string]string{
+			"maxDeliveries":     "12",
+			"deadLetterTopic":   "q-dlq",
+			"redeliveryBackoff": "30s",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "q-dlq", md.SqsDeadLettersQueueName)
+		require.Equal(t, int64(12), md.MessageReceiveLimit)
+		require.Equal(t, int64(13), md.MessageRetryLimit)
+		require.Equal(t, 30*time.Second, md.redeliveryBackoff)
+	})
+
+	t.Run("messages are deleted without a dead-letters queue", func(t *testing.T) {
+		md, err := getMetadata(map[string]string{"maxDeliveries": "3"})
+		require.NoError(t, err)
+		require.Empty(t, md.SqsDeadLettersQueueName)
+		require.Equal(t, int64(0), md.MessageReceiveLimit)
+		require.Equal(t, int64(4), md.MessageRetryLimit)
+	})
+
+	t.Run("component settings take precedence", func(t *testing.T) {
+		md, err := getMetadata(map[string]string{
+			"maxDeliveries":           "3",
+			"deadLetterTopic":         "q-dlq",
+			"sqsDeadLettersQueueName": "q-dead",
+			"messageReceiveLimit":     "5",
+			"messageRetryLimit":       "8",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "q-dead", md.SqsDeadLettersQueueName)
+		require.Equal(t, int64(5), md.MessageReceiveLimit)
+		require.Equal(t, int64(8), md.MessageRetryLimit)
+	})
+}
+
 func Test_getSnsSqsMetadata_legacyaliases(t *testing.T) {
 	t.Parallel()
 	r := require.New(t)
@@ -508,3 +561,91 @@ func Test_buildARN_NonStandardPartition(t *testing.T) {
 	arn := ps.buildARN("sns", "myTopic")
 	r.Equal("arn:aws-cn:sns:cn-northwest-1:123456789012:myTopic", arn)
 }
+
+func Test_isFifoTopic(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	ps := snsSqs{metadata: &snsSqsMetadata{}}
+
+	r.False(ps.isFifoTopic("orders"))
+	r.True(ps.isFifoTopic("orders.fifo"))
+	r.Equal("orders.fifo", nameToAWSSanitizedName("orders.fifo", ps.isFifoTopic("orders.fifo")))
+
+	ps.metadata.Fifo = true
+	r.True(ps.isFifoTopic("orders"))
+}
+
+func Test_getMessageGroupID(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	ps := snsSqs{id: "id", metadata: &snsSqsMetadata{}}
+
+	req := &pubsub.PublishRequest{PubsubName: "ps", Topic: "orders.fifo"}
+	r.Equal("id:ps:orders.fifo", *ps.getMessageGroupID(req))
+
+	ps.metadata.FifoMessageGroupID = "app1"
+	r.Equal("app1", *ps.getMessageGroupID(req))
+
+	req.Metadata = map[string]string{"messageGroupId": "customer-1"}
+	r.Equal("customer-1", *ps.getMessageGroupID(req))
+}
+
+func Test_groupMessages(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	message := func(id string, groupID string) *sqs.Message {
+		m := &sqs.Message{MessageId: aws.String(id)}
+		if groupID != "" {
+			m.Attributes = map[string]*string{sqs.MessageSystemAttributeNameMessageGroupId: aws.String(groupID)}
+		}
+		return m
+	}
+	messages := []*sqs.Message{
+		message("1", "a"),
+		message("2", "b"),
+		message("3", "a"),
+		message("4", ""),
+		message("5", "b"),
+	}
+	ids := func(groups [][]*sqs.Message) [][]string {
+		res := make([][]string, len(groups))
+		for i, g := range groups {
+			for _, m := range g {
+				res[i] = append(res[i], *m.MessageId)
+			}
+		}
+		return res
+	}
+
+	ps := snsSqs{metadata: &snsSqsMetadata{Fifo: true}}
+	r.Equal([][]string{{"1", "3"}, {"2", "5"}, {"4"}}, ids(ps.groupMessages(messages)))
+
+	ps.metadata.Fifo = false
+	r.Equal([][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}}, ids(ps.groupMessages(messages)))
+}
+
+func Test_callQueueHandler(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	ps := snsSqs{logger: logger.NewLogger("SnsSqs unit test")}
+
+	var received *pubsub.NewMessage
+	handler := &SubscriptionTopicHandler{
+		topic:        "orders",
+		requestTopic: "orders",
+		ctx:          t.Context(),
+		handler: func(ctx context.Context, msg *pubsub.NewMessage) error {
+			received = msg
+			return errors.New("not now")
+		},
+	}
+
+	// the message is not acknowledged when the handler fails.
+	err := ps.callQueueHandler(t.Context(), &sqs.Message{
+		MessageId: aws.String("1"),
+		Body:      aws.String(`{"id": 1}`),
+	}, &sqsQueueInfo{url: "url"}, handler)
+	r.ErrorContains(err, "not now")
+	r.Equal("orders", received.Topic)
+	r.JSONEq(`{"id": 1}`, string(received.Data))
+}
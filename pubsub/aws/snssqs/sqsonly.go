@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snssqs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// in SQS-only mode, each topic is an SQS queue of the same name: messages are sent to the queue directly and the
+// subscribers of the topic consume the queue, without SNS topics and subscriptions.
+
+// publishToQueue sends the message to the queue of the topic.
+func (s *snsSqs) publishToQueue(ctx context.Context, req *pubsub.PublishRequest) error {
+	queueInfo, err := s.getQueueForTopic(ctx, req.Topic)
+	if err != nil {
+		return err
+	}
+
+	sendMessageInput := &sqs.SendMessageInput{
+		MessageBody: aws.String(string(req.Data)),
+		QueueUrl:    aws.String(queueInfo.url),
+	}
+	if s.metadata.Fifo {
+		sendMessageInput.MessageGroupId = s.getMessageGroupID(req)
+		// queues created by Dapr use content-based deduplication, so the deduplication ID is only required for queues that don't.
+		if dedupID := req.Metadata[messageDeduplicationIDKey]; dedupID != "" {
+			sendMessageInput.MessageDeduplicationId = aws.String(dedupID)
+		}
+	}
+
+	// sqs client has internal exponential backoffs.
+	_, err = s.authProvider.SnsSqs().Sqs.SendMessageWithContext(ctx, sendMessageInput)
+	if err != nil {
+		wrappedErr := fmt.Errorf("error publishing to queue of topic: %s with queue ARN %s: %w", req.Topic, queueInfo.arn, err)
+		s.logger.Error(wrappedErr)
+
+		return wrappedErr
+	}
+
+	return nil
+}
+
+// subscribeToQueue consumes the queue of the topic until the subscription context is canceled or the component is closed.
+func (s *snsSqs) subscribeToQueue(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	queueInfo, err := s.getQueueForTopic(ctx, req.Topic)
+	if err != nil {
+		return err
+	}
+
+	var deadLettersQueueInfo *sqsQueueInfo
+	if len(s.metadata.SqsDeadLettersQueueName) > 0 {
+		s.topicLock.Lock()
+		deadLettersQueueInfo, err = s.getOrCreateQueue(ctx, s.metadata.SqsDeadLettersQueueName)
+		s.topicLock.Unlock()
+		if err != nil {
+			wrappedErr := fmt.Errorf("error retrieving SQS dead-letter queue: %w", err)
+			s.logger.Error(wrappedErr)
+
+			return wrappedErr
+		}
+
+		err = s.setDeadLettersQueueAttributes(ctx, queueInfo, deadLettersQueueInfo)
+		if err != nil {
+			wrappedErr := fmt.Errorf("error creating dead-letter queue: %w", err)
+			s.logger.Error(wrappedErr)
+
+			return wrappedErr
+		}
+	}
+
+	topicHandler := &SubscriptionTopicHandler{
+		topic:        req.Topic,
+		requestTopic: req.Topic,
+		handler:      handler,
+		ctx:          ctx,
+	}
+
+	consumeCtx, cancel := context.WithCancel(ctx)
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		select {
+		case <-consumeCtx.Done():
+		case <-s.closeCh:
+		}
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.logger.Infof("Starting SQS consumption of queue %s for topic %s", queueInfo.arn, req.Topic)
+		s.consumeQueue(consumeCtx, queueInfo, deadLettersQueueInfo, func(ctx context.Context, message *sqs.Message, queueInfo *sqsQueueInfo) error {
+			return s.callQueueHandler(ctx, message, queueInfo, topicHandler)
+		})
+	}()
+
+	return nil
+}
+
+// getQueueForTopic returns the queue of the topic, creating it if needed.
+// the queues map is shared by publishers and subscribers, so it's accessed while holding the topic lock.
+func (s *snsSqs) getQueueForTopic(ctx context.Context, topic string) (*sqsQueueInfo, error) {
+	s.topicLock.Lock()
+	queueInfo, err := s.getOrCreateQueue(ctx, topic)
+	s.topicLock.Unlock()
+	if err != nil {
+		wrappedErr := fmt.Errorf("error retrieving SQS queue of topic %s: %w", topic, err)
+		s.logger.Error(wrappedErr)
+
+		return nil, wrappedErr
+	}
+	return queueInfo, nil
+}
+
+// callQueueHandler passes the body of a message sent to the queue directly to the handler of the topic.
+func (s *snsSqs) callQueueHandler(ctx context.Context, message *sqs.Message, queueInfo *sqsQueueInfo, handler *SubscriptionTopicHandler) error {
+	s.logger.Debugf("Processing SQS message id: %s of topic: %s", *message.MessageId, handler.requestTopic)
+
+	// call the handler with its own subscription context
+	err := handler.handler(handler.ctx, &pubsub.NewMessage{
+		Data:  []byte(aws.StringValue(message.Body)),
+		Topic: handler.requestTopic,
+	})
+	if err != nil {
+		return fmt.Errorf("error handling message: %w", err)
+	}
+	// otherwise, there was no error, acknowledge the message.
+	return s.acknowledgeMessage(ctx, queueInfo.url, message.ReceiptHandle)
+}
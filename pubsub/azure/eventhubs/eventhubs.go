@@ -18,6 +18,7 @@ import (
 	"errors"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azeventhubs"
 
@@ -25,6 +26,7 @@ import (
 	commonutils "github.com/dapr/components-contrib/common/utils"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
 	"github.com/dapr/kit/strings"
@@ -33,17 +35,25 @@ import (
 // AzureEventHubs allows sending/receiving Azure Event Hubs events.
 type AzureEventHubs struct {
 	*impl.AzureEventHubs
+
+	dedup  *pubsub.Deduplicator
+	logger logger.Logger
 }
 
 // NewAzureEventHubs returns a new Azure Event hubs instance.
 func NewAzureEventHubs(logger logger.Logger) pubsub.PubSub {
 	return &AzureEventHubs{
 		AzureEventHubs: impl.NewAzureEventHubs(logger, false),
+		logger:         logger,
 	}
 }
 
 // Init the object.
-func (aeh *AzureEventHubs) Init(_ context.Context, metadata pubsub.Metadata) error {
+func (aeh *AzureEventHubs) Init(ctx context.Context, metadata pubsub.Metadata) (err error) {
+	aeh.dedup, err = deduplication.New(ctx, metadata.Properties, aeh.logger)
+	if err != nil {
+		return err
+	}
 	return aeh.AzureEventHubs.Init(metadata.Properties)
 }
 
@@ -134,15 +144,21 @@ func (aeh *AzureEventHubs) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 		getAllProperties = aeh.GetAllMessageProperties()
 	}
 
-	checkPointFrequencyPerPartition := commonutils.GetIntValFromString(req.Metadata["checkPointFrequencyPerPartition"], impl.DefaultCheckpointFrequencyPerPartition)
+	checkPointFrequencyPerPartition, checkPointInterval := aeh.getCheckPointConfig(req.Metadata)
+	startPositions, err := impl.ParseStartPositions(req.Metadata)
+	if err != nil {
+		return err
+	}
 
-	pubsubHandler := aeh.GetPubSubHandlerFunc(topic, getAllProperties, handler)
+	pubsubHandler := aeh.GetPubSubHandlerFunc(topic, getAllProperties, aeh.dedup.Handler(handler))
 
 	subscribeConfig := impl.SubscribeConfig{
 		Topic:                           topic,
 		MaxBulkSubCount:                 1,
 		MaxBulkSubAwaitDurationMs:       impl.DefaultMaxBulkSubAwaitDurationMs,
 		CheckPointFrequencyPerPartition: checkPointFrequencyPerPartition,
+		CheckPointInterval:              checkPointInterval,
+		StartPositions:                  startPositions,
 		Handler:                         pubsubHandler,
 	}
 	// Start the subscription
@@ -162,17 +178,23 @@ func (aeh *AzureEventHubs) BulkSubscribe(ctx context.Context, req pubsub.Subscri
 	if !getAllProperties {
 		getAllProperties = aeh.GetAllMessageProperties()
 	}
-	checkPointFrequencyPerPartition := commonutils.GetIntValFromString(req.Metadata["checkPointFrequencyPerPartition"], impl.DefaultCheckpointFrequencyPerPartition)
+	checkPointFrequencyPerPartition, checkPointInterval := aeh.getCheckPointConfig(req.Metadata)
+	startPositions, err := impl.ParseStartPositions(req.Metadata)
+	if err != nil {
+		return err
+	}
 	maxBulkSubCount := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxMessagesCount, impl.DefaultMaxBulkSubCount)
 	maxBulkSubAwaitDurationMs := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxAwaitDurationMs, impl.DefaultMaxBulkSubAwaitDurationMs)
 
-	bulkPubsubHandler := aeh.GetBulkPubSubHandlerFunc(topic, getAllProperties, handler)
+	bulkPubsubHandler := aeh.GetBulkPubSubHandlerFunc(topic, getAllProperties, aeh.dedup.BulkHandler(handler))
 
 	subscribeConfig := impl.SubscribeConfig{
 		Topic:                           topic,
 		MaxBulkSubCount:                 maxBulkSubCount,
 		MaxBulkSubAwaitDurationMs:       maxBulkSubAwaitDurationMs,
 		CheckPointFrequencyPerPartition: checkPointFrequencyPerPartition,
+		CheckPointInterval:              checkPointInterval,
+		StartPositions:                  startPositions,
 		Handler:                         bulkPubsubHandler,
 	}
 
@@ -181,8 +203,16 @@ func (aeh *AzureEventHubs) BulkSubscribe(ctx context.Context, req pubsub.Subscri
 	return aeh.AzureEventHubs.Subscribe(ctx, subscribeConfig)
 }
 
+// getCheckPointConfig returns how often the checkpoint of each partition is updated, from the metadata of the subscription
+// or else of the component.
+func (aeh *AzureEventHubs) getCheckPointConfig(reqMetadata map[string]string) (int, time.Duration) {
+	frequency := commonutils.GetIntValFromString(reqMetadata["checkPointFrequencyPerPartition"], aeh.CheckPointFrequencyPerPartition())
+	intervalInSec := commonutils.GetIntValFromString(reqMetadata["checkPointIntervalInSec"], int(aeh.CheckPointInterval()/time.Second))
+	return frequency, time.Duration(intervalInSec) * time.Second
+}
+
 func (aeh *AzureEventHubs) Close() (err error) {
-	return aeh.AzureEventHubs.Close()
+	return errors.Join(aeh.AzureEventHubs.Close(), aeh.dedup.Close())
 }
 
 // GetComponentMetadata returns the metadata of the component.
@@ -26,6 +26,7 @@ import (
 	commonutils "github.com/dapr/components-contrib/common/utils"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 )
 
@@ -37,6 +38,7 @@ const (
 type azureServiceBus struct {
 	metadata *impl.Metadata
 	client   *impl.Client
+	dedup    *pubsub.Deduplicator
 	logger   logger.Logger
 	closed   atomic.Bool
 	closeCh  chan struct{}
@@ -51,12 +53,17 @@ func NewAzureServiceBusQueues(logger logger.Logger) pubsub.PubSub {
 	}
 }
 
-func (a *azureServiceBus) Init(_ context.Context, metadata pubsub.Metadata) (err error) {
+func (a *azureServiceBus) Init(ctx context.Context, metadata pubsub.Metadata) (err error) {
 	a.metadata, err = impl.ParseMetadata(metadata.Properties, a.logger, impl.MetadataModeQueues)
 	if err != nil {
 		return err
 	}
 
+	a.dedup, err = deduplication.New(ctx, metadata.Properties, a.logger)
+	if err != nil {
+		return err
+	}
+
 	a.client, err = impl.NewClient(a.metadata, metadata.Properties)
 	if err != nil {
 		return err
@@ -81,26 +88,53 @@ func (a *azureServiceBus) BulkPublish(ctx context.Context, req *pubsub.BulkPubli
 	return a.client.PublishPubSubBulk(ctx, req, a.client.EnsureQueue, a.logger)
 }
 
+// ScheduleMessage schedules a message on the queue, to be enqueued at the time set in the ScheduledEnqueueTimeUtc
+// metadata property. It returns the sequence number of the message, which can be used to cancel it.
+func (a *azureServiceBus) ScheduleMessage(ctx context.Context, req *pubsub.PublishRequest) (int64, error) {
+	if a.closed.Load() {
+		return 0, errors.New("component is closed")
+	}
+
+	return a.client.SchedulePubSub(ctx, req, a.client.EnsureQueue)
+}
+
+// CancelScheduledMessages cancels messages scheduled on the queue, by sequence number.
+func (a *azureServiceBus) CancelScheduledMessages(ctx context.Context, queue string, sequenceNumbers ...int64) error {
+	if a.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	return a.client.CancelScheduledMessages(ctx, queue, sequenceNumbers)
+}
+
+// HandleDeferredMessages invokes the handler for messages of the queue that were deferred by returning
+// impl.ErrDeferMessage, by sequence number.
+func (a *azureServiceBus) HandleDeferredMessages(ctx context.Context, queue string, handler pubsub.Handler, sequenceNumbers ...int64) error {
+	if a.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	receiver, err := a.client.GetClient().NewReceiverForQueue(queue, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create receiver for queue %s: %w", queue, err)
+	}
+
+	handlerFn := impl.GetPubSubHandlerFunc(queue, handler, a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second)
+	return a.client.HandleDeferredMessages(ctx, receiver, sequenceNumbers, handlerFn, a.logger)
+}
+
 func (a *azureServiceBus) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
 	if a.closed.Load() {
 		return errors.New("component is closed")
 	}
 
-	sub := impl.NewSubscription(
-		impl.SubscriptionOptions{
-			MaxActiveMessages:     a.metadata.MaxActiveMessages,
-			TimeoutInSec:          a.metadata.TimeoutInSec,
-			MaxBulkSubCount:       nil,
-			MaxRetriableEPS:       a.metadata.MaxRetriableErrorsPerSec,
-			MaxConcurrentHandlers: a.metadata.MaxConcurrentHandlers,
-			Entity:                "queue " + req.Topic,
-			LockRenewalInSec:      a.metadata.LockRenewalInSec,
-			RequireSessions:       false,
-		},
-		a.logger,
-	)
-
-	return a.doSubscribe(ctx, req, sub, impl.GetPubSubHandlerFunc(req.Topic, handler, a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second))
+	opts, err := a.subscriptionOptions(req)
+	if err != nil {
+		return err
+	}
+	sub := impl.NewSubscription(opts, a.logger)
+
+	return a.doSubscribe(ctx, req, sub, impl.GetPubSubHandlerFunc(req.Topic, a.dedup.Handler(handler), a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second))
 }
 
 func (a *azureServiceBus) BulkSubscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.BulkHandler) error {
@@ -109,21 +143,29 @@ func (a *azureServiceBus) BulkSubscribe(ctx context.Context, req pubsub.Subscrib
 	}
 
 	maxBulkSubCount := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxMessagesCount, defaultMaxBulkSubCount)
-	sub := impl.NewSubscription(
-		impl.SubscriptionOptions{
-			MaxActiveMessages:     a.metadata.MaxActiveMessages,
-			TimeoutInSec:          a.metadata.TimeoutInSec,
-			MaxBulkSubCount:       &maxBulkSubCount,
-			MaxRetriableEPS:       a.metadata.MaxRetriableErrorsPerSec,
-			MaxConcurrentHandlers: a.metadata.MaxConcurrentHandlers,
-			Entity:                "queue " + req.Topic,
-			LockRenewalInSec:      a.metadata.LockRenewalInSec,
-			RequireSessions:       false,
-		},
-		a.logger,
-	)
-
-	return a.doSubscribe(ctx, req, sub, impl.GetBulkPubSubHandlerFunc(req.Topic, handler, a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second))
+	opts, err := a.subscriptionOptions(req)
+	if err != nil {
+		return err
+	}
+	opts.MaxBulkSubCount = &maxBulkSubCount
+	sub := impl.NewSubscription(opts, a.logger)
+
+	return a.doSubscribe(ctx, req, sub, impl.GetBulkPubSubHandlerFunc(req.Topic, a.dedup.BulkHandler(handler), a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second))
+}
+
+// subscriptionOptions returns the options for a subscription to the queue, applying the overrides in the request metadata.
+func (a *azureServiceBus) subscriptionOptions(req pubsub.SubscribeRequest) (impl.SubscriptionOptions, error) {
+	opts, err := a.metadata.SubscriptionOptions(req.Metadata)
+	if err != nil {
+		return impl.SubscriptionOptions{}, err
+	}
+	opts.Entity = "queue " + req.Topic
+	if opts.ConcurrencyMode == impl.ConcurrencyModeAdaptive {
+		opts.ActiveMessageCountFn = func(ctx context.Context) (int64, error) {
+			return a.client.GetQueueActiveMessageCount(ctx, req.Topic)
+		}
+	}
+	return opts, nil
 }
 
 // doSubscribe is a helper function that handles the common logic for both Subscribe and BulkSubscribe.
@@ -216,7 +258,7 @@ func (a *azureServiceBus) Close() (err error) {
 
 	a.client.CloseAllSenders(a.logger)
 
-	return nil
+	return a.dedup.Close()
 }
 
 func (a *azureServiceBus) Features() []pubsub.Feature {
@@ -26,7 +26,9 @@ import (
 	commonutils "github.com/dapr/components-contrib/common/utils"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 	"github.com/dapr/kit/strings"
 )
 
@@ -36,12 +38,16 @@ const (
 )
 
 type azureServiceBus struct {
-	metadata *impl.Metadata
-	client   *impl.Client
-	logger   logger.Logger
-	closed   atomic.Bool
-	closeCh  chan struct{}
-	wg       sync.WaitGroup
+	metadata       *impl.Metadata
+	client         *impl.Client
+	topicPrefix    pubsub.TopicPrefix
+	publishLimiter pubsub.PublishRateLimiter
+	deadLetter     pubsub.DeadLetterPolicy
+	dedup          *pubsub.Deduplicator
+	logger         logger.Logger
+	closed         atomic.Bool
+	closeCh        chan struct{}
+	wg             sync.WaitGroup
 }
 
 // NewAzureServiceBusTopics returns a new pub-sub implementation.
@@ -52,12 +58,38 @@ func NewAzureServiceBusTopics(logger logger.Logger) pubsub.PubSub {
 	}
 }
 
-func (a *azureServiceBus) Init(_ context.Context, metadata pubsub.Metadata) (err error) {
+func (a *azureServiceBus) Init(ctx context.Context, metadata pubsub.Metadata) (err error) {
+	metadata.Properties, err = pubsub.ExpandNamespaceProperties(metadata.Properties, pubsub.RuntimeConsumerIDKey)
+	if err != nil {
+		return err
+	}
+	a.topicPrefix, err = pubsub.NewTopicPrefix(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	a.publishLimiter, err = pubsub.NewPublishRateLimiter(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	a.dedup, err = deduplication.New(ctx, metadata.Properties, a.logger)
+	if err != nil {
+		return err
+	}
+
 	a.metadata, err = impl.ParseMetadata(metadata.Properties, a.logger, impl.MetadataModeTopics)
 	if err != nil {
 		return err
 	}
 
+	// Messages are dead-lettered natively: maxDeliveryCount and the forwarding of dead-lettered messages take precedence
+	a.deadLetter, err = pubsub.NewDeadLetterPolicy(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	if a.deadLetter.Enabled() && a.metadata.MaxDeliveryCount == nil {
+		a.metadata.MaxDeliveryCount = ptr.Of(int32(a.deadLetter.MaxDeliveries)) //nolint:gosec
+	}
+
 	a.client, err = impl.NewClient(a.metadata, metadata.Properties)
 	if err != nil {
 		return err
@@ -70,14 +102,60 @@ func (a *azureServiceBus) Publish(ctx context.Context, req *pubsub.PublishReques
 	if a.closed.Load() {
 		return errors.New("component is closed")
 	}
-	return a.client.PublishPubSub(ctx, req, a.client.EnsureTopic, a.logger)
+	if err := a.publishLimiter.Wait(ctx, 1); err != nil {
+		return err
+	}
+	prefixedReq := *req
+	prefixedReq.Topic = a.topicPrefix.Topic(req.Topic)
+	return a.client.PublishPubSub(ctx, &prefixedReq, a.client.EnsureTopic, a.logger)
 }
 
 func (a *azureServiceBus) BulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
 	if a.closed.Load() {
 		return pubsub.BulkPublishResponse{}, errors.New("component is closed")
 	}
-	return a.client.PublishPubSubBulk(ctx, req, a.client.EnsureTopic, a.logger)
+	if err := a.publishLimiter.Wait(ctx, len(req.Entries)); err != nil {
+		return pubsub.NewBulkPublishResponse(req.Entries, err), err
+	}
+	prefixedReq := *req
+	prefixedReq.Topic = a.topicPrefix.Topic(req.Topic)
+	return a.client.PublishPubSubBulk(ctx, &prefixedReq, a.client.EnsureTopic, a.logger)
+}
+
+// ScheduleMessage schedules a message on the topic, to be enqueued at the time set in the ScheduledEnqueueTimeUtc
+// metadata property. It returns the sequence number of the message, which can be used to cancel it.
+func (a *azureServiceBus) ScheduleMessage(ctx context.Context, req *pubsub.PublishRequest) (int64, error) {
+	if a.closed.Load() {
+		return 0, errors.New("component is closed")
+	}
+	prefixedReq := *req
+	prefixedReq.Topic = a.topicPrefix.Topic(req.Topic)
+	return a.client.SchedulePubSub(ctx, &prefixedReq, a.client.EnsureTopic)
+}
+
+// CancelScheduledMessages cancels messages scheduled on the topic, by sequence number.
+func (a *azureServiceBus) CancelScheduledMessages(ctx context.Context, topic string, sequenceNumbers ...int64) error {
+	if a.closed.Load() {
+		return errors.New("component is closed")
+	}
+	return a.client.CancelScheduledMessages(ctx, a.topicPrefix.Topic(topic), sequenceNumbers)
+}
+
+// HandleDeferredMessages invokes the handler for messages of the subscription to the topic that were deferred by
+// returning impl.ErrDeferMessage, by sequence number.
+func (a *azureServiceBus) HandleDeferredMessages(ctx context.Context, topic string, handler pubsub.Handler, sequenceNumbers ...int64) error {
+	if a.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	topic = a.topicPrefix.Topic(topic)
+	receiver, err := a.client.GetClient().NewReceiverForSubscription(topic, a.metadata.ConsumerID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create receiver for subscription %s to topic %s: %w", a.metadata.ConsumerID, topic, err)
+	}
+
+	handlerFn := impl.GetPubSubHandlerFunc(topic, a.topicPrefix.Handler(handler), a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second)
+	return a.client.HandleDeferredMessages(ctx, receiver, sequenceNumbers, handlerFn, a.logger)
 }
 
 func (a *azureServiceBus) Subscribe(subscribeCtx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
@@ -85,24 +163,20 @@ func (a *azureServiceBus) Subscribe(subscribeCtx context.Context, req pubsub.Sub
 		return errors.New("component is closed")
 	}
 
+	req.Topic = a.topicPrefix.Topic(req.Topic)
+	handler = a.deadLetter.BackoffHandler(a.topicPrefix.Handler(a.dedup.Handler(handler)))
+
 	requireSessions := strings.IsTruthy(req.Metadata[impl.RequireSessionsMetadataKey])
 	sessionIdleTimeout := time.Duration(commonutils.GetElemOrDefaultFromMap(req.Metadata, impl.SessionIdleTimeoutMetadataKey, impl.DefaultSesssionIdleTimeoutInSec)) * time.Second
 	maxConcurrentSessions := commonutils.GetElemOrDefaultFromMap(req.Metadata, impl.MaxConcurrentSessionsMetadataKey, impl.DefaultMaxConcurrentSessions)
 
-	sub := impl.NewSubscription(
-		impl.SubscriptionOptions{
-			MaxActiveMessages:     a.metadata.MaxActiveMessages,
-			TimeoutInSec:          a.metadata.TimeoutInSec,
-			MaxBulkSubCount:       nil,
-			MaxRetriableEPS:       a.metadata.MaxRetriableErrorsPerSec,
-			MaxConcurrentHandlers: a.metadata.MaxConcurrentHandlers,
-			Entity:                "topic " + req.Topic,
-			LockRenewalInSec:      a.metadata.LockRenewalInSec,
-			RequireSessions:       requireSessions,
-			SessionIdleTimeout:    sessionIdleTimeout,
-		},
-		a.logger,
-	)
+	opts, err := a.subscriptionOptions(req)
+	if err != nil {
+		return err
+	}
+	opts.RequireSessions = requireSessions
+	opts.SessionIdleTimeout = sessionIdleTimeout
+	sub := impl.NewSubscription(opts, a.logger)
 
 	handlerFn := impl.GetPubSubHandlerFunc(req.Topic, handler, a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second)
 	return a.doSubscribe(subscribeCtx, req, sub, handlerFn, impl.SubscribeOptions{
@@ -116,25 +190,22 @@ func (a *azureServiceBus) BulkSubscribe(subscribeCtx context.Context, req pubsub
 		return errors.New("component is closed")
 	}
 
+	req.Topic = a.topicPrefix.Topic(req.Topic)
+	handler = a.topicPrefix.BulkHandler(a.dedup.BulkHandler(handler))
+
 	requireSessions := strings.IsTruthy(req.Metadata[impl.RequireSessionsMetadataKey])
 	sessionIdleTimeout := time.Duration(commonutils.GetElemOrDefaultFromMap(req.Metadata, impl.SessionIdleTimeoutMetadataKey, impl.DefaultSesssionIdleTimeoutInSec)) * time.Second
 	maxConcurrentSessions := commonutils.GetElemOrDefaultFromMap(req.Metadata, impl.MaxConcurrentSessionsMetadataKey, impl.DefaultMaxConcurrentSessions)
 
 	maxBulkSubCount := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxMessagesCount, defaultMaxBulkSubCount)
-	sub := impl.NewSubscription(
-		impl.SubscriptionOptions{
-			MaxActiveMessages:     a.metadata.MaxActiveMessages,
-			TimeoutInSec:          a.metadata.TimeoutInSec,
-			MaxBulkSubCount:       &maxBulkSubCount,
-			MaxRetriableEPS:       a.metadata.MaxRetriableErrorsPerSec,
-			MaxConcurrentHandlers: a.metadata.MaxConcurrentHandlers,
-			Entity:                "topic " + req.Topic,
-			LockRenewalInSec:      a.metadata.LockRenewalInSec,
-			RequireSessions:       requireSessions,
-			SessionIdleTimeout:    sessionIdleTimeout,
-		},
-		a.logger,
-	)
+	opts, err := a.subscriptionOptions(req)
+	if err != nil {
+		return err
+	}
+	opts.MaxBulkSubCount = &maxBulkSubCount
+	opts.RequireSessions = requireSessions
+	opts.SessionIdleTimeout = sessionIdleTimeout
+	sub := impl.NewSubscription(opts, a.logger)
 
 	handlerFn := impl.GetBulkPubSubHandlerFunc(req.Topic, handler, a.logger, time.Duration(a.metadata.HandlerTimeoutInSec)*time.Second)
 	return a.doSubscribe(subscribeCtx, req, sub, handlerFn, impl.SubscribeOptions{
@@ -143,6 +214,21 @@ func (a *azureServiceBus) BulkSubscribe(subscribeCtx context.Context, req pubsub
 	})
 }
 
+// subscriptionOptions returns the options for a subscription to the topic, applying the overrides in the request metadata.
+func (a *azureServiceBus) subscriptionOptions(req pubsub.SubscribeRequest) (impl.SubscriptionOptions, error) {
+	opts, err := a.metadata.SubscriptionOptions(req.Metadata)
+	if err != nil {
+		return impl.SubscriptionOptions{}, err
+	}
+	opts.Entity = "topic " + req.Topic
+	if opts.ConcurrencyMode == impl.ConcurrencyModeAdaptive {
+		opts.ActiveMessageCountFn = func(ctx context.Context) (int64, error) {
+			return a.client.GetSubscriptionActiveMessageCount(ctx, a.metadata.ConsumerID, req.Topic)
+		}
+	}
+	return opts, nil
+}
+
 // doSubscribe is a helper function that handles the common logic for both Subscribe and BulkSubscribe.
 // The receiveAndBlockFn is a function should invoke a blocking call to receive messages from the topic.
 func (a *azureServiceBus) doSubscribe(
@@ -152,6 +238,14 @@ func (a *azureServiceBus) doSubscribe(
 	handlerFn impl.HandlerFn,
 	opts impl.SubscribeOptions,
 ) error {
+	err := opts.ParseSubscriptionEntityMetadata(req.Metadata)
+	if err != nil {
+		return err
+	}
+	if opts.ForwardDeadLetteredMessagesTo == "" {
+		opts.ForwardDeadLetteredMessagesTo = a.deadLetter.DeadLetterTopic
+	}
+
 	subscribeCtx, cancel := context.WithCancel(parentCtx)
 	a.wg.Add(1)
 	go func() {
@@ -164,7 +258,7 @@ func (a *azureServiceBus) doSubscribe(
 	}()
 
 	// Does nothing if DisableEntityManagement is true
-	err := a.client.EnsureSubscription(subscribeCtx, a.metadata.ConsumerID, req.Topic, opts)
+	err = a.client.EnsureSubscription(subscribeCtx, a.metadata.ConsumerID, req.Topic, opts)
 	if err != nil {
 		return err
 	}
@@ -199,6 +293,11 @@ func (a *azureServiceBus) doSubscribe(
 				a.logger.Debug("Context canceled; will not reconnect")
 				return
 			}
+
+			// Restore the subscription and its rules in case they were changed or deleted while disconnected
+			if eErr := a.client.EnsureSubscription(subscribeCtx, a.metadata.ConsumerID, req.Topic, opts); eErr != nil {
+				a.logger.Warnf("Failed to reconcile subscription %s to topic %s: %v", a.metadata.ConsumerID, req.Topic, eErr)
+			}
 		}
 	}()
 
@@ -214,7 +313,7 @@ func (a *azureServiceBus) Close() (err error) {
 	close(a.closeCh)
 
 	a.client.Close(a.logger)
-	return nil
+	return a.dedup.Close()
 }
 
 func (a *azureServiceBus) Features() []pubsub.Feature {
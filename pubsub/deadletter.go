@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	// MaxDeliveriesKey is the metadata property with the maximum number of times a message is delivered to the app
+	// before it's dead-lettered.
+	MaxDeliveriesKey = "maxDeliveries"
+
+	// DeadLetterTopicKey is the metadata property with the topic messages are moved to after MaxDeliveries failed
+	// deliveries. The topic prefix is not applied to it.
+	// If it's not set, the messages are discarded, or dead-lettered with the native mechanism of the broker.
+	DeadLetterTopicKey = "deadLetterTopic"
+
+	// RedeliveryBackoffKey is the metadata property with the time to wait before a failed message is delivered again.
+	RedeliveryBackoffKey = "redeliveryBackoff"
+
+	// Metadata of the messages published to the dead-letter topic, besides the metadata of the message.
+	DeadLetterOriginalTopicKey = "deadLetterOriginalTopic"
+	DeadLetterDeliveriesKey    = "deadLetterDeliveries"
+	DeadLetterErrorKey         = "deadLetterError"
+)
+
+// DeadLetterPolicy is the dead-letter contract shared by the components: messages that failed to be processed
+// MaxDeliveries times are moved to DeadLetterTopic, waiting Backoff between deliveries.
+// Components map it to the native features of the broker where available, and republish the messages to the
+// dead-letter topic otherwise.
+// The zero value disables the policy.
+type DeadLetterPolicy struct {
+	MaxDeliveries   int           `mapstructure:"maxDeliveries"`
+	DeadLetterTopic string        `mapstructure:"deadLetterTopic"`
+	Backoff         time.Duration `mapstructure:"redeliveryBackoff"`
+}
+
+// NewDeadLetterPolicy returns the DeadLetterPolicy configured with the maxDeliveries, deadLetterTopic and
+// redeliveryBackoff metadata properties.
+func NewDeadLetterPolicy(props map[string]string) (DeadLetterPolicy, error) {
+	var p DeadLetterPolicy
+	err := kitmd.DecodeMetadata(props, &p)
+	if err != nil {
+		return DeadLetterPolicy{}, fmt.Errorf("invalid dead-letter metadata: %w", err)
+	}
+
+	if p.MaxDeliveries < 0 || p.Backoff < 0 {
+		return DeadLetterPolicy{}, fmt.Errorf("invalid dead-letter policy: %s and %s must not be negative", MaxDeliveriesKey, RedeliveryBackoffKey)
+	}
+	if p.DeadLetterTopic != "" && p.MaxDeliveries == 0 {
+		return DeadLetterPolicy{}, fmt.Errorf("invalid dead-letter policy: %s requires %s", DeadLetterTopicKey, MaxDeliveriesKey)
+	}
+	return p, nil
+}
+
+// Enabled returns true if the number of deliveries of the messages is limited.
+func (p DeadLetterPolicy) Enabled() bool {
+	return p.MaxDeliveries > 0
+}
+
+// Exhausted returns true if a message that was delivered the given number of times must not be delivered again.
+func (p DeadLetterPolicy) Exhausted(deliveries int) bool {
+	return p.Enabled() && deliveries >= p.MaxDeliveries
+}
+
+// WaitBackoff waits for the redelivery backoff, or until the context is done.
+// It's meant for components whose broker can't delay redeliveries.
+func (p DeadLetterPolicy) WaitBackoff(ctx context.Context) {
+	if p.Backoff <= 0 {
+		return
+	}
+
+	t := time.NewTimer(p.Backoff)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
+
+// BackoffHandler returns a handler that waits for the redelivery backoff before returning the errors of handler.
+// It's meant for components whose broker redelivers failed messages immediately.
+func (p DeadLetterPolicy) BackoffHandler(handler Handler) Handler {
+	if p.Backoff <= 0 {
+		return handler
+	}
+
+	return func(ctx context.Context, msg *NewMessage) error {
+		err := handler(ctx, msg)
+		if err != nil {
+			p.WaitBackoff(ctx)
+		}
+		return err
+	}
+}
+
+// DeadLetterRequest returns the request publishing a message that failed the given number of times to the
+// dead-letter topic.
+func (p DeadLetterPolicy) DeadLetterRequest(msg *NewMessage, deliveries int, cause error) *PublishRequest {
+	md := make(map[string]string, len(msg.Metadata)+3)
+	maps.Copy(md, msg.Metadata)
+	md[DeadLetterOriginalTopicKey] = msg.Topic
+	md[DeadLetterDeliveriesKey] = strconv.Itoa(deliveries)
+	if cause != nil {
+		md[DeadLetterErrorKey] = cause.Error()
+	}
+
+	return &PublishRequest{
+		Data:        msg.Data,
+		Topic:       p.DeadLetterTopic,
+		Metadata:    md,
+		ContentType: msg.ContentType,
+	}
+}
+
+// HandleFailure applies the policy to a message that failed with err after the given number of deliveries.
+// It returns err if the message must be delivered again. Otherwise, it publishes the message to the dead-letter
+// topic if one is set, and returns nil so the message is acknowledged; if publishing fails, the message must be
+// delivered again.
+func (p DeadLetterPolicy) HandleFailure(ctx context.Context, msg *NewMessage, deliveries int, err error, publish func(ctx context.Context, req *PublishRequest) error) error {
+	if err == nil || !p.Exhausted(deliveries) {
+		return err
+	}
+	if p.DeadLetterTopic == "" {
+		return nil
+	}
+
+	pErr := publish(ctx, p.DeadLetterRequest(msg, deliveries, err))
+	if pErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to publish the message to dead-letter topic %s: %w", p.DeadLetterTopic, pErr))
+	}
+	return nil
+}
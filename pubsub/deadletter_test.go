@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestNewDeadLetterPolicy(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		p, err := NewDeadLetterPolicy(map[string]string{})
+		require.NoError(t, err)
+		assert.False(t, p.Enabled())
+		assert.False(t, p.Exhausted(100))
+	})
+
+	t.Run("valid metadata", func(t *testing.T) {
+		p, err := NewDeadLetterPolicy(map[string]string{
+			MaxDeliveriesKey:     "3",
+			DeadLetterTopicKey:   "orders-dlq",
+			RedeliveryBackoffKey: "5s",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, DeadLetterPolicy{MaxDeliveries: 3, DeadLetterTopic: "orders-dlq", Backoff: 5 * time.Second}, p)
+		assert.False(t, p.Exhausted(2))
+		assert.True(t, p.Exhausted(3))
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{MaxDeliveriesKey: "-1"},
+			{MaxDeliveriesKey: "3", RedeliveryBackoffKey: "-1s"},
+			{DeadLetterTopicKey: "orders-dlq"},
+			{MaxDeliveriesKey: "many"},
+		} {
+			_, err := NewDeadLetterPolicy(props)
+			require.Error(t, err, props)
+		}
+	})
+}
+
+func TestDeadLetterPolicyHandleFailure(t *testing.T) {
+	msg := &NewMessage{
+		Topic:       "orders",
+		Data:        []byte("hello"),
+		Metadata:    map[string]string{"key": "value"},
+		ContentType: ptr.Of("text/plain"),
+	}
+	handlerErr := errors.New("failed")
+
+	var published []*PublishRequest
+	publish := func(ctx context.Context, req *PublishRequest) error {
+		published = append(published, req)
+		return nil
+	}
+
+	t.Run("redelivered until exhausted", func(t *testing.T) {
+		published = nil
+		p := DeadLetterPolicy{MaxDeliveries: 3, DeadLetterTopic: "orders-dlq"}
+
+		require.ErrorIs(t, p.HandleFailure(t.Context(), msg, 2, handlerErr, publish), handlerErr)
+		assert.Empty(t, published)
+
+		require.NoError(t, p.HandleFailure(t.Context(), msg, 3, handlerErr, publish))
+		require.Len(t, published, 1)
+		assert.Equal(t, &PublishRequest{
+			Topic: "orders-dlq",
+			Data:  []byte("hello"),
+			Metadata: map[string]string{
+				"key":                      "value",
+				DeadLetterOriginalTopicKey: "orders",
+				DeadLetterDeliveriesKey:    "3",
+				DeadLetterErrorKey:         "failed",
+			},
+			ContentType: ptr.Of("text/plain"),
+		}, published[0])
+		assert.Equal(t, map[string]string{"key": "value"}, msg.Metadata)
+	})
+
+	t.Run("discarded without a dead-letter topic", func(t *testing.T) {
+		published = nil
+		p := DeadLetterPolicy{MaxDeliveries: 1}
+
+		require.NoError(t, p.HandleFailure(t.Context(), msg, 1, handlerErr, publish))
+		assert.Empty(t, published)
+	})
+
+	t.Run("redelivered if publishing fails", func(t *testing.T) {
+		p := DeadLetterPolicy{MaxDeliveries: 1, DeadLetterTopic: "orders-dlq"}
+
+		err := p.HandleFailure(t.Context(), msg, 1, handlerErr, func(ctx context.Context, req *PublishRequest) error {
+			return errors.New("broker unavailable")
+		})
+		require.ErrorIs(t, err, handlerErr)
+		assert.ErrorContains(t, err, "broker unavailable")
+	})
+
+	t.Run("disabled policy", func(t *testing.T) {
+		require.ErrorIs(t, DeadLetterPolicy{}.HandleFailure(t.Context(), msg, 100, handlerErr, publish), handlerErr)
+	})
+}
+
+func TestDeadLetterPolicyWaitBackoff(t *testing.T) {
+	p := DeadLetterPolicy{Backoff: time.Hour}
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	p.WaitBackoff(ctx)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDeadLetterPolicyBackoffHandler(t *testing.T) {
+	p := DeadLetterPolicy{Backoff: 50 * time.Millisecond}
+	handler := p.BackoffHandler(func(ctx context.Context, msg *NewMessage) error {
+		if string(msg.Data) == "fail" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	start := time.Now()
+	require.NoError(t, handler(t.Context(), &NewMessage{Data: []byte("ok")}))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	start = time.Now()
+	require.Error(t, handler(t.Context(), &NewMessage{Data: []byte("fail")}))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
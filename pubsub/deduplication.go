@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dapr/kit/logger"
+)
+
+// DeduplicationStore records the IDs of the received messages.
+type DeduplicationStore interface {
+	// Add records the ID for the deduplication window, and returns false if it was recorded already.
+	Add(ctx context.Context, id string) (bool, error)
+	// Remove forgets the ID, so the message is processed if it's received again.
+	Remove(ctx context.Context, id string) error
+	Close() error
+}
+
+// Deduplicator drops the messages whose CloudEvent ID was received within the deduplication window, so handlers
+// don't process duplicates delivered by the broker or published more than once.
+// Messages that aren't CloudEvents are never dropped.
+// The zero value and nil don't drop any message.
+type Deduplicator struct {
+	store      DeduplicationStore
+	consumerID string
+	log        logger.Logger
+}
+
+// NewDeduplicator returns a Deduplicator that records the IDs in store.
+// The deduplication package creates the stores configured with the deduplication metadata properties.
+func NewDeduplicator(store DeduplicationStore, consumerID string, log logger.Logger) *Deduplicator {
+	return &Deduplicator{
+		store:      store,
+		consumerID: consumerID,
+		log:        log,
+	}
+}
+
+// Handler returns a Handler that drops duplicate messages before invoking handler.
+// The ID of a message that fails processing is forgotten, so the message is processed when it's redelivered.
+func (d *Deduplicator) Handler(handler Handler) Handler {
+	if d == nil || d.store == nil {
+		return handler
+	}
+	return func(ctx context.Context, msg *NewMessage) error {
+		key, ok := d.reserve(ctx, msg.Topic, msg.Data)
+		if !ok {
+			return nil
+		}
+		err := handler(ctx, msg)
+		if err != nil && key != "" {
+			d.release(ctx, key)
+		}
+		return err
+	}
+}
+
+// BulkHandler returns a BulkHandler that drops duplicate messages before invoking handler.
+// Duplicate entries are reported as processed successfully.
+func (d *Deduplicator) BulkHandler(handler BulkHandler) BulkHandler {
+	if d == nil || d.store == nil {
+		return handler
+	}
+	return func(ctx context.Context, msg *BulkMessage) ([]BulkSubscribeResponseEntry, error) {
+		entries := make([]BulkMessageEntry, 0, len(msg.Entries))
+		keys := make(map[string]string, len(msg.Entries))
+		for _, e := range msg.Entries {
+			key, ok := d.reserve(ctx, msg.Topic, e.Event)
+			if !ok {
+				continue
+			}
+			entries = append(entries, e)
+			if key != "" {
+				keys[e.EntryId] = key
+			}
+		}
+
+		all := msg.Entries
+		msg.Entries = entries
+		var (
+			res []BulkSubscribeResponseEntry
+			err error
+		)
+		if len(entries) > 0 {
+			res, err = handler(ctx, msg)
+		}
+		msg.Entries = all
+
+		// Forget the IDs of the entries that failed
+		failed := make(map[string]error, len(res))
+		for _, r := range res {
+			if r.Error != nil {
+				failed[r.EntryId] = r.Error
+			}
+		}
+		if err != nil && len(res) == 0 {
+			for _, e := range entries {
+				failed[e.EntryId] = err
+			}
+		}
+		for id := range failed {
+			if key, ok := keys[id]; ok {
+				d.release(ctx, key)
+			}
+		}
+
+		if err == nil {
+			return res, nil
+		}
+		statuses := make([]BulkSubscribeResponseEntry, len(all))
+		for i, e := range all {
+			statuses[i] = BulkSubscribeResponseEntry{EntryId: e.EntryId, Error: failed[e.EntryId]}
+		}
+		return statuses, err
+	}
+}
+
+// Close closes the store.
+func (d *Deduplicator) Close() error {
+	if d == nil || d.store == nil {
+		return nil
+	}
+	return d.store.Close()
+}
+
+// reserve records the ID of a message, and returns false if the message is a duplicate.
+// The returned key is empty if the message is not a CloudEvent or it could not be recorded.
+func (d *Deduplicator) reserve(ctx context.Context, topic string, data []byte) (string, bool) {
+	var ce struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(data, &ce) != nil || ce.ID == "" {
+		return "", true
+	}
+
+	key := d.consumerID + "||" + topic + "||" + ce.ID
+	added, err := d.store.Add(ctx, key)
+	if err != nil {
+		// Processing a duplicate is better than losing the message
+		d.log.Warnf("Could not record the ID of message %s on topic %s for deduplication: %v", ce.ID, topic, err)
+		return "", true
+	}
+	if !added {
+		d.log.Debugf("Dropping duplicate message %s on topic %s", ce.ID, topic)
+		return "", false
+	}
+	return key, true
+}
+
+func (d *Deduplicator) release(ctx context.Context, key string) {
+	err := d.store.Remove(ctx, key)
+	if err != nil {
+		d.log.Warnf("Could not remove the ID of a failed message from the deduplication store: %v", err)
+	}
+}
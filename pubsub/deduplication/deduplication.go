@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deduplication implements the stores of the IDs of the messages received by pubsub components, and
+// configures the deduplication of the messages with the deduplication metadata properties.
+package deduplication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	// WindowKey is the metadata property that enables deduplication, dropping the messages with the same CloudEvent
+	// ID received within the window.
+	WindowKey = "deduplicationWindow"
+
+	// StoreKey is the metadata property with the store of the IDs of the received messages: "memory" (default) or
+	// "redis".
+	// The Redis store is configured with the Redis metadata properties prefixed with "deduplication", for example
+	// "deduplicationRedisHost" and "deduplicationRedisPassword".
+	StoreKey = "deduplicationStore"
+
+	// CacheSizeKey is the metadata property with the maximum number of IDs kept by the memory store.
+	CacheSizeKey = "deduplicationCacheSize"
+
+	storeMemory = "memory"
+	storeRedis  = "redis"
+
+	defaultCacheSize = 10000
+)
+
+type deduplicationMetadata struct {
+	DeduplicationWindow    time.Duration `mapstructure:"deduplicationWindow"`
+	DeduplicationStore     string        `mapstructure:"deduplicationStore"`
+	DeduplicationCacheSize int           `mapstructure:"deduplicationCacheSize"`
+	ConsumerID             string        `mapstructure:"consumerID"`
+}
+
+// New returns a Deduplicator configured with the deduplication metadata properties.
+// The Deduplicator doesn't drop any message if the deduplicationWindow property is not set.
+func New(ctx context.Context, props map[string]string, log logger.Logger) (*pubsub.Deduplicator, error) {
+	m := deduplicationMetadata{
+		DeduplicationStore:     storeMemory,
+		DeduplicationCacheSize: defaultCacheSize,
+	}
+	err := kitmd.DecodeMetadata(props, &m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deduplication metadata: %w", err)
+	}
+	if m.DeduplicationWindow < 0 {
+		return nil, fmt.Errorf("invalid %s: must not be negative", WindowKey)
+	}
+	if m.DeduplicationWindow == 0 {
+		return &pubsub.Deduplicator{}, nil
+	}
+
+	var store pubsub.DeduplicationStore
+	switch m.DeduplicationStore {
+	case storeMemory:
+		if m.DeduplicationCacheSize <= 0 {
+			return nil, fmt.Errorf("invalid %s: must be positive", CacheSizeKey)
+		}
+		store, err = NewMemoryStore(m.DeduplicationCacheSize, m.DeduplicationWindow)
+	case storeRedis:
+		store, err = newRedisStoreFromProperties(ctx, props, m.DeduplicationWindow, log)
+	default:
+		return nil, fmt.Errorf("invalid %s %q, expected %q or %q", StoreKey, m.DeduplicationStore, storeMemory, storeRedis)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not create deduplication store: %w", err)
+	}
+
+	return pubsub.NewDeduplicator(store, m.ConsumerID, log), nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deduplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+)
+
+func cloudEvent(id string) []byte {
+	return []byte(`{"specversion":"1.0","id":"` + id + `","source":"app","type":"com.dapr.event.sent","data":"hello"}`)
+}
+
+func TestNew(t *testing.T) {
+	log := logger.NewLogger("test")
+
+	t.Run("disabled by default", func(t *testing.T) {
+		d, err := New(t.Context(), map[string]string{}, log)
+		require.NoError(t, err)
+
+		calls := 0
+		handler := d.Handler(func(ctx context.Context, msg *pubsub.NewMessage) error {
+			calls++
+			return nil
+		})
+		msg := &pubsub.NewMessage{Topic: "orders", Data: cloudEvent("1")}
+		require.NoError(t, handler(t.Context(), msg))
+		require.NoError(t, handler(t.Context(), msg))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{WindowKey: "-1m"},
+			{WindowKey: "1m", StoreKey: "etcd"},
+			{WindowKey: "1m", CacheSizeKey: "0"},
+		} {
+			_, err := New(t.Context(), props, log)
+			require.Error(t, err, props)
+		}
+	})
+
+	t.Run("redis store", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		d, err := New(t.Context(), map[string]string{
+			WindowKey:                "1m",
+			StoreKey:                 "redis",
+			"deduplicationRedisHost": s.Addr(),
+			"consumerID":             "myapp",
+		}, log)
+		require.NoError(t, err)
+		defer d.Close()
+
+		calls := 0
+		handler := d.Handler(func(ctx context.Context, msg *pubsub.NewMessage) error {
+			calls++
+			return nil
+		})
+		msg := &pubsub.NewMessage{Topic: "orders", Data: cloudEvent("1")}
+		require.NoError(t, handler(t.Context(), msg))
+		require.NoError(t, handler(t.Context(), msg))
+		assert.Equal(t, 1, calls)
+		assert.True(t, s.Exists("dedup||myapp||orders||1"))
+
+		s.FastForward(time.Minute)
+		require.NoError(t, handler(t.Context(), msg))
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	store, err := NewMemoryStore(2, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	added, _ := store.Add(t.Context(), "1")
+	assert.True(t, added)
+	added, _ = store.Add(t.Context(), "1")
+	assert.False(t, added)
+
+	// IDs expire after the window
+	time.Sleep(60 * time.Millisecond)
+	added, _ = store.Add(t.Context(), "1")
+	assert.True(t, added)
+
+	// The least recently added IDs are evicted when the cache is full
+	store.Add(t.Context(), "2")
+	store.Add(t.Context(), "3")
+	added, _ = store.Add(t.Context(), "1")
+	assert.True(t, added)
+}
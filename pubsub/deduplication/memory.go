@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deduplication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// memoryStore keeps the IDs in memory, evicting the least recently added when the cache is full.
+type memoryStore struct {
+	lock   sync.Mutex
+	cache  *simplelru.LRU[string, time.Time]
+	window time.Duration
+}
+
+// NewMemoryStore returns a store that keeps up to size IDs in memory.
+func NewMemoryStore(size int, window time.Duration) (pubsub.DeduplicationStore, error) {
+	cache, err := simplelru.NewLRU[string, time.Time](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryStore{
+		cache:  cache,
+		window: window,
+	}, nil
+}
+
+func (s *memoryStore) Add(_ context.Context, id string) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	if expires, ok := s.cache.Peek(id); ok && now.Before(expires) {
+		return false, nil
+	}
+	s.cache.Add(id, now.Add(s.window))
+	return true, nil
+}
+
+func (s *memoryStore) Remove(_ context.Context, id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cache.Remove(id)
+	return nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
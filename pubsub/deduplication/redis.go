@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deduplication
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+)
+
+// redisStore keeps the IDs in Redis keys that expire after the deduplication window, so they're shared
+// by all the instances of the app.
+type redisStore struct {
+	client rediscomponent.RedisClient
+	window time.Duration
+}
+
+// NewRedisStore returns a store that keeps the IDs in Redis.
+func NewRedisStore(client rediscomponent.RedisClient, window time.Duration) pubsub.DeduplicationStore {
+	return &redisStore{
+		client: client,
+		window: window,
+	}
+}
+
+func newRedisStoreFromProperties(ctx context.Context, props map[string]string, window time.Duration, log logger.Logger) (pubsub.DeduplicationStore, error) {
+	// Use the properties prefixed with "deduplication", without the prefix
+	redisProps := make(map[string]string)
+	for k, v := range props {
+		if rest, ok := strings.CutPrefix(k, "deduplication"); ok && rest != "" {
+			redisProps[strings.ToLower(rest[:1])+rest[1:]] = v
+		}
+	}
+
+	client, _, err := rediscomponent.ParseClientFromProperties(redisProps, metadata.StateStoreType, ctx, &log)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisStore(client, window), nil
+}
+
+func (s *redisStore) Add(ctx context.Context, id string) (bool, error) {
+	added, err := s.client.SetNX(ctx, "dedup||"+id, 1, s.window)
+	if err != nil {
+		return false, err
+	}
+	return added != nil && *added, nil
+}
+
+func (s *redisStore) Remove(ctx context.Context, id string) error {
+	return s.client.Del(ctx, "dedup||"+id)
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
+)
+
+func cloudEvent(id string) []byte {
+	return []byte(`{"specversion":"1.0","id":"` + id + `","source":"app","type":"com.dapr.event.sent","data":"hello"}`)
+}
+
+// fakeDeduplicationStore keeps the IDs in a map, without expiring them.
+type fakeDeduplicationStore struct {
+	ids map[string]bool
+}
+
+func (s *fakeDeduplicationStore) Add(_ context.Context, id string) (bool, error) {
+	if s.ids[id] {
+		return false, nil
+	}
+	s.ids[id] = true
+	return true, nil
+}
+
+func (s *fakeDeduplicationStore) Remove(_ context.Context, id string) error {
+	delete(s.ids, id)
+	return nil
+}
+
+func (s *fakeDeduplicationStore) Close() error {
+	return nil
+}
+
+func TestDeduplicatorHandler(t *testing.T) {
+	d := NewDeduplicator(&fakeDeduplicationStore{ids: map[string]bool{}}, "myapp", logger.NewLogger("test"))
+
+	var received []string
+	fail := true
+	handler := d.Handler(func(ctx context.Context, msg *NewMessage) error {
+		received = append(received, string(msg.Data))
+		if fail {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	// Failed messages are processed again when they're redelivered
+	require.Error(t, handler(t.Context(), &NewMessage{Topic: "orders", Data: cloudEvent("1")}))
+	fail = false
+	require.NoError(t, handler(t.Context(), &NewMessage{Topic: "orders", Data: cloudEvent("1")}))
+	require.NoError(t, handler(t.Context(), &NewMessage{Topic: "orders", Data: cloudEvent("1")}))
+	assert.Len(t, received, 2)
+
+	// The same ID on another topic is not a duplicate
+	require.NoError(t, handler(t.Context(), &NewMessage{Topic: "payments", Data: cloudEvent("1")}))
+	assert.Len(t, received, 3)
+
+	// Messages that are not CloudEvents are never dropped
+	require.NoError(t, handler(t.Context(), &NewMessage{Topic: "orders", Data: []byte("raw")}))
+	require.NoError(t, handler(t.Context(), &NewMessage{Topic: "orders", Data: []byte("raw")}))
+	assert.Len(t, received, 5)
+}
+
+func TestDeduplicatorBulkHandler(t *testing.T) {
+	d := NewDeduplicator(&fakeDeduplicationStore{ids: map[string]bool{}}, "myapp", logger.NewLogger("test"))
+
+	var received []string
+	handler := d.BulkHandler(func(ctx context.Context, msg *BulkMessage) ([]BulkSubscribeResponseEntry, error) {
+		res := make([]BulkSubscribeResponseEntry, len(msg.Entries))
+		var err error
+		for i, e := range msg.Entries {
+			received = append(received, e.EntryId)
+			res[i] = BulkSubscribeResponseEntry{EntryId: e.EntryId}
+			if e.EntryId == "c" {
+				res[i].Error = errors.New("failed")
+				err = errors.New("some entries failed")
+			}
+		}
+		return res, err
+	})
+
+	res, err := handler(t.Context(), &BulkMessage{Topic: "orders", Entries: []BulkMessageEntry{
+		{EntryId: "a", Event: cloudEvent("1")},
+		{EntryId: "b", Event: cloudEvent("1")},
+		{EntryId: "c", Event: cloudEvent("2")},
+	}})
+	require.Error(t, err)
+	assert.Equal(t, []string{"a", "c"}, received)
+	require.Len(t, res, 3)
+	require.NoError(t, res[0].Error)
+	require.NoError(t, res[1].Error)
+	require.Error(t, res[2].Error)
+
+	// The failed entry is processed again, the others are duplicates
+	received = nil
+	_, err = handler(t.Context(), &BulkMessage{Topic: "orders", Entries: []BulkMessageEntry{
+		{EntryId: "d", Event: cloudEvent("1")},
+		{EntryId: "e", Event: cloudEvent("2")},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e"}, received)
+}
@@ -0,0 +1,478 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtasks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gcptasks "google.golang.org/api/cloudtasks/v2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/idtoken"
+	"google.golang.org/api/option"
+
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// Keys for the publish request metadata.
+	scheduleTimeKey  = "scheduleTime"
+	scheduleDelayKey = "scheduleDelay"
+	taskIDKey        = "taskId"
+
+	// Keys for the metadata of the received messages, set from the headers of the Cloud Tasks requests.
+	taskNameKey       = "taskName"
+	queueNameKey      = "queueName"
+	retryCountKey     = "retryCount"
+	executionCountKey = "executionCount"
+	etaKey            = "eta"
+)
+
+// Headers of the requests that Cloud Tasks dispatches.
+var taskHeaders = map[string]string{
+	"X-CloudTasks-TaskName":           taskNameKey,
+	"X-CloudTasks-QueueName":          queueNameKey,
+	"X-CloudTasks-TaskRetryCount":     retryCountKey,
+	"X-CloudTasks-TaskExecutionCount": executionCountKey,
+	"X-CloudTasks-TaskETA":            etaKey,
+}
+
+// CloudTasks is a pub/sub component backed by Google Cloud Tasks.
+// Each topic is a queue; messages are published as HTTP tasks that Cloud Tasks dispatches to the push endpoint,
+// which is served by the component and delivers the messages to the subscribers.
+// Failed deliveries are retried by Cloud Tasks, according to the retry configuration of the queue.
+type CloudTasks struct {
+	service  *gcptasks.Service
+	metadata *metadata
+	dedup    *pubsub.Deduplicator
+	logger   logger.Logger
+
+	// Validates the OIDC tokens of the push requests; replaced in tests
+	validateToken func(ctx context.Context, token string, audience string) (*idtoken.Payload, error)
+
+	handlers     map[string]pubsub.Handler
+	handlersLock sync.RWMutex
+	server       *http.Server
+	serverLock   sync.Mutex
+	queues       sync.Map
+
+	closed  atomic.Bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+type gcpAuthJSON struct {
+	ProjectID           string `json:"project_id"`
+	PrivateKeyID        string `json:"private_key_id"`
+	PrivateKey          string `json:"private_key"`
+	ClientEmail         string `json:"client_email"`
+	ClientID            string `json:"client_id"`
+	AuthURI             string `json:"auth_uri"`
+	TokenURI            string `json:"token_uri"`
+	AuthProviderCertURL string `json:"auth_provider_x509_cert_url"`
+	ClientCertURL       string `json:"client_x509_cert_url"`
+	Type                string `json:"type"`
+}
+
+// NewCloudTasks returns a new Cloud Tasks pub/sub instance.
+func NewCloudTasks(logger logger.Logger) pubsub.PubSub {
+	return &CloudTasks{
+		logger:        logger,
+		validateToken: idtoken.Validate,
+		handlers:      make(map[string]pubsub.Handler),
+		closeCh:       make(chan struct{}),
+	}
+}
+
+// Init parses the metadata and creates the Cloud Tasks client.
+func (c *CloudTasks) Init(ctx context.Context, meta pubsub.Metadata) error {
+	m, err := parseMetadata(meta)
+	if err != nil {
+		return err
+	}
+	c.metadata = m
+	if m.InsecureSkipAuth {
+		c.logger.Warnf("insecureSkipAuth is enabled: the requests received on %s are not authenticated", m.ListenAddress)
+	}
+	c.dedup, err = deduplication.New(ctx, meta.Properties, c.logger)
+	if err != nil {
+		return err
+	}
+
+	var opts []option.ClientOption
+	if m.PrivateKeyID != "" {
+		authJSON, _ := json.Marshal(&gcpAuthJSON{
+			ProjectID:           m.IdentityProjectID,
+			PrivateKeyID:        m.PrivateKeyID,
+			PrivateKey:          m.PrivateKey,
+			ClientEmail:         m.ClientEmail,
+			ClientID:            m.ClientID,
+			AuthURI:             m.AuthURI,
+			TokenURI:            m.TokenURI,
+			AuthProviderCertURL: m.AuthProviderCertURL,
+			ClientCertURL:       m.ClientCertURL,
+			Type:                m.Type,
+		})
+		c.logger.Debugf("Using explicit credentials for GCP")
+		opts = append(opts, option.WithCredentialsJSON(authJSON))
+	} else {
+		c.logger.Debugf("Using implicit credentials for GCP")
+	}
+	if m.ConnectionEndpoint != "" {
+		// Used to connect to emulators, which don't require authentication
+		opts = append(opts, option.WithEndpoint(m.ConnectionEndpoint), option.WithoutAuthentication())
+	}
+
+	c.service, err = gcptasks.NewService(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("%s error creating client: %w", errorMessagePrefix, err)
+	}
+
+	return nil
+}
+
+// Publish creates a HTTP task in the queue of the topic.
+// The "scheduleTime" (RFC 3339) or "scheduleDelay" (duration) request metadata properties delay the dispatch of the
+// task, and the "taskId" property names the task, so Cloud Tasks rejects duplicates.
+func (c *CloudTasks) Publish(ctx context.Context, req *pubsub.PublishRequest) error {
+	if c.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	task, err := c.newTask(req, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = c.ensureQueue(ctx, req.Topic)
+	if err != nil {
+		return fmt.Errorf("%s could not get valid queue %s: %w", errorMessagePrefix, req.Topic, err)
+	}
+
+	_, err = c.service.Projects.Locations.Queues.Tasks.
+		Create(c.queuePath(req.Topic), &gcptasks.CreateTaskRequest{Task: task}).
+		Context(ctx).
+		Do()
+	if isStatus(err, http.StatusConflict) {
+		c.logger.Debugf("Task %s already exists in queue %s", req.Metadata[taskIDKey], req.Topic)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s error creating task in queue %s: %w", errorMessagePrefix, req.Topic, err)
+	}
+
+	return nil
+}
+
+func (c *CloudTasks) newTask(req *pubsub.PublishRequest, now time.Time) (*gcptasks.Task, error) {
+	task := &gcptasks.Task{
+		HttpRequest: &gcptasks.HttpRequest{
+			HttpMethod: http.MethodPost,
+			Url:        c.pushURL(req.Topic),
+			Body:       base64.StdEncoding.EncodeToString(req.Data),
+		},
+	}
+	if req.ContentType != nil {
+		task.HttpRequest.Headers = map[string]string{"Content-Type": *req.ContentType}
+	}
+	if c.metadata.ServiceAccountEmail != "" {
+		task.HttpRequest.OidcToken = &gcptasks.OidcToken{
+			ServiceAccountEmail: c.metadata.ServiceAccountEmail,
+			Audience:            c.metadata.PushEndpoint,
+		}
+	}
+	if id := req.Metadata[taskIDKey]; id != "" {
+		task.Name = c.queuePath(req.Topic) + "/tasks/" + id
+	}
+
+	if v := req.Metadata[scheduleTimeKey]; v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s invalid %s %s: %w", errorMessagePrefix, scheduleTimeKey, v, err)
+		}
+		task.ScheduleTime = t.UTC().Format(time.RFC3339Nano)
+	} else if v := req.Metadata[scheduleDelayKey]; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("%s invalid %s %s: must be a positive duration", errorMessagePrefix, scheduleDelayKey, v)
+		}
+		task.ScheduleTime = now.Add(d).UTC().Format(time.RFC3339Nano)
+	}
+
+	return task, nil
+}
+
+// Subscribe registers the handler of a topic, and starts serving the push endpoint if needed.
+func (c *CloudTasks) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	if c.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	handler = c.dedup.Handler(handler)
+
+	// Creates the queue, so the retry configuration is applied even before messages are published
+	err := c.ensureQueue(ctx, req.Topic)
+	if err != nil {
+		return fmt.Errorf("%s could not get valid queue %s: %w", errorMessagePrefix, req.Topic, err)
+	}
+
+	err = c.startServer()
+	if err != nil {
+		return err
+	}
+
+	c.handlersLock.Lock()
+	c.handlers[req.Topic] = handler
+	c.handlersLock.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-c.closeCh:
+		}
+		c.handlersLock.Lock()
+		delete(c.handlers, req.Topic)
+		c.handlersLock.Unlock()
+	}()
+
+	return nil
+}
+
+// startServer starts serving the push endpoint, until the component is closed.
+func (c *CloudTasks) startServer() error {
+	c.serverLock.Lock()
+	defer c.serverLock.Unlock()
+	if c.server != nil {
+		return nil
+	}
+
+	ln, err := net.Listen("tcp", c.metadata.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("%s error listening on %s: %w", errorMessagePrefix, c.metadata.ListenAddress, err)
+	}
+	c.server = &http.Server{
+		Handler:           http.HandlerFunc(c.handlePush),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	c.wg.Add(2)
+	go func() {
+		defer c.wg.Done()
+		c.logger.Infof("Listening for Cloud Tasks requests on %s", ln.Addr())
+		srvErr := c.server.Serve(ln)
+		if srvErr != nil && !errors.Is(srvErr, http.ErrServerClosed) {
+			c.logger.Errorf("Error serving Cloud Tasks requests: %v", srvErr)
+		}
+	}()
+	go func() {
+		defer c.wg.Done()
+		<-c.closeCh
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srvErr := c.server.Shutdown(shutdownCtx)
+		if srvErr != nil {
+			c.logger.Errorf("Error shutting down server: %v", srvErr)
+		}
+	}()
+
+	return nil
+}
+
+// handlePush delivers the message of a task to the subscriber of the topic.
+// Responding with an error status code makes Cloud Tasks retry the task.
+func (c *CloudTasks) handlePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	topic, ok := c.topicFromPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !c.metadata.InsecureSkipAuth && !c.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	c.handlersLock.RLock()
+	handler := c.handlers[topic]
+	c.handlersLock.RUnlock()
+	if handler == nil {
+		// The task is retried until the topic is subscribed
+		http.Error(w, "no subscription for topic "+topic, http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	msg := &pubsub.NewMessage{
+		Data:     data,
+		Topic:    topic,
+		Metadata: make(map[string]string, len(taskHeaders)),
+	}
+	for header, key := range taskHeaders {
+		if v := r.Header.Get(header); v != "" {
+			msg.Metadata[key] = v
+		}
+	}
+	if v := r.Header.Get("Content-Type"); v != "" {
+		msg.ContentType = &v
+	}
+
+	err = handler(r.Context(), msg)
+	if err != nil {
+		c.logger.Errorf("Error processing task %s of queue %s: %v", msg.Metadata[taskNameKey], topic, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorized validates the OIDC token that Cloud Tasks adds to the requests for the service account, and checks that
+// the email of the service account is verified.
+func (c *CloudTasks) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	payload, err := c.validateToken(r.Context(), token, c.metadata.PushEndpoint)
+	if err != nil {
+		c.logger.Debugf("Invalid token in Cloud Tasks request: %v", err)
+		return false
+	}
+	return payload.Claims["email"] == c.metadata.ServiceAccountEmail && payload.Claims["email_verified"] == true
+}
+
+// ensureQueue creates the queue of a topic, or updates its retry configuration, once per queue.
+func (c *CloudTasks) ensureQueue(ctx context.Context, topic string) error {
+	if c.metadata.DisableEntityManagement {
+		return nil
+	}
+	if _, ok := c.queues.Load(topic); ok {
+		return nil
+	}
+
+	queues := c.service.Projects.Locations.Queues
+	queue := &gcptasks.Queue{
+		Name:        c.queuePath(topic),
+		RetryConfig: c.retryConfig(),
+	}
+	_, err := queues.Get(queue.Name).Context(ctx).Do()
+	switch {
+	case isStatus(err, http.StatusNotFound):
+		_, err = queues.Create(c.locationPath(), queue).Context(ctx).Do()
+		if isStatus(err, http.StatusConflict) {
+			err = nil
+		}
+	case err == nil && queue.RetryConfig != nil:
+		_, err = queues.Patch(queue.Name, queue).UpdateMask("retryConfig").Context(ctx).Do()
+	}
+	if err != nil {
+		return err
+	}
+
+	c.queues.Store(topic, struct{}{})
+	return nil
+}
+
+// retryConfig returns the retry configuration of the queues, or nil to use the defaults of Cloud Tasks.
+func (c *CloudTasks) retryConfig() *gcptasks.RetryConfig {
+	if !c.metadata.hasRetryConfig() {
+		return nil
+	}
+	return &gcptasks.RetryConfig{
+		MaxAttempts:      c.metadata.MaxAttempts,
+		MinBackoff:       formatDuration(c.metadata.MinBackoff),
+		MaxBackoff:       formatDuration(c.metadata.MaxBackoff),
+		MaxDoublings:     c.metadata.MaxDoublings,
+		MaxRetryDuration: formatDuration(c.metadata.MaxRetryDuration),
+	}
+}
+
+func (c *CloudTasks) locationPath() string {
+	return "projects/" + c.metadata.ProjectID + "/locations/" + c.metadata.Location
+}
+
+func (c *CloudTasks) queuePath(topic string) string {
+	return c.locationPath() + "/queues/" + topic
+}
+
+func (c *CloudTasks) pushURL(topic string) string {
+	return strings.TrimSuffix(c.metadata.PushEndpoint, "/") + "/" + url.PathEscape(topic)
+}
+
+// topicFromPath returns the topic of a request to the push endpoint.
+func (c *CloudTasks) topicFromPath(path string) (string, bool) {
+	u, _ := url.Parse(c.metadata.PushEndpoint)
+	topic, ok := strings.CutPrefix(path, strings.TrimSuffix(u.Path, "/")+"/")
+	return topic, ok && topic != ""
+}
+
+// formatDuration formats a duration for the Cloud Tasks API, which uses seconds with a "s" suffix.
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+func isStatus(err error, code int) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// Close stops serving the push endpoint.
+func (c *CloudTasks) Close() error {
+	defer c.wg.Wait()
+	if c.closed.CompareAndSwap(false, true) {
+		close(c.closeCh)
+	}
+	return c.dedup.Close()
+}
+
+func (c *CloudTasks) Features() []pubsub.Feature {
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (c *CloudTasks) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := metadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.PubSubType)
+	return
+}
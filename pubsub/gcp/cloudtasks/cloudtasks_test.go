@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gcptasks "google.golang.org/api/cloudtasks/v2"
+	"google.golang.org/api/idtoken"
+
+	mdata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+func getFakeProperties() map[string]string {
+	return map[string]string{
+		"projectID":           "my-project",
+		"location":            "us-central1",
+		"pushEndpoint":        "https://example.com/tasks/",
+		"serviceAccountEmail": "invoker@my-project.iam.gserviceaccount.com",
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		m, err := parseMetadata(pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}})
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultListenAddress, m.ListenAddress)
+		assert.False(t, m.hasRetryConfig())
+	})
+
+	t.Run("insecureSkipAuth without service account", func(t *testing.T) {
+		props := getFakeProperties()
+		props["serviceAccountEmail"] = ""
+		props["insecureSkipAuth"] = "true"
+		m, err := parseMetadata(pubsub.Metadata{Base: mdata.Base{Properties: props}})
+
+		require.NoError(t, err)
+		assert.True(t, m.InsecureSkipAuth)
+	})
+
+	t.Run("retry configuration", func(t *testing.T) {
+		props := getFakeProperties()
+		props["maxAttempts"] = "5"
+		props["minBackoff"] = "500ms"
+		props["maxBackoff"] = "1m"
+		props["maxDoublings"] = "3"
+		props["maxRetryDuration"] = "1h"
+		m, err := parseMetadata(pubsub.Metadata{Base: mdata.Base{Properties: props}})
+
+		require.NoError(t, err)
+		c := &CloudTasks{metadata: m}
+		assert.Equal(t, &gcptasks.RetryConfig{
+			MaxAttempts:      5,
+			MinBackoff:       "0.5s",
+			MaxBackoff:       "60s",
+			MaxDoublings:     3,
+			MaxRetryDuration: "3600s",
+		}, c.retryConfig())
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for name, props := range map[string]map[string]string{
+			"missing projectID":       {"projectID": ""},
+			"missing location":        {"location": ""},
+			"missing pushEndpoint":    {"pushEndpoint": ""},
+			"relative endpoint":       {"pushEndpoint": "/tasks"},
+			"missing service account": {"serviceAccountEmail": ""},
+			"invalid maxAttempts":     {"maxAttempts": "-2"},
+			"negative backoff":        {"minBackoff": "-1s"},
+			"invalid backoffs":        {"minBackoff": "2m", "maxBackoff": "1m"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				fakeProperties := getFakeProperties()
+				for k, v := range props {
+					fakeProperties[k] = v
+				}
+				_, err := parseMetadata(pubsub.Metadata{Base: mdata.Base{Properties: fakeProperties}})
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+// fakeCloudTasks is a fake Cloud Tasks REST API.
+type fakeCloudTasks struct {
+	lock   sync.Mutex
+	queues map[string]*gcptasks.Queue
+	tasks  []*gcptasks.Task
+}
+
+func (f *fakeCloudTasks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/tasks"):
+		var req gcptasks.CreateTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, task := range f.tasks {
+			if req.Task.Name != "" && task.Name == req.Task.Name {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		f.tasks = append(f.tasks, req.Task)
+		json.NewEncoder(w).Encode(req.Task)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/queues"):
+		var queue gcptasks.Queue
+		json.NewDecoder(r.Body).Decode(&queue)
+		f.queues[queue.Name] = &queue
+		json.NewEncoder(w).Encode(&queue)
+	case r.Method == http.MethodGet:
+		queue, ok := f.queues[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(queue)
+	case r.Method == http.MethodPatch:
+		var queue gcptasks.Queue
+		json.NewDecoder(r.Body).Decode(&queue)
+		f.queues[path].RetryConfig = queue.RetryConfig
+		json.NewEncoder(w).Encode(f.queues[path])
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func newTestCloudTasks(t *testing.T, props map[string]string) (*CloudTasks, *fakeCloudTasks) {
+	fake := &fakeCloudTasks{queues: map[string]*gcptasks.Queue{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	fakeProperties := getFakeProperties()
+	fakeProperties["endpoint"] = srv.URL
+	for k, v := range props {
+		fakeProperties[k] = v
+	}
+	c := NewCloudTasks(logger.NewLogger("test")).(*CloudTasks)
+	require.NoError(t, c.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{Properties: fakeProperties}}))
+	t.Cleanup(func() { c.Close() })
+	return c, fake
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("creates the queue and the task", func(t *testing.T) {
+		c, fake := newTestCloudTasks(t, map[string]string{
+			"maxAttempts": "3",
+		})
+
+		err := c.Publish(t.Context(), &pubsub.PublishRequest{
+			Topic:       "orders",
+			Data:        []byte("hello world"),
+			ContentType: ptr.Of("text/plain"),
+			Metadata:    map[string]string{scheduleTimeKey: "2030-01-02T03:04:05Z"},
+		})
+
+		require.NoError(t, err)
+		queue := fake.queues["projects/my-project/locations/us-central1/queues/orders"]
+		require.NotNil(t, queue)
+		assert.Equal(t, int64(3), queue.RetryConfig.MaxAttempts)
+		require.Len(t, fake.tasks, 1)
+		task := fake.tasks[0]
+		assert.Equal(t, "2030-01-02T03:04:05Z", task.ScheduleTime)
+		assert.Equal(t, "https://example.com/tasks/orders", task.HttpRequest.Url)
+		assert.Equal(t, "aGVsbG8gd29ybGQ=", task.HttpRequest.Body)
+		assert.Equal(t, "text/plain", task.HttpRequest.Headers["Content-Type"])
+		assert.Equal(t, "invoker@my-project.iam.gserviceaccount.com", task.HttpRequest.OidcToken.ServiceAccountEmail)
+		assert.Equal(t, "https://example.com/tasks/", task.HttpRequest.OidcToken.Audience)
+	})
+
+	t.Run("updates the retry configuration of existing queues", func(t *testing.T) {
+		c, fake := newTestCloudTasks(t, map[string]string{"maxAttempts": "3"})
+		fake.queues["projects/my-project/locations/us-central1/queues/orders"] = &gcptasks.Queue{
+			Name:        "projects/my-project/locations/us-central1/queues/orders",
+			RetryConfig: &gcptasks.RetryConfig{MaxAttempts: 100},
+		}
+
+		err := c.Publish(t.Context(), &pubsub.PublishRequest{Topic: "orders", Data: []byte("hello world")})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), fake.queues["projects/my-project/locations/us-central1/queues/orders"].RetryConfig.MaxAttempts)
+	})
+
+	t.Run("duplicate task IDs are ignored", func(t *testing.T) {
+		c, fake := newTestCloudTasks(t, nil)
+		req := &pubsub.PublishRequest{
+			Topic:    "orders",
+			Data:     []byte("hello world"),
+			Metadata: map[string]string{taskIDKey: "order-1"},
+		}
+
+		require.NoError(t, c.Publish(t.Context(), req))
+		require.NoError(t, c.Publish(t.Context(), req))
+		require.Len(t, fake.tasks, 1)
+		assert.Equal(t, "projects/my-project/locations/us-central1/queues/orders/tasks/order-1", fake.tasks[0].Name)
+	})
+
+	t.Run("schedule delay", func(t *testing.T) {
+		c := &CloudTasks{metadata: &metadata{PushEndpoint: "https://example.com"}}
+		now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		task, err := c.newTask(&pubsub.PublishRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{scheduleDelayKey: "90s"},
+		}, now)
+		require.NoError(t, err)
+		assert.Equal(t, "2030-01-02T03:05:35Z", task.ScheduleTime)
+
+		_, err = c.newTask(&pubsub.PublishRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{scheduleDelayKey: "-1s"},
+		}, now)
+		require.Error(t, err)
+
+		_, err = c.newTask(&pubsub.PublishRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{scheduleTimeKey: "tomorrow"},
+		}, now)
+		require.Error(t, err)
+	})
+}
+
+func TestHandlePush(t *testing.T) {
+	c := &CloudTasks{
+		logger:   logger.NewLogger("test"),
+		handlers: map[string]pubsub.Handler{},
+		metadata: &metadata{
+			PushEndpoint:        "https://example.com/tasks",
+			ServiceAccountEmail: "invoker@my-project.iam.gserviceaccount.com",
+		},
+		validateToken: func(ctx context.Context, token string, audience string) (*idtoken.Payload, error) {
+			if (token != "valid" && token != "unverified") || audience != "https://example.com/tasks" {
+				return nil, errors.New("invalid token")
+			}
+			return &idtoken.Payload{Claims: map[string]interface{}{
+				"email":          "invoker@my-project.iam.gserviceaccount.com",
+				"email_verified": token == "valid",
+			}}, nil
+		},
+	}
+	var received *pubsub.NewMessage
+	c.handlers["orders"] = func(ctx context.Context, msg *pubsub.NewMessage) error {
+		received = msg
+		if string(msg.Data) == "fail" {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	push := func(path string, token string, body string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "text/plain")
+		req.Header.Set("X-CloudTasks-TaskName", "task-1")
+		req.Header.Set("X-CloudTasks-TaskRetryCount", "2")
+		rec := httptest.NewRecorder()
+		c.handlePush(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, push("/tasks/orders", "valid", "hello world"))
+	require.NotNil(t, received)
+	assert.Equal(t, "orders", received.Topic)
+	assert.Equal(t, []byte("hello world"), received.Data)
+	assert.Equal(t, "text/plain", *received.ContentType)
+	assert.Equal(t, map[string]string{taskNameKey: "task-1", retryCountKey: "2"}, received.Metadata)
+
+	assert.Equal(t, http.StatusInternalServerError, push("/tasks/orders", "valid", "fail"))
+	assert.Equal(t, http.StatusUnauthorized, push("/tasks/orders", "invalid", "hello world"))
+	assert.Equal(t, http.StatusUnauthorized, push("/tasks/orders", "unverified", "hello world"))
+	assert.Equal(t, http.StatusNotFound, push("/tasks/payments", "valid", "hello world"))
+	assert.Equal(t, http.StatusNotFound, push("/other/orders", "valid", "hello world"))
+
+	c.metadata.InsecureSkipAuth = true
+	assert.Equal(t, http.StatusOK, push("/tasks/orders", "invalid", "hello world"))
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtasks
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	errorMessagePrefix = "gcp cloud tasks error:"
+
+	defaultListenAddress = ":8090"
+)
+
+type metadata struct {
+	// Ignored by metadata parser because included in built-in authentication profile
+	Type                string `mapstructure:"type"                    mdignore:"true"`
+	IdentityProjectID   string `mapstructure:"identityProjectID"       mdignore:"true"`
+	ProjectID           string `mapstructure:"projectID"               mdignore:"true"`
+	PrivateKeyID        string `mapstructure:"privateKeyID"            mdignore:"true"`
+	PrivateKey          string `mapstructure:"privateKey"              mdignore:"true"`
+	ClientEmail         string `mapstructure:"clientEmail"             mdignore:"true"`
+	ClientID            string `mapstructure:"clientID"                mdignore:"true"`
+	AuthURI             string `mapstructure:"authURI"                 mdignore:"true"`
+	TokenURI            string `mapstructure:"tokenURI"                mdignore:"true"`
+	AuthProviderCertURL string `mapstructure:"authProviderX509CertUrl" mdignore:"true"`
+	ClientCertURL       string `mapstructure:"clientX509CertUrl"       mdignore:"true"`
+
+	Location                string        `mapstructure:"location"`
+	PushEndpoint            string        `mapstructure:"pushEndpoint"`
+	ListenAddress           string        `mapstructure:"listenAddress"`
+	ServiceAccountEmail     string        `mapstructure:"serviceAccountEmail"`
+	InsecureSkipAuth        bool          `mapstructure:"insecureSkipAuth"`
+	ConnectionEndpoint      string        `mapstructure:"endpoint"`
+	DisableEntityManagement bool          `mapstructure:"disableEntityManagement"`
+	MaxAttempts             int64         `mapstructure:"maxAttempts"`
+	MinBackoff              time.Duration `mapstructure:"minBackoff"`
+	MaxBackoff              time.Duration `mapstructure:"maxBackoff"`
+	MaxDoublings            int64         `mapstructure:"maxDoublings"`
+	MaxRetryDuration        time.Duration `mapstructure:"maxRetryDuration"`
+}
+
+func parseMetadata(md pubsub.Metadata) (*metadata, error) {
+	m := metadata{
+		Type:          "service_account",
+		ListenAddress: defaultListenAddress,
+	}
+	err := kitmd.DecodeMetadata(md.Properties, &m)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", errorMessagePrefix, err)
+	}
+
+	if m.ProjectID == "" {
+		return nil, fmt.Errorf("%s missing attribute projectID", errorMessagePrefix)
+	}
+	if m.Location == "" {
+		return nil, fmt.Errorf("%s missing attribute location", errorMessagePrefix)
+	}
+	if m.PushEndpoint == "" {
+		return nil, fmt.Errorf("%s missing attribute pushEndpoint", errorMessagePrefix)
+	}
+	u, err := url.Parse(m.PushEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%s invalid pushEndpoint %s: must be an absolute HTTP(S) URL", errorMessagePrefix, m.PushEndpoint)
+	}
+	// The push endpoint accepts the requests of anyone who can reach it, unless they're authenticated
+	if m.ServiceAccountEmail == "" && !m.InsecureSkipAuth {
+		return nil, fmt.Errorf("%s missing attribute serviceAccountEmail: it's required to authenticate the requests, unless insecureSkipAuth is true", errorMessagePrefix)
+	}
+
+	// Cloud Tasks accepts -1 for unlimited attempts
+	if m.MaxAttempts < -1 {
+		return nil, fmt.Errorf("%s invalid maxAttempts %d: must be -1 (unlimited) or greater", errorMessagePrefix, m.MaxAttempts)
+	}
+	if m.MinBackoff < 0 || m.MaxBackoff < 0 || m.MaxRetryDuration < 0 || m.MaxDoublings < 0 {
+		return nil, fmt.Errorf("%s invalid retry configuration: values must not be negative", errorMessagePrefix)
+	}
+	if m.MinBackoff > 0 && m.MaxBackoff > 0 && m.MinBackoff > m.MaxBackoff {
+		return nil, fmt.Errorf("%s invalid retry configuration: minBackoff must not be greater than maxBackoff", errorMessagePrefix)
+	}
+
+	return &m, nil
+}
+
+// hasRetryConfig returns true if any of the queue retry settings is configured.
+func (m *metadata) hasRetryConfig() bool {
+	return m.MaxAttempts != 0 || m.MinBackoff > 0 || m.MaxBackoff > 0 || m.MaxDoublings > 0 || m.MaxRetryDuration > 0
+}
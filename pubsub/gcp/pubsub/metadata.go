@@ -39,6 +39,8 @@ type metadata struct {
 	OrderingKey              string        `mapstructure:"orderingKey"`
 	DeadLetterTopic          string        `mapstructure:"deadLetterTopic"`
 	MaxDeliveryAttempts      int           `mapstructure:"maxDeliveryAttempts"`
+	MinRetryBackoff          time.Duration `mapstructure:"minRetryBackoff"`
+	MaxRetryBackoff          time.Duration `mapstructure:"maxRetryBackoff"`
 	MaxOutstandingMessages   int           `mapstructure:"maxOutstandingMessages"`
 	MaxOutstandingBytes      int           `mapstructure:"maxOutstandingBytes"`
 	MaxConcurrentConnections int           `mapstructure:"maxConcurrentConnections"`
@@ -31,6 +31,7 @@ import (
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 )
@@ -48,12 +49,18 @@ const (
 	defaultConnectionRecoveryInSec = 2
 	defaultMaxDeliveryAttempts     = 5
 	defaultAckDeadline             = 20 * time.Second
+
+	// Limits of the delivery policies of subscriptions.
+	minMaxDeliveryAttempts = 5
+	maxMaxDeliveryAttempts = 100
+	maxRetryBackoff        = 600 * time.Second
 )
 
 // GCPPubSub type.
 type GCPPubSub struct {
 	client   *gcppubsub.Client
 	metadata *metadata
+	dedup    *pubsub.Deduplicator
 	logger   logger.Logger
 
 	closed     atomic.Bool
@@ -143,6 +150,17 @@ func createMetadata(pubSubMetadata pubsub.Metadata) (*metadata, error) {
 		return nil, fmt.Errorf("%s invalid AckDeadline %s. Value must be a positive Go duration string or integer", errorMessagePrefix, pubSubMetadata.Properties[metadataAckDeadlineKey])
 	}
 
+	if result.DeadLetterTopic != "" && (result.MaxDeliveryAttempts < minMaxDeliveryAttempts || result.MaxDeliveryAttempts > maxMaxDeliveryAttempts) {
+		return nil, fmt.Errorf("%s invalid maxDeliveryAttempts %d. Value must be between %d and %d", errorMessagePrefix, result.MaxDeliveryAttempts, minMaxDeliveryAttempts, maxMaxDeliveryAttempts)
+	}
+
+	if result.MinRetryBackoff < 0 || result.MinRetryBackoff > maxRetryBackoff || result.MaxRetryBackoff < 0 || result.MaxRetryBackoff > maxRetryBackoff {
+		return nil, fmt.Errorf("%s invalid retry backoff. Values must be between 0 and %s", errorMessagePrefix, maxRetryBackoff)
+	}
+	if result.MinRetryBackoff > 0 && result.MaxRetryBackoff > 0 && result.MinRetryBackoff > result.MaxRetryBackoff {
+		return nil, fmt.Errorf("%s invalid retry backoff. minRetryBackoff must not be greater than maxRetryBackoff", errorMessagePrefix)
+	}
+
 	return &result, nil
 }
 
@@ -152,6 +170,10 @@ func (g *GCPPubSub) Init(ctx context.Context, meta pubsub.Metadata) error {
 	if err != nil {
 		return err
 	}
+	g.dedup, err = deduplication.New(ctx, meta.Properties, g.logger)
+	if err != nil {
+		return err
+	}
 
 	g.wg.Add(1)
 	go func() {
@@ -269,6 +291,8 @@ func (g *GCPPubSub) Subscribe(parentCtx context.Context, req pubsub.SubscribeReq
 	if g.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = g.dedup.Handler(handler)
+
 	g.lock.RLock()
 	_, topicExists := g.topicCache[req.Topic]
 	g.lock.RUnlock()
@@ -458,45 +482,114 @@ func (g *GCPPubSub) ensureSubscription(parentCtx context.Context, subscription s
 		g.lock.Unlock()
 	}
 
+	if g.metadata.DeadLetterTopic != "" && !dlTopicOK {
+		g.lock.Lock()
+		// Double-check if the DeadLetterTopic still doesn't exist to avoid race condition
+		if _, ok := g.topicCache[g.metadata.DeadLetterTopic]; !ok {
+			err := g.ensureTopic(parentCtx, g.metadata.DeadLetterTopic)
+			if err != nil {
+				g.lock.Unlock()
+				return err
+			}
+			g.topicCache[g.metadata.DeadLetterTopic] = cacheEntry{
+				LastSync: time.Now(),
+			}
+		}
+		g.lock.Unlock()
+	}
+	deadLetterPolicy, retryPolicy := g.deliveryPolicies()
+
 	managedSubscription := subscription + "-" + topic
 	entity := g.getSubscription(managedSubscription)
 	exists, subErr := entity.Exists(parentCtx)
+	if subErr != nil {
+		return subErr
+	}
 	if !exists {
 		subConfig := gcppubsub.SubscriptionConfig{
 			AckDeadline:           g.metadata.AckDeadline,
 			Topic:                 g.getTopic(topic),
 			EnableMessageOrdering: g.metadata.EnableMessageOrdering,
-		}
-
-		if g.metadata.DeadLetterTopic != "" && !dlTopicOK {
-			g.lock.Lock()
-			// Double-check if the DeadLetterTopic still doesn't exist to avoid race condition
-			if _, ok := g.topicCache[g.metadata.DeadLetterTopic]; !ok {
-				subErr = g.ensureTopic(parentCtx, g.metadata.DeadLetterTopic)
-				if subErr != nil {
-					g.lock.Unlock()
-					return subErr
-				}
-				g.topicCache[g.metadata.DeadLetterTopic] = cacheEntry{
-					LastSync: time.Now(),
-				}
-			}
-			g.lock.Unlock()
-			dlTopic := fmt.Sprintf("projects/%s/topics/%s", g.metadata.ProjectID, g.metadata.DeadLetterTopic)
-			subConfig.DeadLetterPolicy = &gcppubsub.DeadLetterPolicy{
-				DeadLetterTopic:     dlTopic,
-				MaxDeliveryAttempts: g.metadata.MaxDeliveryAttempts,
-			}
+			DeadLetterPolicy:      deadLetterPolicy,
+			RetryPolicy:           retryPolicy,
 		}
 		_, subErr = g.client.CreateSubscription(parentCtx, managedSubscription, subConfig)
 		if subErr != nil {
 			g.logger.Errorf("unable to create subscription (%s): %#v - %v ", managedSubscription, subConfig, subErr)
 		}
+		return subErr
 	}
 
+	// Attach the delivery policies declared in the metadata to existing subscriptions
+	if deadLetterPolicy == nil && retryPolicy == nil {
+		return nil
+	}
+	current, subErr := entity.Config(parentCtx)
+	if subErr != nil {
+		return subErr
+	}
+	var update gcppubsub.SubscriptionConfigToUpdate
+	if deadLetterPolicy != nil && !sameDeadLetterPolicy(current.DeadLetterPolicy, deadLetterPolicy) {
+		update.DeadLetterPolicy = deadLetterPolicy
+	}
+	if retryPolicy != nil && !sameRetryPolicy(current.RetryPolicy, retryPolicy) {
+		update.RetryPolicy = retryPolicy
+	}
+	if update.DeadLetterPolicy == nil && update.RetryPolicy == nil {
+		return nil
+	}
+	_, subErr = entity.Update(parentCtx, update)
+	if subErr != nil {
+		g.logger.Errorf("unable to update the delivery policies of subscription (%s): %v", managedSubscription, subErr)
+	}
 	return subErr
 }
 
+// deliveryPolicies returns the dead-letter and retry policies of the subscriptions declared in the metadata, if any.
+func (g *GCPPubSub) deliveryPolicies() (*gcppubsub.DeadLetterPolicy, *gcppubsub.RetryPolicy) {
+	var (
+		deadLetterPolicy *gcppubsub.DeadLetterPolicy
+		retryPolicy      *gcppubsub.RetryPolicy
+	)
+	if g.metadata.DeadLetterTopic != "" {
+		deadLetterPolicy = &gcppubsub.DeadLetterPolicy{
+			DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", g.metadata.ProjectID, g.metadata.DeadLetterTopic),
+			MaxDeliveryAttempts: g.metadata.MaxDeliveryAttempts,
+		}
+	}
+	if g.metadata.MinRetryBackoff > 0 || g.metadata.MaxRetryBackoff > 0 {
+		// GCP uses its defaults for the unset values
+		retryPolicy = &gcppubsub.RetryPolicy{}
+		if g.metadata.MinRetryBackoff > 0 {
+			retryPolicy.MinimumBackoff = g.metadata.MinRetryBackoff
+		}
+		if g.metadata.MaxRetryBackoff > 0 {
+			retryPolicy.MaximumBackoff = g.metadata.MaxRetryBackoff
+		}
+	}
+	return deadLetterPolicy, retryPolicy
+}
+
+func sameDeadLetterPolicy(current *gcppubsub.DeadLetterPolicy, desired *gcppubsub.DeadLetterPolicy) bool {
+	return current != nil && current.DeadLetterTopic == desired.DeadLetterTopic && current.MaxDeliveryAttempts == desired.MaxDeliveryAttempts
+}
+
+// sameRetryPolicy returns true if the current retry policy has the backoffs set in the desired one.
+func sameRetryPolicy(current *gcppubsub.RetryPolicy, desired *gcppubsub.RetryPolicy) bool {
+	if current == nil {
+		return false
+	}
+	// Backoffs are optional values holding a time.Duration
+	sameBackoff := func(cur any, want any) bool {
+		if want == nil {
+			return true
+		}
+		curDuration, ok := cur.(time.Duration)
+		return ok && curDuration == want.(time.Duration)
+	}
+	return sameBackoff(current.MinimumBackoff, desired.MinimumBackoff) && sameBackoff(current.MaximumBackoff, desired.MaximumBackoff)
+}
+
 func (g *GCPPubSub) getSubscription(subscription string) *gcppubsub.Subscription {
 	return g.client.Subscription(subscription)
 }
@@ -506,7 +599,7 @@ func (g *GCPPubSub) Close() error {
 	if g.closed.CompareAndSwap(false, true) {
 		close(g.closeCh)
 	}
-	return g.client.Close()
+	return errors.Join(g.client.Close(), g.dedup.Close())
 }
 
 func (g *GCPPubSub) Features() []pubsub.Feature {
@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	gcppubsub "cloud.google.com/go/pubsub"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -292,4 +293,65 @@ func TestInit(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, defaultAckDeadline, md.AckDeadline, "Should use the default AckDeadline when none is specified")
 	})
+
+	t.Run("valid delivery policies", func(t *testing.T) {
+		m := pubsub.Metadata{}
+		m.Properties = map[string]string{
+			"projectId":           "test-project",
+			"deadLetterTopic":     "dlq",
+			"maxDeliveryAttempts": "10",
+			"minRetryBackoff":     "5s",
+			"maxRetryBackoff":     "2m",
+		}
+
+		md, err := createMetadata(m)
+		require.NoError(t, err)
+
+		g := &GCPPubSub{metadata: md}
+		deadLetterPolicy, retryPolicy := g.deliveryPolicies()
+		assert.Equal(t, &gcppubsub.DeadLetterPolicy{DeadLetterTopic: "projects/test-project/topics/dlq", MaxDeliveryAttempts: 10}, deadLetterPolicy)
+		assert.Equal(t, &gcppubsub.RetryPolicy{MinimumBackoff: 5 * time.Second, MaximumBackoff: 2 * time.Minute}, retryPolicy)
+	})
+
+	t.Run("no delivery policies by default", func(t *testing.T) {
+		m := pubsub.Metadata{}
+		m.Properties = map[string]string{
+			"projectId": "test-project",
+		}
+
+		md, err := createMetadata(m)
+		require.NoError(t, err)
+
+		g := &GCPPubSub{metadata: md}
+		deadLetterPolicy, retryPolicy := g.deliveryPolicies()
+		assert.Nil(t, deadLetterPolicy)
+		assert.Nil(t, retryPolicy)
+	})
+
+	t.Run("invalid delivery policies", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{"deadLetterTopic": "dlq", "maxDeliveryAttempts": "101"},
+			{"minRetryBackoff": "11m"},
+			{"minRetryBackoff": "2m", "maxRetryBackoff": "1m"},
+		} {
+			m := pubsub.Metadata{}
+			m.Properties = props
+			m.Properties["projectId"] = "test-project"
+
+			_, err := createMetadata(m)
+			require.Error(t, err, props)
+		}
+	})
+}
+
+func TestSameDeliveryPolicies(t *testing.T) {
+	desiredRetry := &gcppubsub.RetryPolicy{MinimumBackoff: 5 * time.Second}
+	assert.False(t, sameRetryPolicy(nil, desiredRetry))
+	assert.True(t, sameRetryPolicy(&gcppubsub.RetryPolicy{MinimumBackoff: 5 * time.Second, MaximumBackoff: 600 * time.Second}, desiredRetry))
+	assert.False(t, sameRetryPolicy(&gcppubsub.RetryPolicy{MinimumBackoff: 10 * time.Second}, desiredRetry))
+
+	desiredDeadLetter := &gcppubsub.DeadLetterPolicy{DeadLetterTopic: "projects/p/topics/dlq", MaxDeliveryAttempts: 5}
+	assert.False(t, sameDeadLetterPolicy(nil, desiredDeadLetter))
+	assert.True(t, sameDeadLetterPolicy(&gcppubsub.DeadLetterPolicy{DeadLetterTopic: "projects/p/topics/dlq", MaxDeliveryAttempts: 5}, desiredDeadLetter))
+	assert.False(t, sameDeadLetterPolicy(&gcppubsub.DeadLetterPolicy{DeadLetterTopic: "projects/p/topics/dlq", MaxDeliveryAttempts: 10}, desiredDeadLetter))
 }
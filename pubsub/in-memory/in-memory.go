@@ -16,6 +16,11 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,15 +28,23 @@ import (
 	"github.com/dapr/components-contrib/common/eventbus"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 )
 
 type bus struct {
-	bus     eventbus.Bus
-	log     logger.Logger
-	closed  atomic.Bool
-	closeCh chan struct{}
-	wg      sync.WaitGroup
+	bus      eventbus.Bus
+	metadata inMemoryMetadata
+	log      logger.Logger
+	closed   atomic.Bool
+	closeCh  chan struct{}
+	wg       sync.WaitGroup
+	dedup    *pubsub.Deduplicator
+
+	// Last messages published to each topic, delivered to new subscribers
+	replay     map[string][][]byte
+	seq        uint64
+	replayLock sync.Mutex
 }
 
 func New(logger logger.Logger) pubsub.PubSub {
@@ -46,14 +59,24 @@ func (a *bus) Close() error {
 		close(a.closeCh)
 	}
 	a.wg.Wait()
-	return nil
+	return a.dedup.Close()
 }
 
 func (a *bus) Features() []pubsub.Feature {
 	return []pubsub.Feature{pubsub.FeatureSubscribeWildcards}
 }
 
-func (a *bus) Init(_ context.Context, metadata pubsub.Metadata) error {
+func (a *bus) Init(ctx context.Context, metadata pubsub.Metadata) error {
+	m, err := parseMetadata(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	a.dedup, err = deduplication.New(ctx, metadata.Properties, a.log)
+	if err != nil {
+		return err
+	}
+	a.metadata = m
+	a.replay = make(map[string][][]byte)
 	a.bus = eventbus.New(true)
 
 	return nil
@@ -64,7 +87,20 @@ func (a *bus) Publish(_ context.Context, req *pubsub.PublishRequest) error {
 		return errors.New("component is closed")
 	}
 
-	a.bus.Publish(req.Topic, req.Data)
+	// The sequence number lets new subscribers skip the messages they receive from the replay buffer
+	a.replayLock.Lock()
+	a.seq++
+	seq := a.seq
+	if a.metadata.ReplayBufferSize > 0 {
+		buf := append(a.replay[req.Topic], req.Data)
+		if len(buf) > a.metadata.ReplayBufferSize {
+			buf = slices.Clone(buf[len(buf)-a.metadata.ReplayBufferSize:])
+		}
+		a.replay[req.Topic] = buf
+	}
+	a.replayLock.Unlock()
+
+	a.bus.Publish(req.Topic, req.Data, seq)
 
 	return nil
 }
@@ -74,27 +110,51 @@ func (a *bus) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handle
 		return errors.New("component is closed")
 	}
 
-	// For this component we allow built-in retries because it is backed by memory
-	retryHandler := func(data []byte) {
-		for range 10 {
-			handleErr := handler(ctx, &pubsub.NewMessage{Data: data, Topic: req.Topic, Metadata: req.Metadata})
-			if handleErr == nil {
-				break
-			}
-			a.log.Error(handleErr)
-			select {
-			case <-time.After(100 * time.Millisecond):
-				// Nop
-			case <-ctx.Done():
-				return
-			}
+	handler = a.dedup.Handler(handler)
+
+	// Messages published after subscribing are delivered after the replayed messages
+	var (
+		deliverLock sync.Mutex
+		replayedSeq uint64
+	)
+	retryHandler := func(data []byte, seq uint64) {
+		if seq <= replayedSeq {
+			return
 		}
+		deliverLock.Lock()
+		defer deliverLock.Unlock()
+		a.deliver(ctx, req, handler, data)
+	}
+
+	a.replayLock.Lock()
+	replay := a.replayMessages(req.Topic)
+	if len(replay) > 0 {
+		replayedSeq = a.seq
+		deliverLock.Lock()
 	}
 	err := a.bus.SubscribeAsync(req.Topic, retryHandler, true)
+	a.replayLock.Unlock()
 	if err != nil {
+		if len(replay) > 0 {
+			deliverLock.Unlock()
+		}
 		return err
 	}
 
+	if len(replay) > 0 {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			defer deliverLock.Unlock()
+			for _, data := range replay {
+				if ctx.Err() != nil {
+					return
+				}
+				a.deliver(ctx, req, handler, data)
+			}
+		}()
+	}
+
 	// Unsubscribe when context is done
 	a.wg.Add(1)
 	go func() {
@@ -112,7 +172,79 @@ func (a *bus) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handle
 	return nil
 }
 
+// deliver delivers a message to a subscriber, applying the configured delays, drops and duplicates.
+func (a *bus) deliver(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler, data []byte) {
+	if chance(a.metadata.DropPercentage) {
+		a.log.Debugf("Dropping message on topic %s", req.Topic)
+		return
+	}
+
+	delay := a.metadata.DeliveryDelay
+	if a.metadata.DeliveryJitter > 0 {
+		delay += rand.N(a.metadata.DeliveryJitter)
+	}
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		case <-a.closeCh:
+			return
+		}
+	}
+
+	a.handleWithRetries(ctx, req, handler, data)
+	if chance(a.metadata.DuplicatePercentage) {
+		a.log.Debugf("Delivering duplicate message on topic %s", req.Topic)
+		a.handleWithRetries(ctx, req, handler, data)
+	}
+}
+
+// For this component we allow built-in retries because it is backed by memory
+func (a *bus) handleWithRetries(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler, data []byte) {
+	for range 10 {
+		handleErr := handler(ctx, &pubsub.NewMessage{Data: data, Topic: req.Topic, Metadata: req.Metadata})
+		if handleErr == nil {
+			break
+		}
+		a.log.Error(handleErr)
+		select {
+		case <-time.After(100 * time.Millisecond):
+			// Nop
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// replayMessages returns the buffered messages of the topics matching the subscription.
+// For wildcard subscriptions, messages are grouped by topic.
+func (a *bus) replayMessages(pattern string) [][]byte {
+	var msgs [][]byte
+	for _, topic := range slices.Sorted(maps.Keys(a.replay)) {
+		if topicMatches(pattern, topic) {
+			msgs = append(msgs, a.replay[topic]...)
+		}
+	}
+	return msgs
+}
+
+// topicMatches reports whether a topic matches a subscription, the same way as the event bus.
+func topicMatches(pattern string, topic string) bool {
+	if pattern == topic {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	return ok && strings.HasPrefix(topic, prefix) && topic != prefix
+}
+
+func chance(percentage float64) bool {
+	return percentage > 0 && rand.Float64()*100 < percentage //nolint:gosec
+}
+
 // GetComponentMetadata returns the metadata of the component.
 func (a *bus) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
+	metadataStruct := inMemoryMetadata{}
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.PubSubType)
 	return
 }
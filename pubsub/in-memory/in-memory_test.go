@@ -17,9 +17,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	mdata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/kit/logger"
 )
@@ -102,6 +105,118 @@ func TestRetry(t *testing.T) {
 	assert.Equal(t, 5, i)
 }
 
+func TestReplayBuffer(t *testing.T) {
+	bus := New(logger.NewLogger("test"))
+	require.NoError(t, bus.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{Properties: map[string]string{
+		"replayBufferSize": "2",
+	}}}))
+	defer bus.Close()
+
+	for _, data := range []string{"1", "2", "3"} {
+		require.NoError(t, bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte(data), Topic: "demo"}))
+	}
+	require.NoError(t, bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte("other"), Topic: "other"}))
+
+	ch := make(chan []byte, 10)
+	bus.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "dem*"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		ch <- msg.Data
+		return nil
+	})
+	bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte("4"), Topic: "demo"})
+
+	assert.Equal(t, "2", string(<-ch))
+	assert.Equal(t, "3", string(<-ch))
+	assert.Equal(t, "4", string(<-ch))
+}
+
+func TestDeduplication(t *testing.T) {
+	bus := New(logger.NewLogger("test"))
+	require.NoError(t, bus.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{Properties: map[string]string{
+		"deduplicationWindow": "1m",
+	}}}))
+	defer bus.Close()
+
+	ch := make(chan []byte, 10)
+	bus.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "demo"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		ch <- msg.Data
+		return nil
+	})
+
+	for _, id := range []string{"1", "1", "2"} {
+		data := []byte(`{"specversion":"1.0","id":"` + id + `","source":"test","type":"test"}`)
+		require.NoError(t, bus.Publish(t.Context(), &pubsub.PublishRequest{Data: data, Topic: "demo"}))
+	}
+
+	assert.Contains(t, string(<-ch), `"id":"1"`)
+	assert.Contains(t, string(<-ch), `"id":"2"`)
+	assert.Never(t, func() bool { return len(ch) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestChaos(t *testing.T) {
+	newBus := func(t *testing.T, props map[string]string) pubsub.PubSub {
+		bus := New(logger.NewLogger("test"))
+		require.NoError(t, bus.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{Properties: props}}))
+		t.Cleanup(func() { bus.Close() })
+		return bus
+	}
+
+	t.Run("drop", func(t *testing.T) {
+		bus := newBus(t, map[string]string{"dropPercentage": "100"})
+		ch := make(chan []byte, 10)
+		bus.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "demo"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+			ch <- msg.Data
+			return nil
+		})
+
+		bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte("ABCD"), Topic: "demo"})
+		select {
+		case <-ch:
+			t.Fatal("message was not dropped")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		bus := newBus(t, map[string]string{"duplicatePercentage": "100"})
+		ch := make(chan []byte, 10)
+		bus.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "demo"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+			ch <- msg.Data
+			return nil
+		})
+
+		bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte("ABCD"), Topic: "demo"})
+		assert.Equal(t, "ABCD", string(<-ch))
+		assert.Equal(t, "ABCD", string(<-ch))
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		bus := newBus(t, map[string]string{"deliveryDelay": "50ms", "deliveryJitter": "10ms"})
+		ch := make(chan []byte, 10)
+		bus.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "demo"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+			ch <- msg.Data
+			return nil
+		})
+
+		start := time.Now()
+		bus.Publish(t.Context(), &pubsub.PublishRequest{Data: []byte("ABCD"), Topic: "demo"})
+		assert.Equal(t, "ABCD", string(<-ch))
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for k, v := range map[string]string{
+			"dropPercentage":      "101",
+			"duplicatePercentage": "-1",
+			"replayBufferSize":    "-1",
+			"deliveryDelay":       "-1s",
+		} {
+			bus := New(logger.NewLogger("test"))
+			err := bus.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{Properties: map[string]string{k: v}}})
+			require.Error(t, err, k)
+		}
+	})
+}
+
 func publish(ch chan []byte, msg *pubsub.NewMessage) error {
 	go func() { ch <- msg.Data }()
 
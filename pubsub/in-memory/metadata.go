@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"errors"
+	"time"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+type inMemoryMetadata struct {
+	// Delay before each message is delivered to a subscriber
+	DeliveryDelay time.Duration `mapstructure:"deliveryDelay"`
+	// Maximum random delay added to the delivery delay
+	DeliveryJitter time.Duration `mapstructure:"deliveryJitter"`
+	// Percentage of the deliveries that are dropped, from 0 to 100
+	DropPercentage float64 `mapstructure:"dropPercentage"`
+	// Percentage of the deliveries that are duplicated, from 0 to 100
+	DuplicatePercentage float64 `mapstructure:"duplicatePercentage"`
+	// Number of messages retained per topic, which are delivered to new subscribers
+	ReplayBufferSize int `mapstructure:"replayBufferSize"`
+}
+
+func parseMetadata(md map[string]string) (inMemoryMetadata, error) {
+	var m inMemoryMetadata
+	err := kitmd.DecodeMetadata(md, &m)
+	if err != nil {
+		return m, err
+	}
+
+	if m.DeliveryDelay < 0 || m.DeliveryJitter < 0 {
+		return m, errors.New("deliveryDelay and deliveryJitter must not be negative")
+	}
+	if m.DropPercentage < 0 || m.DropPercentage > 100 {
+		return m, errors.New("dropPercentage must be between 0 and 100")
+	}
+	if m.DuplicatePercentage < 0 || m.DuplicatePercentage > 100 {
+		return m, errors.New("duplicatePercentage must be between 0 and 100")
+	}
+	if m.ReplayBufferSize < 0 {
+		return m, errors.New("replayBufferSize must not be negative")
+	}
+
+	return m, nil
+}
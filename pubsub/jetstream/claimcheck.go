@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	defaultClaimCheckBucket = "dapr-claim-check"
+
+	// claimCheckHeader is set on messages whose payload is in the object store.
+	// Its value is "<bucket>/<object>": bucket names cannot contain slashes.
+	claimCheckHeader = "Dapr-Claim-Check"
+)
+
+// initClaimCheck binds to the object store of the claim check bucket, creating it if it doesn't exist.
+func (js *jetstreamPubSub) initClaimCheck() error {
+	if js.meta.ClaimCheckThreshold == 0 {
+		return nil
+	}
+
+	obs, err := js.jsc.ObjectStore(js.meta.ClaimCheckBucket)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		obs, err = js.jsc.CreateObjectStore(&nats.ObjectStoreConfig{
+			Bucket:      js.meta.ClaimCheckBucket,
+			Description: "Payloads of messages published by Dapr",
+			TTL:         js.meta.ClaimCheckTTL,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bind to the claim check bucket %s: %w", js.meta.ClaimCheckBucket, err)
+	}
+	js.objectStores.Store(js.meta.ClaimCheckBucket, obs)
+	return nil
+}
+
+// claimCheck stores the payload of the message in the object store if it exceeds the threshold, replacing the
+// payload with a reference to the object.
+func (js *jetstreamPubSub) claimCheck(msg *nats.Msg) error {
+	if js.meta.ClaimCheckThreshold == 0 || len(msg.Data) <= js.meta.ClaimCheckThreshold {
+		return nil
+	}
+
+	obs, err := js.objectStore(js.meta.ClaimCheckBucket)
+	if err != nil {
+		return err
+	}
+	name := uuid.NewString()
+	_, err = obs.PutBytes(name, msg.Data)
+	if err != nil {
+		return fmt.Errorf("failed to store the payload in the claim check bucket %s: %w", js.meta.ClaimCheckBucket, err)
+	}
+
+	js.l.Debugf("Stored payload of %d bytes in object %s/%s", len(msg.Data), js.meta.ClaimCheckBucket, name)
+	msg.Header.Set(claimCheckHeader, js.meta.ClaimCheckBucket+"/"+name)
+	msg.Data = nil
+	return nil
+}
+
+// redeemClaimCheck returns the payload of a received message, fetching it from the object store if the message
+// has a claim check.
+// Objects are not deleted once retrieved, as other consumers may receive the message: they expire with the TTL of
+// the bucket.
+func (js *jetstreamPubSub) redeemClaimCheck(msg *nats.Msg) ([]byte, error) {
+	ref := msg.Header.Get(claimCheckHeader)
+	if ref == "" {
+		return msg.Data, nil
+	}
+
+	bucket, name, ok := strings.Cut(ref, "/")
+	if !ok || bucket == "" || name == "" {
+		return nil, fmt.Errorf("invalid claim check %q", ref)
+	}
+	obs, err := js.objectStore(bucket)
+	if err != nil {
+		return nil, err
+	}
+	data, err := obs.GetBytes(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the payload from object %s: %w", ref, err)
+	}
+	return data, nil
+}
+
+func (js *jetstreamPubSub) objectStore(bucket string) (nats.ObjectStore, error) {
+	if obs, ok := js.objectStores.Load(bucket); ok {
+		return obs.(nats.ObjectStore), nil
+	}
+
+	obs, err := js.jsc.ObjectStore(bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind to the claim check bucket %s: %w", bucket, err)
+	}
+	js.objectStores.Store(bucket, obs)
+	return obs, nil
+}
@@ -25,6 +25,7 @@ import (
 
 	mdutils "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/retry"
 )
@@ -36,6 +37,11 @@ type jetstreamPubSub struct {
 	meta metadata
 
 	backOffConfig retry.Config
+	deadLetter    pubsub.DeadLetterPolicy
+	dedup         *pubsub.Deduplicator
+
+	// Object stores of the claim check buckets, by bucket name
+	objectStores sync.Map
 
 	closed  atomic.Bool
 	closeCh chan struct{}
@@ -49,12 +55,20 @@ func NewJetStream(logger logger.Logger) pubsub.PubSub {
 	}
 }
 
-func (js *jetstreamPubSub) Init(_ context.Context, metadata pubsub.Metadata) error {
+func (js *jetstreamPubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	var err error
 	js.meta, err = parseMetadata(metadata)
 	if err != nil {
 		return err
 	}
+	js.deadLetter, err = pubsub.NewDeadLetterPolicy(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	js.dedup, err = deduplication.New(ctx, metadata.Properties, js.l)
+	if err != nil {
+		return err
+	}
 
 	var opts []nats.Option
 	opts = append(opts, nats.Name(js.meta.Name))
@@ -95,6 +109,11 @@ func (js *jetstreamPubSub) Init(_ context.Context, metadata pubsub.Metadata) err
 		return err
 	}
 
+	err = js.initClaimCheck()
+	if err != nil {
+		return err
+	}
+
 	// Default retry configuration is used if no backOff properties are set.
 	if err := retry.DecodeConfigWithPrefix(
 		&js.backOffConfig,
@@ -135,8 +154,15 @@ func (js *jetstreamPubSub) Publish(ctx context.Context, req *pubsub.PublishReque
 		js.l.Warn("empty message ID, Jetstream deduplication will not be possible")
 	}
 
+	msg := nats.NewMsg(req.Topic)
+	msg.Data = req.Data
+	err = js.claimCheck(msg)
+	if err != nil {
+		return err
+	}
+
 	js.l.Debugf("Publishing to topic %v id: %s", req.Topic, msgID)
-	_, err = js.jsc.Publish(req.Topic, req.Data, opts...)
+	_, err = js.jsc.PublishMsg(msg, opts...)
 
 	return err
 }
@@ -145,10 +171,17 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 	if js.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = js.dedup.Handler(handler)
 
 	var consumerConfig nats.ConsumerConfig
 
-	consumerConfig.DeliverSubject = nats.NewInbox()
+	pull := js.meta.ConsumerType == consumerTypePull
+	if pull {
+		consumerConfig.MaxWaiting = js.meta.MaxWaiting
+		consumerConfig.MaxRequestBatch = js.meta.FetchBatchSize
+	} else {
+		consumerConfig.DeliverSubject = nats.NewInbox()
+	}
 
 	if v := js.meta.DurableName; v != "" {
 		consumerConfig.Durable = v
@@ -164,7 +197,7 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 		consumerConfig.OptStartSeq = v
 	}
 	consumerConfig.DeliverPolicy = js.meta.internalDeliverPolicy
-	if js.meta.FlowControl {
+	if js.meta.FlowControl && !pull {
 		consumerConfig.FlowControl = true
 	}
 
@@ -173,6 +206,8 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 	}
 	if js.meta.MaxDeliver != 0 {
 		consumerConfig.MaxDeliver = js.meta.MaxDeliver
+	} else if js.deadLetter.Enabled() {
+		consumerConfig.MaxDeliver = js.deadLetter.MaxDeliveries
 	}
 	if len(js.meta.BackOff) != 0 {
 		consumerConfig.BackOff = js.meta.BackOff
@@ -186,10 +221,11 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 	if js.meta.MemoryStorage {
 		consumerConfig.MemoryStorage = true
 	}
-	if js.meta.RateLimit != 0 {
+	// Rate limits and idle heartbeats only apply to push consumers.
+	if js.meta.RateLimit != 0 && !pull {
 		consumerConfig.RateLimit = js.meta.RateLimit
 	}
-	if js.meta.Heartbeat != 0 {
+	if js.meta.Heartbeat != 0 && !pull {
 		consumerConfig.Heartbeat = js.meta.Heartbeat
 	}
 	consumerConfig.AckPolicy = js.meta.internalAckPolicy
@@ -204,19 +240,33 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 		}
 
 		js.l.Debugf("Processing JetStream message %s/%d", m.Subject, jsm.Sequence)
-		err = handler(ctx, &pubsub.NewMessage{
+		msg := &pubsub.NewMessage{
 			Topic: req.Topic,
 			Data:  m.Data,
 			Metadata: map[string]string{
 				"Topic": m.Subject,
 			},
-		})
+		}
+		data, err := js.redeemClaimCheck(m)
+		if err == nil {
+			msg.Data = data
+			err = handler(ctx, msg)
+		}
 		if err != nil {
 			js.l.Errorf("Error processing JetStream message %s/%d: %v", m.Subject, jsm.Sequence, err)
 
+			// JetStream stops delivering messages after MaxDeliver deliveries, but doesn't dead-letter them
+			err = js.deadLetter.HandleFailure(ctx, msg, int(jsm.NumDelivered), err, js.Publish) //nolint:gosec
+			if err == nil {
+				js.l.Warnf("Stopped delivering JetStream message %s/%d after %d deliveries", m.Subject, jsm.Sequence, jsm.NumDelivered)
+			}
+		}
+		if err != nil {
 			if js.meta.internalAckPolicy == nats.AckExplicitPolicy || js.meta.internalAckPolicy == nats.AckAllPolicy {
 				var nakErr error
-				if js.meta.AckWait != 0 {
+				if js.deadLetter.Backoff != 0 {
+					nakErr = m.NakWithDelay(js.deadLetter.Backoff)
+				} else if js.meta.AckWait != 0 {
 					nakErr = m.NakWithDelay(js.meta.AckWait)
 				} else {
 					nakErr = m.Nak()
@@ -267,6 +317,10 @@ func (js *jetstreamPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRe
 		return err
 	}
 
+	if pull {
+		return js.pullSubscribe(ctx, req.Topic, streamName, consumerInfo.Name, concHandler)
+	}
+
 	if queue := js.meta.QueueGroupName; queue != "" {
 		js.l.Debugf("nats: subscribed to subject %s with queue group %s", req.Topic, js.meta.QueueGroupName)
 		sub, err = js.jsc.QueueSubscribe(req.Topic, queue, concHandler, nats.Bind(streamName, consumerInfo.Name))
@@ -299,7 +353,7 @@ func (js *jetstreamPubSub) Close() error {
 	if js.closed.CompareAndSwap(false, true) {
 		close(js.closeCh)
 	}
-	return js.nc.Drain()
+	return errors.Join(js.nc.Drain(), js.dedup.Close())
 }
 
 // Handle nats signature request for challenge response authentication.
@@ -15,6 +15,10 @@ package jetstream
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -165,3 +169,189 @@ func TestNewJetStream_DurableQueuePushConsumer(t *testing.T) {
 	case <-time.After(10 * time.Millisecond):
 	}
 }
+
+func TestNewJetStream_ClaimCheck(t *testing.T) {
+	ns, nc := setupServerAndStream(t)
+	defer ns.Shutdown()
+	defer nc.Drain()
+
+	bus := NewJetStream(logger.NewLogger("test"))
+	defer bus.Close()
+
+	err := bus.Init(t.Context(), pubsub.Metadata{
+		Base: mdata.Base{
+			Properties: map[string]string{
+				"natsURL":             ns.ClientURL(),
+				"claimCheckThreshold": "64",
+				"claimCheckBucket":    "payloads",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	ch := make(chan []byte, 2)
+
+	err = bus.Subscribe(ctx, pubsub.SubscribeRequest{Topic: "test"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		ch <- msg.Data
+		return nil
+	})
+	require.NoError(t, err)
+
+	small := []byte(`{"id": "ABCD-1", "data": "test"}`)
+	large := []byte(`{"id": "ABCD-2", "data": "` + strings.Repeat("x", 1024) + `"}`)
+	for _, payload := range [][]byte{small, large} {
+		err = bus.Publish(ctx, &pubsub.PublishRequest{
+			Data:  payload,
+			Topic: "test",
+		})
+		require.NoError(t, err)
+
+		select {
+		case output := <-ch:
+			assert.Equal(t, payload, output)
+		case <-time.After(time.Second):
+			t.Fatal("receive timeout")
+		}
+	}
+
+	// Only the large payload is stored in the object store.
+	js, _ := nc.JetStream()
+	raw, err := js.GetLastMsg("test", "test")
+	require.NoError(t, err)
+	assert.Empty(t, raw.Data)
+	ref := raw.Header.Get(claimCheckHeader)
+	require.True(t, strings.HasPrefix(ref, "payloads/"))
+
+	obs, err := js.ObjectStore("payloads")
+	require.NoError(t, err)
+	stored, err := obs.GetBytes(strings.TrimPrefix(ref, "payloads/"))
+	require.NoError(t, err)
+	assert.Equal(t, large, stored)
+}
+
+func TestNewJetStream_DurablePullConsumer(t *testing.T) {
+	ns, nc := setupServerAndStream(t)
+	defer ns.Shutdown()
+	defer nc.Drain()
+
+	bus := NewJetStream(logger.NewLogger("test"))
+	defer bus.Close()
+
+	err := bus.Init(t.Context(), pubsub.Metadata{
+		Base: mdata.Base{
+			Properties: map[string]string{
+				"natsURL":        ns.ClientURL(),
+				"durableName":    "test",
+				"consumerType":   "pull",
+				"fetchBatchSize": "2",
+				"fetchMaxWait":   "100ms",
+				"maxWaiting":     "4",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	ch := make(chan []byte, 3)
+
+	err = bus.Subscribe(ctx, pubsub.SubscribeRequest{Topic: "test"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		ch <- msg.Data
+		return nil
+	})
+	require.NoError(t, err)
+
+	js, _ := nc.JetStream()
+	ci, err := js.ConsumerInfo("test", "test")
+	require.NoError(t, err)
+	assert.Empty(t, ci.Config.DeliverSubject)
+	assert.Equal(t, 2, ci.Config.MaxRequestBatch)
+	assert.Equal(t, 4, ci.Config.MaxWaiting)
+
+	// Three messages need two fetches with a batch size of 2.
+	for i := range 3 {
+		payload := []byte(fmt.Sprintf(`{"id": "ABCD-%d", "data": "test"}`, i))
+		err = bus.Publish(ctx, &pubsub.PublishRequest{
+			Data:  payload,
+			Topic: "test",
+		})
+		require.NoError(t, err)
+	}
+
+	for i := range 3 {
+		select {
+		case output := <-ch:
+			assert.Equal(t, []byte(fmt.Sprintf(`{"id": "ABCD-%d", "data": "test"}`, i)), output)
+		case <-time.After(time.Second):
+			t.Fatal("receive timeout")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		ci, err = js.ConsumerInfo("test", "test")
+		return err == nil && ci.NumAckPending == 0 && ci.Delivered.Consumer == 3
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewJetStream_DeadLetter(t *testing.T) {
+	ns, nc := setupServerAndStream(t)
+	defer ns.Shutdown()
+	defer nc.Drain()
+
+	js, _ := nc.JetStream()
+	_, err := js.AddStream(&nats.StreamConfig{
+		Name:     "dlq",
+		Subjects: []string{"test-dlq"},
+		Storage:  nats.MemoryStorage,
+	})
+	require.NoError(t, err)
+
+	bus := NewJetStream(logger.NewLogger("test"))
+	defer bus.Close()
+
+	err = bus.Init(t.Context(), pubsub.Metadata{
+		Base: mdata.Base{
+			Properties: map[string]string{
+				"natsURL":           ns.ClientURL(),
+				"durableName":       "test",
+				"maxDeliveries":     "3",
+				"deadLetterTopic":   "test-dlq",
+				"redeliveryBackoff": "10ms",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	var deliveries atomic.Int32
+	err = bus.Subscribe(ctx, pubsub.SubscribeRequest{Topic: "test"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		deliveries.Add(1)
+		return errors.New("failed")
+	})
+	require.NoError(t, err)
+	ch := make(chan *pubsub.NewMessage, 1)
+	err = bus.Subscribe(ctx, pubsub.SubscribeRequest{Topic: "test-dlq"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+		ch <- msg
+		return nil
+	})
+	require.NoError(t, err)
+
+	ci, err := js.ConsumerInfo("test", "test")
+	require.NoError(t, err)
+	assert.Equal(t, 3, ci.Config.MaxDeliver)
+
+	payload := []byte(`{"id": "ABCD", "data": "test"}`)
+	err = bus.Publish(ctx, &pubsub.PublishRequest{
+		Data:  payload,
+		Topic: "test",
+	})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-ch:
+		assert.Equal(t, payload, msg.Data)
+		assert.Equal(t, int32(3), deliveries.Load())
+	case <-time.After(5 * time.Second):
+		t.Fatal("receive timeout")
+	}
+}
@@ -57,6 +57,18 @@ type metadata struct {
 	Domain                string             `mapstructure:"domain"`
 	APIPrefix             string             `mapstructure:"apiPrefix"`
 
+	// Pull consumers fetch messages in batches of FetchBatchSize, waiting up to FetchMaxWait for each batch.
+	ConsumerType   string        `mapstructure:"consumerType"`
+	FetchBatchSize int           `mapstructure:"fetchBatchSize"`
+	FetchMaxWait   time.Duration `mapstructure:"fetchMaxWait"`
+	MaxWaiting     int           `mapstructure:"maxWaiting"`
+
+	// Payloads larger than ClaimCheckThreshold bytes are stored in the ClaimCheckBucket object store,
+	// and only a reference to the object is published. 0 disables claim checks.
+	ClaimCheckThreshold int           `mapstructure:"claimCheckThreshold"`
+	ClaimCheckBucket    string        `mapstructure:"claimCheckBucket"`
+	ClaimCheckTTL       time.Duration `mapstructure:"claimCheckTTL"`
+
 	Concurrency pubsub.ConcurrencyMode `mapstructure:"concurrency"`
 }
 
@@ -94,6 +106,13 @@ func parseMetadata(psm pubsub.Metadata) (metadata, error) {
 		m.Name = "dapr.io - pubsub.jetstream"
 	}
 
+	if m.ClaimCheckThreshold < 0 {
+		return metadata{}, errors.New("claimCheckThreshold must not be negative")
+	}
+	if m.ClaimCheckThreshold > 0 && m.ClaimCheckBucket == "" {
+		m.ClaimCheckBucket = defaultClaimCheckBucket
+	}
+
 	if m.StartTime != nil {
 		m.internalStartTime = time.Unix(int64(*m.StartTime), 0) //nolint:gosec
 	}
@@ -124,6 +143,29 @@ func parseMetadata(psm pubsub.Metadata) (metadata, error) {
 		m.internalAckPolicy = nats.AckExplicitPolicy
 	}
 
+	switch m.ConsumerType {
+	case consumerTypePush, "":
+		m.ConsumerType = consumerTypePush
+	case consumerTypePull:
+		if m.internalAckPolicy != nats.AckExplicitPolicy {
+			return metadata{}, errors.New("pull consumers require the explicit ack policy")
+		}
+		if m.QueueGroupName != "" {
+			return metadata{}, errors.New("queue groups are not supported with pull consumers: subscribers with the same durable name share the messages instead")
+		}
+		if m.FetchBatchSize < 0 || m.FetchMaxWait < 0 || m.MaxWaiting < 0 {
+			return metadata{}, errors.New("fetchBatchSize, fetchMaxWait and maxWaiting must not be negative")
+		}
+		if m.FetchBatchSize == 0 {
+			m.FetchBatchSize = defaultFetchBatchSize
+		}
+		if m.FetchMaxWait == 0 {
+			m.FetchMaxWait = defaultFetchMaxWait
+		}
+	default:
+		return metadata{}, fmt.Errorf("consumer type %s is not one of: push, pull", m.ConsumerType)
+	}
+
 	// Explicit check to prevent overriding the Single default
 	// (the previous behavior) if not set.
 	// TODO: See https://github.com/dapr/components-contrib/pull/3222#discussion_r1389772053
@@ -74,6 +74,7 @@ func TestParseMetadata(t *testing.T) {
 				internalDeliverPolicy: nats.DeliverAllPolicy,
 				internalAckPolicy:     nats.AckExplicitPolicy,
 				Domain:                "hub",
+				ConsumerType:          "push",
 				Concurrency:           pubsub.Single,
 			},
 			expectErr: false,
@@ -127,10 +128,104 @@ func TestParseMetadata(t *testing.T) {
 				internalDeliverPolicy: nats.DeliverByStartSequencePolicy,
 				internalAckPolicy:     nats.AckAllPolicy,
 				APIPrefix:             "HUB",
+				ConsumerType:          "push",
 				Concurrency:           pubsub.Parallel,
 			},
 			expectErr: false,
 		},
+		{
+			desc: "Valid metadata with claim check",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":             "nats://localhost:4222",
+					"claimCheckThreshold": "1048576",
+					"claimCheckTTL":       "24h",
+				},
+			}},
+			want: metadata{
+				NatsURL:               "nats://localhost:4222",
+				Name:                  "dapr.io - pubsub.jetstream",
+				ClaimCheckThreshold:   1048576,
+				ClaimCheckBucket:      "dapr-claim-check",
+				ClaimCheckTTL:         24 * time.Hour,
+				internalDeliverPolicy: nats.DeliverAllPolicy,
+				internalAckPolicy:     nats.AckExplicitPolicy,
+				ConsumerType:          "push",
+				Concurrency:           pubsub.Single,
+			},
+			expectErr: false,
+		},
+		{
+			desc: "Invalid metadata with negative claim check threshold",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":             "nats://localhost:4222",
+					"claimCheckThreshold": "-1",
+				},
+			}},
+			want:      metadata{},
+			expectErr: true,
+		},
+		{
+			desc: "Valid metadata with pull consumer",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":        "nats://localhost:4222",
+					"durableName":    "myDurable",
+					"consumerType":   "pull",
+					"fetchBatchSize": "50",
+					"maxWaiting":     "16",
+				},
+			}},
+			want: metadata{
+				NatsURL:               "nats://localhost:4222",
+				Name:                  "dapr.io - pubsub.jetstream",
+				DurableName:           "myDurable",
+				ConsumerType:          "pull",
+				FetchBatchSize:        50,
+				FetchMaxWait:          5 * time.Second,
+				MaxWaiting:            16,
+				internalDeliverPolicy: nats.DeliverAllPolicy,
+				internalAckPolicy:     nats.AckExplicitPolicy,
+				Concurrency:           pubsub.Single,
+			},
+			expectErr: false,
+		},
+		{
+			desc: "Invalid metadata with pull consumer and queue group",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":        "nats://localhost:4222",
+					"consumerType":   "pull",
+					"queueGroupName": "myQueue",
+				},
+			}},
+			want:      metadata{},
+			expectErr: true,
+		},
+		{
+			desc: "Invalid metadata with pull consumer and ack none",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":      "nats://localhost:4222",
+					"consumerType": "pull",
+					"ackPolicy":    "none",
+				},
+			}},
+			want:      metadata{},
+			expectErr: true,
+		},
+		{
+			desc: "Invalid metadata with unknown consumer type",
+			input: pubsub.Metadata{Base: mdata.Base{
+				Properties: map[string]string{
+					"natsURL":      "nats://localhost:4222",
+					"consumerType": "poll",
+				},
+			}},
+			want:      metadata{},
+			expectErr: true,
+		},
 		{
 			desc: "Invalid metadata with missing seed key",
 			input: pubsub.Metadata{Base: mdata.Base{
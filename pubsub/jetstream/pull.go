@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	consumerTypePush = "push"
+	consumerTypePull = "pull"
+
+	defaultFetchBatchSize = 10
+	defaultFetchMaxWait   = 5 * time.Second
+)
+
+// pullSubscribe binds a pull subscription to the consumer, and fetches messages in the background until the context
+// is canceled or the component is closed.
+func (js *jetstreamPubSub) pullSubscribe(ctx context.Context, topic string, streamName string, consumerName string, handler nats.MsgHandler) error {
+	sub, err := js.jsc.PullSubscribe(topic, "", nats.Bind(streamName, consumerName))
+	if err != nil {
+		return err
+	}
+	js.l.Debugf("nats: pull subscribed to subject %s with consumer %s", topic, consumerName)
+
+	fetchCtx, fetchCancel := context.WithCancel(context.Background())
+	js.wg.Add(2)
+	go func() {
+		defer js.wg.Done()
+		defer fetchCancel()
+		select {
+		case <-ctx.Done():
+		case <-js.closeCh:
+		}
+	}()
+	go func() {
+		defer js.wg.Done()
+		for fetchCtx.Err() == nil {
+			js.fetch(fetchCtx, sub, handler)
+		}
+		err := sub.Unsubscribe()
+		if err != nil {
+			js.l.Warnf("nats: error while unsubscribing from topic %s: %v", topic, err)
+		}
+	}()
+
+	return nil
+}
+
+// fetch requests a batch of messages and dispatches them to the handler.
+func (js *jetstreamPubSub) fetch(ctx context.Context, sub *nats.Subscription, handler nats.MsgHandler) {
+	reqCtx, reqCancel := context.WithTimeout(ctx, js.meta.FetchMaxWait)
+	msgs, err := sub.Fetch(js.meta.FetchBatchSize, nats.Context(reqCtx))
+	reqCancel()
+	if err != nil && len(msgs) == 0 {
+		if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+			// No messages within the wait time, or the subscription is stopping
+			return
+		}
+		js.l.Errorf("nats: error fetching messages from subject %s: %v", sub.Subject, err)
+		// Wait before the next request so errors don't spin the loop
+		select {
+		case <-ctx.Done():
+		case <-time.After(js.meta.FetchMaxWait):
+		}
+		return
+	}
+
+	for _, msg := range msgs {
+		handler(msg)
+	}
+}
@@ -26,11 +26,15 @@ import (
 	commonutils "github.com/dapr/components-contrib/common/utils"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 )
 
 type PubSub struct {
-	kafka  *kafka.Kafka
-	logger logger.Logger
+	kafka          *kafka.Kafka
+	logger         logger.Logger
+	topicPrefix    pubsub.TopicPrefix
+	publishLimiter pubsub.PublishRateLimiter
+	dedup          *pubsub.Deduplicator
 
 	closed  atomic.Bool
 	closeCh chan struct{}
@@ -38,7 +42,23 @@ type PubSub struct {
 }
 
 func (p *PubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
-	return p.kafka.Init(ctx, metadata.Properties)
+	props, err := pubsub.ExpandNamespaceProperties(metadata.Properties, "consumerGroup", pubsub.RuntimeConsumerIDKey)
+	if err != nil {
+		return err
+	}
+	p.topicPrefix, err = pubsub.NewTopicPrefix(props)
+	if err != nil {
+		return err
+	}
+	p.publishLimiter, err = pubsub.NewPublishRateLimiter(props)
+	if err != nil {
+		return err
+	}
+	p.dedup, err = deduplication.New(ctx, props, p.logger)
+	if err != nil {
+		return err
+	}
+	return p.kafka.Init(ctx, props)
 }
 
 func (p *PubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
@@ -49,10 +69,20 @@ func (p *PubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 	if err != nil {
 		return err
 	}
+	valueReaderSchema, err := kafka.GetValueReaderSchema(req.Metadata)
+	if err != nil {
+		return err
+	}
+	partitionAssignment, err := p.kafka.GetPartitionAssignment(req.Metadata)
+	if err != nil {
+		return err
+	}
 	handlerConfig := kafka.SubscriptionHandlerConfig{
-		IsBulkSubscribe: false,
-		Handler:         adaptHandler(handler),
-		ValueSchemaType: valueSchemaType,
+		IsBulkSubscribe:     false,
+		Handler:             adaptHandler(p.topicPrefix.Handler(p.dedup.Handler(handler))),
+		ValueSchemaType:     valueSchemaType,
+		ValueReaderSchema:   valueReaderSchema,
+		PartitionAssignment: partitionAssignment,
 	}
 
 	p.subscribeUtil(ctx, req, handlerConfig)
@@ -74,11 +104,23 @@ func (p *PubSub) BulkSubscribe(ctx context.Context, req pubsub.SubscribeRequest,
 	if err != nil {
 		return err
 	}
+	valueReaderSchema, err := kafka.GetValueReaderSchema(req.Metadata)
+	if err != nil {
+		return err
+	}
+	partitionAssignment, err := p.kafka.GetPartitionAssignment(req.Metadata)
+	if err != nil {
+		return err
+	}
+	if partitionAssignment != nil {
+		return errors.New("partitions cannot be assigned to bulk subscriptions")
+	}
 	handlerConfig := kafka.SubscriptionHandlerConfig{
-		IsBulkSubscribe: true,
-		SubscribeConfig: subConfig,
-		BulkHandler:     adaptBulkHandler(handler),
-		ValueSchemaType: valueSchemaType,
+		IsBulkSubscribe:   true,
+		SubscribeConfig:   subConfig,
+		BulkHandler:       adaptBulkHandler(p.topicPrefix.BulkHandler(p.dedup.BulkHandler(handler))),
+		ValueSchemaType:   valueSchemaType,
+		ValueReaderSchema: valueReaderSchema,
 	}
 	p.subscribeUtil(ctx, req, handlerConfig)
 	return nil
@@ -97,7 +139,7 @@ func (p *PubSub) subscribeUtil(ctx context.Context, req pubsub.SubscribeRequest,
 		p.wg.Done()
 	}()
 
-	p.kafka.Subscribe(ctx, handlerConfig, req.Topic)
+	p.kafka.Subscribe(ctx, handlerConfig, p.topicPrefix.Topic(req.Topic))
 }
 
 // NewKafka returns a new kafka pubsub instance.
@@ -117,8 +159,11 @@ func (p *PubSub) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 	if p.closed.Load() {
 		return errors.New("component is closed")
 	}
+	if err := p.publishLimiter.Wait(ctx, 1); err != nil {
+		return err
+	}
 
-	return p.kafka.Publish(ctx, req.Topic, req.Data, req.Metadata)
+	return p.kafka.Publish(ctx, p.topicPrefix.Topic(req.Topic), req.Data, req.Metadata)
 }
 
 // BatchPublish messages to Kafka cluster.
@@ -126,8 +171,11 @@ func (p *PubSub) BulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest
 	if p.closed.Load() {
 		return pubsub.BulkPublishResponse{}, errors.New("component is closed")
 	}
+	if err := p.publishLimiter.Wait(ctx, len(req.Entries)); err != nil {
+		return pubsub.NewBulkPublishResponse(req.Entries, err), err
+	}
 
-	return p.kafka.BulkPublish(ctx, req.Topic, req.Entries, req.Metadata)
+	return p.kafka.BulkPublish(ctx, p.topicPrefix.Topic(req.Topic), req.Entries, req.Metadata)
 }
 
 func (p *PubSub) Close() (err error) {
@@ -135,7 +183,7 @@ func (p *PubSub) Close() (err error) {
 	if p.closed.CompareAndSwap(false, true) {
 		close(p.closeCh)
 	}
-	return p.kafka.Close()
+	return errors.Join(p.kafka.Close(), p.dedup.Close())
 }
 
 func (p *PubSub) Features() []pubsub.Feature {
@@ -174,6 +222,17 @@ func adaptBulkHandler(handler pubsub.BulkHandler) kafka.BulkEventHandler {
 	}
 }
 
+// Ping checks the connectivity to the brokers and that no subscription is stalled.
+// The lag of each partition is available with ConsumerHealth.
+func (p *PubSub) Ping(ctx context.Context) error {
+	return p.kafka.Ping(ctx)
+}
+
+// ConsumerHealth returns the lag of the consumed partitions and the number of consumer group rebalances.
+func (p *PubSub) ConsumerHealth() kafka.ConsumerHealth {
+	return p.kafka.ConsumerHealth()
+}
+
 // GetComponentMetadata returns the metadata of the component.
 func (p *PubSub) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := kafka.KafkaMetadata{}
@@ -2,6 +2,7 @@ package kubemq
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"strconv"
 	"time"
@@ -10,11 +11,13 @@ import (
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 )
 
 type kubeMQ struct {
 	metadata         *kubemqMetadata
+	dedup            *pubsub.Deduplicator
 	logger           logger.Logger
 	eventsClient     *kubeMQEvents
 	eventStoreClient *kubeMQEventStore
@@ -26,13 +29,17 @@ func NewKubeMQ(logger logger.Logger) pubsub.PubSub {
 	}
 }
 
-func (k *kubeMQ) Init(_ context.Context, metadata pubsub.Metadata) error {
+func (k *kubeMQ) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	meta, err := createMetadata(metadata)
 	if err != nil {
 		k.logger.Errorf("error init kubemq client error: %s", err.Error())
 		return err
 	}
 	k.metadata = meta
+	k.dedup, err = deduplication.New(ctx, metadata.Properties, k.logger)
+	if err != nil {
+		return err
+	}
 	if meta.IsStore {
 		k.eventStoreClient = newKubeMQEventsStore(k.logger)
 		return k.eventStoreClient.Init(meta)
@@ -55,6 +62,7 @@ func (k *kubeMQ) Publish(_ context.Context, req *pubsub.PublishRequest) error {
 }
 
 func (k *kubeMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	handler = k.dedup.Handler(handler)
 	if k.metadata.IsStore {
 		return k.eventStoreClient.Subscribe(ctx, req, handler)
 	} else {
@@ -64,9 +72,9 @@ func (k *kubeMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 
 func (k *kubeMQ) Close() error {
 	if k.metadata.IsStore {
-		return k.eventStoreClient.Close()
+		return errors.Join(k.eventStoreClient.Close(), k.dedup.Close())
 	} else {
-		return k.eventsClient.Close()
+		return errors.Join(k.eventsClient.Close(), k.dedup.Close())
 	}
 }
 
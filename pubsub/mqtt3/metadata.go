@@ -16,8 +16,11 @@ package mqtt
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
@@ -30,27 +33,48 @@ type mqttMetadata struct {
 	Qos                  byte   `mapstructure:"qos"`
 	Retain               bool   `mapstructure:"retain"`
 	CleanSession         bool   `mapstructure:"cleanSession"`
+
+	// Subscriptions join this shared subscription group, unless the subscription sets its own.
+	SharedSubscriptionGroup string `mapstructure:"sharedSubscriptionGroup"`
+
+	// Options below are used with MQTT 5 only.
+	ProtocolVersion       string        `mapstructure:"protocolVersion"`
+	SessionExpiryInterval time.Duration `mapstructure:"sessionExpiryInterval"`
+	MaxConcurrentHandlers int           `mapstructure:"maxConcurrentHandlers"`
 }
 
 const (
 	// Keys
-	mqttURL          = "url"
-	mqttQOS          = "qos"
-	mqttRetain       = "retain"
-	mqttConsumerID   = "consumerID"
-	mqttCleanSession = "cleanSession"
+	mqttURL                   = "url"
+	mqttQOS                   = "qos"
+	mqttRetain                = "retain"
+	mqttConsumerID            = "consumerID"
+	mqttCleanSession          = "cleanSession"
+	mqttSharedGroup           = "sharedSubscriptionGroup"
+	mqttSessionExpiry         = "sessionExpiryInterval"
+	mqttMaxConcurrentHandlers = "maxConcurrentHandlers"
+
+	// Supported protocol versions
+	protocolVersion311 = "3.1.1"
+	protocolVersion5   = "5"
 
 	// Defaults
-	defaultQOS          = 1
-	defaultRetain       = false
-	defaultWait         = 20 * time.Second
-	defaultCleanSession = false
+	defaultQOS             = 1
+	defaultRetain          = false
+	defaultWait            = 20 * time.Second
+	defaultCleanSession    = false
+	defaultProtocolVersion = protocolVersion311
+
+	// With MQTT 5, a session expiry interval of 0xFFFFFFFF seconds means the session never expires.
+	maxSessionExpiryInterval = math.MaxUint32 * time.Second
 )
 
 func parseMQTTMetaData(md pubsub.Metadata, log logger.Logger) (*mqttMetadata, error) {
 	m := mqttMetadata{
-		Qos:          defaultQOS,
-		CleanSession: defaultCleanSession,
+		Qos:                   defaultQOS,
+		CleanSession:          defaultCleanSession,
+		ProtocolVersion:       defaultProtocolVersion,
+		MaxConcurrentHandlers: mqttcomponent.DefaultMaxConcurrentHandlers,
 	}
 
 	err := kitmd.DecodeMetadata(md.Properties, &m)
@@ -73,6 +97,30 @@ func parseMQTTMetaData(md pubsub.Metadata, log logger.Logger) (*mqttMetadata, er
 		return &m, errors.New("missing consumerID")
 	}
 
+	if strings.ContainsAny(m.SharedSubscriptionGroup, "/+#") {
+		return &m, fmt.Errorf("invalid %s '%s': must not contain '/', '+' or '#'", mqttSharedGroup, m.SharedSubscriptionGroup)
+	}
+
+	switch m.ProtocolVersion {
+	case protocolVersion311:
+		if m.SessionExpiryInterval != 0 || md.Properties[mqttMaxConcurrentHandlers] != "" {
+			log.Warn("Metadata properties 'sessionExpiryInterval' and 'maxConcurrentHandlers' are only used with MQTT 5 and are ignored")
+		}
+	case protocolVersion5:
+		if m.SessionExpiryInterval < 0 || m.SessionExpiryInterval > maxSessionExpiryInterval {
+			return &m, fmt.Errorf("invalid sessionExpiryInterval: must be between 0 and %d seconds", uint32(math.MaxUint32))
+		}
+		// When the session is persistent and no expiry is set, keep the session forever like MQTT 3.1.1 does
+		if md.Properties[mqttSessionExpiry] == "" && !m.CleanSession {
+			m.SessionExpiryInterval = maxSessionExpiryInterval
+		}
+		if m.MaxConcurrentHandlers <= 0 {
+			return &m, fmt.Errorf("invalid maxConcurrentHandlers %d: must be greater than 0", m.MaxConcurrentHandlers)
+		}
+	default:
+		return &m, fmt.Errorf("invalid protocolVersion '%s': supported values are '%s' and '%s'", m.ProtocolVersion, protocolVersion311, protocolVersion5)
+	}
+
 	m.TLSProperties, err = pubsub.TLS(md.Properties)
 	if err != nil {
 		return &m, fmt.Errorf("invalid TLS configuration: %w", err)
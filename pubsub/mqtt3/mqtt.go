@@ -26,10 +26,13 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/eclipse/paho.golang/autopaho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	kitstrings "github.com/dapr/kit/strings"
 )
@@ -42,7 +45,9 @@ const (
 // mqttPubSub type allows sending and receiving data to/from MQTT broker.
 type mqttPubSub struct {
 	conn            mqtt.Client
+	conn5           *autopaho.ConnectionManager
 	metadata        *mqttMetadata
+	dedup           *pubsub.Deduplicator
 	logger          logger.Logger
 	topics          map[string]mqttPubSubSubscription
 	subscribingLock sync.RWMutex
@@ -74,12 +79,20 @@ func (m *mqttPubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
 		return err
 	}
 	m.metadata = mqttMeta
+	m.topics = make(map[string]mqttPubSubSubscription)
+	m.dedup, err = deduplication.New(ctx, metadata.Properties, m.logger)
+	if err != nil {
+		return err
+	}
 
-	err = m.connect(ctx)
+	if m.metadata.ProtocolVersion == protocolVersion5 {
+		err = m.connect5(ctx)
+	} else {
+		err = m.connect(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to establish connection to broker: %w", err)
 	}
-	m.topics = make(map[string]mqttPubSubSubscription)
 
 	m.logger.Debug("mqtt message bus initialization complete")
 
@@ -108,6 +121,10 @@ func (m *mqttPubSub) Publish(ctx context.Context, req *pubsub.PublishRequest) (e
 		}
 	}
 
+	if m.conn5 != nil {
+		return m.publish5(ctx, req, retain)
+	}
+
 	token := m.conn.Publish(req.Topic, m.metadata.Qos, retain, req.Data)
 	ctx, cancel := context.WithTimeout(ctx, defaultWait)
 	defer cancel()
@@ -129,16 +146,25 @@ func (m *mqttPubSub) Publish(ctx context.Context, req *pubsub.PublishRequest) (e
 
 // Subscribe to the topic on MQTT.
 // Request metadata includes:
+// - "sharedSubscriptionGroup": name of the shared subscription group to join, overriding the one in the component's metadata. Messages are load-balanced between the subscribers in the group.
 // - "unsubscribeOnClose": if true, when the subscription is stopped (context canceled), then an Unsubscribe message is sent to the MQTT broker, which will stop delivering messages to this consumer ID until the subscription is explicitly re-started with a new Subscribe call. Otherwise, messages continue to be delivered but are not handled and are NACK'd automatically. "unsubscribeOnClose" should be used with dynamic subscriptions.
 func (m *mqttPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
 	if m.closed.Load() {
 		return errors.New("component is closed")
 	}
 
-	topic := req.Topic
-	if topic == "" {
+	if req.Topic == "" {
 		return errors.New("topic name is empty")
 	}
+	handler = m.dedup.Handler(handler)
+	group := m.metadata.SharedSubscriptionGroup
+	if v, ok := req.Metadata[mqttSharedGroup]; ok {
+		group = v
+	}
+	topic, err := sharedTopic(req.Topic, group)
+	if err != nil {
+		return err
+	}
 	unsubscribeOnClose := kitstrings.IsTruthy(req.Metadata[unsubscribeOnCloseKey])
 
 	m.subscribingLock.Lock()
@@ -147,16 +173,19 @@ func (m *mqttPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest,
 	// Add the topic then start the subscription
 	m.addTopic(topic, handler)
 
-	token := m.conn.Subscribe(topic, m.metadata.Qos, m.onMessage(ctx))
-	var err error
-	select {
-	case <-token.Done():
-		// Subscription went through (sucecessfully or not)
-		err = token.Error()
-	case <-ctx.Done():
-		err = fmt.Errorf("error while waiting for subscription token: %w", ctx.Err())
-	case <-time.After(defaultWait):
-		err = errors.New("timeout waiting for subscription")
+	if m.conn5 != nil {
+		err = m.subscribe5(ctx, topic)
+	} else {
+		token := m.conn.Subscribe(topic, m.metadata.Qos, m.onMessage(ctx))
+		select {
+		case <-token.Done():
+			// Subscription went through (sucecessfully or not)
+			err = token.Error()
+		case <-ctx.Done():
+			err = fmt.Errorf("error while waiting for subscription token: %w", ctx.Err())
+		case <-time.After(defaultWait):
+			err = errors.New("timeout waiting for subscription")
+		}
 	}
 	if err != nil {
 		// Return an error
@@ -188,14 +217,18 @@ func (m *mqttPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest,
 			return
 		}
 
-		unsubscribeToken := m.conn.Unsubscribe(topic)
 		var unsubscribeErr error
-		select {
-		case <-unsubscribeToken.Done():
-			// Subscription went through (sucecessfully or not)
-			unsubscribeErr = token.Error()
-		case <-time.After(defaultWait):
-			unsubscribeErr = fmt.Errorf("timeout while unsubscribing from topic %s", topic)
+		if m.conn5 != nil {
+			unsubscribeErr = m.unsubscribe5(topic)
+		} else {
+			unsubscribeToken := m.conn.Unsubscribe(topic)
+			select {
+			case <-unsubscribeToken.Done():
+				// Subscription went through (sucecessfully or not)
+				unsubscribeErr = unsubscribeToken.Error()
+			case <-time.After(defaultWait):
+				unsubscribeErr = fmt.Errorf("timeout while unsubscribing from topic %s", topic)
+			}
 		}
 		if unsubscribeErr != nil {
 			m.logger.Warnf("Failed to ubsubscribe from topic %s: %v", topic, unsubscribeErr)
@@ -406,11 +439,15 @@ func (m *mqttPubSub) Close() error {
 	}
 
 	// Disconnect
-	m.conn.Disconnect(100)
+	if m.conn5 != nil {
+		mqttcomponent.Disconnect(m.conn5)
+	} else {
+		m.conn.Disconnect(100)
+	}
 
 	m.wg.Wait()
 
-	return nil
+	return m.dedup.Close()
 }
 
 func (m *mqttPubSub) Features() []pubsub.Feature {
@@ -419,6 +456,18 @@ func (m *mqttPubSub) Features() []pubsub.Feature {
 
 var sharedSubscriptionMatch = regexp.MustCompile(`^\$share\/(.*?)\/.`)
 
+// sharedTopic returns the topic filter to subscribe to in order to join a shared subscription group.
+// Topics that already begin with "$share/" are returned unchanged.
+func sharedTopic(topic string, group string) (string, error) {
+	if group == "" || strings.HasPrefix(topic, "$share/") {
+		return topic, nil
+	}
+	if strings.ContainsAny(group, "/+#") {
+		return "", fmt.Errorf("invalid %s '%s': must not contain '/', '+' or '#'", mqttSharedGroup, group)
+	}
+	return "$share/" + group + "/" + topic, nil
+}
+
 // Adds a topic to the list of subscriptions.
 func (m *mqttPubSub) addTopic(origTopicName string, handler pubsub.Handler) {
 	obj := mqttPubSubSubscription{
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+
+	mqttcomponent "github.com/dapr/components-contrib/common/component/mqtt"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// Keys for request and message metadata, used with MQTT 5 only
+	responseTopicKey   = "responseTopic"
+	correlationDataKey = "correlationData"
+	retainedKey        = "retained"
+)
+
+// connect5 connects to the broker using MQTT 5.
+// The connection manager re-establishes the connection when it's lost, until the component is closed.
+func (m *mqttPubSub) connect5(ctx context.Context) error {
+	uri, err := url.Parse(m.metadata.URL)
+	if err != nil {
+		return err
+	}
+
+	// Handlers are invoked with a context that is canceled when the component is closed
+	handlerCtx, handlerCancel := context.WithCancel(context.Background())
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer handlerCancel()
+		<-m.closeCh
+	}()
+
+	cm, err := autopaho.NewConnection(context.Background(), m.createClientConfig5(uri, handlerCtx))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultWait)
+	defer cancel()
+	err = cm.AwaitConnection(ctx)
+	if err != nil {
+		mqttcomponent.Disconnect(cm)
+		return err
+	}
+	m.conn5 = cm
+
+	return nil
+}
+
+func (m *mqttPubSub) createClientConfig5(uri *url.URL, handlerCtx context.Context) autopaho.ClientConfig {
+	tlsConfig, err := pubsub.ConvertTLSPropertiesToTLSConfig(m.metadata.TLSProperties)
+	if err != nil {
+		m.logger.Warnf("failed to load TLS config: %s", err)
+		tlsConfig = nil
+	}
+
+	cfg := mqttcomponent.NewClientConfig(mqttcomponent.ClientOptions{
+		URL:                   uri,
+		ClientID:              m.metadata.ConsumerID,
+		TLSConfig:             tlsConfig,
+		CleanSession:          m.metadata.CleanSession,
+		SessionExpiryInterval: m.metadata.SessionExpiryInterval,
+		ConnectTimeout:        defaultWait,
+	}, m.logger)
+
+	// On (re-)connection, add all established topic subscriptions
+	cfg.OnConnectionUp = func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+		m.subscribingLock.RLock()
+		subscriptions := make([]paho.SubscribeOptions, 0, len(m.topics))
+		for topic := range m.topics {
+			subscriptions = append(subscriptions, paho.SubscribeOptions{Topic: topic, QoS: m.metadata.Qos})
+		}
+		m.subscribingLock.RUnlock()
+
+		// If there's nothing to subscribe to, just return
+		if len(subscriptions) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(handlerCtx, defaultWait)
+		defer cancel()
+		_, err := cm.Subscribe(ctx, &paho.Subscribe{Subscriptions: subscriptions})
+
+		// Nothing we can do in case of errors besides logging them
+		if err != nil {
+			m.logger.Errorf("Error starting subscriptions in the OnConnectionUp handler: %v", err)
+		}
+	}
+
+	dispatcher := mqttcomponent.NewDispatcher(m.metadata.MaxConcurrentHandlers, func(p *paho.Publish) {
+		m.handlePublish5(handlerCtx, p)
+	}, m.logger)
+	cfg.OnPublishReceived = []func(paho.PublishReceived) (bool, error){dispatcher.OnPublishReceived}
+
+	return cfg
+}
+
+// publish5 publishes a message using MQTT 5.
+func (m *mqttPubSub) publish5(ctx context.Context, req *pubsub.PublishRequest, retain bool) error {
+	p, err := newPublish5(req, m.metadata.Qos, retain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultWait)
+	defer cancel()
+	_, err = m.conn5.Publish(ctx, p)
+	if err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	return nil
+}
+
+// newPublish5 returns the PUBLISH packet for a request.
+// The "responseTopic" and "correlationData" request metadata properties are used for request/reply, the TTL sets
+// the message expiry interval, and the other properties are sent as user properties.
+func newPublish5(req *pubsub.PublishRequest, qos byte, retain bool) (*paho.Publish, error) {
+	props := &paho.PublishProperties{
+		ResponseTopic: req.Metadata[responseTopicKey],
+		User: mqttcomponent.UserProperties(req.Metadata,
+			mqttRetain, responseTopicKey, correlationDataKey, contribMetadata.TTLMetadataKey, contribMetadata.TTLInSecondsMetadataKey),
+	}
+	if v := req.Metadata[correlationDataKey]; v != "" {
+		props.CorrelationData = []byte(v)
+	}
+	if req.ContentType != nil {
+		props.ContentType = *req.ContentType
+	}
+
+	ttl, ok, err := contribMetadata.TryGetTTL(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message expiry interval: %w", err)
+	}
+	if ok {
+		expiry := uint32(min(max(ttl/time.Second, 1), math.MaxUint32)) //nolint:gosec
+		props.MessageExpiry = &expiry
+	}
+
+	return &paho.Publish{
+		QoS:        qos,
+		Retain:     retain,
+		Topic:      req.Topic,
+		Payload:    req.Data,
+		Properties: props,
+	}, nil
+}
+
+// subscribe5 adds a topic subscription using MQTT 5.
+func (m *mqttPubSub) subscribe5(ctx context.Context, topic string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultWait)
+	defer cancel()
+	_, err := m.conn5.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: []paho.SubscribeOptions{
+			{Topic: topic, QoS: m.metadata.Qos},
+		},
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = errors.New("timeout waiting for subscription")
+	}
+	return err
+}
+
+// unsubscribe5 removes a topic subscription using MQTT 5.
+func (m *mqttPubSub) unsubscribe5(topic string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+	defer cancel()
+	_, err := m.conn5.Unsubscribe(ctx, &paho.Unsubscribe{Topics: []string{topic}})
+	return err
+}
+
+// handlePublish5 processes a message received with MQTT 5.
+// User properties are included in the message metadata, along with the response topic and the correlation data.
+// The dispatcher acknowledges the message afterwards, even if it couldn't be processed.
+func (m *mqttPubSub) handlePublish5(ctx context.Context, p *paho.Publish) {
+	md := mqttcomponent.UserPropertiesMetadata(p)
+	if p.Properties != nil {
+		if p.Properties.ResponseTopic != "" {
+			md[responseTopicKey] = p.Properties.ResponseTopic
+		}
+		if len(p.Properties.CorrelationData) > 0 {
+			md[correlationDataKey] = string(p.Properties.CorrelationData)
+		}
+	}
+	md[retainedKey] = strconv.FormatBool(p.Retain)
+
+	topicHandler := m.handlerForTopic(p.Topic)
+	if topicHandler == nil {
+		m.logger.Warnf("No handler defined for messages received on topic %s", p.Topic)
+		return
+	}
+
+	m.logger.Debugf("Processing MQTT message %s#%d (retained=%v)", p.Topic, p.PacketID, p.Retain)
+	err := topicHandler(ctx, &pubsub.NewMessage{
+		Topic:    p.Topic,
+		Data:     p.Payload,
+		Metadata: md,
+	})
+	if err != nil {
+		m.logger.Errorf("Failed processing MQTT message %s#%d: %v", p.Topic, p.PacketID, err)
+	}
+}
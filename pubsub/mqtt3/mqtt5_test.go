@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestParseMetadataProtocolVersion(t *testing.T) {
+	log := logger.NewLogger("test")
+
+	t.Run("defaults to MQTT 3.1.1", func(t *testing.T) {
+		m, err := parseMQTTMetaData(pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, protocolVersion311, m.ProtocolVersion)
+		assert.Equal(t, time.Duration(0), m.SessionExpiryInterval)
+	})
+
+	t.Run("MQTT 5 with persistent session never expires by default", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, protocolVersion5, m.ProtocolVersion)
+		assert.Equal(t, maxSessionExpiryInterval, m.SessionExpiryInterval)
+	})
+
+	t.Run("MQTT 5 with session expiry interval", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties[mqttSessionExpiry] = "1h"
+
+		m, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, m.SessionExpiryInterval)
+	})
+
+	t.Run("invalid max concurrent handlers", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "5"
+		fakeMetaData.Properties[mqttMaxConcurrentHandlers] = "0"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid maxConcurrentHandlers")
+	})
+
+	t.Run("invalid protocol version", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["protocolVersion"] = "4"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid protocolVersion")
+	})
+
+	t.Run("invalid shared subscription group", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties[mqttSharedGroup] = "a/b"
+
+		_, err := parseMQTTMetaData(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid sharedSubscriptionGroup")
+	})
+}
+
+func TestSharedTopic(t *testing.T) {
+	topic, err := sharedTopic("orders/#", "workers")
+	require.NoError(t, err)
+	assert.Equal(t, "$share/workers/orders/#", topic)
+
+	topic, err = sharedTopic("orders", "")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", topic)
+
+	topic, err = sharedTopic("$share/other/orders", "workers")
+	require.NoError(t, err)
+	assert.Equal(t, "$share/other/orders", topic)
+
+	_, err = sharedTopic("orders", "work+ers")
+	require.Error(t, err)
+}
+
+func TestMQTT5(t *testing.T) {
+	t.Run("publish request", func(t *testing.T) {
+		p, err := newPublish5(&pubsub.PublishRequest{
+			Topic:       "orders",
+			Data:        []byte("hello world"),
+			ContentType: ptr.Of("application/json"),
+			Metadata: map[string]string{
+				mqttRetain:         "true",
+				responseTopicKey:   "orders/replies",
+				correlationDataKey: "42",
+				"ttlInSeconds":     "30",
+				"b":                "2",
+				"a":                "1",
+			},
+		}, 1, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "orders", p.Topic)
+		assert.Equal(t, byte(1), p.QoS)
+		assert.True(t, p.Retain)
+		assert.Equal(t, "orders/replies", p.Properties.ResponseTopic)
+		assert.Equal(t, []byte("42"), p.Properties.CorrelationData)
+		assert.Equal(t, "application/json", p.Properties.ContentType)
+		assert.Equal(t, uint32(30), *p.Properties.MessageExpiry)
+		assert.Equal(t, paho.UserProperties{
+			{Key: "a", Value: "1"},
+			{Key: "b", Value: "2"},
+		}, p.Properties.User)
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		_, err := newPublish5(&pubsub.PublishRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{"ttlInSeconds": "soon"},
+		}, 1, false)
+
+		require.ErrorContains(t, err, "invalid message expiry interval")
+	})
+
+	t.Run("properties are included in the message metadata", func(t *testing.T) {
+		m := NewMQTTPubSub(logger.NewLogger("test")).(*mqttPubSub)
+		m.topics = make(map[string]mqttPubSubSubscription)
+		called := false
+		m.addTopic("$share/workers/orders/+", func(ctx context.Context, msg *pubsub.NewMessage) error {
+			called = true
+			assert.Equal(t, "orders/eu", msg.Topic)
+			assert.Equal(t, []byte("hello world"), msg.Data)
+			assert.Equal(t, map[string]string{
+				"foo":              "bar",
+				responseTopicKey:   "orders/replies",
+				correlationDataKey: "42",
+				retainedKey:        "false",
+			}, msg.Metadata)
+			return nil
+		})
+
+		m.handlePublish5(t.Context(), &paho.Publish{
+			Topic:   "orders/eu",
+			Payload: []byte("hello world"),
+			Properties: &paho.PublishProperties{
+				ResponseTopic:   "orders/replies",
+				CorrelationData: []byte("42"),
+				User: paho.UserProperties{
+					{Key: "foo", Value: "bar"},
+					{Key: retainedKey, Value: "ignored"},
+				},
+			},
+		})
+		assert.True(t, called)
+	})
+}
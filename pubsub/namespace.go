@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"strings"
+)
+
+const (
+	// TopicPrefixKey is the metadata property containing the prefix added to all topic names.
+	// The value can contain the {namespace} template.
+	TopicPrefixKey = "topicPrefix"
+
+	// NamespaceTemplate is replaced with the namespace of the Dapr sidecar in topic prefixes and consumer groups.
+	NamespaceTemplate = "{namespace}"
+)
+
+// ExpandNamespace replaces the {namespace} template in the value with the namespace of the Dapr sidecar,
+// which is read from the NAMESPACE environmental variable.
+func ExpandNamespace(val string) (string, error) {
+	if !strings.Contains(val, NamespaceTemplate) {
+		return val, nil
+	}
+
+	namespace := os.Getenv("NAMESPACE")
+	if namespace == "" {
+		return "", errors.New("the {namespace} template is used, but the NAMESPACE environmental variable is not set")
+	}
+	return strings.ReplaceAll(val, NamespaceTemplate, namespace), nil
+}
+
+// ExpandNamespaceProperties returns a copy of the metadata properties in which the {namespace} template is expanded
+// for the topic prefix and for the given keys, which are normally those containing the consumer group.
+func ExpandNamespaceProperties(props map[string]string, keys ...string) (map[string]string, error) {
+	res := maps.Clone(props)
+	if res == nil {
+		res = map[string]string{}
+	}
+
+	var err error
+	for _, k := range append(keys, TopicPrefixKey) {
+		v, ok := res[k]
+		if !ok {
+			continue
+		}
+		res[k], err = ExpandNamespace(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// TopicPrefix adds a prefix to the names of topics, so topics used by different namespaces or tenants are isolated.
+// The zero value doesn't add any prefix.
+type TopicPrefix struct {
+	prefix string
+}
+
+// NewTopicPrefix returns a TopicPrefix configured with the topicPrefix metadata property, expanding the {namespace} template.
+func NewTopicPrefix(props map[string]string) (TopicPrefix, error) {
+	prefix, err := ExpandNamespace(props[TopicPrefixKey])
+	if err != nil {
+		return TopicPrefix{}, err
+	}
+	return TopicPrefix{prefix: prefix}, nil
+}
+
+// Topic returns the name of the topic in the broker.
+func (t TopicPrefix) Topic(topic string) string {
+	return t.prefix + topic
+}
+
+// OriginalTopic returns the name of the topic as seen by the app, removing the prefix.
+func (t TopicPrefix) OriginalTopic(topic string) string {
+	return strings.TrimPrefix(topic, t.prefix)
+}
+
+// Handler returns a Handler that restores the topic name as seen by the app before invoking handler.
+func (t TopicPrefix) Handler(handler Handler) Handler {
+	if t.prefix == "" {
+		return handler
+	}
+	return func(ctx context.Context, msg *NewMessage) error {
+		msg.Topic = t.OriginalTopic(msg.Topic)
+		return handler(ctx, msg)
+	}
+}
+
+// BulkHandler returns a BulkHandler that restores the topic name as seen by the app before invoking handler.
+func (t TopicPrefix) BulkHandler(handler BulkHandler) BulkHandler {
+	if t.prefix == "" {
+		return handler
+	}
+	return func(ctx context.Context, msg *BulkMessage) ([]BulkSubscribeResponseEntry, error) {
+		msg.Topic = t.OriginalTopic(msg.Topic)
+		return handler(ctx, msg)
+	}
+}
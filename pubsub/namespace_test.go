@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandNamespaceProperties(t *testing.T) {
+	t.Run("expands templates", func(t *testing.T) {
+		t.Setenv("NAMESPACE", "tenant1")
+
+		props := map[string]string{
+			"consumerID":    "{namespace}-app",
+			"consumerGroup": "group",
+			"topicPrefix":   "{namespace}.",
+			"other":         "{namespace}",
+		}
+		res, err := ExpandNamespaceProperties(props, "consumerID", "consumerGroup")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"consumerID":    "tenant1-app",
+			"consumerGroup": "group",
+			"topicPrefix":   "tenant1.",
+			"other":         "{namespace}",
+		}, res)
+
+		// The original map is not modified
+		assert.Equal(t, "{namespace}-app", props["consumerID"])
+	})
+
+	t.Run("namespace not set", func(t *testing.T) {
+		t.Setenv("NAMESPACE", "")
+
+		_, err := ExpandNamespaceProperties(map[string]string{"consumerID": "{namespace}-app"}, "consumerID")
+		require.Error(t, err)
+
+		res, err := ExpandNamespaceProperties(map[string]string{"consumerID": "app"}, "consumerID")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"consumerID": "app"}, res)
+	})
+}
+
+func TestTopicPrefix(t *testing.T) {
+	t.Setenv("NAMESPACE", "tenant1")
+
+	t.Run("no prefix", func(t *testing.T) {
+		tp, err := NewTopicPrefix(map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, "orders", tp.Topic("orders"))
+		assert.Equal(t, "orders", tp.OriginalTopic("orders"))
+	})
+
+	t.Run("namespace prefix", func(t *testing.T) {
+		tp, err := NewTopicPrefix(map[string]string{TopicPrefixKey: "{namespace}."})
+		require.NoError(t, err)
+		assert.Equal(t, "tenant1.orders", tp.Topic("orders"))
+		assert.Equal(t, "orders", tp.OriginalTopic("tenant1.orders"))
+
+		var topic string
+		handler := tp.Handler(func(ctx context.Context, msg *NewMessage) error {
+			topic = msg.Topic
+			return nil
+		})
+		require.NoError(t, handler(t.Context(), &NewMessage{Topic: "tenant1.orders"}))
+		assert.Equal(t, "orders", topic)
+
+		bulkHandler := tp.BulkHandler(func(ctx context.Context, msg *BulkMessage) ([]BulkSubscribeResponseEntry, error) {
+			topic = msg.Topic
+			return nil, nil
+		})
+		_, err = bulkHandler(t.Context(), &BulkMessage{Topic: "tenant1.payments"})
+		require.NoError(t, err)
+		assert.Equal(t, "payments", topic)
+	})
+}
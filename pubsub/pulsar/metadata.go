@@ -31,6 +31,7 @@ type pulsarMetadata struct {
 	Namespace                        string                    `mapstructure:"namespace"`
 	Persistent                       bool                      `mapstructure:"persistent"`
 	RedeliveryDelay                  time.Duration             `mapstructure:"redeliveryDelay"`
+	NackBackoffMaxDelay              time.Duration             `mapstructure:"nackBackoffMaxDelay"`
 	internalTopicSchemas             map[string]schemaMetadata `mapstructure:"-"`
 	PublicKey                        string                    `mapstructure:"publicKey"`
 	PrivateKey                       string                    `mapstructure:"privateKey"`
@@ -41,6 +42,8 @@ type pulsarMetadata struct {
 	SubscriptionInitialPosition      string                    `mapstructure:"subscribeInitialPosition"`
 	SubscriptionMode                 string                    `mapstructure:"subscribeMode"`
 	Token                            string                    `mapstructure:"token"`
+	EnableTransaction                bool                      `mapstructure:"enableTransaction"`
+	TransactionTimeout               time.Duration             `mapstructure:"transactionTimeout"`
 	oauth2.ClientCredentialsMetadata `mapstructure:",squash"`
 }
 
@@ -33,6 +33,7 @@ import (
 	"github.com/dapr/components-contrib/common/authentication/oauth2"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 )
@@ -113,9 +114,13 @@ type Pulsar struct {
 	client   pulsar.Client
 	metadata pulsarMetadata
 	cache    *lru.Cache[string, pulsar.Producer]
+	dedup    *pubsub.Deduplicator
 	closed   atomic.Bool
 	closeCh  chan struct{}
 	wg       sync.WaitGroup
+
+	// Open transactions of transactional subscriptions, by transaction ID
+	transactions sync.Map
 }
 
 func NewPulsar(l logger.Logger) pubsub.PubSub {
@@ -138,6 +143,7 @@ func parsePulsarMetadata(meta pubsub.Metadata) (*pulsarMetadata, error) {
 		RedeliveryDelay:         defaultRedeliveryDelay,
 		MaxConcurrentHandlers:   defaultConcurrency,
 		ReceiverQueueSize:       defaultReceiverQueueSize,
+		TransactionTimeout:      defaultTransactionTimeout,
 	}
 
 	if err := kitmd.DecodeMetadata(meta.Properties, &m); err != nil {
@@ -154,6 +160,10 @@ func parsePulsarMetadata(meta pubsub.Metadata) (*pulsarMetadata, error) {
 		return nil, errors.New("invalid subscription type. Accepted values are `exclusive`, `shared`, `failover` and `key_shared`")
 	}
 
+	if m.NackBackoffMaxDelay != 0 && m.NackBackoffMaxDelay < m.RedeliveryDelay {
+		return nil, errors.New("pulsar error: nackBackoffMaxDelay must not be lower than redeliveryDelay")
+	}
+
 	m.SubscriptionInitialPosition, err = parseSubscriptionPosition(meta.Properties[subscribeInitialPosition])
 	if err != nil {
 		return nil, errors.New("invalid subscription initial position. Accepted values are `latest` and `earliest`")
@@ -195,6 +205,10 @@ func (p *Pulsar) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	if err != nil {
 		return err
 	}
+	p.dedup, err = deduplication.New(ctx, metadata.Properties, p.logger)
+	if err != nil {
+		return err
+	}
 	pulsarURL := m.Host
 
 	pulsarURL = sanitiseURL(pulsarURL)
@@ -203,6 +217,7 @@ func (p *Pulsar) Init(ctx context.Context, metadata pubsub.Metadata) error {
 		OperationTimeout:           30 * time.Second,
 		ConnectionTimeout:          30 * time.Second,
 		TLSAllowInsecureConnection: !m.EnableTLS,
+		EnableTransaction:          m.EnableTransaction,
 	}
 
 	switch {
@@ -284,6 +299,10 @@ func (p *Pulsar) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 		msg *pulsar.ProducerMessage
 		err error
 	)
+	txn, err := p.publishTransaction(req)
+	if err != nil {
+		return err
+	}
 	topic := p.formatTopic(req.Topic)
 	producer, ok := p.cache.Get(topic)
 
@@ -329,6 +348,7 @@ func (p *Pulsar) Publish(ctx context.Context, req *pubsub.PublishRequest) error
 	if err != nil {
 		return err
 	}
+	msg.Transaction = txn
 
 	if _, err = producer.Send(ctx, msg); err != nil {
 		return err
@@ -395,6 +415,9 @@ func parsePublishMetadata(req *pubsub.PublishRequest, schema schemaMetadata) (
 			if err != nil {
 				return nil, err
 			}
+		case transactionIDKey:
+			// The transaction is set by Publish
+			continue
 		case deliverAfter:
 			msg.DeliverAfter, err = time.ParseDuration(value)
 			if err != nil {
@@ -486,10 +509,34 @@ func getSubscriptionMode(subsModeStr string) pulsar.SubscriptionMode {
 	}
 }
 
+// nackBackoff is a NackBackoffPolicy that doubles the delay before redelivering a negatively acknowledged
+// message each time it is redelivered, starting from the initial delay and up to the max delay.
+type nackBackoff struct {
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+func (b nackBackoff) Next(redeliveryCount uint32) time.Duration {
+	delay := b.initialDelay
+	for range redeliveryCount {
+		delay *= 2
+		if delay >= b.maxDelay || delay <= 0 {
+			return b.maxDelay
+		}
+	}
+	return min(delay, b.maxDelay)
+}
+
 func (p *Pulsar) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
 	if p.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = p.dedup.Handler(handler)
+
+	transactional, err := p.isTransactional(req)
+	if err != nil {
+		return err
+	}
 
 	channel := make(chan pulsar.ConsumerMessage, p.metadata.MaxConcurrentHandlers)
 
@@ -497,7 +544,10 @@ func (p *Pulsar) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 
 	subscribeType := p.metadata.SubscriptionType
 	if s, exists := req.Metadata[subscribeTypeKey]; exists {
-		subscribeType = s
+		subscribeType, err = parseSubscriptionType(s)
+		if err != nil {
+			return fmt.Errorf("invalid subscription type for topic %s. Accepted values are `exclusive`, `shared`, `failover` and `key_shared`", req.Topic)
+		}
 	}
 
 	options := pulsar.ConsumerOptions{
@@ -511,6 +561,13 @@ func (p *Pulsar) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 		ReceiverQueueSize:           p.metadata.ReceiverQueueSize,
 	}
 
+	if p.metadata.NackBackoffMaxDelay != 0 {
+		options.NackBackoffPolicy = nackBackoff{
+			initialDelay: p.metadata.RedeliveryDelay,
+			maxDelay:     p.metadata.NackBackoffMaxDelay,
+		}
+	}
+
 	// Handle KeySharedPolicy for key_shared subscription type
 	if options.Type == pulsar.KeyShared {
 		options.KeySharedPolicy = &pulsar.KeySharedPolicy{
@@ -554,13 +611,19 @@ func (p *Pulsar) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, han
 	go func() {
 		defer p.wg.Done()
 		defer cancel()
-		p.listenMessage(listenCtx, req, consumer, handler)
+		handleFn := p.handleMessage
+		if transactional {
+			handleFn = p.handleMessageInTransaction
+		}
+		p.listenMessage(listenCtx, req, consumer, handler, handleFn)
 	}()
 
 	return nil
 }
 
-func (p *Pulsar) listenMessage(ctx context.Context, req pubsub.SubscribeRequest, consumer pulsar.Consumer, handler pubsub.Handler) {
+type handleMessageFn func(ctx context.Context, originTopic string, msg pulsar.ConsumerMessage, handler pubsub.Handler) error
+
+func (p *Pulsar) listenMessage(ctx context.Context, req pubsub.SubscribeRequest, consumer pulsar.Consumer, handler pubsub.Handler, handleFn handleMessageFn) {
 	defer consumer.Close()
 
 	originTopic := req.Topic
@@ -569,7 +632,7 @@ func (p *Pulsar) listenMessage(ctx context.Context, req pubsub.SubscribeRequest,
 		select {
 		case msg := <-consumer.Chan():
 			if strings.ToLower(req.Metadata[processModeKey]) == processModeSync { //nolint:gocritic
-				err = p.handleMessage(ctx, originTopic, msg, handler)
+				err = handleFn(ctx, originTopic, msg, handler)
 				if err != nil && !errors.Is(err, context.Canceled) {
 					p.logger.Errorf("Error sync processing message: %s/%#v [key=%s]: %v", msg.Topic(), msg.ID(), msg.Key(), err)
 				}
@@ -578,7 +641,7 @@ func (p *Pulsar) listenMessage(ctx context.Context, req pubsub.SubscribeRequest,
 				p.wg.Add(1)
 				go func(msg pulsar.ConsumerMessage) {
 					defer p.wg.Done()
-					err = p.handleMessage(ctx, originTopic, msg, handler)
+					err = handleFn(ctx, originTopic, msg, handler)
 					if err != nil && !errors.Is(err, context.Canceled) {
 						p.logger.Errorf("Error async processing message: %s/%#v [key=%s]: %v", msg.Topic(), msg.ID(), msg.Key(), err)
 					}
@@ -625,7 +688,7 @@ func (p *Pulsar) Close() error {
 	}
 	p.client.Close()
 
-	return nil
+	return p.dedup.Close()
 }
 
 func (p *Pulsar) Features() []pubsub.Feature {
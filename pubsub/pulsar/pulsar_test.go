@@ -464,6 +464,33 @@ func TestParsePublishMetadata(t *testing.T) {
 		msg.DeliverAt.Format(time.RFC3339))
 }
 
+func TestNackBackoff(t *testing.T) {
+	t.Run("metadata", func(t *testing.T) {
+		m := pubsub.Metadata{}
+		m.Properties = map[string]string{
+			"host":                "a",
+			"redeliveryDelay":     "1s",
+			"nackBackoffMaxDelay": "1m",
+		}
+		meta, err := parsePulsarMetadata(m)
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, meta.NackBackoffMaxDelay)
+
+		m.Properties["nackBackoffMaxDelay"] = "500ms"
+		_, err = parsePulsarMetadata(m)
+		require.Error(t, err)
+	})
+
+	t.Run("delay doubles up to the max", func(t *testing.T) {
+		b := nackBackoff{initialDelay: time.Second, maxDelay: 10 * time.Second}
+		assert.Equal(t, time.Second, b.Next(0))
+		assert.Equal(t, 2*time.Second, b.Next(1))
+		assert.Equal(t, 8*time.Second, b.Next(3))
+		assert.Equal(t, 10*time.Second, b.Next(4))
+		assert.Equal(t, 10*time.Second, b.Next(1000))
+	})
+}
+
 func TestMissingHost(t *testing.T) {
 	m := pubsub.Metadata{}
 	m.Properties = map[string]string{"host": ""}
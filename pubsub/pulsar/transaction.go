@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pulsar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// transactionalKey is the subscription metadata property that enables transactions for the subscription.
+	transactionalKey = "transactional"
+
+	// transactionIDKey is set in the metadata of the messages received by transactional subscriptions.
+	// Messages published with the same value in their metadata are part of the transaction.
+	transactionIDKey = "transactionId"
+
+	defaultTransactionTimeout = time.Minute
+)
+
+// handleMessageInTransaction invokes the handler within a transaction, which is exposed to the handler with the
+// "transactionId" message metadata property.
+// The messages published with the transaction ID and the acknowledgement of the received message are committed
+// atomically if the handler succeeds; otherwise, the transaction is aborted and the message is negatively acknowledged.
+func (p *Pulsar) handleMessageInTransaction(ctx context.Context, originTopic string, msg pulsar.ConsumerMessage, handler pubsub.Handler) error {
+	txn, err := p.client.NewTransaction(p.metadata.TransactionTimeout)
+	if err != nil {
+		msg.Nack(msg.Message)
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+	txnID := formatTxnID(txn.GetTxnID())
+	p.transactions.Store(txnID, txn)
+	defer p.transactions.Delete(txnID)
+
+	md := make(map[string]string, len(msg.Properties())+1)
+	for k, v := range msg.Properties() {
+		md[k] = v
+	}
+	md[transactionIDKey] = txnID
+
+	p.logger.Debugf("Processing Pulsar message %s/%#v in transaction %s", msg.Topic(), msg.ID(), txnID)
+	err = handler(ctx, &pubsub.NewMessage{
+		Data:     msg.Payload(),
+		Topic:    originTopic,
+		Metadata: md,
+	})
+	if err == nil {
+		err = msg.Consumer.AckWithTxn(msg.Message, txn)
+		if err == nil {
+			err = txn.Commit(ctx)
+		}
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("could not commit transaction %s: %w", txnID, err)
+	}
+
+	// Use a separate context, so the transaction is aborted even if the subscription is stopping
+	abortCtx, abortCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer abortCancel()
+	abortErr := txn.Abort(abortCtx)
+	if abortErr != nil {
+		p.logger.Warnf("Could not abort transaction %s: %v", txnID, abortErr)
+	}
+	msg.Nack(msg.Message)
+	return err
+}
+
+// publishTransaction returns the open transaction that a publish request is part of, if any.
+func (p *Pulsar) publishTransaction(req *pubsub.PublishRequest) (pulsar.Transaction, error) {
+	txnID := req.Metadata[transactionIDKey]
+	if txnID == "" {
+		return nil, nil
+	}
+	txn, ok := p.transactions.Load(txnID)
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found: it is completed, or it was not started by this component", txnID)
+	}
+	return txn.(pulsar.Transaction), nil
+}
+
+func formatTxnID(id pulsar.TxnID) string {
+	return strconv.FormatUint(id.MostSigBits, 10) + ":" + strconv.FormatUint(id.LeastSigBits, 10)
+}
+
+func (p *Pulsar) isTransactional(req pubsub.SubscribeRequest) (bool, error) {
+	val := req.Metadata[transactionalKey]
+	if val == "" {
+		return false, nil
+	}
+	transactional, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value for topic %s: %w", transactionalKey, req.Topic, err)
+	}
+	if transactional && !p.metadata.EnableTransaction {
+		return false, errors.New("transactional subscriptions require the enableTransaction metadata property")
+	}
+	return transactional, nil
+}
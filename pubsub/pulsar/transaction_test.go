@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pulsar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+type fakeTransaction struct {
+	id pulsar.TxnID
+}
+
+func (t fakeTransaction) Commit(context.Context) error { return nil }
+func (t fakeTransaction) Abort(context.Context) error  { return nil }
+func (t fakeTransaction) GetState() pulsar.TxnState    { return pulsar.TxnOpen }
+func (t fakeTransaction) GetTxnID() pulsar.TxnID       { return t.id }
+
+func TestTransactions(t *testing.T) {
+	t.Run("metadata", func(t *testing.T) {
+		m := pubsub.Metadata{}
+		m.Properties = map[string]string{
+			"host":              "a",
+			"enableTransaction": "true",
+		}
+		meta, err := parsePulsarMetadata(m)
+		require.NoError(t, err)
+		assert.True(t, meta.EnableTransaction)
+		assert.Equal(t, time.Minute, meta.TransactionTimeout)
+	})
+
+	t.Run("transactional subscriptions", func(t *testing.T) {
+		p := &Pulsar{}
+		req := pubsub.SubscribeRequest{Topic: "orders", Metadata: map[string]string{}}
+
+		transactional, err := p.isTransactional(req)
+		require.NoError(t, err)
+		assert.False(t, transactional)
+
+		req.Metadata[transactionalKey] = "true"
+		_, err = p.isTransactional(req)
+		require.ErrorContains(t, err, "enableTransaction")
+
+		p.metadata.EnableTransaction = true
+		transactional, err = p.isTransactional(req)
+		require.NoError(t, err)
+		assert.True(t, transactional)
+
+		req.Metadata[transactionalKey] = "maybe"
+		_, err = p.isTransactional(req)
+		require.Error(t, err)
+	})
+
+	t.Run("publish within a transaction", func(t *testing.T) {
+		p := &Pulsar{}
+		txn := fakeTransaction{id: pulsar.TxnID{MostSigBits: 1, LeastSigBits: 42}}
+		txnID := formatTxnID(txn.GetTxnID())
+		assert.Equal(t, "1:42", txnID)
+		p.transactions.Store(txnID, txn)
+
+		req := &pubsub.PublishRequest{
+			Topic:    "results",
+			Data:     []byte("hello"),
+			Metadata: map[string]string{transactionIDKey: txnID, "foo": "bar"},
+		}
+		got, err := p.publishTransaction(req)
+		require.NoError(t, err)
+		assert.Equal(t, txn, got)
+
+		msg, err := parsePublishMetadata(req, schemaMetadata{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"foo": "bar"}, msg.Properties)
+
+		got, err = p.publishTransaction(&pubsub.PublishRequest{Topic: "results"})
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		p.transactions.Delete(txnID)
+		_, err = p.publishTransaction(req)
+		require.ErrorContains(t, err, "transaction 1:42 not found")
+	})
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// BulkPublish publishes all messages on the channel before waiting for the confirmations, if enabled.
+// Only the messages that failed are published again when retrying.
+func (r *rabbitMQ) BulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
+	if r.closed.Load() {
+		err := errors.New("component is closed")
+		return pubsub.NewBulkPublishResponse(req.Entries, err), err
+	}
+
+	topic := r.topicPrefix.Topic(req.Topic)
+
+	r.logger.Debugf("%s publishing %d messages to %s", logMessagePrefix, len(req.Entries), topic)
+
+	entries := req.Entries
+	attempt := 0
+	for {
+		attempt++
+		channel, connectionCount, failed, err := r.bulkPublishSync(ctx, topic, entries, req.Metadata)
+		if err == nil {
+			return pubsub.BulkPublishResponse{}, nil
+		}
+		if attempt >= publishMaxRetries {
+			r.logger.Errorf("%s bulk publishing failed: %v", logMessagePrefix, err)
+			return pubsub.NewBulkPublishResponse(failed, err), err
+		}
+		entries = failed
+		if mustReconnect(channel, err) {
+			r.logger.Warnf("%s publisher is reconnecting in %s ...", logMessagePrefix, r.metadata.ReconnectWait.String())
+			select {
+			case <-time.After(r.metadata.ReconnectWait):
+			case <-ctx.Done():
+				return pubsub.NewBulkPublishResponse(entries, ctx.Err()), ctx.Err()
+			}
+
+			r.reconnect(r.publisher, connectionCount)
+		} else {
+			r.logger.Warnf("%s bulk publishing attempt (%d/%d) failed: %v", logMessagePrefix, attempt, publishMaxRetries, err)
+			select {
+			case <-time.After(publishRetryWaitSeconds * time.Second):
+			case <-ctx.Done():
+				return pubsub.NewBulkPublishResponse(entries, ctx.Err()), ctx.Err()
+			}
+		}
+	}
+}
+
+// bulkPublishSync publishes the messages and returns the ones that failed.
+func (r *rabbitMQ) bulkPublishSync(ctx context.Context, topic string, entries []pubsub.BulkMessageEntry, reqMetadata map[string]string) (rabbitMQChannelBroker, int, []pubsub.BulkMessageEntry, error) {
+	channel, connectionCount, err := r.publisher.acquire(ctx)
+	if err != nil {
+		return channel, connectionCount, entries, err
+	}
+	defer r.publisher.release(channel, connectionCount)
+
+	if err = r.ensureExchangeDeclared(channel, topic, r.metadata.ExchangeKind, r.metadata.Durable, r.metadata.DeleteWhenUnused); err != nil {
+		r.logger.Errorf("%s publishing to %s failed in ensureExchangeDeclared: %v", logMessagePrefix, topic, err)
+
+		return channel, connectionCount, entries, err
+	}
+
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(entries))
+	for _, entry := range entries {
+		// Metadata of the entry takes precedence over the metadata of the request
+		md := make(map[string]string, len(reqMetadata)+len(entry.Metadata))
+		maps.Copy(md, reqMetadata)
+		maps.Copy(md, entry.Metadata)
+
+		routingKey, p := r.newPublishing(topic, entry.Event, md)
+
+		var confirm *amqp.DeferredConfirmation
+		confirm, err = channel.PublishWithDeferredConfirmWithContext(ctx, topic, routingKey, false, false, p)
+		if err != nil {
+			r.logger.Errorf("%s publishing to %s failed in channel.Publish: %v", logMessagePrefix, topic, err)
+			break
+		}
+		confirms = append(confirms, confirm)
+	}
+
+	// The messages that were not published at all are failed too
+	failed := make([]pubsub.BulkMessageEntry, 0, len(entries)-len(confirms))
+	for i, confirm := range confirms {
+		// confirm will be nil if are not requesting publish confirmations
+		if confirm != nil && !confirm.Wait() {
+			failed = append(failed, entries[i])
+		}
+	}
+	failed = append(failed, entries[len(confirms):]...)
+
+	if err == nil && len(failed) > 0 {
+		err = errors.New("did not receive confirmation of publishing")
+		r.logger.Errorf("%s publishing %d messages to %s failed: %v", logMessagePrefix, len(failed), topic, err)
+	}
+
+	return channel, connectionCount, failed, err
+}
+
+// BulkSubscribe delivers messages to the handler in batches, which are sent when they reach the maximum number of messages
+// or when the maximum await duration has elapsed.
+func (r *rabbitMQ) BulkSubscribe(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.BulkHandler) error {
+	if r.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	req.Topic = r.topicPrefix.Topic(req.Topic)
+	handler = r.topicPrefix.BulkHandler(r.dedup.BulkHandler(handler))
+
+	maxCount := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxMessagesCount, r.metadata.MaxBulkSubCount)
+	maxAwaitDurationMs := commonutils.GetIntValOrDefault(req.BulkSubscribeConfig.MaxAwaitDurationMs, r.metadata.MaxBulkSubAwaitDurationMs)
+	maxAwait := time.Duration(maxAwaitDurationMs) * time.Millisecond
+
+	return r.subscribe(ctx, req, func(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, queueName string, _ subscriptionOptions) error {
+		return r.listenBulkMessages(ctx, channel, msgCh, req.Topic, queueName, maxCount, maxAwait, handler)
+	})
+}
+
+func (r *rabbitMQ) listenBulkMessages(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, topic string, queueName string, maxCount int, maxAwait time.Duration, handler pubsub.BulkHandler) error {
+	batch := make([]amqp.Delivery, 0, maxCount)
+	ticker := time.NewTicker(maxAwait)
+	defer ticker.Stop()
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := r.handleBulkMessages(ctx, batch, topic, queueName, handler)
+		batch = batch[:0]
+		if err != nil && mustReconnect(channel, err) {
+			return err
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, more := <-msgCh:
+			// Handle case of channel closed
+			// Messages in the batch that were not acknowledged are delivered again by the broker
+			if !more {
+				r.logger.Debugf("%s subscriber channel closed for topic %s", logMessagePrefix, topic)
+				return nil
+			}
+
+			batch = append(batch, d)
+			if len(batch) >= maxCount {
+				if err := flush(); err != nil {
+					return err
+				}
+				ticker.Reset(maxAwait)
+			}
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleBulkMessages invokes the handler with a batch of messages, then acknowledges the messages that were processed
+// and handles the ones that failed like single messages.
+// Messages are acknowledged one by one, as acknowledging multiple messages at once would include the pending deliveries
+// of the other subscriptions sharing the channel; acknowledgements are not confirmed by the broker, so this doesn't add
+// round trips.
+func (r *rabbitMQ) handleBulkMessages(ctx context.Context, deliveries []amqp.Delivery, topic string, queueName string, handler pubsub.BulkHandler) error {
+	entries := make([]pubsub.BulkMessageEntry, len(deliveries))
+	for i, d := range deliveries {
+		entries[i] = pubsub.BulkMessageEntry{
+			EntryId:     strconv.Itoa(i),
+			Event:       d.Body,
+			ContentType: d.ContentType,
+			Metadata:    map[string]string{},
+		}
+		if r.metadata.PublishMessagePropertiesToMetadata {
+			entries[i].Metadata = addAMQPPropertiesToMetadata(d)
+		}
+	}
+
+	responses, err := handler(ctx, &pubsub.BulkMessage{
+		Topic:    topic,
+		Entries:  entries,
+		Metadata: map[string]string{},
+	})
+
+	// If the handler failed without reporting the status of each message, all messages are failed
+	var failed map[string]struct{}
+	if err != nil {
+		r.logger.Errorf("%s handling %d messages from topic '%s', %s", errorMessagePrefix, len(deliveries), topic, err)
+
+		failed = make(map[string]struct{}, len(entries))
+		if len(responses) == 0 {
+			for _, entry := range entries {
+				failed[entry.EntryId] = struct{}{}
+			}
+		}
+		for _, res := range responses {
+			if res.Error != nil {
+				failed[res.EntryId] = struct{}{}
+			}
+		}
+	}
+
+	var settleErr error
+	for i, d := range deliveries {
+		if _, ok := failed[entries[i].EntryId]; !ok {
+			err = r.ackMessage(d, topic)
+		} else if len(r.metadata.retryTiers) > 0 {
+			err = r.retryMessage(ctx, d, topic, queueName)
+		} else {
+			err = r.nackMessage(d, topic)
+		}
+		if err != nil && settleErr == nil {
+			settleErr = err
+		}
+	}
+
+	return settleErr
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+func TestBulkPublish(t *testing.T) {
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:             "anyhost",
+			metadataConsumerIDKey:           "consumer",
+			metadataReconnectWaitSecondsKey: "0",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+
+	t.Run("all messages are published", func(t *testing.T) {
+		broker.published = nil
+		res, err := pubsubRabbitMQ.BulkPublish(t.Context(), &pubsub.BulkPublishRequest{
+			Topic:    "mytopic",
+			Metadata: map[string]string{"messageID": "request", "routingKey": "key"},
+			Entries: []pubsub.BulkMessageEntry{
+				{EntryId: "1", Event: []byte("hello")},
+				{EntryId: "2", Event: []byte("world"), Metadata: map[string]string{"messageID": "entry"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.FailedEntries)
+
+		require.Len(t, broker.published, 2)
+		assert.Equal(t, "hello", string(broker.published[0].Body))
+		assert.Equal(t, "request", broker.published[0].MessageId)
+		assert.Equal(t, "world", string(broker.published[1].Body))
+		assert.Equal(t, "entry", broker.published[1].MessageId)
+		assert.Equal(t, "mytopic", broker.lastExchange)
+		assert.Equal(t, "key", broker.lastRoutingKey)
+	})
+
+	t.Run("only failed messages are retried", func(t *testing.T) {
+		broker.published = nil
+		res, err := pubsubRabbitMQ.BulkPublish(t.Context(), &pubsub.BulkPublishRequest{
+			Topic: "mytopic",
+			Entries: []pubsub.BulkMessageEntry{
+				{EntryId: "1", Event: []byte("hello")},
+				{EntryId: "2", Event: []byte(errorChannelConnection)},
+				{EntryId: "3", Event: []byte("world")},
+			},
+		})
+		require.Error(t, err)
+
+		// Messages after the one that failed are not published on the broken channel
+		require.Len(t, res.FailedEntries, 2)
+		assert.Equal(t, "2", res.FailedEntries[0].EntryId)
+		assert.Equal(t, "3", res.FailedEntries[1].EntryId)
+		require.Len(t, broker.published, 1)
+		assert.Equal(t, "hello", string(broker.published[0].Body))
+	})
+}
+
+func TestBulkSubscribe(t *testing.T) {
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	pubsubRabbitMQ.metadata = &rabbitmqMetadata{}
+
+	t.Run("messages are delivered in batches", func(t *testing.T) {
+		broker.acked, broker.nacked = 0, 0
+
+		msgCh := make(chan amqp.Delivery, 5)
+		for _, body := range []string{"a", "b", "c", "d", "e"} {
+			msgCh <- amqp.Delivery{Acknowledger: broker, Body: []byte(body)}
+		}
+		close(msgCh)
+
+		var batches [][]string
+		handler := func(ctx context.Context, msg *pubsub.BulkMessage) ([]pubsub.BulkSubscribeResponseEntry, error) {
+			assert.Equal(t, "mytopic", msg.Topic)
+			batch := make([]string, len(msg.Entries))
+			for i, entry := range msg.Entries {
+				batch[i] = string(entry.Event)
+			}
+			batches = append(batches, batch)
+
+			if len(batches) > 1 {
+				return nil, nil
+			}
+			// The second message of the first batch fails
+			return []pubsub.BulkSubscribeResponseEntry{
+				{EntryId: msg.Entries[0].EntryId},
+				{EntryId: msg.Entries[1].EntryId, Error: errors.New("failed")},
+			}, errors.New("failed")
+		}
+
+		err := pubsubRabbitMQ.listenBulkMessages(t.Context(), broker, msgCh, "mytopic", "myqueue", 2, time.Minute, handler)
+		require.NoError(t, err)
+
+		// The incomplete batch is not delivered once the channel is closed, and is delivered again by the broker
+		assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}}, batches)
+		assert.Equal(t, 3, broker.acked)
+		assert.Equal(t, 1, broker.nacked)
+	})
+
+	t.Run("incomplete batches are delivered after the await duration", func(t *testing.T) {
+		broker.acked, broker.nacked = 0, 0
+
+		msgCh := make(chan amqp.Delivery, 1)
+		msgCh <- amqp.Delivery{Acknowledger: broker, Body: []byte("a")}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		handler := func(ctx context.Context, msg *pubsub.BulkMessage) ([]pubsub.BulkSubscribeResponseEntry, error) {
+			assert.Len(t, msg.Entries, 1)
+			cancel()
+			return nil, nil
+		}
+
+		err := pubsubRabbitMQ.listenBulkMessages(ctx, broker, msgCh, "mytopic", "myqueue", 10, 50*time.Millisecond, handler)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, broker.acked)
+	})
+}
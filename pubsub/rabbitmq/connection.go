@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	connectionNamePublisher = "publisher"
+	connectionNameConsumer  = "consumer"
+)
+
+// rabbitMQConnection is a connection to the broker and the channels opened on it.
+// Publishing and consuming use separate connections: the broker applies flow control to connections that publish
+// faster than it can handle, which would otherwise block the deliveries to the consumers sharing the connection.
+type rabbitMQConnection struct {
+	// name is "publisher" or "consumer"
+	name string
+	// Number of channels to open on the connection
+	poolSize int
+	// Whether to put the channels in confirm mode, if publisher confirms are enabled
+	confirm bool
+
+	lock            sync.RWMutex
+	connection      rabbitMQConnectionBroker
+	channels        []rabbitMQChannelBroker
+	idle            chan rabbitMQChannelBroker
+	connectionCount int
+}
+
+func newRabbitMQConnection(name string, poolSize int, confirm bool) *rabbitMQConnection {
+	return &rabbitMQConnection{
+		name:     name,
+		poolSize: poolSize,
+		confirm:  confirm,
+	}
+}
+
+// acquire takes a channel from the pool for exclusive use, waiting for one to be released if they are all in use.
+// The channel must be released once done.
+func (c *rabbitMQConnection) acquire(ctx context.Context) (rabbitMQChannelBroker, int, error) {
+	c.lock.RLock()
+	idle, connectionCount := c.idle, c.connectionCount
+	c.lock.RUnlock()
+
+	if idle == nil {
+		return nil, connectionCount, errors.New(errorChannelNotInitialized)
+	}
+
+	select {
+	case channel, ok := <-idle:
+		// The pool is closed when the connection is reset
+		if !ok {
+			return nil, connectionCount, errors.New(errorChannelNotInitialized)
+		}
+		return channel, connectionCount, nil
+	case <-ctx.Done():
+		return nil, connectionCount, ctx.Err()
+	}
+}
+
+// release returns a channel to the pool, unless the connection it was opened on has been reset since.
+func (c *rabbitMQConnection) release(channel rabbitMQChannelBroker, connectionCount int) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.idle != nil && connectionCount == c.connectionCount {
+		c.idle <- channel
+	}
+}
+
+// shared returns the first channel, which is shared by all users of the connection.
+// this function call should be wrapped by lock.
+func (c *rabbitMQConnection) shared() rabbitMQChannelBroker {
+	if len(c.channels) == 0 {
+		return nil
+	}
+	return c.channels[0]
+}
+
+// reconnect re-establishes the connection, unless it was already re-established since connectionCount was read.
+func (r *rabbitMQ) reconnect(c *rabbitMQConnection, connectionCount int) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if r.isStopped() {
+		// Do not reconnect on stopped service.
+		return errors.New("cannot connect after component is stopped")
+	}
+
+	r.logger.Infof("%s %s connectionCount: current=%d reference=%d", logMessagePrefix, c.name, c.connectionCount, connectionCount)
+	if connectionCount != c.connectionCount {
+		// Reconnection request is old.
+		r.logger.Infof("%s stale reconnect attempt", logMessagePrefix)
+
+		return nil
+	}
+
+	err := r.reset(c)
+	if err != nil {
+		return err
+	}
+
+	tlsCfg, err := pubsub.ConvertTLSPropertiesToTLSConfig(r.metadata.TLSProperties)
+	if err != nil {
+		return err
+	}
+
+	// The connections are named after their role, so they can be told apart in the management UI
+	clientName := r.metadata.ClientName
+	if clientName != "" {
+		clientName += "-" + c.name
+	}
+
+	sasl, err := r.saslMechanisms()
+	if err != nil {
+		return err
+	}
+
+	c.connection, err = r.connectionDial(r.metadata.internalProtocol, r.metadata.connectionURI(), clientName, r.metadata.HeartBeat, tlsCfg, sasl)
+	if err != nil {
+		r.reset(c)
+
+		return err
+	}
+
+	c.channels = make([]rabbitMQChannelBroker, 0, c.poolSize)
+	for range c.poolSize {
+		var channel rabbitMQChannelBroker
+		channel, err = c.connection.Channel()
+		if err != nil {
+			r.reset(c)
+
+			return err
+		}
+		c.channels = append(c.channels, channel)
+
+		if c.confirm && r.metadata.PublisherConfirm {
+			err = channel.Confirm(false)
+			if err != nil {
+				r.reset(c)
+
+				return err
+			}
+		}
+	}
+
+	c.idle = make(chan rabbitMQChannelBroker, len(c.channels))
+	for _, channel := range c.channels {
+		c.idle <- channel
+	}
+
+	c.connectionCount++
+
+	r.logger.Infof("%s %s connected with connectionCount=%d", logMessagePrefix, c.name, c.connectionCount)
+
+	return nil
+}
+
+// this function call should be wrapped by the lock of the connection.
+func (r *rabbitMQ) reset(c *rabbitMQConnection) (err error) {
+	r.exchangesMutex.Lock()
+	if len(r.declaredExchanges) > 0 {
+		r.declaredExchanges = make(map[string]bool)
+	}
+	r.exchangesMutex.Unlock()
+
+	if c.idle != nil {
+		// Wakes up the publishers waiting for a channel
+		close(c.idle)
+		c.idle = nil
+	}
+	for _, channel := range c.channels {
+		if err2 := channel.Close(); err2 != nil {
+			r.logger.Errorf("%s reset: %s channel.Close() failed: %v", logMessagePrefix, c.name, err2)
+			if err == nil {
+				err = err2
+			}
+		}
+	}
+	c.channels = nil
+	if c.connection != nil {
+		if err2 := c.connection.Close(); err2 != nil {
+			r.logger.Errorf("%s reset: %s connection.Close() failed: %v", logMessagePrefix, c.name, err2)
+			if err == nil {
+				err = err2
+			}
+		}
+		c.connection = nil
+	}
+
+	return
+}
+
+func (r *rabbitMQ) closeConnection(c *rabbitMQConnection) error {
+	if c == nil {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return r.reset(c)
+}
+
+// amqpConnection adapts amqp.Connection to rabbitMQConnectionBroker.
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) Channel() (rabbitMQChannelBroker, error) {
+	channel, err := c.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// multiChannelConnection opens a different channel each time.
+type multiChannelConnection struct {
+	channels        []*rabbitMQInMemoryBroker
+	secret          string
+	updateSecretErr error
+}
+
+func (c *multiChannelConnection) Channel() (rabbitMQChannelBroker, error) {
+	channel := newBroker()
+	c.channels = append(c.channels, channel)
+	return channel, nil
+}
+
+func (c *multiChannelConnection) UpdateSecret(newSecret, reason string) error {
+	if c.updateSecretErr != nil {
+		return c.updateSecretErr
+	}
+	c.secret = newSecret
+	return nil
+}
+
+func (c *multiChannelConnection) Close() error {
+	return nil
+}
+
+func TestSeparateConnections(t *testing.T) {
+	pubsubRabbitMQ := newRabbitMQTest(newBroker())
+	var clientNames []string
+	pubsubRabbitMQ.connectionDial = func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error) {
+		clientNames = append(clientNames, clientName)
+		return &multiChannelConnection{}, nil
+	}
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:                 "anyhost",
+			metadataConsumerIDKey:               "consumer",
+			metadataClientNameKey:               "myapp",
+			metadataPublisherChannelPoolSizeKey: "2",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+	defer pubsubRabbitMQ.Close()
+
+	assert.Equal(t, []string{"myapp-publisher", "myapp-consumer"}, clientNames)
+	assert.Len(t, pubsubRabbitMQ.publisher.connection.(*multiChannelConnection).channels, 2)
+	assert.Len(t, pubsubRabbitMQ.consumer.connection.(*multiChannelConnection).channels, 1)
+}
+
+func TestPublisherChannelPool(t *testing.T) {
+	pubsubRabbitMQ := newRabbitMQTest(newBroker())
+	pubsubRabbitMQ.connectionDial = func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error) {
+		return &multiChannelConnection{}, nil
+	}
+	pubsubRabbitMQ.metadata = &rabbitmqMetadata{}
+	pool := newRabbitMQConnection(connectionNamePublisher, 2, true)
+	err := pubsubRabbitMQ.reconnect(pool, 0)
+	require.NoError(t, err)
+
+	t.Run("channels are used by one publisher at a time", func(t *testing.T) {
+		ch1, count, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+		ch2, _, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+		assert.NotSame(t, ch1, ch2)
+
+		// All channels are in use
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		_, _, err = pool.acquire(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		pool.release(ch1, count)
+		ch3, _, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+		assert.Same(t, ch1, ch3)
+
+		pool.release(ch2, count)
+		pool.release(ch3, count)
+	})
+
+	t.Run("reconnecting wakes up waiting publishers", func(t *testing.T) {
+		ch1, count, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+		ch2, _, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, _, err := pool.acquire(t.Context())
+			errCh <- err
+		}()
+		// Wait for the publisher to be waiting for a channel
+		time.Sleep(100 * time.Millisecond)
+
+		err = pubsubRabbitMQ.reconnect(pool, count)
+		require.NoError(t, err)
+		select {
+		case err = <-errCh:
+			require.ErrorContains(t, err, errorChannelNotInitialized)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timeout waiting for publisher")
+		}
+
+		// Channels of the previous connection are not returned to the pool
+		pool.release(ch1, count)
+		pool.release(ch2, count)
+		assert.Len(t, pool.idle, 2)
+		ch, _, err := pool.acquire(t.Context())
+		require.NoError(t, err)
+		assert.NotSame(t, ch1, ch)
+		assert.NotSame(t, ch2, ch)
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// redeliverMessage applies the dead-letter policy to a message that failed to be processed.
+// Classic queues don't count deliveries, so the message is republished to its queue with the number of retries in a
+// header, until it was delivered maxDeliveries times. It's then published to the dead-letter topic if one is set, or
+// rejected otherwise, which routes it to the dead letter exchange of the queue when enableDeadLetter is true.
+func (r *rabbitMQ) redeliverMessage(ctx context.Context, d amqp.Delivery, msg *pubsub.NewMessage, topic string, queueName string, handlerErr error) error {
+	retryCount := deliveryRetryCount(d)
+	deliveries := retryCount + 1
+
+	if !r.deadLetter.Exhausted(deliveries) {
+		r.deadLetter.WaitBackoff(ctx)
+		err := r.publishRetry(ctx, d, queueName, retryCount+1)
+		if err != nil {
+			r.logger.Errorf("%s error republishing message '%s' from topic '%s' to queue '%s', %s", logMessagePrefix, d.MessageId, topic, queueName, err)
+			if nackErr := r.nackMessage(d, topic); nackErr != nil {
+				return nackErr
+			}
+			return err
+		}
+		r.logger.Debugf("%s republished message '%s' from topic '%s' after %d deliveries", logMessagePrefix, d.MessageId, topic, deliveries)
+		return r.ackMessage(d, topic)
+	}
+
+	if r.deadLetter.DeadLetterTopic == "" {
+		r.logger.Warnf("%s rejecting message '%s' from topic '%s' after %d deliveries", logMessagePrefix, d.MessageId, topic, deliveries)
+		err := d.Nack(false, false)
+		if err != nil {
+			r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+		}
+		return err
+	}
+
+	err := r.deadLetter.HandleFailure(ctx, msg, deliveries, handlerErr, r.publishDeadLetter)
+	if err != nil {
+		// The message is requeued so publishing it to the dead-letter topic is attempted again
+		r.logger.Errorf("%s %s", errorMessagePrefix, err)
+		if nackErr := d.Nack(false, true); nackErr != nil {
+			r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, nackErr)
+		}
+		return err
+	}
+	r.logger.Warnf("%s moved message '%s' from topic '%s' to dead-letter topic '%s' after %d deliveries", logMessagePrefix, d.MessageId, topic, r.deadLetter.DeadLetterTopic, deliveries)
+	return r.ackMessage(d, topic)
+}
+
+// publishDeadLetter publishes a message to the dead-letter topic, which isn't prefixed.
+func (r *rabbitMQ) publishDeadLetter(ctx context.Context, req *pubsub.PublishRequest) error {
+	channel, connectionCount, err := r.publishSync(ctx, req)
+	if err != nil && ctx.Err() == nil && mustReconnect(channel, err) {
+		r.reconnect(r.publisher, connectionCount)
+	}
+	return err
+}
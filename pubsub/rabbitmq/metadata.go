@@ -14,12 +14,15 @@ limitations under the License.
 package rabbitmq
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/dapr/components-contrib/common/authentication/oauth2"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/kit/logger"
@@ -28,6 +31,7 @@ import (
 
 type rabbitmqMetadata struct {
 	pubsub.TLSProperties               `mapstructure:",squash"`
+	oauth2.ClientCredentialsMetadata   `mapstructure:",squash"`
 	ConsumerID                         string                 `mapstructure:"consumerID" mdignore:"true"`
 	ConnectionString                   string                 `mapstructure:"connectionString"`
 	Protocol                           string                 `mapstructure:"protocol"`
@@ -37,6 +41,9 @@ type rabbitmqMetadata struct {
 	Password                           string                 `mapstructure:"password"`
 	Durable                            bool                   `mapstructure:"durable"`
 	EnableDeadLetter                   bool                   `mapstructure:"enableDeadLetter"`
+	DeadLetterExchange                 string                 `mapstructure:"deadLetterExchange"`
+	RetryTiers                         string                 `mapstructure:"retryTiers"`
+	retryTiers                         []retryTier            `mapstructure:"-"`
 	DeleteWhenUnused                   bool                   `mapstructure:"deletedWhenUnused"`
 	AutoAck                            bool                   `mapstructure:"autoAck"`
 	RequeueInFailure                   bool                   `mapstructure:"requeueInFailure"`
@@ -49,10 +56,13 @@ type rabbitmqMetadata struct {
 	ClientName                         string                 `mapstructure:"clientName"`
 	HeartBeat                          time.Duration          `mapstructure:"heartBeat"`
 	PublisherConfirm                   bool                   `mapstructure:"publisherConfirm"`
+	PublisherChannelPoolSize           int                    `mapstructure:"publisherChannelPoolSize"`
 	SaslExternal                       bool                   `mapstructure:"saslExternal"`
 	Concurrency                        pubsub.ConcurrencyMode `mapstructure:"concurrency"`
 	DefaultQueueTTL                    *time.Duration         `mapstructure:"ttlInSeconds"`
 	PublishMessagePropertiesToMetadata bool                   `mapstructure:"publishMessagePropertiesToMetadata"`
+	MaxBulkSubCount                    int                    `mapstructure:"maxBulkSubCount"`
+	MaxBulkSubAwaitDurationMs          int                    `mapstructure:"maxBulkSubAwaitDurationMs"`
 }
 
 const (
@@ -68,6 +78,8 @@ const (
 
 	metadataDurableKey                            = "durable"
 	metadataEnableDeadLetterKey                   = "enableDeadLetter"
+	metadataDeadLetterExchangeKey                 = "deadLetterExchange"
+	metadataRetryTiersKey                         = "retryTiers"
 	metadataDeleteWhenUnusedKey                   = "deletedWhenUnused"
 	metadataAutoAckKey                            = "autoAck"
 	metadataRequeueInFailureKey                   = "requeueInFailure"
@@ -78,14 +90,20 @@ const (
 	metadataMaxLenBytesKey                        = "maxLenBytes"
 	metadataExchangeKindKey                       = "exchangeKind"
 	metadataPublisherConfirmKey                   = "publisherConfirm"
+	metadataPublisherChannelPoolSizeKey           = "publisherChannelPoolSize"
 	metadataSaslExternal                          = "saslExternal"
 	metadataMaxPriority                           = "maxPriority"
 	metadataClientNameKey                         = "clientName"
 	metadataHeartBeatKey                          = "heartBeat"
 	metadataQueueNameKey                          = "queueName"
 	metadataPublishMessagePropertiesToMetadataKey = "publishMessagePropertiesToMetadata"
+	metadataMaxBulkSubCountKey                    = "maxBulkSubCount"
+	metadataMaxBulkSubAwaitDurationMsKey          = "maxBulkSubAwaitDurationMs"
 
-	defaultReconnectWaitSeconds = 3
+	defaultReconnectWaitSeconds      = 3
+	defaultPublisherChannelPoolSize  = 4
+	defaultMaxBulkSubCount           = 100
+	defaultMaxBulkSubAwaitDurationMs = 1000
 
 	protocolAMQP  = "amqp"
 	protocolAMQPS = "amqps"
@@ -102,9 +120,12 @@ func createMetadata(pubSubMetadata pubsub.Metadata, log logger.Logger) (*rabbitm
 		ReconnectWait:                      time.Duration(defaultReconnectWaitSeconds) * time.Second,
 		ExchangeKind:                       fanoutExchangeKind,
 		PublisherConfirm:                   false,
+		PublisherChannelPoolSize:           defaultPublisherChannelPoolSize,
 		SaslExternal:                       false,
 		HeartBeat:                          defaultHeartbeat,
 		PublishMessagePropertiesToMetadata: false,
+		MaxBulkSubCount:                    defaultMaxBulkSubCount,
+		MaxBulkSubAwaitDurationMs:          defaultMaxBulkSubAwaitDurationMs,
 	}
 
 	// upgrade metadata
@@ -161,10 +182,75 @@ func createMetadata(pubSubMetadata pubsub.Metadata, log logger.Logger) (*rabbitm
 		return &result, fmt.Errorf("%s can only be set to true, when all these properties are set: %s, %s, %s", metadataSaslExternal, pubsub.CACert, pubsub.ClientCert, pubsub.ClientKey)
 	}
 
+	if result.PublisherChannelPoolSize < 1 {
+		return &result, fmt.Errorf("%s %s must be greater than 0", errorMessagePrefix, metadataPublisherChannelPoolSizeKey)
+	}
+
+	if result.MaxBulkSubCount < 1 {
+		return &result, fmt.Errorf("%s %s must be greater than 0", errorMessagePrefix, metadataMaxBulkSubCountKey)
+	}
+
+	if result.MaxBulkSubAwaitDurationMs < 1 {
+		return &result, fmt.Errorf("%s %s must be greater than 0", errorMessagePrefix, metadataMaxBulkSubAwaitDurationMsKey)
+	}
+
+	if result.ClientCredentialsMetadata.TokenURL != "" && result.SaslExternal {
+		return &result, fmt.Errorf("%s oauth2TokenURL cannot be used when %s is set to true", errorMessagePrefix, metadataSaslExternal)
+	}
+
+	result.retryTiers, err = parseRetryTiers(result.RetryTiers)
+	if err != nil {
+		return &result, fmt.Errorf("%s invalid %s: %w", errorMessagePrefix, metadataRetryTiersKey, err)
+	}
+	if len(result.retryTiers) > 0 {
+		if !result.EnableDeadLetter {
+			return &result, fmt.Errorf("%s %s requires %s to be set to true", errorMessagePrefix, metadataRetryTiersKey, metadataEnableDeadLetterKey)
+		}
+		if result.AutoAck {
+			return &result, fmt.Errorf("%s %s cannot be used when %s is set to true", errorMessagePrefix, metadataRetryTiersKey, metadataAutoAckKey)
+		}
+		if result.RequeueInFailure {
+			log.Warnf("%s %s is ignored when %s is set", logMessagePrefix, metadataRequeueInFailureKey, metadataRetryTiersKey)
+		}
+	}
+
 	result.Concurrency, err = pubsub.Concurrency(pubSubMetadata.Properties)
 	return &result, err
 }
 
+// retryTier is a delayed retry queue that failed messages wait in before being delivered again.
+type retryTier struct {
+	// name is used in the name of the retry queue, for example "5s"
+	name  string
+	delay time.Duration
+}
+
+// parseRetryTiers parses a comma-separated list of delays, such as "5s,1m,10m".
+func parseRetryTiers(val string) ([]retryTier, error) {
+	if strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(val, ",")
+	tiers := make([]retryTier, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		delay, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		// RabbitMQ message TTLs are expressed in milliseconds
+		if delay < time.Millisecond {
+			return nil, errors.New("delays must be at least 1ms")
+		}
+		tiers[i] = retryTier{
+			name:  part,
+			delay: delay,
+		}
+	}
+	return tiers, nil
+}
+
 func (m *rabbitmqMetadata) formatQueueDeclareArgs(origin amqp.Table) amqp.Table {
 	if origin == nil {
 		origin = amqp.Table{}
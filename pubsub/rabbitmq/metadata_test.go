@@ -536,3 +536,94 @@ func TestConnectionURI(t *testing.T) {
 		assert.Equal(t, testCase.expectedOutput, m.connectionURI())
 	}
 }
+
+func TestCreateMetadataRetryTiers(t *testing.T) {
+	log := logger.NewLogger("test")
+
+	t.Run("retry tiers", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties[metadataEnableDeadLetterKey] = "true"
+		fakeMetaData.Properties[metadataRetryTiersKey] = "5s, 1m,10m"
+
+		m, err := createMetadata(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, []retryTier{
+			{name: "5s", delay: 5 * time.Second},
+			{name: "1m", delay: time.Minute},
+			{name: "10m", delay: 10 * time.Minute},
+		}, m.retryTiers)
+	})
+
+	t.Run("no retry tiers", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+
+		m, err := createMetadata(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Empty(t, m.retryTiers)
+	})
+
+	t.Run("invalid delay", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties[metadataEnableDeadLetterKey] = "true"
+		fakeMetaData.Properties[metadataRetryTiersKey] = "5s,soon"
+
+		_, err := createMetadata(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "invalid retryTiers")
+	})
+
+	t.Run("requires dead letter", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties[metadataRetryTiersKey] = "5s"
+
+		_, err := createMetadata(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "requires enableDeadLetter")
+	})
+
+	t.Run("not allowed with auto ack", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties[metadataEnableDeadLetterKey] = "true"
+		fakeMetaData.Properties[metadataAutoAckKey] = "true"
+		fakeMetaData.Properties[metadataRetryTiersKey] = "5s"
+
+		_, err := createMetadata(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "cannot be used when autoAck")
+	})
+}
+
+func TestCreateMetadataOAuth2(t *testing.T) {
+	log := logger.NewLogger("test")
+
+	t.Run("client credentials", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["oauth2TokenURL"] = "https://keycloak/realms/dapr/protocol/openid-connect/token"
+		fakeMetaData.Properties["oauth2ClientID"] = "dapr"
+		fakeMetaData.Properties["oauth2ClientSecret"] = "secret"
+		fakeMetaData.Properties["oauth2Scopes"] = "rabbitmq.read:*/*,rabbitmq.write:*/*"
+		fakeMetaData.Properties["oauth2Audiences"] = "rabbitmq"
+
+		m, err := createMetadata(fakeMetaData, log)
+
+		require.NoError(t, err)
+		assert.Equal(t, "dapr", m.ClientCredentialsMetadata.ClientID)
+		assert.Equal(t, []string{"rabbitmq.read:*/*", "rabbitmq.write:*/*"}, m.ClientCredentialsMetadata.Scopes)
+		assert.Equal(t, []string{"rabbitmq"}, m.ClientCredentialsMetadata.Audiences)
+	})
+
+	t.Run("not allowed with external sasl", func(t *testing.T) {
+		fakeMetaData := pubsub.Metadata{Base: mdata.Base{Properties: getFakeProperties()}}
+		fakeMetaData.Properties["oauth2TokenURL"] = "https://keycloak/realms/dapr/protocol/openid-connect/token"
+		fakeMetaData.Properties[metadataSaslExternal] = "true"
+		fakeMetaData.Properties[pubsub.CACert] = getFakeCaCert()
+		fakeMetaData.Properties[pubsub.ClientCert] = getFakeClientCert()
+		fakeMetaData.Properties[pubsub.ClientKey] = getFakeClientKey()
+
+		_, err := createMetadata(fakeMetaData, log)
+
+		require.ErrorContains(t, err, "oauth2TokenURL cannot be used when saslExternal")
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// Tokens are renewed this long before they expire, or when 80% of their lifetime has passed if they are shorter-lived.
+	oauth2TokenRefreshBuffer = time.Minute
+	// Minimum time between attempts to renew a token.
+	oauth2TokenRetryWait = 5 * time.Second
+	oauth2TokenTimeout   = 30 * time.Second
+)
+
+// tokenSource provides the OAuth2 access tokens used to authenticate with the broker's OAuth 2.0 backend.
+type tokenSource interface {
+	TokenWithExpiry() (string, time.Time, error)
+	RenewToken(ctx context.Context) error
+}
+
+// saslMechanisms returns the mechanisms used to authenticate, or nil to use the credentials in the connection URI.
+func (r *rabbitMQ) saslMechanisms() ([]amqp.Authentication, error) {
+	switch {
+	case r.tokenSource != nil:
+		// The broker reads the token from the password, and ignores the username
+		token, _, err := r.tokenSource.TokenWithExpiry()
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to get oauth2 token: %w", errorMessagePrefix, err)
+		}
+		return []amqp.Authentication{&amqp.PlainAuth{
+			Username: r.metadata.Username,
+			Password: token,
+		}}, nil
+	case r.metadata.SaslExternal && r.metadata.internalProtocol == protocolAMQPS:
+		return []amqp.Authentication{&amqp.ExternalAuth{}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// refreshTokenForever renews the token before it expires, until the component is closed.
+func (r *rabbitMQ) refreshTokenForever() {
+	for {
+		wait := oauth2TokenRetryWait
+		_, expiry, err := r.tokenSource.TokenWithExpiry()
+		if err != nil {
+			r.logger.Errorf("%s failed to get oauth2 token: %v", logMessagePrefix, err)
+		} else if expiry.IsZero() {
+			r.logger.Infof("%s oauth2 token does not expire and will not be renewed", logMessagePrefix)
+			return
+		} else {
+			wait = tokenRefreshWait(time.Until(expiry))
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-r.closeCh:
+			return
+		}
+
+		err = r.refreshToken()
+		if err != nil {
+			r.logger.Errorf("%s failed to renew oauth2 token: %v", logMessagePrefix, err)
+		}
+	}
+}
+
+// tokenRefreshWait returns how long to wait before renewing a token that expires after remaining.
+func tokenRefreshWait(remaining time.Duration) time.Duration {
+	wait := remaining - min(oauth2TokenRefreshBuffer, remaining/5)
+	return max(wait, oauth2TokenRetryWait)
+}
+
+// refreshToken renews the token, and updates the secret of the open connections with it.
+// The broker closes connections whose token has expired.
+func (r *rabbitMQ) refreshToken() error {
+	ctx, cancel := context.WithTimeout(context.Background(), oauth2TokenTimeout)
+	defer cancel()
+	err := r.tokenSource.RenewToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, _, err := r.tokenSource.TokenWithExpiry()
+	if err != nil {
+		return err
+	}
+
+	r.logger.Debugf("%s renewed oauth2 token", logMessagePrefix)
+	r.updateSecret(r.publisher, token)
+	r.updateSecret(r.consumer, token)
+
+	return nil
+}
+
+// updateSecret updates the token of an open connection, and reconnects if that fails.
+// Connections that are not open use the new token when they are re-established.
+func (r *rabbitMQ) updateSecret(c *rabbitMQConnection, token string) {
+	c.lock.RLock()
+	connection, connectionCount := c.connection, c.connectionCount
+	c.lock.RUnlock()
+
+	if connection == nil {
+		return
+	}
+
+	err := connection.UpdateSecret(token, "oauth2 token renewed")
+	if err != nil {
+		r.logger.Warnf("%s failed to update the secret of the %s connection, reconnecting: %v", logMessagePrefix, c.name, err)
+		if err = r.reconnect(c, connectionCount); err != nil {
+			r.logger.Errorf("%s failed to reconnect the %s connection: %v", logMessagePrefix, c.name, err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTokenSource struct {
+	renewed int
+	expiry  time.Time
+}
+
+func (s *fakeTokenSource) TokenWithExpiry() (string, time.Time, error) {
+	return "token" + strconv.Itoa(s.renewed), s.expiry, nil
+}
+
+func (s *fakeTokenSource) RenewToken(ctx context.Context) error {
+	s.renewed++
+	return nil
+}
+
+func TestOAuth2Token(t *testing.T) {
+	var (
+		dialed      []*multiChannelConnection
+		dialedSASLs [][]amqp.Authentication
+	)
+	pubsubRabbitMQ := newRabbitMQTest(newBroker())
+	pubsubRabbitMQ.connectionDial = func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error) {
+		conn := &multiChannelConnection{}
+		dialed = append(dialed, conn)
+		dialedSASLs = append(dialedSASLs, sasl)
+		return conn, nil
+	}
+	pubsubRabbitMQ.metadata = &rabbitmqMetadata{Username: "myuser"}
+	pubsubRabbitMQ.tokenSource = &fakeTokenSource{expiry: time.Now().Add(time.Hour)}
+	pubsubRabbitMQ.publisher = newRabbitMQConnection(connectionNamePublisher, 1, true)
+	pubsubRabbitMQ.consumer = newRabbitMQConnection(connectionNameConsumer, 1, false)
+
+	require.NoError(t, pubsubRabbitMQ.reconnect(pubsubRabbitMQ.publisher, 0))
+	require.NoError(t, pubsubRabbitMQ.reconnect(pubsubRabbitMQ.consumer, 0))
+
+	t.Run("token is used as password", func(t *testing.T) {
+		require.Len(t, dialedSASLs, 2)
+		for _, sasl := range dialedSASLs {
+			assert.Equal(t, []amqp.Authentication{&amqp.PlainAuth{Username: "myuser", Password: "token0"}}, sasl)
+		}
+	})
+
+	t.Run("renewed token updates the secret of the connections", func(t *testing.T) {
+		err := pubsubRabbitMQ.refreshToken()
+		require.NoError(t, err)
+
+		assert.Equal(t, "token1", dialed[0].secret)
+		assert.Equal(t, "token1", dialed[1].secret)
+		assert.Len(t, dialed, 2)
+	})
+
+	t.Run("connections are re-established if the secret can't be updated", func(t *testing.T) {
+		dialed[1].updateSecretErr = errors.New("connection closed")
+
+		err := pubsubRabbitMQ.refreshToken()
+		require.NoError(t, err)
+
+		assert.Equal(t, "token2", dialed[0].secret)
+		require.Len(t, dialed, 3)
+		assert.Equal(t, []amqp.Authentication{&amqp.PlainAuth{Username: "myuser", Password: "token2"}}, dialedSASLs[2])
+		assert.Same(t, dialed[2], pubsubRabbitMQ.consumer.connection)
+	})
+}
+
+func TestTokenRefreshWait(t *testing.T) {
+	assert.Equal(t, 59*time.Minute, tokenRefreshWait(time.Hour))
+	assert.Equal(t, 4*time.Minute, tokenRefreshWait(5*time.Minute))
+	assert.Equal(t, oauth2TokenRetryWait, tokenRefreshWait(time.Second))
+	assert.Equal(t, oauth2TokenRetryWait, tokenRefreshWait(-time.Second))
+}
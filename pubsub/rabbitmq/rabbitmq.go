@@ -28,9 +28,11 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/dapr/components-contrib/common/authentication/oauth2"
 	common "github.com/dapr/components-contrib/common/component/rabbitmq"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	kitstrings "github.com/dapr/kit/strings"
 )
@@ -44,6 +46,7 @@ const (
 	errorInvalidQueueType           = "invalid queue type"
 	defaultDeadLetterExchangeFormat = "dlx-%s"
 	defaultDeadLetterQueueFormat    = "dlq-%s"
+	defaultRetryQueueFormat         = "%s.retry.%s"
 
 	publishMaxRetries       = 3
 	publishRetryWaitSeconds = 2
@@ -54,27 +57,42 @@ const (
 	argMaxLength                       = "x-max-length"
 	argMaxLengthBytes                  = "x-max-length-bytes"
 	argDeadLetterExchange              = "x-dead-letter-exchange"
+	argDeadLetterRoutingKey            = "x-dead-letter-routing-key"
+	argMessageTTL                      = "x-message-ttl"
 	argMaxPriority                     = "x-max-priority"
 	argSingleActiveConsumer            = "x-single-active-consumer"
+	argHeadersMatch                    = "x-match"
 	propertyClientName                 = "connection_name"
+	headerRetryCount                   = "x-dapr-retry-count"
 	queueModeLazy                      = "lazy"
 	reqMetadataRoutingKey              = "routingKey"
 	reqMetadataQueueTypeKey            = "queueType" // at the moment, only supporting classic and quorum queues
 	reqMetadataSingleActiveConsumerKey = "singleActiveConsumer"
 	reqMetadataMaxLenKey               = "maxLen"
 	reqMetadataMaxLenBytesKey          = "maxLenBytes"
+	reqMetadataPrefetchCountKey        = "prefetchCount"
+	reqMetadataWorkerPoolSizeKey       = "workerPoolSize"
+	reqMetadataHeadersMatchKey         = "headersMatch"
+	// Metadata keys with this prefix set the headers of published messages, and the headers matched by subscriptions
+	reqMetadataHeaderPrefix = "header:"
 )
 
 // RabbitMQ allows sending/receiving messages in pub/sub format.
 type rabbitMQ struct {
-	connection        rabbitMQConnectionBroker
-	channel           rabbitMQChannelBroker
-	channelMutex      sync.RWMutex
-	connectionCount   int
+	publisher         *rabbitMQConnection
+	consumer          *rabbitMQConnection
+	consumeMutex      sync.Mutex
 	metadata          *rabbitmqMetadata
+	topicPrefix       pubsub.TopicPrefix
+	publishLimiter    pubsub.PublishRateLimiter
+	deadLetter        pubsub.DeadLetterPolicy
+	dedup             *pubsub.Deduplicator
 	declaredExchanges map[string]bool
+	exchangesMutex    sync.Mutex
 
-	connectionDial func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, externalSasl bool) (rabbitMQConnectionBroker, rabbitMQChannelBroker, error)
+	tokenSource tokenSource
+
+	connectionDial func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error)
 	closeCh        chan struct{}
 	closed         atomic.Bool
 	wg             sync.WaitGroup
@@ -102,6 +120,8 @@ type rabbitMQChannelBroker interface {
 
 // interface used to allow unit testing.
 type rabbitMQConnectionBroker interface {
+	Channel() (rabbitMQChannelBroker, error)
+	UpdateSecret(newSecret, reason string) error
 	Close() error
 }
 
@@ -115,13 +135,8 @@ func NewRabbitMQ(logger logger.Logger) pubsub.PubSub {
 	}
 }
 
-func dial(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, externalSasl bool) (rabbitMQConnectionBroker, rabbitMQChannelBroker, error) {
-	var (
-		conn *amqp.Connection
-		ch   *amqp.Channel
-		err  error
-		cfg  = amqp.Config{Heartbeat: heartBeat, Locale: defaultLocale} // use default locale of amqp091-go
-	)
+func dial(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error) {
+	cfg := amqp.Config{Heartbeat: heartBeat, Locale: defaultLocale} // use default locale of amqp091-go
 	if len(clientName) > 0 {
 		cfg.Properties = map[string]interface{}{
 			propertyClientName: clientName,
@@ -130,111 +145,98 @@ func dial(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls
 
 	if protocol == protocolAMQPS {
 		cfg.TLSClientConfig = tlsCfg
-		if externalSasl {
-			cfg.SASL = []amqp.Authentication{&amqp.ExternalAuth{}}
-		}
 	}
-	conn, err = amqp.DialConfig(uri, cfg)
+	// If not set, the credentials in the URI are used
+	cfg.SASL = sasl
+	conn, err := amqp.DialConfig(uri, cfg)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	ch, err = conn.Channel()
-	if err != nil {
-		conn.Close()
-		return nil, nil, err
+		return nil, err
 	}
 
-	return conn, ch, nil
+	return amqpConnection{conn}, nil
 }
 
 // Init does metadata parsing and connection creation.
-func (r *rabbitMQ) Init(_ context.Context, metadata pubsub.Metadata) error {
-	meta, err := createMetadata(metadata, r.logger)
+func (r *rabbitMQ) Init(ctx context.Context, metadata pubsub.Metadata) (err error) {
+	metadata.Properties, err = pubsub.ExpandNamespaceProperties(metadata.Properties, metadataConsumerIDKey)
 	if err != nil {
 		return err
 	}
-
-	r.metadata = meta
-
-	if err := r.reconnect(0); err != nil {
+	r.topicPrefix, err = pubsub.NewTopicPrefix(metadata.Properties)
+	if err != nil {
 		return err
 	}
-
-	return nil
-}
-
-func (r *rabbitMQ) reconnect(connectionCount int) error {
-	r.channelMutex.Lock()
-	defer r.channelMutex.Unlock()
-
-	if r.isStopped() {
-		// Do not reconnect on stopped service.
-		return errors.New("cannot connect after component is stopped")
-	}
-
-	r.logger.Infof("%s connectionCount: current=%d reference=%d", logMessagePrefix, r.connectionCount, connectionCount)
-	if connectionCount != r.connectionCount {
-		// Reconnection request is old.
-		r.logger.Infof("%s stale reconnect attempt", logMessagePrefix)
-
-		return nil
+	r.publishLimiter, err = pubsub.NewPublishRateLimiter(metadata.Properties)
+	if err != nil {
+		return err
 	}
-
-	err := r.reset()
+	r.dedup, err = deduplication.New(ctx, metadata.Properties, r.logger)
 	if err != nil {
 		return err
 	}
 
-	tlsCfg, err := pubsub.ConvertTLSPropertiesToTLSConfig(r.metadata.TLSProperties)
+	meta, err := createMetadata(metadata, r.logger)
 	if err != nil {
 		return err
 	}
-
-	r.connection, r.channel, err = r.connectionDial(r.metadata.internalProtocol, r.metadata.connectionURI(), r.metadata.ClientName, r.metadata.HeartBeat, tlsCfg, r.metadata.SaslExternal)
+	r.deadLetter, err = pubsub.NewDeadLetterPolicy(metadata.Properties)
 	if err != nil {
-		r.reset()
-
 		return err
 	}
+	if r.deadLetter.Enabled() && (len(meta.retryTiers) > 0 || meta.AutoAck) {
+		return fmt.Errorf("%s %s cannot be used with %s or %s", errorMessagePrefix, pubsub.MaxDeliveriesKey, metadataRetryTiersKey, metadataAutoAckKey)
+	}
 
-	if r.metadata.PublisherConfirm {
-		err = r.channel.Confirm(false)
+	r.metadata = meta
+	r.publisher = newRabbitMQConnection(connectionNamePublisher, meta.PublisherChannelPoolSize, true)
+	r.consumer = newRabbitMQConnection(connectionNameConsumer, 1, false)
+
+	if meta.ClientCredentialsMetadata.TokenURL != "" {
+		var cc *oauth2.ClientCredentials
+		cc, err = oauth2.NewClientCredentials(ctx, oauth2.ClientCredentialsOptions{
+			Logger:       r.logger,
+			TokenURL:     meta.ClientCredentialsMetadata.TokenURL,
+			CAPEM:        []byte(meta.ClientCredentialsMetadata.TokenCAPEM),
+			ClientID:     meta.ClientCredentialsMetadata.ClientID,
+			ClientSecret: meta.ClientCredentialsMetadata.ClientSecret,
+			Scopes:       meta.ClientCredentialsMetadata.Scopes,
+			Audiences:    meta.ClientCredentialsMetadata.Audiences,
+		})
 		if err != nil {
-			r.reset()
-
-			return err
+			return fmt.Errorf("%s could not instantiate oauth2 token provider: %w", errorMessagePrefix, err)
 		}
+		r.tokenSource = cc
 	}
 
-	r.connectionCount++
+	if err := r.reconnect(r.publisher, 0); err != nil {
+		return err
+	}
+	if err := r.reconnect(r.consumer, 0); err != nil {
+		r.closeConnection(r.publisher)
+		return err
+	}
 
-	r.logger.Infof("%s connected with connectionCount=%d", logMessagePrefix, r.connectionCount)
+	if r.tokenSource != nil {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.refreshTokenForever()
+		}()
+	}
 
 	return nil
 }
 
-func (r *rabbitMQ) publishSync(ctx context.Context, req *pubsub.PublishRequest) (rabbitMQChannelBroker, int, error) {
-	r.channelMutex.Lock()
-	defer r.channelMutex.Unlock()
-
-	if r.channel == nil {
-		return r.channel, r.connectionCount, errors.New(errorChannelNotInitialized)
-	}
-
-	if err := r.ensureExchangeDeclared(r.channel, req.Topic, r.metadata.ExchangeKind, r.metadata.Durable, r.metadata.DeleteWhenUnused); err != nil {
-		r.logger.Errorf("%s publishing to %s failed in ensureExchangeDeclared: %v", logMessagePrefix, req.Topic, err)
-
-		return r.channel, r.connectionCount, err
-	}
+// newPublishing returns the routing key and the message to publish to the topic, built from the request metadata.
+func (r *rabbitMQ) newPublishing(topic string, data []byte, md map[string]string) (string, amqp.Publishing) {
 	routingKey := ""
-	if val, ok := req.Metadata[reqMetadataRoutingKey]; ok && val != "" {
+	if val, ok := md[reqMetadataRoutingKey]; ok && val != "" {
 		routingKey = val
 	}
 
-	ttl, ok, err := metadata.TryGetTTL(req.Metadata)
+	ttl, ok, err := metadata.TryGetTTL(md)
 	if err != nil {
-		r.logger.Warnf("%s publishing to %s failed to parse TryGetTTL: %v, it is ignored.", logMessagePrefix, req.Topic, err)
+		r.logger.Warnf("%s publishing to %s failed to parse TryGetTTL: %v, it is ignored.", logMessagePrefix, topic, err)
 	}
 	var expiration string
 	if ok {
@@ -246,27 +248,53 @@ func (r *rabbitMQ) publishSync(ctx context.Context, req *pubsub.PublishRequest)
 
 	p := amqp.Publishing{
 		ContentType:  "text/plain",
-		Body:         req.Data,
+		Body:         data,
 		DeliveryMode: r.metadata.DeliveryMode,
 		Expiration:   expiration,
 	}
 
-	priority, ok, err := metadata.TryGetPriority(req.Metadata)
+	priority, ok, err := metadata.TryGetPriority(md)
 	if err != nil {
-		r.logger.Warnf("%s publishing to %s failed to parse priority: %v, it is ignored.", logMessagePrefix, req.Topic, err)
+		r.logger.Warnf("%s publishing to %s failed to parse priority: %v, it is ignored.", logMessagePrefix, topic, err)
 	}
 
 	if ok {
 		p.Priority = priority
 	}
 
-	common.ApplyMetadataToPublishing(req.Metadata, &p)
+	common.ApplyMetadataToPublishing(md, &p)
+
+	for k, v := range md {
+		if name, ok := strings.CutPrefix(k, reqMetadataHeaderPrefix); ok && name != "" {
+			if p.Headers == nil {
+				p.Headers = amqp.Table{}
+			}
+			p.Headers[name] = v
+		}
+	}
+
+	return routingKey, p
+}
+
+func (r *rabbitMQ) publishSync(ctx context.Context, req *pubsub.PublishRequest) (rabbitMQChannelBroker, int, error) {
+	channel, connectionCount, err := r.publisher.acquire(ctx)
+	if err != nil {
+		return channel, connectionCount, err
+	}
+	defer r.publisher.release(channel, connectionCount)
+
+	if err = r.ensureExchangeDeclared(channel, req.Topic, r.metadata.ExchangeKind, r.metadata.Durable, r.metadata.DeleteWhenUnused); err != nil {
+		r.logger.Errorf("%s publishing to %s failed in ensureExchangeDeclared: %v", logMessagePrefix, req.Topic, err)
+
+		return channel, connectionCount, err
+	}
+	routingKey, p := r.newPublishing(req.Topic, req.Data, req.Metadata)
 
-	confirm, err := r.channel.PublishWithDeferredConfirmWithContext(ctx, req.Topic, routingKey, false, false, p)
+	confirm, err := channel.PublishWithDeferredConfirmWithContext(ctx, req.Topic, routingKey, false, false, p)
 	if err != nil {
 		r.logger.Errorf("%s publishing to %s failed in channel.Publish: %v", logMessagePrefix, req.Topic, err)
 
-		return r.channel, r.connectionCount, err
+		return channel, connectionCount, err
 	}
 
 	// confirm will be nil if are not requesting publish confirmations
@@ -279,13 +307,20 @@ func (r *rabbitMQ) publishSync(ctx context.Context, req *pubsub.PublishRequest)
 		}
 	}
 
-	return r.channel, r.connectionCount, nil
+	return channel, connectionCount, nil
 }
 
 func (r *rabbitMQ) Publish(ctx context.Context, req *pubsub.PublishRequest) error {
 	if r.closed.Load() {
 		return errors.New("component is closed")
 	}
+	if err := r.publishLimiter.Wait(ctx, 1); err != nil {
+		return err
+	}
+
+	prefixedReq := *req
+	prefixedReq.Topic = r.topicPrefix.Topic(req.Topic)
+	req = &prefixedReq
 
 	r.logger.Debugf("%s publishing message to %s", logMessagePrefix, req.Topic)
 
@@ -308,7 +343,7 @@ func (r *rabbitMQ) Publish(ctx context.Context, req *pubsub.PublishRequest) erro
 				return nil
 			}
 
-			r.reconnect(connectionCount)
+			r.reconnect(r.publisher, connectionCount)
 		} else {
 			r.logger.Warnf("%s publishing attempt (%d/%d) failed: %v", logMessagePrefix, attempt, publishMaxRetries, err)
 			select {
@@ -325,6 +360,18 @@ func (r *rabbitMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, h
 		return errors.New("component is closed")
 	}
 
+	req.Topic = r.topicPrefix.Topic(req.Topic)
+	handler = r.topicPrefix.Handler(r.dedup.Handler(handler))
+
+	return r.subscribe(ctx, req, func(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, queueName string, opts subscriptionOptions) error {
+		return r.listenMessages(ctx, channel, msgCh, req.Topic, queueName, opts.workerPoolSize, handler)
+	})
+}
+
+// listenFunc processes the messages delivered to a subscription, until the channel is closed or an error occurs.
+type listenFunc func(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, queueName string, opts subscriptionOptions) error
+
+func (r *rabbitMQ) subscribe(ctx context.Context, req pubsub.SubscribeRequest, listen listenFunc) error {
 	queueName := req.Metadata[metadataQueueNameKey]
 	if queueName == "" {
 		if r.metadata.ConsumerID == "" {
@@ -333,6 +380,11 @@ func (r *rabbitMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, h
 		queueName = fmt.Sprintf("%s-%s", r.metadata.ConsumerID, req.Topic)
 	}
 
+	opts, err := r.parseSubscriptionOptions(req)
+	if err != nil {
+		return err
+	}
+
 	r.logger.Infof("%s subscribe to topic/queue '%s/%s'", logMessagePrefix, req.Topic, queueName)
 
 	// Do not set a timeout on the context, as we're just waiting for the first ack; we're using a semaphore instead
@@ -343,7 +395,7 @@ func (r *rabbitMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, h
 	r.wg.Add(2)
 	go func() {
 		defer r.wg.Done()
-		r.subscribeForever(subctx, req, queueName, handler, ackCh)
+		r.subscribeForever(subctx, req, queueName, opts, listen, ackCh)
 	}()
 	go func() {
 		defer r.wg.Done()
@@ -367,7 +419,7 @@ func (r *rabbitMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, h
 	}
 }
 
-// this function call should be wrapped by channelMutex.
+// this function call should be wrapped by the lock of the consumer connection.
 func (r *rabbitMQ) prepareSubscription(channel rabbitMQChannelBroker, req pubsub.SubscribeRequest, queueName string) (*amqp.Queue, error) {
 	err := r.ensureExchangeDeclared(channel, req.Topic, r.metadata.ExchangeKind, r.metadata.Durable, r.metadata.DeleteWhenUnused)
 	if err != nil {
@@ -379,34 +431,10 @@ func (r *rabbitMQ) prepareSubscription(channel rabbitMQChannelBroker, req pubsub
 	r.logger.Infof("%s declaring queue '%s'", logMessagePrefix, queueName)
 	var args amqp.Table
 	if r.metadata.EnableDeadLetter {
-		// declare dead letter exchange
-		dlxName := fmt.Sprintf(defaultDeadLetterExchangeFormat, queueName)
-		dlqName := fmt.Sprintf(defaultDeadLetterQueueFormat, queueName)
-		// dead letter exchange is always durable
-		err = r.ensureExchangeDeclared(channel, dlxName, fanoutExchangeKind, true, r.metadata.DeleteWhenUnused)
+		args, err = r.prepareDeadLetter(channel, req, queueName)
 		if err != nil {
-			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in ensureExchangeDeclared: %v", logMessagePrefix, req.Topic, dlqName, err)
-
-			return nil, err
-		}
-		var q amqp.Queue
-		dlqArgs := r.metadata.formatQueueDeclareArgs(nil)
-		// dead letter queue use lazy mode, keeping as many messages as possible on disk to reduce RAM usage
-		dlqArgs[argQueueMode] = queueModeLazy
-		q, err = channel.QueueDeclare(dlqName, true, r.metadata.DeleteWhenUnused, false, false, dlqArgs)
-		if err != nil {
-			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueDeclare: %v", logMessagePrefix, req.Topic, dlqName, err)
-
-			return nil, err
-		}
-		err = channel.QueueBind(q.Name, "", dlxName, false, nil)
-		if err != nil {
-			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueBind: %v", logMessagePrefix, req.Topic, dlqName, err)
-
 			return nil, err
 		}
-		r.logger.Infof("%s declared dead letter exchange for queue '%s' bind dead letter queue '%s' to dead letter exchange '%s'", logMessagePrefix, queueName, dlqName, dlxName)
-		args = amqp.Table{argDeadLetterExchange: dlxName}
 	}
 	args = r.metadata.formatQueueDeclareArgs(args)
 
@@ -462,21 +490,16 @@ func (r *rabbitMQ) prepareSubscription(channel rabbitMQChannelBroker, req pubsub
 		args[argMaxLength] = parsedVal
 	}
 
-	q, err := channel.QueueDeclare(queueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, false, false, args)
+	bindArgs, err := r.headersBindingArgs(req)
 	if err != nil {
-		r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueDeclare: %v", logMessagePrefix, req.Topic, queueName, err)
-
 		return nil, err
 	}
 
-	if r.metadata.PrefetchCount > 0 {
-		r.logger.Infof("%s setting prefetch count to %s", logMessagePrefix, strconv.Itoa(int(r.metadata.PrefetchCount)))
-		err = channel.Qos(int(r.metadata.PrefetchCount), 0, false)
-		if err != nil {
-			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.Qos: %v", logMessagePrefix, req.Topic, queueName, err)
+	q, err := channel.QueueDeclare(queueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, false, false, args)
+	if err != nil {
+		r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueDeclare: %v", logMessagePrefix, req.Topic, queueName, err)
 
-			return nil, err
-		}
+		return nil, err
 	}
 
 	metadataRoutingKey := ""
@@ -487,7 +510,7 @@ func (r *rabbitMQ) prepareSubscription(channel rabbitMQChannelBroker, req pubsub
 	for i := range routingKeys {
 		routingKey := routingKeys[i]
 		r.logger.Debugf("%s binding queue '%s' to exchange '%s' with routing key '%s'", logMessagePrefix, q.Name, req.Topic, routingKey)
-		err = channel.QueueBind(q.Name, routingKey, req.Topic, false, nil)
+		err = channel.QueueBind(q.Name, routingKey, req.Topic, false, bindArgs)
 		if err != nil {
 			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueBind: %v", logMessagePrefix, req.Topic, queueName, err)
 
@@ -498,20 +521,175 @@ func (r *rabbitMQ) prepareSubscription(channel rabbitMQChannelBroker, req pubsub
 	return &q, nil
 }
 
+// headersBindingArgs returns the arguments binding the queue to a headers exchange, which routes the messages whose
+// headers match the ones set on the subscription metadata, instead of using routing keys.
+func (r *rabbitMQ) headersBindingArgs(req pubsub.SubscribeRequest) (amqp.Table, error) {
+	args := amqp.Table{}
+	for k, v := range req.Metadata {
+		if name, ok := strings.CutPrefix(k, reqMetadataHeaderPrefix); ok && name != "" {
+			args[name] = v
+		}
+	}
+
+	if val := req.Metadata[reqMetadataHeadersMatchKey]; val != "" {
+		if !headersMatchValid(val) {
+			return nil, fmt.Errorf("%s invalid %s value %s for topic '%s'", errorMessagePrefix, reqMetadataHeadersMatchKey, val, req.Topic)
+		}
+		args[argHeadersMatch] = val
+	}
+
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if r.metadata.ExchangeKind != amqp.ExchangeHeaders {
+		return nil, fmt.Errorf("%s header matching on topic '%s' requires the %s exchange kind", errorMessagePrefix, req.Topic, amqp.ExchangeHeaders)
+	}
+
+	return args, nil
+}
+
+// headersMatchValid returns true if the value is a valid x-match argument: with "all" every header must match, with
+// "any" at least one. Headers starting with "x-" are only matched with the "-with-x" variants.
+func headersMatchValid(val string) bool {
+	return val == "all" || val == "any" || val == "all-with-x" || val == "any-with-x"
+}
+
+// this function call should be wrapped by the lock of the consumer connection.
+func (r *rabbitMQ) prepareDeadLetter(channel rabbitMQChannelBroker, req pubsub.SubscribeRequest, queueName string) (amqp.Table, error) {
+	// declare dead letter exchange
+	// by default, each queue has its own dead letter exchange; a shared exchange routes messages by queue name instead
+	dlxName := fmt.Sprintf(defaultDeadLetterExchangeFormat, queueName)
+	dlxKind := fanoutExchangeKind
+	routingKey := ""
+	if r.metadata.DeadLetterExchange != "" {
+		dlxName = r.metadata.DeadLetterExchange
+		dlxKind = amqp.ExchangeDirect
+		routingKey = queueName
+	}
+	dlqName := fmt.Sprintf(defaultDeadLetterQueueFormat, queueName)
+	// dead letter exchange is always durable
+	err := r.ensureExchangeDeclared(channel, dlxName, dlxKind, true, r.metadata.DeleteWhenUnused)
+	if err != nil {
+		r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in ensureExchangeDeclared: %v", logMessagePrefix, req.Topic, dlqName, err)
+
+		return nil, err
+	}
+	dlqArgs := r.metadata.formatQueueDeclareArgs(nil)
+	// dead letter queue use lazy mode, keeping as many messages as possible on disk to reduce RAM usage
+	dlqArgs[argQueueMode] = queueModeLazy
+	q, err := channel.QueueDeclare(dlqName, true, r.metadata.DeleteWhenUnused, false, false, dlqArgs)
+	if err != nil {
+		r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueDeclare: %v", logMessagePrefix, req.Topic, dlqName, err)
+
+		return nil, err
+	}
+	err = channel.QueueBind(q.Name, routingKey, dlxName, false, nil)
+	if err != nil {
+		r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueBind: %v", logMessagePrefix, req.Topic, dlqName, err)
+
+		return nil, err
+	}
+	r.logger.Infof("%s declared dead letter exchange for queue '%s' bind dead letter queue '%s' to dead letter exchange '%s'", logMessagePrefix, queueName, dlqName, dlxName)
+
+	// declare retry queues: messages expire after the delay of the tier, and are then dead-lettered back to the queue through the default exchange
+	for _, tier := range r.metadata.retryTiers {
+		retryQueueName := fmt.Sprintf(defaultRetryQueueFormat, queueName, tier.name)
+		retryArgs := amqp.Table{
+			argMessageTTL:           tier.delay.Milliseconds(),
+			argDeadLetterExchange:   "",
+			argDeadLetterRoutingKey: queueName,
+		}
+		_, err = channel.QueueDeclare(retryQueueName, true, r.metadata.DeleteWhenUnused, false, false, retryArgs)
+		if err != nil {
+			r.logger.Errorf("%s prepareSubscription for topic/queue '%s/%s' failed in channel.QueueDeclare: %v", logMessagePrefix, req.Topic, retryQueueName, err)
+
+			return nil, err
+		}
+		r.logger.Infof("%s declared retry queue '%s' for queue '%s' with delay %s", logMessagePrefix, retryQueueName, queueName, tier.delay)
+	}
+
+	args := amqp.Table{argDeadLetterExchange: dlxName}
+	if routingKey != "" {
+		args[argDeadLetterRoutingKey] = routingKey
+	}
+	return args, nil
+}
+
+// subscriptionOptions are set on the subscription metadata and override the configuration of the component.
+type subscriptionOptions struct {
+	// Number of messages to prefetch; 0 means no limit
+	prefetchCount int
+	// Maximum number of messages processed concurrently; 0 means the concurrency mode of the component applies
+	workerPoolSize int
+}
+
+func (r *rabbitMQ) parseSubscriptionOptions(req pubsub.SubscribeRequest) (subscriptionOptions, error) {
+	opts := subscriptionOptions{
+		prefetchCount: int(r.metadata.PrefetchCount),
+	}
+
+	if val := req.Metadata[reqMetadataPrefetchCountKey]; val != "" {
+		parsedVal, err := strconv.ParseUint(val, 10, 16)
+		if err != nil {
+			return opts, fmt.Errorf("%s can't parse %s value on subscription metadata for topic '%s': %w", errorMessagePrefix, reqMetadataPrefetchCountKey, req.Topic, err)
+		}
+		opts.prefetchCount = int(parsedVal)
+	}
+
+	if val := req.Metadata[reqMetadataWorkerPoolSizeKey]; val != "" {
+		parsedVal, err := strconv.ParseUint(val, 10, 16)
+		if err != nil {
+			return opts, fmt.Errorf("%s can't parse %s value on subscription metadata for topic '%s': %w", errorMessagePrefix, reqMetadataWorkerPoolSizeKey, req.Topic, err)
+		}
+		opts.workerPoolSize = int(parsedVal)
+	}
+
+	return opts, nil
+}
+
+// consume starts consuming messages from the queue.
+// The prefetch count applies to the consumers started on the channel after it's set, and the channel is shared
+// by all subscriptions, so setting it and starting the consumer must not interleave with other subscriptions.
+func (r *rabbitMQ) consume(channel rabbitMQChannelBroker, req pubsub.SubscribeRequest, q *amqp.Queue, queueName string, prefetchCount int) (<-chan amqp.Delivery, error) {
+	r.consumeMutex.Lock()
+	defer r.consumeMutex.Unlock()
+
+	if prefetchCount > 0 {
+		r.logger.Infof("%s setting prefetch count to %s for topic/queue '%s/%s'", logMessagePrefix, strconv.Itoa(prefetchCount), req.Topic, queueName)
+	}
+	err := channel.Qos(prefetchCount, 0, false)
+	if err != nil {
+		r.logger.Errorf("%s subscription for topic/queue '%s/%s' failed in channel.Qos: %v", logMessagePrefix, req.Topic, queueName, err)
+
+		return nil, err
+	}
+
+	return channel.Consume(
+		q.Name,
+		queueName,          // consumerID
+		r.metadata.AutoAck, // autoAck
+		false,              // exclusive
+		false,              // noLocal
+		false,              // noWait
+		nil,
+	)
+}
+
 func (r *rabbitMQ) ensureSubscription(req pubsub.SubscribeRequest, queueName string) (rabbitMQChannelBroker, int, *amqp.Queue, error) {
-	r.channelMutex.RLock()
-	defer r.channelMutex.RUnlock()
+	r.consumer.lock.RLock()
+	defer r.consumer.lock.RUnlock()
 
-	if r.channel == nil {
-		return nil, r.connectionCount, nil, errors.New(errorChannelNotInitialized)
+	channel := r.consumer.shared()
+	if channel == nil {
+		return nil, r.consumer.connectionCount, nil, errors.New(errorChannelNotInitialized)
 	}
 
-	q, err := r.prepareSubscription(r.channel, req, queueName)
+	q, err := r.prepareSubscription(channel, req, queueName)
 
-	return r.channel, r.connectionCount, q, err
+	return channel, r.consumer.connectionCount, q, err
 }
 
-func (r *rabbitMQ) subscribeForever(ctx context.Context, req pubsub.SubscribeRequest, queueName string, handler pubsub.Handler, ackCh chan bool) {
+func (r *rabbitMQ) subscribeForever(ctx context.Context, req pubsub.SubscribeRequest, queueName string, opts subscriptionOptions, listen listenFunc, ackCh chan bool) {
 	for {
 		var (
 			err             error
@@ -528,15 +706,7 @@ func (r *rabbitMQ) subscribeForever(ctx context.Context, req pubsub.SubscribeReq
 				break
 			}
 
-			msgs, err = channel.Consume(
-				q.Name,
-				queueName,          // consumerID
-				r.metadata.AutoAck, // autoAck
-				false,              // exclusive
-				false,              // noLocal
-				false,              // noWait
-				nil,
-			)
+			msgs, err = r.consume(channel, req, q, queueName, opts.prefetchCount)
 			if err != nil {
 				errFuncName = "channel.Consume"
 				break
@@ -548,7 +718,7 @@ func (r *rabbitMQ) subscribeForever(ctx context.Context, req pubsub.SubscribeReq
 				ackCh = nil
 			}
 
-			err = r.listenMessages(ctx, channel, msgs, req.Topic, handler)
+			err = listen(ctx, channel, msgs, queueName, opts)
 			if err != nil {
 				errFuncName = "listenMessages"
 				break
@@ -584,12 +754,23 @@ func (r *rabbitMQ) subscribeForever(ctx context.Context, req pubsub.SubscribeReq
 				r.logger.Infof("%s subscription for %s has context canceled", logMessagePrefix, queueName)
 				return
 			}
-			r.reconnect(connectionCount)
+			r.reconnect(r.consumer, connectionCount)
 		}
 	}
 }
 
-func (r *rabbitMQ) listenMessages(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, topic string, handler pubsub.Handler) error {
+func (r *rabbitMQ) listenMessages(ctx context.Context, channel rabbitMQChannelBroker, msgCh <-chan amqp.Delivery, topic string, queueName string, workerPoolSize int, handler pubsub.Handler) error {
+	// A worker pool set on the subscription takes precedence over the concurrency mode of the component
+	concurrency := r.metadata.Concurrency
+	var workers chan struct{}
+	switch {
+	case workerPoolSize == 1:
+		concurrency = pubsub.Single
+	case workerPoolSize > 1:
+		concurrency = pubsub.Parallel
+		workers = make(chan struct{}, workerPoolSize)
+	}
+
 	var err error
 	for {
 		select {
@@ -602,17 +783,30 @@ func (r *rabbitMQ) listenMessages(ctx context.Context, channel rabbitMQChannelBr
 				return nil
 			}
 
-			switch r.metadata.Concurrency {
+			switch concurrency {
 			case pubsub.Single:
-				err = r.handleMessage(ctx, d, topic, handler)
+				err = r.handleMessage(ctx, d, topic, queueName, handler)
 				if err != nil && mustReconnect(channel, err) {
 					return err
 				}
 			case pubsub.Parallel:
+				// Wait for a worker to be available, if the pool is bounded
+				if workers != nil {
+					select {
+					case workers <- struct{}{}:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
 				r.wg.Add(1)
 				go func(d amqp.Delivery) {
 					defer r.wg.Done()
-					if err := r.handleMessage(ctx, d, topic, handler); err != nil {
+					if workers != nil {
+						defer func() {
+							<-workers
+						}()
+					}
+					if err := r.handleMessage(ctx, d, topic, queueName, handler); err != nil {
 						r.logger.Errorf("%s error handling message: %v", logMessagePrefix, err)
 					}
 				}(d)
@@ -621,7 +815,7 @@ func (r *rabbitMQ) listenMessages(ctx context.Context, channel rabbitMQChannelBr
 	}
 }
 
-func (r *rabbitMQ) handleMessage(ctx context.Context, d amqp.Delivery, topic string, handler pubsub.Handler) error {
+func (r *rabbitMQ) handleMessage(ctx context.Context, d amqp.Delivery, topic string, queueName string, handler pubsub.Handler) error {
 	pubsubMsg := &pubsub.NewMessage{
 		Data:     d.Body,
 		Topic:    topic,
@@ -633,30 +827,142 @@ func (r *rabbitMQ) handleMessage(ctx context.Context, d amqp.Delivery, topic str
 	}
 
 	err := handler(ctx, pubsubMsg)
-
 	if err != nil {
 		r.logger.Errorf("%s handling message from topic '%s', %s", errorMessagePrefix, topic, err)
 
-		if !r.metadata.AutoAck {
-			// if message is not auto acked we need to ack/nack
-			r.logger.Debugf("%s nacking message '%s' from topic '%s', requeue=%t", logMessagePrefix, d.MessageId, topic, r.metadata.RequeueInFailure)
-			if err = d.Nack(false, r.metadata.RequeueInFailure); err != nil {
-				r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
-			}
+		if len(r.metadata.retryTiers) > 0 {
+			return r.retryMessage(ctx, d, topic, queueName)
+		}
+		if r.deadLetter.Enabled() {
+			return r.redeliverMessage(ctx, d, pubsubMsg, topic, queueName, err)
 		}
-	} else if !r.metadata.AutoAck {
-		// if message is not auto acked we need to ack/nack
-		r.logger.Debugf("%s acking message '%s' from topic '%s'", logMessagePrefix, d.MessageId, topic)
-		if err = d.Ack(false); err != nil {
-			r.logger.Errorf("%s error acking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+
+		if nackErr := r.nackMessage(d, topic); nackErr != nil {
+			return nackErr
+		}
+		return err
+	}
+
+	return r.ackMessage(d, topic)
+}
+
+func (r *rabbitMQ) ackMessage(d amqp.Delivery, topic string) error {
+	if r.metadata.AutoAck {
+		return nil
+	}
+
+	// if message is not auto acked we need to ack/nack
+	r.logger.Debugf("%s acking message '%s' from topic '%s'", logMessagePrefix, d.MessageId, topic)
+	err := d.Ack(false)
+	if err != nil {
+		r.logger.Errorf("%s error acking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+	}
+	return err
+}
+
+func (r *rabbitMQ) nackMessage(d amqp.Delivery, topic string) error {
+	if r.metadata.AutoAck {
+		return nil
+	}
+
+	// if message is not auto acked we need to ack/nack
+	r.logger.Debugf("%s nacking message '%s' from topic '%s', requeue=%t", logMessagePrefix, d.MessageId, topic, r.metadata.RequeueInFailure)
+	err := d.Nack(false, r.metadata.RequeueInFailure)
+	if err != nil {
+		r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+	}
+	return err
+}
+
+// retryMessage moves a message that failed to be processed to the retry queue of its next tier.
+// Once all tiers have been tried, the message is dead-lettered to the dead letter queue.
+func (r *rabbitMQ) retryMessage(ctx context.Context, d amqp.Delivery, topic string, queueName string) error {
+	retryCount := deliveryRetryCount(d)
+	if retryCount < len(r.metadata.retryTiers) {
+		retryQueueName := fmt.Sprintf(defaultRetryQueueFormat, queueName, r.metadata.retryTiers[retryCount].name)
+		err := r.publishRetry(ctx, d, retryQueueName, retryCount+1)
+		if err == nil {
+			r.logger.Debugf("%s moved message '%s' from topic '%s' to retry queue '%s'", logMessagePrefix, d.MessageId, topic, retryQueueName)
+			if err = d.Ack(false); err != nil {
+				r.logger.Errorf("%s error acking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+			}
+			return err
 		}
+		r.logger.Errorf("%s error moving message '%s' from topic '%s' to retry queue '%s', %s", logMessagePrefix, d.MessageId, topic, retryQueueName, err)
 	}
 
+	r.logger.Debugf("%s nacking message '%s' from topic '%s' after %d retries", logMessagePrefix, d.MessageId, topic, retryCount)
+	err := d.Nack(false, false)
+	if err != nil {
+		r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+	}
 	return err
 }
 
-// this function call should be wrapped by channelMutex.
+// deliveryRetryCount returns the number of times a message was moved to a retry queue or republished.
+func deliveryRetryCount(d amqp.Delivery) int {
+	switch v := d.Headers[headerRetryCount].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// publishRetry publishes a copy of the message to a retry queue through the default exchange.
+// It's published with the publisher connection, so flow control applied to it doesn't block the consumers.
+func (r *rabbitMQ) publishRetry(ctx context.Context, d amqp.Delivery, retryQueueName string, retryCount int) error {
+	channel, connectionCount, err := r.publisher.acquire(ctx)
+	if err == nil {
+		err = r.publishRetryOnChannel(ctx, channel, d, retryQueueName, retryCount)
+		r.publisher.release(channel, connectionCount)
+	}
+	if err != nil && ctx.Err() == nil && mustReconnect(channel, err) {
+		// Messages are dead-lettered while the connection is down, so it's re-established without waiting for the next publish
+		r.reconnect(r.publisher, connectionCount)
+	}
+	return err
+}
+
+func (r *rabbitMQ) publishRetryOnChannel(ctx context.Context, channel rabbitMQChannelBroker, d amqp.Delivery, retryQueueName string, retryCount int) error {
+	headers := make(amqp.Table, len(d.Headers)+1)
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[headerRetryCount] = int32(retryCount) //nolint:gosec
+
+	confirm, err := channel.PublishWithDeferredConfirmWithContext(ctx, "", retryQueueName, false, false, amqp.Publishing{
+		Headers:         headers,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    d.DeliveryMode,
+		Priority:        d.Priority,
+		CorrelationId:   d.CorrelationId,
+		ReplyTo:         d.ReplyTo,
+		MessageId:       d.MessageId,
+		Timestamp:       d.Timestamp,
+		Type:            d.Type,
+		UserId:          d.UserId,
+		AppId:           d.AppId,
+		Body:            d.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	// confirm will be nil if are not requesting publish confirmations
+	if confirm != nil && !confirm.Wait() {
+		return errors.New("did not receive confirmation of publishing")
+	}
+	return nil
+}
+
 func (r *rabbitMQ) ensureExchangeDeclared(channel rabbitMQChannelBroker, exchange, exchangeKind string, durable bool, autoDelete bool) error {
+	r.exchangesMutex.Lock()
+	defer r.exchangesMutex.Unlock()
+
 	if !r.containsExchange(exchange) {
 		r.logger.Debugf("%s declaring exchange '%s' of kind '%s'", logMessagePrefix, exchange, exchangeKind)
 		err := channel.ExchangeDeclare(exchange, exchangeKind, durable, autoDelete, false, false, nil)
@@ -672,63 +978,40 @@ func (r *rabbitMQ) ensureExchangeDeclared(channel rabbitMQChannelBroker, exchang
 	return nil
 }
 
-// this function call should be wrapped by channelMutex.
+// this function call should be wrapped by exchangesMutex.
 func (r *rabbitMQ) containsExchange(exchange string) bool {
 	_, exists := r.declaredExchanges[exchange]
 
 	return exists
 }
 
-// this function call should be wrapped by channelMutex.
+// this function call should be wrapped by exchangesMutex.
 func (r *rabbitMQ) putExchange(exchange string) {
 	r.declaredExchanges[exchange] = true
 }
 
-// this function call should be wrapped by channelMutex.
-func (r *rabbitMQ) reset() (err error) {
-	if len(r.declaredExchanges) > 0 {
-		r.declaredExchanges = make(map[string]bool)
-	}
-
-	if r.channel != nil {
-		if err = r.channel.Close(); err != nil {
-			r.logger.Errorf("%s reset: channel.Close() failed: %v", logMessagePrefix, err)
-		}
-		r.channel = nil
-	}
-	if r.connection != nil {
-		if err2 := r.connection.Close(); err2 != nil {
-			r.logger.Errorf("%s reset: connection.Close() failed: %v", logMessagePrefix, err2)
-			if err == nil {
-				err = err2
-			}
-		}
-		r.connection = nil
-	}
-
-	return
-}
-
 func (r *rabbitMQ) isStopped() bool {
 	return r.closed.Load()
 }
 
-// Close closes the rabbitMQ connection. Blocks until all go routines are done.
+// Close closes the rabbitMQ connections. Blocks until all go routines are done.
 func (r *rabbitMQ) Close() error {
-	r.channelMutex.Lock()
-	defer r.channelMutex.Unlock()
-
 	if r.closed.CompareAndSwap(false, true) {
 		close(r.closeCh)
 	}
 
-	defer r.wg.Wait()
+	err := errors.Join(
+		r.closeConnection(r.publisher),
+		r.closeConnection(r.consumer),
+		r.dedup.Close(),
+	)
+	r.wg.Wait()
 
-	return r.reset()
+	return err
 }
 
 func (r *rabbitMQ) Features() []pubsub.Feature {
-	return []pubsub.Feature{pubsub.FeatureMessageTTL}
+	return []pubsub.Feature{pubsub.FeatureMessageTTL, pubsub.FeatureBulkPublish}
 }
 
 func mustReconnect(channel rabbitMQChannelBroker, err error) bool {
@@ -40,9 +40,9 @@ func newRabbitMQTest(broker *rabbitMQInMemoryBroker) *rabbitMQ {
 	return &rabbitMQ{
 		declaredExchanges: make(map[string]bool),
 		logger:            logger.NewLogger("test"),
-		connectionDial: func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, externalSasl bool) (rabbitMQConnectionBroker, rabbitMQChannelBroker, error) {
+		connectionDial: func(protocol, uri, clientName string, heartBeat time.Duration, tlsCfg *tls.Config, sasl []amqp.Authentication) (rabbitMQConnectionBroker, error) {
 			broker.connectCount.Add(1)
-			return broker, broker, nil
+			return broker, nil
 		},
 		closeCh: make(chan struct{}),
 	}
@@ -73,7 +73,7 @@ func TestPublishAndSubscribeWithPriorityQueue(t *testing.T) {
 	}}
 	err := pubsubRabbitMQ.Init(t.Context(), metadata)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), broker.connectCount.Load())
+	assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 	assert.Equal(t, int32(0), broker.closeCount.Load())
 
 	topic := "mytopic"
@@ -187,6 +187,103 @@ func TestConcurrencyMode(t *testing.T) {
 	})
 }
 
+func TestSubscriptionOptions(t *testing.T) {
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:      "anyhost",
+			metadataConsumerIDKey:    "consumer",
+			metadataPrefetchCountKey: "10",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+
+	t.Run("defaults to the component configuration", func(t *testing.T) {
+		opts, err := pubsubRabbitMQ.parseSubscriptionOptions(pubsub.SubscribeRequest{Topic: "mytopic"})
+		require.NoError(t, err)
+		assert.Equal(t, subscriptionOptions{prefetchCount: 10}, opts)
+	})
+
+	t.Run("overridden on the subscription", func(t *testing.T) {
+		opts, err := pubsubRabbitMQ.parseSubscriptionOptions(pubsub.SubscribeRequest{
+			Topic: "mytopic",
+			Metadata: map[string]string{
+				reqMetadataPrefetchCountKey:  "500",
+				reqMetadataWorkerPoolSizeKey: "4",
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, subscriptionOptions{prefetchCount: 500, workerPoolSize: 4}, opts)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		err := pubsubRabbitMQ.Subscribe(t.Context(), pubsub.SubscribeRequest{
+			Topic: "mytopic",
+			Metadata: map[string]string{
+				reqMetadataWorkerPoolSizeKey: "-1",
+			},
+		}, nil)
+		require.ErrorContains(t, err, "can't parse workerPoolSize")
+	})
+
+	t.Run("prefetch count is set for each subscription", func(t *testing.T) {
+		handler := func(ctx context.Context, msg *pubsub.NewMessage) error {
+			return nil
+		}
+		err := pubsubRabbitMQ.Subscribe(t.Context(), pubsub.SubscribeRequest{
+			Topic:    "topic1",
+			Metadata: map[string]string{reqMetadataPrefetchCountKey: "0"},
+		}, handler)
+		require.NoError(t, err)
+		err = pubsubRabbitMQ.Subscribe(t.Context(), pubsub.SubscribeRequest{
+			Topic: "topic2",
+		}, handler)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{0, 10}, broker.prefetchCounts)
+	})
+}
+
+func TestWorkerPool(t *testing.T) {
+	pubsubRabbitMQ := newRabbitMQTest(newBroker())
+	pubsubRabbitMQ.metadata = &rabbitmqMetadata{
+		AutoAck:     true,
+		Concurrency: pubsub.Single,
+	}
+
+	const messages = 10
+	msgCh := make(chan amqp.Delivery, messages)
+	for range messages {
+		msgCh <- amqp.Delivery{Body: []byte("hello world")}
+	}
+	close(msgCh)
+
+	var active, maxActive, processed atomic.Int32
+	handler := func(ctx context.Context, msg *pubsub.NewMessage) error {
+		n := active.Add(1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		active.Add(-1)
+		processed.Add(1)
+		return nil
+	}
+
+	err := pubsubRabbitMQ.listenMessages(t.Context(), nil, msgCh, "mytopic", "myqueue", 3, handler)
+	require.NoError(t, err)
+	pubsubRabbitMQ.wg.Wait()
+
+	assert.Equal(t, int32(messages), processed.Load())
+	assert.LessOrEqual(t, maxActive.Load(), int32(3))
+	assert.Greater(t, maxActive.Load(), int32(1))
+}
+
 func TestPublishAndSubscribe(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -237,7 +334,7 @@ func TestPublishAndSubscribe(t *testing.T) {
 			}}
 			err := pubsubRabbitMQ.Init(t.Context(), metadata)
 			require.NoError(t, err)
-			assert.Equal(t, int32(1), broker.connectCount.Load())
+			assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 			assert.Equal(t, int32(0), broker.closeCount.Load())
 
 			messageCount := 0
@@ -281,7 +378,7 @@ func TestPublishReconnect(t *testing.T) {
 	}}
 	err := pubsubRabbitMQ.Init(t.Context(), metadata)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), broker.connectCount.Load())
+	assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 	assert.Equal(t, int32(0), broker.closeCount.Load())
 
 	topic := "othertopic"
@@ -311,8 +408,8 @@ func TestPublishReconnect(t *testing.T) {
 	assert.Equal(t, 1, messageCount)
 	assert.Equal(t, "hello world", lastMessage)
 	// Check that reconnection happened
-	assert.Equal(t, int32(3), broker.connectCount.Load()) // three counts - one initial connection plus 2 reconnect attempts
-	assert.Equal(t, int32(4), broker.closeCount.Load())   // four counts - one for connection, one for channel , times 2 reconnect attempts
+	assert.Equal(t, int32(4), broker.connectCount.Load()) // two initial connections plus 2 reconnect attempts of the publisher
+	assert.Equal(t, int32(10), broker.closeCount.Load())  // ten counts - one for the connection, one for each of the 4 channels, times 2 reconnect attempts
 
 	err = pubsubRabbitMQ.Publish(t.Context(), &pubsub.PublishRequest{Topic: topic, Data: []byte("foo bar")})
 	require.NoError(t, err)
@@ -332,7 +429,7 @@ func TestPublishReconnectAfterClose(t *testing.T) {
 	}}
 	err := pubsubRabbitMQ.Init(t.Context(), metadata)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), broker.connectCount.Load())
+	assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 	assert.Equal(t, int32(0), broker.closeCount.Load())
 
 	topic := "mytopic2"
@@ -360,15 +457,15 @@ func TestPublishReconnectAfterClose(t *testing.T) {
 	// Close PubSub
 	err = pubsubRabbitMQ.Close()
 	require.NoError(t, err)
-	assert.Equal(t, int32(2), broker.closeCount.Load()) // two counts - one for connection, one for channel
+	assert.Equal(t, int32(7), broker.closeCount.Load()) // seven counts - the publisher connection and its 4 channels, the consumer connection and its channel
 
 	err = pubsubRabbitMQ.Publish(t.Context(), &pubsub.PublishRequest{Topic: topic, Data: []byte(errorChannelConnection)})
 	require.Error(t, err)
 	assert.Equal(t, 1, messageCount)
 	assert.Equal(t, "hello world", lastMessage)
 	// Check that reconnection did not happened
-	assert.Equal(t, int32(1), broker.connectCount.Load())
-	assert.Equal(t, int32(2), broker.closeCount.Load()) // two counts - one for connection, one for channel
+	assert.Equal(t, int32(2), broker.connectCount.Load())
+	assert.Equal(t, int32(7), broker.closeCount.Load())
 }
 
 func TestSubscribeBindRoutingKeys(t *testing.T) {
@@ -382,7 +479,7 @@ func TestSubscribeBindRoutingKeys(t *testing.T) {
 	}}
 	err := pubsubRabbitMQ.Init(t.Context(), metadata)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), broker.connectCount.Load())
+	assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 	assert.Equal(t, int32(0), broker.closeCount.Load())
 
 	topic := "mytopic_routingkeys"
@@ -395,6 +492,59 @@ func TestSubscribeBindRoutingKeys(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHeadersExchange(t *testing.T) {
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:     "anyhost",
+			metadataConsumerIDKey:   "consumer",
+			metadataExchangeKindKey: "headers",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+
+	t.Run("queue is bound with the headers to match", func(t *testing.T) {
+		_, err = pubsubRabbitMQ.prepareSubscription(broker, pubsub.SubscribeRequest{Topic: "mytopic", Metadata: map[string]string{
+			"header:region":  "eu",
+			"header:type":    "order",
+			"headersMatch":   "any",
+			"prefetchCount":  "1",
+			"header:":        "ignored",
+			"otherMetadata:": "ignored",
+		}}, "consumer-mytopic")
+		require.NoError(t, err)
+		assert.Equal(t, amqp.Table{"region": "eu", "type": "order", "x-match": "any"}, broker.lastBindArgs)
+	})
+
+	t.Run("invalid x-match", func(t *testing.T) {
+		_, err = pubsubRabbitMQ.prepareSubscription(broker, pubsub.SubscribeRequest{Topic: "mytopic", Metadata: map[string]string{
+			"header:region": "eu",
+			"headersMatch":  "some",
+		}}, "consumer-mytopic")
+		require.Error(t, err)
+	})
+
+	t.Run("published messages have the headers", func(t *testing.T) {
+		err = pubsubRabbitMQ.Publish(t.Context(), &pubsub.PublishRequest{Topic: "mytopic", Data: []byte("hello"), Metadata: map[string]string{
+			"header:region": "eu",
+			"messageID":     "msg1",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, amqp.Table{"region": "eu"}, broker.lastMsgMetadata.Headers)
+	})
+
+	t.Run("header matching requires a headers exchange", func(t *testing.T) {
+		pubsubRabbitMQ.metadata.ExchangeKind = fanoutExchangeKind
+		defer func() { pubsubRabbitMQ.metadata.ExchangeKind = amqp.ExchangeHeaders }()
+		_, err = pubsubRabbitMQ.prepareSubscription(broker, pubsub.SubscribeRequest{Topic: "mytopic", Metadata: map[string]string{
+			"header:region": "eu",
+		}}, "consumer-mytopic")
+		require.Error(t, err)
+	})
+}
+
 func TestSubscribeReconnect(t *testing.T) {
 	broker := newBroker()
 	pubsubRabbitMQ := newRabbitMQTest(broker)
@@ -409,7 +559,7 @@ func TestSubscribeReconnect(t *testing.T) {
 	}}
 	err := pubsubRabbitMQ.Init(t.Context(), metadata)
 	require.NoError(t, err)
-	assert.Equal(t, int32(1), broker.connectCount.Load())
+	assert.Equal(t, int32(2), broker.connectCount.Load()) // one connection for publishing, one for consuming
 	assert.Equal(t, int32(0), broker.closeCount.Load())
 
 	topic := "thetopic"
@@ -452,23 +602,173 @@ func TestSubscribeReconnect(t *testing.T) {
 	time.Sleep(time.Second)
 
 	// Check that reconnection happened
-	assert.Equal(t, int32(3), broker.connectCount.Load()) // initial connect + 2 reconnects
+	assert.Equal(t, int32(4), broker.connectCount.Load()) // two initial connections + 2 reconnects of the consumer
 	assert.Equal(t, int32(4), broker.closeCount.Load())   // two counts for each connection closure - one for connection, one for channel
 }
 
+func TestRetryTiers(t *testing.T) {
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:           "anyhost",
+			metadataConsumerIDKey:         "consumer",
+			metadataEnableDeadLetterKey:   "true",
+			metadataDeadLetterExchangeKey: "parking",
+			metadataRetryTiersKey:         "5s,1m",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+
+	t.Run("topology is declared", func(t *testing.T) {
+		_, err = pubsubRabbitMQ.prepareSubscription(broker, pubsub.SubscribeRequest{Topic: "mytopic"}, "consumer-mytopic")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"dlq-consumer-mytopic", "consumer-mytopic.retry.5s", "consumer-mytopic.retry.1m", "consumer-mytopic"}, broker.declaredQueues)
+		assert.Equal(t, amqp.Table{
+			argMessageTTL:           int64(5000),
+			argDeadLetterExchange:   "",
+			argDeadLetterRoutingKey: "consumer-mytopic",
+		}, broker.declaredQueueArgs["consumer-mytopic.retry.5s"])
+		assert.Equal(t, int64(60000), broker.declaredQueueArgs["consumer-mytopic.retry.1m"][argMessageTTL])
+		assert.Equal(t, "parking", broker.declaredQueueArgs["consumer-mytopic"][argDeadLetterExchange])
+		assert.Equal(t, "consumer-mytopic", broker.declaredQueueArgs["consumer-mytopic"][argDeadLetterRoutingKey])
+		assert.True(t, pubsubRabbitMQ.declaredExchanges["parking"])
+	})
+
+	t.Run("failed messages move through the retry tiers", func(t *testing.T) {
+		handler := func(ctx context.Context, msg *pubsub.NewMessage) error {
+			return errors.New("failed")
+		}
+		d := amqp.Delivery{
+			Acknowledger: broker,
+			MessageId:    "msg1",
+			Headers:      amqp.Table{"foo": "bar"},
+			Body:         []byte("hello world"),
+		}
+
+		for i, tier := range []string{"5s", "1m"} {
+			err = pubsubRabbitMQ.handleMessage(t.Context(), d, "mytopic", "consumer-mytopic", handler)
+			require.NoError(t, err)
+			assert.Equal(t, "", broker.lastExchange)
+			assert.Equal(t, "consumer-mytopic.retry."+tier, broker.lastRoutingKey)
+			assert.Equal(t, "msg1", broker.lastMsgMetadata.MessageId)
+			assert.Equal(t, "bar", broker.lastMsgMetadata.Headers["foo"])
+			assert.Equal(t, int32(i+1), broker.lastMsgMetadata.Headers[headerRetryCount])
+			assert.Equal(t, i+1, broker.acked)
+			assert.Equal(t, 0, broker.nacked)
+
+			// The message is delivered again after the delay
+			d.Headers = broker.lastMsgMetadata.Headers
+		}
+
+		// After the last tier the message is dead-lettered
+		err = pubsubRabbitMQ.handleMessage(t.Context(), d, "mytopic", "consumer-mytopic", handler)
+		require.NoError(t, err)
+		assert.Equal(t, 2, broker.acked)
+		assert.Equal(t, 1, broker.nacked)
+	})
+}
+
+func TestDeadLetterPolicy(t *testing.T) {
+	handler := func(ctx context.Context, msg *pubsub.NewMessage) error {
+		return errors.New("failed")
+	}
+
+	t.Run("messages are republished then moved to the dead-letter topic", func(t *testing.T) {
+		broker := newBroker()
+		pubsubRabbitMQ := newRabbitMQTest(broker)
+		err := pubsubRabbitMQ.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{
+			Properties: map[string]string{
+				metadataHostnameKey:       "anyhost",
+				metadataConsumerIDKey:     "consumer",
+				pubsub.MaxDeliveriesKey:   "3",
+				pubsub.DeadLetterTopicKey: "mytopic-dlq",
+			},
+		}})
+		require.NoError(t, err)
+
+		d := amqp.Delivery{
+			Acknowledger: broker,
+			MessageId:    "msg1",
+			Body:         []byte("hello world"),
+		}
+		for i := range 2 {
+			err = pubsubRabbitMQ.handleMessage(t.Context(), d, "mytopic", "consumer-mytopic", handler)
+			require.NoError(t, err)
+			assert.Equal(t, "", broker.lastExchange)
+			assert.Equal(t, "consumer-mytopic", broker.lastRoutingKey)
+			assert.Equal(t, int32(i+1), broker.lastMsgMetadata.Headers[headerRetryCount])
+			assert.Equal(t, i+1, broker.acked)
+
+			d.Headers = broker.lastMsgMetadata.Headers
+		}
+
+		err = pubsubRabbitMQ.handleMessage(t.Context(), d, "mytopic", "consumer-mytopic", handler)
+		require.NoError(t, err)
+		assert.Equal(t, "mytopic-dlq", broker.lastExchange)
+		assert.Equal(t, []byte("hello world"), broker.lastMsgMetadata.Body)
+		assert.Equal(t, 3, broker.acked)
+		assert.Equal(t, 0, broker.nacked)
+	})
+
+	t.Run("messages are rejected without a dead-letter topic", func(t *testing.T) {
+		broker := newBroker()
+		pubsubRabbitMQ := newRabbitMQTest(broker)
+		err := pubsubRabbitMQ.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{
+			Properties: map[string]string{
+				metadataHostnameKey:     "anyhost",
+				metadataConsumerIDKey:   "consumer",
+				pubsub.MaxDeliveriesKey: "1",
+			},
+		}})
+		require.NoError(t, err)
+
+		err = pubsubRabbitMQ.handleMessage(t.Context(), amqp.Delivery{Acknowledger: broker, Body: []byte("hello world")}, "mytopic", "consumer-mytopic", handler)
+		require.NoError(t, err)
+		assert.Equal(t, 0, broker.acked)
+		assert.Equal(t, 1, broker.nacked)
+	})
+
+	t.Run("retry tiers conflict", func(t *testing.T) {
+		broker := newBroker()
+		pubsubRabbitMQ := newRabbitMQTest(broker)
+		err := pubsubRabbitMQ.Init(t.Context(), pubsub.Metadata{Base: mdata.Base{
+			Properties: map[string]string{
+				metadataHostnameKey:         "anyhost",
+				metadataConsumerIDKey:       "consumer",
+				metadataEnableDeadLetterKey: "true",
+				metadataRetryTiersKey:       "5s",
+				pubsub.MaxDeliveriesKey:     "3",
+			},
+		}})
+		require.Error(t, err)
+	})
+}
+
 func createAMQPMessage(body []byte) amqp.Delivery {
 	return amqp.Delivery{Body: body}
 }
 
 type rabbitMQInMemoryBroker struct {
-	buffer          chan amqp.Delivery
-	declaredQueues  []string
-	connectCount    atomic.Int32
-	closeCount      atomic.Int32
-	lastMsgMetadata *amqp.Publishing // Add this field to capture the last message metadata
+	buffer            chan amqp.Delivery
+	declaredQueues    []string
+	declaredQueueArgs map[string]amqp.Table
+	lastExchange      string
+	lastRoutingKey    string
+	lastBindArgs      amqp.Table
+	acked             int
+	nacked            int
+	prefetchCounts    []int
+	published         []amqp.Publishing
+	connectCount      atomic.Int32
+	closeCount        atomic.Int32
+	lastMsgMetadata   *amqp.Publishing // Add this field to capture the last message metadata
 }
 
 func (r *rabbitMQInMemoryBroker) Qos(prefetchCount, prefetchSize int, global bool) error {
+	r.prefetchCounts = append(r.prefetchCounts, prefetchCount)
 	return nil
 }
 
@@ -485,6 +785,9 @@ func (r *rabbitMQInMemoryBroker) PublishWithDeferredConfirmWithContext(ctx conte
 
 	// Store the last message metadata for inspection in tests
 	r.lastMsgMetadata = &msg
+	r.published = append(r.published, msg)
+	r.lastExchange = exchange
+	r.lastRoutingKey = key
 
 	// Use a non-blocking send or a separate goroutine to prevent deadlock
 	// when there's no consumer reading from the buffer
@@ -500,10 +803,15 @@ func (r *rabbitMQInMemoryBroker) PublishWithDeferredConfirmWithContext(ctx conte
 
 func (r *rabbitMQInMemoryBroker) QueueDeclare(name string, durable bool, autoDelete bool, exclusive bool, noWait bool, args amqp.Table) (amqp.Queue, error) {
 	r.declaredQueues = append(r.declaredQueues, name)
+	if r.declaredQueueArgs == nil {
+		r.declaredQueueArgs = make(map[string]amqp.Table)
+	}
+	r.declaredQueueArgs[name] = args
 	return amqp.Queue{Name: name}, nil
 }
 
 func (r *rabbitMQInMemoryBroker) QueueBind(name string, key string, exchange string, noWait bool, args amqp.Table) error {
+	r.lastBindArgs = args
 	return nil
 }
 
@@ -512,10 +820,17 @@ func (r *rabbitMQInMemoryBroker) Consume(queue string, consumer string, autoAck
 }
 
 func (r *rabbitMQInMemoryBroker) Nack(tag uint64, multiple bool, requeue bool) error {
+	r.nacked++
 	return nil
 }
 
 func (r *rabbitMQInMemoryBroker) Ack(tag uint64, multiple bool) error {
+	r.acked++
+	return nil
+}
+
+func (r *rabbitMQInMemoryBroker) Reject(tag uint64, requeue bool) error {
+	r.nacked++
 	return nil
 }
 
@@ -523,6 +838,14 @@ func (r *rabbitMQInMemoryBroker) ExchangeDeclare(name string, kind string, durab
 	return nil
 }
 
+func (r *rabbitMQInMemoryBroker) Channel() (rabbitMQChannelBroker, error) {
+	return r, nil
+}
+
+func (r *rabbitMQInMemoryBroker) UpdateSecret(newSecret, reason string) error {
+	return nil
+}
+
 func (r *rabbitMQInMemoryBroker) Confirm(noWait bool) error {
 	return nil
 }
@@ -733,3 +1056,38 @@ func TestPublishMessagePropertiesToMetadataFlag(t *testing.T) {
 		assert.Empty(t, receivedMsg.Metadata, "Metadata should be empty when flag is not set (defaults to false)")
 	})
 }
+
+func TestNamespacedTopicPrefix(t *testing.T) {
+	t.Setenv("NAMESPACE", "tenant1")
+
+	broker := newBroker()
+	pubsubRabbitMQ := newRabbitMQTest(broker)
+	metadata := pubsub.Metadata{Base: mdata.Base{
+		Properties: map[string]string{
+			metadataHostnameKey:   "anyhost",
+			metadataConsumerIDKey: "{namespace}-consumer",
+			pubsub.TopicPrefixKey: "{namespace}.",
+		},
+	}}
+	err := pubsubRabbitMQ.Init(t.Context(), metadata)
+	require.NoError(t, err)
+
+	received := make(chan *pubsub.NewMessage, 1)
+	handler := func(ctx context.Context, msg *pubsub.NewMessage) error {
+		received <- msg
+		return nil
+	}
+
+	err = pubsubRabbitMQ.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "mytopic"}, handler)
+	require.NoError(t, err)
+	assert.Contains(t, broker.declaredQueues, "tenant1-consumer-tenant1.mytopic")
+
+	req := &pubsub.PublishRequest{Topic: "mytopic", Data: []byte("hello world")}
+	err = pubsubRabbitMQ.Publish(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "mytopic", req.Topic)
+
+	msg := <-received
+	assert.Equal(t, "mytopic", msg.Topic)
+	assert.Equal(t, "hello world", string(msg.Data))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	// MaxPublishPerSecondKey is the metadata property with the maximum number of messages published per second.
+	MaxPublishPerSecondKey = "maxPublishPerSecond"
+
+	// PublishBurstKey is the metadata property with the number of messages that can be published at once, above the
+	// rate. Defaults to the rate, rounded up.
+	PublishBurstKey = "publishBurst"
+
+	// PublishRateLimitWaitKey is the metadata property with the maximum time a publish request waits when the rate
+	// is exceeded, before failing. Defaults to 0, which fails immediately.
+	PublishRateLimitWaitKey = "publishRateLimitWait"
+)
+
+// PublishRateLimitedError is returned when publishing would exceed the rate limit of the component.
+// The request can be retried after RetryAfter.
+type PublishRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *PublishRateLimitedError) Error() string {
+	return fmt.Sprintf("publish rate limit exceeded, retry after %s", e.RetryAfter)
+}
+
+type publishRateLimitMetadata struct {
+	MaxPublishPerSecond  float64       `mapstructure:"maxPublishPerSecond"`
+	PublishBurst         int           `mapstructure:"publishBurst"`
+	PublishRateLimitWait time.Duration `mapstructure:"publishRateLimitWait"`
+}
+
+// PublishRateLimiter limits the rate of the messages published by a component, so apps can't overwhelm shared
+// brokers.
+// The zero value doesn't limit the rate.
+type PublishRateLimiter struct {
+	limiter *rate.Limiter
+	wait    time.Duration
+}
+
+// NewPublishRateLimiter returns a PublishRateLimiter configured with the maxPublishPerSecond, publishBurst and
+// publishRateLimitWait metadata properties.
+func NewPublishRateLimiter(props map[string]string) (PublishRateLimiter, error) {
+	var m publishRateLimitMetadata
+	err := kitmd.DecodeMetadata(props, &m)
+	if err != nil {
+		return PublishRateLimiter{}, fmt.Errorf("invalid publish rate limit metadata: %w", err)
+	}
+
+	if m.MaxPublishPerSecond < 0 || m.PublishBurst < 0 || m.PublishRateLimitWait < 0 {
+		return PublishRateLimiter{}, fmt.Errorf("invalid publish rate limit: %s, %s and %s must not be negative", MaxPublishPerSecondKey, PublishBurstKey, PublishRateLimitWaitKey)
+	}
+	if m.MaxPublishPerSecond == 0 {
+		return PublishRateLimiter{}, nil
+	}
+
+	burst := m.PublishBurst
+	if burst == 0 {
+		burst = int(math.Ceil(m.MaxPublishPerSecond))
+	}
+	return PublishRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(m.MaxPublishPerSecond), burst),
+		wait:    m.PublishRateLimitWait,
+	}, nil
+}
+
+// Wait reserves n messages, waiting up to the configured time if the rate is exceeded.
+// It returns a *PublishRateLimitedError if the messages can't be published within that time.
+func (l PublishRateLimiter) Wait(ctx context.Context, n int) error {
+	if l.limiter == nil {
+		return nil
+	}
+
+	r := l.limiter.ReserveN(time.Now(), n)
+	if !r.OK() {
+		return fmt.Errorf("cannot publish %d messages at once: the publish burst is %d", n, l.limiter.Burst())
+	}
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if delay > l.wait {
+		r.Cancel()
+		return &PublishRateLimitedError{RetryAfter: delay}
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishRateLimiter(t *testing.T) {
+	t.Run("no limit by default", func(t *testing.T) {
+		l, err := NewPublishRateLimiter(map[string]string{})
+		require.NoError(t, err)
+
+		for range 100 {
+			require.NoError(t, l.Wait(t.Context(), 1))
+		}
+	})
+
+	t.Run("burst defaults to the rate", func(t *testing.T) {
+		l, err := NewPublishRateLimiter(map[string]string{MaxPublishPerSecondKey: "2.5"})
+		require.NoError(t, err)
+
+		for range 3 {
+			require.NoError(t, l.Wait(t.Context(), 1))
+		}
+		err = l.Wait(t.Context(), 1)
+		var rateLimitedErr *PublishRateLimitedError
+		require.ErrorAs(t, err, &rateLimitedErr)
+		assert.Greater(t, rateLimitedErr.RetryAfter, time.Duration(0))
+	})
+
+	t.Run("bulk requests", func(t *testing.T) {
+		l, err := NewPublishRateLimiter(map[string]string{
+			MaxPublishPerSecondKey: "1",
+			PublishBurstKey:        "5",
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, l.Wait(t.Context(), 5))
+		require.ErrorAs(t, l.Wait(t.Context(), 1), new(*PublishRateLimitedError))
+		require.ErrorContains(t, l.Wait(t.Context(), 6), "cannot publish 6 messages at once")
+	})
+
+	t.Run("waits for capacity", func(t *testing.T) {
+		l, err := NewPublishRateLimiter(map[string]string{
+			MaxPublishPerSecondKey:  "20",
+			PublishBurstKey:         "1",
+			PublishRateLimitWaitKey: "1s",
+		})
+		require.NoError(t, err)
+
+		start := time.Now()
+		require.NoError(t, l.Wait(t.Context(), 1))
+		require.NoError(t, l.Wait(t.Context(), 1))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		require.ErrorIs(t, l.Wait(ctx, 1), context.Canceled)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, props := range []map[string]string{
+			{MaxPublishPerSecondKey: "-1"},
+			{MaxPublishPerSecondKey: "1", PublishBurstKey: "-1"},
+			{MaxPublishPerSecondKey: "fast"},
+		} {
+			_, err := NewPublishRateLimiter(props)
+			require.Error(t, err, props)
+		}
+	})
+}
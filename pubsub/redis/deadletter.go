@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// Fields added to the messages moved to the dead-letter stream, besides the data and metadata of the message.
+	deadLetterOriginalStreamField = "originalStream"
+	deadLetterOriginalIDField     = "originalId"
+	deadLetterDeliveryCountField  = "deliveryCount"
+
+	deadLetterStreamSuffix = "-deadletter"
+)
+
+// autoClaimPendingMessages reclaims the messages pending for longer than `processingTimeout` with `XAUTOCLAIM`,
+// scanning the whole pending list of the consumer group, and funnels them to the message channel.
+// Unlike `XCLAIM`, `XAUTOCLAIM` removes the messages that no longer exist from the pending list (Redis 7+).
+func (r *redisStreams) autoClaimPendingMessages(ctx context.Context, stream string, handler pubsub.Handler) {
+	start := "0-0"
+	for {
+		claimed, next, err := r.client.XAutoClaimResult(ctx,
+			stream,
+			r.clientSettings.ConsumerID,
+			r.clientSettings.ConsumerID,
+			r.clientSettings.ProcessingTimeout,
+			start,
+			int64(r.clientSettings.QueueDepth), //nolint:gosec
+		)
+		if err != nil && !errors.Is(err, r.client.GetNilValueError()) {
+			r.logger.Errorf("error auto-claiming pending Redis messages: %v", err)
+			return
+		}
+
+		if r.clientSettings.MaxDeliveryCount > 0 {
+			claimed = r.deadLetterMessages(ctx, stream, claimed, func(msg rediscomponent.RedisXMessage) (int64, bool) {
+				count, ok := r.deliveryCount(ctx, stream, msg.ID)
+				// Claiming the message counted as a delivery
+				return count - 1, ok
+			})
+		}
+		r.enqueueMessages(ctx, stream, handler, claimed)
+
+		if next == "" || next == "0-0" || ctx.Err() != nil {
+			return
+		}
+		start = next
+	}
+}
+
+// deliveryCount returns the number of times a pending message was delivered.
+func (r *redisStreams) deliveryCount(ctx context.Context, stream string, messageID string) (int64, bool) {
+	pending, err := r.client.XPendingExtResult(ctx, stream, r.clientSettings.ConsumerID, messageID, messageID, 1)
+	if err != nil || len(pending) == 0 {
+		if err != nil && !errors.Is(err, r.client.GetNilValueError()) {
+			r.logger.Warnf("error retrieving the delivery count of Redis message %s: %v", messageID, err)
+		}
+		return 0, false
+	}
+	return pending[0].RetryCount, true
+}
+
+// deadLetterMessages moves the messages delivered at least `maxDeliveryCount` times to the dead-letter stream, and
+// returns the other messages.
+// Messages that can't be moved remain pending, and are retried when pending messages are reclaimed again.
+func (r *redisStreams) deadLetterMessages(ctx context.Context, stream string, msgs []rediscomponent.RedisXMessage, deliveryCount func(msg rediscomponent.RedisXMessage) (int64, bool)) []rediscomponent.RedisXMessage {
+	keep := msgs[:0]
+	for _, msg := range msgs {
+		count, ok := deliveryCount(msg)
+		if !ok || count < r.clientSettings.MaxDeliveryCount {
+			keep = append(keep, msg)
+			continue
+		}
+
+		err := r.deadLetter(ctx, stream, msg, count)
+		if err != nil {
+			r.logger.Errorf("error moving Redis message %s to the dead-letter stream after %d deliveries: %v", msg.ID, count, err)
+			continue
+		}
+		r.logger.Warnf("Moved Redis message %s of stream %s to the dead-letter stream after %d deliveries", msg.ID, stream, count)
+	}
+	return keep
+}
+
+// deadLetter adds a message to the dead-letter stream, then acknowledges it on the original stream.
+func (r *redisStreams) deadLetter(ctx context.Context, stream string, msg rediscomponent.RedisXMessage, deliveryCount int64) error {
+	values := maps.Clone(msg.Values)
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	values[deadLetterOriginalStreamField] = stream
+	values[deadLetterOriginalIDField] = msg.ID
+	values[deadLetterDeliveryCountField] = deliveryCount
+
+	_, err := r.client.XAdd(ctx, r.deadLetterStream(stream), r.clientSettings.MaxLenApprox, r.clientSettings.GetMinID(time.Now()), values)
+	if err != nil {
+		return err
+	}
+
+	// Use the background context in case subscriptionCtx is already closed.
+	return r.client.XAck(context.Background(), stream, r.clientSettings.ConsumerID, msg.ID)
+}
+
+func (r *redisStreams) deadLetterStream(stream string) string {
+	if r.clientSettings.DeadLetterStream != "" {
+		return r.clientSettings.DeadLetterStream
+	}
+	return stream + deadLetterStreamSuffix
+}
@@ -27,7 +27,9 @@ import (
 	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 const (
@@ -39,17 +41,32 @@ const (
 	concurrency       = "concurrency"
 	maxLenApprox      = "maxLenApprox"
 	streamTTL         = "streamTTL"
+
+	// Subscription metadata key to subscribe to a pattern of channels (PSUBSCRIBE) in channels mode.
+	channelPatternKey = "channelPattern"
+
+	// Message metadata keys set in channels mode.
+	channelMetadataKey = "channel"
+	patternMetadataKey = "pattern"
 )
 
 // redisStreams handles consuming from a Redis stream using
 // `XREADGROUP` for reading new messages and `XPENDING` and
-// `XCLAIM` for redelivering messages that previously failed.
+// `XCLAIM` (or `XAUTOCLAIM`) for redelivering messages that previously failed.
+// Messages redelivered more than `maxDeliveryCount` times are moved to a dead-letter stream.
 //
 // See https://redis.io/topics/streams-intro for more information
 // on the mechanics of Redis Streams.
+//
+// When `pubSubMode` is set to "channels", classic Redis PUB/SUB is used
+// instead: messages are delivered at most once to the subscribers
+// connected at publish time, without persistence or redelivery.
 type redisStreams struct {
 	client         rediscomponent.RedisClient
 	clientSettings *rediscomponent.Settings
+	topicPrefix    pubsub.TopicPrefix
+	publishLimiter pubsub.PublishRateLimiter
+	dedup          *pubsub.Deduplicator
 	logger         logger.Logger
 	wg             sync.WaitGroup
 	closed         atomic.Bool
@@ -76,8 +93,24 @@ func NewRedisStreams(logger logger.Logger) pubsub.PubSub {
 }
 
 func (r *redisStreams) Init(ctx context.Context, metadata pubsub.Metadata) error {
-	var err error
-	r.client, r.clientSettings, err = rediscomponent.ParseClientFromProperties(metadata.Properties, contribMetadata.PubSubType, ctx, &r.logger)
+	props, err := pubsub.ExpandNamespaceProperties(metadata.Properties, consumerID)
+	if err != nil {
+		return err
+	}
+	r.topicPrefix, err = pubsub.NewTopicPrefix(props)
+	if err != nil {
+		return err
+	}
+	r.publishLimiter, err = pubsub.NewPublishRateLimiter(props)
+	if err != nil {
+		return err
+	}
+	r.dedup, err = deduplication.New(ctx, props, r.logger)
+	if err != nil {
+		return err
+	}
+
+	r.client, r.clientSettings, err = rediscomponent.ParseClientFromProperties(props, contribMetadata.PubSubType, ctx, &r.logger)
 	if err != nil {
 		return err
 	}
@@ -85,6 +118,13 @@ func (r *redisStreams) Init(ctx context.Context, metadata pubsub.Metadata) error
 	if _, err = r.client.PingResult(ctx); err != nil {
 		return fmt.Errorf("redis streams: error connecting to redis at %s: %s", r.clientSettings.Host, err)
 	}
+	if r.clientSettings.PubSubMode == rediscomponent.PubSubModeChannels && r.clientSettings.NotifyKeyspaceEvents != "" {
+		// Managed services often disallow CONFIG, in which case notifications need to be enabled on the server
+		if err = r.client.DoWrite(ctx, "CONFIG", "SET", "notify-keyspace-events", r.clientSettings.NotifyKeyspaceEvents); err != nil {
+			r.logger.Warnf("redis pubsub: failed to set notify-keyspace-events to %q: %v", r.clientSettings.NotifyKeyspaceEvents, err)
+		}
+	}
+
 	r.queue = make(chan redisMessageWrapper, int(r.clientSettings.QueueDepth)) //nolint:gosec
 
 	for range r.clientSettings.Concurrency {
@@ -102,6 +142,18 @@ func (r *redisStreams) Publish(ctx context.Context, req *pubsub.PublishRequest)
 	if r.closed.Load() {
 		return errors.New("component is closed")
 	}
+	if err := r.publishLimiter.Wait(ctx, 1); err != nil {
+		return err
+	}
+
+	if r.clientSettings.PubSubMode == rediscomponent.PubSubModeChannels {
+		// Channel messages carry the raw payload only, metadata is not propagated
+		err := r.client.DoWrite(ctx, "PUBLISH", r.topicPrefix.Topic(req.Topic), req.Data)
+		if err != nil {
+			return fmt.Errorf("redis pubsub: error from publish: %s", err)
+		}
+		return nil
+	}
 
 	redisPayload := map[string]interface{}{"data": req.Data}
 
@@ -113,7 +165,7 @@ func (r *redisStreams) Publish(ctx context.Context, req *pubsub.PublishRequest)
 		redisPayload["metadata"] = serializedMetadata
 	}
 
-	_, err := r.client.XAdd(ctx, req.Topic, r.clientSettings.MaxLenApprox, r.clientSettings.GetMinID(time.Now()), redisPayload)
+	_, err := r.client.XAdd(ctx, r.topicPrefix.Topic(req.Topic), r.clientSettings.MaxLenApprox, r.clientSettings.GetMinID(time.Now()), redisPayload)
 	if err != nil {
 		return fmt.Errorf("redis streams: error from publish: %s", err)
 	}
@@ -136,6 +188,13 @@ func (r *redisStreams) Subscribe(ctx context.Context, req pubsub.SubscribeReques
 		return errors.New("component is closed")
 	}
 
+	req.Topic = r.topicPrefix.Topic(req.Topic)
+	handler = r.topicPrefix.Handler(r.dedup.Handler(handler))
+
+	if r.clientSettings.PubSubMode == rediscomponent.PubSubModeChannels {
+		return r.subscribeChannel(ctx, req, handler)
+	}
+
 	if err := r.CreateConsumerGroup(ctx, req.Topic); err != nil {
 		return err
 	}
@@ -164,6 +223,51 @@ func (r *redisStreams) Subscribe(ctx context.Context, req pubsub.SubscribeReques
 	return nil
 }
 
+// subscribeChannel subscribes to a Redis PUB/SUB channel, or to a pattern of channels
+// when the `channelPattern` subscription metadata is set, and funnels the received
+// messages to the message channel.
+func (r *redisStreams) subscribeChannel(ctx context.Context, req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	isPattern := kitstrings.IsTruthy(req.Metadata[channelPatternKey])
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	err := r.client.ChannelSubscribe(loopCtx, req.Topic, isPattern, func(msg *rediscomponent.RedisChannelMessage) {
+		rmsg := redisMessageWrapper{
+			ctx: loopCtx,
+			message: pubsub.NewMessage{
+				Topic:    req.Topic,
+				Data:     []byte(msg.Payload),
+				Metadata: map[string]string{channelMetadataKey: msg.Channel},
+			},
+			handler: handler,
+		}
+		if msg.Pattern != "" {
+			rmsg.message.Metadata[patternMetadataKey] = msg.Pattern
+		}
+
+		select {
+		case r.queue <- rmsg:
+		case <-loopCtx.Done():
+		}
+	})
+	if err != nil {
+		cancel()
+		return fmt.Errorf("redis pubsub: error subscribing to channel %s: %w", req.Topic, err)
+	}
+
+	r.wg.Add(1)
+	go func() {
+		// Catch the close signal, as in the streams subscription
+		defer r.wg.Done()
+		defer cancel()
+		select {
+		case <-loopCtx.Done():
+		case <-r.closeCh:
+		}
+	}()
+
+	return nil
+}
+
 // enqueueMessages is a shared function that funnels new messages (via polling)
 // and redelivered messages (via reclaiming) to a channel where workers can
 // pick them up for processing.
@@ -249,6 +353,11 @@ func (r *redisStreams) processMessage(msg redisMessageWrapper) error {
 		return err
 	}
 
+	// Channel messages have no identifier and are not acknowledged
+	if msg.messageID == "" {
+		return nil
+	}
+
 	// Use the background context in case subscriptionCtx is already closed.
 	if err := r.client.XAck(context.Background(), msg.message.Topic, r.clientSettings.ConsumerID, msg.messageID); err != nil {
 		r.logger.Errorf("Error acknowledging Redis message %s: %v", msg.messageID, err)
@@ -318,6 +427,11 @@ func (r *redisStreams) reclaimPendingMessagesLoop(ctx context.Context, stream st
 // reclaimPendingMessages handles reclaiming messages that previously failed to process and
 // funneling them to the message channel by calling `enqueueMessages`.
 func (r *redisStreams) reclaimPendingMessages(ctx context.Context, stream string, handler pubsub.Handler) {
+	if r.clientSettings.UseXAutoClaim {
+		r.autoClaimPendingMessages(ctx, stream, handler)
+		return
+	}
+
 	for {
 		// Retrieve pending messages for this stream and consumer
 		pendingResult, err := r.client.XPendingExtResult(ctx,
@@ -360,7 +474,17 @@ func (r *redisStreams) reclaimPendingMessages(ctx context.Context, stream string
 			break
 		}
 
-		// Enqueue claimed messages
+		// Move the messages delivered too many times to the dead-letter stream, then enqueue the others
+		if r.clientSettings.MaxDeliveryCount > 0 {
+			deliveryCounts := make(map[string]int64, len(pendingResult))
+			for _, msg := range pendingResult {
+				deliveryCounts[msg.ID] = msg.RetryCount
+			}
+			claimResult = r.deadLetterMessages(ctx, stream, claimResult, func(msg rediscomponent.RedisXMessage) (int64, bool) {
+				count, ok := deliveryCounts[msg.ID]
+				return count, ok
+			})
+		}
 		r.enqueueMessages(ctx, stream, handler, claimResult)
 
 		// If the Redis nil error is returned, it means somes message in the pending
@@ -419,10 +543,11 @@ func (r *redisStreams) Close() error {
 		close(r.closeCh)
 	}
 
+	err := r.dedup.Close()
 	if r.client == nil {
-		return nil
+		return err
 	}
-	return r.client.Close()
+	return errors.Join(r.client.Close(), err)
 }
 
 func (r *redisStreams) Features() []pubsub.Feature {
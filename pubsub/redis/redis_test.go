@@ -16,11 +16,16 @@ package redis
 import (
 	"context"
 	"errors"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -153,6 +158,78 @@ func TestProcessStreamsWithoutEventMetadata(t *testing.T) {
 	assert.Equal(t, 3, messageCount)
 }
 
+func TestChannelsMode(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := commonredis.ClientFromV8Client(redis.NewClient(&redis.Options{Addr: s.Addr()}))
+
+	newChannels := func(t *testing.T) *redisStreams {
+		r := &redisStreams{
+			client:         client,
+			clientSettings: &commonredis.Settings{PubSubMode: commonredis.PubSubModeChannels},
+			logger:         logger.NewLogger("test"),
+			closeCh:        make(chan struct{}),
+			queue:          make(chan redisMessageWrapper, 10),
+		}
+		go r.worker()
+		t.Cleanup(func() {
+			if r.closed.CompareAndSwap(false, true) {
+				close(r.closeCh)
+			}
+			r.wg.Wait()
+		})
+		return r
+	}
+
+	t.Run("publish and subscribe", func(t *testing.T) {
+		r := newChannels(t)
+		received := make(chan *pubsub.NewMessage, 1)
+		err := r.Subscribe(t.Context(), pubsub.SubscribeRequest{Topic: "orders"}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+			received <- msg
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = r.Publish(t.Context(), &pubsub.PublishRequest{Topic: "orders", Data: []byte("hello")})
+		require.NoError(t, err)
+
+		select {
+		case msg := <-received:
+			assert.Equal(t, "orders", msg.Topic)
+			assert.Equal(t, "hello", string(msg.Data))
+			assert.Equal(t, "orders", msg.Metadata[channelMetadataKey])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+
+		// Nothing is written to a stream
+		assert.False(t, s.Exists("orders"))
+	})
+
+	t.Run("pattern subscribe", func(t *testing.T) {
+		r := newChannels(t)
+		received := make(chan *pubsub.NewMessage, 1)
+		err := r.Subscribe(t.Context(), pubsub.SubscribeRequest{
+			Topic:    "__keyspace@0__:order*",
+			Metadata: map[string]string{channelPatternKey: "true"},
+		}, func(ctx context.Context, msg *pubsub.NewMessage) error {
+			received <- msg
+			return errors.New("handler errors are not redelivered")
+		})
+		require.NoError(t, err)
+
+		s.Publish("__keyspace@0__:order1", "set")
+
+		select {
+		case msg := <-received:
+			assert.Equal(t, "set", string(msg.Data))
+			assert.Equal(t, "__keyspace@0__:order1", msg.Metadata[channelMetadataKey])
+			assert.Equal(t, "__keyspace@0__:order*", msg.Metadata[patternMetadataKey])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	})
+}
+
 func generateRedisStreamTestData(messageCount int, data string, metadata string) []commonredis.RedisXMessage {
 	generateXMessage := func(id int) commonredis.RedisXMessage {
 		values := map[string]interface{}{
@@ -176,3 +253,85 @@ func generateRedisStreamTestData(messageCount int, data string, metadata string)
 
 	return xmessageArray
 }
+
+func TestReclaimAndDeadLetter(t *testing.T) {
+	for _, autoClaim := range []bool{false, true} {
+		t.Run("autoClaim="+strconv.FormatBool(autoClaim), func(t *testing.T) {
+			s := miniredis.RunT(t)
+			now := time.Now()
+			s.SetTime(now)
+			// XAUTOCLAIM replies have three elements with Redis 7, which the v9 client is used for
+			client := commonredis.ClientFromV9Client(redisv9.NewClient(&redisv9.Options{Addr: s.Addr()}))
+			r := &redisStreams{
+				client: client,
+				clientSettings: &commonredis.Settings{
+					ConsumerID:        "group",
+					ProcessingTimeout: time.Second,
+					QueueDepth:        10,
+					UseXAutoClaim:     autoClaim,
+					MaxDeliveryCount:  2,
+				},
+				logger: logger.NewLogger("test"),
+				queue:  make(chan redisMessageWrapper, 10),
+			}
+			ctx := t.Context()
+			noopHandler := func(ctx context.Context, msg *pubsub.NewMessage) error { return nil }
+
+			require.NoError(t, client.XGroupCreateMkStream(ctx, "orders", "group", "0"))
+			id, err := client.XAdd(ctx, "orders", 0, "", map[string]interface{}{"data": "hello"})
+			require.NoError(t, err)
+
+			// A consumer receives the message and never acknowledges it
+			_, err = client.XReadGroupResult(ctx, "group", "dead-consumer", []string{"orders", ">"}, 10, 0)
+			require.NoError(t, err)
+
+			// The message is reclaimed once idle for longer than the processing timeout
+			r.reclaimPendingMessages(ctx, "orders", noopHandler)
+			assert.Empty(t, r.queue)
+
+			now = now.Add(2 * time.Second)
+			s.SetTime(now)
+			r.reclaimPendingMessages(ctx, "orders", noopHandler)
+			require.Len(t, r.queue, 1)
+			msg := <-r.queue
+			assert.Equal(t, id, msg.messageID)
+			assert.Equal(t, "hello", string(msg.message.Data))
+
+			// After the max number of deliveries, it's moved to the dead-letter stream
+			now = now.Add(2 * time.Second)
+			s.SetTime(now)
+			r.reclaimPendingMessages(ctx, "orders", noopHandler)
+			assert.Empty(t, r.queue)
+
+			pending, err := client.XPendingExtResult(ctx, "orders", "group", "-", "+", 10)
+			if !errors.Is(err, redisv9.Nil) {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, pending)
+
+			deadLettered, err := s.Stream("orders-deadletter")
+			require.NoError(t, err)
+			require.Len(t, deadLettered, 1)
+			assert.Equal(t, []string{
+				"data", "hello",
+				deadLetterDeliveryCountField, "2",
+				deadLetterOriginalIDField, id,
+				deadLetterOriginalStreamField, "orders",
+			}, sortedFieldValues(deadLettered[0].Values))
+		})
+	}
+}
+
+// sortedFieldValues sorts the field/value pairs of a stream entry by field.
+func sortedFieldValues(values []string) []string {
+	pairs := make([][2]string, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		pairs = append(pairs, [2]string{values[i], values[i+1]})
+	}
+	slices.SortFunc(pairs, func(a, b [2]string) int { return strings.Compare(a[0], b[0]) })
+	sorted := make([]string, 0, len(values))
+	for _, p := range pairs {
+		sorted = append(sorted, p[0], p[1])
+	}
+	return sorted
+}
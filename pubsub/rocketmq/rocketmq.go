@@ -33,6 +33,7 @@ import (
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 	kitstrings "github.com/dapr/kit/strings"
 )
@@ -76,6 +77,7 @@ type rocketMQ struct {
 	consumerLock  sync.Mutex
 	topics        map[string]mqc.MessageSelector
 	msgProperties map[string]bool
+	dedup         *pubsub.Deduplicator
 	logger        logger.Logger
 	wg            sync.WaitGroup
 	closed        atomic.Bool
@@ -92,12 +94,16 @@ func NewRocketMQ(l logger.Logger) pubsub.PubSub {
 	}
 }
 
-func (r *rocketMQ) Init(_ context.Context, metadata pubsub.Metadata) error {
+func (r *rocketMQ) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	var err error
 	r.metadata, err = parseRocketMQMetaData(metadata)
 	if err != nil {
 		return err
 	}
+	r.dedup, err = deduplication.New(ctx, metadata.Properties, r.logger)
+	if err != nil {
+		return err
+	}
 	r.topics = make(map[string]mqc.MessageSelector)
 	r.msgProperties = make(map[string]bool)
 	rlog.SetLogLevel(r.metadata.LogLevel)
@@ -378,6 +384,7 @@ func (r *rocketMQ) Subscribe(ctx context.Context, req pubsub.SubscribeRequest, h
 	if r.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = r.dedup.Handler(handler)
 
 	selector, e := buildMessageSelector(req)
 	if e != nil {
@@ -550,7 +557,7 @@ func (r *rocketMQ) Close() error {
 		r.consumer = nil
 	}
 
-	return nil
+	return r.dedup.Close()
 }
 
 // GetComponentMetadata returns the metadata of the component.
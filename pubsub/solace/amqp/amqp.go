@@ -31,6 +31,7 @@ import (
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/deduplication"
 	"github.com/dapr/kit/logger"
 )
 
@@ -43,6 +44,7 @@ const (
 type amqpPubSub struct {
 	session           *amqp.Session
 	metadata          *metadata
+	dedup             *pubsub.Deduplicator
 	logger            logger.Logger
 	publishLock       sync.RWMutex
 	publishRetryCount int
@@ -67,6 +69,10 @@ func (a *amqpPubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
 	}
 
 	a.metadata = amqpMeta
+	a.dedup, err = deduplication.New(ctx, metadata.Properties, a.logger)
+	if err != nil {
+		return err
+	}
 
 	s, err := a.connect(ctx)
 	if err != nil {
@@ -109,6 +115,7 @@ func (a *amqpPubSub) Publish(ctx context.Context, req *pubsub.PublishRequest) er
 	}
 
 	m := amqp.NewMessage(req.Data)
+	m.ApplicationProperties = applicationProperties(req.Metadata)
 
 	// If the request has ttl specified, put it on the message header
 	ttlProp := req.Metadata["ttlInSeconds"]
@@ -158,12 +165,18 @@ func (a *amqpPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest,
 	if a.closed.Load() {
 		return errors.New("component is closed")
 	}
+	handler = a.dedup.Handler(handler)
 
 	prefixedTopic := AddPrefixToAddress(req.Topic)
 
+	opts, err := a.receiverOptions(req)
+	if err != nil {
+		return err
+	}
+
 	receiver, err := a.session.NewReceiver(ctx,
 		prefixedTopic,
-		nil,
+		opts,
 	)
 
 	if err == nil {
@@ -209,6 +222,13 @@ func (a *amqpPubSub) subscribeForever(ctx context.Context, receiver *amqp.Receiv
 				Topic: receiver.LinkName(),
 			}
 
+			if len(msg.ApplicationProperties) > 0 {
+				pubsubMsg.Metadata = make(map[string]string, len(msg.ApplicationProperties))
+				for k, v := range msg.ApplicationProperties {
+					pubsubMsg.Metadata[k] = fmt.Sprint(v)
+				}
+			}
+
 			if err != nil {
 				a.logger.Errorf("failed to establish receiver")
 			}
@@ -316,7 +336,7 @@ func (a *amqpPubSub) Close() error {
 	if err != nil {
 		a.logger.Warnf("failed to close the connection.", err)
 	}
-	return err
+	return errors.Join(err, a.dedup.Close())
 }
 
 // Feature list for AMQP PubSub
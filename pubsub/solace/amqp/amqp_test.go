@@ -19,6 +19,8 @@ import (
 	"errors"
 	"testing"
 
+	amqp "github.com/Azure/go-amqp"
+
 	mdata "github.com/dapr/components-contrib/metadata"
 
 	"github.com/dapr/components-contrib/pubsub"
@@ -140,3 +142,91 @@ func TestParseMetadata(t *testing.T) {
 		assert.NotNil(t, m.tlsCfg.ClientKey, "failed to parse valid client certificate key")
 	})
 }
+
+func TestReceiverOptions(t *testing.T) {
+	log := logger.NewLogger("test")
+	newPubSub := func(t *testing.T, props map[string]string) *amqpPubSub {
+		fakeProperties := getFakeProperties()
+		for k, v := range props {
+			fakeProperties[k] = v
+		}
+		m, err := parseAMQPMetaData(pubsub.Metadata{Base: mdata.Base{Properties: fakeProperties}}, log)
+		require.NoError(t, err)
+		return &amqpPubSub{metadata: m, logger: log}
+	}
+
+	t.Run("non-durable by default", func(t *testing.T) {
+		a := newPubSub(t, nil)
+		opts, err := a.receiverOptions(pubsub.SubscribeRequest{Topic: "orders"})
+
+		require.NoError(t, err)
+		assert.Empty(t, opts.Name)
+		assert.Equal(t, amqp.DurabilityNone, opts.Durability)
+		assert.Empty(t, opts.Filters)
+	})
+
+	t.Run("durable topic endpoint", func(t *testing.T) {
+		a := newPubSub(t, map[string]string{durable: "true"})
+		opts, err := a.receiverOptions(pubsub.SubscribeRequest{Topic: "topic:orders"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "client-orders", opts.Name)
+		assert.Equal(t, amqp.DurabilityUnsettledState, opts.Durability)
+		assert.Equal(t, amqp.ExpiryPolicyNever, opts.ExpiryPolicy)
+	})
+
+	t.Run("subscription overrides durability", func(t *testing.T) {
+		a := newPubSub(t, map[string]string{durable: "true"})
+		opts, err := a.receiverOptions(pubsub.SubscribeRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{durable: "false"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, amqp.DurabilityNone, opts.Durability)
+
+		_, err = a.receiverOptions(pubsub.SubscribeRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{durable: "maybe"},
+		})
+		require.ErrorContains(t, err, "invalid durable value")
+	})
+
+	t.Run("queues are not durable topic endpoints", func(t *testing.T) {
+		a := newPubSub(t, map[string]string{durable: "true"})
+		opts, err := a.receiverOptions(pubsub.SubscribeRequest{Topic: "queue:orders"})
+
+		require.NoError(t, err)
+		assert.Empty(t, opts.Name)
+		assert.Equal(t, amqp.DurabilityNone, opts.Durability)
+	})
+
+	t.Run("durable requires a consumer ID", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[durable] = "true"
+		delete(fakeProperties, "consumerID")
+		_, err := parseAMQPMetaData(pubsub.Metadata{Base: mdata.Base{Properties: fakeProperties}}, log)
+
+		require.ErrorContains(t, err, "missing consumerID")
+	})
+
+	t.Run("selector", func(t *testing.T) {
+		a := newPubSub(t, nil)
+		opts, err := a.receiverOptions(pubsub.SubscribeRequest{
+			Topic:    "orders",
+			Metadata: map[string]string{selector: "region = 'eu'"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, opts.Filters, 1)
+	})
+}
+
+func TestApplicationProperties(t *testing.T) {
+	assert.Nil(t, applicationProperties(nil))
+	assert.Nil(t, applicationProperties(map[string]string{"ttlInSeconds": "10"}))
+	assert.Equal(t, map[string]any{"region": "eu"}, applicationProperties(map[string]string{
+		"region":       "eu",
+		"ttlInSeconds": "10",
+	}))
+}
@@ -34,6 +34,10 @@ type metadata struct {
 	Username  string
 	Password  string
 	Anonymous bool
+	// Name of the consumer, used to name the durable topic endpoints
+	ConsumerID string `mapstructure:"consumerID"`
+	// If true, topic subscriptions use durable topic endpoints that retain messages while the consumer is disconnected
+	Durable bool `mapstructure:"durable"`
 }
 
 type tlsCfg struct {
@@ -51,6 +55,8 @@ const (
 	amqpCACert     = "caCert"
 	amqpClientCert = "clientCert"
 	amqpClientKey  = "clientKey"
+	durable        = "durable"
+	selector       = "selector"
 	defaultWait    = 30 * time.Second
 )
 
@@ -85,6 +91,10 @@ func parseAMQPMetaData(md pubsub.Metadata, log logger.Logger) (*metadata, error)
 		}
 	}
 
+	if m.Durable && m.ConsumerID == "" {
+		return &m, fmt.Errorf("%s missing consumerID, required for durable subscriptions", errorMsgPrefix)
+	}
+
 	if m.CaCert != "" {
 		if !isValidPEM(m.CaCert) {
 			return &m, fmt.Errorf("%s invalid caCert", errorMsgPrefix)
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	amqp "github.com/Azure/go-amqp"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+// receiverOptions returns the options of the receiver link for a subscription.
+// The "durable" subscription metadata property overrides the component setting, and the "selector" property filters
+// the messages on the broker using a SQL-92 selector expression on the message properties.
+func (a *amqpPubSub) receiverOptions(req pubsub.SubscribeRequest) (*amqp.ReceiverOptions, error) {
+	opts := &amqp.ReceiverOptions{}
+
+	if v := req.Metadata[selector]; v != "" {
+		opts.Filters = []amqp.LinkFilter{amqp.NewSelectorFilter(v)}
+	}
+
+	isDurable := a.metadata.Durable
+	if v := req.Metadata[durable]; v != "" {
+		var err error
+		isDurable, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s invalid %s value for topic %s: %w", errorMsgPrefix, durable, req.Topic, err)
+		}
+	}
+
+	// Queues are durable already
+	if isDurable && !strings.HasPrefix(req.Topic, "queue:") {
+		if a.metadata.ConsumerID == "" {
+			return nil, fmt.Errorf("%s missing consumerID, required for durable subscriptions", errorMsgPrefix)
+		}
+
+		// The broker names the durable topic endpoint after the link, and keeps it when the link is detached or the
+		// session ends, so the subscription resumes from the messages retained in the meantime
+		opts.Name = durableEndpointName(a.metadata.ConsumerID, req.Topic)
+		opts.Durability = amqp.DurabilityUnsettledState
+		opts.ExpiryPolicy = amqp.ExpiryPolicyNever
+	}
+
+	return opts, nil
+}
+
+func durableEndpointName(consumerID string, topic string) string {
+	return consumerID + "-" + strings.TrimPrefix(topic, "topic:")
+}
+
+// applicationProperties returns the publish request metadata to send as application properties of the message, so
+// subscribers can select messages based on them.
+func applicationProperties(md map[string]string) map[string]any {
+	props := make(map[string]any, len(md))
+	for k, v := range md {
+		if k == "ttlInSeconds" {
+			continue
+		}
+		props[k] = v
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
@@ -16,9 +16,12 @@ package secretmanager
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 
 	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
@@ -30,6 +33,9 @@ import (
 const (
 	VersionID    = "version_id"
 	VersionStage = "version_stage"
+	// VersionStages is a comma-separated list of stages to retrieve at once, e.g. "AWSCURRENT,AWSPENDING".
+	// The response data contains one entry per stage that exists, keyed by the stage name.
+	VersionStages = "version_stages"
 )
 
 var _ secretstores.SecretStore = (*smSecretStore)(nil)
@@ -77,7 +83,12 @@ func (s *smSecretStore) Init(ctx context.Context, metadata secretstores.Metadata
 }
 
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values.
+// The version ID and the stage labels of the returned version are included in the response metadata.
 func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	if value, ok := req.Metadata[VersionStages]; ok {
+		return s.getSecretStages(ctx, req.Name, value)
+	}
+
 	var versionID *string
 	if value, ok := req.Metadata[VersionID]; ok {
 		versionID = &value
@@ -96,15 +107,76 @@ func (s *smSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecre
 	}
 
 	resp := secretstores.GetSecretResponse{
-		Data: map[string]string{},
+		Data:     map[string]string{},
+		Metadata: map[string]string{},
 	}
 	if output.Name != nil && output.SecretString != nil {
 		resp.Data[*output.Name] = *output.SecretString
 	}
+	if output.VersionId != nil {
+		resp.Metadata[VersionID] = *output.VersionId
+	}
+	if len(output.VersionStages) > 0 {
+		resp.Metadata[VersionStages] = joinStages(output.VersionStages)
+	}
+
+	return resp, nil
+}
+
+// getSecretStages retrieves the versions of a secret that are labeled with the given stages.
+// Stages that aren't attached to any version are skipped, which is expected for AWSPENDING outside of a rotation.
+func (s *smSecretStore) getSecretStages(ctx context.Context, name string, stages string) (secretstores.GetSecretResponse, error) {
+	resp := secretstores.GetSecretResponse{
+		Data:     map[string]string{},
+		Metadata: map[string]string{},
+	}
+
+	for _, stage := range strings.Split(stages, ",") {
+		stage = strings.TrimSpace(stage)
+		if stage == "" {
+			continue
+		}
+
+		output, err := s.authProvider.SecretManager().Manager.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId:     &name,
+			VersionStage: &stage,
+		})
+		if err != nil {
+			var awsErr awserr.Error
+			if errors.As(err, &awsErr) && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+				continue
+			}
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't get secret stage %s: %s", stage, err)
+		}
+
+		if output.SecretString != nil {
+			resp.Data[stage] = *output.SecretString
+		}
+		if output.VersionId != nil {
+			resp.Metadata[stage+"."+VersionID] = *output.VersionId
+		}
+		if len(output.VersionStages) > 0 {
+			resp.Metadata[stage+"."+VersionStages] = joinStages(output.VersionStages)
+		}
+	}
+
+	if len(resp.Data) == 0 {
+		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't get secret: no version of %s found with stages %s", name, stages)
+	}
 
 	return resp, nil
 }
 
+func joinStages(stages []*string) string {
+	labels := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		if stage != nil {
+			labels = append(labels, *stage)
+		}
+	}
+	return strings.Join(labels, ",")
+}
+
 // BulkGetSecret retrieves all secrets in the store and returns a map of decrypted string/string values.
 func (s *smSecretStore) BulkGetSecret(ctx context.Context, req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
 	resp := secretstores.BulkGetSecretResponse{
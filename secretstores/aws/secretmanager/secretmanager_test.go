@@ -19,6 +19,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/stretchr/testify/assert"
@@ -162,6 +164,87 @@ func TestGetSecret(t *testing.T) {
 			require.NoError(t, e)
 			assert.Equal(t, secretValue, output.Data[req.Name])
 		})
+
+		t.Run("returns version id and stages in metadata", func(t *testing.T) {
+			mockSSM := &awsAuth.MockSecretManager{
+				GetSecretValueFn: func(ctx context.Context, input *secretsmanager.GetSecretValueInput, option ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+					return &secretsmanager.GetSecretValueOutput{
+						Name:          input.SecretId,
+						SecretString:  aws.String(secretValue),
+						VersionId:     aws.String("v1"),
+						VersionStages: aws.StringSlice([]string{"AWSCURRENT", "custom"}),
+					}, nil
+				},
+			}
+
+			mockAuthProvider := &awsAuth.StaticAuth{}
+			mockAuthProvider.WithMockClients(&awsAuth.Clients{
+				Secret: &awsAuth.SecretManagerClients{Manager: mockSSM},
+			})
+			s := smSecretStore{
+				authProvider: mockAuthProvider,
+			}
+
+			req := secretstores.GetSecretRequest{
+				Name:     "/aws/secret/testing",
+				Metadata: map[string]string{},
+			}
+			output, e := s.GetSecret(t.Context(), req)
+			require.NoError(t, e)
+			assert.Equal(t, secretValue, output.Data[req.Name])
+			assert.Equal(t, "v1", output.Metadata[VersionID])
+			assert.Equal(t, "AWSCURRENT,custom", output.Metadata[VersionStages])
+		})
+
+		t.Run("with multiple version stages", func(t *testing.T) {
+			mockSSM := &awsAuth.MockSecretManager{
+				GetSecretValueFn: func(ctx context.Context, input *secretsmanager.GetSecretValueInput, option ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+					require.NotNil(t, input.VersionStage)
+					switch *input.VersionStage {
+					case "AWSCURRENT":
+						return &secretsmanager.GetSecretValueOutput{
+							Name:          input.SecretId,
+							SecretString:  aws.String("current"),
+							VersionId:     aws.String("v2"),
+							VersionStages: aws.StringSlice([]string{"AWSCURRENT"}),
+						}, nil
+					case "AWSPREVIOUS":
+						return &secretsmanager.GetSecretValueOutput{
+							Name:          input.SecretId,
+							SecretString:  aws.String("previous"),
+							VersionId:     aws.String("v1"),
+							VersionStages: aws.StringSlice([]string{"AWSPREVIOUS"}),
+						}, nil
+					default:
+						return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+					}
+				},
+			}
+
+			mockAuthProvider := &awsAuth.StaticAuth{}
+			mockAuthProvider.WithMockClients(&awsAuth.Clients{
+				Secret: &awsAuth.SecretManagerClients{Manager: mockSSM},
+			})
+			s := smSecretStore{
+				authProvider: mockAuthProvider,
+			}
+
+			req := secretstores.GetSecretRequest{
+				Name: "/aws/secret/testing",
+				Metadata: map[string]string{
+					VersionStages: "AWSCURRENT, AWSPREVIOUS,AWSPENDING",
+				},
+			}
+			output, e := s.GetSecret(t.Context(), req)
+			require.NoError(t, e)
+			assert.Equal(t, map[string]string{"AWSCURRENT": "current", "AWSPREVIOUS": "previous"}, output.Data)
+			assert.Equal(t, "v2", output.Metadata["AWSCURRENT."+VersionID])
+			assert.Equal(t, "v1", output.Metadata["AWSPREVIOUS."+VersionID])
+
+			req.Metadata[VersionStages] = "AWSPENDING"
+			_, e = s.GetSecret(t.Context(), req)
+			require.Error(t, e)
+		})
 	})
 
 	t.Run("unsuccessfully retrieve secret", func(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +28,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"golang.org/x/net/http2"
@@ -46,6 +49,8 @@ const (
 	componentCaPem               string = "caPem"
 	componentSkipVerify          string = "skipVerify"
 	componentTLSServerName       string = "tlsServerName"
+	componentClientCert          string = "clientCert"
+	componentClientKey           string = "clientKey"
 	componentVaultToken          string = "vaultToken"
 	componentVaultTokenMountPath string = "vaultTokenMountPath"
 	componentVaultKVPrefix       string = "vaultKVPrefix"
@@ -56,6 +61,7 @@ const (
 	vaultEnginePath              string = "enginePath"
 	vaultValueType               string = "vaultValueType"
 	versionID                    string = "version_id"
+	defaultVaultCertAuthPath     string = "cert"
 
 	DataStr string = "data"
 )
@@ -67,6 +73,13 @@ const (
 	valueTypeText valueType = "text"
 )
 
+type authMethod string
+
+const (
+	authMethodToken authMethod = "token"
+	authMethodCert  authMethod = "cert"
+)
+
 var _ secretstores.SecretStore = (*vaultSecretStore)(nil)
 
 func (v valueType) isMapType() bool {
@@ -85,6 +98,14 @@ type vaultSecretStore struct {
 	vaultEnginePath     string
 	vaultValueType      valueType
 
+	// Settings for the TLS certificate auth method.
+	// When it's used, the token is obtained by logging in with the client certificate and is renewed before its lease expires.
+	authMethod   authMethod
+	certAuthPath string
+	certAuthRole string
+	tokenRenewAt time.Time
+	tokenLock    sync.Mutex
+
 	json jsoniter.API
 
 	logger logger.Logger
@@ -96,6 +117,8 @@ type VaultMetadata struct {
 	CaPem               string
 	SkipVerify          string
 	TLSServerName       string
+	ClientCert          string
+	ClientKey           string
 	VaultAddr           string
 	VaultKVPrefix       string
 	VaultKVUsePrefix    bool
@@ -103,6 +126,9 @@ type VaultMetadata struct {
 	VaultTokenMountPath string
 	EnginePath          string
 	VaultValueType      string
+	VaultAuthMethod     string
+	VaultCertAuthPath   string
+	VaultCertAuthRole   string
 }
 
 // tlsConfig is TLS configuration to interact with HashiCorp Vault.
@@ -112,6 +138,8 @@ type tlsConfig struct {
 	vaultCAPath     string
 	vaultSkipVerify bool
 	vaultServerName string
+	clientCert      string
+	clientKey       string
 }
 
 // vaultKVResponse is the response data from Vault KV.
@@ -121,6 +149,14 @@ type vaultKVResponse struct {
 	} `json:"data"`
 }
 
+// vaultAuthResponse is the response data from a Vault login.
+type vaultAuthResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
 // vaultListKVResponse is the response data from Vault KV.
 type vaultListKVResponse struct {
 	Data struct {
@@ -138,7 +174,7 @@ func NewHashiCorpVaultSecretStore(logger logger.Logger) secretstores.SecretStore
 }
 
 // Init creates a HashiCorp Vault client.
-func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) error {
+func (v *vaultSecretStore) Init(ctx context.Context, meta secretstores.Metadata) error {
 	m := VaultMetadata{
 		VaultKVUsePrefix: true,
 	}
@@ -171,11 +207,32 @@ func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) e
 		}
 	}
 
-	v.vaultToken = m.VaultToken
-	v.vaultTokenMountPath = m.VaultTokenMountPath
-	initErr := v.initVaultToken()
-	if initErr != nil {
-		return initErr
+	v.authMethod = authMethodToken
+	if m.VaultAuthMethod != "" {
+		v.authMethod = authMethod(m.VaultAuthMethod)
+	}
+	switch v.authMethod {
+	case authMethodToken:
+		v.vaultToken = m.VaultToken
+		v.vaultTokenMountPath = m.VaultTokenMountPath
+		initErr := v.initVaultToken()
+		if initErr != nil {
+			return initErr
+		}
+	case authMethodCert:
+		if m.ClientCert == "" || m.ClientKey == "" {
+			return fmt.Errorf("vault init error, %s and %s are required with the cert auth method", componentClientCert, componentClientKey)
+		}
+		if m.VaultToken != "" || m.VaultTokenMountPath != "" {
+			return fmt.Errorf("vault init error, %s and %s cannot be set with the cert auth method", componentVaultToken, componentVaultTokenMountPath)
+		}
+		v.certAuthPath = defaultVaultCertAuthPath
+		if m.VaultCertAuthPath != "" {
+			v.certAuthPath = strings.Trim(m.VaultCertAuthPath, "/")
+		}
+		v.certAuthRole = m.VaultCertAuthRole
+	default:
+		return fmt.Errorf("vault init error, invalid auth method %s, accepted values are token or cert", m.VaultAuthMethod)
 	}
 
 	vaultKVPrefix := m.VaultKVPrefix
@@ -196,6 +253,13 @@ func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) e
 
 	v.client = client
 
+	if v.authMethod == authMethodCert {
+		err = v.certLogin(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -213,6 +277,8 @@ func metadataToTLSConfig(meta *VaultMetadata) *tlsConfig {
 	tlsConf.vaultCAPem = meta.CaPem
 	tlsConf.vaultCAPath = meta.CaPath
 	tlsConf.vaultServerName = meta.TLSServerName
+	tlsConf.clientCert = meta.ClientCert
+	tlsConf.clientKey = meta.ClientKey
 
 	return &tlsConf
 }
@@ -232,7 +298,11 @@ func (v *vaultSecretStore) getSecret(ctx context.Context, secret, version string
 		return nil, fmt.Errorf("couldn't generate request: %w", err)
 	}
 	// Set vault token.
-	httpReq.Header.Set(vaultHTTPHeader, v.vaultToken)
+	token, err := v.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(vaultHTTPHeader, token)
 	// Set X-Vault-Request header
 	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
 
@@ -351,7 +421,11 @@ func (v *vaultSecretStore) listKeysUnderPath(ctx context.Context, path string) (
 		return nil, fmt.Errorf("couldn't generate request: %s", err)
 	}
 	// Set vault token.
-	httpReq.Header.Set(vaultHTTPHeader, v.vaultToken)
+	token, err := v.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(vaultHTTPHeader, token)
 	// Set X-Vault-Request header
 	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
 	httpresp, err := v.client.Do(httpReq)
@@ -421,6 +495,80 @@ func (v *vaultSecretStore) initVaultToken() error {
 	return nil
 }
 
+// token returns the token used to authenticate requests.
+// With the cert auth method, it logs in again when the current token is close to the end of its lease.
+func (v *vaultSecretStore) token(ctx context.Context) (string, error) {
+	if v.authMethod != authMethodCert {
+		return v.vaultToken, nil
+	}
+
+	v.tokenLock.Lock()
+	defer v.tokenLock.Unlock()
+
+	if v.vaultToken != "" && (v.tokenRenewAt.IsZero() || time.Now().Before(v.tokenRenewAt)) {
+		return v.vaultToken, nil
+	}
+
+	err := v.certLogin(ctx)
+	if err != nil {
+		return "", err
+	}
+	return v.vaultToken, nil
+}
+
+// certLogin obtains a token from the TLS certificate auth method, using the client certificate configured on the HTTP client.
+// Callers must hold tokenLock, except during Init.
+func (v *vaultSecretStore) certLogin(ctx context.Context) error {
+	body := map[string]string{}
+	if v.certAuthRole != "" {
+		body["name"] = v.certAuthRole
+	}
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("couldn't encode login request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.vaultAddress+"/v1/auth/"+v.certAuthPath+"/login", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("couldn't generate request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	// Set X-Vault-Request header
+	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
+
+	httpresp, err := v.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("couldn't login with client certificate: %w", err)
+	}
+
+	defer httpresp.Body.Close()
+
+	if httpresp.StatusCode != http.StatusOK {
+		var b bytes.Buffer
+		io.Copy(&b, httpresp.Body)
+		return fmt.Errorf("couldn't login with client certificate, status code %d, body %s",
+			httpresp.StatusCode, b.String())
+	}
+
+	var d vaultAuthResponse
+	if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+		return fmt.Errorf("couldn't decode response body: %s", err)
+	}
+	if d.Auth.ClientToken == "" {
+		return errors.New("couldn't login with client certificate: response did not contain a token")
+	}
+
+	v.vaultToken = d.Auth.ClientToken
+	v.tokenRenewAt = time.Time{}
+	if d.Auth.LeaseDuration > 0 {
+		// Renew once 80% of the lease has elapsed
+		v.tokenRenewAt = time.Now().Add(time.Duration(d.Auth.LeaseDuration) * time.Second * 4 / 5)
+	}
+	v.logger.Debugf("hashicorp vault: logged in with client certificate, token lease is %ds", d.Auth.LeaseDuration)
+
+	return nil
+}
+
 func (v *vaultSecretStore) createHTTPClient(config *tlsConfig) (*http.Client, error) {
 	tlsClientConfig := &tls.Config{MinVersion: tls.VersionTLS12}
 
@@ -442,6 +590,14 @@ func (v *vaultSecretStore) createHTTPClient(config *tlsConfig) (*http.Client, er
 		}
 	}
 
+	if config.clientCert != "" && config.clientKey != "" {
+		cert, err := loadClientCertificate(config.clientCert, config.clientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
 	// Setup http transport
 	transport := &http.Transport{
 		TLSClientConfig: tlsClientConfig,
@@ -496,6 +652,35 @@ func (v *vaultSecretStore) getRootCAsPools(vaultCAPem string, vaultCAPath string
 	return certPool, nil
 }
 
+// loadClientCertificate loads the client certificate and key, each of which is either a PEM-encoded string or the path to a PEM file.
+func loadClientCertificate(cert string, key string) (tls.Certificate, error) {
+	certBytes, err := readPEMOrFile(componentClientCert, cert)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyBytes, err := readPEMOrFile(componentClientKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	pair, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("couldn't load client certificate: %w", err)
+	}
+	return pair, nil
+}
+
+// readPEMOrFile returns val if it's PEM-encoded, otherwise treats it as a path and returns the contents of the file.
+func readPEMOrFile(name string, val string) ([]byte, error) {
+	if block, _ := pem.Decode([]byte(val)); block != nil {
+		return []byte(val), nil
+	}
+	data, err := os.ReadFile(val)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s from disk: %s", name, err)
+	}
+	return data, nil
+}
+
 // readCertificateFile reads the certificate at given path.
 func readCertificateFile(certPool *x509.CertPool, path string) error {
 	// Read certificate file
@@ -14,10 +14,23 @@ limitations under the License.
 package vault
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -117,6 +130,126 @@ func TestVaultTLSConfig(t *testing.T) {
 	})
 }
 
+func TestVaultCertAuth(t *testing.T) {
+	clientCert, clientKey := generateClientCertificate(t)
+
+	var logins atomic.Int32
+	leaseDuration := int64(3600)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/cert/login":
+			var body map[string]string
+			if len(r.TLS.PeerCertificates) == 0 || json.NewDecoder(r.Body).Decode(&body) != nil || body["name"] != "web" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			n := logins.Add(1)
+			json.NewEncoder(w).Encode(map[string]any{
+				"auth": map[string]any{
+					"client_token":   "token" + strconv.Itoa(int(n)),
+					"lease_duration": leaseDuration,
+				},
+			})
+		case "/v1/secret/data/dapr/mysecret":
+			if r.Header.Get(vaultHTTPHeader) != "token"+strconv.Itoa(int(logins.Load())) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"data":{"data":{"foo":"bar"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	properties := map[string]string{
+		"vaultAddr":         server.URL,
+		"caPem":             serverCA,
+		"clientCert":        clientCert,
+		"clientKey":         clientKey,
+		"vaultAuthMethod":   "cert",
+		"vaultCertAuthRole": "web",
+	}
+
+	t.Run("login and get secret", func(t *testing.T) {
+		logins.Store(0)
+		target := &vaultSecretStore{logger: logger.NewLogger("test")}
+		err := target.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: properties}})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), logins.Load())
+
+		res, err := target.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "mysecret"})
+		require.NoError(t, err)
+		assert.Equal(t, "bar", res.Data["foo"])
+		assert.Equal(t, int32(1), logins.Load())
+	})
+
+	t.Run("token is renewed before lease expires", func(t *testing.T) {
+		logins.Store(0)
+		target := &vaultSecretStore{logger: logger.NewLogger("test")}
+		err := target.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: properties}})
+		require.NoError(t, err)
+
+		target.tokenRenewAt = time.Now().Add(-time.Second)
+		res, err := target.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "mysecret"})
+		require.NoError(t, err)
+		assert.Equal(t, "bar", res.Data["foo"])
+		assert.Equal(t, int32(2), logins.Load())
+	})
+
+	t.Run("client certificate is required", func(t *testing.T) {
+		target := &vaultSecretStore{logger: logger.NewLogger("test")}
+		err := target.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultAddr":       server.URL,
+			"vaultAuthMethod": "cert",
+		}}})
+		require.Error(t, err)
+	})
+
+	t.Run("token cannot be set with cert auth", func(t *testing.T) {
+		props := map[string]string{componentVaultToken: expectedTok}
+		for k, v := range properties {
+			props[k] = v
+		}
+		target := &vaultSecretStore{logger: logger.NewLogger("test")}
+		err := target.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: props}})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid auth method", func(t *testing.T) {
+		target := &vaultSecretStore{logger: logger.NewLogger("test")}
+		err := target.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultAuthMethod": "approle",
+		}}})
+		require.Error(t, err)
+	})
+}
+
+func generateClientCertificate(t *testing.T) (certPEM string, keyPEM string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dapr"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
 func TestVaultEnginePath(t *testing.T) {
 	t.Run("without engine path config", func(t *testing.T) {
 		v := vaultSecretStore{
@@ -16,6 +16,8 @@ package secretstores
 // GetSecretResponse describes the response object for a secret returned from a secret store.
 type GetSecretResponse struct {
 	Data map[string]string `json:"data"`
+	// Metadata contains optional, store-specific information about the returned secret, such as its version.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // BulkGetSecretResponse describes the response object for all the secrets returned from a secret store.
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 	"github.com/dapr/kit/ptr"
+	kitstrings "github.com/dapr/kit/strings"
 )
 
 // StateStore is a CosmosDB state store.
@@ -57,6 +59,9 @@ type metadata struct {
 	Database    string `json:"database"`
 	Collection  string `json:"collection"`
 	ContentType string `json:"contentType"`
+
+	// Maximum staleness of items served by the integrated cache, when connecting through a dedicated gateway
+	MaxIntegratedCacheStaleness time.Duration `json:"maxIntegratedCacheStaleness"`
 }
 
 type cosmosOperationType string
@@ -79,8 +84,17 @@ type CosmosItem struct {
 }
 
 const (
-	metadataPartitionKey = "partitionKey"
-	defaultTimeout       = 20 * time.Second
+	metadataPartitionKey                = "partitionKey"
+	metadataConsistencyLevel            = "consistencyLevel"
+	metadataSessionToken                = "sessionToken"
+	metadataMaxIntegratedCacheStaleness = "maxIntegratedCacheStaleness"
+	metadataBypassIntegratedCache       = "bypassIntegratedCache"
+	defaultTimeout                      = 20 * time.Second
+
+	// Headers used to control the integrated cache of the dedicated gateway, which are not supported by the SDK
+	// See: https://learn.microsoft.com/azure/cosmos-db/integrated-cache
+	headerDedicatedGatewayMaxAge      = "x-ms-dedicatedgateway-max-age"
+	headerDedicatedGatewayBypassCache = "x-ms-dedicatedgateway-bypass-cache"
 )
 
 // Policy that makes all queries cross-partition
@@ -139,6 +153,9 @@ func (c *StateStore) Init(ctx context.Context, meta state.Metadata) error {
 	if m.ContentType == "" {
 		return errors.New("contentType is required")
 	}
+	if m.MaxIntegratedCacheStaleness < 0 {
+		return errors.New("maxIntegratedCacheStaleness must not be negative")
+	}
 
 	// Internal query policy was created due to lack of cross partition query capability in the current Go sdk
 	opts := azcosmos.ClientOptions{
@@ -223,6 +240,11 @@ func (c *StateStore) Get(ctx context.Context, req *state.GetRequest) (*state.Get
 		options.ConsistencyLevel = azcosmos.ConsistencyLevelEventual.ToPtr()
 	}
 
+	ctx, err := c.applyReadOptions(ctx, req.Metadata, &options.ConsistencyLevel, &options.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+
 	readCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 	readItem, err := c.client.ReadItem(readCtx, azcosmos.NewPartitionKeyString(partitionKey), req.Key, &options)
@@ -244,6 +266,12 @@ func (c *StateStore) Get(ctx context.Context, req *state.GetRequest) (*state.Get
 			state.GetRespMetaKeyTTLExpireTime: time.Unix(item.TS+int64(*item.TTL), 0).UTC().Format(time.RFC3339),
 		}
 	}
+	if readItem.SessionToken != nil && *readItem.SessionToken != "" {
+		if metadata == nil {
+			metadata = make(map[string]string, 1)
+		}
+		metadata[metadataSessionToken] = *readItem.SessionToken
+	}
 
 	// We are sure this is a []byte if not nil
 	b, _ := item.Value.([]byte)
@@ -284,6 +312,11 @@ func (c *StateStore) getMulti(ctx context.Context, req []state.GetRequest) ([]st
 	if consistency != "" {
 		queryOpts.ConsistencyLevel = &consistency
 	}
+	// All requests in a bulk operation share the same metadata
+	ctx, err := c.applyReadOptions(ctx, req[0].Metadata, &queryOpts.ConsistencyLevel, &queryOpts.SessionToken)
+	if err != nil {
+		return nil, err
+	}
 	pager := c.client.NewQueryItemsPager(
 		"SELECT * FROM r WHERE ARRAY_CONTAINS(@keys, r.id)",
 		pk, queryOpts,
@@ -602,6 +635,11 @@ func (c *StateStore) Query(ctx context.Context, req *state.QueryRequest) (*state
 		q.partitionKey = val
 	}
 
+	ctx, err := c.applyReadOptions(ctx, req.Metadata, &q.consistencyLevel, &q.sessionToken)
+	if err != nil {
+		return nil, err
+	}
+
 	data, token, err := q.execute(ctx, c.client)
 	if err != nil {
 		return nil, err
@@ -676,6 +714,52 @@ func createUpsertItem(contentType string, req state.SetRequest, partitionKey str
 	return item, nil
 }
 
+// applyReadOptions applies the consistency level and session token set in the request metadata,
+// and returns a context with the headers that control the integrated cache.
+func (c *StateStore) applyReadOptions(ctx context.Context, requestMetadata map[string]string, consistencyLevel **azcosmos.ConsistencyLevel, sessionToken **string) (context.Context, error) {
+	if val := requestMetadata[metadataConsistencyLevel]; val != "" {
+		level, err := parseConsistencyLevel(val)
+		if err != nil {
+			return ctx, err
+		}
+		*consistencyLevel = &level
+	}
+
+	if val := requestMetadata[metadataSessionToken]; val != "" {
+		*sessionToken = &val
+	}
+
+	header := http.Header{}
+	if kitstrings.IsTruthy(requestMetadata[metadataBypassIntegratedCache]) {
+		header.Set(headerDedicatedGatewayBypassCache, "true")
+	}
+	maxStaleness := c.metadata.MaxIntegratedCacheStaleness
+	if val := requestMetadata[metadataMaxIntegratedCacheStaleness]; val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d < 0 {
+			return ctx, fmt.Errorf("invalid value for metadata property %s: %s", metadataMaxIntegratedCacheStaleness, val)
+		}
+		maxStaleness = d
+	}
+	if maxStaleness > 0 {
+		header.Set(headerDedicatedGatewayMaxAge, strconv.FormatInt(maxStaleness.Milliseconds(), 10))
+	}
+	if len(header) == 0 {
+		return ctx, nil
+	}
+	return policy.WithHTTPHeader(ctx, header), nil
+}
+
+// parseConsistencyLevel parses a consistency level, case-insensitively.
+func parseConsistencyLevel(val string) (azcosmos.ConsistencyLevel, error) {
+	for _, level := range azcosmos.ConsistencyLevelValues() {
+		if strings.EqualFold(string(level), val) {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("invalid value for metadata property %s: %s", metadataConsistencyLevel, val)
+}
+
 // This is a helper to return the partition key to use.  If if metadata["partitionkey"] is present,
 // use that, otherwise use what's in "key".
 func populatePartitionMetadata(key string, requestMetadata map[string]string) string {
@@ -38,6 +38,9 @@ type Query struct {
 	limit        int
 	token        string
 	partitionKey string
+
+	consistencyLevel *azcosmos.ConsistencyLevel
+	sessionToken     *string
 }
 
 func (q *Query) VisitEQ(f *query.EQ) (string, error) {
@@ -255,7 +258,10 @@ func (q *Query) setNextParameterFloat(val float64) string {
 }
 
 func (q *Query) execute(ctx context.Context, client *azcosmos.ContainerClient) ([]state.QueryItem, string, error) {
-	opts := &azcosmos.QueryOptions{}
+	opts := &azcosmos.QueryOptions{
+		ConsistencyLevel: q.consistencyLevel,
+		SessionToken:     q.sessionToken,
+	}
 
 	resultLimit := q.limit
 	opts.QueryParameters = append(opts.QueryParameters, q.query.parameters...)
@@ -14,10 +14,16 @@ limitations under the License.
 package cosmosdb
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -357,3 +363,77 @@ func TestCreateCosmosItemWithTTL(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestApplyReadOptions(t *testing.T) {
+	store := &StateStore{
+		metadata: metadata{MaxIntegratedCacheStaleness: 5 * time.Minute},
+	}
+
+	// Returns the headers added to requests sent with the context
+	getHeaders := func(t *testing.T, ctx context.Context) http.Header {
+		t.Helper()
+		var header http.Header
+		pl := runtime.NewPipeline("test", "v0.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+				header = req.Header
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+			}),
+		})
+		req, err := runtime.NewRequest(ctx, http.MethodGet, "https://localhost")
+		require.NoError(t, err)
+		_, err = pl.Do(req)
+		require.NoError(t, err)
+		return header
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		var level *azcosmos.ConsistencyLevel
+		var sessionToken *string
+		ctx, err := store.applyReadOptions(t.Context(), nil, &level, &sessionToken)
+		require.NoError(t, err)
+		assert.Nil(t, level)
+		assert.Nil(t, sessionToken)
+
+		header := getHeaders(t, ctx)
+		assert.Equal(t, "300000", header.Get(headerDedicatedGatewayMaxAge))
+		assert.Empty(t, header.Get(headerDedicatedGatewayBypassCache))
+	})
+
+	t.Run("request overrides", func(t *testing.T) {
+		level := azcosmos.ConsistencyLevelSession.ToPtr()
+		var sessionToken *string
+		ctx, err := store.applyReadOptions(t.Context(), map[string]string{
+			"consistencyLevel":            "boundedstaleness",
+			"sessionToken":                "0:1#100",
+			"maxIntegratedCacheStaleness": "30s",
+			"bypassIntegratedCache":       "true",
+		}, &level, &sessionToken)
+		require.NoError(t, err)
+		assert.Equal(t, azcosmos.ConsistencyLevelBoundedStaleness, *level)
+		assert.Equal(t, "0:1#100", *sessionToken)
+
+		header := getHeaders(t, ctx)
+		assert.Equal(t, "30000", header.Get(headerDedicatedGatewayMaxAge))
+		assert.Equal(t, "true", header.Get(headerDedicatedGatewayBypassCache))
+	})
+
+	t.Run("invalid consistency level", func(t *testing.T) {
+		var level *azcosmos.ConsistencyLevel
+		var sessionToken *string
+		_, err := store.applyReadOptions(t.Context(), map[string]string{"consistencyLevel": "foo"}, &level, &sessionToken)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid max staleness", func(t *testing.T) {
+		var level *azcosmos.ConsistencyLevel
+		var sessionToken *string
+		_, err := store.applyReadOptions(t.Context(), map[string]string{"maxIntegratedCacheStaleness": "-1s"}, &level, &sessionToken)
+		require.Error(t, err)
+	})
+}
+
+type transportFunc func(req *http.Request) (*http.Response, error)
+
+func (fn transportFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
@@ -15,6 +15,8 @@ package postgresql
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dapr/components-contrib/common/authentication/aws"
@@ -30,6 +32,19 @@ const (
 	pgTableState pgTable = "state"
 )
 
+// Storage types for the value column.
+const (
+	valueTypeBytea = "bytea"
+	valueTypeJSONB = "jsonb"
+)
+
+// Operator classes for the GIN index on JSONB values.
+const (
+	jsonbIndexPathOps = "jsonb_path_ops"
+	jsonbIndexOps     = "jsonb_ops"
+	jsonbIndexNone    = "none"
+)
+
 const (
 	defaultCleanupInternal = time.Hour
 	defaultTimeout         = 20 * time.Second // Default timeout for network requests
@@ -42,6 +57,8 @@ type pgMetadata struct {
 	MetadataTableName string         `mapstructure:"metadataTableName"` // Could be in the format "schema.table" or just "table"
 	Timeout           time.Duration  `mapstructure:"timeout" mapstructurealiases:"timeoutInSeconds"`
 	CleanupInterval   *time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
+	ValueType         string         `mapstructure:"valueType"`  // "bytea" (default) or "jsonb", which enables the query API
+	JSONBIndex        string         `mapstructure:"jsonbIndex"` // Operator class of the GIN index on JSONB values, or "none"
 
 	aws.DeprecatedPostgresIAM `mapstructure:",squash"`
 }
@@ -53,6 +70,8 @@ func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithM
 	m.MetadataTableName = "dapr_metadata"
 	m.CleanupInterval = ptr.Of(defaultCleanupInternal)
 	m.Timeout = defaultTimeout
+	m.ValueType = valueTypeBytea
+	m.JSONBIndex = jsonbIndexPathOps
 
 	// Decode the metadata
 	err := metadata.DecodeMetadata(meta.Properties, &m)
@@ -71,6 +90,16 @@ func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithM
 		return errors.New("invalid value for 'timeout': must be greater than 1s")
 	}
 
+	// Value type and index
+	m.ValueType = strings.ToLower(m.ValueType)
+	if m.ValueType != valueTypeBytea && m.ValueType != valueTypeJSONB {
+		return fmt.Errorf("invalid value for 'valueType': %s. Expected %s or %s", m.ValueType, valueTypeBytea, valueTypeJSONB)
+	}
+	m.JSONBIndex = strings.ToLower(m.JSONBIndex)
+	if m.JSONBIndex != jsonbIndexPathOps && m.JSONBIndex != jsonbIndexOps && m.JSONBIndex != jsonbIndexNone {
+		return fmt.Errorf("invalid value for 'jsonbIndex': %s. Expected %s, %s, or %s", m.JSONBIndex, jsonbIndexPathOps, jsonbIndexOps, jsonbIndexNone)
+	}
+
 	// Cleanup interval
 	// Non-positive value from meta means disable auto cleanup.
 	// We need to do this check because an empty string and "0" are treated differently by DecodeMetadata
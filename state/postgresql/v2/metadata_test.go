@@ -257,4 +257,33 @@ func TestMetadata(t *testing.T) {
 		_ = assert.NotNil(t, m.CleanupInterval) &&
 			assert.Equal(t, defaultCleanupInternal, *m.CleanupInterval)
 	})
+
+	t.Run("value type", func(t *testing.T) {
+		m := pgMetadata{}
+		props := map[string]string{
+			"connectionString": "foo=bar",
+		}
+
+		opts := postgresql.InitWithMetadataOpts{}
+		err := m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, valueTypeBytea, m.ValueType)
+		assert.Equal(t, jsonbIndexPathOps, m.JSONBIndex)
+
+		props["valueType"] = "JSONB"
+		props["jsonbIndex"] = "jsonb_ops"
+		err = m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, valueTypeJSONB, m.ValueType)
+		assert.Equal(t, jsonbIndexOps, m.JSONBIndex)
+
+		props["valueType"] = "json"
+		err = m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.ErrorContains(t, err, "valueType")
+
+		props["valueType"] = "jsonb"
+		props["jsonbIndex"] = "btree"
+		err = m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.ErrorContains(t, err, "jsonbIndex")
+	})
 }
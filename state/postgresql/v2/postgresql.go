@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -67,7 +68,8 @@ type Options struct {
 }
 
 // NewPostgreSQLStateStore creates a new instance of PostgreSQL state store v2 with the default options.
-// The v2 of the component uses a different format for storing data, always in a BYTEA column, which is more efficient than the JSONB column used in v1.
+// The v2 of the component uses a different format for storing data, by default in a BYTEA column, which is more efficient than the JSONB column used in v1.
+// Setting valueType to "jsonb" stores values in a JSONB column instead, enabling the query API.
 // Additionally, v2 uses random UUIDs for etags instead of the xmin column, expanding support to all Postgres-compatible databases such as CockroachDB, etc.
 func NewPostgreSQLStateStore(logger logger.Logger) state.Store {
 	return NewPostgreSQLStateStoreWithOptions(logger, Options{})
@@ -138,6 +140,11 @@ func (p *PostgreSQL) Init(ctx context.Context, meta state.Metadata) (err error)
 		return err
 	}
 
+	err = p.ensureValueType(ctx)
+	if err != nil {
+		return err
+	}
+
 	if p.metadata.CleanupInterval != nil {
 		gc, err := sqlinternal.ScheduleGarbageCollector(sqlinternal.GCOptions{
 			Logger: p.logger,
@@ -212,13 +219,89 @@ CREATE INDEX ON %[1]s (expires_at);
 	})
 }
 
+// ensureValueType converts the value column when the configured valueType differs from the table's,
+// and maintains the GIN index on JSONB values.
+func (p *PostgreSQL) ensureValueType(ctx context.Context) error {
+	stateTable := p.metadata.TableName(pgTableState)
+
+	var current string
+	err := p.db.QueryRow(ctx,
+		`SELECT format_type(atttypid, atttypmod) FROM pg_attribute WHERE attrelid = $1::regclass AND attname = 'value'`,
+		stateTable,
+	).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to get the type of the value column of table '%s': %w", stateTable, err)
+	}
+	if current == valueTypeBytea && p.metadata.ValueType == valueTypeBytea {
+		return nil
+	}
+
+	// The GIN indexes are dropped before converting back to bytea, and when switching operator class
+	for _, opclass := range []string{jsonbIndexPathOps, jsonbIndexOps} {
+		if p.metadata.ValueType == valueTypeJSONB && opclass == p.metadata.JSONBIndex {
+			continue
+		}
+		_, err = p.db.Exec(ctx, "DROP INDEX IF EXISTS "+p.jsonbIndexName(opclass, true))
+		if err != nil {
+			return fmt.Errorf("failed to drop index on table '%s': %w", stateTable, err)
+		}
+	}
+
+	if current != p.metadata.ValueType {
+		p.logger.Infof("Converting the value column of table '%s' from %s to %s", stateTable, current, p.metadata.ValueType)
+		var using string
+		if p.metadata.ValueType == valueTypeJSONB {
+			using = "convert_from(value, 'UTF8')::jsonb"
+		} else {
+			using = "convert_to(value::text, 'UTF8')"
+		}
+		_, err = p.db.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN value TYPE %s USING %s`, stateTable, p.metadata.ValueType, using))
+		if err != nil {
+			return fmt.Errorf("failed to convert the value column of table '%s' to %s (all existing values must be valid JSON): %w", stateTable, p.metadata.ValueType, err)
+		}
+	}
+
+	if p.metadata.ValueType == valueTypeJSONB && p.metadata.JSONBIndex != jsonbIndexNone {
+		_, err = p.db.Exec(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (value %s)`,
+			p.jsonbIndexName(p.metadata.JSONBIndex, false), stateTable, p.metadata.JSONBIndex))
+		if err != nil {
+			// Multiple sidecars may race to create the index, as for the table
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &pgErr) || pgErr.Code != pgerrcode.UniqueViolation {
+				return fmt.Errorf("failed to create index on table '%s': %w", stateTable, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonbIndexName returns the name of the GIN index on JSONB values for an operator class.
+// Indexes are created in the schema of the table, so the name is qualified with it only when requested.
+func (p *PostgreSQL) jsonbIndexName(opclass string, qualified bool) string {
+	stateTable := p.metadata.TableName(pgTableState)
+	schema, table := "", stateTable
+	if i := strings.LastIndexByte(stateTable, '.'); i >= 0 {
+		schema, table = stateTable[:i+1], stateTable[i+1:]
+	}
+	name := table + "_value_" + opclass + "_idx"
+	if qualified {
+		return schema + name
+	}
+	return name
+}
+
 // Features returns the features available in this state store.
 func (p *PostgreSQL) Features() []state.Feature {
-	return []state.Feature{
+	features := []state.Feature{
 		state.FeatureETag,
 		state.FeatureTransactional,
 		state.FeatureTTL,
 	}
+	if p.metadata.ValueType == valueTypeJSONB {
+		features = append(features, state.FeatureQueryAPI)
+	}
+	return features
 }
 
 func (p *PostgreSQL) GetDB() *pgxpool.Pool {
@@ -255,6 +338,9 @@ func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier,
 			return fmt.Errorf("failed to marshal to JSON: %w", err)
 		}
 	}
+	if p.metadata.ValueType == valueTypeJSONB && !json.Valid(value) {
+		return errors.New("value must be valid JSON when valueType is jsonb")
+	}
 
 	// TTL
 	var ttlSeconds int
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/dapr/kit/ptr"
+)
+
+// Query executes a query against the store.
+// It requires values to be stored as JSONB: filters are translated into SQL/JSON path predicates,
+// which can use the GIN index on the value column.
+func (p *PostgreSQL) Query(parentCtx context.Context, req *state.QueryRequest) (*state.QueryResponse, error) {
+	if p.metadata.ValueType != valueTypeJSONB {
+		return nil, errors.New("the query API requires the metadata property 'valueType' to be set to 'jsonb'")
+	}
+
+	q := &Query{
+		tableName: p.metadata.TableName(pgTableState),
+	}
+	qbuilder := query.NewQueryBuilder(q)
+	if err := qbuilder.BuildQuery(&req.Query); err != nil {
+		return &state.QueryResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
+	defer cancel()
+	data, token, err := q.execute(ctx, p.db)
+	if err != nil {
+		return &state.QueryResponse{}, err
+	}
+
+	return &state.QueryResponse{
+		Results: data,
+		Token:   token,
+	}, nil
+}
+
+type Query struct {
+	query     string
+	params    []any
+	limit     int
+	skip      *int64
+	tableName string
+}
+
+func (q *Query) VisitEQ(f *query.EQ) (string, error) {
+	return q.whereJSONPath(f.Key, "==", f.Val)
+}
+
+func (q *Query) VisitNEQ(f *query.NEQ) (string, error) {
+	return q.whereJSONPath(f.Key, "!=", f.Val)
+}
+
+func (q *Query) VisitGT(f *query.GT) (string, error) {
+	if v, ok := f.Val.(string); ok {
+		return "", fmt.Errorf("unsupported type of value %s; string type not permitted", v)
+	}
+	return q.whereJSONPath(f.Key, ">", f.Val)
+}
+
+func (q *Query) VisitGTE(f *query.GTE) (string, error) {
+	if v, ok := f.Val.(string); ok {
+		return "", fmt.Errorf("unsupported type of value %s; string type not permitted", v)
+	}
+	return q.whereJSONPath(f.Key, ">=", f.Val)
+}
+
+func (q *Query) VisitLT(f *query.LT) (string, error) {
+	if v, ok := f.Val.(string); ok {
+		return "", fmt.Errorf("unsupported type of value %s; string type not permitted", v)
+	}
+	return q.whereJSONPath(f.Key, "<", f.Val)
+}
+
+func (q *Query) VisitLTE(f *query.LTE) (string, error) {
+	if v, ok := f.Val.(string); ok {
+		return "", fmt.Errorf("unsupported type of value %s; string type not permitted", v)
+	}
+	return q.whereJSONPath(f.Key, "<=", f.Val)
+}
+
+func (q *Query) VisitIN(f *query.IN) (string, error) {
+	if len(f.Vals) == 0 {
+		return "", fmt.Errorf("empty IN operator for key %q", f.Key)
+	}
+	return q.whereJSONPath(f.Key, "==", f.Vals...)
+}
+
+func (q *Query) visitFilters(op string, filters []query.Filter) (string, error) {
+	arr := make([]string, len(filters))
+	for i, fil := range filters {
+		var err error
+		switch f := fil.(type) {
+		case *query.EQ:
+			arr[i], err = q.VisitEQ(f)
+		case *query.NEQ:
+			arr[i], err = q.VisitNEQ(f)
+		case *query.GT:
+			arr[i], err = q.VisitGT(f)
+		case *query.GTE:
+			arr[i], err = q.VisitGTE(f)
+		case *query.LT:
+			arr[i], err = q.VisitLT(f)
+		case *query.LTE:
+			arr[i], err = q.VisitLTE(f)
+		case *query.IN:
+			arr[i], err = q.VisitIN(f)
+		case *query.OR:
+			arr[i], err = q.VisitOR(f)
+		case *query.AND:
+			arr[i], err = q.VisitAND(f)
+		default:
+			return "", fmt.Errorf("unsupported filter type %#v", f)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "(" + strings.Join(arr, " "+op+" ") + ")", nil
+}
+
+func (q *Query) VisitAND(f *query.AND) (string, error) {
+	return q.visitFilters("AND", f.Filters)
+}
+
+func (q *Query) VisitOR(f *query.OR) (string, error) {
+	return q.visitFilters("OR", f.Filters)
+}
+
+func (q *Query) Finalize(filters string, qq *query.Query) error {
+	q.query = "SELECT key, value, etag FROM " + q.tableName + " WHERE (expires_at IS NULL OR expires_at >= now())"
+
+	if filters != "" {
+		q.query += " AND " + filters
+	}
+
+	if len(qq.Sort) > 0 {
+		q.query += " ORDER BY "
+
+		for sortIndex, sortItem := range qq.Sort {
+			if sortIndex > 0 {
+				q.query += ", "
+			}
+			q.params = append(q.params, strings.Split(sortItem.Key, "."))
+			q.query += "value #> $" + strconv.Itoa(len(q.params))
+			switch strings.ToUpper(sortItem.Order) {
+			case "":
+			case query.ASC:
+				q.query += " " + query.ASC
+			case query.DESC:
+				q.query += " " + query.DESC
+			default:
+				return fmt.Errorf("invalid sort order %q for key %q", sortItem.Order, sortItem.Key)
+			}
+		}
+	}
+
+	if qq.Page.Limit > 0 {
+		q.query += " LIMIT " + strconv.Itoa(qq.Page.Limit)
+		q.limit = qq.Page.Limit
+	}
+
+	if len(qq.Page.Token) != 0 {
+		skip, err := strconv.ParseInt(qq.Page.Token, 10, 64)
+		if err != nil {
+			return err
+		}
+		q.query += " OFFSET " + strconv.FormatInt(skip, 10)
+		q.skip = &skip
+	}
+
+	return nil
+}
+
+func (q *Query) execute(ctx context.Context, db pginterfaces.DBQuerier) ([]state.QueryItem, string, error) {
+	rows, err := db.Query(ctx, q.query, q.params...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	ret := []state.QueryItem{}
+	for rows.Next() {
+		var (
+			key  string
+			data []byte
+			etag string
+		)
+		if err = rows.Scan(&key, &data, &etag); err != nil {
+			return nil, "", err
+		}
+		ret = append(ret, state.QueryItem{
+			Key:  key,
+			Data: data,
+			ETag: ptr.Of(etag),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var token string
+	if q.limit != 0 {
+		var skip int64
+		if q.skip != nil {
+			skip = *q.skip
+		}
+		token = strconv.FormatInt(skip+int64(len(ret)), 10)
+	}
+
+	return ret, token, nil
+}
+
+// whereJSONPath returns a predicate matching values where the field at key compares to any of the values.
+// The SQL/JSON path is passed as a parameter, for example `$."person"."org" ? (@ == "A" || @ == "B")`.
+func (q *Query) whereJSONPath(key string, op string, values ...any) (string, error) {
+	conditions := make([]string, len(values))
+	for i, v := range values {
+		literal, err := jsonPathLiteral(v)
+		if err != nil {
+			return "", fmt.Errorf("unsupported value for key %q: %w", key, err)
+		}
+		conditions[i] = "@ " + op + " " + literal
+	}
+
+	q.params = append(q.params, translateFieldToJSONPath(key)+" ? ("+strings.Join(conditions, " || ")+")")
+	return "value @? $" + strconv.Itoa(len(q.params)) + "::jsonpath", nil
+}
+
+func translateFieldToJSONPath(key string) string {
+	path := "$"
+	for _, part := range strings.Split(key, ".") {
+		// Keys are quoted so any character is allowed
+		quoted, _ := jsonPathLiteral(part)
+		path += "." + quoted
+	}
+	return path
+}
+
+// jsonPathLiteral encodes a scalar as a SQL/JSON path literal, whose syntax is the same as JSON's.
+func jsonPathLiteral(value any) (string, error) {
+	switch value.(type) {
+	case string, bool, nil,
+		float64, float32, int, int64, int32, uint, uint64, uint32, json.Number:
+	default:
+		return "", fmt.Errorf("type %T is not a scalar", value)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+)
+
+func TestPostgresqlQueryBuildQuery(t *testing.T) {
+	const selectState = "SELECT key, value, etag FROM state WHERE (expires_at IS NULL OR expires_at >= now())"
+
+	tests := []struct {
+		input  string
+		query  string
+		params []any
+	}{
+		{
+			input:  "../../../tests/state/query/q1.json",
+			query:  selectState + " LIMIT 2",
+			params: nil,
+		},
+		{
+			input:  "../../../tests/state/query/q2.json",
+			query:  selectState + " AND value @? $1::jsonpath LIMIT 2",
+			params: []any{`$."state" ? (@ == "CA")`},
+		},
+		{
+			input:  "../../../tests/state/query/q2-token.json",
+			query:  selectState + " AND value @? $1::jsonpath LIMIT 2 OFFSET 2",
+			params: []any{`$."state" ? (@ == "CA")`},
+		},
+		{
+			input: "../../../tests/state/query/q3.json",
+			query: selectState + " AND (value @? $1::jsonpath AND value @? $2::jsonpath) ORDER BY value #> $3 DESC, value #> $4",
+			params: []any{
+				`$."person"."org" ? (@ == "A")`,
+				`$."state" ? (@ == "CA" || @ == "WA")`,
+				[]string{"state"},
+				[]string{"person", "name"},
+			},
+		},
+		{
+			input: "../../../tests/state/query/q4-notequal.json",
+			query: selectState + " AND (value @? $1::jsonpath OR (value @? $2::jsonpath AND value @? $3::jsonpath)) ORDER BY value #> $4 DESC, value #> $5 LIMIT 2",
+			params: []any{
+				`$."person"."org" ? (@ == "A")`,
+				`$."person"."org" ? (@ != "B")`,
+				`$."state" ? (@ == "CA" || @ == "WA")`,
+				[]string{"state"},
+				[]string{"person", "name"},
+			},
+		},
+		{
+			input: "../../../tests/state/query/q8.json",
+			query: selectState + " AND (value @? $1::jsonpath OR (value @? $2::jsonpath AND value @? $3::jsonpath)) ORDER BY value #> $4 DESC, value #> $5 LIMIT 2",
+			params: []any{
+				`$."person"."org" ? (@ >= 123)`,
+				`$."person"."org" ? (@ < 10)`,
+				`$."state" ? (@ == "CA" || @ == "WA")`,
+				[]string{"state"},
+				[]string{"person", "name"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			data, err := os.ReadFile(test.input)
+			require.NoError(t, err)
+			var qq query.Query
+			err = json.Unmarshal(data, &qq)
+			require.NoError(t, err)
+
+			q := &Query{
+				tableName: "state",
+			}
+			qbuilder := query.NewQueryBuilder(q)
+			err = qbuilder.BuildQuery(&qq)
+			require.NoError(t, err)
+			assert.Equal(t, test.query, q.query)
+			assert.Equal(t, test.params, q.params)
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	t.Run("keys and strings are quoted", func(t *testing.T) {
+		q := &Query{}
+		_, err := q.whereJSONPath(`a"b.c`, "==", `x" || true`)
+		require.NoError(t, err)
+		assert.Equal(t, []any{`$."a\"b"."c" ? (@ == "x\" || true")`}, q.params)
+	})
+
+	t.Run("objects are not supported", func(t *testing.T) {
+		q := &Query{}
+		_, err := q.whereJSONPath("a", "==", map[string]any{"b": 1})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid sort order", func(t *testing.T) {
+		q := &Query{tableName: "state"}
+		err := q.Finalize("", &query.Query{QueryFields: query.QueryFields{Sort: []query.Sorting{{Key: "a", Order: "; DROP TABLE state"}}}})
+		require.Error(t, err)
+	})
+}
+
+func TestQueryRequiresJSONB(t *testing.T) {
+	p := &PostgreSQL{}
+	p.metadata.ValueType = valueTypeBytea
+	_, err := p.Query(t.Context(), &state.QueryRequest{})
+	require.ErrorContains(t, err, "valueType")
+	assert.NotContains(t, p.Features(), state.FeatureQueryAPI)
+
+	p.metadata.ValueType = valueTypeJSONB
+	assert.Contains(t, p.Features(), state.FeatureQueryAPI)
+}
+
+func TestJSONBIndexName(t *testing.T) {
+	p := &PostgreSQL{}
+	p.metadata.TablePrefix = "myschema.dapr_"
+	assert.Equal(t, "dapr_state_value_jsonb_path_ops_idx", p.jsonbIndexName(jsonbIndexPathOps, false))
+	assert.Equal(t, "myschema.dapr_state_value_jsonb_ops_idx", p.jsonbIndexName(jsonbIndexOps, true))
+}
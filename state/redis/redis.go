@@ -85,6 +85,11 @@ const (
 	connectedSlavesReplicas  = "connected_slaves:"
 	infoReplicationDelimiter = "\r\n"
 	ttlInSeconds             = "ttlInSeconds"
+	queryVectorKey           = "queryVectorKey"
+	queryVector              = "queryVector"
+	queryVectorTopK          = "queryVectorTopK"
+	jsonPath                 = "jsonPath"
+	defaultVectorTopK        = 10
 	defaultBase              = 10
 	defaultBitSize           = 0
 	defaultDB                = 0
@@ -101,6 +106,8 @@ type StateStore struct {
 	replicas                       int
 	querySchemas                   querySchemas
 	suppressActorStateStoreWarning atomic.Bool
+	cache                          *clientCache
+	stopCache                      context.CancelFunc
 
 	logger logger.Logger
 }
@@ -136,6 +143,10 @@ func (r *StateStore) Init(ctx context.Context, metadata state.Metadata) error {
 		return err
 	}
 
+	if err = validateKeyHashTag(r.clientSettings.KeyHashTag); err != nil {
+		return fmt.Errorf("redis store: %w", err)
+	}
+
 	// check for query schemas
 	if r.querySchemas, err = parseQuerySchemas(r.clientSettings.QueryIndexes); err != nil {
 		return fmt.Errorf("redis store: error parsing query index schema: %w", err)
@@ -154,10 +165,24 @@ func (r *StateStore) Init(ctx context.Context, metadata state.Metadata) error {
 	}
 
 	r.clientHasJSON = rediscomponent.ClientHasJSONSupport(r.client)
+	if r.clientSettings.UseJSON && !r.clientHasJSON {
+		return errors.New("redis store: redis-json server support is required when useJSON is enabled")
+	}
+
+	if r.clientSettings.ClientCache {
+		if err = r.startClientCache(); err != nil {
+			return fmt.Errorf("redis store: error starting client-side cache: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// isJSON returns true if the value of a request is stored as a RedisJSON document.
+func (r *StateStore) isJSON(md map[string]string) bool {
+	return r.clientHasJSON && (r.clientSettings.UseJSON || md[daprmetadata.ContentType] == contenttype.JSONContentType)
+}
+
 // Features returns the features available in this state store.
 func (r *StateStore) Features() []state.Feature {
 	if r.clientHasJSON {
@@ -206,10 +231,26 @@ func (r *StateStore) Delete(ctx context.Context, req *state.DeleteRequest) error
 	if !req.HasETag() {
 		req.ETag = ptr.Of("0")
 	}
+	storeReq := *req
+	storeReq.Key = r.storeKey(req.Key)
+	req = &storeReq
+	defer r.cache.invalidate(req.Key)
 
-	if req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON {
+	path := req.Metadata[jsonPath]
+	switch {
+	case path != "":
+		if !r.isJSON(req.Metadata) {
+			return errJSONPathNotJSON
+		}
+		var args []any
+		args, err = r.deleteJSONPathArgs(req, path)
+		if err != nil {
+			return err
+		}
+		err = r.client.DoWrite(ctx, args...)
+	case r.isJSON(req.Metadata):
 		err = r.client.DoWrite(ctx, "EVAL", delJSONQuery, 1, req.Key, *req.ETag)
-	} else {
+	default:
 		err = r.client.DoWrite(ctx, "EVAL", delDefaultQuery, 1, req.Key, *req.ETag)
 	}
 	if err != nil {
@@ -308,11 +349,37 @@ func (r *StateStore) getJSON(ctx context.Context, req *state.GetRequest) (*state
 
 // Get retrieves state from redis with a key.
 func (r *StateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
-	if req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON {
-		return r.getJSON(ctx, req)
+	storeReq := *req
+	storeReq.Key = r.storeKey(req.Key)
+	req = &storeReq
+
+	if path := req.Metadata[jsonPath]; path != "" {
+		if !r.isJSON(req.Metadata) {
+			return nil, errJSONPathNotJSON
+		}
+		return r.getJSONPath(ctx, req, path)
+	}
+	isJSON := r.isJSON(req.Metadata)
+	if res, ok := r.cache.get(req.Key, isJSON); ok {
+		return res, nil
 	}
 
-	return r.getDefault(ctx, req)
+	token := r.cache.reserve(req.Key)
+	var (
+		res *state.GetResponse
+		err error
+	)
+	if isJSON {
+		res, err = r.getJSON(ctx, req)
+	} else {
+		res, err = r.getDefault(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.cache.store(req.Key, token, isJSON, res)
+
+	return res, nil
 }
 
 type jsonEntry struct {
@@ -343,11 +410,27 @@ func (r *StateStore) Set(ctx context.Context, req *state.SetRequest) error {
 	if req.Options.Concurrency == state.FirstWrite {
 		firstWrite = 0
 	}
+	storeReq := *req
+	storeReq.Key = r.storeKey(req.Key)
+	req = &storeReq
+	defer r.cache.invalidate(req.Key)
 
-	if req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON {
+	path := req.Metadata[jsonPath]
+	switch {
+	case path != "":
+		if !r.isJSON(req.Metadata) {
+			return errJSONPathNotJSON
+		}
+		var args []any
+		args, err = r.setJSONPathArgs(req, path, ver)
+		if err != nil {
+			return err
+		}
+		err = r.client.DoWrite(ctx, args...)
+	case r.isJSON(req.Metadata):
 		bt, _ := utils.Marshal(&jsonEntry{Data: req.Value}, r.json.Marshal)
 		err = r.client.DoWrite(ctx, "EVAL", setJSONQuery, 1, req.Key, ver, bt, firstWrite)
-	} else {
+	default:
 		bt, _ := utils.Marshal(req.Value, r.json.Marshal)
 		err = r.client.DoWrite(ctx, "EVAL", setDefaultQuery, 1, req.Key, ver, bt, firstWrite)
 	}
@@ -391,12 +474,18 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 	}
 
 	// Check if the entire transaction is using JSON based on the transactional request's metadata
-	isJSON := request.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON
+	isJSON := r.isJSON(request.Metadata)
 
+	keys := make([]string, 0, len(request.Operations))
+	defer func() {
+		r.cache.invalidate(keys...)
+	}()
 	pipe := r.client.TxPipeline()
 	for _, o := range request.Operations {
 		switch req := o.(type) {
 		case state.SetRequest:
+			req.Key = r.storeKey(req.Key)
+			keys = append(keys, req.Key)
 			ver, err := r.parseETag(&req)
 			if err != nil {
 				return err
@@ -412,7 +501,16 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 			var bt []byte
 			isReqJSON := isJSON ||
 				(len(req.Metadata) > 0 && req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType)
-			if isReqJSON {
+			if path := req.Metadata[jsonPath]; path != "" {
+				if !isReqJSON || !r.clientHasJSON {
+					return errJSONPathNotJSON
+				}
+				args, err := r.setJSONPathArgs(&req, path, ver)
+				if err != nil {
+					return err
+				}
+				pipe.Do(ctx, args...)
+			} else if isReqJSON {
 				bt, _ = utils.Marshal(&jsonEntry{Data: req.Value}, r.json.Marshal)
 				pipe.Do(ctx, "EVAL", setJSONQuery, 1, req.Key, ver, bt)
 			} else {
@@ -427,12 +525,23 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 			}
 
 		case state.DeleteRequest:
+			req.Key = r.storeKey(req.Key)
+			keys = append(keys, req.Key)
 			if !req.HasETag() {
 				req.ETag = ptr.Of("0")
 			}
 			isReqJSON := isJSON ||
 				(len(req.Metadata) > 0 && req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType)
-			if isReqJSON {
+			if path := req.Metadata[jsonPath]; path != "" {
+				if !isReqJSON || !r.clientHasJSON {
+					return errJSONPathNotJSON
+				}
+				args, err := r.deleteJSONPathArgs(&req, path)
+				if err != nil {
+					return err
+				}
+				pipe.Do(ctx, args...)
+			} else if isReqJSON {
 				pipe.Do(ctx, "EVAL", delJSONQuery, 1, req.Key, *req.ETag)
 			} else {
 				pipe.Do(ctx, "EVAL", delDefaultQuery, 1, req.Key, *req.ETag)
@@ -440,6 +549,11 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 		}
 	}
 
+	// Redis Cluster executes transactions only on keys of the same hash slot
+	if err := r.validateSlots(keys); err != nil {
+		return err
+	}
+
 	err := pipe.Exec(ctx)
 
 	return err
@@ -528,6 +642,9 @@ func (r *StateStore) Query(ctx context.Context, req *state.QueryRequest) (*state
 	}
 
 	q := NewQuery(indexName, elem.keys)
+	if err := r.parseVectorQuery(q, elem, req.Metadata); err != nil {
+		return &state.QueryResponse{}, err
+	}
 	qbuilder := query.NewQueryBuilder(q)
 	if err := qbuilder.BuildQuery(&req.Query); err != nil {
 		return &state.QueryResponse{}, err
@@ -536,6 +653,9 @@ func (r *StateStore) Query(ctx context.Context, req *state.QueryRequest) (*state
 	if err != nil {
 		return &state.QueryResponse{}, err
 	}
+	for i := range data {
+		data[i].Key = r.stateKey(data[i].Key)
+	}
 
 	return &state.QueryResponse{
 		Results: data,
@@ -543,7 +663,38 @@ func (r *StateStore) Query(ctx context.Context, req *state.QueryRequest) (*state
 	}, nil
 }
 
+// parseVectorQuery adds a KNN vector similarity search to the query if requested in the metadata.
+func (r *StateStore) parseVectorQuery(q *Query, elem *querySchemaElem, md map[string]string) error {
+	key := md[queryVectorKey]
+	if key == "" {
+		return nil
+	}
+	field, ok := elem.vectors[key]
+	if !ok {
+		return fmt.Errorf("JSON path %q does not have a vector index", key)
+	}
+
+	var vector []float64
+	if err := r.json.UnmarshalFromString(md[queryVector], &vector); err != nil {
+		return fmt.Errorf("invalid value for metadata property %s: %w", queryVector, err)
+	}
+
+	topK := defaultVectorTopK
+	if val := md[queryVectorTopK]; val != "" {
+		var err error
+		topK, err = strconv.Atoi(val)
+		if err != nil || topK <= 0 {
+			return fmt.Errorf("invalid value for metadata property %s: must be a positive integer", queryVectorTopK)
+		}
+	}
+
+	return q.WithKNN(field, topK, vector)
+}
+
 func (r *StateStore) Close() error {
+	if r.stopCache != nil {
+		r.stopCache()
+	}
 	return r.client.Close()
 }
 
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	defaultClientCacheTTL       = 5 * time.Minute
+	defaultClientCacheMaxMemory = 64 << 20 // 64 MB
+
+	// Approximate memory of a cache entry, in addition to its key and value.
+	cacheEntryOverhead = 128
+)
+
+// clientCache caches the values read from Redis, until they expire or are invalidated by the server when their keys
+// are modified. The least recently used values are evicted when the cache exceeds its maximum memory.
+// The methods of a nil cache do nothing.
+type clientCache struct {
+	ttl       time.Duration
+	maxMemory int64
+
+	lock    sync.Mutex
+	memory  int64
+	entries map[string]*list.Element
+	// Least recently used entries are at the back.
+	lru *list.List
+	// Reservations of the keys being read, which are cached only if they're not invalidated while they're read.
+	pending   map[string]uint64
+	nextToken uint64
+}
+
+type cacheEntry struct {
+	key     string
+	isJSON  bool
+	res     state.GetResponse
+	size    int64
+	expires time.Time
+}
+
+func newClientCache(ttl time.Duration, maxMemory int64) *clientCache {
+	return &clientCache{
+		ttl:       ttl,
+		maxMemory: maxMemory,
+		entries:   map[string]*list.Element{},
+		lru:       list.New(),
+		pending:   map[string]uint64{},
+	}
+}
+
+// get returns a copy of the cached value of a key, read as a JSON document or not.
+func (c *clientCache) get(key string, isJSON bool) (*state.GetResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if entry.isJSON != isJSON || time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+
+	return copyGetResponse(&entry.res), true
+}
+
+// reserve returns the token with which the value of a key is stored once it's read.
+func (c *clientCache) reserve(key string) uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.nextToken++
+	c.pending[key] = c.nextToken
+	return c.nextToken
+}
+
+// store caches the value of a key, unless the key was invalidated since it was reserved.
+func (c *clientCache) store(key string, token uint64, isJSON bool, res *state.GetResponse) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.pending[key] != token {
+		return
+	}
+	delete(c.pending, key)
+
+	entry := &cacheEntry{
+		key:     key,
+		isJSON:  isJSON,
+		res:     *copyGetResponse(res),
+		expires: time.Now().Add(c.ttl),
+	}
+	entry.size = int64(len(key)+len(res.Data)) + cacheEntryOverhead
+	if entry.size > c.maxMemory {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.memory += entry.size
+	for c.memory > c.maxMemory {
+		c.remove(c.lru.Back())
+	}
+}
+
+// invalidate removes keys from the cache, and cancels their reservations.
+func (c *clientCache) invalidate(keys ...string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, key := range keys {
+		delete(c.pending, key)
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+	}
+}
+
+// flush removes all keys from the cache, and cancels all reservations.
+func (c *clientCache) flush() {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	clear(c.pending)
+	clear(c.entries)
+	c.lru.Init()
+	c.memory = 0
+}
+
+// handleInvalidation handles the invalidations of keys received from the server: all keys are invalidated when nil.
+func (c *clientCache) handleInvalidation(keys []string) {
+	if keys == nil {
+		c.flush()
+		return
+	}
+	c.invalidate(keys...)
+}
+
+// remove removes an entry: the lock must be held.
+func (c *clientCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.memory -= entry.size
+}
+
+func copyGetResponse(res *state.GetResponse) *state.GetResponse {
+	cp := &state.GetResponse{
+		Data:     bytes.Clone(res.Data),
+		Metadata: maps.Clone(res.Metadata),
+	}
+	if res.ETag != nil {
+		cp.ETag = ptr.Of(*res.ETag)
+	}
+	if res.ContentType != nil {
+		cp.ContentType = ptr.Of(*res.ContentType)
+	}
+	return cp
+}
+
+// startClientCache starts the client-side cache, and the subscription to the invalidations of the keys modified on the
+// server.
+func (r *StateStore) startClientCache() error {
+	ttl := time.Duration(r.clientSettings.ClientCacheTTL)
+	if ttl <= 0 {
+		ttl = defaultClientCacheTTL
+	}
+	maxMemory := r.clientSettings.ClientCacheMaxMemory
+	if maxMemory <= 0 {
+		maxMemory = defaultClientCacheMaxMemory
+	}
+	cache := newClientCache(ttl, maxMemory)
+
+	// The subscription runs until the store is closed
+	cacheCtx, cancel := context.WithCancel(context.Background())
+	err := r.client.InvalidationSubscribe(cacheCtx, &rediscomponent.InvalidationSubscribeArgs{
+		OnConnect: cache.flush,
+		Handler:   cache.handleInvalidation,
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	r.cache = cache
+	r.stopCache = cancel
+	r.logger.Infof("Client-side cache enabled, with a TTL of %v and a maximum memory of %d bytes", ttl, maxMemory)
+	return nil
+}
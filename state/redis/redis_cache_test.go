@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestClientCache(t *testing.T) {
+	res := &state.GetResponse{Data: []byte(`"deathstar"`), ETag: ptr.Of("1")}
+
+	t.Run("store and get", func(t *testing.T) {
+		c := newClientCache(time.Minute, 1<<20)
+		c.store("weapon", c.reserve("weapon"), false, res)
+
+		cached, ok := c.get("weapon", false)
+		require.True(t, ok)
+		assert.Equal(t, res, cached)
+
+		// The cached value is a copy
+		cached.Data[0] = 'x'
+		cached, _ = c.get("weapon", false)
+		assert.Equal(t, `"deathstar"`, string(cached.Data))
+
+		// Values read as JSON documents are cached separately
+		_, ok = c.get("weapon", true)
+		assert.False(t, ok)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		c := newClientCache(time.Millisecond, 1<<20)
+		c.store("weapon", c.reserve("weapon"), false, res)
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := c.get("weapon", false)
+		assert.False(t, ok)
+		assert.Zero(t, c.memory)
+	})
+
+	t.Run("invalidated while read", func(t *testing.T) {
+		c := newClientCache(time.Minute, 1<<20)
+		token := c.reserve("weapon")
+		c.invalidate("weapon")
+		c.store("weapon", token, false, res)
+
+		_, ok := c.get("weapon", false)
+		assert.False(t, ok)
+
+		token = c.reserve("weapon")
+		c.handleInvalidation(nil)
+		c.store("weapon", token, false, res)
+
+		_, ok = c.get("weapon", false)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		c := newClientCache(time.Minute, 1<<20)
+		c.store("weapon", c.reserve("weapon"), false, res)
+		c.store("planet", c.reserve("planet"), false, res)
+
+		c.handleInvalidation([]string{"weapon"})
+		_, ok := c.get("weapon", false)
+		assert.False(t, ok)
+		_, ok = c.get("planet", false)
+		assert.True(t, ok)
+
+		c.handleInvalidation(nil)
+		_, ok = c.get("planet", false)
+		assert.False(t, ok)
+		assert.Zero(t, c.memory)
+	})
+
+	t.Run("eviction of least recently used values", func(t *testing.T) {
+		value := &state.GetResponse{Data: []byte(strings.Repeat("x", 100))}
+		entrySize := int64(len("key1")+100) + cacheEntryOverhead
+		c := newClientCache(time.Minute, 3*entrySize)
+
+		c.store("key1", c.reserve("key1"), false, value)
+		c.store("key2", c.reserve("key2"), false, value)
+		c.store("key3", c.reserve("key3"), false, value)
+		_, ok := c.get("key1", false)
+		require.True(t, ok)
+		c.store("key4", c.reserve("key4"), false, value)
+
+		_, ok = c.get("key2", false)
+		assert.False(t, ok)
+		for _, key := range []string{"key1", "key3", "key4"} {
+			_, ok = c.get(key, false)
+			assert.True(t, ok, key)
+		}
+		assert.Equal(t, 3*entrySize, c.memory)
+
+		// Values larger than the cache are not cached
+		c.store("large", c.reserve("large"), false, &state.GetResponse{Data: make([]byte, 4*entrySize)})
+		_, ok = c.get("large", false)
+		assert.False(t, ok)
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *clientCache
+		c.store("weapon", c.reserve("weapon"), false, res)
+		c.invalidate("weapon")
+		c.flush()
+		_, ok := c.get("weapon", false)
+		assert.False(t, ok)
+	})
+}
+
+func TestGetWithClientCache(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	ss := &StateStore{
+		client:         c,
+		clientSettings: &rediscomponent.Settings{},
+		json:           jsoniter.ConfigFastest,
+		logger:         logger.NewLogger("test"),
+		cache:          newClientCache(time.Minute, 1<<20),
+	}
+
+	err := ss.Set(t.Context(), &state.SetRequest{Key: "weapon", Value: "deathstar"})
+	require.NoError(t, err)
+
+	res, err := ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"deathstar"`, string(res.Data))
+
+	// Values are read from the cache until they're invalidated
+	s.HSet("weapon", "data", `"tiefighter"`)
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"deathstar"`, string(res.Data))
+
+	ss.cache.handleInvalidation([]string{"weapon"})
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"tiefighter"`, string(res.Data))
+
+	// Writes of the store invalidate the values
+	err = ss.Set(t.Context(), &state.SetRequest{Key: "weapon", Value: "xwing"})
+	require.NoError(t, err)
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"xwing"`, string(res.Data))
+	assert.Equal(t, ptr.Of("2"), res.ETag)
+
+	err = ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "weapon", Value: "awing"},
+		},
+	})
+	require.NoError(t, err)
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"awing"`, string(res.Data))
+
+	err = ss.Delete(t.Context(), &state.DeleteRequest{Key: "weapon"})
+	require.NoError(t, err)
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+	require.NoError(t, err)
+	assert.Nil(t, res.Data)
+}
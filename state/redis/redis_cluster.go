@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	// Hash tag strategies of the keys, co-locating the keys of transactions in the same hash slot on Redis Cluster.
+	// Keys are left as they are.
+	keyHashTagNone = "none"
+	// The first segment of the keys, which is the prefix of the app, is the hash tag: "app||key" is stored as
+	// "{app}||key".
+	keyHashTagPrefix = "prefix"
+	// All segments of the keys but the last one are the hash tag, co-locating the keys of each actor:
+	// "app||type||id||key" is stored as "{app||type||id}||key".
+	keyHashTagParent = "parent"
+
+	clusterSlots = 16384
+)
+
+// CrossSlotError is returned by transactions on Redis Cluster whose keys are not in the same hash slot, which can't be
+// executed atomically.
+type CrossSlotError struct {
+	keys  [2]string
+	slots [2]int
+}
+
+// Error returns the error message. It implements the error interface.
+func (e *CrossSlotError) Error() string {
+	return fmt.Sprintf("keys %q (slot %d) and %q (slot %d) of the transaction are not in the same hash slot: set keyHashTag to co-locate the keys of transactions",
+		e.keys[0], e.slots[0], e.keys[1], e.slots[1])
+}
+
+// Keys returns two keys of the transaction that are not in the same hash slot.
+func (e *CrossSlotError) Keys() []string {
+	return e.keys[:]
+}
+
+func validateKeyHashTag(strategy string) error {
+	switch strategy {
+	case "", keyHashTagNone, keyHashTagPrefix, keyHashTagParent:
+		return nil
+	default:
+		return fmt.Errorf("invalid keyHashTag %q: use %s, %s or %s", strategy, keyHashTagNone, keyHashTagPrefix, keyHashTagParent)
+	}
+}
+
+// storeKey returns the key of the state in Redis, with the hash tag of the strategy of the store.
+// Keys without separator are left as they are.
+func (r *StateStore) storeKey(key string) string {
+	var i int
+	switch r.clientSettings.KeyHashTag {
+	case keyHashTagPrefix:
+		i = strings.Index(key, state.KeyPrefixDelimiter)
+	case keyHashTagParent:
+		i = strings.LastIndex(key, state.KeyPrefixDelimiter)
+	default:
+		return key
+	}
+	if i <= 0 {
+		return key
+	}
+	return "{" + key[:i] + "}" + key[i:]
+}
+
+// stateKey returns the key of the state from its key in Redis, removing the hash tag added by storeKey.
+func (r *StateStore) stateKey(key string) string {
+	var i int
+	switch r.clientSettings.KeyHashTag {
+	case keyHashTagPrefix:
+		i = strings.Index(key, state.KeyPrefixDelimiter)
+	case keyHashTagParent:
+		i = strings.LastIndex(key, state.KeyPrefixDelimiter)
+	default:
+		return key
+	}
+	if i <= 1 || key[0] != '{' || key[i-1] != '}' {
+		return key
+	}
+	return key[1:i-1] + key[i:]
+}
+
+// validateSlots returns a CrossSlotError if the keys are not in the same hash slot on Redis Cluster.
+func (r *StateStore) validateSlots(keys []string) error {
+	if r.clientSettings.RedisType != rediscomponent.ClusterType || len(keys) < 2 {
+		return nil
+	}
+	slot := keySlot(keys[0])
+	for _, key := range keys[1:] {
+		if s := keySlot(key); s != slot {
+			return &CrossSlotError{
+				keys:  [2]string{keys[0], key},
+				slots: [2]int{slot, s},
+			}
+		}
+	}
+	return nil
+}
+
+// keySlot returns the hash slot of a key on Redis Cluster: the CRC16 of its hash tag, the part between the first "{"
+// and the next "}" if it's not empty, or of the whole key.
+// See: https://redis.io/docs/latest/operate/oss_and_stack/reference/cluster-spec/#hash-tags
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % clusterSlots)
+}
+
+// crc16 returns the CRC16-CCITT (XMODEM) checksum of a string, as computed by Redis Cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := range len(s) {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
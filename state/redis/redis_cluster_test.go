@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/logger"
+)
+
+func TestKeySlot(t *testing.T) {
+	assert.Equal(t, uint16(0x31C3), crc16("123456789"))
+
+	// Values returned by CLUSTER KEYSLOT
+	assert.Equal(t, 12182, keySlot("foo"))
+	assert.Equal(t, 5061, keySlot("bar"))
+	assert.Equal(t, 866, keySlot("hello"))
+
+	// Hash tags
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.following"))
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.followers"))
+	assert.Equal(t, keySlot("bar"), keySlot("foo{bar}{zap}"))
+	// Empty hash tags are ignored: the whole key is hashed
+	assert.Equal(t, int(crc16("foo{}{bar}")%clusterSlots), keySlot("foo{}{bar}"))
+	assert.NotEqual(t, keySlot("bar"), keySlot("foo{}{bar}"))
+}
+
+func TestStoreKey(t *testing.T) {
+	tests := []struct {
+		strategy string
+		key      string
+		storeKey string
+	}{
+		{"", "app||key", "app||key"},
+		{keyHashTagNone, "app||key", "app||key"},
+		{keyHashTagPrefix, "app||key", "{app}||key"},
+		{keyHashTagPrefix, "app||type||id||key", "{app}||type||id||key"},
+		{keyHashTagPrefix, "key", "key"},
+		{keyHashTagPrefix, "||key", "||key"},
+		{keyHashTagParent, "app||key", "{app}||key"},
+		{keyHashTagParent, "app||type||id||key", "{app||type||id}||key"},
+		{keyHashTagParent, "{app}||key", "{{app}}||key"},
+		{keyHashTagParent, "key", "key"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.strategy+"/"+tc.key, func(t *testing.T) {
+			ss := &StateStore{clientSettings: &rediscomponent.Settings{KeyHashTag: tc.strategy}}
+			assert.Equal(t, tc.storeKey, ss.storeKey(tc.key))
+			assert.Equal(t, tc.key, ss.stateKey(tc.storeKey))
+		})
+	}
+
+	require.NoError(t, validateKeyHashTag(keyHashTagParent))
+	require.Error(t, validateKeyHashTag("actor"))
+}
+
+func TestValidateSlots(t *testing.T) {
+	ss := &StateStore{clientSettings: &rediscomponent.Settings{
+		RedisType:  rediscomponent.ClusterType,
+		KeyHashTag: keyHashTagParent,
+	}}
+
+	require.NoError(t, ss.validateSlots([]string{ss.storeKey("app||a"), ss.storeKey("app||b")}))
+	require.NoError(t, ss.validateSlots([]string{ss.storeKey("app||type||1||a"), ss.storeKey("app||type||1||b")}))
+
+	err := ss.validateSlots([]string{"foo", "bar"})
+	var crossSlotErr *CrossSlotError
+	require.ErrorAs(t, err, &crossSlotErr)
+	assert.Equal(t, []string{"foo", "bar"}, crossSlotErr.Keys())
+
+	// Keys are in a single slot on standalone servers
+	ss.clientSettings.RedisType = ""
+	require.NoError(t, ss.validateSlots([]string{"foo", "bar"}))
+}
+
+func TestTransactionalHashTags(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	ss := &StateStore{
+		client: c,
+		clientSettings: &rediscomponent.Settings{
+			RedisType:  rediscomponent.ClusterType,
+			KeyHashTag: keyHashTagPrefix,
+		},
+		json:   jsoniter.ConfigFastest,
+		logger: logger.NewLogger("test"),
+	}
+
+	err := ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "app||weapon", Value: "deathstar"},
+			state.SetRequest{Key: "app||planet", Value: "alderaan"},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, s.Exists("{app}||weapon"))
+	assert.True(t, s.Exists("{app}||planet"))
+
+	res, err := ss.Get(t.Context(), &state.GetRequest{Key: "app||weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"deathstar"`, string(res.Data))
+
+	err = ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "app||weapon", Value: "tiefighter"},
+			state.DeleteRequest{Key: "other||planet"},
+		},
+	})
+	var crossSlotErr *CrossSlotError
+	require.ErrorAs(t, err, &crossSlotErr)
+
+	// The transaction was not executed
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "app||weapon"})
+	require.NoError(t, err)
+	assert.Equal(t, `"deathstar"`, string(res.Data))
+
+	err = ss.Delete(t.Context(), &state.DeleteRequest{Key: "app||planet"})
+	require.NoError(t, err)
+	assert.False(t, s.Exists("{app}||planet"))
+}
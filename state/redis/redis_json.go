@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/utils"
+)
+
+const (
+	// Sets the value at a path of an existing document, and increments its version.
+	setJSONPathQuery = `
+	local etag = redis.pcall("JSON.GET", KEYS[1], ".version");
+	if not etag or type(etag) == "table" then
+	  return error("failed to set key " .. KEYS[1] .. ": key not found")
+	end;
+	if ARGV[1] ~= "0" and etag ~= ARGV[1] then
+	  return error("failed to set key " .. KEYS[1])
+	end;
+	if not redis.call("JSON.SET", KEYS[1], ARGV[2], ARGV[3]) then
+	  return error("failed to set key " .. KEYS[1] .. ": path " .. ARGV[2] .. " not found")
+	end;
+	return redis.call("JSON.NUMINCRBY", KEYS[1], ".version", 1)`
+	// Deletes the values at a path of a document, and increments its version if any was deleted.
+	delJSONPathQuery = `
+	local etag = redis.pcall("JSON.GET", KEYS[1], ".version");
+	if not etag or type(etag) == "table" then
+	  return 0
+	end;
+	if ARGV[1] ~= "0" and etag ~= ARGV[1] then
+	  return error("failed to delete " .. KEYS[1])
+	end;
+	local deleted = redis.call("JSON.DEL", KEYS[1], ARGV[2]);
+	if deleted > 0 then
+	  redis.call("JSON.NUMINCRBY", KEYS[1], ".version", 1)
+	end;
+	return deleted`
+
+	// JSONPath of the version of the documents.
+	versionJSONPath = "$.version"
+)
+
+var (
+	errJSONPathNotJSON = errors.New("metadata property " + jsonPath + " requires values stored as RedisJSON documents: set the content type to application/json or enable useJSON")
+	errInvalidJSONPath = errors.New("invalid value for metadata property " + jsonPath + ": must be a JSONPath starting with $")
+)
+
+// dataJSONPath returns the path in the document of a JSONPath relative to the value, which is stored in the data field
+// of the document.
+func dataJSONPath(path string) (string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return "", errInvalidJSONPath
+	}
+	return "$.data" + path[1:], nil
+}
+
+// getJSONPath returns the JSON array of the values matching a path of the value, and the version of the document.
+func (r *StateStore) getJSONPath(ctx context.Context, req *state.GetRequest, path string) (*state.GetResponse, error) {
+	docPath, err := dataJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.DoRead(ctx, "JSON.GET", req.Key, docPath, versionJSONPath)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &state.GetResponse{}, nil
+	}
+	str, ok := res.(string)
+	if !ok {
+		return nil, errors.New("invalid result")
+	}
+
+	return r.parseJSONPathResult(str, docPath)
+}
+
+// parseJSONPathResult parses the result of a JSON.GET command with the path in the document and the path of the
+// version, which is an object with the values matching each path.
+func (r *StateStore) parseJSONPathResult(str string, docPath string) (*state.GetResponse, error) {
+	var result map[string]jsoniter.RawMessage
+	if err := r.json.UnmarshalFromString(str, &result); err != nil {
+		return nil, err
+	}
+
+	data, ok := result[docPath]
+	if !ok {
+		return nil, errors.New("invalid result")
+	}
+
+	var versions []int
+	if err := r.json.Unmarshal(result[versionJSONPath], &versions); err != nil {
+		return nil, err
+	}
+	var version *string
+	if len(versions) > 0 {
+		version = new(string)
+		*version = strconv.Itoa(versions[0])
+	}
+
+	return &state.GetResponse{
+		Data: data,
+		ETag: version,
+	}, nil
+}
+
+// setJSONPathArgs returns the command setting the value at a path of the value of an existing document.
+func (r *StateStore) setJSONPathArgs(req *state.SetRequest, path string, ver int) ([]any, error) {
+	docPath, err := dataJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	bt, err := utils.Marshal(req.Value, r.json.Marshal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value of key %s: %w", req.Key, err)
+	}
+
+	return []any{"EVAL", setJSONPathQuery, 1, req.Key, ver, docPath, bt}, nil
+}
+
+// deleteJSONPathArgs returns the command deleting the values matching a path of the value of a document.
+func (r *StateStore) deleteJSONPathArgs(req *state.DeleteRequest, path string) ([]any, error) {
+	docPath, err := dataJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if docPath == "$.data" {
+		return nil, fmt.Errorf("invalid value for metadata property %s: delete the key to delete the whole value", jsonPath)
+	}
+
+	return []any{"EVAL", delJSONPathQuery, 1, req.Key, *req.ETag, docPath}, nil
+}
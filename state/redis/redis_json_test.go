@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestDataJSONPath(t *testing.T) {
+	tests := map[string]string{
+		"$":                "$.data",
+		"$.address.city":   "$.data.address.city",
+		"$['address']":     "$.data['address']",
+		"$..city":          "$.data..city",
+		"$.items[0].price": "$.data.items[0].price",
+	}
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			docPath, err := dataJSONPath(path)
+			require.NoError(t, err)
+			assert.Equal(t, expected, docPath)
+		})
+	}
+
+	_, err := dataJSONPath(".address.city")
+	require.ErrorIs(t, err, errInvalidJSONPath)
+}
+
+func TestParseJSONPathResult(t *testing.T) {
+	ss := newStateStore(logger.NewLogger("test"))
+
+	res, err := ss.parseJSONPathResult(`{"$.data.address.city":["Paris"],"$.version":[3]}`, "$.data.address.city")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["Paris"]`, string(res.Data))
+	assert.Equal(t, ptr.Of("3"), res.ETag)
+
+	res, err = ss.parseJSONPathResult(`{"$.data.missing":[],"$.version":[]}`, "$.data.missing")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(res.Data))
+	assert.Nil(t, res.ETag)
+
+	_, err = ss.parseJSONPathResult(`{"$.version":[3]}`, "$.data.missing")
+	require.Error(t, err)
+}
+
+func TestJSONPathArgs(t *testing.T) {
+	ss := newStateStore(logger.NewLogger("test"))
+
+	t.Run("set", func(t *testing.T) {
+		args, err := ss.setJSONPathArgs(&state.SetRequest{
+			Key:   "order",
+			Value: map[string]any{"city": "Paris"},
+		}, "$.address", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"EVAL", setJSONPathQuery, 1, "order", 2, "$.data.address", []byte(`{"city":"Paris"}`)}, args)
+
+		args, err = ss.setJSONPathArgs(&state.SetRequest{
+			Key:   "order",
+			Value: []byte(`"shipped"`),
+		}, "$.status", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`"shipped"`), args[6])
+
+		_, err = ss.setJSONPathArgs(&state.SetRequest{Key: "order"}, "status", 0)
+		require.ErrorIs(t, err, errInvalidJSONPath)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		args, err := ss.deleteJSONPathArgs(&state.DeleteRequest{
+			Key:  "order",
+			ETag: ptr.Of("4"),
+		}, "$.items[0]")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"EVAL", delJSONPathQuery, 1, "order", "4", "$.data.items[0]"}, args)
+
+		_, err = ss.deleteJSONPathArgs(&state.DeleteRequest{
+			Key:  "order",
+			ETag: ptr.Of("0"),
+		}, "$")
+		require.Error(t, err)
+	})
+}
+
+func TestJSONPathRequiresJSON(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	ss := &StateStore{
+		client:         c,
+		clientSettings: &rediscomponent.Settings{},
+		json:           jsoniter.ConfigFastest,
+		logger:         logger.NewLogger("test"),
+	}
+	md := map[string]string{
+		jsonPath:      "$.status",
+		"contentType": "application/json",
+	}
+
+	_, err := ss.Get(t.Context(), &state.GetRequest{Key: "order", Metadata: md})
+	require.ErrorIs(t, err, errJSONPathNotJSON)
+
+	err = ss.Set(t.Context(), &state.SetRequest{Key: "order", Value: "shipped", Metadata: md})
+	require.ErrorIs(t, err, errJSONPathNotJSON)
+
+	err = ss.Delete(t.Context(), &state.DeleteRequest{Key: "order", Metadata: md})
+	require.ErrorIs(t, err, errJSONPathNotJSON)
+
+	err = ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "order", Value: "shipped", Metadata: md},
+		},
+	})
+	require.ErrorIs(t, err, errJSONPathNotJSON)
+}
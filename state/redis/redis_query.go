@@ -15,8 +15,10 @@ package redis
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -25,7 +27,15 @@ import (
 	"github.com/dapr/components-contrib/state/query"
 )
 
-var ErrMultipleSortBy error = errors.New("multiple SORTBY steps are not allowed. Sort multiple fields in a single step")
+var (
+	ErrMultipleSortBy error = errors.New("multiple SORTBY steps are not allowed. Sort multiple fields in a single step")
+	ErrVectorSortBy   error = errors.New("SORTBY is not allowed in vector similarity queries, which are sorted by distance")
+)
+
+const (
+	vectorQueryParam = "vector"
+	vectorScoreAlias = "__vector_score"
+)
 
 type Query struct {
 	schemaName string
@@ -33,6 +43,14 @@ type Query struct {
 	query      []interface{}
 	limit      int
 	offset     int64
+	knn        *knnQuery
+}
+
+// knnQuery is a K-nearest neighbors query against a VECTOR index.
+type knnQuery struct {
+	alias  string
+	topK   int
+	vector []byte
 }
 
 func NewQuery(schemaName string, aliases map[string]string) *Query {
@@ -42,6 +60,35 @@ func NewQuery(schemaName string, aliases map[string]string) *Query {
 	}
 }
 
+// WithKNN turns the query into a vector similarity search, returning the topK documents closest to the vector.
+func (q *Query) WithKNN(field vectorField, topK int, vector []float64) error {
+	if len(vector) != field.dimension {
+		return fmt.Errorf("vector has %d dimensions, but the index requires %d", len(vector), field.dimension)
+	}
+
+	// Vectors are passed to RediSearch as a binary blob of little-endian floats
+	var blob []byte
+	switch field.dataType {
+	case vectorDataTypeFloat64:
+		blob = make([]byte, 8*len(vector))
+		for i, v := range vector {
+			binary.LittleEndian.PutUint64(blob[i*8:], math.Float64bits(v))
+		}
+	default:
+		blob = make([]byte, 4*len(vector))
+		for i, v := range vector {
+			binary.LittleEndian.PutUint32(blob[i*4:], math.Float32bits(float32(v)))
+		}
+	}
+
+	q.knn = &knnQuery{
+		alias:  field.alias,
+		topK:   topK,
+		vector: blob,
+	}
+	return nil
+}
+
 func (q *Query) getAlias(jsonPath string) (string, error) {
 	alias, ok := q.aliases[jsonPath]
 	if !ok {
@@ -267,6 +314,20 @@ func (q *Query) Finalize(filters string, qq *query.Query) error {
 	}
 	q.query = []interface{}{filters}
 
+	// vector similarity search, which pre-filters documents and then sorts them by distance
+	if q.knn != nil {
+		if len(qq.Sort) > 0 {
+			return ErrVectorSortBy
+		}
+		if filters != "*" {
+			filters = "(" + filters + ")"
+		}
+		q.query = []interface{}{
+			fmt.Sprintf("%s=>[KNN %d @%s $%s AS %s]", filters, q.knn.topK, q.knn.alias, vectorQueryParam, vectorScoreAlias),
+			"SORTBY", vectorScoreAlias,
+		}
+	}
+
 	// sorting
 	if len(qq.Sort) > 0 {
 		if len(qq.Sort) != 1 {
@@ -297,6 +358,10 @@ func (q *Query) Finalize(filters string, qq *query.Query) error {
 			q.query = append(q.query, "LIMIT", "0", strconv.Itoa(q.limit))
 		}
 	}
+	// query parameters are supported by dialect 2 and later
+	if q.knn != nil {
+		q.query = append(q.query, "PARAMS", "2", vectorQueryParam, q.knn.vector, "DIALECT", "2")
+	}
 
 	return nil
 }
@@ -17,11 +17,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	indexTypeVector = "VECTOR"
+
+	vectorAlgorithmFlat = "FLAT"
+	vectorAlgorithmHNSW = "HNSW"
+
+	vectorDataTypeFloat32 = "FLOAT32"
+	vectorDataTypeFloat64 = "FLOAT64"
+
+	vectorDistanceL2     = "L2"
+	vectorDistanceIP     = "IP"
+	vectorDistanceCosine = "COSINE"
 )
 
 type index struct {
 	Key  string `json:"key"`
 	Type string `json:"type"`
+
+	// Options for indexes of type VECTOR.
+	Algorithm      string `json:"algorithm,omitempty"`
+	Dimension      int    `json:"dimension,omitempty"`
+	DataType       string `json:"dataType,omitempty"`
+	DistanceMetric string `json:"distanceMetric,omitempty"`
+}
+
+// vectorField contains the information needed to run KNN queries against a VECTOR index.
+type vectorField struct {
+	alias     string
+	dataType  string
+	dimension int
 }
 
 type querySchema struct {
@@ -30,8 +59,9 @@ type querySchema struct {
 }
 
 type querySchemaElem struct {
-	schema []interface{}
-	keys   map[string]string
+	schema  []interface{}
+	keys    map[string]string
+	vectors map[string]vectorField
 }
 
 type querySchemas map[string]*querySchemaElem
@@ -55,14 +85,26 @@ func parseQuerySchemas(content string) (querySchemas, error) {
 			return nil, fmt.Errorf("duplicate schema name %s", schema.Name)
 		}
 		elem := &querySchemaElem{
-			keys:   make(map[string]string),
-			schema: []interface{}{"FT.CREATE", schema.Name, "ON", "JSON", "SCHEMA"},
+			keys:    make(map[string]string),
+			vectors: make(map[string]vectorField),
+			schema:  []interface{}{"FT.CREATE", schema.Name, "ON", "JSON", "SCHEMA"},
 		}
 		for id, indx := range schema.Indexes {
 			if err := validateIndex(schema.Name, indx); err != nil {
 				return nil, err
 			}
 			alias := fmt.Sprintf("var%d", id)
+			if strings.ToUpper(indx.Type) == indexTypeVector {
+				field, err := parseVectorIndex(schema.Name, alias, &indx)
+				if err != nil {
+					return nil, err
+				}
+				elem.vectors[indx.Key] = field
+				// VECTOR <algorithm> <number of attributes> TYPE <type> DIM <dimension> DISTANCE_METRIC <metric>
+				elem.schema = append(elem.schema, "$.data."+indx.Key, "AS", alias, indexTypeVector, indx.Algorithm, "6",
+					"TYPE", indx.DataType, "DIM", strconv.Itoa(indx.Dimension), "DISTANCE_METRIC", indx.DistanceMetric)
+				continue
+			}
 			elem.keys[indx.Key] = alias
 			elem.schema = append(elem.schema, "$.data."+indx.Key, "AS", alias, indx.Type, "SORTABLE")
 		}
@@ -82,3 +124,46 @@ func validateIndex(name string, indx index) error {
 
 	return nil
 }
+
+// parseVectorIndex validates the options of a VECTOR index, applying defaults where needed.
+func parseVectorIndex(name string, alias string, indx *index) (vectorField, error) {
+	indx.Algorithm = strings.ToUpper(indx.Algorithm)
+	switch indx.Algorithm {
+	case "":
+		indx.Algorithm = vectorAlgorithmHNSW
+	case vectorAlgorithmFlat, vectorAlgorithmHNSW:
+		// Nop
+	default:
+		return vectorField{}, fmt.Errorf("invalid vector algorithm %q for key %s in query schema %s", indx.Algorithm, indx.Key, name)
+	}
+
+	indx.DataType = strings.ToUpper(indx.DataType)
+	switch indx.DataType {
+	case "":
+		indx.DataType = vectorDataTypeFloat32
+	case vectorDataTypeFloat32, vectorDataTypeFloat64:
+		// Nop
+	default:
+		return vectorField{}, fmt.Errorf("invalid vector data type %q for key %s in query schema %s", indx.DataType, indx.Key, name)
+	}
+
+	indx.DistanceMetric = strings.ToUpper(indx.DistanceMetric)
+	switch indx.DistanceMetric {
+	case "":
+		indx.DistanceMetric = vectorDistanceCosine
+	case vectorDistanceL2, vectorDistanceIP, vectorDistanceCosine:
+		// Nop
+	default:
+		return vectorField{}, fmt.Errorf("invalid vector distance metric %q for key %s in query schema %s", indx.DistanceMetric, indx.Key, name)
+	}
+
+	if indx.Dimension <= 0 {
+		return vectorField{}, fmt.Errorf("vector dimension for key %s in query schema %s must be greater than zero", indx.Key, name)
+	}
+
+	return vectorField{
+		alias:     alias,
+		dataType:  indx.DataType,
+		dimension: indx.Dimension,
+	}, nil
+}
@@ -107,6 +107,53 @@ func TestParsingMultiSchema(t *testing.T) {
 		}, schemas["schema2"].schema)
 }
 
+func TestParsingVectorSchema(t *testing.T) {
+	content := `
+[
+    {
+        "name": "schema1",
+        "indexes": [
+            {
+                "key": "category",
+                "type": "TEXT"
+            },
+            {
+                "key": "embedding",
+                "type": "VECTOR",
+                "dimension": 3
+            },
+            {
+                "key": "image",
+                "type": "vector",
+                "algorithm": "flat",
+                "dimension": 512,
+                "dataType": "FLOAT64",
+                "distanceMetric": "L2"
+            }
+        ]
+    }
+]`
+	schemas, err := parseQuerySchemas(content)
+	require.NoError(t, err)
+	assert.Len(t, schemas, 1)
+	assert.Equal(t,
+		map[string]string{"category": "var0"},
+		schemas["schema1"].keys)
+	assert.Equal(t,
+		map[string]vectorField{
+			"embedding": {alias: "var1", dataType: "FLOAT32", dimension: 3},
+			"image":     {alias: "var2", dataType: "FLOAT64", dimension: 512},
+		},
+		schemas["schema1"].vectors)
+	assert.Equal(t,
+		[]interface{}{
+			"FT.CREATE", "schema1", "ON", "JSON", "SCHEMA",
+			"$.data.category", "AS", "var0", "TEXT", "SORTABLE",
+			"$.data.embedding", "AS", "var1", "VECTOR", "HNSW", "6", "TYPE", "FLOAT32", "DIM", "3", "DISTANCE_METRIC", "COSINE",
+			"$.data.image", "AS", "var2", "VECTOR", "FLAT", "6", "TYPE", "FLOAT64", "DIM", "512", "DISTANCE_METRIC", "L2",
+		}, schemas["schema1"].schema)
+}
+
 func TestParsingSchemaErrors(t *testing.T) {
 	tests := []struct{ content, err string }{
 		{
@@ -137,6 +184,38 @@ func TestParsingSchemaErrors(t *testing.T) {
 			]`,
 			err: "empty type in query schema schema3",
 		},
+		{
+			content: `
+			[
+				{
+					"name": "schema4",
+					"indexes": [
+						{
+							"key": "embedding",
+							"type": "VECTOR"
+						}
+					]
+				}
+			]`,
+			err: "vector dimension for key embedding in query schema schema4 must be greater than zero",
+		},
+		{
+			content: `
+			[
+				{
+					"name": "schema5",
+					"indexes": [
+						{
+							"key": "embedding",
+							"type": "VECTOR",
+							"dimension": 3,
+							"algorithm": "IVF"
+						}
+					]
+				}
+			]`,
+			err: `invalid vector algorithm "IVF" for key embedding in query schema schema5`,
+		},
 	}
 
 	for _, test := range tests {
@@ -73,3 +73,54 @@ func TestRedisQuery(t *testing.T) {
 		}
 	}
 }
+
+func TestRedisVectorQuery(t *testing.T) {
+	field := vectorField{alias: "var1", dataType: "FLOAT32", dimension: 2}
+	blob := []byte{0x0, 0x0, 0x80, 0x3f, 0x0, 0x0, 0x0, 0xc0} // [1.0, -2.0]
+
+	t.Run("without filters", func(t *testing.T) {
+		q := NewQuery("schema1", map[string]string{"state": "var0"})
+		require.NoError(t, q.WithKNN(field, 5, []float64{1, -2}))
+
+		qq := query.Query{}
+		qq.Page.Limit = 3
+		require.NoError(t, query.NewQueryBuilder(q).BuildQuery(&qq))
+		assert.Equal(t, []interface{}{
+			"*=>[KNN 5 @var1 $vector AS __vector_score]", "SORTBY", "__vector_score",
+			"LIMIT", "0", "3",
+			"PARAMS", "2", "vector", blob, "DIALECT", "2",
+		}, q.query)
+	})
+
+	t.Run("with filters", func(t *testing.T) {
+		q := NewQuery("schema1", map[string]string{"state": "var0"})
+		require.NoError(t, q.WithKNN(field, 5, []float64{1, -2}))
+
+		qq := query.Query{Filter: &query.EQ{Key: "state", Val: "CA"}}
+		require.NoError(t, query.NewQueryBuilder(q).BuildQuery(&qq))
+		assert.Equal(t, []interface{}{
+			"(@var0:(CA))=>[KNN 5 @var1 $vector AS __vector_score]", "SORTBY", "__vector_score",
+			"PARAMS", "2", "vector", blob, "DIALECT", "2",
+		}, q.query)
+	})
+
+	t.Run("float64 vector", func(t *testing.T) {
+		q := NewQuery("schema1", nil)
+		require.NoError(t, q.WithKNN(vectorField{alias: "var0", dataType: "FLOAT64", dimension: 1}, 1, []float64{1}))
+		assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}, q.knn.vector)
+	})
+
+	t.Run("wrong dimension", func(t *testing.T) {
+		q := NewQuery("schema1", nil)
+		require.Error(t, q.WithKNN(field, 5, []float64{1, 2, 3}))
+	})
+
+	t.Run("sorting not allowed", func(t *testing.T) {
+		q := NewQuery("schema1", map[string]string{"state": "var0"})
+		require.NoError(t, q.WithKNN(field, 5, []float64{1, -2}))
+
+		qq := query.Query{}
+		qq.Sort = []query.Sorting{{Key: "state"}}
+		require.ErrorIs(t, query.NewQueryBuilder(q).BuildQuery(&qq), ErrVectorSortBy)
+	})
+}
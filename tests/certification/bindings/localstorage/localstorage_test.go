@@ -199,7 +199,9 @@ func componentRuntimeOptions() []embedded.Option {
 
 	bindingsRegistry := bindings_loader.NewRegistry()
 	bindingsRegistry.Logger = log
-	bindingsRegistry.RegisterOutputBinding(bindings_localstorage.NewLocalStorage, "localstorage")
+	bindingsRegistry.RegisterOutputBinding(func(l logger.Logger) bindings.OutputBinding {
+		return bindings_localstorage.NewLocalStorage(l)
+	}, "localstorage")
 
 	return []embedded.Option{
 		embedded.WithBindings(bindingsRegistry),
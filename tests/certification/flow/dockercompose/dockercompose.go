@@ -14,14 +14,13 @@ limitations under the License.
 package dockercompose
 
 import (
-	"os/exec"
-
 	"github.com/dapr/components-contrib/tests/certification/flow"
 )
 
 type Compose struct {
 	project  string
 	filename string
+	runtime  Runtime
 }
 
 func Run(project, filename string) (string, flow.Runnable, flow.Runnable) {
@@ -32,9 +31,16 @@ func New(project, filename string) Compose {
 	return Compose{
 		project:  project,
 		filename: filename,
+		runtime:  DefaultRuntime(),
 	}
 }
 
+// WithRuntime returns a copy of the Compose project that uses the given container runtime.
+func (c Compose) WithRuntime(runtime Runtime) Compose {
+	c.runtime = runtime
+	return c
+}
+
 func (c Compose) AppID() string {
 	return c.project
 }
@@ -48,9 +54,7 @@ func Up(project, filename string) flow.Runnable {
 }
 
 func (c Compose) Up(ctx flow.Context) error {
-	out, err := exec.Command(
-		"docker",
-		"compose",
+	out, err := c.runtime.Command(
 		"-p", c.project,
 		"-f", c.filename,
 		"up", "-d",
@@ -65,9 +69,7 @@ func Down(project, filename string) flow.Runnable {
 }
 
 func (c Compose) Down(ctx flow.Context) error {
-	out, err := exec.Command(
-		"docker",
-		"compose",
+	out, err := c.runtime.Command(
 		"-p", c.project,
 		"-f", c.filename,
 		"down", "-v").CombinedOutput()
@@ -83,13 +85,12 @@ func Start(project, filename string, services ...string) flow.Runnable {
 func (c Compose) Start(services ...string) flow.Runnable {
 	return func(ctx flow.Context) error {
 		args := []string{
-			"compose",
 			"-p", c.project,
 			"-f", c.filename,
 			"start",
 		}
 		args = append(args, services...)
-		out, err := exec.Command("docker", args...).CombinedOutput()
+		out, err := c.runtime.Command(args...).CombinedOutput()
 		ctx.Log(string(out))
 		return err
 	}
@@ -102,13 +103,12 @@ func Stop(project, filename string, services ...string) flow.Runnable {
 func (c Compose) Stop(services ...string) flow.Runnable {
 	return func(ctx flow.Context) error {
 		args := []string{
-			"compose",
 			"-p", c.project,
 			"-f", c.filename,
 			"stop",
 		}
 		args = append(args, services...)
-		out, err := exec.Command("docker", args...).CombinedOutput()
+		out, err := c.runtime.Command(args...).CombinedOutput()
 		ctx.Log(string(out))
 		return err
 	}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockercompose
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// EnvRuntime selects the container runtime: "docker", "podman", or the name of another CLI with a "compose" command, such as "nerdctl".
+	// When not set, Docker is used if available, and Podman otherwise.
+	EnvRuntime = "CONTAINER_RUNTIME"
+	// EnvPlatform sets the platform of the images that are pulled and run, such as "linux/amd64".
+	// When not set, images for the platform of the host are used, for example "linux/arm64" on Apple Silicon.
+	// Setting it to "linux/amd64" allows running images that are not published for arm64, using emulation.
+	EnvPlatform = "CONTAINER_PLATFORM"
+)
+
+// Runtime runs Compose projects with a container engine.
+type Runtime interface {
+	// Name returns the name of the runtime.
+	Name() string
+	// Command returns the command that runs Compose with the given arguments.
+	Command(args ...string) *exec.Cmd
+}
+
+// CLIRuntime is a Runtime that invokes the Compose plugin of a container engine's CLI, such as "docker compose".
+type CLIRuntime struct {
+	// Binary is the name or path of the CLI.
+	Binary string
+	// Platform, if set, is the platform of the images to use.
+	Platform string
+}
+
+// Docker returns a runtime that uses "docker compose".
+func Docker() CLIRuntime {
+	return CLIRuntime{
+		Binary:   "docker",
+		Platform: os.Getenv(EnvPlatform),
+	}
+}
+
+// Podman returns a runtime that uses "podman compose", which works with rootless containers too.
+func Podman() CLIRuntime {
+	return CLIRuntime{
+		Binary:   "podman",
+		Platform: os.Getenv(EnvPlatform),
+	}
+}
+
+func (r CLIRuntime) Name() string {
+	return r.Binary
+}
+
+func (r CLIRuntime) Command(args ...string) *exec.Cmd {
+	cmd := exec.Command(r.Binary, append([]string{"compose"}, args...)...)
+	if r.Platform != "" {
+		// Compose uses this platform for services that don't set one
+		cmd.Env = append(os.Environ(),
+			"DOCKER_DEFAULT_PLATFORM="+r.Platform,
+		)
+	}
+	return cmd
+}
+
+// DefaultRuntime returns the runtime configured with the CONTAINER_RUNTIME environment variable.
+func DefaultRuntime() Runtime {
+	// The name is case-insensitive, but other values are paths to binaries
+	name := os.Getenv(EnvRuntime)
+	switch strings.ToLower(name) {
+	case "":
+		// Detect below
+	case "docker":
+		return Docker()
+	case "podman":
+		return Podman()
+	default:
+		return CLIRuntime{
+			Binary:   name,
+			Platform: os.Getenv(EnvPlatform),
+		}
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		if _, err = exec.LookPath("podman"); err == nil {
+			return Podman()
+		}
+	}
+	return Docker()
+}
+
+// Command returns the command that runs Compose with the given arguments, using the default runtime.
+func Command(args ...string) *exec.Cmd {
+	return DefaultRuntime().Command(args...)
+}
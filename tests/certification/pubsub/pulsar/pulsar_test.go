@@ -25,7 +25,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -122,8 +121,7 @@ func TestPulsar(t *testing.T) {
 		require.NoError(t, os.Chmod(dir, 0o777))
 
 		t.Log("Starting OAuth2 server...")
-		out, err := exec.Command(
-			"docker", "compose",
+		out, err := dockercompose.Command(
 			"-p", "oauth2",
 			"-f", dockerComposeMockOAuth2YAML,
 			"up", "-d").CombinedOutput()
@@ -132,8 +130,7 @@ func TestPulsar(t *testing.T) {
 
 		t.Cleanup(func() {
 			t.Log("Stopping OAuth2 server...")
-			out, err = exec.Command(
-				"docker", "compose",
+			out, err = dockercompose.Command(
 				"-p", "oauth2",
 				"-f", dockerComposeMockOAuth2YAML,
 				"down", "-v",